@@ -0,0 +1,211 @@
+package manifest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const manifestVersion = 1
+
+type Generator struct {
+	appRepo        shared.CFAppRepository
+	packageRepo    shared.CFPackageRepository
+	stateCollector StateCollector
+}
+
+func NewGenerator(
+	appRepo shared.CFAppRepository,
+	packageRepo shared.CFPackageRepository,
+	stateCollector StateCollector,
+) Generator {
+	return Generator{
+		appRepo:        appRepo,
+		packageRepo:    packageRepo,
+		stateCollector: stateCollector,
+	}
+}
+
+func (g Generator) GenerateForApp(ctx context.Context, authInfo authorization.Info, appGUID string) (payloads.Manifest, error) {
+	appRecord, err := g.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return payloads.Manifest{}, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	application, err := g.generateApplication(ctx, authInfo, appRecord)
+	if err != nil {
+		return payloads.Manifest{}, err
+	}
+
+	return payloads.Manifest{
+		Version:      manifestVersion,
+		Applications: []payloads.ManifestApplication{application},
+	}, nil
+}
+
+func (g Generator) GenerateForSpace(ctx context.Context, authInfo authorization.Info, spaceGUID string) (payloads.Manifest, error) {
+	appRecords, err := g.appRepo.ListApps(ctx, authInfo, repositories.ListAppsMessage{
+		SpaceGUIDs: []string{spaceGUID},
+	})
+	if err != nil {
+		return payloads.Manifest{}, apierrors.FromK8sError(err, repositories.AppResourceType)
+	}
+
+	slices.SortFunc(appRecords, func(a, b repositories.AppRecord) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	applications := []payloads.ManifestApplication{}
+	for _, appRecord := range appRecords {
+		application, err := g.generateApplication(ctx, authInfo, appRecord)
+		if err != nil {
+			return payloads.Manifest{}, err
+		}
+		applications = append(applications, application)
+	}
+
+	return payloads.Manifest{
+		Version:      manifestVersion,
+		Applications: applications,
+	}, nil
+}
+
+func (g Generator) generateApplication(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord) (payloads.ManifestApplication, error) {
+	appState, err := g.stateCollector.CollectState(ctx, authInfo, appRecord.Name, appRecord.SpaceGUID)
+	if err != nil {
+		return payloads.ManifestApplication{}, err
+	}
+
+	appEnv, err := g.appRepo.GetAppEnv(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return payloads.ManifestApplication{}, err
+	}
+
+	application := payloads.ManifestApplication{
+		Name:      appRecord.Name,
+		Env:       appEnv.EnvironmentVariables,
+		Processes: generateProcesses(appState.Processes),
+		Routes:    generateRoutes(appState.Routes),
+		Services:  generateServices(appState.ServiceBindings),
+		Metadata: payloads.MetadataPatch{
+			Labels:      toPatchMap(appRecord.Labels),
+			Annotations: toPatchMap(appRecord.Annotations),
+		},
+	}
+
+	if len(application.Routes) == 0 {
+		application.NoRoute = true
+	}
+
+	if appRecord.Lifecycle.Type == string(korifiv1alpha1.DockerPackage) {
+		image, err := g.dockerImage(ctx, authInfo, appRecord.GUID)
+		if err != nil {
+			return payloads.ManifestApplication{}, err
+		}
+		application.Docker = map[string]any{"image": image}
+	} else {
+		application.Buildpacks = appRecord.Lifecycle.Data.Buildpacks
+	}
+
+	return application, nil
+}
+
+func (g Generator) dockerImage(ctx context.Context, authInfo authorization.Info, appGUID string) (string, error) {
+	packages, err := g.packageRepo.ListPackages(ctx, authInfo, repositories.ListPackagesMessage{
+		AppGUIDs: []string{appGUID},
+		OrderBy:  "-created_at",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range packages {
+		if p.Type == string(korifiv1alpha1.DockerPackage) {
+			return p.SourceImageRef, nil
+		}
+	}
+
+	return "", nil
+}
+
+func generateProcesses(processes map[string]repositories.ProcessRecord) []payloads.ManifestApplicationProcess {
+	result := []payloads.ManifestApplicationProcess{}
+	for _, processType := range slices.Sorted(maps.Keys(processes)) {
+		process := processes[processType]
+		manifestProcess := payloads.ManifestApplicationProcess{
+			Type:      process.Type,
+			Instances: tools.PtrTo(process.DesiredInstances),
+			Memory:    tools.PtrTo(fmt.Sprintf("%dM", process.MemoryMB)),
+			DiskQuota: tools.PtrTo(fmt.Sprintf("%dM", process.DiskQuotaMB)),
+		}
+
+		if process.Command != "" {
+			manifestProcess.Command = tools.PtrTo(process.Command)
+		}
+		if process.HealthCheck.Type != "" {
+			manifestProcess.HealthCheckType = tools.PtrTo(process.HealthCheck.Type)
+		}
+		if process.HealthCheck.Data.HTTPEndpoint != "" {
+			manifestProcess.HealthCheckHTTPEndpoint = tools.PtrTo(process.HealthCheck.Data.HTTPEndpoint)
+		}
+		if process.HealthCheck.Data.InvocationTimeoutSeconds > 0 {
+			manifestProcess.HealthCheckInvocationTimeout = tools.PtrTo(process.HealthCheck.Data.InvocationTimeoutSeconds)
+		}
+		if process.HealthCheck.Data.TimeoutSeconds > 0 {
+			manifestProcess.Timeout = tools.PtrTo(process.HealthCheck.Data.TimeoutSeconds)
+		}
+
+		result = append(result, manifestProcess)
+	}
+
+	return result
+}
+
+func generateRoutes(routes map[string]repositories.RouteRecord) []payloads.ManifestRoute {
+	result := []payloads.ManifestRoute{}
+	for _, route := range slices.Sorted(maps.Keys(routes)) {
+		result = append(result, payloads.ManifestRoute{Route: tools.PtrTo(route)})
+	}
+
+	return result
+}
+
+func generateServices(serviceBindings map[string]repositories.ServiceBindingRecord) []payloads.ManifestApplicationService {
+	result := []payloads.ManifestApplicationService{}
+	for _, serviceName := range slices.Sorted(maps.Keys(serviceBindings)) {
+		result = append(result, payloads.ManifestApplicationService{
+			Name:        serviceName,
+			BindingName: serviceBindings[serviceName].Name,
+		})
+	}
+
+	return result
+}
+
+// toPatchMap drops the keys in the cloudfoundry.org domain, as they are set
+// by Korifi and the generated manifest could not be applied otherwise
+func toPatchMap(m map[string]string) map[string]*string {
+	result := map[string]*string{}
+	for k, v := range m {
+		if payloads.IsCloudFoundryKey(k) {
+			continue
+		}
+		result[k] = tools.PtrTo(v)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,314 @@
+package manifest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("Generator", func() {
+	var (
+		appRepo             *fake.CFAppRepository
+		packageRepo         *fake.CFPackageRepository
+		processRepo         *fake.CFProcessRepository
+		routeRepo           *fake.CFRouteRepository
+		serviceInstanceRepo *fake.CFServiceInstanceRepository
+		serviceBindingRepo  *fake.CFServiceBindingRepository
+		generator           manifest.Generator
+		appRecord           repositories.AppRecord
+		generatedManifest   payloads.Manifest
+		generateErr         error
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		packageRepo = new(fake.CFPackageRepository)
+		processRepo = new(fake.CFProcessRepository)
+		routeRepo = new(fake.CFRouteRepository)
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		serviceBindingRepo = new(fake.CFServiceBindingRepository)
+
+		generator = manifest.NewGenerator(
+			appRepo,
+			packageRepo,
+			manifest.NewStateCollector(
+				appRepo,
+				new(fake.CFDomainRepository),
+				processRepo,
+				routeRepo,
+				serviceInstanceRepo,
+				serviceBindingRepo,
+			),
+		)
+
+		appRecord = repositories.AppRecord{
+			Name:      "my-app",
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+			Lifecycle: repositories.Lifecycle{
+				Type: "buildpack",
+				Data: repositories.LifecycleData{
+					Buildpacks: []string{"my-buildpack"},
+				},
+			},
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+		}
+		appRepo.GetAppReturns(appRecord, nil)
+		appRepo.ListAppsReturns([]repositories.AppRecord{appRecord}, nil)
+		appRepo.GetAppEnvReturns(repositories.AppEnvRecord{
+			EnvironmentVariables: map[string]string{"FOO": "bar"},
+		}, nil)
+
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			{
+				Type:             "worker",
+				Command:          "bundle exec work",
+				DesiredInstances: 0,
+				MemoryMB:         128,
+				DiskQuotaMB:      512,
+				HealthCheck:      repositories.HealthCheck{Type: "process"},
+			},
+			{
+				Type:             "web",
+				DesiredInstances: 2,
+				MemoryMB:         256,
+				DiskQuotaMB:      1024,
+				HealthCheck: repositories.HealthCheck{
+					Type: "http",
+					Data: repositories.HealthCheckData{
+						HTTPEndpoint:             "/healthz",
+						InvocationTimeoutSeconds: 5,
+						TimeoutSeconds:           60,
+					},
+				},
+			},
+		}, nil)
+
+		routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{
+			{Host: "my-app", Domain: repositories.DomainRecord{Name: "my.domain"}},
+			{Host: "another", Domain: repositories.DomainRecord{Name: "my.domain"}, Path: "/foo"},
+		}, nil)
+
+		serviceInstanceRepo.ListServiceInstancesReturns([]repositories.ServiceInstanceRecord{
+			{GUID: "db-guid", Name: "my-db"},
+			{GUID: "cache-guid", Name: "my-cache"},
+		}, nil)
+		serviceBindingRepo.ListServiceBindingsReturns([]repositories.ServiceBindingRecord{
+			{GUID: "db-binding-guid", ServiceInstanceGUID: "db-guid", Name: tools.PtrTo("db")},
+			{GUID: "cache-binding-guid", ServiceInstanceGUID: "cache-guid"},
+		}, nil)
+	})
+
+	Describe("GenerateForApp", func() {
+		JustBeforeEach(func() {
+			generatedManifest, generateErr = generator.GenerateForApp(context.Background(), authorization.Info{}, "app-guid")
+		})
+
+		It("generates the manifest of the app", func() {
+			Expect(generateErr).NotTo(HaveOccurred())
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(generatedManifest).To(Equal(payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name:       "my-app",
+					Env:        map[string]string{"FOO": "bar"},
+					Buildpacks: []string{"my-buildpack"},
+					Processes: []payloads.ManifestApplicationProcess{
+						{
+							Type:                         "web",
+							Instances:                    tools.PtrTo[int32](2),
+							Memory:                       tools.PtrTo("256M"),
+							DiskQuota:                    tools.PtrTo("1024M"),
+							HealthCheckType:              tools.PtrTo("http"),
+							HealthCheckHTTPEndpoint:      tools.PtrTo("/healthz"),
+							HealthCheckInvocationTimeout: tools.PtrTo[int32](5),
+							Timeout:                      tools.PtrTo[int32](60),
+						},
+						{
+							Type:            "worker",
+							Command:         tools.PtrTo("bundle exec work"),
+							Instances:       tools.PtrTo[int32](0),
+							Memory:          tools.PtrTo("128M"),
+							DiskQuota:       tools.PtrTo("512M"),
+							HealthCheckType: tools.PtrTo("process"),
+						},
+					},
+					Routes: []payloads.ManifestRoute{
+						{Route: tools.PtrTo("another.my.domain/foo")},
+						{Route: tools.PtrTo("my-app.my.domain")},
+					},
+					Services: []payloads.ManifestApplicationService{
+						{Name: "my-cache"},
+						{Name: "my-db", BindingName: tools.PtrTo("db")},
+					},
+					Metadata: payloads.MetadataPatch{
+						Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+						Annotations: map[string]*string{"bar": tools.PtrTo("baz")},
+					},
+				}},
+			}))
+		})
+
+		When("the app has no routes", func() {
+			BeforeEach(func() {
+				routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{}, nil)
+			})
+
+			It("sets no-route", func() {
+				Expect(generateErr).NotTo(HaveOccurred())
+				Expect(generatedManifest.Applications[0].Routes).To(BeEmpty())
+				Expect(generatedManifest.Applications[0].NoRoute).To(BeTrue())
+			})
+		})
+
+		When("the app has metadata in the cloudfoundry.org domain", func() {
+			BeforeEach(func() {
+				appRecord.Labels["korifi.cloudfoundry.org/app-guid"] = "app-guid"
+				appRecord.Annotations["cloudfoundry.org/propagate-deletion"] = "true"
+				appRecord.Annotations["korifi.cloudfoundry.org/app-rev"] = "2"
+				appRepo.ListAppsReturns([]repositories.AppRecord{appRecord}, nil)
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("leaves it out of the manifest", func() {
+				Expect(generateErr).NotTo(HaveOccurred())
+				Expect(generatedManifest.Applications[0].Metadata).To(Equal(payloads.MetadataPatch{
+					Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+					Annotations: map[string]*string{"bar": tools.PtrTo("baz")},
+				}))
+			})
+
+			When("the app only has metadata in the cloudfoundry.org domain", func() {
+				BeforeEach(func() {
+					delete(appRecord.Labels, "foo")
+					delete(appRecord.Annotations, "bar")
+				})
+
+				It("leaves the metadata empty", func() {
+					Expect(generateErr).NotTo(HaveOccurred())
+					Expect(generatedManifest.Applications[0].Metadata).To(BeZero())
+				})
+			})
+		})
+
+		When("the app is a docker app", func() {
+			BeforeEach(func() {
+				appRecord.Lifecycle = repositories.Lifecycle{Type: "docker"}
+				appRepo.ListAppsReturns([]repositories.AppRecord{appRecord}, nil)
+				appRepo.GetAppReturns(appRecord, nil)
+
+				packageRepo.ListPackagesReturns([]repositories.PackageRecord{
+					{Type: "docker", SourceImageRef: "my/latest-image"},
+					{Type: "docker", SourceImageRef: "my/older-image"},
+				}, nil)
+			})
+
+			It("sets the image of the latest package", func() {
+				Expect(generateErr).NotTo(HaveOccurred())
+
+				Expect(packageRepo.ListPackagesCallCount()).To(Equal(1))
+				_, _, listMessage := packageRepo.ListPackagesArgsForCall(0)
+				Expect(listMessage).To(Equal(repositories.ListPackagesMessage{
+					AppGUIDs: []string{"app-guid"},
+					OrderBy:  "-created_at",
+				}))
+
+				Expect(generatedManifest.Applications[0].Docker).To(Equal(map[string]any{"image": "my/latest-image"}))
+				Expect(generatedManifest.Applications[0].Buildpacks).To(BeEmpty())
+			})
+		})
+
+		When("the app cannot be accessed", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(generateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("getting the app env fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("get-env-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(generateErr).To(MatchError("get-env-err"))
+			})
+		})
+	})
+
+	Describe("GenerateForSpace", func() {
+		BeforeEach(func() {
+			spaceApps := []repositories.AppRecord{
+				{Name: "zed", GUID: "zed-guid", SpaceGUID: "space-guid"},
+				appRecord,
+			}
+			appRepo.ListAppsStub = func(_ context.Context, _ authorization.Info, message repositories.ListAppsMessage) ([]repositories.AppRecord, error) {
+				if len(message.Names) == 0 {
+					return spaceApps, nil
+				}
+				return slices.DeleteFunc(slices.Clone(spaceApps), func(a repositories.AppRecord) bool {
+					return !slices.Contains(message.Names, a.Name)
+				}), nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			generatedManifest, generateErr = generator.GenerateForSpace(context.Background(), authorization.Info{}, "space-guid")
+		})
+
+		It("generates a manifest with all apps in the space sorted by name", func() {
+			Expect(generateErr).NotTo(HaveOccurred())
+
+			_, _, listMessage := appRepo.ListAppsArgsForCall(0)
+			Expect(listMessage.SpaceGUIDs).To(ConsistOf("space-guid"))
+
+			Expect(generatedManifest.Version).To(Equal(1))
+			Expect(generatedManifest.Applications).To(HaveLen(2))
+			Expect(generatedManifest.Applications[0].Name).To(Equal("my-app"))
+			Expect(generatedManifest.Applications[1].Name).To(Equal("zed"))
+		})
+
+		When("the space has no apps", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsStub = nil
+				appRepo.ListAppsReturns([]repositories.AppRecord{}, nil)
+			})
+
+			It("generates a manifest without apps", func() {
+				Expect(generateErr).NotTo(HaveOccurred())
+				Expect(generatedManifest.Applications).To(BeEmpty())
+			})
+		})
+
+		When("listing the apps fails", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsStub = nil
+				appRepo.ListAppsReturns(nil, errors.New("list-apps-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(generateErr).To(MatchError(ContainSubstring("list-apps-err")))
+			})
+		})
+	})
+})
@@ -0,0 +1,177 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/promql"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=list
+
+//counterfeiter:generate -o fake -fake-name MetricsRecorder . MetricsRecorder
+
+type MetricsRecorder interface {
+	Record(now time.Time, samples ...promql.Sample)
+}
+
+// MetricsSampler periodically records the usage of all app instances into a
+// metrics store, so that PromQL queries have a history to look back at
+// regardless of when the app has been first queried. Each API replica runs
+// its own sampler into its own store, so PromQL results depend on the replica
+// serving the query.
+type MetricsSampler struct {
+	privilegedClient client.Client
+	recorder         MetricsRecorder
+	interval         time.Duration
+}
+
+func NewMetricsSampler(privilegedClient client.Client, recorder MetricsRecorder, interval time.Duration) *MetricsSampler {
+	return &MetricsSampler{
+		privilegedClient: privilegedClient,
+		recorder:         recorder,
+		interval:         interval,
+	}
+}
+
+// Start samples the app metrics every interval until the context is done
+func (s *MetricsSampler) Start(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("metrics-sampler")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sample(ctx); err != nil {
+			logger.Error(err, "failed to sample app metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *MetricsSampler) Sample(ctx context.Context) error {
+	appList := &korifiv1alpha1.CFAppList{}
+	if err := s.privilegedClient.List(ctx, appList); err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+	apps := map[string]korifiv1alpha1.CFApp{}
+	for _, app := range appList.Items {
+		apps[app.Name] = app
+	}
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	if err := s.privilegedClient.List(ctx, processList); err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+	processes := map[string]korifiv1alpha1.CFProcess{}
+	for _, process := range processList.Items {
+		processes[process.Name] = process
+	}
+
+	podList := &corev1.PodList{}
+	if err := s.privilegedClient.List(ctx, podList, client.HasLabels{korifiv1alpha1.GUIDLabelKey, korifiv1alpha1.PodIndexLabelKey}); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// The metrics server copies the pod labels and only reports pods once it
+	// has scraped them
+	podMetricsList := &metricsv1beta1.PodMetricsList{}
+	if err := s.privilegedClient.List(ctx, podMetricsList, client.HasLabels{korifiv1alpha1.GUIDLabelKey, korifiv1alpha1.PodIndexLabelKey}); err != nil {
+		return fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+	podMetrics := map[types.NamespacedName]metricsv1beta1.PodMetrics{}
+	for _, m := range podMetricsList.Items {
+		podMetrics[client.ObjectKeyFromObject(&m)] = m
+	}
+
+	now := time.Now()
+	var samples []promql.Sample
+	for _, pod := range podList.Items {
+		process, ok := processes[pod.Labels[korifiv1alpha1.GUIDLabelKey]]
+		if !ok {
+			continue
+		}
+
+		app, ok := apps[process.Spec.AppRef.Name]
+		if !ok || pod.Labels[korifiv1alpha1.VersionLabelKey] != app.Annotations[korifiv1alpha1.CFAppRevisionKey] {
+			continue
+		}
+
+		index, err := extractIndex(pod)
+		if err != nil || getPodState(pod) == stateDown {
+			continue
+		}
+
+		metrics, ok := podMetrics[client.ObjectKeyFromObject(&pod)]
+		if !ok {
+			continue
+		}
+
+		usage, ok := podUsage(metrics)
+		if !ok {
+			continue
+		}
+
+		samples = append(samples, statsSamples(app, PodStatsRecord{
+			ProcessGUID: process.Name,
+			ProcessType: process.Spec.ProcessType,
+			Index:       index,
+			Usage:       usage,
+			MemQuota:    tools.PtrTo(megabytesToBytes(process.Spec.MemoryMB)),
+			DiskQuota:   tools.PtrTo(megabytesToBytes(process.Spec.DiskQuotaMB)),
+		})...)
+	}
+
+	s.recorder.Record(now, samples...)
+
+	return nil
+}
+
+// statsSamples converts instance stats into samples labelled the same way as
+// the gauge envelopes served by the log-cache read endpoint
+func statsSamples(app korifiv1alpha1.CFApp, podStats PodStatsRecord) []promql.Sample {
+	gauges := map[string]float64{
+		"cpu":          tools.ZeroIfNil(podStats.Usage.CPU),
+		"memory":       float64(tools.ZeroIfNil(podStats.Usage.Mem)),
+		"disk":         float64(tools.ZeroIfNil(podStats.Usage.Disk)),
+		"memory_quota": float64(tools.ZeroIfNil(podStats.MemQuota)),
+		"disk_quota":   float64(tools.ZeroIfNil(podStats.DiskQuota)),
+	}
+
+	var samples []promql.Sample
+	for name, value := range gauges {
+		samples = append(samples, promql.Sample{
+			Labels: promql.Labels{
+				promql.MetricNameLabel: name,
+				"app_id":               app.Name,
+				"app_name":             app.Spec.DisplayName,
+				"instance_id":          strconv.Itoa(podStats.Index),
+				"process_type":         podStats.ProcessType,
+				"process_id":           podStats.ProcessGUID,
+				"source_id":            app.Name,
+				"space_id":             app.Namespace,
+			},
+			Point: promql.Point{
+				Timestamp: *podStats.Usage.Timestamp,
+				Value:     value,
+			},
+		})
+	}
+
+	return samples
+}
@@ -0,0 +1,214 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	"code.cloudfoundry.org/korifi/api/promql"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	k8sfake "code.cloudfoundry.org/korifi/controllers/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MetricsSampler", func() {
+	var (
+		k8sClient   *k8sfake.Client
+		recorder    *fake.MetricsRecorder
+		sampler     *MetricsSampler
+		pods        []corev1.Pod
+		podMetrics  []metricsv1beta1.PodMetrics
+		metricsTime time.Time
+		sampleErr   error
+	)
+
+	BeforeEach(func() {
+		k8sClient = new(k8sfake.Client)
+		recorder = new(fake.MetricsRecorder)
+		metricsTime = time.Unix(1700000000, 0)
+
+		pods = []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "space-guid",
+				Name:      "pod-1",
+				Labels: map[string]string{
+					korifiv1alpha1.GUIDLabelKey:     "process-guid",
+					korifiv1alpha1.VersionLabelKey:  "2",
+					korifiv1alpha1.PodIndexLabelKey: "1",
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}}
+
+		podMetrics = []metricsv1beta1.PodMetrics{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "space-guid",
+				Name:      "pod-1",
+			},
+			Timestamp: metav1.NewTime(metricsTime),
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Name: "application",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Ki"),
+				},
+			}},
+		}}
+
+		k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFAppList:
+				list.Items = []korifiv1alpha1.CFApp{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "space-guid",
+						Name:        "app-guid",
+						Annotations: map[string]string{korifiv1alpha1.CFAppRevisionKey: "2"},
+					},
+					Spec: korifiv1alpha1.CFAppSpec{DisplayName: "my-app"},
+				}}
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = []korifiv1alpha1.CFProcess{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "space-guid",
+						Name:      "process-guid",
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:      corev1.LocalObjectReference{Name: "app-guid"},
+						ProcessType: "web",
+						MemoryMB:    256,
+						DiskQuotaMB: 1024,
+					},
+				}}
+			case *corev1.PodList:
+				list.Items = pods
+			case *metricsv1beta1.PodMetricsList:
+				list.Items = podMetrics
+			}
+			return nil
+		}
+
+		sampler = NewMetricsSampler(k8sClient, recorder, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		sampleErr = sampler.Sample(ctx)
+	})
+
+	It("lists the app instance pods and their metrics once", func() {
+		Expect(sampleErr).NotTo(HaveOccurred())
+		Expect(k8sClient.ListCallCount()).To(Equal(4))
+		_, _, listOptions := k8sClient.ListArgsForCall(2)
+		Expect(listOptions).To(Equal([]client.ListOption{client.HasLabels{korifiv1alpha1.GUIDLabelKey, korifiv1alpha1.PodIndexLabelKey}}))
+		_, metricsList, listOptions := k8sClient.ListArgsForCall(3)
+		Expect(metricsList).To(BeAssignableToTypeOf(&metricsv1beta1.PodMetricsList{}))
+		Expect(listOptions).To(Equal([]client.ListOption{client.HasLabels{korifiv1alpha1.GUIDLabelKey, korifiv1alpha1.PodIndexLabelKey}}))
+		Expect(k8sClient.GetCallCount()).To(BeZero())
+	})
+
+	It("records the instance usage", func() {
+		Expect(recorder.RecordCallCount()).To(Equal(1))
+		_, samples := recorder.RecordArgsForCall(0)
+		Expect(samples).To(HaveLen(5))
+		Expect(samples).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"Labels": Equal(promql.Labels{
+				promql.MetricNameLabel: "cpu",
+				"app_id":               "app-guid",
+				"app_name":             "my-app",
+				"instance_id":          "1",
+				"process_type":         "web",
+				"process_id":           "process-guid",
+				"source_id":            "app-guid",
+				"space_id":             "space-guid",
+			}),
+			"Point": Equal(promql.Point{Timestamp: metricsTime, Value: 0.5}),
+		})))
+		Expect(samples).To(ContainElements(
+			MatchFields(IgnoreExtras, Fields{
+				"Labels": HaveKeyWithValue(promql.MetricNameLabel, "memory"),
+				"Point":  MatchFields(IgnoreExtras, Fields{"Value": Equal(1024.0)}),
+			}),
+			MatchFields(IgnoreExtras, Fields{
+				"Labels": HaveKeyWithValue(promql.MetricNameLabel, "memory_quota"),
+				"Point":  MatchFields(IgnoreExtras, Fields{"Value": Equal(256.0 * 1024 * 1024)}),
+			}),
+		))
+	})
+
+	When("the pod belongs to a previous app revision", func() {
+		BeforeEach(func() {
+			pods[0].Labels[korifiv1alpha1.VersionLabelKey] = "1"
+		})
+
+		It("does not record it", func() {
+			_, samples := recorder.RecordArgsForCall(0)
+			Expect(samples).To(BeEmpty())
+		})
+	})
+
+	When("the pod does not belong to a process", func() {
+		BeforeEach(func() {
+			pods[0].Labels[korifiv1alpha1.GUIDLabelKey] = "task-guid"
+		})
+
+		It("does not record it", func() {
+			_, samples := recorder.RecordArgsForCall(0)
+			Expect(samples).To(BeEmpty())
+		})
+	})
+
+	When("the pod metrics are not available yet", func() {
+		BeforeEach(func() {
+			podMetrics = nil
+		})
+
+		It("does not record the pod", func() {
+			Expect(sampleErr).NotTo(HaveOccurred())
+			_, samples := recorder.RecordArgsForCall(0)
+			Expect(samples).To(BeEmpty())
+		})
+	})
+
+	When("listing the pod metrics fails", func() {
+		BeforeEach(func() {
+			listStub := k8sClient.ListStub
+			k8sClient.ListStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*metricsv1beta1.PodMetricsList); ok {
+					return errors.New("metrics-error")
+				}
+				return listStub(ctx, list, opts...)
+			}
+		})
+
+		It("returns the error", func() {
+			Expect(sampleErr).To(MatchError(ContainSubstring("metrics-error")))
+			Expect(recorder.RecordCallCount()).To(BeZero())
+		})
+	})
+
+	When("listing the apps fails", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = nil
+			k8sClient.ListReturns(errors.New("list-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(sampleErr).To(MatchError(ContainSubstring("list-error")))
+			Expect(recorder.RecordCallCount()).To(BeZero())
+		})
+	})
+})
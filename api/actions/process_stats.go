@@ -116,36 +116,51 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 
 		records[index].State = podState
 
-		metricsMap := aggregateContainerMetrics(m.Metrics.Containers)
-		if len(metricsMap) == 0 {
+		usage, ok := podUsage(m.Metrics)
+		if !ok {
 			continue
 		}
 
-		if cpuQuantity, ok := metricsMap["cpu"]; ok {
-			value := float64(cpuQuantity.ScaledValue(resource.Nano))
-			// CF tracks CPU usage as a percentage of cores used.
-			// Convert the number of nanoCPU to CPU for greatest accuracy.
-			percentage := value / 1e9
-			records[index].Usage.CPU = &percentage
-		}
-
-		if memQuantity, ok := metricsMap["memory"]; ok {
-			value := memQuantity.Value()
-			records[index].Usage.Mem = &value
-		}
-
-		if storageQuantity, ok := metricsMap["storage"]; ok {
-			value := storageQuantity.Value()
-			records[index].Usage.Disk = &value
-		}
-
-		records[index].Usage.Timestamp = tools.PtrTo(m.Metrics.Timestamp.Time)
+		records[index].Usage = usage
 		records[index].MemQuota = tools.PtrTo(megabytesToBytes(processRecord.MemoryMB))
 		records[index].DiskQuota = tools.PtrTo(megabytesToBytes(processRecord.DiskQuotaMB))
 	}
 	return records, nil
 }
 
+// podUsage aggregates the usage of the pod containers, returning false when
+// the metrics server has not reported any usage yet
+func podUsage(podMetrics metricsv1beta1.PodMetrics) (Usage, bool) {
+	metricsMap := aggregateContainerMetrics(podMetrics.Containers)
+	if len(metricsMap) == 0 {
+		return Usage{}, false
+	}
+
+	usage := Usage{
+		Timestamp: tools.PtrTo(podMetrics.Timestamp.Time),
+	}
+
+	if cpuQuantity, ok := metricsMap["cpu"]; ok {
+		value := float64(cpuQuantity.ScaledValue(resource.Nano))
+		// CF tracks CPU usage as a percentage of cores used.
+		// Convert the number of nanoCPU to CPU for greatest accuracy.
+		percentage := value / 1e9
+		usage.CPU = &percentage
+	}
+
+	if memQuantity, ok := metricsMap["memory"]; ok {
+		value := memQuantity.Value()
+		usage.Mem = &value
+	}
+
+	if storageQuantity, ok := metricsMap["storage"]; ok {
+		value := storageQuantity.Value()
+		usage.Disk = &value
+	}
+
+	return usage, true
+}
+
 func (a *ProcessStats) FetchAppProcessesStats(ctx context.Context, authInfo authorization.Info, appGUID string) ([]PodStatsRecord, error) {
 	appProcesses, err := a.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
 		AppGUIDs: []string{appGUID},
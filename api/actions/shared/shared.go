@@ -24,6 +24,13 @@ type CFAppRepository interface {
 	ListApps(context.Context, authorization.Info, repositories.ListAppsMessage) ([]repositories.AppRecord, error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
+
+type CFPackageRepository interface {
+	ListPackages(context.Context, authorization.Info, repositories.ListPackagesMessage) ([]repositories.PackageRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
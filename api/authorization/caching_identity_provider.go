@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"k8s.io/apimachinery/pkg/util/cache"
 )
 
@@ -27,6 +28,7 @@ func NewCachingIdentityProvider(identityProvider IdentityProvider, identityCache
 func (p *CachingIdentityProvider) GetIdentity(ctx context.Context, info Info) (Identity, error) {
 	idInterface, ok := p.identityCache.Get(info.Hash())
 	if ok {
+		metrics.IdentityCacheRequestsTotal.WithLabelValues("hit").Inc()
 		id, castOK := idInterface.(Identity)
 		if castOK {
 			return id, nil
@@ -34,6 +36,7 @@ func (p *CachingIdentityProvider) GetIdentity(ctx context.Context, info Info) (I
 		return Identity{}, fmt.Errorf("identity-provider cache: expected authorization.Identity{}, got %T", idInterface)
 	}
 
+	metrics.IdentityCacheRequestsTotal.WithLabelValues("miss").Inc()
 	identity, err := p.identityProvider.GetIdentity(ctx, info)
 	if err == nil {
 		p.identityCache.Set(info.Hash(), identity, cacheTTL)
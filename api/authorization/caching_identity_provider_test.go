@@ -7,8 +7,10 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/utils/clock/testing"
@@ -22,6 +24,8 @@ var _ = Describe("IdentityProvider", func() {
 		aliceId, id   authorization.Identity
 		identityCache *cache.Expiring
 		getErr        error
+		hitsPre       float64
+		missesPre     float64
 	)
 
 	BeforeEach(func() {
@@ -35,6 +39,9 @@ var _ = Describe("IdentityProvider", func() {
 		fakeProvider.GetIdentityReturns(aliceId, nil)
 
 		idProvider = authorization.NewCachingIdentityProvider(fakeProvider, identityCache)
+
+		hitsPre = testutil.ToFloat64(metrics.IdentityCacheRequestsTotal.WithLabelValues("hit"))
+		missesPre = testutil.ToFloat64(metrics.IdentityCacheRequestsTotal.WithLabelValues("miss"))
 	})
 
 	JustBeforeEach(func() {
@@ -51,6 +58,11 @@ var _ = Describe("IdentityProvider", func() {
 		Expect(actualAuthInfo).To(Equal(authInfo))
 	})
 
+	It("counts a cache miss", func() {
+		Expect(testutil.ToFloat64(metrics.IdentityCacheRequestsTotal.WithLabelValues("miss"))).To(Equal(missesPre + 1))
+		Expect(testutil.ToFloat64(metrics.IdentityCacheRequestsTotal.WithLabelValues("hit"))).To(Equal(hitsPre))
+	})
+
 	When("the real identity provider fails", func() {
 		BeforeEach(func() {
 			fakeProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
@@ -75,6 +87,10 @@ var _ = Describe("IdentityProvider", func() {
 			Expect(id).To(Equal(aliceId))
 		})
 
+		It("counts a cache hit", func() {
+			Expect(testutil.ToFloat64(metrics.IdentityCacheRequestsTotal.WithLabelValues("hit"))).To(Equal(hitsPre + 1))
+		})
+
 		It("uses the hash of the auth info as a key", func() {
 			Expect(identityCache.Len()).To(Equal(1))
 			_, ok := identityCache.Get(authInfo.Hash())
@@ -66,8 +66,10 @@ func (c *CertInspector) WhoAmI(ctx context.Context, certPEM []byte) (Identity, e
 		return Identity{}, apierrors.NewInvalidAuthError(err)
 	}
 
+	// the kubernetes api server maps the certificate organizations to groups
 	return Identity{
-		Name: cert.Subject.CommonName,
-		Kind: rbacv1.UserKind,
+		Name:   cert.Subject.CommonName,
+		Kind:   rbacv1.UserKind,
+		Groups: cert.Subject.Organization,
 	}, nil
 }
@@ -83,6 +83,20 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 }
 
 func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identity, namespace string) (bool, error) {
+	return o.boundIn(ctx, identity, namespace, func(rbacv1.RoleBinding) bool {
+		return true
+	})
+}
+
+// HasRoleIn checks whether the identity is bound to the role with the given
+// name in the namespace, e.g. whether it is a CF admin in the root namespace
+func (o *NamespacePermissions) HasRoleIn(ctx context.Context, identity Identity, namespace, roleName string) (bool, error) {
+	return o.boundIn(ctx, identity, namespace, func(roleBinding rbacv1.RoleBinding) bool {
+		return roleBinding.RoleRef.Name == roleName
+	})
+}
+
+func (o *NamespacePermissions) boundIn(ctx context.Context, identity Identity, namespace string, matchesRoleBinding func(rbacv1.RoleBinding) bool) (bool, error) {
 	var rolebindings rbacv1.RoleBindingList
 	err := o.privilegedClient.List(ctx, &rolebindings, client.InNamespace(namespace))
 	if err != nil {
@@ -90,6 +104,10 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 	}
 
 	for _, roleBinding := range rolebindings.Items {
+		if !matchesRoleBinding(roleBinding) {
+			continue
+		}
+
 		for _, subject := range roleBinding.Subjects {
 			isMatch, err := SameSubject(subject, identity)
 			if err != nil {
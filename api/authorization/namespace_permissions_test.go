@@ -406,6 +406,33 @@ var _ = Describe("Namespace Permissions", func() {
 			})
 		})
 	})
+
+	Describe("Has Role In", func() {
+		BeforeEach(func() {
+			org1NS = createNamespace("org1", map[string]string{korifiv1alpha1.CFOrgDisplayNameKey: "org1"})
+			createRoleBindingForUser(userName, roleName1, org1NS)
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: org1NS}})).To(Succeed())
+		})
+
+		When("the user is bound to the role in the namespace", func() {
+			It("returns true", func() {
+				hasRole, err := nsPerms.HasRoleIn(ctx, userIdentity, org1NS, roleName1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRole).To(BeTrue())
+			})
+		})
+
+		When("the user is bound to a different role in the namespace", func() {
+			It("returns false", func() {
+				hasRole, err := nsPerms.HasRoleIn(ctx, userIdentity, org1NS, roleName2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasRole).To(BeFalse())
+			})
+		})
+	})
 })
 
 func generateGUID(prefix string) string {
@@ -0,0 +1,38 @@
+package authorization
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// OIDCTokenInspector identifies the users of tokens issued by the configured
+// OIDC issuers and delegates all other tokens to the fallback inspector
+type OIDCTokenInspector struct {
+	verifier *OIDCVerifier
+	fallback TokenIdentityInspector
+}
+
+func NewOIDCTokenInspector(verifier *OIDCVerifier, fallback TokenIdentityInspector) *OIDCTokenInspector {
+	return &OIDCTokenInspector{
+		verifier: verifier,
+		fallback: fallback,
+	}
+}
+
+func (i *OIDCTokenInspector) WhoAmI(ctx context.Context, token string) (Identity, error) {
+	if !i.verifier.IsOIDCToken(token) {
+		return i.fallback.WhoAmI(ctx, token)
+	}
+
+	user, err := i.verifier.Verify(ctx, token)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{
+		Name:   user.Username,
+		Kind:   rbacv1.UserKind,
+		Groups: user.Groups,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package authorization_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/fake"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCTokenInspector", func() {
+	var (
+		fallbackInspector *fake.TokenIdentityInspector
+		inspector         *authorization.OIDCTokenInspector
+		token             string
+		id                authorization.Identity
+		err               error
+	)
+
+	BeforeEach(func() {
+		fallbackInspector = new(fake.TokenIdentityInspector)
+		fallbackInspector.WhoAmIReturns(authorization.Identity{Kind: rbacv1.UserKind, Name: "reviewed"}, nil)
+
+		verifier, verifierErr := authorization.NewOIDCVerifier([]authorization.OIDCIssuer{{
+			URL:            authProvider.IssuerURL(),
+			ClientID:       authProvider.ClientID(),
+			CAData:         authProvider.CACert(),
+			UsernamePrefix: "native:",
+		}})
+		Expect(verifierErr).NotTo(HaveOccurred())
+		inspector = authorization.NewOIDCTokenInspector(verifier, fallbackInspector)
+
+		token = authProvider.GenerateJWTToken("alice")
+	})
+
+	JustBeforeEach(func() {
+		id, err = inspector.WhoAmI(context.Background(), token)
+	})
+
+	It("verifies the token itself", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(authorization.Identity{Kind: rbacv1.UserKind, Name: "native:alice"}))
+		Expect(fallbackInspector.WhoAmICallCount()).To(BeZero())
+	})
+
+	When("the token is not issued by a configured issuer", func() {
+		BeforeEach(func() {
+			token = "a-service-account-token"
+		})
+
+		It("delegates to the fallback inspector", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("reviewed"))
+			Expect(fallbackInspector.WhoAmICallCount()).To(Equal(1))
+			_, actualToken := fallbackInspector.WhoAmIArgsForCall(0)
+			Expect(actualToken).To(Equal("a-service-account-token"))
+		})
+	})
+
+	When("the token is invalid", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("alice") + "x"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+})
@@ -0,0 +1,387 @@
+package authorization
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	emailUsernameClaim   = "email"
+
+	// noUsernamePrefix explicitly disables the default username prefix, the
+	// same as for the kubernetes api server
+	noUsernamePrefix = "-"
+
+	// users and groups with this prefix are reserved for kubernetes
+	// components, e.g. "system:masters", and must never be impersonated on
+	// behalf of a token
+	reservedNamePrefix = "system:"
+
+	// keys are refetched on unknown key IDs to follow key rotations, but
+	// not more often than this in order not to hammer the issuer with
+	// requests carrying forged tokens
+	minJWKSRefreshInterval = 10 * time.Second
+	tokenExpiryLeeway      = 30 * time.Second
+)
+
+var supportedSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+}
+
+type OIDCIssuer struct {
+	URL            string
+	ClientID       string
+	CAData         []byte
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+type OIDCUser struct {
+	Username string
+	Groups   []string
+}
+
+// OIDCVerifier validates bearer tokens issued by the configured OIDC issuers
+// without involving the kubernetes api server, so that it does not need to
+// be configured with the issuers itself
+type OIDCVerifier struct {
+	issuers map[string]*oidcIssuer
+}
+
+type oidcIssuer struct {
+	config     OIDCIssuer
+	httpClient *http.Client
+
+	mutex       sync.Mutex
+	keys        jose.JSONWebKeySet
+	lastRefresh time.Time
+	// refreshDone is closed once the keys refresh in flight completes
+	refreshDone chan struct{}
+}
+
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+func NewOIDCVerifier(issuers []OIDCIssuer) (*OIDCVerifier, error) {
+	verifier := &OIDCVerifier{issuers: map[string]*oidcIssuer{}}
+
+	for _, issuer := range issuers {
+		if issuer.URL == "" || issuer.ClientID == "" {
+			return nil, errors.New("oidc issuers require a url and a client id")
+		}
+
+		if issuer.UsernameClaim == "" {
+			issuer.UsernameClaim = defaultUsernameClaim
+		}
+		issuer.UsernamePrefix = usernamePrefix(issuer)
+
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(issuer.CAData) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(issuer.CAData) {
+				return nil, fmt.Errorf("invalid CA certificate for oidc issuer %q", issuer.URL)
+			}
+		}
+
+		verifier.issuers[issuer.URL] = &oidcIssuer{
+			config: issuer,
+			httpClient: &http.Client{
+				Timeout:   10 * time.Second,
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			},
+		}
+	}
+
+	return verifier, nil
+}
+
+// usernamePrefix defaults the prefix to the issuer URL for claims other than
+// email, the same as the kubernetes api server, so that users of an issuer
+// cannot take over the bindings of users with the same name from another
+// issuer or of certificate users
+func usernamePrefix(issuer OIDCIssuer) string {
+	switch {
+	case issuer.UsernamePrefix == noUsernamePrefix:
+		return ""
+	case issuer.UsernamePrefix == "" && issuer.UsernameClaim != emailUsernameClaim:
+		return issuer.URL + "#"
+	default:
+		return issuer.UsernamePrefix
+	}
+}
+
+// IsOIDCToken returns whether the token claims to be issued by one of the
+// configured issuers. It does not verify the token.
+func (v *OIDCVerifier) IsOIDCToken(token string) bool {
+	_, ok := v.issuerFor(token)
+	return ok
+}
+
+func (v *OIDCVerifier) issuerFor(token string) (*oidcIssuer, bool) {
+	if v == nil || len(v.issuers) == 0 {
+		return nil, false
+	}
+
+	parsedToken, err := jwt.ParseSigned(token, supportedSignatureAlgorithms)
+	if err != nil {
+		return nil, false
+	}
+
+	claims := jwt.Claims{}
+	if err = parsedToken.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return nil, false
+	}
+
+	issuer, ok := v.issuers[claims.Issuer]
+	return issuer, ok
+}
+
+func (v *OIDCVerifier) Verify(ctx context.Context, token string) (OIDCUser, error) {
+	issuer, ok := v.issuerFor(token)
+	if !ok {
+		return OIDCUser{}, apierrors.NewInvalidAuthError(errors.New("token not issued by a configured oidc issuer"))
+	}
+
+	user, err := issuer.verify(ctx, token)
+	if err != nil {
+		return OIDCUser{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	return user, nil
+}
+
+// ImpersonationConfig returns the impersonation config for the user of an
+// OIDC token. The boolean result is false for tokens of other issuers.
+func (v *OIDCVerifier) ImpersonationConfig(ctx context.Context, token string) (rest.ImpersonationConfig, bool, error) {
+	if !v.IsOIDCToken(token) {
+		return rest.ImpersonationConfig{}, false, nil
+	}
+
+	user, err := v.Verify(ctx, token)
+	if err != nil {
+		return rest.ImpersonationConfig{}, true, err
+	}
+
+	return rest.ImpersonationConfig{
+		UserName: user.Username,
+		Groups:   user.Groups,
+	}, true, nil
+}
+
+func (i *oidcIssuer) verify(ctx context.Context, token string) (OIDCUser, error) {
+	parsedToken, err := jwt.ParseSigned(token, supportedSignatureAlgorithms)
+	if err != nil {
+		return OIDCUser{}, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if len(parsedToken.Headers) != 1 {
+		return OIDCUser{}, errors.New("token must have exactly one signature")
+	}
+
+	key, err := i.key(ctx, parsedToken.Headers[0].KeyID)
+	if err != nil {
+		return OIDCUser{}, err
+	}
+
+	claims := jwt.Claims{}
+	customClaims := map[string]any{}
+	if err = parsedToken.Claims(key.Key, &claims, &customClaims); err != nil {
+		return OIDCUser{}, fmt.Errorf("failed to verify token signature: %w", err)
+	}
+
+	if err = claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:      i.config.URL,
+		AnyAudience: jwt.Audience{i.config.ClientID},
+		Time:        time.Now(),
+	}, tokenExpiryLeeway); err != nil {
+		return OIDCUser{}, fmt.Errorf("invalid token claims: %w", err)
+	}
+
+	if claims.Expiry == nil {
+		return OIDCUser{}, errors.New("token has no expiry")
+	}
+
+	return i.user(customClaims)
+}
+
+func (i *oidcIssuer) user(claims map[string]any) (OIDCUser, error) {
+	username, ok := claims[i.config.UsernameClaim].(string)
+	if !ok || username == "" {
+		return OIDCUser{}, fmt.Errorf("token has no %q claim", i.config.UsernameClaim)
+	}
+
+	// same as the kubernetes api server, only trust verified email addresses
+	if i.config.UsernameClaim == emailUsernameClaim {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return OIDCUser{}, errors.New("email of token is not verified")
+		}
+	}
+
+	user := OIDCUser{Username: i.config.UsernamePrefix + username}
+	if strings.HasPrefix(user.Username, reservedNamePrefix) {
+		return OIDCUser{}, fmt.Errorf("username %q is reserved", user.Username)
+	}
+
+	if i.config.GroupsClaim == "" {
+		return user, nil
+	}
+
+	switch groups := claims[i.config.GroupsClaim].(type) {
+	case string:
+		user.Groups = []string{i.config.GroupsPrefix + groups}
+	case []any:
+		for _, group := range groups {
+			groupName, ok := group.(string)
+			if !ok {
+				return OIDCUser{}, fmt.Errorf("invalid %q claim", i.config.GroupsClaim)
+			}
+			user.Groups = append(user.Groups, i.config.GroupsPrefix+groupName)
+		}
+	case nil:
+	default:
+		return OIDCUser{}, fmt.Errorf("invalid %q claim", i.config.GroupsClaim)
+	}
+
+	for _, group := range user.Groups {
+		if strings.HasPrefix(group, reservedNamePrefix) {
+			return OIDCUser{}, fmt.Errorf("group %q is reserved", group)
+		}
+	}
+
+	return user, nil
+}
+
+// key looks up the signing key, refreshing the keys of the issuer on unknown
+// key IDs. The keys are fetched without holding the mutex, so that a slow
+// issuer does not block the verification of tokens signed with known keys.
+func (i *oidcIssuer) key(ctx context.Context, keyID string) (jose.JSONWebKey, error) {
+	i.mutex.Lock()
+	if key, ok := findKey(i.keys, keyID); ok {
+		i.mutex.Unlock()
+		return key, nil
+	}
+
+	refreshDone := i.refreshDone
+	if refreshDone == nil {
+		// failed refreshes are rate limited as well, so that an unavailable
+		// issuer is not hammered either
+		if time.Since(i.lastRefresh) < minJWKSRefreshInterval {
+			i.mutex.Unlock()
+			return jose.JSONWebKey{}, fmt.Errorf("unknown signing key %q", keyID)
+		}
+
+		i.lastRefresh = time.Now()
+		refreshDone = make(chan struct{})
+		i.refreshDone = refreshDone
+		i.mutex.Unlock()
+
+		if err := i.refreshKeys(ctx, refreshDone); err != nil {
+			return jose.JSONWebKey{}, err
+		}
+	} else {
+		i.mutex.Unlock()
+
+		select {
+		case <-refreshDone:
+		case <-ctx.Done():
+			return jose.JSONWebKey{}, ctx.Err()
+		}
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if key, ok := findKey(i.keys, keyID); ok {
+		return key, nil
+	}
+
+	return jose.JSONWebKey{}, fmt.Errorf("unknown signing key %q", keyID)
+}
+
+func (i *oidcIssuer) refreshKeys(ctx context.Context, refreshDone chan struct{}) error {
+	keys, err := i.fetchKeys(ctx)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.refreshDone = nil
+	close(refreshDone)
+
+	if err != nil {
+		return err
+	}
+	i.keys = keys
+
+	return nil
+}
+
+func findKey(keys jose.JSONWebKeySet, keyID string) (jose.JSONWebKey, bool) {
+	for _, key := range keys.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		if keyID == "" || key.KeyID == keyID {
+			return key, true
+		}
+	}
+
+	return jose.JSONWebKey{}, false
+}
+
+func (i *oidcIssuer) fetchKeys(ctx context.Context) (jose.JSONWebKeySet, error) {
+	discovery := oidcDiscovery{}
+	if err := i.getJSON(ctx, strings.TrimSuffix(i.config.URL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to discover oidc issuer %q: %w", i.config.URL, err)
+	}
+
+	if discovery.Issuer != i.config.URL {
+		return jose.JSONWebKeySet{}, fmt.Errorf("oidc issuer %q advertises a different issuer %q", i.config.URL, discovery.Issuer)
+	}
+
+	keys := jose.JSONWebKeySet{}
+	if err := i.getJSON(ctx, discovery.JWKSURI, &keys); err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to get signing keys of oidc issuer %q: %w", i.config.URL, err)
+	}
+
+	return keys, nil
+}
+
+func (i *oidcIssuer) getJSON(ctx context.Context, url string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
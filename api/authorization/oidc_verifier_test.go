@@ -0,0 +1,309 @@
+package authorization_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/testhelpers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OIDCVerifier", func() {
+	var (
+		issuer   authorization.OIDCIssuer
+		verifier *authorization.OIDCVerifier
+		token    string
+		user     authorization.OIDCUser
+		err      error
+	)
+
+	BeforeEach(func() {
+		issuer = authorization.OIDCIssuer{
+			URL:            authProvider.IssuerURL(),
+			ClientID:       authProvider.ClientID(),
+			CAData:         authProvider.CACert(),
+			UsernamePrefix: "native:",
+			GroupsClaim:    "groups",
+			GroupsPrefix:   "native-group:",
+		}
+		token = authProvider.GenerateJWTToken("alice", "developers", "admins")
+	})
+
+	JustBeforeEach(func() {
+		verifier, err = authorization.NewOIDCVerifier([]authorization.OIDCIssuer{issuer})
+		Expect(err).NotTo(HaveOccurred())
+
+		user, err = verifier.Verify(context.Background(), token)
+	})
+
+	It("maps the token claims to a prefixed user and groups", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user).To(Equal(authorization.OIDCUser{
+			Username: "native:alice",
+			Groups:   []string{"native-group:developers", "native-group:admins"},
+		}))
+	})
+
+	It("recognises the token as an oidc token", func() {
+		Expect(verifier.IsOIDCToken(token)).To(BeTrue())
+	})
+
+	When("the username claim is configured", func() {
+		BeforeEach(func() {
+			issuer.UsernameClaim = "email"
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss":            authProvider.IssuerURL(),
+				"aud":            authProvider.ClientID(),
+				"sub":            "alice",
+				"email":          "alice@example.com",
+				"email_verified": true,
+				"exp":            time.Now().Add(time.Minute).Unix(),
+			})
+		})
+
+		It("uses the claim as username", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal("native:alice@example.com"))
+		})
+
+		When("the email is not verified", func() {
+			BeforeEach(func() {
+				token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+					"iss":            authProvider.IssuerURL(),
+					"aud":            authProvider.ClientID(),
+					"email":          "alice@example.com",
+					"email_verified": false,
+					"exp":            time.Now().Add(time.Minute).Unix(),
+				})
+			})
+
+			It("returns an invalid auth error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			})
+		})
+	})
+
+	When("no username prefix is configured", func() {
+		BeforeEach(func() {
+			issuer.UsernamePrefix = ""
+		})
+
+		It("prefixes the username with the issuer url", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal(authProvider.IssuerURL() + "#alice"))
+		})
+
+		When("another issuer issues a token for the same subject", func() {
+			var otherUser authorization.OIDCUser
+
+			BeforeEach(func() {
+				otherProvider := testhelpers.NewAuthProvider()
+				DeferCleanup(otherProvider.Stop)
+
+				otherVerifier, verifierErr := authorization.NewOIDCVerifier([]authorization.OIDCIssuer{issuer, {
+					URL:      otherProvider.IssuerURL(),
+					ClientID: otherProvider.ClientID(),
+					CAData:   otherProvider.CACert(),
+				}})
+				Expect(verifierErr).NotTo(HaveOccurred())
+
+				var verifyErr error
+				otherUser, verifyErr = otherVerifier.Verify(context.Background(), otherProvider.GenerateJWTToken("alice"))
+				Expect(verifyErr).NotTo(HaveOccurred())
+			})
+
+			It("maps the subjects to different users", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(otherUser.Username).NotTo(Equal(user.Username))
+			})
+		})
+
+		When("the username claim is email", func() {
+			BeforeEach(func() {
+				issuer.UsernameClaim = "email"
+				token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+					"iss":            authProvider.IssuerURL(),
+					"aud":            authProvider.ClientID(),
+					"sub":            "alice",
+					"email":          "alice@example.com",
+					"email_verified": true,
+					"exp":            time.Now().Add(time.Minute).Unix(),
+				})
+			})
+
+			It("does not prefix the username", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user.Username).To(Equal("alice@example.com"))
+			})
+		})
+	})
+
+	When("the username prefix is disabled", func() {
+		BeforeEach(func() {
+			issuer.UsernamePrefix = "-"
+		})
+
+		It("does not prefix the username", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Username).To(Equal("alice"))
+		})
+	})
+
+	When("the username is reserved for kubernetes components", func() {
+		BeforeEach(func() {
+			issuer.UsernamePrefix = "-"
+			token = authProvider.GenerateJWTToken("system:kube-controller-manager")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("a group is reserved for kubernetes components", func() {
+		BeforeEach(func() {
+			issuer.GroupsPrefix = ""
+			token = authProvider.GenerateJWTToken("alice", "developers", "system:masters")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token has expired", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss": authProvider.IssuerURL(),
+				"aud": authProvider.ClientID(),
+				"sub": "alice",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			})
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token is issued for another audience", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss": authProvider.IssuerURL(),
+				"aud": "someone-else",
+				"sub": "alice",
+				"exp": time.Now().Add(time.Minute).Unix(),
+			})
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token is issued by an unknown issuer", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss": "https://unknown.example.com",
+				"aud": authProvider.ClientID(),
+				"sub": "alice",
+				"exp": time.Now().Add(time.Minute).Unix(),
+			})
+		})
+
+		It("does not recognise the token as an oidc token", func() {
+			Expect(verifier.IsOIDCToken(token)).To(BeFalse())
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token is not a jwt", func() {
+		BeforeEach(func() {
+			token = "xxx"
+		})
+
+		It("does not recognise the token as an oidc token", func() {
+			Expect(verifier.IsOIDCToken(token)).To(BeFalse())
+		})
+	})
+
+	When("the signing key is rotated", func() {
+		BeforeEach(func() {
+			DeferCleanup(authProvider.RotateSigningKey)
+		})
+
+		JustBeforeEach(func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			authProvider.RotateSigningKey()
+			user, err = verifier.Verify(context.Background(), authProvider.GenerateJWTToken("bob"))
+		})
+
+		It("fetches the new signing keys", func() {
+			Expect(err).To(MatchError(ContainSubstring("unknown signing key")))
+
+			Eventually(func(g Gomega) {
+				user, err = verifier.Verify(context.Background(), authProvider.GenerateJWTToken("bob"))
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(user.Username).To(Equal("native:bob"))
+			}, "15s").Should(Succeed())
+		})
+	})
+
+	When("the issuer is unavailable", func() {
+		var issuerRequests atomic.Int32
+
+		BeforeEach(func() {
+			issuerRequests.Store(0)
+			unavailableIssuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				issuerRequests.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			DeferCleanup(unavailableIssuer.Close)
+
+			issuer = authorization.OIDCIssuer{
+				URL:      unavailableIssuer.URL,
+				ClientID: authProvider.ClientID(),
+			}
+			token = authProvider.GenerateJWTTokenWithClaims(jwt.MapClaims{
+				"iss": unavailableIssuer.URL,
+				"aud": authProvider.ClientID(),
+				"sub": "alice",
+				"exp": time.Now().Add(time.Minute).Unix(),
+			})
+		})
+
+		It("does not retry fetching the signing keys right away", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(issuerRequests.Load()).To(BeEquivalentTo(1))
+
+			_, err = verifier.Verify(context.Background(), token)
+			Expect(err).To(MatchError(ContainSubstring("unknown signing key")))
+			Expect(issuerRequests.Load()).To(BeEquivalentTo(1))
+		})
+	})
+
+	Describe("ImpersonationConfig", func() {
+		It("returns the config impersonating the user", func() {
+			impersonationConfig, isOIDCToken, err := verifier.ImpersonationConfig(context.Background(), token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isOIDCToken).To(BeTrue())
+			Expect(impersonationConfig.UserName).To(Equal("native:alice"))
+			Expect(impersonationConfig.Groups).To(ConsistOf("native-group:developers", "native-group:admins"))
+		})
+
+		It("ignores other tokens", func() {
+			_, isOIDCToken, err := verifier.ImpersonationConfig(context.Background(), "xxx")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isOIDCToken).To(BeFalse())
+		})
+	})
+})
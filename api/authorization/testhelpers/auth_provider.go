@@ -35,6 +35,7 @@ type AuthProvider struct {
 	server       *ghttp.Server
 	serverCAPath string
 	signingKey   *rsa.PrivateKey
+	keyID        int
 }
 
 func NewAuthProvider() *AuthProvider {
@@ -42,15 +43,42 @@ func NewAuthProvider() *AuthProvider {
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 	server := ghttp.NewTLSServer()
-	configureServer(server, signingKey)
+	configureServer(server, signingKey, "1")
 
 	return &AuthProvider{
 		server:       server,
 		serverCAPath: writeCAToTempFile(server),
 		signingKey:   signingKey,
+		keyID:        1,
 	}
 }
 
+// RotateSigningKey replaces the signing key with a new one that has a
+// different key ID, both in the signed tokens and in the served JWKS
+func (p *AuthProvider) RotateSigningKey() {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	p.signingKey = signingKey
+	p.keyID++
+	configureServer(p.server, signingKey, fmt.Sprint(p.keyID))
+}
+
+func (p *AuthProvider) IssuerURL() string {
+	return p.server.URL()
+}
+
+func (p *AuthProvider) ClientID() string {
+	return audience
+}
+
+func (p *AuthProvider) CACert() []byte {
+	caCert, err := os.ReadFile(p.serverCAPath)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	return caCert
+}
+
 func (p *AuthProvider) GenerateJWTToken(subject string, groups ...string) string {
 	atClaims := jwt.MapClaims{}
 	atClaims["iss"] = p.server.URL()
@@ -58,7 +86,13 @@ func (p *AuthProvider) GenerateJWTToken(subject string, groups ...string) string
 	atClaims["sub"] = subject
 	atClaims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 	atClaims["groups"] = groups
-	at := jwt.NewWithClaims(jwt.SigningMethodRS256, atClaims)
+
+	return p.GenerateJWTTokenWithClaims(atClaims)
+}
+
+func (p *AuthProvider) GenerateJWTTokenWithClaims(claims jwt.MapClaims) string {
+	at := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	at.Header["kid"] = fmt.Sprint(p.keyID)
 	token, err := at.SignedString(p.signingKey)
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
@@ -79,7 +113,7 @@ func writeCAToTempFile(server *ghttp.Server) string {
 	return certOut.Name()
 }
 
-func renderJWKSResponse(signingKey *rsa.PrivateKey) string {
+func renderJWKSResponse(signingKey *rsa.PrivateKey, keyID string) string {
 	template := &x509.Certificate{
 		IsCA:                  true,
 		BasicConstraintsValid: true,
@@ -103,7 +137,7 @@ func renderJWKSResponse(signingKey *rsa.PrivateKey) string {
 	jwks := jose.JSONWebKeySet{
 		Keys: []jose.JSONWebKey{{
 			Key:          &signingKey.PublicKey,
-			KeyID:        "1",
+			KeyID:        keyID,
 			Use:          "sig",
 			Algorithm:    "RS256",
 			Certificates: []*x509.Certificate{cert},
@@ -115,7 +149,7 @@ func renderJWKSResponse(signingKey *rsa.PrivateKey) string {
 	return string(jwksBytes)
 }
 
-func configureServer(server *ghttp.Server, signingKey *rsa.PrivateKey) {
+func configureServer(server *ghttp.Server, signingKey *rsa.PrivateKey, keyID string) {
 	applicationJSONHeader := http.Header{}
 	applicationJSONHeader.Add("Content-Type", "application/json")
 
@@ -141,7 +175,7 @@ func configureServer(server *ghttp.Server, signingKey *rsa.PrivateKey) {
 		"/jwks.json",
 		ghttp.RespondWith(
 			http.StatusOK,
-			renderJWKSResponse(signingKey),
+			renderJWKSResponse(signingKey, keyID),
 			applicationJSONHeader,
 		),
 	)
@@ -58,6 +58,7 @@ var _ = Describe("TokenReviewer", func() {
 		It("extracts the identity of the serviceaccount", func() {
 			Expect(id.Kind).To(Equal(rbacv1.ServiceAccountKind))
 			Expect(id.Name).To(Equal("system:serviceaccount:cf:my-serviceaccount"))
+			Expect(id.Groups).To(ContainElements("system:serviceaccounts", "system:serviceaccounts:cf"))
 		})
 	})
 
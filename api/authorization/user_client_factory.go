@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"context"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -21,19 +22,30 @@ type UserClientFactory interface {
 }
 
 type UnprivilegedClientFactory struct {
-	config   *rest.Config
-	mapper   meta.RESTMapper
-	wrappers []ClientWrappingFunc
+	config             *rest.Config
+	impersonatorConfig *rest.Config
+	oidcVerifier       *OIDCVerifier
+	mapper             meta.RESTMapper
+	wrappers           []ClientWrappingFunc
 }
 
 func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper) UnprivilegedClientFactory {
 	return UnprivilegedClientFactory{
-		config:   rest.AnonymousClientConfig(rest.CopyConfig(config)),
-		mapper:   mapper,
-		wrappers: []ClientWrappingFunc{},
+		config:             rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatorConfig: rest.CopyConfig(config),
+		mapper:             mapper,
+		wrappers:           []ClientWrappingFunc{},
 	}
 }
 
+// WithOIDCVerifier makes the clients of users authenticated by the
+// configured OIDC issuers impersonate them, as their tokens are not
+// necessarily accepted by the api server
+func (f UnprivilegedClientFactory) WithOIDCVerifier(verifier *OIDCVerifier) UnprivilegedClientFactory {
+	f.oidcVerifier = verifier
+	return f
+}
+
 func (f UnprivilegedClientFactory) WithWrappingFunc(wrapper ClientWrappingFunc) UnprivilegedClientFactory {
 	f.wrappers = append(f.wrappers, wrapper)
 	return f
@@ -44,7 +56,17 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch,
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
-		config.BearerToken = authInfo.Token
+		impersonationConfig, isOIDCToken, err := f.oidcVerifier.ImpersonationConfig(context.Background(), authInfo.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if isOIDCToken {
+			config = rest.CopyConfig(f.impersonatorConfig)
+			config.Impersonate = impersonationConfig
+		} else {
+			config.BearerToken = authInfo.Token
+		}
 
 	case CertScheme:
 		certBlock, rst := pem.Decode(authInfo.CertData)
@@ -135,6 +135,37 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 				})
 			})
 		})
+
+		Context("tokens of natively verified oidc issuers", func() {
+			BeforeEach(func() {
+				oidcVerifier, err := authorization.NewOIDCVerifier([]authorization.OIDCIssuer{{
+					URL:            authProvider.IssuerURL(),
+					ClientID:       authProvider.ClientID(),
+					CAData:         authProvider.CACert(),
+					UsernamePrefix: "native:",
+				}})
+				Expect(err).NotTo(HaveOccurred())
+				clientFactory = clientFactory.WithOIDCVerifier(oidcVerifier)
+
+				authInfo.Token = authProvider.GenerateJWTToken(userName)
+			})
+
+			It("impersonates the user and forbids access to them", func() {
+				Expect(buildClientErr).NotTo(HaveOccurred())
+				Expect(k8serrors.IsForbidden(podListErr)).To(BeTrue())
+			})
+
+			When("a role binding for the prefixed user exists", func() {
+				BeforeEach(func() {
+					allowListingPods("native:" + userName)
+				})
+
+				It("allows listing pods", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(podListErr).NotTo(HaveOccurred())
+				})
+			})
+		})
 	})
 
 	Context("isolation", func() {
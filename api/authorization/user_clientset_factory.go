@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"context"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -17,21 +18,39 @@ type UserClientsetFactory interface {
 }
 
 type UnprivilegedClientsetFactory struct {
-	config *rest.Config
+	config             *rest.Config
+	impersonatorConfig *rest.Config
+	oidcVerifier       *OIDCVerifier
 }
 
 func NewUnprivilegedClientsetFactory(config *rest.Config) UnprivilegedClientsetFactory {
 	return UnprivilegedClientsetFactory{
-		config: rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		config:             rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatorConfig: rest.CopyConfig(config),
 	}
 }
 
+func (f UnprivilegedClientsetFactory) WithOIDCVerifier(verifier *OIDCVerifier) UnprivilegedClientsetFactory {
+	f.oidcVerifier = verifier
+	return f
+}
+
 func (f UnprivilegedClientsetFactory) BuildClientset(authInfo Info) (k8sclient.Interface, error) {
 	config := rest.CopyConfig(f.config)
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
-		config.BearerToken = authInfo.Token
+		impersonationConfig, isOIDCToken, err := f.oidcVerifier.ImpersonationConfig(context.Background(), authInfo.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if isOIDCToken {
+			config = rest.CopyConfig(f.impersonatorConfig)
+			config.Impersonate = impersonationConfig
+		} else {
+			config.BearerToken = authInfo.Token
+		}
 
 	case CertScheme:
 		certBlock, rst := pem.Decode(authInfo.CertData)
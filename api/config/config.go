@@ -3,9 +3,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 
 	"go.uber.org/zap/zapcore"
 	"k8s.io/client-go/rest"
@@ -20,6 +24,7 @@ const (
 type (
 	APIConfig struct {
 		InternalPort      int `yaml:"internalPort"`
+		MetricsPort       int `yaml:"metricsPort"`
 		IdleTimeout       int `yaml:"idleTimeout"`
 		ReadTimeout       int `yaml:"readTimeout"`
 		ReadHeaderTimeout int `yaml:"readHeaderTimeout"`
@@ -32,15 +37,17 @@ type (
 
 		InfoConfig InfoConfig `yaml:"infoConfig"`
 
-		RootNamespace                            string                 `yaml:"rootNamespace"`
-		BuilderName                              string                 `yaml:"builderName"`
-		RunnerName                               string                 `yaml:"runnerName"`
-		ContainerRepositoryPrefix                string                 `yaml:"containerRepositoryPrefix"`
-		ContainerRegistryType                    string                 `yaml:"containerRegistryType"`
-		PackageRegistrySecretNames               []string               `yaml:"packageRegistrySecretNames"`
-		DefaultDomainName                        string                 `yaml:"defaultDomainName"`
-		UserCertificateExpirationWarningDuration string                 `yaml:"userCertificateExpirationWarningDuration"`
-		DefaultLifecycleConfig                   DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
+		RootNamespace                            string                           `yaml:"rootNamespace"`
+		BuilderName                              string                           `yaml:"builderName"`
+		RunnerName                               string                           `yaml:"runnerName"`
+		ContainerRepositoryPrefix                string                           `yaml:"containerRepositoryPrefix"`
+		ContainerRegistryType                    string                           `yaml:"containerRegistryType"`
+		ContainerRepositoryCreator               registry.RepositoryCreatorConfig `yaml:"containerRepositoryCreator"`
+		PackageRegistrySecretNames               []string                         `yaml:"packageRegistrySecretNames"`
+		PackageBlobstore                         blobstore.Config                 `yaml:"packageBlobstore"`
+		DefaultDomainName                        string                           `yaml:"defaultDomainName"`
+		UserCertificateExpirationWarningDuration string                           `yaml:"userCertificateExpirationWarningDuration"`
+		DefaultLifecycleConfig                   DefaultLifecycleConfig           `yaml:"defaultLifecycleConfig"`
 
 		RoleMappings map[string]Role `yaml:"roleMappings"`
 
@@ -48,15 +55,34 @@ type (
 		AuthProxyCACert string        `yaml:"authProxyCACert"`
 		LogLevel        zapcore.Level `yaml:"logLevel"`
 
+		RateLimit RateLimit      `yaml:"rateLimit"`
+		Tracing   tracing.Config `yaml:"tracing"`
+
 		Experimental Experimental `yaml:"experimental"`
 	}
 
+	RateLimit struct {
+		Enabled bool `yaml:"enabled"`
+		// GeneralLimit is the number of requests an authenticated user can
+		// make per reset interval, admins are not limited
+		GeneralLimit int `yaml:"generalLimit"`
+		// UnauthenticatedLimit is the number of requests a client IP can
+		// make per reset interval without authenticating or failing to authenticate
+		UnauthenticatedLimit int    `yaml:"unauthenticatedLimit"`
+		ResetInterval        string `yaml:"resetInterval"`
+		// TrustedProxies are the CIDRs of the proxies in front of the API
+		// whose X-Forwarded-For header is used to find the client IP
+		TrustedProxies []string `yaml:"trustedProxies"`
+	}
+
 	Experimental struct {
 		ManagedServices  ManagedServices `yaml:"managedServices"`
 		UAA              UAA             `yaml:"uaa"`
+		OIDC             OIDC            `yaml:"oidc"`
 		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
 		K8SClient        K8SClientConfig `yaml:"k8sClient"`
 		SecurityGroups   SecurityGroups  `yaml:"securityGroups"`
+		SSH              SSH             `yaml:"ssh"`
 	}
 
 	ManagedServices struct {
@@ -68,6 +94,21 @@ type (
 		URL     string `yaml:"url"`
 	}
 
+	OIDC struct {
+		Enabled bool         `yaml:"enabled"`
+		Issuers []OIDCIssuer `yaml:"issuers"`
+	}
+
+	OIDCIssuer struct {
+		IssuerURL      string `yaml:"issuerURL"`
+		ClientID       string `yaml:"clientID"`
+		CACert         string `yaml:"caCert"`
+		UsernameClaim  string `yaml:"usernameClaim"`
+		UsernamePrefix string `yaml:"usernamePrefix"`
+		GroupsClaim    string `yaml:"groupsClaim"`
+		GroupsPrefix   string `yaml:"groupsPrefix"`
+	}
+
 	ExtenalLogCache struct {
 		Enabled               bool   `yaml:"enabled"`
 		URL                   string `yaml:"url"`
@@ -83,6 +124,20 @@ type (
 		Enabled bool `yaml:"enabled"`
 	}
 
+	SSH struct {
+		Enabled bool `yaml:"enabled"`
+		// ProxyAddress is the host:port the cf cli connects to
+		ProxyAddress string `yaml:"proxyAddress"`
+		// HostKeyPath is the path of the private host key of the ssh proxy,
+		// which is needed to advertise its fingerprint
+		HostKeyPath string `yaml:"hostKeyPath"`
+		// CodesNamespace is the namespace dedicated to the secrets of the
+		// ssh codes, so that the ssh proxy does not need access to the
+		// secrets of the root namespace
+		CodesNamespace string `yaml:"codesNamespace"`
+		CodeTTL        string `yaml:"codeTTL"`
+	}
+
 	RoleLevel string
 
 	Role struct {
@@ -160,9 +215,86 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.Experimental.OIDC.Enabled {
+		for _, issuer := range c.Experimental.OIDC.Issuers {
+			if issuer.IssuerURL == "" || issuer.ClientID == "" {
+				return errors.New("OIDC issuers require an issuerURL and a clientID")
+			}
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		if c.RateLimit.GeneralLimit <= 0 || c.RateLimit.UnauthenticatedLimit <= 0 {
+			return errors.New("rate limiting requires a positive generalLimit and unauthenticatedLimit")
+		}
+
+		if c.RateLimit.ResetInterval != "" {
+			if _, err := time.ParseDuration(c.RateLimit.ResetInterval); err != nil {
+				return errors.New(`invalid duration format for rateLimit resetInterval. Use a format like "1h"`)
+			}
+		}
+
+		for _, cidr := range c.RateLimit.TrustedProxies {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid rateLimit trustedProxies CIDR %q", cidr)
+			}
+		}
+	}
+
+	if c.ContainerRegistryType == registry.HarborContainerRegistryType || c.ContainerRegistryType == registry.GenericContainerRegistryType {
+		if c.ContainerRepositoryCreator.URL == "" {
+			return fmt.Errorf("containerRepositoryCreator url is required for container registry type %q", c.ContainerRegistryType)
+		}
+	}
+
+	if err := c.PackageBlobstore.Validate(); err != nil {
+		return fmt.Errorf("invalid packageBlobstore: %w", err)
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	if c.Experimental.SSH.Enabled {
+		if c.Experimental.SSH.ProxyAddress == "" || c.Experimental.SSH.HostKeyPath == "" || c.Experimental.SSH.CodesNamespace == "" {
+			return errors.New("SSH requires a proxyAddress, a hostKeyPath and a codesNamespace")
+		}
+
+		if c.Experimental.SSH.CodeTTL != "" {
+			if _, err := time.ParseDuration(c.Experimental.SSH.CodeTTL); err != nil {
+				return errors.New(`invalid duration format for ssh codeTTL. Use a format like "5m"`)
+			}
+		}
+	}
+
 	return nil
 }
 
+func (c SSH) GetCodeTTL() time.Duration {
+	if c.CodeTTL == "" {
+		return 5 * time.Minute
+	}
+	d, _ := time.ParseDuration(c.CodeTTL)
+	return d
+}
+
+func (c RateLimit) GetResetInterval() time.Duration {
+	if c.ResetInterval == "" {
+		return time.Hour
+	}
+	d, _ := time.ParseDuration(c.ResetInterval)
+	return d
+}
+
+func (c RateLimit) GetTrustedProxies() []*net.IPNet {
+	trustedProxies := []*net.IPNet{}
+	for _, cidr := range c.TrustedProxies {
+		_, ipNet, _ := net.ParseCIDR(cidr)
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+	return trustedProxies
+}
+
 func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	if c.UserCertificateExpirationWarningDuration == "" {
 		return time.Hour * 24 * 7
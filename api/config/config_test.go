@@ -1,7 +1,9 @@
 package config_test
 
 import (
+	"net"
 	"os"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
@@ -28,6 +30,7 @@ var _ = Describe("Config", func() {
 
 		configMap = map[string]interface{}{
 			"internalPort":      1,
+			"metricsPort":       6,
 			"idleTimeout":       2,
 			"readTimeout":       3,
 			"readHeaderTimeout": 4,
@@ -87,6 +90,7 @@ var _ = Describe("Config", func() {
 	It("populates the config", func() {
 		Expect(loadErr).NotTo(HaveOccurred())
 		Expect(cfg.InternalPort).To(Equal(1))
+		Expect(cfg.MetricsPort).To(Equal(6))
 		Expect(cfg.IdleTimeout).To(Equal(2))
 		Expect(cfg.ReadTimeout).To(Equal(3))
 		Expect(cfg.ReadHeaderTimeout).To(Equal(4))
@@ -213,6 +217,246 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("OIDC issuers are configured", func() {
+		BeforeEach(func() {
+			configMap["experimental"].(map[string]any)["oidc"] = map[string]any{
+				"enabled": true,
+				"issuers": []map[string]any{{
+					"issuerURL":      "https://issuer.example.com",
+					"clientID":       "korifi",
+					"usernameClaim":  "email",
+					"usernamePrefix": "oidc:",
+					"groupsClaim":    "groups",
+					"groupsPrefix":   "oidc:",
+				}},
+			}
+		})
+
+		It("populates the issuers", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.OIDC.Enabled).To(BeTrue())
+			Expect(cfg.Experimental.OIDC.Issuers).To(ConsistOf(config.OIDCIssuer{
+				IssuerURL:      "https://issuer.example.com",
+				ClientID:       "korifi",
+				UsernameClaim:  "email",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "groups",
+				GroupsPrefix:   "oidc:",
+			}))
+		})
+
+		When("an issuer has no client id", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["oidc"] = map[string]any{
+					"enabled": true,
+					"issuers": []map[string]any{{
+						"issuerURL": "https://issuer.example.com",
+					}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("require an issuerURL and a clientID")))
+			})
+		})
+	})
+
+	When("ssh is enabled", func() {
+		BeforeEach(func() {
+			configMap["experimental"].(map[string]any)["ssh"] = map[string]any{
+				"enabled":        true,
+				"proxyAddress":   "ssh.foo:2222",
+				"hostKeyPath":    "/etc/ssh-proxy/host-key",
+				"codesNamespace": "korifi-ssh-codes",
+			}
+		})
+
+		It("populates the ssh config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.SSH.ProxyAddress).To(Equal("ssh.foo:2222"))
+			Expect(cfg.Experimental.SSH.CodesNamespace).To(Equal("korifi-ssh-codes"))
+			Expect(cfg.Experimental.SSH.GetCodeTTL()).To(Equal(5 * time.Minute))
+		})
+
+		When("the proxy address is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["experimental"].(map[string]any)["ssh"].(map[string]any), "proxyAddress")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("SSH requires a proxyAddress")))
+			})
+		})
+
+		When("the codes namespace is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["experimental"].(map[string]any)["ssh"].(map[string]any), "codesNamespace")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("codesNamespace")))
+			})
+		})
+
+		When("the code ttl is invalid", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["ssh"].(map[string]any)["codeTTL"] = "foo"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid duration format for ssh codeTTL")))
+			})
+		})
+	})
+
+	When("rate limiting is enabled", func() {
+		BeforeEach(func() {
+			configMap["rateLimit"] = map[string]any{
+				"enabled":              true,
+				"generalLimit":         2000,
+				"unauthenticatedLimit": 100,
+			}
+		})
+
+		It("populates the rate limit config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.RateLimit.GeneralLimit).To(Equal(2000))
+			Expect(cfg.RateLimit.UnauthenticatedLimit).To(Equal(100))
+			Expect(cfg.RateLimit.GetResetInterval()).To(Equal(time.Hour))
+		})
+
+		When("a limit is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["rateLimit"].(map[string]any), "unauthenticatedLimit")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("requires a positive generalLimit and unauthenticatedLimit")))
+			})
+		})
+
+		When("the reset interval is invalid", func() {
+			BeforeEach(func() {
+				configMap["rateLimit"].(map[string]any)["resetInterval"] = "foo"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid duration format for rateLimit resetInterval")))
+			})
+		})
+
+		When("trusted proxies are configured", func() {
+			BeforeEach(func() {
+				configMap["rateLimit"].(map[string]any)["trustedProxies"] = []string{"10.0.0.0/8"}
+			})
+
+			It("parses them", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.RateLimit.GetTrustedProxies()).To(ConsistOf(
+					&net.IPNet{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+				))
+			})
+
+			When("a trusted proxy is not a CIDR", func() {
+				BeforeEach(func() {
+					configMap["rateLimit"].(map[string]any)["trustedProxies"] = []string{"10.0.0.1"}
+				})
+
+				It("returns an error", func() {
+					Expect(loadErr).To(MatchError(ContainSubstring(`invalid rateLimit trustedProxies CIDR "10.0.0.1"`)))
+				})
+			})
+		})
+	})
+
+	When("the container registry type is Harbor", func() {
+		BeforeEach(func() {
+			configMap["containerRegistryType"] = "Harbor"
+			configMap["containerRepositoryCreator"] = map[string]any{
+				"url":             "https://harbor.example.com",
+				"credentialsPath": "/etc/korifi-registry-credentials",
+				"retention": map[string]any{
+					"retainLatestTags": 10,
+				},
+			}
+		})
+
+		It("populates the repository creator config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.ContainerRepositoryCreator.URL).To(Equal("https://harbor.example.com"))
+			Expect(cfg.ContainerRepositoryCreator.CredentialsPath).To(Equal("/etc/korifi-registry-credentials"))
+			Expect(cfg.ContainerRepositoryCreator.Retention.RetainLatestTags).To(Equal(10))
+		})
+
+		When("the url is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["containerRepositoryCreator"].(map[string]any), "url")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring(`containerRepositoryCreator url is required for container registry type "Harbor"`)))
+			})
+		})
+	})
+
+	When("the package blobstore is configured", func() {
+		BeforeEach(func() {
+			configMap["packageBlobstore"] = map[string]any{
+				"type":            "S3",
+				"url":             "https://s3.example.com",
+				"bucket":          "packages",
+				"region":          "eu-west-1",
+				"credentialsPath": "/etc/korifi-blobstore-credentials",
+			}
+		})
+
+		It("populates the package blobstore config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.PackageBlobstore.Type).To(Equal("S3"))
+			Expect(cfg.PackageBlobstore.URL).To(Equal("https://s3.example.com"))
+			Expect(cfg.PackageBlobstore.Bucket).To(Equal("packages"))
+			Expect(cfg.PackageBlobstore.Region).To(Equal("eu-west-1"))
+			Expect(cfg.PackageBlobstore.CredentialsPath).To(Equal("/etc/korifi-blobstore-credentials"))
+		})
+
+		When("the bucket is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["packageBlobstore"].(map[string]any), "bucket")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("the S3 blobstore requires a url and a bucket")))
+			})
+		})
+	})
+
+	When("tracing is enabled", func() {
+		BeforeEach(func() {
+			configMap["tracing"] = map[string]any{
+				"enabled":  true,
+				"endpoint": "otel-collector:4317",
+				"insecure": true,
+			}
+		})
+
+		It("populates the tracing config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Tracing.Enabled).To(BeTrue())
+			Expect(cfg.Tracing.Endpoint).To(Equal("otel-collector:4317"))
+			Expect(cfg.Tracing.Insecure).To(BeTrue())
+		})
+
+		When("the endpoint is missing", func() {
+			BeforeEach(func() {
+				delete(configMap["tracing"].(map[string]any), "endpoint")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("tracing requires an endpoint")))
+			})
+		})
+	})
+
 	When("external port is specified", func() {
 		BeforeEach(func() {
 			configMap["externalPort"] = 1234
@@ -370,6 +370,21 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type RateLimitExceededError struct {
+	apiError
+}
+
+func NewRateLimitExceededError(detail string) RateLimitExceededError {
+	return RateLimitExceededError{
+		apiError: apiError{
+			title:      "CF-RateLimitExceeded",
+			detail:     detail,
+			code:       10013,
+			httpStatus: http.StatusTooManyRequests,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
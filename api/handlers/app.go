@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
@@ -14,9 +15,11 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
 
+	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
 )
 
@@ -78,6 +81,11 @@ type App struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	sshEnabled              bool
+	includeResolver         *include.IncludeResolver[
+		[]repositories.AppRecord,
+		repositories.AppRecord,
+	]
 }
 
 func NewApp(
@@ -93,6 +101,8 @@ func NewApp(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	sshEnabled bool,
+	relationshipRepo include.ResourceRelationshipRepository,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -107,6 +117,8 @@ func NewApp(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		sshEnabled:              sshEnabled,
+		includeResolver:         include.NewIncludeResolver[[]repositories.AppRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
 
@@ -115,11 +127,22 @@ func (h *App) get(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get")
 
+	payload := new(payloads.AppGet)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "GUID", appGUID)
 	}
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
+
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, []repositories.AppRecord{app}, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL, includedResources...)), nil
 }
 
 //nolint:dupl
@@ -159,12 +182,35 @@ func (h *App) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
 	}
 
-	appList, err := h.appRepo.ListApps(r.Context(), authInfo, payload.ToMessage())
+	message := payload.ToMessage()
+	if payload.OrganizationGUIDs != "" {
+		// apps are not indexed by organization, so the filter is resolved to the spaces of the requested organizations
+		var spaces []repositories.SpaceRecord
+		spaces, err = h.spaceRepo.ListSpaces(r.Context(), authInfo, payload.ToListSpacesMessage())
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to list spaces")
+		}
+
+		if len(spaces) == 0 {
+			return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForApp, []repositories.AppRecord{}, h.serverURL, *r.URL)), nil
+		}
+
+		message.SpaceGUIDs = slices.Collect(it.Map(slices.Values(spaces), func(s repositories.SpaceRecord) string {
+			return s.GUID
+		}))
+	}
+
+	appList, err := h.appRepo.ListApps(r.Context(), authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app(s) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForApp, appList, h.serverURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, appList, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForApp, appList, h.serverURL, *r.URL, includedResources...)), nil
 }
 
 func (h *App) setCurrentDroplet(r *http.Request) (*routing.Response, error) {
@@ -660,32 +706,87 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	enabled, reason, err := sshStatus(r.Context(), authInfo, h.spaceRepo, h.sshEnabled, app)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to determine ssh status", "AppGUID", appGUID)
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
+		Enabled: enabled,
+		Reason:  reason,
 	}), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
+
 	switch featureName {
 	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		}
+
+		return routing.NewResponse(http.StatusOK).WithBody(appSSHFeature(app.SSHEnabled)), nil
 	case "revisions":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "revisions",
-			"description": "Enable versioning of an application",
-			"enabled":     false,
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.FeatureResponse{
+			Name:        "revisions",
+			Description: "Enable versioning of an application",
+			Enabled:     false,
 		}), nil
 	default:
 		return nil, apierrors.NewNotFoundError(nil, "Feature")
 	}
 }
 
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-feature")
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported app feature", "Feature", featureName)
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.FeaturePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, payload.ToAppSSHMessage(appGUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(appSSHFeature(app.SSHEnabled)), nil
+}
+
+func appSSHFeature(enabled bool) presenter.FeatureResponse {
+	return presenter.FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into the app.",
+		Enabled:     enabled,
+	}
+}
+
 func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.restart-instance")
@@ -758,6 +859,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
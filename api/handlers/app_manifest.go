@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppManifestPath = "/v3/apps/{guid}/manifest"
+)
+
+type AppManifest struct {
+	serverURL         url.URL
+	manifestGenerator ManifestGenerator
+}
+
+func NewAppManifest(
+	serverURL url.URL,
+	manifestGenerator ManifestGenerator,
+) *AppManifest {
+	return &AppManifest{
+		serverURL:         serverURL,
+		manifestGenerator: manifestGenerator,
+	}
+}
+
+func (h *AppManifest) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppManifest) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppManifestPath, Handler: h.get},
+	}
+}
+
+func (h *AppManifest) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-manifest.get")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	manifest, err := h.manifestGenerator.GenerateForApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to generate app manifest", "guid", appGUID)
+	}
+
+	return manifestResponse(manifest)
+}
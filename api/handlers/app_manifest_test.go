@@ -0,0 +1,89 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppManifest", func() {
+	var manifestGenerator *fake.ManifestGenerator
+
+	BeforeEach(func() {
+		manifestGenerator = new(fake.ManifestGenerator)
+		manifestGenerator.GenerateForAppReturns(payloads.Manifest{
+			Version: 1,
+			Applications: []payloads.ManifestApplication{{
+				Name: "app1",
+				Env:  map[string]string{"FOO": "bar"},
+				Processes: []payloads.ManifestApplicationProcess{{
+					Type:      "web",
+					Instances: tools.PtrTo[int32](2),
+					Memory:    tools.PtrTo("256M"),
+				}},
+				Docker: map[string]any{"image": "my/image"},
+			}},
+		}, nil)
+
+		apiHandler := NewAppManifest(*serverURL, manifestGenerator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/manifest", nil)
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	It("returns the app manifest as YAML", func() {
+		Expect(manifestGenerator.GenerateForAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppGUID := manifestGenerator.GenerateForAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualAppGUID).To(Equal("app-guid"))
+
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-yaml"))
+		Expect(rr).To(HaveHTTPBody(MatchYAML(`---
+version: 1
+applications:
+- name: app1
+  env:
+    FOO: bar
+  processes:
+  - type: web
+    instances: 2
+    memory: 256M
+  docker:
+    image: my/image
+`)))
+	})
+
+	When("the app is not found", func() {
+		BeforeEach(func() {
+			manifestGenerator.GenerateForAppReturns(payloads.Manifest{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+		})
+
+		It("returns a not found error", func() {
+			expectNotFoundError("App")
+		})
+	})
+
+	When("generating the manifest fails", func() {
+		BeforeEach(func() {
+			manifestGenerator.GenerateForAppReturns(payloads.Manifest{}, errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			expectUnknownError()
+		})
+	})
+})
@@ -12,7 +12,9 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/handlers/stats"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -37,11 +39,13 @@ var _ = Describe("App", func() {
 		routeRepo               *fake.CFRouteRepository
 		domainRepo              *fake.CFDomainRepository
 		spaceRepo               *fake.CFSpaceRepository
+		orgRepo                 *fake.CFOrgRepository
 		packageRepo             *fake.CFPackageRepository
 		podRepo                 *fake.PodRepository
 		requestValidator        *fake.RequestValidator
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		sshEnabled              bool
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -54,26 +58,14 @@ var _ = Describe("App", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		spaceRepo = new(fake.CFSpaceRepository)
+		orgRepo = new(fake.CFOrgRepository)
 		packageRepo = new(fake.CFPackageRepository)
 		requestValidator = new(fake.RequestValidator)
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
 
-		apiHandler := NewApp(
-			*serverURL,
-			appRepo,
-			dropletRepo,
-			processRepo,
-			routeRepo,
-			domainRepo,
-			spaceRepo,
-			packageRepo,
-			requestValidator,
-			podRepo,
-			gaugesCollector,
-			instancesStateCollector,
-		)
+		sshEnabled = true
 
 		appRecord = repositories.AppRecord{
 			GUID:        appGUID,
@@ -97,10 +89,41 @@ var _ = Describe("App", func() {
 			},
 		}
 		appRepo.GetAppReturns(appRecord, nil)
-		routerBuilder.LoadRoutes(apiHandler)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:       spaceGUID,
+			Name:       "my-space",
+			SSHEnabled: true,
+		}, nil)
 	})
 
 	JustBeforeEach(func() {
+		apiHandler := NewApp(
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			processRepo,
+			routeRepo,
+			domainRepo,
+			spaceRepo,
+			packageRepo,
+			requestValidator,
+			podRepo,
+			gaugesCollector,
+			instancesStateCollector,
+			sshEnabled,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				spaceRepo,
+				orgRepo,
+				new(fake.CFUserRepository),
+				domainRepo,
+				appRepo,
+			),
+		)
+
+		routerBuilder.LoadRoutes(apiHandler)
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -124,6 +147,33 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		When("the space and org are included", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+					GUID:             spaceGUID,
+					Name:             "my-space",
+					OrganizationGUID: "test-org-guid",
+				}}, nil)
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{{
+					GUID: "test-org-guid",
+					Name: "my-org",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppGet{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"space"}},
+					{RelationshipPath: []string{"space", "organization"}},
+				}})
+			})
+
+			It("returns the included resources", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.spaces[0].guid", spaceGUID),
+					MatchJSONPath("$.included.organizations[0].guid", "test-org-guid"),
+				)))
+			})
+		})
+
 		When("the app is not accessible", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -367,6 +417,104 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("filtering by organization guids", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{
+					SpaceGUIDs:        "s1,s2",
+					OrganizationGUIDs: "o1",
+				})
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "s1"}}, nil)
+			})
+
+			It("lists the apps in the spaces of the requested organizations", func() {
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+				_, actualAuthInfo, spacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(spacesMessage).To(Equal(repositories.ListSpacesMessage{
+					GUIDs:             []string{"s1", "s2"},
+					OrganizationGUIDs: []string{"o1"},
+				}))
+
+				Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+				_, _, message := appRepo.ListAppsArgsForCall(0)
+				Expect(message.SpaceGUIDs).To(ConsistOf("s1"))
+			})
+
+			When("the organizations have no matching spaces", func() {
+				BeforeEach(func() {
+					spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{}, nil)
+				})
+
+				It("returns an empty list without listing apps", func() {
+					Expect(appRepo.ListAppsCallCount()).To(BeZero())
+					Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.resources", BeEmpty())))
+				})
+			})
+
+			When("listing spaces fails", func() {
+				BeforeEach(func() {
+					spaceRepo.ListSpacesReturns(nil, errors.New("list-spaces-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("the space and org are included", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+					GUID:             "test-space-guid",
+					Name:             "my-space",
+					OrganizationGUID: "test-org-guid",
+				}}, nil)
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{{
+					GUID: "test-org-guid",
+					Name: "my-org",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"space"}},
+					{RelationshipPath: []string{"space", "organization"}},
+				}})
+			})
+
+			It("lists the related resources with a single call per relationship", func() {
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(2))
+				_, _, spaceListMessage := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(spaceListMessage.GUIDs).To(ConsistOf("test-space-guid", "test-space-guid"))
+
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, orgListMessage := orgRepo.ListOrgsArgsForCall(0)
+				Expect(orgListMessage.GUIDs).To(ConsistOf("test-org-guid"))
+			})
+
+			It("returns the included resources", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.spaces", HaveLen(1)),
+					MatchJSONPath("$.included.spaces[0].guid", "test-space-guid"),
+					MatchJSONPath("$.included.organizations", HaveLen(1)),
+					MatchJSONPath("$.included.organizations[0].name", "my-org"),
+				)))
+			})
+		})
+
+		When("resolving the included resources fails", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(nil, errors.New("list-spaces-err"))
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppList{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"space"}},
+				}})
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("no apps can be found", func() {
 			BeforeEach(func() {
 				appRepo.ListAppsReturns([]repositories.AppRecord{}, nil)
@@ -1788,16 +1936,88 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
+			appRecord.SSHEnabled = true
+			appRepo.GetAppReturns(appRecord, nil)
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
 		})
 
-		It("returns false", func() {
+		It("returns true", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.reason", BeEmpty()),
 			)))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+		})
+
+		When("ssh is disabled globally", func() {
+			BeforeEach(func() {
+				sshEnabled = false
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled globally")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					GUID: spaceGUID,
+					Name: "my-space",
+				}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for space my-space")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.SSHEnabled = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for app")),
+				)))
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("getting the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("get-space-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 
@@ -1816,6 +2036,28 @@ var _ = Describe("App", func() {
 					MatchJSONPath("$.enabled", BeFalse()),
 				)))
 			})
+
+			When("ssh is enabled for the app", func() {
+				BeforeEach(func() {
+					appRecord.SSHEnabled = true
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns ssh enabled true", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.enabled", BeTrue())))
+				})
+			})
+
+			When("the app cannot be found", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError("App")
+				})
+			})
 		})
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
@@ -1850,6 +2092,63 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features/ssh", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(true),
+			})
+			appRepo.PatchAppReturns(repositories.AppRecord{GUID: appGUID, SSHEnabled: true}, nil)
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("patches the app", func() {
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, _, msg := appRepo.PatchAppArgsForCall(0)
+			Expect(msg).To(Equal(repositories.PatchAppMessage{
+				AppGUID:    appGUID,
+				SpaceGUID:  spaceGUID,
+				SSHEnabled: tools.PtrTo(true),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is not supported", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = "/v3/audit_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name AuditEventRepository . AuditEventRepository
+
+type AuditEventRepository interface {
+	ListAuditEvents(ctx context.Context, authInfo authorization.Info, message repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+	GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (repositories.AuditEventRecord, error)
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	auditEventRepo   AuditEventRepository
+	requestValidator RequestValidator
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	auditEventRepo AuditEventRepository,
+	requestValidator RequestValidator,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		auditEventRepo:   auditEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	payload := new(payloads.AuditEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list audit events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get audit event", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,140 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		auditEventRepo   *fake.AuditEventRepository
+		requestValidator *fake.RequestValidator
+		requestMethod    string
+		requestPath      string
+	)
+
+	BeforeEach(func() {
+		auditEventRepo = new(fake.AuditEventRepository)
+		auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+			GUID:       "event-guid",
+			CreatedAt:  time.UnixMilli(1000),
+			Type:       "audit.app.process.crash",
+			TargetGUID: "app-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+		apiHandler := NewAuditEvent(*serverURL, auditEventRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/audit_events endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AuditEventList{
+				Types: "audit.app.process.crash",
+			})
+			auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+				{GUID: "event-guid", Type: "audit.app.process.crash"},
+			}, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/audit_events?types=audit.app.process.crash"
+		})
+
+		It("returns the audit events", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Types).To(ConsistOf("audit.app.process.crash"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/audit_events?types=audit.app.process.crash"),
+				MatchJSONPath("$.resources", HaveLen(1)),
+				MatchJSONPath("$.resources[0].guid", "event-guid"),
+				MatchJSONPath("$.resources[0].type", "audit.app.process.crash"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("foo"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+				Expect(auditEventRepo.ListAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/audit_events/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/audit_events/event-guid"
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.target.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/audit_events/event-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the audit event", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
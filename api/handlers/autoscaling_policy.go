@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	ProcessAutoscalingPolicyPath = "/v3/processes/{guid}/autoscaling_policy"
+)
+
+//counterfeiter:generate -o fake -fake-name AutoscalingPolicyRepository . AutoscalingPolicyRepository
+
+type AutoscalingPolicyRepository interface {
+	GetAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, spaceGUID, processGUID string) (repositories.AutoscalingPolicyRecord, error)
+	SetAutoscalingPolicy(context.Context, authorization.Info, repositories.SetAutoscalingPolicyMessage) (repositories.AutoscalingPolicyRecord, error)
+	DeleteAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, spaceGUID, processGUID string) error
+}
+
+type AutoscalingPolicy struct {
+	serverURL             url.URL
+	requestValidator      RequestValidator
+	processRepo           CFProcessRepository
+	autoscalingPolicyRepo AutoscalingPolicyRepository
+}
+
+func NewAutoscalingPolicy(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	processRepo CFProcessRepository,
+	autoscalingPolicyRepo AutoscalingPolicyRepository,
+) *AutoscalingPolicy {
+	return &AutoscalingPolicy{
+		serverURL:             serverURL,
+		requestValidator:      requestValidator,
+		processRepo:           processRepo,
+		autoscalingPolicyRepo: autoscalingPolicyRepo,
+	}
+}
+
+func (h *AutoscalingPolicy) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.get")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get process", "processGUID", processGUID)
+	}
+
+	policy, err := h.autoscalingPolicyRepo.GetAutoscalingPolicy(r.Context(), authInfo, process.SpaceGUID, process.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get autoscaling policy", "processGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAutoscalingPolicy(policy, h.serverURL)), nil
+}
+
+func (h *AutoscalingPolicy) set(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.set")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.AutoscalingPolicySet
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get process", "processGUID", processGUID)
+	}
+
+	policy, err := h.autoscalingPolicyRepo.SetAutoscalingPolicy(r.Context(), authInfo, payload.ToMessage(process.GUID, process.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to set autoscaling policy", "processGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAutoscalingPolicy(policy, h.serverURL)), nil
+}
+
+func (h *AutoscalingPolicy) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.delete")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get process", "processGUID", processGUID)
+	}
+
+	err = h.autoscalingPolicyRepo.DeleteAutoscalingPolicy(r.Context(), authInfo, process.SpaceGUID, process.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete autoscaling policy", "processGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *AutoscalingPolicy) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AutoscalingPolicy) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ProcessAutoscalingPolicyPath, Handler: h.get},
+		{Method: "PUT", Pattern: ProcessAutoscalingPolicyPath, Handler: h.set},
+		{Method: "DELETE", Pattern: ProcessAutoscalingPolicyPath, Handler: h.delete},
+	}
+}
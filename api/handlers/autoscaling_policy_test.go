@@ -0,0 +1,202 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoscalingPolicy", func() {
+	var (
+		requestValidator      *fake.RequestValidator
+		processRepo           *fake.CFProcessRepository
+		autoscalingPolicyRepo *fake.AutoscalingPolicyRepository
+		requestMethod         string
+		requestBody           string
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+
+		processRepo = new(fake.CFProcessRepository)
+		processRepo.GetProcessReturns(repositories.ProcessRecord{
+			GUID:      "process-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		autoscalingPolicyRepo = new(fake.AutoscalingPolicyRepository)
+		policyRecord := repositories.AutoscalingPolicyRecord{
+			ProcessGUID:  "process-guid",
+			SpaceGUID:    "space-guid",
+			MinInstances: 1,
+			MaxInstances: 5,
+		}
+		autoscalingPolicyRepo.GetAutoscalingPolicyReturns(policyRecord, nil)
+		autoscalingPolicyRepo.SetAutoscalingPolicyReturns(policyRecord, nil)
+
+		apiHandler := handlers.NewAutoscalingPolicy(
+			*serverURL,
+			requestValidator,
+			processRepo,
+			autoscalingPolicyRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		requestBody = ""
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, "/v3/processes/process-guid/autoscaling_policy", strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/processes/:guid/autoscaling_policy", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+		})
+
+		It("returns the autoscaling policy of the process", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(autoscalingPolicyRepo.GetAutoscalingPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, actualProcessGUID := autoscalingPolicyRepo.GetAutoscalingPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.instance_min_count", BeEquivalentTo(1)),
+				MatchJSONPath("$.instance_max_count", BeEquivalentTo(5)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/processes/process-guid/autoscaling_policy"),
+			)))
+		})
+
+		When("the user cannot get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ProcessResourceType)
+			})
+		})
+
+		When("the process has no autoscaling policy", func() {
+			BeforeEach(func() {
+				autoscalingPolicyRepo.GetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{}, apierrors.NewNotFoundError(nil, repositories.AutoscalingPolicyResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AutoscalingPolicyResourceType)
+			})
+		})
+	})
+
+	Describe("PUT /v3/processes/:guid/autoscaling_policy", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPut
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AutoscalingPolicySet{
+				InstanceMinCount:     1,
+				InstanceMaxCount:     5,
+				CPUUtilizationTarget: tools.PtrTo[int32](70),
+			})
+		})
+
+		It("sets the autoscaling policy of the process", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(autoscalingPolicyRepo.SetAutoscalingPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := autoscalingPolicyRepo.SetAutoscalingPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.SetAutoscalingPolicyMessage{
+				ProcessGUID:           "process-guid",
+				SpaceGUID:             "space-guid",
+				MinInstances:          1,
+				MaxInstances:          5,
+				CPUUtilizationPercent: tools.PtrTo[int32](70),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.links.process.href", "https://api.example.org/v3/processes/process-guid")))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(autoscalingPolicyRepo.SetAutoscalingPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ProcessResourceType)
+			})
+		})
+
+		When("setting the policy fails", func() {
+			BeforeEach(func() {
+				autoscalingPolicyRepo.SetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/processes/:guid/autoscaling_policy", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+		})
+
+		It("deletes the autoscaling policy of the process", func() {
+			Expect(autoscalingPolicyRepo.DeleteAutoscalingPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, actualProcessGUID := autoscalingPolicyRepo.DeleteAutoscalingPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("deleting the policy fails", func() {
+			BeforeEach(func() {
+				autoscalingPolicyRepo.DeleteAutoscalingPolicyReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
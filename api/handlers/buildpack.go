@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -13,38 +15,50 @@ import (
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 )
 
 const (
-	BuildpacksPath = "/v3/buildpacks"
+	BuildpacksPath      = "/v3/buildpacks"
+	BuildpackPath       = "/v3/buildpacks/{guid}"
+	BuildpackUploadPath = "/v3/buildpacks/{guid}/upload"
 )
 
 //counterfeiter:generate -o fake -fake-name BuildpackRepository . BuildpackRepository
 type BuildpackRepository interface {
 	ListBuildpacks(ctx context.Context, authInfo authorization.Info, message repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error)
+	GetBuildpack(ctx context.Context, authInfo authorization.Info, guid string) (repositories.BuildpackRecord, error)
+	CreateBuildpack(ctx context.Context, authInfo authorization.Info, message repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)
+	UpdateBuildpack(ctx context.Context, authInfo authorization.Info, message repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)
+	UpdateBuildpackImage(ctx context.Context, authInfo authorization.Info, message repositories.UpdateBuildpackImageMessage) (repositories.BuildpackRecord, error)
+	DeleteBuildpack(ctx context.Context, authInfo authorization.Info, guid string) error
+	GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error)
 }
 
 type Buildpack struct {
 	serverURL        url.URL
 	buildpackRepo    BuildpackRepository
+	imageRepo        ImageRepository
 	requestValidator RequestValidator
 }
 
 func NewBuildpack(
 	serverURL url.URL,
 	buildpackRepo BuildpackRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
 ) *Buildpack {
 	return &Buildpack{
 		serverURL:        serverURL,
 		buildpackRepo:    buildpackRepo,
+		imageRepo:        imageRepo,
 		requestValidator: requestValidator,
 	}
 }
 
 func (h *Buildpack) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
-	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.build.list")
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.list")
 
 	payload := new(payloads.BuildpackList)
 	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
@@ -59,6 +73,135 @@ func (h *Buildpack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForBuildpack, buildpacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Buildpack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	buildpack, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch buildpack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.create")
+
+	var payload payloads.BuildpackCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to decode payload")
+	}
+
+	buildpack, err := h.buildpackRepo.CreateBuildpack(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create buildpack", "name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.BuildpackUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to decode payload")
+	}
+
+	_, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch buildpack", "guid", guid)
+	}
+
+	buildpack, err := h.buildpackRepo.UpdateBuildpack(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update buildpack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+// upload accepts either the reference of an existing buildpackage image in
+// the "image" form field, or a buildpack archive in the "bits" form file that
+// is pushed to the registry as a buildpackage image
+func (h *Buildpack) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.upload")
+
+	guid := routing.URLParam(r, "guid")
+
+	buildpack, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch buildpack", "guid", guid)
+	}
+
+	if buildpack.Locked {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Buildpack is locked"), "Cannot upload bits to a locked buildpack", "guid", guid)
+	}
+
+	imageRef, err := h.uploadedImageRef(r, authInfo, buildpack)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to upload buildpack", "guid", guid)
+	}
+
+	buildpack, err = h.buildpackRepo.UpdateBuildpackImage(r.Context(), authInfo, repositories.UpdateBuildpackImageMessage{
+		GUID:  guid,
+		Image: imageRef,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update buildpack image", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) uploadedImageRef(r *http.Request, authInfo authorization.Info, buildpack repositories.BuildpackRecord) (string, error) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return "", apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form")
+	}
+
+	if image := r.FormValue("image"); image != "" {
+		if _, err := name.ParseReference(image); err != nil {
+			return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", image))
+		}
+
+		return image, nil
+	}
+
+	bitsFile, _, err := r.FormFile("bits")
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, "Upload must include either bits or an image")
+	}
+	defer bitsFile.Close()
+
+	return h.imageRepo.UploadBuildpackImage(r.Context(), authInfo, buildpack.ImageRef, bitsFile, buildpack.GUID)
+}
+
+func (h *Buildpack) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch buildpack", "guid", guid)
+	}
+
+	if err = h.buildpackRepo.DeleteBuildpack(r.Context(), authInfo, guid); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete buildpack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(guid, presenter.BuildpackDeleteOperation, h.serverURL)), nil
+}
+
 func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -66,5 +209,10 @@ func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 func (h *Buildpack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: BuildpacksPath, Handler: h.list},
+		{Method: "GET", Pattern: BuildpackPath, Handler: h.get},
+		{Method: "POST", Pattern: BuildpacksPath, Handler: h.create},
+		{Method: "PATCH", Pattern: BuildpackPath, Handler: h.update},
+		{Method: "POST", Pattern: BuildpackUploadPath, Handler: h.upload},
+		{Method: "DELETE", Pattern: BuildpackPath, Handler: h.delete},
 	}
 }
@@ -1,36 +1,69 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Buildpack", func() {
 	var (
 		buildpackRepo    *fake.BuildpackRepository
-		req              *http.Request
+		imageRepo        *fake.ImageRepository
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		contentType      string
 		requestValidator *fake.RequestValidator
+		buildpack        repositories.BuildpackRecord
 	)
 
 	BeforeEach(func() {
+		requestBody = ""
+		contentType = ""
 		buildpackRepo = new(fake.BuildpackRepository)
+		imageRepo = new(fake.ImageRepository)
+
+		buildpack = repositories.BuildpackRecord{
+			GUID:      "buildpack-guid",
+			Name:      "my-buildpack",
+			State:     repositories.BuildpackStateAwaitingUpload,
+			Position:  1,
+			Enabled:   true,
+			ImageRef:  "container.registry/foo/my/prefix-buildpack-guid-buildpack",
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+		buildpackRepo.GetBuildpackReturns(buildpack, nil)
 
 		requestValidator = new(fake.RequestValidator)
-		apiHandler := NewBuildpack(*serverURL, buildpackRepo, requestValidator)
+		apiHandler := NewBuildpack(*serverURL, buildpackRepo, imageRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+		if contentType != "" {
+			req.Header.Add("Content-Type", contentType)
+		}
+
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -39,23 +72,25 @@ var _ = Describe("Buildpack", func() {
 			buildpackRepo.ListBuildpacksReturns([]repositories.BuildpackRecord{
 				{
 					Name:      "paketo-foopacks/bar",
+					Filename:  "paketo-foopacks/bar@1.0.0",
+					State:     repositories.BuildpackStateReady,
 					Position:  1,
 					Stack:     "waffle-house",
 					Version:   "1.0.0",
+					Enabled:   true,
 					CreatedAt: time.UnixMilli(1000),
 					UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
 				},
 			}, nil)
 
-			var err error
-			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/buildpacks", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodGet
+			requestPath = "/v3/buildpacks"
 		})
 
 		It("validates the request", func() {
 			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
 			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
-			Expect(actualReq.URL).To(Equal(req.URL))
+			Expect(actualReq.URL.String()).To(Equal("/v3/buildpacks"))
 		})
 
 		It("returns the buildpacks for the default builder", func() {
@@ -70,6 +105,7 @@ var _ = Describe("Buildpack", func() {
 				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/buildpacks"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].filename", "paketo-foopacks/bar@1.0.0"),
+				MatchJSONPath("$.resources[0].state", "READY"),
 			)))
 		})
 
@@ -83,4 +119,264 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/buildpacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/buildpacks/buildpack-guid"
+		})
+
+		It("returns the buildpack", func() {
+			Expect(buildpackRepo.GetBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := buildpackRepo.GetBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("buildpack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "buildpack-guid"),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/buildpacks/buildpack-guid"),
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/buildpacks/buildpack-guid/upload"),
+			)))
+		})
+
+		When("the user is not authorized to get the buildpack", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/buildpacks endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackCreate{
+				Name:     "my-buildpack",
+				Position: tools.PtrTo(1),
+			})
+			buildpackRepo.CreateBuildpackReturns(buildpack, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/buildpacks"
+			requestBody = "the-json-body"
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the buildpack", func() {
+			Expect(buildpackRepo.CreateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildpackRepo.CreateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-buildpack"))
+			Expect(message.Position).To(Equal(1))
+			Expect(message.Enabled).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "buildpack-guid")))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+				Expect(buildpackRepo.CreateBuildpackCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized to create buildpacks", func() {
+			BeforeEach(func() {
+				buildpackRepo.CreateBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/buildpacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackUpdate{
+				Position: tools.PtrTo(3),
+				Locked:   tools.PtrTo(true),
+			})
+			buildpackRepo.UpdateBuildpackReturns(buildpack, nil)
+
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/buildpacks/buildpack-guid"
+			requestBody = "the-json-body"
+		})
+
+		It("updates the buildpack", func() {
+			Expect(buildpackRepo.UpdateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildpackRepo.UpdateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("buildpack-guid"))
+			Expect(message.Position).To(PointTo(Equal(3)))
+			Expect(message.Locked).To(PointTo(BeTrue()))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "buildpack-guid")))
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+				Expect(buildpackRepo.UpdateBuildpackCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the POST /v3/buildpacks/{guid}/upload endpoint", func() {
+		uploadForm := func(fields map[string]string, files map[string]string) {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			for fieldName, value := range fields {
+				Expect(writer.WriteField(fieldName, value)).To(Succeed())
+			}
+			for fieldName, contents := range files {
+				part, err := writer.CreateFormFile(fieldName, "buildpack.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader(contents))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(writer.Close()).To(Succeed())
+
+			requestBody = b.String()
+			contentType = writer.FormDataContentType()
+		}
+
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/buildpacks/buildpack-guid/upload"
+			uploadForm(nil, map[string]string{"bits": "the-buildpack-contents"})
+
+			imageRepo.UploadBuildpackImageReturns("container.registry/foo/my/prefix-buildpack-guid-buildpack@sha256:some-sha", nil)
+			buildpackRepo.UpdateBuildpackImageReturns(buildpack, nil)
+		})
+
+		It("pushes the buildpack bits and updates the buildpack image", func() {
+			Expect(imageRepo.UploadBuildpackImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, bitsReader, actualTags := imageRepo.UploadBuildpackImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("container.registry/foo/my/prefix-buildpack-guid-buildpack"))
+			actualContents, err := io.ReadAll(bitsReader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContents)).To(Equal("the-buildpack-contents"))
+			Expect(actualTags).To(ConsistOf("buildpack-guid"))
+
+			Expect(buildpackRepo.UpdateBuildpackImageCallCount()).To(Equal(1))
+			_, _, message := buildpackRepo.UpdateBuildpackImageArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateBuildpackImageMessage{
+				GUID:  "buildpack-guid",
+				Image: "container.registry/foo/my/prefix-buildpack-guid-buildpack@sha256:some-sha",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "buildpack-guid")))
+		})
+
+		When("an image is provided instead of bits", func() {
+			BeforeEach(func() {
+				uploadForm(map[string]string{"image": "gcr.io/paketo-buildpacks/java:1.2.3"}, nil)
+			})
+
+			It("uses the image as is", func() {
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+
+				Expect(buildpackRepo.UpdateBuildpackImageCallCount()).To(Equal(1))
+				_, _, message := buildpackRepo.UpdateBuildpackImageArgsForCall(0)
+				Expect(message.Image).To(Equal("gcr.io/paketo-buildpacks/java:1.2.3"))
+			})
+		})
+
+		When("the image is not a valid reference", func() {
+			BeforeEach(func() {
+				uploadForm(map[string]string{"image": "NOT%A%REF"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`invalid image ref: "NOT%A%REF"`)
+				Expect(buildpackRepo.UpdateBuildpackImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("neither bits nor an image are provided", func() {
+			BeforeEach(func() {
+				uploadForm(nil, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("the buildpack is locked", func() {
+			BeforeEach(func() {
+				buildpack.Locked = true
+				buildpackRepo.GetBuildpackReturns(buildpack, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Buildpack is locked")
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+				Expect(buildpackRepo.UpdateBuildpackImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("pushing the bits fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadBuildpackImageReturns("", errors.New("push-failed"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(buildpackRepo.UpdateBuildpackImageCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/buildpacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/buildpacks/buildpack-guid"
+		})
+
+		It("deletes the buildpack", func() {
+			Expect(buildpackRepo.DeleteBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := buildpackRepo.DeleteBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("buildpack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/buildpack.delete~buildpack-guid"))
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+				Expect(buildpackRepo.DeleteBuildpackCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -17,8 +17,10 @@ import (
 )
 
 const (
-	DropletsPath = "/v3/droplets"
-	DropletPath  = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -26,23 +28,35 @@ type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletImage(context.Context, authorization.Info, repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error)
+	CopyDroplet(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)
 }
 
 type Droplet struct {
-	serverURL        url.URL
-	dropletRepo      CFDropletRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	dropletRepo         CFDropletRepository
+	appRepo             CFAppRepository
+	imageRepo           ImageRepository
+	requestValidator    RequestValidator
+	registrySecretNames []string
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
+	registrySecretNames []string,
 ) *Droplet {
 	return &Droplet{
-		serverURL:        serverURL,
-		dropletRepo:      dropletRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		dropletRepo:         dropletRepo,
+		appRepo:             appRepo,
+		imageRepo:           imageRepo,
+		requestValidator:    requestValidator,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -112,6 +126,174 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	if sourceGUID := r.URL.Query().Get("source_guid"); sourceGUID != "" {
+		return h.copy(r, sourceGUID)
+	}
+
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.getApp(r, authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.Relationships.App.Data.GUID)
+	}
+
+	if appRecord.Lifecycle.Type != "buildpack" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplets can only be created for buildpack apps."),
+			"Cannot create droplet for app", "App GUID", appRecord.GUID, "lifecycle", appRecord.Lifecycle.Type,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.copy")
+
+	var payload payloads.DropletCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceDroplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source droplet is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source droplet", "guid", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.getApp(r, authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.Relationships.App.Data.GUID)
+	}
+
+	if appRecord.Lifecycle.Type != sourceDroplet.Lifecycle.Type {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot copy a %s droplet to a %s app.", sourceDroplet.Lifecycle.Type, appRecord.Lifecycle.Type)),
+			"Droplet and app lifecycle types do not match", "guid", sourceGUID, "App GUID", appRecord.GUID,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CopyDroplet(r.Context(), authInfo, payload.ToMessage(sourceGUID, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying droplet with repository", "guid", sourceGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) getApp(r *http.Request, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.AsUnprocessableEntity(
+			err,
+			"App is invalid. Ensure it exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return appRecord, nil
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	bitsFile, _, err := r.FormFile("bits")
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	}
+	defer bitsFile.Close()
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository", "guid", dropletGUID)
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet bits can only be uploaded to droplets awaiting upload."),
+			"Droplet is not awaiting upload", "guid", dropletGUID, "state", droplet.State,
+		)
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.RepositoryRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadDropletImage", "guid", dropletGUID)
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletImage(r.Context(), authInfo, repositories.UpdateDropletImageMessage{
+		GUID:                dropletGUID,
+		ImageRef:            uploadedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletImage", "guid", dropletGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(dropletGUID, presenter.DropletUploadOperation, h.serverURL)).
+		WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository", "guid", dropletGUID)
+	}
+
+	if droplet.Lifecycle.Type != "buildpack" || droplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Only staged buildpack droplets can be downloaded."),
+			"Droplet cannot be downloaded", "guid", dropletGUID, "state", droplet.State,
+		)
+	}
+
+	imageReader, err := h.imageRepo.DownloadDropletImage(r.Context(), authInfo, droplet.ImageRef)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadDropletImage", "guid", dropletGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tar")).
+		WithStreamedBody("application/x-tar", imageReader), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -121,5 +303,8 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "GET", Pattern: DropletsPath, Handler: h.list},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
@@ -1,7 +1,10 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
@@ -30,15 +33,23 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 		err              error
 		reqPath          string
 		reqMethod        string
+		reqBody          io.Reader
+		reqContentType   string
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.ImageRepository)
 		requestValidator = new(fake.RequestValidator)
+		reqBody = strings.NewReader("the-json-body")
+		reqContentType = ""
 
 		appGUID = "test-app-guid"
 		packageGUID = "test-package-guid"
@@ -48,14 +59,20 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			[]string{"registry-secret"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
-		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, strings.NewReader("the-json-body"))
+		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, reqBody)
 		Expect(err).NotTo(HaveOccurred())
+		if reqContentType != "" {
+			req.Header.Set("Content-Type", reqContentType)
+		}
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -294,4 +311,386 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:    dropletGUID,
+				State:   repositories.DropletStateAwaitingUpload,
+				AppGUID: appGUID,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+				},
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCreate{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: appGUID}},
+				},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			})
+
+			reqMethod = http.MethodPost
+			reqPath = "/v3/droplets"
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the droplet", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUID).To(Equal(appGUID))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/droplets/"+dropletGUID+"/upload"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the app is not a buildpack app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplets can only be created for buildpack apps.")
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("create-droplet-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the source_guid query parameter is set", func() {
+			BeforeEach(func() {
+				reqPath = "/v3/droplets?source_guid=source-droplet-guid"
+
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      "source-droplet-guid",
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+
+				dropletRepo.CopyDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateStaged,
+					AppGUID:   appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCopy{
+					Relationships: &payloads.DropletRelationships{
+						App: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: appGUID}},
+					},
+				})
+			})
+
+			It("copies the droplet to the app", func() {
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualSourceGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualSourceGUID).To(Equal("source-droplet-guid"))
+
+				Expect(dropletRepo.CopyDropletCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := dropletRepo.CopyDropletArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.CopyDropletMessage{
+					SourceGUID: "source-droplet-guid",
+					AppGUID:    appGUID,
+					SpaceGUID:  "space-guid",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", dropletGUID)))
+			})
+
+			When("the source droplet is forbidden", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet is invalid. Ensure it exists and you have access to it.")
+				})
+			})
+
+			When("the lifecycle types do not match", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						GUID:      appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot copy a buildpack droplet to a docker app.")
+				})
+			})
+
+			When("copying the droplet fails", func() {
+				BeforeEach(func() {
+					dropletRepo.CopyDropletReturns(repositories.DropletRecord{}, errors.New("copy-droplet-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:          dropletGUID,
+				State:         repositories.DropletStateAwaitingUpload,
+				SpaceGUID:     "space-guid",
+				RepositoryRef: "registry.repo/app-droplets",
+				Lifecycle:     repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			imageRepo.UploadDropletImageReturns("registry.repo/app-droplets@sha256:abc", nil)
+
+			dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     repositories.DropletStateProcessingUpload,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			part, err := writer.CreateFormFile("bits", "droplet.tar")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = io.Copy(part, strings.NewReader("the-droplet-tarball"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			reqBody = &b
+			reqContentType = writer.FormDataContentType()
+			reqMethod = http.MethodPost
+			reqPath = "/v3/droplets/" + dropletGUID + "/upload"
+		})
+
+		It("uploads the droplet image", func() {
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, tarball, spaceGUID, tags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/app-droplets"))
+			tarballContents, err := io.ReadAll(tarball)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(tarballContents)).To(Equal("the-droplet-tarball"))
+			Expect(spaceGUID).To(Equal("space-guid"))
+			Expect(tags).To(ConsistOf(dropletGUID))
+		})
+
+		It("updates the droplet image", func() {
+			Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateDropletImageMessage{
+				GUID:                dropletGUID,
+				ImageRef:            "registry.repo/app-droplets@sha256:abc",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+		})
+
+		It("returns an upload job", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/droplet.upload~"+dropletGUID))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+			)))
+		})
+
+		When("the droplet is not awaiting upload", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: repositories.DropletStateStaged,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet bits can only be uploaded to droplets awaiting upload.")
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("access to the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Droplet")
+			})
+		})
+
+		When("no bits are given", func() {
+			BeforeEach(func() {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				Expect(writer.Close()).To(Succeed())
+
+				reqBody = &b
+				reqContentType = writer.FormDataContentType()
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include bits")
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", errors.New("upload-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the droplet image fails", func() {
+			BeforeEach(func() {
+				dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{}, errors.New("update-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     repositories.DropletStateStaged,
+				ImageRef:  "registry.repo/app-droplets@sha256:abc",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			imageRepo.DownloadDropletImageReturns(io.NopCloser(strings.NewReader("the-droplet-tarball")), nil)
+
+			reqMethod = http.MethodGet
+			reqPath = "/v3/droplets/" + dropletGUID + "/download"
+		})
+
+		It("streams the droplet image", func() {
+			Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, imageRef := imageRepo.DownloadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(imageRef).To(Equal("registry.repo/app-droplets@sha256:abc"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", `attachment; filename="`+dropletGUID+`.tar"`))
+			Expect(rr).To(HaveHTTPBody("the-droplet-tarball"))
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateAwaitingUpload,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged buildpack droplets can be downloaded.")
+			})
+		})
+
+		When("the droplet is a docker droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged buildpack droplets can be downloaded.")
+			})
+		})
+
+		When("access to the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Droplet")
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletImageReturns(nil, errors.New("download-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,146 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("EnvVarGroup", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		envVarGroupRepo  *fake.CFEnvVarGroupRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		envVarGroupRepo = new(fake.CFEnvVarGroupRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+			Name:                 "running",
+			EnvironmentVariables: map[string]string{"HTTP_PROXY": "http://proxy"},
+		}, nil)
+
+		apiHandler := NewEnvVarGroup(
+			*serverURL,
+			requestValidator,
+			envVarGroupRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/environment_variable_groups/{name}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/environment_variable_groups/running"
+		})
+
+		It("returns the environment variable group", func() {
+			Expect(envVarGroupRepo.GetEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := envVarGroupRepo.GetEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("running"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "running"),
+				MatchJSONPath("$.var.HTTP_PROXY", "http://proxy"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/environment_variable_groups/running"),
+			)))
+		})
+
+		When("the user is not authorized to read the group", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.EnvVarGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.EnvVarGroupResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/environment_variable_groups/{name}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/environment_variable_groups/staging"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.EnvVarGroupPatch{
+				Var: map[string]any{
+					"HTTP_PROXY": "http://new-proxy",
+					"NO_PROXY":   nil,
+				},
+			})
+			envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+				Name:                 "staging",
+				EnvironmentVariables: map[string]string{"HTTP_PROXY": "http://new-proxy"},
+			}, nil)
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("patches the environment variable group", func() {
+			Expect(envVarGroupRepo.PatchEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := envVarGroupRepo.PatchEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("staging"))
+			Expect(message.EnvironmentVariables).To(MatchAllKeys(Keys{
+				"HTTP_PROXY": PointTo(Equal("http://new-proxy")),
+				"NO_PROXY":   BeNil(),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "staging"),
+				MatchJSONPath("$.var.HTTP_PROXY", "http://new-proxy"),
+			)))
+		})
+
+		When("the user is not authorized to patch the group", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.EnvVarGroupResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("patching fails", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
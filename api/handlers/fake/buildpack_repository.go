@@ -4,6 +4,7 @@ package fake
 import (
 	"context"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/handlers"
@@ -11,6 +12,64 @@ import (
 )
 
 type BuildpackRepository struct {
+	CreateBuildpackStub        func(context.Context, authorization.Info, repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)
+	createBuildpackMutex       sync.RWMutex
+	createBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateBuildpackMessage
+	}
+	createBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	createBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	DeleteBuildpackStub        func(context.Context, authorization.Info, string) error
+	deleteBuildpackMutex       sync.RWMutex
+	deleteBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteBuildpackReturns struct {
+		result1 error
+	}
+	deleteBuildpackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetBuildpackStub        func(context.Context, authorization.Info, string) (repositories.BuildpackRecord, error)
+	getBuildpackMutex       sync.RWMutex
+	getBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	getBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	GetDeletedAtStub        func(context.Context, authorization.Info, string) (*time.Time, error)
+	getDeletedAtMutex       sync.RWMutex
+	getDeletedAtArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getDeletedAtReturns struct {
+		result1 *time.Time
+		result2 error
+	}
+	getDeletedAtReturnsOnCall map[int]struct {
+		result1 *time.Time
+		result2 error
+	}
 	ListBuildpacksStub        func(context.Context, authorization.Info, repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error)
 	listBuildpacksMutex       sync.RWMutex
 	listBuildpacksArgsForCall []struct {
@@ -26,10 +85,301 @@ type BuildpackRepository struct {
 		result1 []repositories.BuildpackRecord
 		result2 error
 	}
+	UpdateBuildpackStub        func(context.Context, authorization.Info, repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)
+	updateBuildpackMutex       sync.RWMutex
+	updateBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackMessage
+	}
+	updateBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	updateBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	UpdateBuildpackImageStub        func(context.Context, authorization.Info, repositories.UpdateBuildpackImageMessage) (repositories.BuildpackRecord, error)
+	updateBuildpackImageMutex       sync.RWMutex
+	updateBuildpackImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackImageMessage
+	}
+	updateBuildpackImageReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	updateBuildpackImageReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *BuildpackRepository) CreateBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error) {
+	fake.createBuildpackMutex.Lock()
+	ret, specificReturn := fake.createBuildpackReturnsOnCall[len(fake.createBuildpackArgsForCall)]
+	fake.createBuildpackArgsForCall = append(fake.createBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateBuildpackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateBuildpackStub
+	fakeReturns := fake.createBuildpackReturns
+	fake.recordInvocation("CreateBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.createBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) CreateBuildpackCallCount() int {
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	return len(fake.createBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) CreateBuildpackCalls(stub func(context.Context, authorization.Info, repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) CreateBuildpackArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateBuildpackMessage) {
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	argsForCall := fake.createBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) CreateBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = nil
+	fake.createBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) CreateBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = nil
+	if fake.createBuildpackReturnsOnCall == nil {
+		fake.createBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.createBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) DeleteBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteBuildpackMutex.Lock()
+	ret, specificReturn := fake.deleteBuildpackReturnsOnCall[len(fake.deleteBuildpackArgsForCall)]
+	fake.deleteBuildpackArgsForCall = append(fake.deleteBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteBuildpackStub
+	fakeReturns := fake.deleteBuildpackReturns
+	fake.recordInvocation("DeleteBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.deleteBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackCallCount() int {
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	return len(fake.deleteBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	argsForCall := fake.deleteBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackReturns(result1 error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = nil
+	fake.deleteBuildpackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackReturnsOnCall(i int, result1 error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = nil
+	if fake.deleteBuildpackReturnsOnCall == nil {
+		fake.deleteBuildpackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBuildpackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *BuildpackRepository) GetBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.BuildpackRecord, error) {
+	fake.getBuildpackMutex.Lock()
+	ret, specificReturn := fake.getBuildpackReturnsOnCall[len(fake.getBuildpackArgsForCall)]
+	fake.getBuildpackArgsForCall = append(fake.getBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetBuildpackStub
+	fakeReturns := fake.getBuildpackReturns
+	fake.recordInvocation("GetBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.getBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) GetBuildpackCallCount() int {
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
+	return len(fake.getBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) GetBuildpackCalls(stub func(context.Context, authorization.Info, string) (repositories.BuildpackRecord, error)) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) GetBuildpackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
+	argsForCall := fake.getBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) GetBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = nil
+	fake.getBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) GetBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = nil
+	if fake.getBuildpackReturnsOnCall == nil {
+		fake.getBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.getBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) GetDeletedAt(arg1 context.Context, arg2 authorization.Info, arg3 string) (*time.Time, error) {
+	fake.getDeletedAtMutex.Lock()
+	ret, specificReturn := fake.getDeletedAtReturnsOnCall[len(fake.getDeletedAtArgsForCall)]
+	fake.getDeletedAtArgsForCall = append(fake.getDeletedAtArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetDeletedAtStub
+	fakeReturns := fake.getDeletedAtReturns
+	fake.recordInvocation("GetDeletedAt", []interface{}{arg1, arg2, arg3})
+	fake.getDeletedAtMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) GetDeletedAtCallCount() int {
+	fake.getDeletedAtMutex.RLock()
+	defer fake.getDeletedAtMutex.RUnlock()
+	return len(fake.getDeletedAtArgsForCall)
+}
+
+func (fake *BuildpackRepository) GetDeletedAtCalls(stub func(context.Context, authorization.Info, string) (*time.Time, error)) {
+	fake.getDeletedAtMutex.Lock()
+	defer fake.getDeletedAtMutex.Unlock()
+	fake.GetDeletedAtStub = stub
+}
+
+func (fake *BuildpackRepository) GetDeletedAtArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getDeletedAtMutex.RLock()
+	defer fake.getDeletedAtMutex.RUnlock()
+	argsForCall := fake.getDeletedAtArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) GetDeletedAtReturns(result1 *time.Time, result2 error) {
+	fake.getDeletedAtMutex.Lock()
+	defer fake.getDeletedAtMutex.Unlock()
+	fake.GetDeletedAtStub = nil
+	fake.getDeletedAtReturns = struct {
+		result1 *time.Time
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) GetDeletedAtReturnsOnCall(i int, result1 *time.Time, result2 error) {
+	fake.getDeletedAtMutex.Lock()
+	defer fake.getDeletedAtMutex.Unlock()
+	fake.GetDeletedAtStub = nil
+	if fake.getDeletedAtReturnsOnCall == nil {
+		fake.getDeletedAtReturnsOnCall = make(map[int]struct {
+			result1 *time.Time
+			result2 error
+		})
+	}
+	fake.getDeletedAtReturnsOnCall[i] = struct {
+		result1 *time.Time
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) ListBuildpacks(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error) {
 	fake.listBuildpacksMutex.Lock()
 	ret, specificReturn := fake.listBuildpacksReturnsOnCall[len(fake.listBuildpacksArgsForCall)]
@@ -96,11 +446,155 @@ func (fake *BuildpackRepository) ListBuildpacksReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *BuildpackRepository) UpdateBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error) {
+	fake.updateBuildpackMutex.Lock()
+	ret, specificReturn := fake.updateBuildpackReturnsOnCall[len(fake.updateBuildpackArgsForCall)]
+	fake.updateBuildpackArgsForCall = append(fake.updateBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateBuildpackStub
+	fakeReturns := fake.updateBuildpackReturns
+	fake.recordInvocation("UpdateBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.updateBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackCallCount() int {
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	return len(fake.updateBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackCalls(stub func(context.Context, authorization.Info, repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateBuildpackMessage) {
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	argsForCall := fake.updateBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = nil
+	fake.updateBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = nil
+	if fake.updateBuildpackReturnsOnCall == nil {
+		fake.updateBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.updateBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateBuildpackImageMessage) (repositories.BuildpackRecord, error) {
+	fake.updateBuildpackImageMutex.Lock()
+	ret, specificReturn := fake.updateBuildpackImageReturnsOnCall[len(fake.updateBuildpackImageArgsForCall)]
+	fake.updateBuildpackImageArgsForCall = append(fake.updateBuildpackImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackImageMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateBuildpackImageStub
+	fakeReturns := fake.updateBuildpackImageReturns
+	fake.recordInvocation("UpdateBuildpackImage", []interface{}{arg1, arg2, arg3})
+	fake.updateBuildpackImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImageCallCount() int {
+	fake.updateBuildpackImageMutex.RLock()
+	defer fake.updateBuildpackImageMutex.RUnlock()
+	return len(fake.updateBuildpackImageArgsForCall)
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImageCalls(stub func(context.Context, authorization.Info, repositories.UpdateBuildpackImageMessage) (repositories.BuildpackRecord, error)) {
+	fake.updateBuildpackImageMutex.Lock()
+	defer fake.updateBuildpackImageMutex.Unlock()
+	fake.UpdateBuildpackImageStub = stub
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImageArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateBuildpackImageMessage) {
+	fake.updateBuildpackImageMutex.RLock()
+	defer fake.updateBuildpackImageMutex.RUnlock()
+	argsForCall := fake.updateBuildpackImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImageReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackImageMutex.Lock()
+	defer fake.updateBuildpackImageMutex.Unlock()
+	fake.UpdateBuildpackImageStub = nil
+	fake.updateBuildpackImageReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackImageReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackImageMutex.Lock()
+	defer fake.updateBuildpackImageMutex.Unlock()
+	fake.UpdateBuildpackImageStub = nil
+	if fake.updateBuildpackImageReturnsOnCall == nil {
+		fake.updateBuildpackImageReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.updateBuildpackImageReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
+	fake.getDeletedAtMutex.RLock()
+	defer fake.getDeletedAtMutex.RUnlock()
 	fake.listBuildpacksMutex.RLock()
 	defer fake.listBuildpacksMutex.RUnlock()
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	fake.updateBuildpackImageMutex.RLock()
+	defer fake.updateBuildpackImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -26,6 +26,19 @@ type CFPackageRepository struct {
 		result1 repositories.PackageRecord
 		result2 error
 	}
+	DeletePackageStub        func(context.Context, authorization.Info, repositories.DeletePackageMessage) error
+	deletePackageMutex       sync.RWMutex
+	deletePackageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePackageMessage
+	}
+	deletePackageReturns struct {
+		result1 error
+	}
+	deletePackageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetPackageStub        func(context.Context, authorization.Info, string) (repositories.PackageRecord, error)
 	getPackageMutex       sync.RWMutex
 	getPackageArgsForCall []struct {
@@ -156,6 +169,69 @@ func (fake *CFPackageRepository) CreatePackageReturnsOnCall(i int, result1 repos
 	}{result1, result2}
 }
 
+func (fake *CFPackageRepository) DeletePackage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeletePackageMessage) error {
+	fake.deletePackageMutex.Lock()
+	ret, specificReturn := fake.deletePackageReturnsOnCall[len(fake.deletePackageArgsForCall)]
+	fake.deletePackageArgsForCall = append(fake.deletePackageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePackageMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeletePackageStub
+	fakeReturns := fake.deletePackageReturns
+	fake.recordInvocation("DeletePackage", []interface{}{arg1, arg2, arg3})
+	fake.deletePackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFPackageRepository) DeletePackageCallCount() int {
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
+	return len(fake.deletePackageArgsForCall)
+}
+
+func (fake *CFPackageRepository) DeletePackageCalls(stub func(context.Context, authorization.Info, repositories.DeletePackageMessage) error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = stub
+}
+
+func (fake *CFPackageRepository) DeletePackageArgsForCall(i int) (context.Context, authorization.Info, repositories.DeletePackageMessage) {
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
+	argsForCall := fake.deletePackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFPackageRepository) DeletePackageReturns(result1 error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = nil
+	fake.deletePackageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFPackageRepository) DeletePackageReturnsOnCall(i int, result1 error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = nil
+	if fake.deletePackageReturnsOnCall == nil {
+		fake.deletePackageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePackageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFPackageRepository) GetPackage(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.PackageRecord, error) {
 	fake.getPackageMutex.Lock()
 	ret, specificReturn := fake.getPackageReturnsOnCall[len(fake.getPackageArgsForCall)]
@@ -425,6 +501,8 @@ func (fake *CFPackageRepository) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createPackageMutex.RLock()
 	defer fake.createPackageMutex.RUnlock()
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
 	fake.getPackageMutex.RLock()
 	defer fake.getPackageMutex.RUnlock()
 	fake.listPackagesMutex.RLock()
@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	IsolationSegmentsPath                 = "/v3/isolation_segments"
+	IsolationSegmentPath                  = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrganizationsPath     = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrganizationPath      = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	SpaceIsolationSegmentRelationshipPath = "/v3/spaces/{guid}/relationships/isolation_segment"
+)
+
+//counterfeiter:generate -o fake -fake-name CFIsolationSegmentRepository . CFIsolationSegmentRepository
+
+type CFIsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	UpdateIsolationSegment(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+	EntitleOrganizations(context.Context, authorization.Info, string, []string) (repositories.IsolationSegmentRecord, error)
+	RevokeOrganization(context.Context, authorization.Info, string, string) error
+}
+
+type IsolationSegment struct {
+	serverURL            url.URL
+	requestValidator     RequestValidator
+	isolationSegmentRepo CFIsolationSegmentRepository
+	orgRepo              CFOrgRepository
+	spaceRepo            CFSpaceRepository
+}
+
+func NewIsolationSegment(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	isolationSegmentRepo CFIsolationSegmentRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo CFSpaceRepository,
+) *IsolationSegment {
+	return &IsolationSegment{
+		serverURL:            serverURL,
+		requestValidator:     requestValidator,
+		isolationSegmentRepo: isolationSegmentRepo,
+		orgRepo:              orgRepo,
+		spaceRepo:            spaceRepo,
+	}
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	var payload payloads.IsolationSegmentCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create isolation segment", "name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	payload := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segments")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForIsolationSegment, isolationSegments, h.serverURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.UpdateIsolationSegment(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	if len(isolationSegment.OrganizationGUIDs) > 0 {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Revoke the Organization entitlements for your Isolation Segment."),
+			"isolation segment is still entitled to organizations", "guid", guid,
+		)
+	}
+
+	err = h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listOrganizations(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-organizations")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) entitleOrganizations(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle-organizations")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentEntitleOrganizations
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	orgGUIDs := payload.OrganizationGUIDs()
+	orgs, err := h.orgRepo.ListOrgs(r.Context(), authInfo, repositories.ListOrgsMessage{GUIDs: orgGUIDs})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list organizations")
+	}
+
+	if len(orgs) != len(orgGUIDs) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Organizations could not be found or you do not have access to them."),
+			"some organizations could not be found", "guids", orgGUIDs,
+		)
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.EntitleOrganizations(r.Context(), authInfo, guid, orgGUIDs)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to entitle organizations", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) revokeOrganization(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke-organization")
+
+	guid := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", guid)
+	}
+
+	err = h.isolationSegmentRepo.RevokeOrganization(r.Context(), authInfo, guid, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to revoke organization", "guid", guid, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) getSpaceIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-space-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) setSpaceIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.set-space-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceIsolationSegmentPatch
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "spaceGUID", spaceGUID)
+	}
+
+	isolationSegmentGUID := payload.IsolationSegmentGUID()
+	if isolationSegmentGUID != "" {
+		isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, notEntitledMessage(isolationSegmentGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{}),
+				"failed to get isolation segment", "guid", isolationSegmentGUID,
+			)
+		}
+
+		if !isolationSegment.IsEntitled(space.OrganizationGUID) {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(errors.New("isolation segment not entitled"), notEntitledMessage(isolationSegmentGUID)),
+				"isolation segment is not entitled to the space organization", "guid", isolationSegmentGUID, "orgGUID", space.OrganizationGUID,
+			)
+		}
+	}
+
+	space, err = h.spaceRepo.PatchSpaceIsolationSegment(r.Context(), authInfo, repositories.PatchSpaceIsolationSegmentMessage{
+		GUID:                 space.GUID,
+		OrgGUID:              space.OrganizationGUID,
+		IsolationSegmentGUID: isolationSegmentGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to set space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.serverURL)), nil
+}
+
+func notEntitledMessage(isolationSegmentGUID string) string {
+	return fmt.Sprintf("Unable to assign isolation segment with guid '%s'. Ensure it has been entitled to the organization that this space belongs to.", isolationSegmentGUID)
+}
+
+func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
+		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "PATCH", Pattern: IsolationSegmentPath, Handler: h.update},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "GET", Pattern: IsolationSegmentOrganizationsPath, Handler: h.listOrganizations},
+		{Method: "POST", Pattern: IsolationSegmentOrganizationsPath, Handler: h.entitleOrganizations},
+		{Method: "DELETE", Pattern: IsolationSegmentOrganizationPath, Handler: h.revokeOrganization},
+		{Method: "GET", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.getSpaceIsolationSegment},
+		{Method: "PATCH", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.setSpaceIsolationSegment},
+	}
+}
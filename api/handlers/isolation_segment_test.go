@@ -0,0 +1,426 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		requestMethod        string
+		requestPath          string
+		requestBody          string
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		orgRepo              *fake.CFOrgRepository
+		spaceRepo            *fake.CFSpaceRepository
+		requestValidator     *fake.RequestValidator
+		isolationSegment     repositories.IsolationSegmentRecord
+	)
+
+	BeforeEach(func() {
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		isolationSegment = repositories.IsolationSegmentRecord{
+			GUID: "iso-seg-guid",
+			Name: "my-segment",
+			Placement: repositories.IsolationSegmentPlacement{
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []repositories.IsolationSegmentToleration{{
+					Key:      "dedicated",
+					Operator: "Equal",
+					Value:    "regulated",
+					Effect:   "NoSchedule",
+				}},
+			},
+			OrganizationGUIDs: []string{"org-guid"},
+		}
+		isolationSegmentRepo.GetIsolationSegmentReturns(isolationSegment, nil)
+
+		apiHandler := NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/isolation_segments"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "my-segment",
+				Placement: payloads.IsolationSegmentPlacement{
+					NodeSelector: map[string]string{"pool": "regulated"},
+				},
+			})
+			isolationSegmentRepo.CreateIsolationSegmentReturns(isolationSegment, nil)
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-segment"))
+			Expect(message.Placement.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "iso-seg-guid"),
+				MatchJSONPath("$.name", "my-segment"),
+				MatchJSONPath("$.placement.node_selector.pool", "regulated"),
+				MatchJSONPath("$.placement.tolerations[0].key", "dedicated"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+				MatchJSONPath("$.links.organizations.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"),
+			)))
+		})
+
+		When("the repository returns a uniqueness error", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewUniquenessError(nil, "already exists"))
+			})
+
+			It("returns a uniqueness error", func() {
+				expectErrorResponse(http.StatusUnprocessableEntity, "CF-UniquenessError", "already exists", 10016)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/isolation_segments/iso-seg-guid"
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "iso-seg-guid")))
+		})
+
+		When("the user is not authorized to get the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/isolation_segments?names=my-segment"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				Names: "my-segment",
+			})
+			isolationSegmentRepo.ListIsolationSegmentsReturns([]repositories.IsolationSegmentRecord{isolationSegment}, nil)
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, _, message := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(message.Names).To(ConsistOf("my-segment"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "iso-seg-guid"),
+			)))
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/isolation_segments/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/isolation_segments/iso-seg-guid"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+			isolationSegmentRepo.UpdateIsolationSegmentReturns(isolationSegment, nil)
+		})
+
+		It("updates the isolation segment", func() {
+			Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(Equal(1))
+			_, _, message := isolationSegmentRepo.UpdateIsolationSegmentArgsForCall(0)
+			Expect(message.GUID).To(Equal("iso-seg-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+			Expect(message.Placement).To(BeNil())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "iso-seg-guid")))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/isolation_segments/iso-seg-guid"
+
+			isolationSegment.OrganizationGUIDs = nil
+			isolationSegmentRepo.GetIsolationSegmentReturns(isolationSegment, nil)
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is still entitled to organizations", func() {
+			BeforeEach(func() {
+				isolationSegment.OrganizationGUIDs = []string{"org-guid"}
+				isolationSegmentRepo.GetIsolationSegmentReturns(isolationSegment, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Revoke the Organization entitlements for your Isolation Segment.")
+				Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/{guid}/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/isolation_segments/iso-seg-guid/relationships/organizations"
+		})
+
+		It("returns the entitled organizations", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/organizations"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"),
+			)))
+		})
+	})
+
+	Describe("POST /v3/isolation_segments/{guid}/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/isolation_segments/iso-seg-guid/relationships/organizations"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitleOrganizations{
+				Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+			})
+			orgRepo.ListOrgsReturns([]repositories.OrgRecord{{GUID: "org-guid"}}, nil)
+			isolationSegmentRepo.EntitleOrganizationsReturns(isolationSegment, nil)
+		})
+
+		It("entitles the organizations", func() {
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+			_, _, listOrgsMessage := orgRepo.ListOrgsArgsForCall(0)
+			Expect(listOrgsMessage.GUIDs).To(ConsistOf("org-guid"))
+
+			Expect(isolationSegmentRepo.EntitleOrganizationsCallCount()).To(Equal(1))
+			_, _, actualGUID, actualOrgGUIDs := isolationSegmentRepo.EntitleOrganizationsArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+			Expect(actualOrgGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[0].guid", "org-guid")))
+		})
+
+		When("some organizations cannot be found", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(nil, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organizations could not be found or you do not have access to them.")
+				Expect(isolationSegmentRepo.EntitleOrganizationsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/{guid}/relationships/organizations/{org_guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/isolation_segments/iso-seg-guid/relationships/organizations/org-guid"
+		})
+
+		It("revokes the organization", func() {
+			Expect(isolationSegmentRepo.RevokeOrganizationCallCount()).To(Equal(1))
+			_, _, actualGUID, actualOrgGUID := isolationSegmentRepo.RevokeOrganizationArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is still assigned to spaces of the organization", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.RevokeOrganizationReturns(apierrors.NewUnprocessableEntityError(nil, "Currently assigned to: my-space"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Currently assigned to: my-space")
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/{guid}/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/spaces/space-guid/relationships/isolation_segment"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}, nil)
+		})
+
+		It("returns the space isolation segment relationship", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "iso-seg-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+			)))
+		})
+
+		When("the space has no isolation segment", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid"}, nil)
+			})
+
+			It("returns null data", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/{guid}/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/spaces/space-guid/relationships/isolation_segment"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceIsolationSegmentPatch{
+				Data: &payloads.RelationshipData{GUID: "iso-seg-guid"},
+			})
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:             "space-guid",
+				OrganizationGUID: "org-guid",
+			}, nil)
+			spaceRepo.PatchSpaceIsolationSegmentReturns(repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}, nil)
+		})
+
+		It("assigns the isolation segment to the space", func() {
+			Expect(spaceRepo.PatchSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, _, message := spaceRepo.PatchSpaceIsolationSegmentArgsForCall(0)
+			Expect(message).To(Equal(repositories.PatchSpaceIsolationSegmentMessage{
+				GUID:                 "space-guid",
+				OrgGUID:              "org-guid",
+				IsolationSegmentGUID: "iso-seg-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "iso-seg-guid")))
+		})
+
+		When("the isolation segment is not entitled to the space organization", func() {
+			BeforeEach(func() {
+				isolationSegment.OrganizationGUIDs = []string{"another-org-guid"}
+				isolationSegmentRepo.GetIsolationSegmentReturns(isolationSegment, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to assign isolation segment with guid 'iso-seg-guid'. Ensure it has been entitled to the organization that this space belongs to.")
+				Expect(spaceRepo.PatchSpaceIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to assign isolation segment with guid 'iso-seg-guid'.")
+			})
+		})
+
+		When("the isolation segment is being unassigned", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceIsolationSegmentPatch{})
+			})
+
+			It("clears the space isolation segment without looking it up", func() {
+				Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(BeZero())
+				Expect(spaceRepo.PatchSpaceIsolationSegmentCallCount()).To(Equal(1))
+				_, _, message := spaceRepo.PatchSpaceIsolationSegmentArgsForCall(0)
+				Expect(message.IsolationSegmentGUID).To(BeEmpty())
+			})
+		})
+	})
+})
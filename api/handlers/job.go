@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -27,6 +28,10 @@ const (
 	SpaceDeleteJobType                  = "space.delete"
 	DomainDeleteJobType                 = "domain.delete"
 	RoleDeleteJobType                   = "role.delete"
+	UserDeleteJobType                   = "user.delete"
+	BuildpackDeleteJobType              = "buildpack.delete"
+	StackDeleteJobType                  = "stack.delete"
+	DropletUploadJobType                = "droplet.upload"
 	ServiceBrokerCreateJobType          = "service_broker.create"
 	ServiceBrokerUpdateJobType          = "service_broker.update"
 	ServiceBrokerDeleteJobType          = "service_broker.delete"
@@ -89,8 +94,10 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 
 	switch job.Type {
 	case syncSpaceJobType:
+		metrics.JobPollsTotal.WithLabelValues(job.Type).Inc()
 		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForManifestApplyJob(job, h.serverURL)), nil
 	case spaceDeleteUnmappedRoutesJobType:
+		metrics.JobPollsTotal.WithLabelValues(job.Type).Inc()
 		authInfo, _ := authorization.InfoFromContext(ctx)
 		state := repositories.ResourceStateReady
 
@@ -108,6 +115,7 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 	default:
 		deletionRepository, ok := h.deletionRepositories[job.Type]
 		if ok {
+			metrics.JobPollsTotal.WithLabelValues(job.Type).Inc()
 			jobResponse, err := h.handleDeleteJob(ctx, deletionRepository, job)
 			if err != nil {
 				return nil, err
@@ -118,6 +126,7 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 
 		stateRepository, ok := h.stateRepositories[job.Type]
 		if ok {
+			metrics.JobPollsTotal.WithLabelValues(job.Type).Inc()
 			jobResponse, err := h.handleStateJob(ctx, stateRepository, job)
 			if err != nil {
 				return nil, err
@@ -9,12 +9,14 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 var _ = Describe("Job", func() {
@@ -45,8 +47,15 @@ var _ = Describe("Job", func() {
 	})
 
 	Describe("GET /v3/jobs/space.apply_manifest", func() {
+		var pollsPre float64
+
 		BeforeEach(func() {
 			jobGUID = "space.apply_manifest~cf-space-guid"
+			pollsPre = testutil.ToFloat64(metrics.JobPollsTotal.WithLabelValues("space.apply_manifest"))
+		})
+
+		It("counts the poll", func() {
+			Expect(testutil.ToFloat64(metrics.JobPollsTotal.WithLabelValues("space.apply_manifest"))).To(Equal(pollsPre + 1))
 		})
 
 		It("returns a complete status", func() {
@@ -258,6 +267,10 @@ var _ = Describe("Job", func() {
 		It("returns an error", func() {
 			expectNotFoundError("Job")
 		})
+
+		It("does not count the poll", func() {
+			Expect(metrics.JobPollsTotal.DeleteLabelValues("unknown")).To(BeFalse())
+		})
 	})
 
 	When("the job guid is invalid", func() {
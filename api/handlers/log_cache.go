@@ -3,12 +3,15 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"slices"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/promql"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/tools"
@@ -17,9 +20,11 @@ import (
 )
 
 const (
-	LogCacheInfoPath = "/api/v1/info"
-	LogCacheReadPath = "/api/v1/read/{source-id}"
-	logCacheVersion  = "2.11.4+cf-k8s"
+	LogCacheInfoPath        = "/api/v1/info"
+	LogCacheReadPath        = "/api/v1/read/{source-id}"
+	LogCachePromQLPath      = "/api/v1/promql"
+	LogCachePromQLRangePath = "/api/v1/promql_range"
+	logCacheVersion         = "2.11.4+cf-k8s"
 )
 
 //counterfeiter:generate -o fake -fake-name ProcessStats . ProcessStats
@@ -33,6 +38,12 @@ type LogRepository interface {
 	GetAppLogs(context.Context, authorization.Info, repositories.GetLogsMessage) ([]repositories.LogRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name MetricsStore . MetricsStore
+type MetricsStore interface {
+	Eval(expr promql.Expr, at time.Time) []promql.Sample
+	EvalRange(expr promql.Expr, start, end time.Time, step time.Duration) []promql.Series
+}
+
 // LogCache implements the minimal set of log-cache API endpoints/features necessary
 // to support the "cf push" workfloh.handlerWrapper.
 type LogCache struct {
@@ -41,6 +52,7 @@ type LogCache struct {
 	buildRepo        CFBuildRepository
 	logRepo          LogRepository
 	processStats     ProcessStats
+	metricsStore     MetricsStore
 }
 
 func NewLogCache(
@@ -49,6 +61,7 @@ func NewLogCache(
 	buildRepository CFBuildRepository,
 	logRepo LogRepository,
 	processStats ProcessStats,
+	metricsStore MetricsStore,
 ) *LogCache {
 	return &LogCache{
 		requestValidator: requestValidator,
@@ -56,6 +69,7 @@ func NewLogCache(
 		buildRepo:        buildRepository,
 		logRepo:          logRepo,
 		processStats:     processStats,
+		metricsStore:     metricsStore,
 	}
 }
 
@@ -121,6 +135,71 @@ func (h *LogCache) readStats(ctx context.Context, authInfo authorization.Info, a
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStats(appRecord, stats)), nil
 }
 
+func (h *LogCache) promQL(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.promql")
+
+	payload := payloads.LogCachePromQL{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	expr, err := h.parseQuery(r.Context(), authInfo, payload.Query)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to parse query")
+	}
+
+	at := time.Now()
+	if payload.Time != nil {
+		at = *payload.Time
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPromQLVector(h.metricsStore.Eval(expr, at))), nil
+}
+
+func (h *LogCache) promQLRange(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.promql-range")
+
+	payload := payloads.LogCachePromQLRange{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	expr, err := h.parseQuery(r.Context(), authInfo, payload.Query)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to parse query")
+	}
+
+	series := h.metricsStore.EvalRange(expr, *payload.Start, *payload.End, payload.Step)
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPromQLMatrix(series)), nil
+}
+
+// parseQuery parses the PromQL query and checks that the user can see all
+// the apps it selects. The metrics of those apps are sampled into the
+// metrics store in the background.
+func (h *LogCache) parseQuery(ctx context.Context, authInfo authorization.Info, query string) (promql.Expr, error) {
+	expr, err := promql.Parse(query)
+	if err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, err.Error())
+	}
+
+	sourceIDs, err := promql.SourceIDs(expr)
+	if err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, err.Error())
+	}
+
+	slices.Sort(sourceIDs)
+	for _, sourceID := range slices.Compact(sourceIDs) {
+		if _, err := h.appRepo.GetApp(ctx, authInfo, sourceID); err != nil {
+			return nil, apierrors.ForbiddenAsNotFound(err)
+		}
+	}
+
+	return expr, nil
+}
+
 func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheInfoPath, Handler: h.info},
@@ -130,5 +209,7 @@ func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 func (h *LogCache) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheReadPath, Handler: h.read},
+		{Method: "GET", Pattern: LogCachePromQLPath, Handler: h.promQL},
+		{Method: "GET", Pattern: LogCachePromQLRangePath, Handler: h.promQLRange},
 	}
 }
@@ -4,12 +4,14 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/promql"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -25,6 +27,7 @@ var _ = Describe("LogCache", func() {
 		buildRepo        *fake.CFBuildRepository
 		logRepo          *fake.LogRepository
 		processStats     *fake.ProcessStats
+		metricsStore     *fake.MetricsStore
 		req              *http.Request
 		requestValidator *fake.RequestValidator
 	)
@@ -35,6 +38,7 @@ var _ = Describe("LogCache", func() {
 		buildRepo = new(fake.CFBuildRepository)
 		logRepo = new(fake.LogRepository)
 		processStats = new(fake.ProcessStats)
+		metricsStore = new(fake.MetricsStore)
 
 		appRepo.GetAppReturns(repositories.AppRecord{
 			GUID:      "app-guid",
@@ -51,6 +55,7 @@ var _ = Describe("LogCache", func() {
 			buildRepo,
 			logRepo,
 			processStats,
+			metricsStore,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -239,4 +244,152 @@ var _ = Describe("LogCache", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/promql", func() {
+		var (
+			payload   *payloads.LogCachePromQL
+			queryTime time.Time
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/promql", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			queryTime = time.Unix(1700000000, 0)
+			payload = &payloads.LogCachePromQL{
+				Query: `avg(max_over_time(cpu{source_id="app-guid"}[1m])) by (instance_id)`,
+				Time:  &queryTime,
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			metricsStore.EvalReturns([]promql.Sample{{
+				Labels: promql.Labels{"instance_id": "1"},
+				Point:  promql.Point{Timestamp: queryTime, Value: 0.5},
+			}})
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualPayload).To(Equal(payload))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("gets the app of the queried source id", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		It("does not fetch the app metrics", func() {
+			Expect(processStats.FetchAppProcessesStatsCallCount()).To(BeZero())
+		})
+
+		It("evaluates the query at the requested time", func() {
+			Expect(metricsStore.EvalCallCount()).To(Equal(1))
+			expr, at := metricsStore.EvalArgsForCall(0)
+			Expect(expr).To(BeAssignableToTypeOf(&promql.Aggregation{}))
+			Expect(at).To(Equal(queryTime))
+		})
+
+		It("returns the query result", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"status": "success",
+				"data": {
+					"resultType": "vector",
+					"result": [{"metric": {"instance_id": "1"}, "value": [1700000000, "0.5"]}]
+				}
+			}`)))
+		})
+	})
+
+	Describe("GET /api/v1/promql_range", func() {
+		var (
+			payload    *payloads.LogCachePromQLRange
+			start, end time.Time
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/promql_range", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			start = time.Unix(1700000000, 0)
+			end = start.Add(time.Minute)
+			payload = &payloads.LogCachePromQLRange{
+				Query: `memory{source_id="app-guid"}`,
+				Start: &start,
+				End:   &end,
+				Step:  30 * time.Second,
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			metricsStore.EvalRangeReturns([]promql.Series{{
+				Labels: promql.Labels{"instance_id": "0"},
+				Points: []promql.Point{
+					{Timestamp: start, Value: 1},
+					{Timestamp: start.Add(30 * time.Second), Value: 2},
+				},
+			}})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("gets the app of the queried source id", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		It("evaluates the query over the requested range", func() {
+			Expect(metricsStore.EvalRangeCallCount()).To(Equal(1))
+			expr, actualStart, actualEnd, actualStep := metricsStore.EvalRangeArgsForCall(0)
+			Expect(expr).To(BeAssignableToTypeOf(&promql.VectorSelector{}))
+			Expect(actualStart).To(Equal(start))
+			Expect(actualEnd).To(Equal(end))
+			Expect(actualStep).To(Equal(30 * time.Second))
+		})
+
+		It("returns the query result", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"status": "success",
+				"data": {
+					"resultType": "matrix",
+					"result": [{"metric": {"instance_id": "0"}, "values": [[1700000000, "1"], [1700000030, "2"]]}]
+				}
+			}`)))
+		})
+	})
 })
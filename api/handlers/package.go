@@ -21,11 +21,13 @@ const (
 	PackagePath         = "/v3/packages/{guid}"
 	PackagesPath        = "/v3/packages"
 	PackageUploadPath   = "/v3/packages/{guid}/upload"
+	PackageDownloadPath = "/v3/packages/{guid}/download"
 	PackageDropletsPath = "/v3/packages/{guid}/droplets"
 )
 
 //counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
 //counterfeiter:generate -o fake -fake-name ImageRepository . ImageRepository
+//counterfeiter:generate -o fake -fake-name PackageBlobRepository . PackageBlobRepository
 //counterfeiter:generate -o fake -fake-name RequestValidator . RequestValidator
 
 type CFPackageRepository interface {
@@ -34,10 +36,22 @@ type CFPackageRepository interface {
 	CreatePackage(context.Context, authorization.Info, repositories.CreatePackageMessage) (repositories.PackageRecord, error)
 	UpdatePackageSource(context.Context, authorization.Info, repositories.UpdatePackageSourceMessage) (repositories.PackageRecord, error)
 	UpdatePackage(context.Context, authorization.Info, repositories.UpdatePackageMessage) (repositories.PackageRecord, error)
+	DeletePackage(context.Context, authorization.Info, repositories.DeletePackageMessage) error
 }
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, archiveReader io.Reader, tags ...string) (imageRefWithDigest string, err error)
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarballReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string) (io.ReadCloser, error)
+	CopySourceImage(ctx context.Context, authInfo authorization.Info, srcRef string, imageRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error)
+}
+
+type PackageBlobRepository interface {
+	UploadPackageBits(ctx context.Context, authInfo authorization.Info, packageGUID string, srcReader io.Reader, spaceGUID string) (blobURL string, err error)
+	CopyPackageBits(ctx context.Context, authInfo authorization.Info, srcBlobURL string, packageGUID string, spaceGUID string) (blobURL string, err error)
+	DownloadPackageBits(ctx context.Context, authInfo authorization.Info, blobURL string, spaceGUID string) (io.ReadCloser, error)
 }
 
 type Package struct {
@@ -46,16 +60,20 @@ type Package struct {
 	appRepo             CFAppRepository
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
+	packageBlobRepo     PackageBlobRepository
 	requestValidator    RequestValidator
 	registrySecretNames []string
 }
 
+// NewPackage creates the package handler. Package bits are stored in the
+// container registry unless a packageBlobRepo is given.
 func NewPackage(
 	serverURL url.URL,
 	packageRepo CFPackageRepository,
 	appRepo CFAppRepository,
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
+	packageBlobRepo PackageBlobRepository,
 	requestValidator RequestValidator,
 	registrySecretNames []string,
 ) *Package {
@@ -65,6 +83,7 @@ func NewPackage(
 		appRepo:             appRepo,
 		dropletRepo:         dropletRepo,
 		imageRepo:           imageRepo,
+		packageBlobRepo:     packageBlobRepo,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
 	}
@@ -103,6 +122,10 @@ func (h Package) list(r *http.Request) (*routing.Response, error) {
 }
 
 func (h Package) create(r *http.Request) (*routing.Response, error) {
+	if sourceGUID := r.URL.Query().Get("source_guid"); sourceGUID != "" {
+		return h.copy(r, sourceGUID)
+	}
+
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
@@ -134,6 +157,119 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+func (h Package) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.copy")
+
+	var payload payloads.PackageCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source package is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source package",
+			"Package GUID", sourceGUID,
+		)
+	}
+
+	if sourceRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package must be ready to be copied."),
+			"Source package is not ready",
+			"Package GUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(sourceRecord, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
+	}
+
+	if record.Type != "bits" {
+		return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+	}
+
+	updateSourceMessage, err := h.copyBits(r.Context(), authInfo, sourceRecord, record)
+	if err != nil {
+		// the bits can only be copied once the package guid is known, so
+		// do not leave an empty package behind
+		deleteErr := h.packageRepo.DeletePackage(r.Context(), authInfo, repositories.DeletePackageMessage{
+			GUID:      record.GUID,
+			SpaceGUID: record.SpaceGUID,
+		})
+		if deleteErr != nil {
+			logger.Info("failed to delete the package the bits could not be copied to", "Package GUID", record.GUID, "reason", deleteErr)
+		}
+
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying package bits", "Package GUID", sourceGUID)
+	}
+
+	record, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, updateSourceMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+}
+
+// copyBits copies the bits within the storage of the source package, so
+// that packages uploaded before the blobstore was enabled remain copyable
+func (h Package) copyBits(ctx context.Context, authInfo authorization.Info, sourceRecord, record repositories.PackageRecord) (repositories.UpdatePackageSourceMessage, error) {
+	if sourceRecord.SourceBlobURL != "" {
+		if h.packageBlobRepo == nil {
+			return repositories.UpdatePackageSourceMessage{}, apierrors.NewUnprocessableEntityError(nil, "Source package bits are stored in a blobstore that is not configured.")
+		}
+
+		blobURL, err := h.packageBlobRepo.CopyPackageBits(ctx, authInfo, sourceRecord.SourceBlobURL, record.GUID, record.SpaceGUID)
+		if err != nil {
+			return repositories.UpdatePackageSourceMessage{}, err
+		}
+
+		return repositories.UpdatePackageSourceMessage{
+			GUID:      record.GUID,
+			SpaceGUID: record.SpaceGUID,
+			BlobURL:   blobURL,
+		}, nil
+	}
+
+	copiedImageRef, err := h.imageRepo.CopySourceImage(ctx, authInfo, sourceRecord.SourceImageRef, record.ImageRef, record.SpaceGUID, record.GUID)
+	if err != nil {
+		return repositories.UpdatePackageSourceMessage{}, err
+	}
+
+	return repositories.UpdatePackageSourceMessage{
+		GUID:                record.GUID,
+		SpaceGUID:           record.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	}, nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -185,22 +321,92 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, bitsFile, packageRecord.SpaceGUID, packageGUID)
+	updateSourceMessage, err := h.uploadBits(r.Context(), authInfo, packageRecord, bitsFile)
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
+		return nil, apierrors.LogAndReturn(logger, err, "Error uploading package bits")
 	}
 
-	packageRecord, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
-		GUID:                packageGUID,
+	packageRecord, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, updateSourceMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
+}
+
+func (h Package) uploadBits(ctx context.Context, authInfo authorization.Info, packageRecord repositories.PackageRecord, bits io.Reader) (repositories.UpdatePackageSourceMessage, error) {
+	if h.packageBlobRepo != nil {
+		blobURL, err := h.packageBlobRepo.UploadPackageBits(ctx, authInfo, packageRecord.GUID, bits, packageRecord.SpaceGUID)
+		if err != nil {
+			return repositories.UpdatePackageSourceMessage{}, err
+		}
+
+		return repositories.UpdatePackageSourceMessage{
+			GUID:      packageRecord.GUID,
+			SpaceGUID: packageRecord.SpaceGUID,
+			BlobURL:   blobURL,
+		}, nil
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(ctx, authInfo, packageRecord.ImageRef, bits, packageRecord.SpaceGUID, packageRecord.GUID)
+	if err != nil {
+		return repositories.UpdatePackageSourceMessage{}, err
+	}
+
+	return repositories.UpdatePackageSourceMessage{
+		GUID:                packageRecord.GUID,
 		SpaceGUID:           packageRecord.SpaceGUID,
 		ImageRef:            uploadedImageRef,
 		RegistrySecretNames: h.registrySecretNames,
-	})
+	}, nil
+}
+
+func (h Package) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.download")
+
+	packageGUID := routing.URLParam(r, "guid")
+	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
+	if packageRecord.Type != "bits" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package type must be bits."),
+			fmt.Sprintf("downloading bits of %s packages is not supported", packageRecord.Type),
+		)
+	}
+
+	if packageRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package has no bits to download."),
+			"Error, cannot download package bits before they are uploaded", "packageGUID", packageGUID,
+		)
+	}
+
+	zipReader, err := h.downloadBits(r.Context(), authInfo, packageRecord)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error downloading package bits")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")).
+		WithStreamedBody("application/zip", zipReader), nil
+}
+
+func (h Package) downloadBits(ctx context.Context, authInfo authorization.Info, packageRecord repositories.PackageRecord) (io.ReadCloser, error) {
+	if packageRecord.SourceBlobURL == "" {
+		return h.imageRepo.DownloadSourceImage(ctx, authInfo, packageRecord.SourceImageRef, packageRecord.SpaceGUID)
+	}
+
+	if h.packageBlobRepo == nil {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Package bits are stored in a blobstore that is not configured.")
+	}
+
+	return h.packageBlobRepo.DownloadPackageBits(ctx, authInfo, packageRecord.SourceBlobURL, packageRecord.SpaceGUID)
 }
 
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
@@ -238,6 +444,7 @@ func (h *Package) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: PackagesPath, Handler: h.list},
 		{Method: "POST", Pattern: PackagesPath, Handler: h.create},
 		{Method: "POST", Pattern: PackageUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: PackageDownloadPath, Handler: h.download},
 		{Method: "GET", Pattern: PackageDropletsPath, Handler: h.listDroplets},
 	}
 }
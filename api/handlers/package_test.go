@@ -29,6 +29,8 @@ var _ = Describe("Package", func() {
 		appRepo                     *fake.CFAppRepository
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
+		packageBlobRepo             *fake.PackageBlobRepository
+		handlerPackageBlobRepo      PackageBlobRepository
 		requestValidator            *fake.RequestValidator
 		packageImagePullSecretNames []string
 
@@ -44,6 +46,8 @@ var _ = Describe("Package", func() {
 		appRepo = new(fake.CFAppRepository)
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
+		packageBlobRepo = new(fake.PackageBlobRepository)
+		handlerPackageBlobRepo = nil
 		requestValidator = new(fake.RequestValidator)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
@@ -52,13 +56,16 @@ var _ = Describe("Package", func() {
 		spaceGUID = generateGUID("space")
 		createdAt = time.Now()
 		updatedAt = tools.PtrTo(time.Now())
+	})
 
+	JustBeforeEach(func() {
 		apiHandler := NewPackage(
 			*serverURL,
 			packageRepo,
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			handlerPackageBlobRepo,
 			requestValidator,
 			packageImagePullSecretNames,
 		)
@@ -721,6 +728,45 @@ var _ = Describe("Package", func() {
 			})
 		})
 
+		When("the package blobstore is configured", func() {
+			BeforeEach(func() {
+				handlerPackageBlobRepo = packageBlobRepo
+				packageBlobRepo.UploadPackageBitsReturns("https://blobstore.example.com/packages/the-package.zip", nil)
+			})
+
+			It("uploads the bits to the blobstore", func() {
+				Expect(imageRepo.UploadSourceImageCallCount()).To(BeZero())
+
+				Expect(packageBlobRepo.UploadPackageBitsCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualPackageGUID, srcFile, actualSpaceGUID := packageBlobRepo.UploadPackageBitsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualPackageGUID).To(Equal(packageGUID))
+				Expect(io.ReadAll(srcFile)).To(BeEquivalentTo("the-src-file-contents"))
+				Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+				_, _, message := packageRepo.UpdatePackageSourceArgsForCall(0)
+				Expect(message).To(Equal(repositories.UpdatePackageSourceMessage{
+					GUID:      packageGUID,
+					SpaceGUID: spaceGUID,
+					BlobURL:   "https://blobstore.example.com/packages/the-package.zip",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			When("uploading the bits errors", func() {
+				BeforeEach(func() {
+					packageBlobRepo.UploadPackageBitsReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+				})
+
+				It("returns an error", func() {
+					expectBlobstoreUnavailableError()
+				})
+				itDoesntUpdateAnyPackages()
+			})
+		})
+
 		When("the package type is not bits", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{
@@ -740,6 +786,396 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the POST /v3/packages?source_guid= endpoint", func() {
+		var sourceGUID string
+
+		BeforeEach(func() {
+			sourceGUID = generateGUID("source-package")
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopy{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: appGUID,
+						},
+					},
+				},
+			})
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				GUID:           sourceGUID,
+				Type:           "bits",
+				State:          "READY",
+				SourceImageRef: "registry.repo/source-app-packages@sha256:abc",
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: spaceGUID,
+				GUID:      appGUID,
+			}, nil)
+
+			packageRepo.CreatePackageReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "AWAITING_UPLOAD",
+				ImageRef:  "registry.repo/app-packages",
+			}, nil)
+
+			imageRepo.CopySourceImageReturns("registry.repo/app-packages@sha256:abc", nil)
+
+			packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "READY",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourceGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("creates a package for the target app", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSourceGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSourceGUID).To(Equal(sourceGUID))
+
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := packageRepo.CreatePackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+			}))
+		})
+
+		It("copies the source image", func() {
+			Expect(imageRepo.CopySourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, srcRef, imageRef, actualSpaceGUID, tags := imageRepo.CopySourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(srcRef).To(Equal("registry.repo/source-app-packages@sha256:abc"))
+			Expect(imageRef).To(Equal("registry.repo/app-packages"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(tags).To(ConsistOf(packageGUID))
+
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			_, _, updateMessage := packageRepo.UpdatePackageSourceArgsForCall(0)
+			Expect(updateMessage).To(Equal(repositories.UpdatePackageSourceMessage{
+				GUID:                packageGUID,
+				SpaceGUID:           spaceGUID,
+				ImageRef:            "registry.repo/app-packages@sha256:abc",
+				RegistrySecretNames: []string{"package-image-pull-secret"},
+			}))
+		})
+
+		It("returns the copied package", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "READY"),
+			)))
+		})
+
+		When("the source package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:           sourceGUID,
+					Type:           "docker",
+					State:          "READY",
+					SourceImageRef: "some/image",
+				}, nil)
+
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  packageGUID,
+					State: "READY",
+				}, nil)
+			})
+
+			It("creates a docker package referencing the same image", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, createMessage := packageRepo.CreatePackageArgsForCall(0)
+				Expect(createMessage.Type).To(Equal("docker"))
+				Expect(createMessage.Data).To(Equal(&repositories.PackageData{Image: "some/image"}))
+
+				Expect(imageRepo.CopySourceImageCallCount()).To(BeZero())
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+		})
+
+		When("the source package cannot be found", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewNotFoundError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the source package is not ready", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  sourceGUID,
+					Type:  "bits",
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package must be ready to be copied.")
+				Expect(packageRepo.CreatePackageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("creating the package fails", func() {
+			BeforeEach(func() {
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopySourceImageReturns("", errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+			})
+
+			It("deletes the created package", func() {
+				Expect(packageRepo.DeletePackageCallCount()).To(Equal(1))
+				_, actualAuthInfo, deleteMessage := packageRepo.DeletePackageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(deleteMessage).To(Equal(repositories.DeletePackageMessage{
+					GUID:      packageGUID,
+					SpaceGUID: spaceGUID,
+				}))
+			})
+
+			When("deleting the created package fails", func() {
+				BeforeEach(func() {
+					packageRepo.DeletePackageReturns(errors.New("delete-error"))
+				})
+
+				It("returns the copy error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("the source package bits are in the package blobstore", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:          sourceGUID,
+					Type:          "bits",
+					State:         "READY",
+					SourceBlobURL: "https://blobstore.example.com/packages/source-package.zip",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error if the blobstore is not configured", func() {
+				expectUnprocessableEntityError("Source package bits are stored in a blobstore that is not configured.")
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+				Expect(packageRepo.DeletePackageCallCount()).To(Equal(1))
+			})
+
+			When("the package blobstore is configured", func() {
+				BeforeEach(func() {
+					handlerPackageBlobRepo = packageBlobRepo
+					packageBlobRepo.CopyPackageBitsReturns("https://blobstore.example.com/packages/the-package.zip", nil)
+				})
+
+				It("copies the source blob", func() {
+					Expect(imageRepo.CopySourceImageCallCount()).To(BeZero())
+
+					Expect(packageBlobRepo.CopyPackageBitsCallCount()).To(Equal(1))
+					_, actualAuthInfo, srcBlobURL, actualPackageGUID, actualSpaceGUID := packageBlobRepo.CopyPackageBitsArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(srcBlobURL).To(Equal("https://blobstore.example.com/packages/source-package.zip"))
+					Expect(actualPackageGUID).To(Equal(packageGUID))
+					Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+					Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+					_, _, updateMessage := packageRepo.UpdatePackageSourceArgsForCall(0)
+					Expect(updateMessage).To(Equal(repositories.UpdatePackageSourceMessage{
+						GUID:      packageGUID,
+						SpaceGUID: spaceGUID,
+						BlobURL:   "https://blobstore.example.com/packages/the-package.zip",
+					}))
+				})
+			})
+		})
+
+		When("the package blobstore is configured and the source package bits are in the registry", func() {
+			BeforeEach(func() {
+				handlerPackageBlobRepo = packageBlobRepo
+			})
+
+			It("copies the source image", func() {
+				Expect(packageBlobRepo.CopyPackageBitsCallCount()).To(BeZero())
+				Expect(imageRepo.CopySourceImageCallCount()).To(Equal(1))
+			})
+		})
+
+		When("updating the package source fails", func() {
+			BeforeEach(func() {
+				packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/packages/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				GUID:           packageGUID,
+				Type:           "bits",
+				SpaceGUID:      spaceGUID,
+				State:          "READY",
+				SourceImageRef: "registry.repo/app-packages@sha256:abc",
+			}, nil)
+
+			imageRepo.DownloadSourceImageReturns(io.NopCloser(strings.NewReader("the-src-zip")), nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/packages/"+packageGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("streams the package bits", func() {
+			Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, imageRef, actualSpaceGUID := imageRepo.DownloadSourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(imageRef).To(Equal("registry.repo/app-packages@sha256:abc"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")))
+			Expect(rr).To(HaveHTTPBody("the-src-zip"))
+		})
+
+		When("the package is not found", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Package")
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  packageGUID,
+					Type:  "docker",
+					State: "READY",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package type must be bits.")
+			})
+		})
+
+		When("the package bits have not been uploaded", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  packageGUID,
+					Type:  "bits",
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package has no bits to download.")
+			})
+		})
+
+		When("downloading the bits fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadSourceImageReturns(nil, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns the error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("the package bits are in the package blobstore", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:          packageGUID,
+					Type:          "bits",
+					SpaceGUID:     spaceGUID,
+					State:         "READY",
+					SourceBlobURL: "https://blobstore.example.com/packages/the-package.zip",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error if the blobstore is not configured", func() {
+				expectUnprocessableEntityError("Package bits are stored in a blobstore that is not configured.")
+			})
+
+			When("the package blobstore is configured", func() {
+				BeforeEach(func() {
+					handlerPackageBlobRepo = packageBlobRepo
+					packageBlobRepo.DownloadPackageBitsReturns(io.NopCloser(strings.NewReader("the-blob-zip")), nil)
+				})
+
+				It("streams the package bits from the blobstore", func() {
+					Expect(imageRepo.DownloadSourceImageCallCount()).To(BeZero())
+
+					Expect(packageBlobRepo.DownloadPackageBitsCallCount()).To(Equal(1))
+					_, actualAuthInfo, blobURL, actualSpaceGUID := packageBlobRepo.DownloadPackageBitsArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(blobURL).To(Equal("https://blobstore.example.com/packages/the-package.zip"))
+					Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody("the-blob-zip"))
+				})
+			})
+		})
+	})
+
 	Describe("the GET /v3/packages/:guid/droplets endpoint", func() {
 		var dropletGUID string
 		var queryString string
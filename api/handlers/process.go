@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"github.com/go-logr/logr"
@@ -55,6 +56,10 @@ type Process struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	includeResolver         *include.IncludeResolver[
+		[]repositories.ProcessRecord,
+		repositories.ProcessRecord,
+	]
 }
 
 func NewProcess(
@@ -64,6 +69,7 @@ func NewProcess(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	relationshipRepo include.ResourceRelationshipRepository,
 ) *Process {
 	return &Process{
 		serverURL:               serverURL,
@@ -72,6 +78,7 @@ func NewProcess(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		includeResolver:         include.NewIncludeResolver[[]repositories.ProcessRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
 
@@ -228,7 +235,12 @@ func (h *Process) list(r *http.Request) (*routing.Response, error) { //nolint:du
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch processes(s) from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, processList, processListFilter.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL, includedResources...)), nil
 }
 
 func (h *Process) update(r *http.Request) (*routing.Response, error) {
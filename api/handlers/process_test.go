@@ -10,7 +10,9 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/handlers/stats"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -26,6 +28,7 @@ var _ = Describe("Process", func() {
 		podRepo                 *fake.PodRepository
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		appRepo                 *fake.CFAppRepository
 	)
 
 	BeforeEach(func() {
@@ -34,6 +37,7 @@ var _ = Describe("Process", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
+		appRepo = new(fake.CFAppRepository)
 
 		apiHandler := NewProcess(
 			*serverURL,
@@ -42,6 +46,16 @@ var _ = Describe("Process", func() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				new(fake.CFSpaceRepository),
+				new(fake.CFOrgRepository),
+				new(fake.CFUserRepository),
+				new(fake.CFDomainRepository),
+				appRepo,
+			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -391,6 +405,47 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("the app is included", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+					{
+						GUID:    "process-guid",
+						AppGUID: "app-guid",
+					},
+				}, nil)
+				appRepo.ListAppsReturns([]repositories.AppRecord{{
+					GUID: "app-guid",
+					Name: "my-app",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ProcessList{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"app"}},
+				}})
+			})
+
+			It("returns the included apps", func() {
+				Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+				_, _, message := appRepo.ListAppsArgsForCall(0)
+				Expect(message.Guids).To(ConsistOf("app-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.apps[0].guid", "app-guid"),
+					MatchJSONPath("$.included.apps[0].name", "my-app"),
+				)))
+			})
+
+			When("listing the apps fails", func() {
+				BeforeEach(func() {
+					appRepo.ListAppsReturns(nil, errors.New("list-apps-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
 		When("the request body is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boo"))
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -33,13 +34,18 @@ type Role struct {
 	apiBaseURL       url.URL
 	roleRepo         CFRoleRepository
 	requestValidator RequestValidator
+	includeResolver  *include.IncludeResolver[
+		[]repositories.RoleRecord,
+		repositories.RoleRecord,
+	]
 }
 
-func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator) *Role {
+func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator, relationshipRepo include.ResourceRelationshipRepository) *Role {
 	return &Role{
 		apiBaseURL:       apiBaseURL,
 		roleRepo:         roleRepo,
 		requestValidator: requestValidator,
+		includeResolver:  include.NewIncludeResolver[[]repositories.RoleRecord](relationshipRepo, presenter.NewResource(apiBaseURL)),
 	}
 }
 
@@ -80,7 +86,12 @@ func (h *Role) list(r *http.Request) (*routing.Response, error) {
 
 	filteredRoles := filterRoles(payload, roles)
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRole, filteredRoles, h.apiBaseURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, filteredRoles, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRole, filteredRoles, h.apiBaseURL, *r.URL, includedResources...)), nil
 }
 
 func filterRoles(roleListFilter *payloads.RoleList, roles []repositories.RoleRecord) []repositories.RoleRecord {
@@ -11,7 +11,9 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -28,14 +30,30 @@ var _ = Describe("Role", func() {
 	var (
 		apiHandler       *handlers.Role
 		roleRepo         *fake.CFRoleRepository
+		userRepo         *fake.CFUserRepository
 		requestValidator *fake.RequestValidator
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
+		userRepo = new(fake.CFUserRepository)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(
+			*serverURL,
+			roleRepo,
+			requestValidator,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				new(fake.CFSpaceRepository),
+				new(fake.CFOrgRepository),
+				userRepo,
+				new(fake.CFDomainRepository),
+				new(fake.CFAppRepository),
+			),
+		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -194,6 +212,35 @@ var _ = Describe("Role", func() {
 			)))
 		})
 
+		When("users are included", func() {
+			BeforeEach(func() {
+				roleRepo.ListRolesReturns([]repositories.RoleRecord{
+					{GUID: "role-1", User: "bob"},
+				}, nil)
+				userRepo.ListUsersReturns([]repositories.UserRecord{
+					{GUID: "bob", Username: "bob"},
+				}, nil)
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RoleList{
+					IncludeResourceRules: []params.IncludeResourceRule{{
+						RelationshipPath: []string{"user"},
+						Fields:           []string{},
+					}},
+				})
+			})
+
+			It("lists the users of the roles", func() {
+				Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+				_, _, message := userRepo.ListUsersArgsForCall(0)
+				Expect(message.GUIDs).To(ConsistOf("bob"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.users", HaveLen(1)),
+					MatchJSONPath("$.included.users[0].guid", "bob"),
+					MatchJSONPath("$.included.users[0].username", "bob"),
+				)))
+			})
+		})
+
 		Describe("filtering", func() {
 			BeforeEach(func() {
 				roleRepo.ListRolesReturns([]repositories.RoleRecord{
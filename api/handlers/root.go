@@ -17,18 +17,20 @@ type Root struct {
 	baseURL     url.URL
 	uaaConfig   config.UAA
 	logCacheURL url.URL
+	appSSH      presenter.AppSSH
 }
 
-func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) *Root {
+func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, appSSH presenter.AppSSH) *Root {
 	return &Root{
 		baseURL:     baseURL,
 		uaaConfig:   uaaConfig,
 		logCacheURL: logCacheURL,
+		appSSH:      appSSH,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL, h.appSSH)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -24,7 +25,7 @@ var _ = Describe("Root", func() {
 		logCacheURL, err = url.Parse("https://my.logcache.org")
 		Expect(err).NotTo(HaveOccurred())
 
-		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, *logCacheURL)
+		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, *logCacheURL, presenter.AppSSH{})
 	})
 
 	JustBeforeEach(func() {
@@ -59,7 +60,9 @@ var _ = Describe("Root", func() {
 						Enabled: true,
 						URL:     "https://my.uaa",
 					},
-					*logCacheURL)
+					*logCacheURL,
+					presenter.AppSSH{},
+				)
 			})
 
 			It("returns the uaa config", func() {
@@ -72,5 +75,29 @@ var _ = Describe("Root", func() {
 				)))
 			})
 		})
+
+		When("app ssh is enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewRoot(
+					*serverURL,
+					config.UAA{},
+					*logCacheURL,
+					presenter.AppSSH{
+						Address:            "ssh.example.org:2222",
+						HostKeyFingerprint: "my-fingerprint",
+					},
+				)
+			})
+
+			It("returns the app ssh link", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.links.app_ssh.href", "ssh.example.org:2222"),
+					MatchJSONPath("$.links.app_ssh.meta.host_key_fingerprint", "my-fingerprint"),
+					MatchJSONPath("$.links.app_ssh.meta.oauth_client", "ssh-proxy"),
+				)))
+			})
+		})
 	})
 })
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"github.com/go-logr/logr"
@@ -43,6 +44,10 @@ type Route struct {
 	appRepo          CFAppRepository
 	spaceRepo        CFSpaceRepository
 	requestValidator RequestValidator
+	includeResolver  *include.IncludeResolver[
+		[]repositories.RouteRecord,
+		repositories.RouteRecord,
+	]
 }
 
 func NewRoute(
@@ -52,6 +57,7 @@ func NewRoute(
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
+	relationshipRepo include.ResourceRelationshipRepository,
 ) *Route {
 	return &Route{
 		serverURL:        serverURL,
@@ -60,6 +66,7 @@ func NewRoute(
 		appRepo:          appRepo,
 		spaceRepo:        spaceRepo,
 		requestValidator: requestValidator,
+		includeResolver:  include.NewIncludeResolver[[]repositories.RouteRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
 
@@ -69,12 +76,22 @@ func (h *Route) get(r *http.Request) (*routing.Response, error) {
 
 	routeGUID := routing.URLParam(r, "guid")
 
+	payload := new(payloads.RouteGet)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
 	route, err := h.lookupRouteAndDomain(r.Context(), logger, authInfo, routeGUID)
 	if err != nil {
 		return nil, err
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, []repositories.RouteRecord{route}, payload.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL, includedResources...)), nil
 }
 
 func (h *Route) list(r *http.Request) (*routing.Response, error) {
@@ -91,7 +108,12 @@ func (h *Route) list(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch routes from Kubernetes")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRoute, routes, h.serverURL, *r.URL)), nil
+	includedResources, err := h.includeResolver.ResolveIncludes(r.Context(), authInfo, routes, routeListFilter.IncludeResourceRules)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to build included resources")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRoute, routes, h.serverURL, *r.URL, includedResources...)), nil
 }
 
 func (h *Route) listDestinations(r *http.Request) (*routing.Response, error) {
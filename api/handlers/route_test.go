@@ -10,7 +10,9 @@ import (
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -25,6 +27,7 @@ var _ = Describe("Route", func() {
 		domainRepo       *fake.CFDomainRepository
 		appRepo          *fake.CFAppRepository
 		spaceRepo        *fake.CFSpaceRepository
+		orgRepo          *fake.CFOrgRepository
 		requestValidator *fake.RequestValidator
 
 		requestMethod string
@@ -64,6 +67,8 @@ var _ = Describe("Route", func() {
 			Name: "test-space-guid",
 		}, nil)
 
+		orgRepo = new(fake.CFOrgRepository)
+
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewRoute(
@@ -73,6 +78,16 @@ var _ = Describe("Route", func() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			relationships.NewResourseRelationshipsRepo(
+				new(fake.CFServiceOfferingRepository),
+				new(fake.CFServiceBrokerRepository),
+				new(fake.CFServicePlanRepository),
+				spaceRepo,
+				orgRepo,
+				new(fake.CFUserRepository),
+				domainRepo,
+				appRepo,
+			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -111,6 +126,31 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		When("the domain is included", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns([]repositories.DomainRecord{{
+					GUID: "test-domain-guid",
+					Name: "example.org",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteGet{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"domain"}},
+				}})
+			})
+
+			It("returns the included domain", func() {
+				Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+				_, _, message := domainRepo.ListDomainsArgsForCall(0)
+				Expect(message.GUIDs).To(ConsistOf("test-domain-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.domains[0].guid", "test-domain-guid"),
+					MatchJSONPath("$.included.domains[0].name", "example.org"),
+				)))
+			})
+		})
+
 		When("the route is not accessible", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
@@ -218,6 +258,51 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("the domain and org are included", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns([]repositories.DomainRecord{{
+					GUID: "test-domain-guid",
+					Name: "example.org",
+				}}, nil)
+				spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{
+					GUID:             "test-space-guid",
+					OrganizationGUID: "test-org-guid",
+				}}, nil)
+				orgRepo.ListOrgsReturns([]repositories.OrgRecord{{
+					GUID: "test-org-guid",
+					Name: "test-org",
+				}}, nil)
+
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"domain"}},
+					{RelationshipPath: []string{"space", "organization"}},
+				}})
+			})
+
+			It("returns the included resources", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.domains", HaveLen(1)),
+					MatchJSONPath("$.included.domains[0].guid", "test-domain-guid"),
+					MatchJSONPath("$.included.organizations", HaveLen(1)),
+					MatchJSONPath("$.included.organizations[0].guid", "test-org-guid"),
+				)))
+			})
+		})
+
+		When("resolving the included resources fails", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns(nil, errors.New("list-domains-err"))
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouteList{IncludeResourceRules: []params.IncludeResourceRule{
+					{RelationshipPath: []string{"domain"}},
+				}})
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("there is a failure Listing Routes", func() {
 			BeforeEach(func() {
 				routeRepo.ListRoutesReturns([]repositories.RouteRecord{}, errors.New("unknown!"))
@@ -61,6 +61,9 @@ var _ = Describe("ServiceInstance", func() {
 				servicePlanRepo,
 				spaceRepo,
 				orgRepo,
+				new(fake.CFUserRepository),
+				new(fake.CFDomainRepository),
+				new(fake.CFAppRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -46,6 +46,9 @@ var _ = Describe("ServiceOffering", func() {
 				servicePlanRepo,
 				spaceRepo,
 				orgRepo,
+				new(fake.CFUserRepository),
+				new(fake.CFDomainRepository),
+				new(fake.CFAppRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
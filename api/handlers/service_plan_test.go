@@ -47,6 +47,9 @@ var _ = Describe("ServicePlan", func() {
 				servicePlanRepo,
 				spaceRepo,
 				orgRepo,
+				new(fake.CFUserRepository),
+				new(fake.CFDomainRepository),
+				new(fake.CFAppRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -21,6 +21,7 @@ const (
 	SpacesPath         = "/v3/spaces"
 	SpacePath          = "/v3/spaces/{guid}"
 	RoutesForSpacePath = "/v3/spaces/{guid}/routes"
+	SpaceFeaturePath   = "/v3/spaces/{guid}/features/{name}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
@@ -31,6 +32,8 @@ type CFSpaceRepository interface {
 	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, repositories.DeleteSpaceMessage) error
 	PatchSpaceMetadata(context.Context, authorization.Info, repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error)
+	PatchSpaceFeatures(context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error)
+	PatchSpaceIsolationSegment(context.Context, authorization.Info, repositories.PatchSpaceIsolationSegmentMessage) (repositories.SpaceRecord, error)
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
@@ -197,6 +200,60 @@ func (h *Space) deleteUnmappedRoutes(r *http.Request) (*routing.Response, error)
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceDeleteUnmappedRoutesOperation, h.apiBaseURL)), nil
 }
 
+func (h *Space) getFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-feature")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported space feature", "Feature", featureName)
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(spaceSSHFeature(space.SSHEnabled)), nil
+}
+
+func (h *Space) updateFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.update-feature")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported space feature", "Feature", featureName)
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	var payload payloads.FeaturePatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err = h.spaceRepo.PatchSpaceFeatures(r.Context(), authInfo, payload.ToSpaceSSHMessage(spaceGUID, space.OrganizationGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch space features", "GUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(spaceSSHFeature(space.SSHEnabled)), nil
+}
+
+func spaceSSHFeature(enabled bool) presenter.FeatureResponse {
+	return presenter.FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     enabled,
+	}
+}
+
 func (h *Space) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -209,5 +266,7 @@ func (h *Space) AuthenticatedRoutes() []routing.Route {
 		{Method: "DELETE", Pattern: SpacePath, Handler: h.delete},
 		{Method: "GET", Pattern: SpacePath, Handler: h.get},
 		{Method: "DELETE", Pattern: RoutesForSpacePath, Handler: h.deleteUnmappedRoutes},
+		{Method: "GET", Pattern: SpaceFeaturePath, Handler: h.getFeature},
+		{Method: "PATCH", Pattern: SpaceFeaturePath, Handler: h.updateFeature},
 	}
 }
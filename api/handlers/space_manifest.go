@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -12,18 +14,21 @@ import (
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v3"
 )
 
 const (
 	SpaceManifestApplyPath = "/v3/spaces/{spaceGUID}/actions/apply_manifest"
 	SpaceManifestDiffPath  = "/v3/spaces/{spaceGUID}/manifest_diff"
+	SpaceManifestPath      = "/v3/spaces/{spaceGUID}/manifest"
 )
 
 type SpaceManifest struct {
-	serverURL        url.URL
-	manifestApplier  ManifestApplier
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL         url.URL
+	manifestApplier   ManifestApplier
+	manifestGenerator ManifestGenerator
+	spaceRepo         CFSpaceRepository
+	requestValidator  RequestValidator
 }
 
 //counterfeiter:generate -o fake -fake-name ManifestApplier . ManifestApplier
@@ -31,17 +36,25 @@ type ManifestApplier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) error
 }
 
+//counterfeiter:generate -o fake -fake-name ManifestGenerator . ManifestGenerator
+type ManifestGenerator interface {
+	GenerateForApp(ctx context.Context, authInfo authorization.Info, appGUID string) (payloads.Manifest, error)
+	GenerateForSpace(ctx context.Context, authInfo authorization.Info, spaceGUID string) (payloads.Manifest, error)
+}
+
 func NewSpaceManifest(
 	serverURL url.URL,
 	manifestApplier ManifestApplier,
+	manifestGenerator ManifestGenerator,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
 ) *SpaceManifest {
 	return &SpaceManifest{
-		serverURL:        serverURL,
-		manifestApplier:  manifestApplier,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:         serverURL,
+		manifestApplier:   manifestApplier,
+		manifestGenerator: manifestGenerator,
+		spaceRepo:         spaceRepo,
+		requestValidator:  requestValidator,
 	}
 }
 
@@ -53,6 +66,7 @@ func (h *SpaceManifest) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "POST", Pattern: SpaceManifestApplyPath, Handler: h.apply},
 		{Method: "POST", Pattern: SpaceManifestDiffPath, Handler: h.diff},
+		{Method: "GET", Pattern: SpaceManifestPath, Handler: h.get},
 	}
 }
 
@@ -86,3 +100,30 @@ func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
 
 	return routing.NewResponse(http.StatusAccepted).WithBody(map[string]interface{}{"diff": []string{}}), nil
 }
+
+func (h *SpaceManifest) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-manifest.get")
+
+	spaceGUID := routing.URLParam(r, "spaceGUID")
+
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	manifest, err := h.manifestGenerator.GenerateForSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to generate space manifest", "guid", spaceGUID)
+	}
+
+	return manifestResponse(manifest)
+}
+
+func manifestResponse(manifest payloads.Manifest) (*routing.Response, error) {
+	manifestYAML, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithStreamedBody("application/x-yaml", bytes.NewReader(manifestYAML)), nil
+}
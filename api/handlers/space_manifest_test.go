@@ -19,11 +19,12 @@ import (
 
 var _ = Describe("SpaceManifest", func() {
 	var (
-		manifestApplier  *fake.ManifestApplier
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
-		requestMethod    string
-		requestPath      string
+		manifestApplier   *fake.ManifestApplier
+		manifestGenerator *fake.ManifestGenerator
+		spaceRepo         *fake.CFSpaceRepository
+		requestValidator  *fake.RequestValidator
+		requestMethod     string
+		requestPath       string
 	)
 
 	BeforeEach(func() {
@@ -31,12 +32,14 @@ var _ = Describe("SpaceManifest", func() {
 		requestPath = ""
 
 		manifestApplier = new(fake.ManifestApplier)
+		manifestGenerator = new(fake.ManifestGenerator)
 		spaceRepo = new(fake.CFSpaceRepository)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewSpaceManifest(
 			*serverURL,
 			manifestApplier,
+			manifestGenerator,
 			spaceRepo,
 			requestValidator,
 		)
@@ -165,4 +168,66 @@ var _ = Describe("SpaceManifest", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/spaces/{spaceGUID}/manifest", func() {
+		BeforeEach(func() {
+			requestMethod = "GET"
+			requestPath = "/v3/spaces/test-space-guid/manifest"
+
+			manifestGenerator.GenerateForSpaceReturns(payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name:       "app1",
+					Buildpacks: []string{"my-buildpack"},
+					Routes: []payloads.ManifestRoute{{
+						Route: tools.PtrTo("app1.my-domain.com"),
+					}},
+				}},
+			}, nil)
+		})
+
+		It("returns the space manifest as YAML", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+
+			Expect(manifestGenerator.GenerateForSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := manifestGenerator.GenerateForSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-yaml"))
+			Expect(rr).To(HaveHTTPBody(MatchYAML(`---
+version: 1
+applications:
+- name: app1
+  buildpacks:
+  - my-buildpack
+  routes:
+  - route: app1.my-domain.com
+`)))
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(errors.New("foo"), repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Space")
+				Expect(manifestGenerator.GenerateForSpaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("generating the manifest fails", func() {
+			BeforeEach(func() {
+				manifestGenerator.GenerateForSpaceReturns(payloads.Manifest{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
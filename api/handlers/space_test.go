@@ -64,6 +64,9 @@ var _ = Describe("Space", func() {
 				servicePlanRepo,
 				spaceRepo,
 				orgRepo,
+				new(fake.CFUserRepository),
+				new(fake.CFDomainRepository),
+				new(fake.CFAppRepository),
 			),
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -486,4 +489,103 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+
+	Describe("Getting a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:       "the-space-guid",
+				SSHEnabled: true,
+			}, nil)
+		})
+
+		It("returns the ssh feature", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is not supported", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/foo"
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+			})
+		})
+
+		When("the space cannot be found", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Space")
+			})
+		})
+	})
+
+	Describe("Updating a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{
+				GUID:       "the-space-guid",
+				SSHEnabled: false,
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeaturePatch{
+				Enabled: tools.PtrTo(false),
+			})
+		})
+
+		It("updates the space", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+
+			Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(1))
+			_, _, msg := spaceRepo.PatchSpaceFeaturesArgsForCall(0)
+			Expect(msg).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:       "the-space-guid",
+				OrgGUID:    "the-org-guid",
+				SSHEnabled: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
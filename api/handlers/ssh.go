@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppSSHCodePath     = "/v3/apps/{guid}/ssh_code"
+	OAuthAuthorizePath = "/oauth/authorize"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeRepository . SSHCodeRepository
+
+type SSHCodeRepository interface {
+	CreateSSHCode(context.Context, authorization.Info, repositories.CreateSSHCodeMessage) (repositories.SSHCodeRecord, error)
+}
+
+type SSH struct {
+	apiBaseURL       url.URL
+	appRepo          CFAppRepository
+	spaceRepo        CFSpaceRepository
+	sshCodeRepo      SSHCodeRepository
+	identityProvider IdentityProvider
+}
+
+func NewSSH(
+	apiBaseURL url.URL,
+	appRepo CFAppRepository,
+	spaceRepo CFSpaceRepository,
+	sshCodeRepo SSHCodeRepository,
+	identityProvider IdentityProvider,
+) *SSH {
+	return &SSH{
+		apiBaseURL:       apiBaseURL,
+		appRepo:          appRepo,
+		spaceRepo:        spaceRepo,
+		sshCodeRepo:      sshCodeRepo,
+		identityProvider: identityProvider,
+	}
+}
+
+func (h *SSH) createAppSSHCode(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.ssh.create-app-ssh-code")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	enabled, reason, err := sshStatus(r.Context(), authInfo, h.spaceRepo, true, app)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to determine ssh status", "AppGUID", appGUID)
+	}
+	if !enabled {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("SSH is not enabled for the app: %s", reason)), "ssh disabled", "AppGUID", appGUID)
+	}
+
+	code, err := h.createCode(r.Context(), authInfo, app.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create ssh code", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSSHCode(code)), nil
+}
+
+// authorize mimics the UAA authorization code flow the cf cli uses to obtain
+// ssh codes for the ssh-proxy client
+func (h *SSH) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.ssh.authorize")
+
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != presenter.SSHProxyOAuthClient {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Only the code response type is supported for the ssh-proxy client"), "unsupported authorize request")
+	}
+
+	code, err := h.createCode(r.Context(), authInfo, "")
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create ssh code")
+	}
+
+	redirectURL := h.apiBaseURL
+	redirectURL.Path = OAuthAuthorizePath
+	redirectURL.RawQuery = url.Values{"code": {code.Code}}.Encode()
+
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", redirectURL.String()), nil
+}
+
+func (h *SSH) createCode(ctx context.Context, authInfo authorization.Info, appGUID string) (repositories.SSHCodeRecord, error) {
+	identity, err := h.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return repositories.SSHCodeRecord{}, err
+	}
+
+	return h.sshCodeRepo.CreateSSHCode(ctx, authInfo, repositories.CreateSSHCodeMessage{
+		User:    identity.Name,
+		Groups:  identity.Groups,
+		AppGUID: appGUID,
+	})
+}
+
+func sshStatus(ctx context.Context, authInfo authorization.Info, spaceRepo CFSpaceRepository, globallyEnabled bool, app repositories.AppRecord) (bool, string, error) {
+	if !globallyEnabled {
+		return false, "Disabled globally", nil
+	}
+
+	space, err := spaceRepo.GetSpace(ctx, authInfo, app.SpaceGUID)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !space.SSHEnabled {
+		return false, fmt.Sprintf("Disabled for space %s", space.Name), nil
+	}
+
+	if !app.SSHEnabled {
+		return false, "Disabled for app", nil
+	}
+
+	return true, "", nil
+}
+
+func (h *SSH) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SSH) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: AppSSHCodePath, Handler: h.createAppSSHCode},
+		{Method: "GET", Pattern: OAuthAuthorizePath, Handler: h.authorize},
+	}
+}
@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("SSH", func() {
+	var (
+		appRepo          *fake.CFAppRepository
+		spaceRepo        *fake.CFSpaceRepository
+		sshCodeRepo      *fake.SSHCodeRepository
+		identityProvider *fake.IdentityProvider
+		requestMethod    string
+		requestPath      string
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:       "app-guid",
+			SpaceGUID:  "space-guid",
+			SSHEnabled: true,
+		}, nil)
+
+		spaceRepo = new(fake.CFSpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:       "space-guid",
+			Name:       "my-space",
+			SSHEnabled: true,
+		}, nil)
+
+		sshCodeRepo = new(fake.SSHCodeRepository)
+		sshCodeRepo.CreateSSHCodeReturns(repositories.SSHCodeRecord{
+			Code:      "the-code",
+			ExpiresAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}, nil)
+
+		identityProvider = new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind, Groups: []string{"the-group"}}, nil)
+
+		apiHandler := handlers.NewSSH(
+			*serverURL,
+			appRepo,
+			spaceRepo,
+			sshCodeRepo,
+			identityProvider,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/:guid/ssh_code", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/apps/app-guid/ssh_code"
+		})
+
+		It("creates a code bound to the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(Equal(1))
+			_, _, message := sshCodeRepo.CreateSSHCodeArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateSSHCodeMessage{
+				User:    "the-user",
+				Groups:  []string{"the-group"},
+				AppGUID: "app-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.code", "the-code"),
+				MatchJSONPath("$.expires_at", "2024-01-02T03:04:05Z"),
+			)))
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("ssh is disabled for the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{Name: "my-space"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("SSH is not enabled for the app: Disabled for space my-space")
+				Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(BeZero())
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("SSH is not enabled for the app: Disabled for app")
+			})
+		})
+
+		When("getting the identity fails", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("creating the code fails", func() {
+			BeforeEach(func() {
+				sshCodeRepo.CreateSSHCodeReturns(repositories.SSHCodeRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /oauth/authorize", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/oauth/authorize?response_type=code&client_id=ssh-proxy"
+		})
+
+		It("redirects with a code that is not bound to an app", func() {
+			Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(Equal(1))
+			_, _, message := sshCodeRepo.CreateSSHCodeArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateSSHCodeMessage{
+				User:   "the-user",
+				Groups: []string{"the-group"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/oauth/authorize?code=the-code"))
+		})
+
+		When("the client is not the ssh proxy", func() {
+			BeforeEach(func() {
+				requestPath = "/oauth/authorize?response_type=code&client_id=cf"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only the code response type is supported for the ssh-proxy client")
+				Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(BeZero())
+			})
+		})
+	})
+})
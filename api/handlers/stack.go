@@ -4,9 +4,11 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -16,24 +18,35 @@ import (
 
 const (
 	StacksPath = "/v3/stacks"
+	StackPath  = "/v3/stacks/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name StackRepository . StackRepository
+
 type StackRepository interface {
-	ListStacks(ctx context.Context, authInfo authorization.Info) ([]repositories.StackRecord, error)
+	ListStacks(ctx context.Context, authInfo authorization.Info, message repositories.ListStacksMessage) ([]repositories.StackRecord, error)
+	GetStack(ctx context.Context, authInfo authorization.Info, guid string) (repositories.StackRecord, error)
+	CreateStack(ctx context.Context, authInfo authorization.Info, message repositories.CreateStackMessage) (repositories.StackRecord, error)
+	UpdateStack(ctx context.Context, authInfo authorization.Info, message repositories.UpdateStackMessage) (repositories.StackRecord, error)
+	DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error
+	GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error)
 }
 
 type Stack struct {
-	serverURL url.URL
-	stackRepo StackRepository
+	serverURL        url.URL
+	stackRepo        StackRepository
+	requestValidator RequestValidator
 }
 
 func NewStack(
 	serverURL url.URL,
 	stackRepo StackRepository,
+	requestValidator RequestValidator,
 ) *Stack {
 	return &Stack{
-		serverURL: serverURL,
-		stackRepo: stackRepo,
+		serverURL:        serverURL,
+		stackRepo:        stackRepo,
+		requestValidator: requestValidator,
 	}
 }
 
@@ -41,7 +54,12 @@ func (h *Stack) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.list")
 
-	stacks, err := h.stackRepo.ListStacks(r.Context(), authInfo)
+	payload := new(payloads.StackList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	stacks, err := h.stackRepo.ListStacks(r.Context(), authInfo, payload.ToMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch stacks from Kubernetes")
 	}
@@ -49,6 +67,79 @@ func (h *Stack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForStack, stacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Stack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch stack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.create")
+
+	var payload payloads.StackCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	stack, err := h.stackRepo.CreateStack(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create stack", "name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.StackUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.stackRepo.GetStack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch stack", "guid", guid)
+	}
+
+	stack, err := h.stackRepo.UpdateStack(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update stack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.stackRepo.GetStack(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch stack", "guid", guid)
+	}
+
+	if err = h.stackRepo.DeleteStack(r.Context(), authInfo, guid); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete stack", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(guid, presenter.StackDeleteOperation, h.serverURL)), nil
+}
+
 func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -56,5 +147,9 @@ func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 func (h *Stack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: StacksPath, Handler: h.list},
+		{Method: "GET", Pattern: StackPath, Handler: h.get},
+		{Method: "POST", Pattern: StacksPath, Handler: h.create},
+		{Method: "PATCH", Pattern: StackPath, Handler: h.update},
+		{Method: "DELETE", Pattern: StackPath, Handler: h.delete},
 	}
 }
@@ -3,65 +3,107 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Stack", func() {
 	var (
-		stackRepo *fake.StackRepository
-		req       *http.Request
+		stackRepo        *fake.StackRepository
+		requestValidator *fake.RequestValidator
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		stack            repositories.StackRecord
 	)
 
 	BeforeEach(func() {
+		requestBody = ""
 		stackRepo = new(fake.StackRepository)
 
-		apiHandler := NewStack(*serverURL, stackRepo)
+		stack = repositories.StackRecord{
+			GUID:             "stack-guid",
+			Name:             "cflinuxfs4",
+			Description:      "Ubuntu Jammy",
+			BuildRootfsImage: "my.repository/build:jammy",
+			RunRootfsImage:   "my.repository/run:jammy",
+			CreatedAt:        time.UnixMilli(1000),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+		}
+		stackRepo.GetStackReturns(stack, nil)
+
+		requestValidator = new(fake.RequestValidator)
+		apiHandler := NewStack(*serverURL, stackRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
 	Describe("the GET /v3/stacks endpoint", func() {
 		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.StackList{
+				Names: "io.buildpacks.stacks.jammy",
+			})
 			stackRepo.ListStacksReturns([]repositories.StackRecord{
 				{
 					Name:        "io.buildpacks.stacks.jammy",
 					Description: "Jammy Stack",
+					Default:     true,
 					CreatedAt:   time.UnixMilli(1000),
 					UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
 				},
 			}, nil)
 
-			var err error
-			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodGet
+			requestPath = "/v3/stacks?names=io.buildpacks.stacks.jammy"
 		})
 
-		It("returns the stacks for the default builder", func() {
+		It("returns the stacks", func() {
 			Expect(stackRepo.ListStacksCallCount()).To(Equal(1))
-			_, actualAuthInfo := stackRepo.ListStacksArgsForCall(0)
+			_, actualAuthInfo, message := stackRepo.ListStacksArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("io.buildpacks.stacks.jammy"))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/stacks"),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/stacks?names=io.buildpacks.stacks.jammy"),
 				MatchJSONPath("$.resources", HaveLen(1)),
 				MatchJSONPath("$.resources[0].name", "io.buildpacks.stacks.jammy"),
+				MatchJSONPath("$.resources[0].description", "Jammy Stack"),
+				MatchJSONPath("$.resources[0].default", BeTrue()),
 			)))
 		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("foo"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+				Expect(stackRepo.ListStacksCallCount()).To(BeZero())
+			})
+		})
+
 		When("there is some other error fetching the stacks", func() {
 			BeforeEach(func() {
 				stackRepo.ListStacksReturns([]repositories.StackRecord{}, errors.New("unknown!"))
@@ -72,4 +114,159 @@ var _ = Describe("Stack", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/stacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/stacks/stack-guid"
+		})
+
+		It("returns the stack", func() {
+			Expect(stackRepo.GetStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.GetStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-guid"),
+				MatchJSONPath("$.name", "cflinuxfs4"),
+				MatchJSONPath("$.build_rootfs_image", "my.repository/build:jammy"),
+				MatchJSONPath("$.run_rootfs_image", "my.repository/run:jammy"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/stacks/stack-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the stack", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewForbiddenError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/stacks endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackCreate{
+				Name:             "cflinuxfs4",
+				StackID:          "io.buildpacks.stacks.jammy",
+				BuildRootfsImage: "my.repository/build:jammy",
+				RunRootfsImage:   "my.repository/run:jammy",
+			})
+			stackRepo.CreateStackReturns(stack, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/stacks"
+			requestBody = "the-json-body"
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the stack", func() {
+			Expect(stackRepo.CreateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := stackRepo.CreateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(MatchFields(IgnoreExtras, Fields{
+				"Name":             Equal("cflinuxfs4"),
+				"StackID":          Equal("io.buildpacks.stacks.jammy"),
+				"BuildRootfsImage": Equal("my.repository/build:jammy"),
+				"RunRootfsImage":   Equal("my.repository/run:jammy"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "stack-guid")))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+				Expect(stackRepo.CreateStackCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized to create stacks", func() {
+			BeforeEach(func() {
+				stackRepo.CreateStackReturns(repositories.StackRecord{}, apierrors.NewForbiddenError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/stacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackUpdate{
+				RunRootfsImage: tools.PtrTo("my.repository/run:jammy-patched"),
+			})
+			stackRepo.UpdateStackReturns(stack, nil)
+
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/stacks/stack-guid"
+			requestBody = "the-json-body"
+		})
+
+		It("updates the stack", func() {
+			Expect(stackRepo.UpdateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := stackRepo.UpdateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("stack-guid"))
+			Expect(message.RunRootfsImage).To(PointTo(Equal("my.repository/run:jammy-patched")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "stack-guid")))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.UpdateStackCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/stacks/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/stacks/stack-guid"
+		})
+
+		It("deletes the stack", func() {
+			Expect(stackRepo.DeleteStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.DeleteStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/stack.delete~stack-guid"))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.DeleteStackCallCount()).To(BeZero())
+			})
+		})
+	})
 })
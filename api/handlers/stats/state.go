@@ -12,10 +12,14 @@ import (
 )
 
 type ProcessInstanceState struct {
-	ID        int
-	Type      string
-	State     korifiv1alpha1.InstanceState
-	Timestamp *metav1.Time
+	ID            int
+	Type          string
+	State         korifiv1alpha1.InstanceState
+	Timestamp     *metav1.Time
+	Details       string
+	CrashCount    int32
+	LastCrash     *korifiv1alpha1.InstanceCrash
+	CrashingSince *metav1.Time
 }
 
 //counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
@@ -49,10 +53,14 @@ func (c *ProcessInstancesStateCollector) CollectProcessInstancesStates(ctx conte
 		}
 
 		states = append(states, ProcessInstanceState{
-			ID:        instanceIdInt,
-			Type:      process.Type,
-			State:     instanceStatus.State,
-			Timestamp: instanceStatus.Timestamp,
+			ID:            instanceIdInt,
+			Type:          process.Type,
+			State:         instanceStatus.State,
+			Timestamp:     instanceStatus.Timestamp,
+			Details:       instanceStatus.Details,
+			CrashCount:    instanceStatus.CrashCount,
+			LastCrash:     instanceStatus.LastCrash,
+			CrashingSince: instanceStatus.CrashingSince,
 		})
 
 	}
@@ -29,7 +29,14 @@ var _ = Describe("State", func() {
 			Type: "web",
 			InstancesStatus: map[string]korifiv1alpha1.InstanceStatus{
 				"1": {
-					State: korifiv1alpha1.InstanceStateCrashed,
+					State:      korifiv1alpha1.InstanceStateCrashed,
+					Details:    "CrashLoopBackOff",
+					CrashCount: 3,
+					LastCrash: &korifiv1alpha1.InstanceCrash{
+						Reason:     "OOMKilled",
+						ExitStatus: 137,
+					},
+					CrashingSince: tools.PtrTo(metav1.NewTime(time.UnixMilli(1000).UTC())),
 				},
 				"2": {
 					State:     korifiv1alpha1.InstanceStateRunning,
@@ -68,9 +75,16 @@ var _ = Describe("State", func() {
 		Expect(stateErr).NotTo(HaveOccurred())
 		Expect(instancesState).To(ConsistOf(
 			stats.ProcessInstanceState{
-				ID:    1,
-				Type:  "web",
-				State: korifiv1alpha1.InstanceStateCrashed,
+				ID:         1,
+				Type:       "web",
+				State:      korifiv1alpha1.InstanceStateCrashed,
+				Details:    "CrashLoopBackOff",
+				CrashCount: 3,
+				LastCrash: &korifiv1alpha1.InstanceCrash{
+					Reason:     "OOMKilled",
+					ExitStatus: 137,
+				},
+				CrashingSince: tools.PtrTo(metav1.NewTime(time.UnixMilli(1000).UTC())),
 			},
 			stats.ProcessInstanceState{
 				ID:        2,
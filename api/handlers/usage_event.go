@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppUsageEventsPath                   = "/v3/app_usage_events"
+	AppUsageEventPath                    = "/v3/app_usage_events/{guid}"
+	AppUsageEventsPurgeAndReseedPath     = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+	ServiceUsageEventsPath               = "/v3/service_usage_events"
+	ServiceUsageEventPath                = "/v3/service_usage_events/{guid}"
+	ServiceUsageEventsPurgeAndReseedPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name UsageEventRepository . UsageEventRepository
+
+type UsageEventRepository interface {
+	ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message repositories.ListUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)
+	GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (repositories.AppUsageEventRecord, error)
+	PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error
+	ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+	GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (repositories.ServiceUsageEventRecord, error)
+	PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error
+}
+
+type UsageEvent struct {
+	serverURL        url.URL
+	usageEventRepo   UsageEventRepository
+	requestValidator RequestValidator
+}
+
+func NewUsageEvent(
+	serverURL url.URL,
+	usageEventRepo UsageEventRepository,
+	requestValidator RequestValidator,
+) *UsageEvent {
+	return &UsageEvent{
+		serverURL:        serverURL,
+		usageEventRepo:   usageEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *UsageEvent) listAppUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.list-app-usage-events")
+
+	payload := new(payloads.AppUsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	usageEvents, err := h.usageEventRepo.ListAppUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAppUsageEvent, usageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) getAppUsageEvent(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.get-app-usage-event")
+
+	guid := routing.URLParam(r, "guid")
+
+	usageEvent, err := h.usageEventRepo.GetAppUsageEvent(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get app usage event", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppUsageEvent(usageEvent, h.serverURL)), nil
+}
+
+func (h *UsageEvent) purgeAndReseedAppUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.purge-and-reseed-app-usage-events")
+
+	if err := h.usageEventRepo.PurgeAndReseedAppUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to purge and reseed app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *UsageEvent) listServiceUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.list-service-usage-events")
+
+	payload := new(payloads.ServiceUsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	usageEvents, err := h.usageEventRepo.ListServiceUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceUsageEvent, usageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) getServiceUsageEvent(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.get-service-usage-event")
+
+	guid := routing.URLParam(r, "guid")
+
+	usageEvent, err := h.usageEventRepo.GetServiceUsageEvent(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get service usage event", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceUsageEvent(usageEvent, h.serverURL)), nil
+}
+
+func (h *UsageEvent) purgeAndReseedServiceUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.usage-event.purge-and-reseed-service-usage-events")
+
+	if err := h.usageEventRepo.PurgeAndReseedServiceUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to purge and reseed service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *UsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *UsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.listAppUsageEvents},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.getAppUsageEvent},
+		{Method: "POST", Pattern: AppUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseedAppUsageEvents},
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.listServiceUsageEvents},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.getServiceUsageEvent},
+		{Method: "POST", Pattern: ServiceUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseedServiceUsageEvents},
+	}
+}
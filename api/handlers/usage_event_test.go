@@ -0,0 +1,251 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageEvent", func() {
+	var (
+		usageEventRepo   *fake.UsageEventRepository
+		requestValidator *fake.RequestValidator
+		requestMethod    string
+		requestPath      string
+	)
+
+	BeforeEach(func() {
+		usageEventRepo = new(fake.UsageEventRepository)
+		usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+			GUID:      "app-event-guid",
+			CreatedAt: time.UnixMilli(1000),
+			State:     "STARTED",
+			AppGUID:   "app-guid",
+		}, nil)
+		usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+			GUID:                "service-event-guid",
+			CreatedAt:           time.UnixMilli(1000),
+			State:               "CREATED",
+			ServiceInstanceGUID: "instance-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+		apiHandler := NewUsageEvent(*serverURL, usageEventRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/app_usage_events endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppUsageEventList{
+				AfterGUID: "some-guid",
+			})
+			usageEventRepo.ListAppUsageEventsReturns([]repositories.AppUsageEventRecord{
+				{GUID: "app-event-guid", State: "STARTED"},
+			}, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/app_usage_events?after_guid=some-guid"
+		})
+
+		It("returns the app usage events", func() {
+			Expect(usageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AfterGUID).To(Equal("some-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/app_usage_events?after_guid=some-guid"),
+				MatchJSONPath("$.resources", HaveLen(1)),
+				MatchJSONPath("$.resources[0].guid", "app-event-guid"),
+				MatchJSONPath("$.resources[0].state.current", "STARTED"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("foo"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+				Expect(usageEventRepo.ListAppUsageEventsCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the usage events fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListAppUsageEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/app_usage_events/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/app_usage_events/app-event-guid"
+		})
+
+		It("returns the app usage event", func() {
+			Expect(usageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("app-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "app-event-guid"),
+				MatchJSONPath("$.app.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/app-event-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the usage event", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := usageEventRepo.PurgeAndReseedAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedAppUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_usage_events endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceUsageEventList{
+				ServiceInstanceTypes: "managed_service_instance",
+			})
+			usageEventRepo.ListServiceUsageEventsReturns([]repositories.ServiceUsageEventRecord{
+				{GUID: "service-event-guid", State: "CREATED"},
+			}, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_usage_events?service_instance_types=managed_service_instance"
+		})
+
+		It("returns the service usage events", func() {
+			Expect(usageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.ServiceInstanceTypes).To(ConsistOf("managed_service_instance"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "service-event-guid"),
+				MatchJSONPath("$.resources[0].state", "CREATED"),
+			)))
+		})
+
+		When("listing the usage events fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListServiceUsageEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_usage_events/{guid} endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_usage_events/service-event-guid"
+		})
+
+		It("returns the service usage event", func() {
+			Expect(usageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, _, actualGUID := usageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualGUID).To(Equal("service-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-event-guid"),
+				MatchJSONPath("$.service_instance.guid", "instance-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/service-event-guid"),
+			)))
+		})
+
+		When("the usage event does not exist", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedServiceUsageEventsCallCount()).To(Equal(1))
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedServiceUsageEventsReturns(errors.New("purge-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -1,43 +1,118 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
-	"strings"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
 )
 
 const (
-	usersPath = "/v3/users"
+	UsersPath = "/v3/users"
+	UserPath  = UsersPath + "/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFUserRepository . CFUserRepository
+
+type CFUserRepository interface {
+	CreateUser(context.Context, authorization.Info, repositories.CreateUserMessage) (repositories.UserRecord, error)
+	ListUsers(context.Context, authorization.Info, repositories.ListUsersMessage) ([]repositories.UserRecord, error)
+	GetUser(context.Context, authorization.Info, string) (repositories.UserRecord, error)
+	DeleteUser(context.Context, authorization.Info, string) error
+	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
+}
+
 type User struct {
-	apiBaseURL url.URL
+	apiBaseURL       url.URL
+	userRepo         CFUserRepository
+	requestValidator RequestValidator
+}
+
+func NewUser(apiBaseURL url.URL, userRepo CFUserRepository, requestValidator RequestValidator) *User {
+	return &User{
+		apiBaseURL:       apiBaseURL,
+		userRepo:         userRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *User) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.create")
+
+	var payload payloads.UserCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message := payload.ToMessage()
+	record, err := h.userRepo.CreateUser(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create user", "Username", message.Username, "Origin", message.Origin)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForUser(record, h.apiBaseURL)), nil
+}
+
+func (h *User) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.list")
+
+	payload := new(payloads.UserList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	users, err := h.userRepo.ListUsers(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list users")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForUser, users, h.apiBaseURL, *r.URL)), nil
 }
 
-func NewUser(apiBaseURL url.URL) User {
-	return User{
-		apiBaseURL: apiBaseURL,
+func (h *User) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.get")
+	userGUID := routing.URLParam(r, "guid")
+
+	user, err := h.userRepo.GetUser(r.Context(), authInfo, userGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch user", "UserGUID", userGUID)
 	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForUser(user, h.apiBaseURL)), nil
 }
 
-func (h User) list(req *http.Request) (*routing.Response, error) {
-	usernames := req.URL.Query().Get("usernames")
-	users := []string{}
-	if len(usernames) > 0 {
-		users = strings.Split(usernames, ",")
+func (h *User) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.user.delete")
+	userGUID := routing.URLParam(r, "guid")
+
+	if err := h.userRepo.DeleteUser(r.Context(), authInfo, userGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to delete user", "UserGUID", userGUID)
 	}
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForUser, users, h.apiBaseURL, *req.URL)), nil
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(userGUID, presenter.UserDeleteOperation, h.apiBaseURL)), nil
 }
 
-func (h User) UnauthenticatedRoutes() []routing.Route {
+func (h *User) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
 
-func (h User) AuthenticatedRoutes() []routing.Route {
+func (h *User) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
-		{Method: "GET", Pattern: usersPath, Handler: h.list},
+		{Method: "GET", Pattern: UsersPath, Handler: h.list},
+		{Method: "GET", Pattern: UserPath, Handler: h.get},
+		{Method: "POST", Pattern: UsersPath, Handler: h.create},
+		{Method: "DELETE", Pattern: UserPath, Handler: h.delete},
 	}
 }
@@ -1,55 +1,210 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("User", func() {
-	var query string
+	var (
+		userRepo         *fake.CFUserRepository
+		requestValidator *fake.RequestValidator
+		requestMethod    string
+		requestPath      string
+	)
 
 	BeforeEach(func() {
-		query = ""
-		userHandler := handlers.NewUser(*serverURL)
-		routerBuilder.LoadRoutes(userHandler)
+		userRepo = new(fake.CFUserRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		routerBuilder.LoadRoutes(handlers.NewUser(*serverURL, userRepo, requestValidator))
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequestWithContext(ctx, "GET", "/v3/users"+query, nil)
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-json-body"))
 		Expect(err).NotTo(HaveOccurred())
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
+	Describe("POST /v3/users", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/users"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.UserCreate{
+				Username: "bob",
+				Origin:   "uaa",
+			})
+			userRepo.CreateUserReturns(repositories.UserRecord{
+				GUID:      "uaa:bob",
+				Username:  "bob",
+				Origin:    "uaa",
+				CreatedAt: time.UnixMilli(1000),
+			}, nil)
+		})
+
+		It("creates the user", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(userRepo.CreateUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := userRepo.CreateUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateUserMessage{Username: "bob", Origin: "uaa"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "uaa:bob"),
+				MatchJSONPath("$.username", "bob"),
+				MatchJSONPath("$.origin", "uaa"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/users/uaa:bob"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the user already exists", func() {
+			BeforeEach(func() {
+				userRepo.CreateUserReturns(repositories.UserRecord{}, apierrors.NewUniquenessError(nil, "User with guid 'uaa:bob' already exists."))
+			})
+
+			It("returns an error", func() {
+				expectErrorResponse(http.StatusUnprocessableEntity, "CF-UniquenessError", "User with guid 'uaa:bob' already exists.", 10016)
+			})
+		})
+	})
+
 	Describe("GET /v3/users", func() {
-		When("no parameters are provided", func() {
-			It("returns an empty list", func() {
-				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.total_results", BeZero()),
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users"),
-				)))
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/users?usernames=bob,alice"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UserList{
+				Usernames: "bob,alice",
+			})
+			userRepo.ListUsersReturns([]repositories.UserRecord{
+				{GUID: "bob", Username: "bob"},
+				{GUID: "uaa:alice", Username: "alice", Origin: "uaa"},
+			}, nil)
+		})
+
+		It("lists the users", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := userRepo.ListUsersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Usernames).To(ConsistOf("bob", "alice"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users?usernames=bob,alice"),
+				MatchJSONPath("$.resources[0].username", "bob"),
+				MatchJSONPath("$.resources[0].origin", BeNil()),
+				MatchJSONPath("$.resources[1].username", "alice"),
+				MatchJSONPath("$.resources[1].origin", "uaa"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the users fails", func() {
+			BeforeEach(func() {
+				userRepo.ListUsersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/users/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/users/uaa:bob"
+
+			userRepo.GetUserReturns(repositories.UserRecord{GUID: "uaa:bob", Username: "bob", Origin: "uaa"}, nil)
+		})
+
+		It("returns the user", func() {
+			Expect(userRepo.GetUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, guid := userRepo.GetUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(guid).To(Equal("uaa:bob"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "uaa:bob"),
+				MatchJSONPath("$.presentation_name", "bob"),
+			)))
+		})
+
+		When("the user is not accessible", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewForbiddenError(nil, repositories.UserResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.UserResourceType)
 			})
 		})
+	})
 
-		When("usernames are passed", func() {
+	Describe("DELETE /v3/users/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/users/uaa:bob"
+		})
+
+		It("deletes the user", func() {
+			Expect(userRepo.DeleteUserCallCount()).To(Equal(1))
+			_, actualAuthInfo, guid := userRepo.DeleteUserArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(guid).To(Equal("uaa:bob"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/user.delete~uaa:bob"))
+		})
+
+		When("the user does not exist", func() {
 			BeforeEach(func() {
-				query = "?usernames=foo,bar"
-			})
-
-			It("returns a list of users matching the usernames", func() {
-				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
-					MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/users?usernames=foo,bar"),
-					MatchJSONPath("$.resources[0].username", "foo"),
-					MatchJSONPath("$.resources[1].username", "bar"),
-				)))
+				userRepo.DeleteUserReturns(apierrors.NewNotFoundError(nil, repositories.UserResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.UserResourceType)
 			})
 		})
 	})
@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
@@ -17,9 +19,12 @@ import (
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/stats"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"code.cloudfoundry.org/korifi/api/middleware"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/promql"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/conditions"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient"
@@ -29,13 +34,18 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	toolsregistry "code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 	"code.cloudfoundry.org/korifi/version"
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
+	"github.com/go-logr/logr"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -46,13 +56,17 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-var conditionTimeout = time.Second * 120
+var (
+	conditionTimeout      = time.Second * 120
+	metricsSampleInterval = time.Second * 15
+)
 
 func init() {
 	utilruntime.Must(metav1.AddMetaToScheme(scheme.Scheme))
@@ -95,6 +109,15 @@ func main() {
 
 	ctrl.Log.Info("starting Korifi API", "version", version.Version)
 
+	var tracerProvider *sdktrace.TracerProvider
+	if cfg.Tracing.Enabled {
+		traceExporter, err := tracing.NewOTLPExporter(context.Background(), cfg.Tracing)
+		if err != nil {
+			panic(fmt.Sprintf("could not create trace exporter: %v", err))
+		}
+		tracerProvider = tracing.Setup("korifi-api", traceExporter)
+	}
+
 	privilegedClient, err := client.NewWithWatch(k8sClientConfig, client.Options{})
 	if err != nil {
 		panic(fmt.Sprintf("could not create privileged k8s client: %v", err))
@@ -119,11 +142,17 @@ func main() {
 		panic(fmt.Sprintf("could not create kubernetes REST mapper: %v", err))
 	}
 
-	identityProvider := wireIdentityProvider(privilegedClient, k8sClientConfig)
+	oidcVerifier, err := wireOIDCVerifier(cfg.Experimental.OIDC)
+	if err != nil {
+		panic(fmt.Sprintf("could not create oidc verifier: %v", err))
+	}
+
+	identityProvider := wireIdentityProvider(privilegedClient, k8sClientConfig, oidcVerifier)
 	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
 	nsPermissions := authorization.NewNamespacePermissions(privilegedClient, cachingIdentityProvider)
 
 	userClientFactoryUnfiltered := authorization.NewUnprivilegedClientFactory(k8sClientConfig, mapper).
+		WithOIDCVerifier(oidcVerifier).
 		WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 			return k8s.NewRetryingClient(client, k8s.IsForbidden, k8s.NewDefaultBackoff())
 		})
@@ -161,11 +190,18 @@ func main() {
 	)
 	processRepo := repositories.NewProcessRepo(klient)
 	podRepo := repositories.NewPodRepo(klientUnfiltered)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(klient, privilegedClient, cfg.RootNamespace)
 	appRepo := repositories.NewAppRepo(
 		klient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
+		envVarGroupRepo,
+	)
+	dropletRepo := repositories.NewDropletRepo(
+		klient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType, cfg.ContainerRepositoryCreator),
+		cfg.ContainerRepositoryPrefix,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFBuild, korifiv1alpha1.CFBuildList](conditionTimeout),
 	)
-	dropletRepo := repositories.NewDropletRepo(klient)
 	routeRepo := repositories.NewRouteRepo(klient)
 	domainRepo := repositories.NewDomainRepo(
 		klientUnfiltered,
@@ -180,7 +216,8 @@ func main() {
 	)
 	logRepo := repositories.NewLogRepo(
 		klientUnfiltered,
-		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig),
+		privilegedClient,
+		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig).WithOIDCVerifier(oidcVerifier),
 		repositories.DefaultLogStreamer,
 	)
 	runnerInfoRepo := repositories.NewRunnerInfoRepository(
@@ -188,9 +225,15 @@ func main() {
 		cfg.RunnerName,
 		cfg.RootNamespace,
 	)
+	// Package bits are not pushed to the registry when the package blobstore
+	// is configured, so there are no package repositories to create
+	var packageRepositoryCreator toolsregistry.RepositoryCreator = toolsregistry.NoopRepositoryCreator{}
+	if !cfg.PackageBlobstore.IsEnabled() {
+		packageRepositoryCreator = toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType, cfg.ContainerRepositoryCreator)
+	}
 	packageRepo := repositories.NewPackageRepo(
 		klient,
-		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		packageRepositoryCreator,
 		cfg.ContainerRepositoryPrefix,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](conditionTimeout),
 		repositories.NewPackageSorter(),
@@ -211,12 +254,17 @@ func main() {
 		klientUnfiltered,
 		cfg.BuilderName,
 		cfg.RootNamespace,
+		cfg.DefaultLifecycleConfig.Stack,
 	)
+	usageEventRepo := repositories.NewUsageEventRepository(klient, cfg.RootNamespace, repositories.UsageEventSettleWindow)
+	auditEventRepo := repositories.NewAuditEventRepository(klient)
 	buildpackRepo := repositories.NewBuildpackRepository(
 		klientUnfiltered,
 		cfg.BuilderName,
 		cfg.RootNamespace,
 		repositories.NewBuildpackSorter(),
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType, cfg.ContainerRepositoryCreator),
+		cfg.ContainerRepositoryPrefix,
 	)
 	roleRepo := repositories.NewRoleRepo(
 		klient,
@@ -227,13 +275,24 @@ func main() {
 		cfg.RoleMappings,
 		repositories.NewRoleSorter(),
 	)
+	userRepo := repositories.NewUserRepo(klient, roleRepo, cfg.RootNamespace)
 	imageClient := image.NewClient(privilegedClientset)
 	imageRepo := repositories.NewImageRepository(
 		klientUnfiltered,
 		imageClient,
+		imageClient,
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
+	var packageBlobRepo handlers.PackageBlobRepository
+	var packageBlobstore blobstore.Store
+	if cfg.PackageBlobstore.IsEnabled() {
+		packageBlobstore, err = blobstore.NewStore(cfg.PackageBlobstore)
+		if err != nil {
+			panic(fmt.Sprintf("could not create package blobstore: %v", err))
+		}
+		packageBlobRepo = repositories.NewPackageBlobRepository(klientUnfiltered, packageBlobstore)
+	}
 	taskRepo := repositories.NewTaskRepo(
 		klient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](conditionTimeout),
@@ -243,12 +302,15 @@ func main() {
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(klient, cfg.RootNamespace, nsPermissions)
 	servicePlanRepo := repositories.NewServicePlanRepo(klient, cfg.RootNamespace, orgRepo)
 	securityGroupRepo := repositories.NewSecurityGroupRepo(klient, cfg.RootNamespace)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(klient, cfg.RootNamespace)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
+	stateCollector := manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+	manifestGenerator := manifest.NewGenerator(appRepo, packageRepo, stateCollector)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		stateCollector,
 		manifest.NewNormalizer(cfg.DefaultDomainName),
 		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 	)
@@ -258,11 +320,22 @@ func main() {
 	routerBuilder := routing.NewRouterBuilder()
 	routerBuilder.UseMiddleware(
 		middleware.Correlation(ctrl.Log),
+		middleware.Tracing,
 		middleware.CFCliVersion,
 		middleware.HTTPLogging,
+		middleware.HTTPMetrics,
 		chiMiddlewares.StripSlashes,
 	)
 
+	if cfg.RateLimit.Enabled {
+		routerBuilder.UseMiddleware(middleware.UnauthenticatedRateLimit(
+			cfg.RateLimit.UnauthenticatedLimit,
+			cfg.RateLimit.GetResetInterval(),
+			cfg.RateLimit.GetTrustedProxies(),
+			clock.RealClock{},
+		))
+	}
+
 	if !cfg.Experimental.ManagedServices.Enabled {
 		routerBuilder.UseMiddleware(middleware.DisableManagedServices)
 	}
@@ -285,12 +358,27 @@ func main() {
 		),
 	)
 
+	if cfg.RateLimit.Enabled {
+		routerBuilder.UseAuthMiddleware(middleware.RateLimit(
+			cachingIdentityProvider,
+			nsPermissions,
+			cfg.RootNamespace,
+			cfg.RoleMappings["admin"].Name,
+			cfg.RateLimit.GeneralLimit,
+			cfg.RateLimit.GetResetInterval(),
+			clock.RealClock{},
+		))
+	}
+
 	relationshipsRepo := relationships.NewResourseRelationshipsRepo(
 		serviceOfferingRepo,
 		serviceBrokerRepo,
 		servicePlanRepo,
 		spaceRepo,
 		orgRepo,
+		userRepo,
+		domainRepo,
+		appRepo,
 	)
 
 	instancesStateCollector := stats.NewProcessInstanceStateCollector(processRepo)
@@ -319,9 +407,14 @@ func main() {
 		)
 	}
 
+	appSSH, err := wireAppSSH(cfg.Experimental.SSH)
+	if err != nil {
+		panic(fmt.Sprintf("could not configure app ssh: %v", err))
+	}
+
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL, appSSH),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
@@ -340,6 +433,8 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			cfg.Experimental.SSH.Enabled,
+			relationshipsRepo,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -348,6 +443,7 @@ func main() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			relationshipsRepo,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
@@ -358,6 +454,7 @@ func main() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			packageBlobRepo,
 			requestValidator,
 			cfg.PackageRegistrySecretNames,
 		),
@@ -371,7 +468,10 @@ func main() {
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewProcess(
 			*serverURL,
@@ -380,6 +480,13 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			relationshipsRepo,
+		),
+		handlers.NewAutoscalingPolicy(
+			*serverURL,
+			requestValidator,
+			processRepo,
+			repositories.NewAutoscalingPolicyRepo(klient),
 		),
 		handlers.NewDomain(
 			*serverURL,
@@ -396,6 +503,17 @@ func main() {
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
+			requestValidator,
+		),
+		handlers.NewUsageEvent(
+			*serverURL,
+			usageEventRepo,
+			requestValidator,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
 		),
 		handlers.NewJob(
 			*serverURL,
@@ -406,6 +524,9 @@ func main() {
 				handlers.RouteDeleteJobType:                  routeRepo,
 				handlers.DomainDeleteJobType:                 domainRepo,
 				handlers.RoleDeleteJobType:                   roleRepo,
+				handlers.UserDeleteJobType:                   userRepo,
+				handlers.BuildpackDeleteJobType:              buildpackRepo,
+				handlers.StackDeleteJobType:                  stackRepo,
 				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
@@ -415,6 +536,7 @@ func main() {
 				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.DropletUploadJobType:                dropletRepo,
 			},
 			routeRepo,
 			500*time.Millisecond,
@@ -438,19 +560,30 @@ func main() {
 		handlers.NewSpaceManifest(
 			*serverURL,
 			manifest,
+			manifestGenerator,
 			spaceRepo,
 			requestValidator,
 		),
+		handlers.NewAppManifest(
+			*serverURL,
+			manifestGenerator,
+		),
 		handlers.NewRole(
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			relationshipsRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
-		handlers.NewUser(*serverURL),
+		handlers.NewUser(
+			*serverURL,
+			userRepo,
+			requestValidator,
+		),
 		handlers.NewBuildpack(
 			*serverURL,
 			buildpackRepo,
+			imageRepo,
 			requestValidator,
 		),
 		handlers.NewServiceInstance(
@@ -497,15 +630,42 @@ func main() {
 			spaceRepo,
 			requestValidator,
 		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			requestValidator,
+			envVarGroupRepo,
+		),
+		handlers.NewIsolationSegment(
+			*serverURL,
+			requestValidator,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+		),
 	}
 
 	if !cfg.Experimental.ExternalLogCache.Enabled {
+		metricsStore := promql.NewStore()
+		go actions.NewMetricsSampler(privilegedClient, metricsStore, metricsSampleInterval).
+			Start(logr.NewContext(context.Background(), ctrl.Log))
+
 		apiHandlers = append(apiHandlers, handlers.NewLogCache(
 			requestValidator,
 			appRepo,
 			buildRepo,
 			logRepo,
 			processStats,
+			metricsStore,
+		))
+	}
+
+	if cfg.Experimental.SSH.Enabled {
+		apiHandlers = append(apiHandlers, handlers.NewSSH(
+			*serverURL,
+			appRepo,
+			spaceRepo,
+			repositories.NewSSHCodeRepo(privilegedClient, cfg.Experimental.SSH.CodesNamespace, cfg.Experimental.SSH.GetCodeTTL()),
+			cachingIdentityProvider,
 		))
 	}
 
@@ -516,12 +676,22 @@ func main() {
 	routerBuilder.SetNotFoundHandler(handlers.NotFound)
 	routerBuilder.SetMethodNotAllowedHandler(handlers.NotFound)
 
+	go serveMetrics(cfg.MetricsPort)
+
+	serveMux := http.NewServeMux()
+	serveMux.Handle("/", routerBuilder.Build())
+	// Build pods download filesystem blobs on signed URLs, without CF
+	// credentials
+	if filesystemStore, ok := packageBlobstore.(*blobstore.FilesystemStore); ok {
+		serveMux.Handle(blobstore.FilesystemBlobsPath, filesystemStore)
+	}
+
 	portString := fmt.Sprintf(":%v", cfg.InternalPort)
 	tlsPath, tlsFound := os.LookupEnv("TLSCONFIG")
 
 	srv := &http.Server{
 		Addr:              portString,
-		Handler:           routerBuilder.Build(),
+		Handler:           serveMux,
 		IdleTimeout:       time.Duration(cfg.IdleTimeout * int(time.Second)),
 		ReadTimeout:       time.Duration(cfg.ReadTimeout * int(time.Second)),
 		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout * int(time.Second)),
@@ -529,6 +699,17 @@ func main() {
 		ErrorLog:          log.New(&tools.LogrWriter{Logger: ctrl.Log, Message: "HTTP server error"}, "", 0),
 	}
 
+	shutdownComplete := make(chan struct{})
+	go func() {
+		defer close(shutdownComplete)
+
+		<-ctrl.SetupSignalHandler().Done()
+		ctrl.Log.Info("shutting down")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			ctrl.Log.Error(err, "error shutting down the server")
+		}
+	}()
+
 	if tlsFound {
 		ctrl.Log.Info("listening with TLS on " + portString)
 		certPath := filepath.Join(tlsPath, "tls.crt")
@@ -554,22 +735,95 @@ func main() {
 			GetCertificate: certWatcher.GetCertificate,
 		}
 		err = srv.ListenAndServeTLS("", "")
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ctrl.Log.Error(err, "error serving TLS")
 			os.Exit(1)
 		}
 	} else {
 		ctrl.Log.Info("listening without TLS on " + portString)
 		err := srv.ListenAndServe()
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ctrl.Log.Error(err, "error serving HTTP")
 			os.Exit(1)
 		}
 	}
+
+	<-shutdownComplete
+
+	// Spans are exported in batches, flush the ones of the last requests
+	if tracerProvider != nil {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			ctrl.Log.Error(err, "error shutting down tracer provider")
+		}
+	}
+}
+
+// serveMetrics serves metrics on their own port, so that they are not exposed
+// through the API ingress, and scrapes are neither logged nor counted as CF
+// API requests
+func serveMetrics(port int) {
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+
+	portString := fmt.Sprintf(":%v", port)
+	metricsSrv := &http.Server{
+		Addr:              portString,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          log.New(&tools.LogrWriter{Logger: ctrl.Log, Message: "metrics server error"}, "", 0),
+	}
+
+	ctrl.Log.Info("serving metrics on " + portString)
+	if err := metricsSrv.ListenAndServe(); err != nil {
+		ctrl.Log.Error(err, "error serving metrics")
+		os.Exit(1)
+	}
 }
 
-func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
-	tokenReviewer := authorization.NewTokenReviewer(client)
+func wireIdentityProvider(client client.Client, restConfig *rest.Config, oidcVerifier *authorization.OIDCVerifier) authorization.IdentityProvider {
+	tokenInspector := authorization.NewOIDCTokenInspector(oidcVerifier, authorization.NewTokenReviewer(client))
 	certInspector := authorization.NewCertInspector(restConfig)
-	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
+	return authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
+}
+
+func wireAppSSH(sshConfig config.SSH) (presenter.AppSSH, error) {
+	if !sshConfig.Enabled {
+		return presenter.AppSSH{}, nil
+	}
+
+	hostKey, err := os.ReadFile(sshConfig.HostKeyPath)
+	if err != nil {
+		return presenter.AppSSH{}, fmt.Errorf("failed to read ssh host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(hostKey)
+	if err != nil {
+		return presenter.AppSSH{}, fmt.Errorf("failed to parse ssh host key: %w", err)
+	}
+
+	return presenter.AppSSH{
+		Address:            sshConfig.ProxyAddress,
+		HostKeyFingerprint: strings.TrimPrefix(ssh.FingerprintSHA256(signer.PublicKey()), "SHA256:"),
+	}, nil
+}
+
+func wireOIDCVerifier(oidcConfig config.OIDC) (*authorization.OIDCVerifier, error) {
+	if !oidcConfig.Enabled {
+		return authorization.NewOIDCVerifier(nil)
+	}
+
+	issuers := []authorization.OIDCIssuer{}
+	for _, issuer := range oidcConfig.Issuers {
+		issuers = append(issuers, authorization.OIDCIssuer{
+			URL:            issuer.IssuerURL,
+			ClientID:       issuer.ClientID,
+			CAData:         []byte(issuer.CACert),
+			UsernameClaim:  issuer.UsernameClaim,
+			UsernamePrefix: issuer.UsernamePrefix,
+			GroupsClaim:    issuer.GroupsClaim,
+			GroupsPrefix:   issuer.GroupsPrefix,
+		})
+	}
+
+	return authorization.NewOIDCVerifier(issuers)
 }
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "korifi_api"
+
+var (
+	Registry = prometheus.NewRegistry()
+
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests handled, partitioned by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, partitioned by method, route pattern and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	K8sClientRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "k8s_client_request_duration_seconds",
+		Help:      "Latency of Kubernetes client calls made on behalf of users, partitioned by verb.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"verb"})
+
+	K8sClientErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "k8s_client_errors_total",
+		Help:      "Number of failed Kubernetes client calls made on behalf of users, partitioned by verb and status reason.",
+	}, []string{"verb", "reason"})
+
+	IdentityCacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "identity_cache_requests_total",
+		Help:      "Number of identity cache lookups, partitioned by result (hit or miss).",
+	}, []string{"result"})
+
+	JobPollsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_polls_total",
+		Help:      "Number of job polls, partitioned by job type.",
+	}, []string{"type"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		K8sClientRequestDuration,
+		K8sClientErrorsTotal,
+		IdentityCacheRequestsTotal,
+		JobPollsTotal,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
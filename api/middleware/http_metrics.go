@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/metrics"
+	"code.cloudfoundry.org/korifi/api/routing"
+)
+
+const (
+	unmatchedRoute = "unmatched"
+	otherMethod    = "other"
+)
+
+func HTTPMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t1 := time.Now()
+
+		wrapper := &responseWriterWrapper{writer: w}
+		next.ServeHTTP(wrapper, r)
+
+		// The route pattern is only known once the router has matched the
+		// request. Unmatched requests share a label to keep the cardinality
+		// of the metrics bounded.
+		route := routing.RoutePattern(r)
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		status := wrapper.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		labels := []string{normalizeMethod(r.Method), route, strconv.Itoa(status)}
+		metrics.HTTPRequestsTotal.WithLabelValues(labels...).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(t1).Seconds())
+	})
+}
+
+// normalizeMethod maps non-standard methods to a shared label, as clients can
+// send arbitrary method names
+func normalizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return otherMethod
+	}
+}
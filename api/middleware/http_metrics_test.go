@@ -0,0 +1,93 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/api/metrics"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/routing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type metricsRoutable struct{}
+
+func (metricsRoutable) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{{
+		Method:  http.MethodGet,
+		Pattern: "/v3/apps/{guid}",
+		Handler: func(_ *http.Request) (*routing.Response, error) {
+			return routing.NewResponse(http.StatusTeapot), nil
+		},
+	}}
+}
+
+func (metricsRoutable) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+var _ = Describe("HTTPMetrics", func() {
+	var (
+		router     http.Handler
+		method     string
+		requestURL string
+		labels     []string
+		countPre   float64
+	)
+
+	BeforeEach(func() {
+		routerBuilder := routing.NewRouterBuilder()
+		routerBuilder.UseMiddleware(middleware.HTTPMetrics)
+		routerBuilder.LoadRoutes(metricsRoutable{})
+		router = routerBuilder.Build()
+
+		method = http.MethodGet
+		requestURL = "http://localhost/v3/apps/my-app"
+		labels = []string{http.MethodGet, "/v3/apps/{guid}", "418"}
+	})
+
+	JustBeforeEach(func() {
+		countPre = testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(labels...))
+
+		request, err := http.NewRequest(method, requestURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, request)
+	})
+
+	It("delegates to the next handler", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+	})
+
+	It("counts the request by method, route pattern and status", func() {
+		Expect(testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(labels...))).To(Equal(countPre + 1))
+	})
+
+	It("records the request latency", func() {
+		Expect(testutil.CollectAndCount(metrics.HTTPRequestDuration, "korifi_api_http_request_duration_seconds")).To(BeNumerically(">", 0))
+	})
+
+	When("no route matches the request", func() {
+		BeforeEach(func() {
+			requestURL = "http://localhost/does/not/exist"
+			labels = []string{http.MethodGet, "unmatched", "404"}
+		})
+
+		It("counts the request as unmatched", func() {
+			Expect(testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(labels...))).To(Equal(countPre + 1))
+		})
+	})
+
+	When("the request method is not a standard one", func() {
+		BeforeEach(func() {
+			method = "FOO"
+			labels = []string{"other", "unmatched", "405"}
+		})
+
+		It("counts the request as other method", func() {
+			Expect(testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(labels...))).To(Equal(countPre + 1))
+		})
+	})
+})
@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/utils/clock"
+)
+
+const (
+	rateLimitExceededDetail                = "Rate Limit Exceeded"
+	unauthenticatedRateLimitExceededDetail = "Rate Limit Exceeded: Unauthenticated requests from this IP address have exceeded the limit. Please log in."
+	failedAuthRateLimitExceededDetail      = "Rate Limit Exceeded: Requests from this IP address that failed to authenticate have exceeded the limit."
+)
+
+//counterfeiter:generate -o fake -fake-name RoleChecker . RoleChecker
+
+type RoleChecker interface {
+	HasRoleIn(ctx context.Context, identity authorization.Identity, namespace, roleName string) (bool, error)
+}
+
+type rateLimit struct {
+	identityProvider IdentityProvider
+	roleChecker      RoleChecker
+	rootNamespace    string
+	adminRoleName    string
+	adminCache       *cache.Expiring
+	counter          *rateLimitCounter
+}
+
+// RateLimit limits the number of requests an authenticated identity can make
+// per reset interval. Identities bound to the admin role in the root namespace
+// are not limited.
+func RateLimit(
+	identityProvider IdentityProvider,
+	roleChecker RoleChecker,
+	rootNamespace string,
+	adminRoleName string,
+	limit int,
+	resetInterval time.Duration,
+	clock clock.Clock,
+) func(http.Handler) http.Handler {
+	return (&rateLimit{
+		identityProvider: identityProvider,
+		roleChecker:      roleChecker,
+		rootNamespace:    rootNamespace,
+		adminRoleName:    adminRoleName,
+		adminCache:       cache.NewExpiringWithClock(clock),
+		counter:          newRateLimitCounter(limit, resetInterval, clock),
+	}).middleware
+}
+
+func (m *rateLimit) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logr.FromContextOrDiscard(r.Context()).WithName("rate-limit")
+
+		authInfo, ok := authorization.InfoFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity, err := m.identityProvider.GetIdentity(r.Context(), authInfo)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		isAdmin, err := m.isAdmin(r.Context(), identity)
+		if err != nil {
+			logger.Info("failed to check whether the user is an admin", "reason", err)
+		}
+
+		if isAdmin {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		m.counter.limit(logger, w, r, next, identity.Hash(), rateLimitExceededDetail)
+	})
+}
+
+func (m *rateLimit) isAdmin(ctx context.Context, identity authorization.Identity) (bool, error) {
+	if isAdmin, ok := m.adminCache.Get(identity.Hash()); ok {
+		return isAdmin.(bool), nil
+	}
+
+	isAdmin, err := m.roleChecker.HasRoleIn(ctx, identity, m.rootNamespace, m.adminRoleName)
+	if err != nil {
+		return false, err
+	}
+
+	m.adminCache.Set(identity.Hash(), isAdmin, cacheTTL)
+	return isAdmin, nil
+}
+
+// UnauthenticatedRateLimit limits the number of requests without an
+// Authorization header a client IP can make per reset interval. Requests with
+// an Authorization header are only counted when they fail to authenticate,
+// as they never reach RateLimit, and are rejected once the limit has been
+// exceeded. The
+// X-Forwarded-For header is only used to find the client IP when the request
+// comes from one of the trusted proxies.
+func UnauthenticatedRateLimit(limit int, resetInterval time.Duration, trustedProxies []*net.IPNet, clock clock.Clock) func(http.Handler) http.Handler {
+	counter := newRateLimitCounter(limit, resetInterval, clock)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := logr.FromContextOrDiscard(r.Context()).WithName("unauthenticated-rate-limit")
+			key := clientIP(r, trustedProxies)
+
+			if r.Header.Get("Authorization") != "" {
+				counter.limitFailures(logger, w, r, next, key, http.StatusUnauthorized, failedAuthRateLimitExceededDetail)
+				return
+			}
+
+			counter.limit(logger, w, r, next, key, unauthenticatedRateLimitExceededDetail)
+		})
+	}
+}
+
+// clientIP walks the X-Forwarded-For header from the right, as every trusted
+// proxy appends the address it received the request from. The first address
+// that is not a trusted proxy is the client, anything left of it could have
+// been made up by the client.
+func clientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteIP = r.RemoteAddr
+	}
+
+	if !isTrustedProxy(remoteIP, trustedProxies) {
+		return remoteIP
+	}
+
+	forwardedFor := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		forwardedIP := strings.TrimSpace(forwardedFor[i])
+		if forwardedIP == "" {
+			continue
+		}
+
+		if !isTrustedProxy(forwardedIP, trustedProxies) {
+			return forwardedIP
+		}
+	}
+
+	return remoteIP
+}
+
+func isTrustedProxy(address string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, trustedProxy := range trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// rateLimitCounter counts requests in fixed windows starting with the first
+// request of a key. Counts are kept in memory, so every API replica limits
+// requests independently.
+type rateLimitCounter struct {
+	maxRequests   int
+	resetInterval time.Duration
+	clock         clock.Clock
+	windows       *cache.Expiring
+	mutex         sync.Mutex
+}
+
+func newRateLimitCounter(maxRequests int, resetInterval time.Duration, clock clock.Clock) *rateLimitCounter {
+	return &rateLimitCounter{
+		maxRequests:   maxRequests,
+		resetInterval: resetInterval,
+		clock:         clock,
+		windows:       cache.NewExpiringWithClock(clock),
+	}
+}
+
+func (c *rateLimitCounter) hit(key string) rateLimitWindow {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.clock.Now()
+	window := rateLimitWindow{resetAt: now.Add(c.resetInterval)}
+	if existingWindow, ok := c.windows.Get(key); ok {
+		window = existingWindow.(rateLimitWindow)
+	}
+
+	window.count++
+	c.windows.Set(key, window, window.resetAt.Sub(now))
+
+	return window
+}
+
+func (c *rateLimitCounter) get(key string) (rateLimitWindow, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	window, ok := c.windows.Get(key)
+	if !ok {
+		return rateLimitWindow{}, false
+	}
+
+	return window.(rateLimitWindow), true
+}
+
+func (c *rateLimitCounter) limit(logger logr.Logger, w http.ResponseWriter, r *http.Request, next http.Handler, key string, exceededDetail string) {
+	window := c.hit(key)
+	c.setHeaders(w, window)
+
+	if window.count > c.maxRequests {
+		presentRateLimitExceeded(logger, w, exceededDetail)
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+// limitFailures only counts requests that next responds to with
+// failureStatus, and rejects all requests once the limit has been exceeded
+func (c *rateLimitCounter) limitFailures(logger logr.Logger, w http.ResponseWriter, r *http.Request, next http.Handler, key string, failureStatus int, exceededDetail string) {
+	if window, ok := c.get(key); ok && window.count >= c.maxRequests {
+		c.setHeaders(w, window)
+		presentRateLimitExceeded(logger, w, exceededDetail)
+		return
+	}
+
+	wrapper := &responseWriterWrapper{writer: w}
+	next.ServeHTTP(wrapper, r)
+
+	if wrapper.status == failureStatus {
+		c.hit(key)
+	}
+}
+
+func (c *rateLimitCounter) setHeaders(w http.ResponseWriter, window rateLimitWindow) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(c.maxRequests))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(c.maxRequests-window.count, 0)))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(window.resetAt.Unix(), 10))
+}
+
+func presentRateLimitExceeded(logger logr.Logger, w http.ResponseWriter, exceededDetail string) {
+	routing.PresentError(logger, w, apierrors.LogAndReturn(logger, apierrors.NewRateLimitExceededError(exceededDetail), "rate limit exceeded"))
+}
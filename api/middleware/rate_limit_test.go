@@ -0,0 +1,304 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/middleware/fake"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("RateLimit", func() {
+	var (
+		rateLimitMiddleware func(http.Handler) http.Handler
+		identityProvider    *fake.IdentityProvider
+		roleChecker         *fake.RoleChecker
+		fakeClock           *testing.FakeClock
+		teapotHandler       http.Handler
+		ctx                 context.Context
+		requestCount        int
+	)
+
+	serveRequests := func() {
+		for range requestCount {
+			rr = httptest.NewRecorder()
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/v3/apps", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			rateLimitMiddleware(teapotHandler).ServeHTTP(rr, request)
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = authorization.NewContext(context.Background(), &authorization.Info{Token: "a-token"})
+
+		identityProvider = new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "bob", Kind: rbacv1.UserKind}, nil)
+
+		roleChecker = new(fake.RoleChecker)
+		roleChecker.HasRoleInReturns(false, nil)
+
+		teapotHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		fakeClock = testing.NewFakeClock(time.Now())
+		rateLimitMiddleware = middleware.RateLimit(identityProvider, roleChecker, "cfroot", "cf-admin", 2, time.Hour, fakeClock)
+		requestCount = 1
+	})
+
+	JustBeforeEach(func() {
+		serveRequests()
+	})
+
+	It("delegates to the next middleware", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+	})
+
+	It("sets the rate limit headers", func() {
+		Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Limit", "2"))
+		Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Remaining", "1"))
+		Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Reset", strconv.FormatInt(fakeClock.Now().Add(time.Hour).Unix(), 10)))
+	})
+
+	It("checks whether the user is an admin", func() {
+		Expect(roleChecker.HasRoleInCallCount()).To(Equal(1))
+		_, actualIdentity, actualNamespace, actualRole := roleChecker.HasRoleInArgsForCall(0)
+		Expect(actualIdentity.Name).To(Equal("bob"))
+		Expect(actualNamespace).To(Equal("cfroot"))
+		Expect(actualRole).To(Equal("cf-admin"))
+	})
+
+	When("the limit is exceeded", func() {
+		BeforeEach(func() {
+			requestCount = 3
+		})
+
+		It("returns a rate limit exceeded error", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+			Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Remaining", "0"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"errors": [{
+					"code": 10013,
+					"title": "CF-RateLimitExceeded",
+					"detail": "Rate Limit Exceeded"
+				}]
+			}`)))
+		})
+
+		It("caches the admin check", func() {
+			Expect(roleChecker.HasRoleInCallCount()).To(Equal(1))
+		})
+
+		When("the reset interval passes", func() {
+			JustBeforeEach(func() {
+				fakeClock.Step(time.Hour + time.Second)
+				requestCount = 1
+				serveRequests()
+			})
+
+			It("resets the limit", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Remaining", "1"))
+			})
+		})
+
+		When("another user makes a request", func() {
+			JustBeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, nil)
+				requestCount = 1
+				serveRequests()
+			})
+
+			It("counts the requests separately", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				roleChecker.HasRoleInReturns(true, nil)
+			})
+
+			It("does not limit the requests", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+				Expect(rr.Header().Get("X-RateLimit-Limit")).To(BeEmpty())
+			})
+		})
+
+		When("checking whether the user is an admin fails", func() {
+			BeforeEach(func() {
+				roleChecker.HasRoleInReturns(false, errors.New("role-check-err"))
+			})
+
+			It("limits the requests", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+			})
+		})
+	})
+
+	When("the request is not authenticated", func() {
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+
+		It("delegates to the next middleware without limiting", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header().Get("X-RateLimit-Limit")).To(BeEmpty())
+		})
+	})
+
+	When("getting the identity fails", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("id-error"))
+		})
+
+		It("delegates to the next middleware without limiting", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header().Get("X-RateLimit-Limit")).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("UnauthenticatedRateLimit", func() {
+	var (
+		rateLimitMiddleware func(http.Handler) http.Handler
+		teapotHandler       http.Handler
+		authHeader          string
+		forwardedFor        string
+		remoteAddr          string
+		requestCount        int
+		handlerStatus       int
+	)
+
+	serveRequests := func() {
+		for range requestCount {
+			rr = httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "http://localhost/v3/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.RemoteAddr = remoteAddr
+			if authHeader != "" {
+				request.Header.Set("Authorization", authHeader)
+			}
+			if forwardedFor != "" {
+				request.Header.Set("X-Forwarded-For", forwardedFor)
+			}
+
+			rateLimitMiddleware(teapotHandler).ServeHTTP(rr, request)
+		}
+	}
+
+	BeforeEach(func() {
+		handlerStatus = http.StatusTeapot
+		teapotHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(handlerStatus)
+		})
+
+		_, trustedProxy, err := net.ParseCIDR("10.0.0.0/24")
+		Expect(err).NotTo(HaveOccurred())
+		rateLimitMiddleware = middleware.UnauthenticatedRateLimit(1, time.Hour, []*net.IPNet{trustedProxy}, testing.NewFakeClock(time.Now()))
+		authHeader = ""
+		forwardedFor = ""
+		remoteAddr = "10.0.0.1:5678"
+		requestCount = 2
+	})
+
+	JustBeforeEach(func() {
+		serveRequests()
+	})
+
+	It("limits the requests per client IP", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+		Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Limit", "1"))
+		Expect(rr).To(HaveHTTPBody(ContainSubstring("Unauthenticated requests from this IP address have exceeded the limit")))
+	})
+
+	When("another client makes a request through a trusted proxy", func() {
+		JustBeforeEach(func() {
+			forwardedFor = "192.168.0.1, 10.0.0.2"
+			requestCount = 1
+			serveRequests()
+		})
+
+		It("counts the requests separately", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+		})
+
+		When("the client makes up X-Forwarded-For addresses", func() {
+			JustBeforeEach(func() {
+				forwardedFor = "172.16.0.1, 192.168.0.1, 10.0.0.2"
+				serveRequests()
+			})
+
+			It("counts the requests of the client address appended by the proxy", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+			})
+		})
+	})
+
+	When("the request does not come from a trusted proxy", func() {
+		BeforeEach(func() {
+			remoteAddr = "192.168.0.1:5678"
+			requestCount = 1
+		})
+
+		JustBeforeEach(func() {
+			forwardedFor = "172.16.0.1"
+			requestCount = 1
+			serveRequests()
+		})
+
+		It("ignores the X-Forwarded-For header", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+		})
+	})
+
+	When("the request has an Authorization header", func() {
+		BeforeEach(func() {
+			authHeader = "Bearer a-token"
+		})
+
+		It("does not limit the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr.Header().Get("X-RateLimit-Limit")).To(BeEmpty())
+		})
+
+		When("the request fails to authenticate", func() {
+			BeforeEach(func() {
+				handlerStatus = http.StatusUnauthorized
+			})
+
+			It("limits the failed requests per client IP", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-RateLimit-Limit", "1"))
+				Expect(rr).To(HaveHTTPBody(ContainSubstring("Requests from this IP address that failed to authenticate have exceeded the limit")))
+			})
+		})
+
+		When("the client IP has exceeded the limit without an Authorization header", func() {
+			BeforeEach(func() {
+				authHeader = ""
+			})
+
+			JustBeforeEach(func() {
+				authHeader = "Bearer a-token"
+				requestCount = 1
+				serveRequests()
+			})
+
+			It("limits the request", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusTooManyRequests))
+			})
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/tools/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing wraps the request in a server span, continuing the trace of the
+// client if it sent a trace context. It is expected to run after
+// Correlation, so that the correlation ID can be recorded on the span.
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("korifi.correlation_id", w.Header().Get(CorrelationIDHeader)),
+			),
+		)
+		defer span.End()
+
+		wrapper := &responseWriterWrapper{writer: w}
+		next.ServeHTTP(wrapper, r.WithContext(ctx))
+
+		status := wrapper.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		if route := routing.RoutePattern(r); route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type tracingRoutable struct {
+	status        int
+	handlerSpanID *trace.SpanID
+}
+
+func (t tracingRoutable) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{{
+		Method:  http.MethodGet,
+		Pattern: "/v3/apps/{guid}",
+		Handler: func(r *http.Request) (*routing.Response, error) {
+			*t.handlerSpanID = trace.SpanContextFromContext(r.Context()).SpanID()
+			return routing.NewResponse(t.status), nil
+		},
+	}}
+}
+
+func (tracingRoutable) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+var _ = Describe("Tracing", func() {
+	var (
+		exporter      *tracetest.InMemoryExporter
+		status        int
+		traceParent   string
+		handlerSpanID trace.SpanID
+		span          sdktrace.ReadOnlySpan
+	)
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		DeferCleanup(func() {
+			otel.SetTracerProvider(noop.NewTracerProvider())
+		})
+
+		status = http.StatusTeapot
+		traceParent = ""
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder := routing.NewRouterBuilder()
+		routerBuilder.UseMiddleware(middleware.Correlation(logr.Discard()), middleware.Tracing)
+		routerBuilder.LoadRoutes(tracingRoutable{status: status, handlerSpanID: &handlerSpanID})
+
+		request, err := http.NewRequest(http.MethodGet, "http://localhost/v3/apps/my-app", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set(middleware.CorrelationIDHeader, "my-correlation-id")
+		if traceParent != "" {
+			request.Header.Set("traceparent", traceParent)
+		}
+
+		rr = httptest.NewRecorder()
+		routerBuilder.Build().ServeHTTP(rr, request)
+
+		spans := exporter.GetSpans().Snapshots()
+		Expect(spans).To(HaveLen(1))
+		span = spans[0]
+	})
+
+	It("delegates to the next handler", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+	})
+
+	It("names the span after the route pattern", func() {
+		Expect(span.Name()).To(Equal("GET /v3/apps/{guid}"))
+		Expect(span.SpanKind()).To(Equal(trace.SpanKindServer))
+	})
+
+	It("records the request attributes", func() {
+		Expect(span.Attributes()).To(ContainElements(
+			attribute.String("http.route", "/v3/apps/{guid}"),
+			attribute.Int("http.response.status_code", http.StatusTeapot),
+			attribute.String("korifi.correlation_id", "my-correlation-id"),
+		))
+		Expect(span.Status().Code).To(Equal(codes.Unset))
+	})
+
+	It("passes the span to the handler", func() {
+		Expect(handlerSpanID).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	When("the client sends a trace context", func() {
+		BeforeEach(func() {
+			traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		})
+
+		It("continues the client trace", func() {
+			Expect(span.SpanContext().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(span.Parent().SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		})
+	})
+
+	When("the handler fails", func() {
+		BeforeEach(func() {
+			status = http.StatusInternalServerError
+		})
+
+		It("marks the span as failed", func() {
+			Expect(span.Status().Code).To(Equal(codes.Error))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"regexp"
 
 	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -81,17 +82,40 @@ func (d AppSetCurrentDroplet) Validate() error {
 	)
 }
 
+type AppGet struct {
+	IncludeResourceRules []params.IncludeResourceRule
+}
+
+func (g AppGet) Validate() error {
+	return jellidation.ValidateStruct(&g,
+		jellidation.Field(&g.IncludeResourceRules, includeResourceRulesOneOf("space", "space.organization")),
+	)
+}
+
+func (g *AppGet) SupportedKeys() []string {
+	return []string{"include"}
+}
+
+func (g *AppGet) DecodeFromURLValues(values url.Values) error {
+	g.IncludeResourceRules = params.ParseIncludes(values)
+	return nil
+}
+
 type AppList struct {
-	Names         string
-	GUIDs         string
-	SpaceGUIDs    string
-	OrderBy       string
-	LabelSelector string
+	Names                string
+	GUIDs                string
+	SpaceGUIDs           string
+	OrganizationGUIDs    string
+	OrderBy              string
+	LabelSelector        string
+	IncludeResourceRules []params.IncludeResourceRule
+	Timestamps           repositories.TimestampFilters
 }
 
 func (a AppList) Validate() error {
 	return jellidation.ValidateStruct(&a,
 		jellidation.Field(&a.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name", "state")),
+		jellidation.Field(&a.IncludeResourceRules, includeResourceRulesOneOf("space", "space.organization")),
 	)
 }
 
@@ -102,20 +126,33 @@ func (a *AppList) ToMessage() repositories.ListAppsMessage {
 		SpaceGUIDs:    parse.ArrayParam(a.SpaceGUIDs),
 		LabelSelector: a.LabelSelector,
 		OrderBy:       a.OrderBy,
+		Timestamps:    a.Timestamps,
+	}
+}
+
+func (a *AppList) ToListSpacesMessage() repositories.ListSpacesMessage {
+	return repositories.ListSpacesMessage{
+		GUIDs:             parse.ArrayParam(a.SpaceGUIDs),
+		OrganizationGUIDs: parse.ArrayParam(a.OrganizationGUIDs),
 	}
 }
 
 func (a *AppList) SupportedKeys() []string {
-	return []string{"names", "guids", "space_guids", "order_by", "per_page", "page", "label_selector"}
+	return append([]string{"names", "guids", "space_guids", "organization_guids", "order_by", "per_page", "page", "label_selector", "include"}, params.TimestampFilterKeys()...)
 }
 
 func (a *AppList) DecodeFromURLValues(values url.Values) error {
 	a.Names = values.Get("names")
 	a.GUIDs = values.Get("guids")
 	a.SpaceGUIDs = values.Get("space_guids")
+	a.OrganizationGUIDs = values.Get("organization_guids")
 	a.OrderBy = values.Get("order_by")
 	a.LabelSelector = values.Get("label_selector")
-	return nil
+	a.IncludeResourceRules = params.ParseIncludes(values)
+
+	var err error
+	a.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type AppPatchEnvVars struct {
@@ -135,31 +172,35 @@ func (p AppPatchEnvVars) Validate() error {
 }
 
 func (a *AppPatchEnvVars) ToMessage(appGUID, spaceGUID string) repositories.PatchAppEnvVarsMessage {
-	message := repositories.PatchAppEnvVarsMessage{
+	return repositories.PatchAppEnvVarsMessage{
 		AppGUID:              appGUID,
 		SpaceGUID:            spaceGUID,
-		EnvironmentVariables: map[string]*string{},
+		EnvironmentVariables: toEnvVarPatch(a.Var),
 	}
+}
+
+func toEnvVarPatch(vars map[string]any) map[string]*string {
+	envVars := map[string]*string{}
 
-	for k, v := range a.Var {
+	for k, v := range vars {
 		switch v := v.(type) {
 		case nil:
-			message.EnvironmentVariables[k] = nil
+			envVars[k] = nil
 		case bool:
 			stringVar := fmt.Sprintf("%t", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case float32:
 			stringVar := fmt.Sprintf("%f", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case int:
 			stringVar := fmt.Sprintf("%d", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case string:
-			message.EnvironmentVariables[k] = &v
+			envVars[k] = &v
 		}
 	}
 
-	return message
+	return envVars
 }
 
 type AppPatch struct {
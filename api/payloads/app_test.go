@@ -1,7 +1,10 @@
 package payloads_test
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -31,6 +34,17 @@ var _ = Describe("AppList", func() {
 			Entry("order_by state", "order_by=state", payloads.AppList{OrderBy: "state"}),
 			Entry("order_by -state", "order_by=-state", payloads.AppList{OrderBy: "-state"}),
 			Entry("label_selector=foo", "label_selector=foo", payloads.AppList{LabelSelector: "foo"}),
+			Entry("include", "include=space,space.organization", payloads.AppList{IncludeResourceRules: []params.IncludeResourceRule{
+				{RelationshipPath: []string{"space"}, Fields: []string{}},
+				{RelationshipPath: []string{"space", "organization"}, Fields: []string{}},
+			}}),
+			Entry("organization_guids", "organization_guids=org_guid", payloads.AppList{OrganizationGUIDs: "org_guid"}),
+			Entry("updated_ats[lt]", "updated_ats[lt]=2024-01-01T10:00:00Z", payloads.AppList{Timestamps: repositories.TimestampFilters{
+				UpdatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampLessThan,
+					Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+				}},
+			}}),
 		)
 
 		DescribeTable("invalid query",
@@ -39,6 +53,8 @@ var _ = Describe("AppList", func() {
 				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 			},
 			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid include", "include=organization", "value must be one of"),
+			Entry("invalid created_ats", "created_ats=yesterday", "not a valid RFC3339 timestamp"),
 		)
 	})
 
@@ -60,6 +76,43 @@ var _ = Describe("AppList", func() {
 			}))
 		})
 	})
+
+	Describe("ToListSpacesMessage", func() {
+		It("translates to a spaces repository message", func() {
+			appList := payloads.AppList{
+				SpaceGUIDs:        "s1,s2",
+				OrganizationGUIDs: "o1,o2",
+			}
+			Expect(appList.ToListSpacesMessage()).To(Equal(repositories.ListSpacesMessage{
+				GUIDs:             []string{"s1", "s2"},
+				OrganizationGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("AppGet", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppGet payloads.AppGet) {
+			actualAppGet, decodeErr := decodeQuery[payloads.AppGet](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppGet).To(Equal(expectedAppGet))
+		},
+		Entry("no include", "", payloads.AppGet{}),
+		Entry("include", "include=space.organization", payloads.AppGet{IncludeResourceRules: []params.IncludeResourceRule{
+			{RelationshipPath: []string{"space", "organization"}, Fields: []string{}},
+		}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppGet](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid include", "include=foo", "value must be one of"),
+		Entry("unsupported key", "foo=bar", "unsupported query parameter: foo"),
+	)
 })
 
 var _ = Describe("App payload validation", func() {
@@ -0,0 +1,42 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AuditEventList struct {
+	GUIDs       string
+	Types       string
+	TargetGUIDs string
+	SpaceGUIDs  string
+	Timestamps  repositories.TimestampFilters
+}
+
+func (l AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		GUIDs:       parse.ArrayParam(l.GUIDs),
+		Types:       parse.ArrayParam(l.Types),
+		TargetGUIDs: parse.ArrayParam(l.TargetGUIDs),
+		SpaceGUIDs:  parse.ArrayParam(l.SpaceGUIDs),
+		Timestamps:  l.Timestamps,
+	}
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	return append([]string{"guids", "types", "target_guids", "space_guids", "per_page", "page"}, params.TimestampFilterKeys()...)
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Types = values.Get("types")
+	l.TargetGUIDs = values.Get("target_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
+}
@@ -0,0 +1,55 @@
+package payloads_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+var _ = Describe("AuditEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.AuditEventList) {
+			actualList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=a,b", payloads.AuditEventList{GUIDs: "a,b"}),
+		Entry("types", "types=audit.app.process.crash", payloads.AuditEventList{Types: "audit.app.process.crash"}),
+		Entry("target_guids", "target_guids=a,b", payloads.AuditEventList{TargetGUIDs: "a,b"}),
+		Entry("space_guids", "space_guids=a,b", payloads.AuditEventList{SpaceGUIDs: "a,b"}),
+		Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.AuditEventList{Timestamps: repositories.TimestampFilters{
+			CreatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampGreaterThanOrEqual,
+				Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			}},
+		}}),
+		Entry("empty", "", payloads.AuditEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	It("converts to a message", func() {
+		Expect(payloads.AuditEventList{
+			GUIDs:       "a,b",
+			Types:       "audit.app.process.crash",
+			TargetGUIDs: "c",
+			SpaceGUIDs:  "d",
+		}.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+			GUIDs:       []string{"a", "b"},
+			Types:       []string{"audit.app.process.crash"},
+			TargetGUIDs: []string{"c"},
+			SpaceGUIDs:  []string{"d"},
+		}))
+	})
+})
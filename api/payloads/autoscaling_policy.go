@@ -0,0 +1,136 @@
+package payloads
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"time"
+
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/jellydator/validation"
+)
+
+var timeOfDayRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+type AutoscalingPolicySet struct {
+	InstanceMinCount        int32                       `json:"instance_min_count"`
+	InstanceMaxCount        int32                       `json:"instance_max_count"`
+	CPUUtilizationTarget    *int32                      `json:"cpu_utilization_target"`
+	MemoryUtilizationTarget *int32                      `json:"memory_utilization_target"`
+	Schedules               *AutoscalingPolicySchedules `json:"schedules"`
+	Vertical                *AutoscalingPolicyVertical  `json:"vertical"`
+}
+
+type AutoscalingPolicySchedules struct {
+	Timezone          string                         `json:"timezone"`
+	RecurringSchedule []AutoscalingRecurringSchedule `json:"recurring_schedule"`
+}
+
+type AutoscalingRecurringSchedule struct {
+	DaysOfWeek       []int32 `json:"days_of_week"`
+	StartTime        string  `json:"start_time"`
+	EndTime          string  `json:"end_time"`
+	InstanceMinCount int32   `json:"instance_min_count"`
+	InstanceMaxCount int32   `json:"instance_max_count"`
+}
+
+type AutoscalingPolicyVertical struct {
+	MemoryInMBMin int64 `json:"memory_in_mb_min"`
+	MemoryInMBMax int64 `json:"memory_in_mb_max"`
+}
+
+func (p AutoscalingPolicySet) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.InstanceMinCount, payload_validation.StrictlyRequired, validation.Min(int32(1)).Error("must be greater than 0")),
+		validation.Field(&p.InstanceMaxCount, payload_validation.StrictlyRequired, validation.Min(p.InstanceMinCount).Error("must be greater than or equal to instance_min_count")),
+		validation.Field(&p.CPUUtilizationTarget, validation.Min(int32(1)).Error("must be greater than 0"), validation.NilOrNotEmpty.Error("must be greater than 0")),
+		validation.Field(&p.MemoryUtilizationTarget, validation.Min(int32(1)).Error("must be greater than 0"), validation.NilOrNotEmpty.Error("must be greater than 0")),
+		validation.Field(&p.Schedules),
+		validation.Field(&p.Vertical),
+	)
+}
+
+func (s AutoscalingPolicySchedules) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Timezone, validation.By(validateTimezone)),
+		validation.Field(&s.RecurringSchedule),
+	)
+}
+
+func (s AutoscalingRecurringSchedule) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.DaysOfWeek, payload_validation.StrictlyRequired, validation.Each(
+			validation.Required.Error("must be between 1 and 7"),
+			validation.Min(int32(1)).Error("must be between 1 and 7"),
+			validation.Max(int32(7)).Error("must be between 1 and 7"),
+		)),
+		validation.Field(&s.StartTime, payload_validation.StrictlyRequired, validation.Match(timeOfDayRegex).Error("must be a time of day in HH:MM format")),
+		validation.Field(&s.EndTime, payload_validation.StrictlyRequired,
+			validation.Match(timeOfDayRegex).Error("must be a time of day in HH:MM format"),
+			validation.When(timeOfDayRegex.MatchString(s.StartTime), validation.By(func(value any) error {
+				// zero padded HH:MM times sort lexicographically
+				if value.(string) <= s.StartTime {
+					return errors.New("must be after start_time")
+				}
+				return nil
+			})),
+		),
+		validation.Field(&s.InstanceMinCount, payload_validation.StrictlyRequired, validation.Min(int32(1)).Error("must be greater than 0")),
+		validation.Field(&s.InstanceMaxCount, payload_validation.StrictlyRequired, validation.Min(s.InstanceMinCount).Error("must be greater than or equal to instance_min_count")),
+	)
+}
+
+func (v AutoscalingPolicyVertical) Validate() error {
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.MemoryInMBMin, payload_validation.StrictlyRequired, validation.Min(int64(1)).Error("must be greater than 0")),
+		validation.Field(&v.MemoryInMBMax, payload_validation.StrictlyRequired, validation.Min(v.MemoryInMBMin).Error("must be greater than or equal to memory_in_mb_min")),
+	)
+}
+
+func validateTimezone(value any) error {
+	timezone, ok := value.(string)
+	if !ok {
+		return errors.New("must be a string")
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.New("must be a valid IANA time zone")
+	}
+
+	return nil
+}
+
+func (p AutoscalingPolicySet) ToMessage(processGUID, spaceGUID string) repositories.SetAutoscalingPolicyMessage {
+	message := repositories.SetAutoscalingPolicyMessage{
+		ProcessGUID:              processGUID,
+		SpaceGUID:                spaceGUID,
+		MinInstances:             p.InstanceMinCount,
+		MaxInstances:             p.InstanceMaxCount,
+		CPUUtilizationPercent:    p.CPUUtilizationTarget,
+		MemoryUtilizationPercent: p.MemoryUtilizationTarget,
+	}
+
+	if p.Schedules != nil {
+		message.Timezone = p.Schedules.Timezone
+		message.Schedules = slices.Collect(it.Map(slices.Values(p.Schedules.RecurringSchedule), func(s AutoscalingRecurringSchedule) repositories.AutoscalingSchedule {
+			return repositories.AutoscalingSchedule{
+				DaysOfWeek:   s.DaysOfWeek,
+				StartTime:    s.StartTime,
+				EndTime:      s.EndTime,
+				MinInstances: s.InstanceMinCount,
+				MaxInstances: s.InstanceMaxCount,
+			}
+		}))
+	}
+
+	if p.Vertical != nil {
+		message.Vertical = &repositories.VerticalAutoscaling{
+			MinMemoryMB: p.Vertical.MemoryInMBMin,
+			MaxMemoryMB: p.Vertical.MemoryInMBMax,
+		}
+	}
+
+	return message
+}
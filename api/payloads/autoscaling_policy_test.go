@@ -0,0 +1,155 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("AutoscalingPolicySet", func() {
+	var (
+		setPayload           payloads.AutoscalingPolicySet
+		autoscalingPolicySet *payloads.AutoscalingPolicySet
+		validatorErr         error
+	)
+
+	BeforeEach(func() {
+		autoscalingPolicySet = new(payloads.AutoscalingPolicySet)
+		setPayload = payloads.AutoscalingPolicySet{
+			InstanceMinCount:     1,
+			InstanceMaxCount:     5,
+			CPUUtilizationTarget: tools.PtrTo[int32](70),
+			Schedules: &payloads.AutoscalingPolicySchedules{
+				Timezone: "Europe/London",
+				RecurringSchedule: []payloads.AutoscalingRecurringSchedule{{
+					DaysOfWeek:       []int32{1, 2, 3, 4, 5},
+					StartTime:        "08:00",
+					EndTime:          "18:00",
+					InstanceMinCount: 3,
+					InstanceMaxCount: 10,
+				}},
+			},
+			Vertical: &payloads.AutoscalingPolicyVertical{
+				MemoryInMBMin: 256,
+				MemoryInMBMax: 2048,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(setPayload), autoscalingPolicySet)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(autoscalingPolicySet).To(PointTo(Equal(setPayload)))
+	})
+
+	When("instance_min_count is not set", func() {
+		BeforeEach(func() {
+			setPayload.InstanceMinCount = 0
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "instance_min_count cannot be blank")
+		})
+	})
+
+	When("instance_max_count is lower than instance_min_count", func() {
+		BeforeEach(func() {
+			setPayload.InstanceMinCount = 4
+			setPayload.InstanceMaxCount = 3
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "instance_max_count must be greater than or equal to instance_min_count")
+		})
+	})
+
+	When("the cpu utilization target is not positive", func() {
+		BeforeEach(func() {
+			setPayload.CPUUtilizationTarget = tools.PtrTo[int32](0)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "cpu_utilization_target must be greater than 0")
+		})
+	})
+
+	When("the timezone is invalid", func() {
+		BeforeEach(func() {
+			setPayload.Schedules.Timezone = "Mars/Olympus_Mons"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "timezone must be a valid IANA time zone")
+		})
+	})
+
+	When("a schedule day of week is out of range", func() {
+		BeforeEach(func() {
+			setPayload.Schedules.RecurringSchedule[0].DaysOfWeek = []int32{0}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "days_of_week")
+		})
+	})
+
+	When("a schedule start time is malformed", func() {
+		BeforeEach(func() {
+			setPayload.Schedules.RecurringSchedule[0].StartTime = "8am"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "start_time must be a time of day in HH:MM format")
+		})
+	})
+
+	When("a schedule ends before it starts", func() {
+		BeforeEach(func() {
+			setPayload.Schedules.RecurringSchedule[0].EndTime = "07:00"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "end_time must be after start_time")
+		})
+	})
+
+	When("the vertical memory maximum is lower than the minimum", func() {
+		BeforeEach(func() {
+			setPayload.Vertical.MemoryInMBMax = 128
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb_max must be greater than or equal to memory_in_mb_min")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(setPayload.ToMessage("process-guid", "space-guid")).To(Equal(repositories.SetAutoscalingPolicyMessage{
+				ProcessGUID:           "process-guid",
+				SpaceGUID:             "space-guid",
+				MinInstances:          1,
+				MaxInstances:          5,
+				CPUUtilizationPercent: tools.PtrTo[int32](70),
+				Timezone:              "Europe/London",
+				Schedules: []repositories.AutoscalingSchedule{{
+					DaysOfWeek:   []int32{1, 2, 3, 4, 5},
+					StartTime:    "08:00",
+					EndTime:      "18:00",
+					MinInstances: 3,
+					MaxInstances: 10,
+				}},
+				Vertical: &repositories.VerticalAutoscaling{
+					MinMemoryMB: 256,
+					MaxMemoryMB: 2048,
+				},
+			}))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -55,6 +56,7 @@ type BuildList struct {
 	AppGUIDs     string
 	States       string
 	OrderBy      string
+	Timestamps   repositories.TimestampFilters
 }
 
 func (b *BuildList) ToMessage() repositories.ListBuildsMessage {
@@ -63,11 +65,12 @@ func (b *BuildList) ToMessage() repositories.ListBuildsMessage {
 		AppGUIDs:     parse.ArrayParam(b.AppGUIDs),
 		States:       parse.ArrayParam(b.States),
 		OrderBy:      b.OrderBy,
+		Timestamps:   b.Timestamps,
 	}
 }
 
 func (p *BuildList) SupportedKeys() []string {
-	return []string{"package_guids", "app_guids", "states", "order_by", "per_page", "page"}
+	return append([]string{"package_guids", "app_guids", "states", "order_by", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (p *BuildList) DecodeFromURLValues(values url.Values) error {
@@ -75,7 +78,10 @@ func (p *BuildList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
 	p.States = values.Get("states")
 	p.OrderBy = values.Get("order_by")
-	return nil
+
+	var err error
+	p.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (p BuildList) Validate() error {
@@ -1,11 +1,15 @@
 package payloads_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 var _ = Describe("BuildpackList", func() {
@@ -24,6 +28,12 @@ var _ = Describe("BuildpackList", func() {
 			Entry("-updated_at", "order_by=-updated_at", payloads.BuildpackList{OrderBy: "-updated_at"}),
 			Entry("position", "order_by=position", payloads.BuildpackList{OrderBy: "position"}),
 			Entry("-position", "order_by=-position", payloads.BuildpackList{OrderBy: "-position"}),
+			Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.BuildpackList{Timestamps: repositories.TimestampFilters{
+				CreatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampGreaterThanOrEqual,
+					Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+				}},
+			}}),
 			Entry("empty", "order_by=", payloads.BuildpackList{OrderBy: ""}),
 		)
 
@@ -45,3 +55,157 @@ var _ = Describe("BuildpackList", func() {
 		Entry("created_at", payloads.BuildpackList{OrderBy: "created_at"}, repositories.ListBuildpacksMessage{OrderBy: "created_at"}),
 	)
 })
+
+var _ = Describe("BuildpackCreate", func() {
+	var (
+		createPayload   payloads.BuildpackCreate
+		buildpackCreate *payloads.BuildpackCreate
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		buildpackCreate = new(payloads.BuildpackCreate)
+		createPayload = payloads.BuildpackCreate{
+			Name:     "my-buildpack",
+			Stack:    "cflinuxfs4",
+			Position: tools.PtrTo(2),
+			Enabled:  tools.PtrTo(false),
+			Locked:   tools.PtrTo(true),
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), buildpackCreate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(buildpackCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the position is less than 1", func() {
+		BeforeEach(func() {
+			createPayload.Position = tools.PtrTo(0)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "position must be no less than 1")
+		})
+	})
+
+	When("the metadata uses a prefix reserved for the platform", func() {
+		BeforeEach(func() {
+			createPayload.Metadata.Labels = map[string]string{"korifi.cloudfoundry.org/foo": "bar"}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "cflinuxfs4",
+				Position: 2,
+				Enabled:  false,
+				Locked:   true,
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+
+		When("the optional fields are not set", func() {
+			BeforeEach(func() {
+				createPayload = payloads.BuildpackCreate{Name: "my-buildpack"}
+			})
+
+			It("defaults them", func() {
+				Expect(createPayload.ToMessage()).To(Equal(repositories.CreateBuildpackMessage{
+					Name:     "my-buildpack",
+					Position: 1,
+					Enabled:  true,
+					Locked:   false,
+				}))
+			})
+		})
+	})
+})
+
+var _ = Describe("BuildpackUpdate", func() {
+	var (
+		updatePayload   payloads.BuildpackUpdate
+		buildpackUpdate *payloads.BuildpackUpdate
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		buildpackUpdate = new(payloads.BuildpackUpdate)
+		updatePayload = payloads.BuildpackUpdate{
+			Name:     tools.PtrTo("new-name"),
+			Position: tools.PtrTo(3),
+			Enabled:  tools.PtrTo(true),
+			Metadata: payloads.MetadataPatch{
+				Annotations: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), buildpackUpdate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(buildpackUpdate).To(PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the position is less than 1", func() {
+		BeforeEach(func() {
+			updatePayload.Position = tools.PtrTo(0)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "position must be no less than 1")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(updatePayload.ToMessage("buildpack-guid")).To(Equal(repositories.UpdateBuildpackMessage{
+				GUID:     "buildpack-guid",
+				Name:     tools.PtrTo("new-name"),
+				Position: tools.PtrTo(3),
+				Enabled:  tools.PtrTo(true),
+				MetadataPatch: repositories.MetadataPatch{
+					Annotations: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
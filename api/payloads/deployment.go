@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"slices"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -47,10 +48,11 @@ type DeploymentList struct {
 	AppGUIDs     string `json:"app_guids"`
 	OrderBy      string `json:"order_by"`
 	StatusValues string `json:"status_values"`
+	Timestamps   repositories.TimestampFilters
 }
 
 func (d *DeploymentList) SupportedKeys() []string {
-	return []string{"app_guids", "status_values", "order_by"}
+	return append([]string{"app_guids", "status_values", "order_by"}, params.TimestampFilterKeys()...)
 }
 
 func (d *DeploymentList) IgnoredKeys() []*regexp.Regexp {
@@ -65,7 +67,9 @@ func (d *DeploymentList) DecodeFromURLValues(values url.Values) error {
 	d.OrderBy = values.Get("order_by")
 	d.StatusValues = values.Get("status_values")
 
-	return nil
+	var err error
+	d.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (d DeploymentList) Validate() error {
@@ -94,5 +98,6 @@ func (d *DeploymentList) ToMessage() repositories.ListDeploymentsMessage {
 		AppGUIDs:     parse.ArrayParam(d.AppGUIDs),
 		StatusValues: statusValues,
 		OrderBy:      d.OrderBy,
+		Timestamps:   d.Timestamps,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -64,20 +65,25 @@ func (c DomainUpdate) Validate() error {
 }
 
 type DomainList struct {
-	Names string
+	Names      string
+	Timestamps repositories.TimestampFilters
 }
 
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 	return repositories.ListDomainsMessage{
-		Names: parse.ArrayParam(d.Names),
+		Names:      parse.ArrayParam(d.Names),
+		Timestamps: d.Timestamps,
 	}
 }
 
 func (d *DomainList) SupportedKeys() []string {
-	return []string{"names", "per_page", "page"}
+	return append([]string{"names", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (d *DomainList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
-	return nil
+
+	var err error
+	d.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
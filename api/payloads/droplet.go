@@ -4,11 +4,66 @@ import (
 	"net/url"
 	"regexp"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
 
+type DropletCreate struct {
+	Relationships *DropletRelationships `json:"relationships"`
+	ProcessTypes  map[string]string     `json:"process_types"`
+	Metadata      Metadata              `json:"metadata"`
+}
+
+func (c DropletCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c DropletCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Lifecycle:    appRecord.Lifecycle,
+		ProcessTypes: c.ProcessTypes,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type DropletCopy struct {
+	Relationships *DropletRelationships `json:"relationships"`
+}
+
+func (c DropletCopy) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+	)
+}
+
+func (c DropletCopy) ToMessage(sourceGUID string, appRecord repositories.AppRecord) repositories.CopyDropletMessage {
+	return repositories.CopyDropletMessage{
+		SourceGUID: sourceGUID,
+		AppGUID:    appRecord.GUID,
+		SpaceGUID:  appRecord.SpaceGUID,
+	}
+}
+
+type DropletRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (r DropletRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.App, validation.NotNil),
+	)
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
@@ -33,16 +88,17 @@ type DropletList struct {
 	GUIDs      string
 	AppGUIDs   string
 	SpaceGUIDs string
+	Timestamps repositories.TimestampFilters
 }
 
 func (l *DropletList) SupportedKeys() []string {
-	return []string{
+	return append([]string{
 		"guids",
 		"states",
 		"app_guids",
 		"space_guids",
 		"organization_guids",
-	}
+	}, params.TimestampFilterKeys()...)
 }
 
 func (l *DropletList) IgnoredKeys() []*regexp.Regexp {
@@ -57,7 +113,10 @@ func (l *DropletList) DecodeFromURLValues(values url.Values) error {
 	l.GUIDs = values.Get("guids")
 	l.AppGUIDs = values.Get("app_guids")
 	l.SpaceGUIDs = values.Get("space_guids")
-	return nil
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (l *DropletList) ToMessage() repositories.ListDropletsMessage {
@@ -65,5 +124,6 @@ func (l *DropletList) ToMessage() repositories.ListDropletsMessage {
 		GUIDs:      parse.ArrayParam(l.GUIDs),
 		AppGUIDs:   parse.ArrayParam(l.AppGUIDs),
 		SpaceGUIDs: parse.ArrayParam(l.SpaceGUIDs),
+		Timestamps: l.Timestamps,
 	}
 }
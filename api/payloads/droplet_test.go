@@ -2,6 +2,7 @@ package payloads_test
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
@@ -91,3 +92,125 @@ var _ = Describe("DropletUpdate", func() {
 		})
 	})
 })
+
+var _ = Describe("DropletCreate", func() {
+	var (
+		createPayload payloads.DropletCreate
+		dropletCreate *payloads.DropletCreate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		dropletCreate = new(payloads.DropletCreate)
+		createPayload = payloads.DropletCreate{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+			ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), dropletCreate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(dropletCreate).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.App = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			appRecord := repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}
+			Expect(createPayload.ToMessage(appRecord)).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Lifecycle:    repositories.Lifecycle{Type: "buildpack"},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("DropletCopy", func() {
+	var (
+		copyPayload  payloads.DropletCopy
+		dropletCopy  *payloads.DropletCopy
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		dropletCopy = new(payloads.DropletCopy)
+		copyPayload = payloads.DropletCopy{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), dropletCopy)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(dropletCopy).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(copyPayload.ToMessage("source-guid", repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+			})).To(Equal(repositories.CopyDropletMessage{
+				SourceGUID: "source-guid",
+				AppGUID:    "app-guid",
+				SpaceGUID:  "space-guid",
+			}))
+		})
+	})
+})
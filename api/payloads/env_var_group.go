@@ -0,0 +1,30 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type EnvVarGroupPatch struct {
+	Var map[string]any `json:"var"`
+}
+
+func (p EnvVarGroupPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Var,
+			validation.StrictlyRequired,
+			jellidation.Map().Keys(
+				validation.NotStartWith("VCAP_"),
+				validation.NotStartWith("VMC_"),
+				validation.NotEqual("PORT"),
+			).AllowExtraKeys(),
+		))
+}
+
+func (p EnvVarGroupPatch) ToMessage(name string) repositories.PatchEnvVarGroupMessage {
+	return repositories.PatchEnvVarGroupMessage{
+		Name:                 name,
+		EnvironmentVariables: toEnvVarPatch(p.Var),
+	}
+}
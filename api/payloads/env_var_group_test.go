@@ -0,0 +1,77 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("EnvVarGroupPatch", func() {
+	var (
+		payload        payloads.EnvVarGroupPatch
+		decodedPayload *payloads.EnvVarGroupPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.EnvVarGroupPatch{
+			Var: map[string]any{
+				"HTTP_PROXY": "http://proxy",
+				"NO_PROXY":   nil,
+			},
+		}
+
+		decodedPayload = new(payloads.EnvVarGroupPatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(PointTo(Equal(payload)))
+	})
+
+	When("it contains a 'PORT' key", func() {
+		BeforeEach(func() {
+			payload.Var["PORT"] = "2222"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VCAP_'", func() {
+		BeforeEach(func() {
+			payload.Var["VCAP_foo"] = "bar"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+
+	When("var is missing", func() {
+		BeforeEach(func() {
+			payload.Var = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			message := payload.ToMessage("running")
+			Expect(message.Name).To(Equal("running"))
+			Expect(message.EnvironmentVariables).To(MatchAllKeys(Keys{
+				"HTTP_PROXY": PointTo(Equal("http://proxy")),
+				"NO_PROXY":   BeNil(),
+			}))
+		})
+	})
+})
@@ -0,0 +1,32 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type FeaturePatch struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p FeaturePatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p FeaturePatch) ToAppSSHMessage(appGUID, spaceGUID string) repositories.PatchAppMessage {
+	return repositories.PatchAppMessage{
+		AppGUID:    appGUID,
+		SpaceGUID:  spaceGUID,
+		SSHEnabled: p.Enabled,
+	}
+}
+
+func (p FeaturePatch) ToSpaceSSHMessage(spaceGUID, orgGUID string) repositories.PatchSpaceFeaturesMessage {
+	return repositories.PatchSpaceFeaturesMessage{
+		GUID:       spaceGUID,
+		OrgGUID:    orgGUID,
+		SSHEnabled: p.Enabled,
+	}
+}
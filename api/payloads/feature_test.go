@@ -0,0 +1,61 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeaturePatch", func() {
+	var (
+		patchPayload   payloads.FeaturePatch
+		decodedPayload *payloads.FeaturePatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.FeaturePatch)
+		patchPayload = payloads.FeaturePatch{
+			Enabled: tools.PtrTo(true),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(patchPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(patchPayload)))
+	})
+
+	It("converts to an app patch message", func() {
+		Expect(decodedPayload.ToAppSSHMessage("app-guid", "space-guid")).To(Equal(repositories.PatchAppMessage{
+			AppGUID:    "app-guid",
+			SpaceGUID:  "space-guid",
+			SSHEnabled: tools.PtrTo(true),
+		}))
+	})
+
+	It("converts to a space patch message", func() {
+		Expect(decodedPayload.ToSpaceSSHMessage("space-guid", "org-guid")).To(Equal(repositories.PatchSpaceFeaturesMessage{
+			GUID:       "space-guid",
+			OrgGUID:    "org-guid",
+			SSHEnabled: tools.PtrTo(true),
+		}))
+	})
+
+	When("enabled is missing", func() {
+		BeforeEach(func() {
+			patchPayload.Enabled = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+})
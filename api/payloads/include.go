@@ -0,0 +1,21 @@
+package payloads
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	jellidation "github.com/jellydator/validation"
+)
+
+func includeResourceRulesOneOf(relationshipPaths ...any) jellidation.Rule {
+	return jellidation.Each(jellidation.By(func(value any) error {
+		rule, ok := value.(params.IncludeResourceRule)
+		if !ok {
+			return fmt.Errorf("%T is not supported, IncludeResourceRule is expected", value)
+		}
+
+		return validation.OneOf(relationshipPaths...).Validate(strings.Join(rule.RelationshipPath, "."))
+	}))
+}
@@ -0,0 +1,167 @@
+package payloads
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/jellydator/validation"
+)
+
+type IsolationSegmentToleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+func (t IsolationSegmentToleration) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Operator, payload_validation.OneOf("Exists", "Equal")),
+		validation.Field(&t.Effect, payload_validation.OneOf("NoSchedule", "PreferNoSchedule", "NoExecute")),
+	)
+}
+
+type IsolationSegmentPlacement struct {
+	NodeSelector map[string]string            `json:"node_selector"`
+	Tolerations  []IsolationSegmentToleration `json:"tolerations"`
+}
+
+func (p IsolationSegmentPlacement) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Tolerations),
+	)
+}
+
+func (p IsolationSegmentPlacement) toRepositoryPlacement() repositories.IsolationSegmentPlacement {
+	return repositories.IsolationSegmentPlacement{
+		NodeSelector: p.NodeSelector,
+		Tolerations: slices.Collect(it.Map(slices.Values(p.Tolerations), func(t IsolationSegmentToleration) repositories.IsolationSegmentToleration {
+			return repositories.IsolationSegmentToleration(t)
+		})),
+	}
+}
+
+type IsolationSegmentCreate struct {
+	Name      string                    `json:"name"`
+	Placement IsolationSegmentPlacement `json:"placement"`
+	Metadata  Metadata                  `json:"metadata"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, payload_validation.StrictlyRequired),
+		validation.Field(&c.Placement),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name:      c.Name,
+		Placement: c.Placement.toRepositoryPlacement(),
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type IsolationSegmentUpdate struct {
+	Name      *string                    `json:"name"`
+	Placement *IsolationSegmentPlacement `json:"placement"`
+	Metadata  MetadataPatch              `json:"metadata"`
+}
+
+func (u IsolationSegmentUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty),
+		validation.Field(&u.Placement),
+		validation.Field(&u.Metadata),
+	)
+}
+
+func (u IsolationSegmentUpdate) ToMessage(guid string) repositories.UpdateIsolationSegmentMessage {
+	message := repositories.UpdateIsolationSegmentMessage{
+		GUID: guid,
+		Name: u.Name,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+
+	if u.Placement != nil {
+		placement := u.Placement.toRepositoryPlacement()
+		message.Placement = &placement
+	}
+
+	return message
+}
+
+type IsolationSegmentList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	Timestamps        repositories.TimestampFilters
+}
+
+func (l IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		Timestamps:        l.Timestamps,
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return append([]string{"guids", "names", "organization_guids", "per_page", "page"}, params.TimestampFilterKeys()...)
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
+}
+
+type IsolationSegmentEntitleOrganizations struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (e IsolationSegmentEntitleOrganizations) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Data, validation.Required),
+	)
+}
+
+func (e IsolationSegmentEntitleOrganizations) OrganizationGUIDs() []string {
+	return slices.Collect(it.Map(slices.Values(e.Data), func(d RelationshipData) string {
+		return d.GUID
+	}))
+}
+
+type SpaceIsolationSegmentPatch struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (p SpaceIsolationSegmentPatch) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Data),
+	)
+}
+
+func (p SpaceIsolationSegmentPatch) IsolationSegmentGUID() string {
+	if p.Data == nil {
+		return ""
+	}
+	return p.Data.GUID
+}
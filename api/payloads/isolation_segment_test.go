@@ -0,0 +1,221 @@
+package payloads_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		createPayload          payloads.IsolationSegmentCreate
+		isolationSegmentCreate *payloads.IsolationSegmentCreate
+		validatorErr           error
+	)
+
+	BeforeEach(func() {
+		isolationSegmentCreate = new(payloads.IsolationSegmentCreate)
+		createPayload = payloads.IsolationSegmentCreate{
+			Name: "my-segment",
+			Placement: payloads.IsolationSegmentPlacement{
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []payloads.IsolationSegmentToleration{{
+					Key:      "dedicated",
+					Operator: "Equal",
+					Value:    "regulated",
+					Effect:   "NoSchedule",
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), isolationSegmentCreate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(isolationSegmentCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a toleration operator is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Placement.Tolerations[0].Operator = "Sometimes"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "operator value must be one of")
+		})
+	})
+
+	When("a toleration effect is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Placement.Tolerations[0].Effect = "Evict"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "effect value must be one of")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{
+				Name: "my-segment",
+				Placement: repositories.IsolationSegmentPlacement{
+					NodeSelector: map[string]string{"pool": "regulated"},
+					Tolerations: []repositories.IsolationSegmentToleration{{
+						Key:      "dedicated",
+						Operator: "Equal",
+						Value:    "regulated",
+						Effect:   "NoSchedule",
+					}},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentUpdate", func() {
+	var (
+		updatePayload          payloads.IsolationSegmentUpdate
+		isolationSegmentUpdate *payloads.IsolationSegmentUpdate
+		validatorErr           error
+	)
+
+	BeforeEach(func() {
+		isolationSegmentUpdate = new(payloads.IsolationSegmentUpdate)
+		updatePayload = payloads.IsolationSegmentUpdate{
+			Name: tools.PtrTo("new-name"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), isolationSegmentUpdate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(isolationSegmentUpdate).To(PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("only sets the placement when it is provided", func() {
+			Expect(updatePayload.ToMessage("guid").Placement).To(BeNil())
+
+			updatePayload.Placement = &payloads.IsolationSegmentPlacement{NodeSelector: map[string]string{"pool": "new"}}
+			Expect(updatePayload.ToMessage("guid").Placement).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"NodeSelector": Equal(map[string]string{"pool": "new"}),
+			})))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedIsolationSegmentList payloads.IsolationSegmentList) {
+			actualIsolationSegmentList, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualIsolationSegmentList).To(Equal(expectedIsolationSegmentList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.IsolationSegmentList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.IsolationSegmentList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.IsolationSegmentList{OrganizationGUIDs: "o1,o2"}),
+		Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.IsolationSegmentList{Timestamps: repositories.TimestampFilters{
+			CreatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampGreaterThanOrEqual,
+				Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			}},
+		}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+})
+
+var _ = Describe("IsolationSegmentEntitleOrganizations", func() {
+	var (
+		entitlePayload payloads.IsolationSegmentEntitleOrganizations
+		decoded        *payloads.IsolationSegmentEntitleOrganizations
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decoded = new(payloads.IsolationSegmentEntitleOrganizations)
+		entitlePayload = payloads.IsolationSegmentEntitleOrganizations{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(entitlePayload), decoded)
+	})
+
+	It("returns the organization guids", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decoded.OrganizationGUIDs()).To(Equal([]string{"org-1", "org-2"}))
+	})
+
+	When("no organizations are given", func() {
+		BeforeEach(func() {
+			entitlePayload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SpaceIsolationSegmentPatch", func() {
+	It("decodes a null relationship", func() {
+		decoded := new(payloads.SpaceIsolationSegmentPatch)
+		req, err := http.NewRequest("PATCH", "http://foo.com/bar", strings.NewReader(`{"data": null}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(validator.DecodeAndValidateJSONPayload(req, decoded)).To(Succeed())
+		Expect(decoded.IsolationSegmentGUID()).To(BeEmpty())
+	})
+
+	It("decodes an isolation segment relationship", func() {
+		decoded := new(payloads.SpaceIsolationSegmentPatch)
+		req, err := http.NewRequest("PATCH", "http://foo.com/bar", strings.NewReader(`{"data": {"guid": "iso-seg-guid"}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(validator.DecodeAndValidateJSONPayload(req, decoded)).To(Succeed())
+		Expect(decoded.IsolationSegmentGUID()).To(Equal("iso-seg-guid"))
+	})
+})
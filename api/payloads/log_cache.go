@@ -1,10 +1,16 @@
 package payloads
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/url"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/promql"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
@@ -61,3 +67,125 @@ func getBool(values url.Values, key string) (bool, error) {
 	}
 	return strconv.ParseBool(s)
 }
+
+const maxPromQLRangePoints = 11000
+
+type LogCachePromQL struct {
+	Query string
+	Time  *time.Time
+}
+
+func (l LogCachePromQL) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Query, jellidation.Required, jellidation.By(validatePromQL)),
+	)
+}
+
+func (l *LogCachePromQL) SupportedKeys() []string {
+	return []string{"query", "time"}
+}
+
+func (l *LogCachePromQL) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.Query = values.Get("query")
+	if l.Time, err = getPromQLTime(values, "time"); err != nil {
+		return err
+	}
+	return nil
+}
+
+type LogCachePromQLRange struct {
+	Query string
+	Start *time.Time
+	End   *time.Time
+	Step  time.Duration
+}
+
+func (l LogCachePromQLRange) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Query, jellidation.Required, jellidation.By(validatePromQL)),
+		jellidation.Field(&l.Start, jellidation.NotNil),
+		jellidation.Field(&l.End, jellidation.NotNil, jellidation.By(func(any) error {
+			if l.Start != nil && l.End != nil && l.End.Before(*l.Start) {
+				return errors.New("must not be before start")
+			}
+			return nil
+		})),
+		jellidation.Field(&l.Step, jellidation.Required, jellidation.Min(time.Duration(0)).Exclusive(), jellidation.By(func(any) error {
+			if l.Start != nil && l.End != nil && l.Step > 0 && l.End.Sub(*l.Start)/l.Step > maxPromQLRangePoints {
+				return fmt.Errorf("exceeded maximum resolution of %d points per timeseries", maxPromQLRangePoints)
+			}
+			return nil
+		})),
+	)
+}
+
+func (l *LogCachePromQLRange) SupportedKeys() []string {
+	return []string{"query", "start", "end", "step"}
+}
+
+func (l *LogCachePromQLRange) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.Query = values.Get("query")
+	if l.Start, err = getPromQLTime(values, "start"); err != nil {
+		return err
+	}
+	if l.End, err = getPromQLTime(values, "end"); err != nil {
+		return err
+	}
+	if values.Get("step") != "" {
+		if l.Step, err = parsePromQLStep(values.Get("step")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePromQL(value any) error {
+	query, ok := value.(string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	expr, err := promql.Parse(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = promql.SourceIDs(expr)
+	return err
+}
+
+// getPromQLTime parses unix timestamps with optional fractional seconds as
+// well as RFC3339 timestamps, same as the prometheus API
+func getPromQLTime(values url.Values, key string) (*time.Time, error) {
+	s := values.Get(key)
+	if s == "" {
+		return nil, nil
+	}
+
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(seconds)
+		return tools.PtrTo(time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: must be a unix or RFC3339 timestamp", key, s)
+	}
+
+	return &t, nil
+}
+
+func parsePromQLStep(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	step, err := promql.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid step %q: %w", s, err)
+	}
+
+	return step, nil
+}
@@ -1,6 +1,9 @@
 package payloads_test
 
 import (
+	"net/url"
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -46,3 +49,71 @@ var _ = Describe("LogCacheRead", func() {
 		)
 	})
 })
+
+var _ = Describe("LogCachePromQL", func() {
+	DescribeTable("valid query",
+		func(query string, expected payloads.LogCachePromQL) {
+			actual, decodeErr := decodeQuery[payloads.LogCachePromQL](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actual).To(Equal(expected))
+		},
+		Entry("query", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`), payloads.LogCachePromQL{
+			Query: `cpu{source_id="app-guid"}`,
+		}),
+		Entry("unix time", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&time=1700000000.5", payloads.LogCachePromQL{
+			Query: `cpu{source_id="app-guid"}`,
+			Time:  tools.PtrTo(time.Unix(1700000000, 500000000).UTC()),
+		}),
+		Entry("RFC3339 time", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&time=2023-11-14T22:13:20Z", payloads.LogCachePromQL{
+			Query: `cpu{source_id="app-guid"}`,
+			Time:  tools.PtrTo(time.Unix(1700000000, 0).UTC()),
+		}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.LogCachePromQL](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing query", "", "cannot be blank"),
+		Entry("unparseable query", "query="+url.QueryEscape(`cpu{source_id=}`), "unexpected"),
+		Entry("missing source id", "query="+url.QueryEscape(`cpu{instance_id="0"}`), "must specify a source_id"),
+		Entry("invalid time", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&time=yesterday", "must be a unix or RFC3339 timestamp"),
+	)
+})
+
+var _ = Describe("LogCachePromQLRange", func() {
+	DescribeTable("valid query",
+		func(query string, expected payloads.LogCachePromQLRange) {
+			actual, decodeErr := decodeQuery[payloads.LogCachePromQLRange](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actual).To(Equal(expected))
+		},
+		Entry("step in seconds", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=1700000000&end=1700000060&step=15", payloads.LogCachePromQLRange{
+			Query: `cpu{source_id="app-guid"}`,
+			Start: tools.PtrTo(time.Unix(1700000000, 0).UTC()),
+			End:   tools.PtrTo(time.Unix(1700000060, 0).UTC()),
+			Step:  15 * time.Second,
+		}),
+		Entry("step as duration", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=1700000000&end=1700000060&step=1m", payloads.LogCachePromQLRange{
+			Query: `cpu{source_id="app-guid"}`,
+			Start: tools.PtrTo(time.Unix(1700000000, 0).UTC()),
+			End:   tools.PtrTo(time.Unix(1700000060, 0).UTC()),
+			Step:  time.Minute,
+		}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.LogCachePromQLRange](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing start", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&end=1700000060&step=15", "is required"),
+		Entry("missing step", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=1700000000&end=1700000060", "cannot be blank"),
+		Entry("invalid step", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=1700000000&end=1700000060&step=often", "invalid step"),
+		Entry("end before start", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=1700000060&end=1700000000&step=15", "must not be before start"),
+		Entry("too many points", "query="+url.QueryEscape(`cpu{source_id="app-guid"}`)+"&start=0&end=1700000000&step=1", "exceeded maximum resolution"),
+	)
+})
@@ -15,62 +15,62 @@ import (
 )
 
 type Manifest struct {
-	Version      int                   `yaml:"version"`
+	Version      int                   `yaml:"version,omitempty"`
 	Applications []ManifestApplication `json:"applications" yaml:"applications"`
 }
 
 type ManifestApplication struct {
-	Name         string            `json:"name" yaml:"name"`
-	Env          map[string]string `yaml:"env"`
-	DefaultRoute bool              `json:"default-route" yaml:"default-route"`
-	RandomRoute  bool              `yaml:"random-route"`
-	NoRoute      bool              `yaml:"no-route"`
-	Command      *string           `yaml:"command"`
-	Instances    *int32            `json:"instances" yaml:"instances"`
-	Memory       *string           `json:"memory" yaml:"memory"`
-	DiskQuota    *string           `json:"disk_quota" yaml:"disk_quota"`
+	Name         string            `json:"name" yaml:"name,omitempty"`
+	Env          map[string]string `yaml:"env,omitempty"`
+	DefaultRoute bool              `json:"default-route" yaml:"default-route,omitempty"`
+	RandomRoute  bool              `yaml:"random-route,omitempty"`
+	NoRoute      bool              `yaml:"no-route,omitempty"`
+	Command      *string           `yaml:"command,omitempty"`
+	Instances    *int32            `json:"instances" yaml:"instances,omitempty"`
+	Memory       *string           `json:"memory" yaml:"memory,omitempty"`
+	DiskQuota    *string           `json:"disk_quota" yaml:"disk_quota,omitempty"`
 	// AltDiskQuota supports `disk-quota` with a hyphen for backwards compatibility.
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string                      `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string                      `json:"health-check-type" yaml:"health-check-type"`
-	Timeout                      *int32                       `json:"timeout" yaml:"timeout"`
-	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes"`
-	Routes                       []ManifestRoute              `json:"routes" yaml:"routes"`
-	Buildpacks                   []string                     `yaml:"buildpacks"`
+	AltDiskQuota                 *string                      `json:"disk-quota" yaml:"disk-quota,omitempty"`
+	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType              *string                      `json:"health-check-type" yaml:"health-check-type,omitempty"`
+	Timeout                      *int32                       `json:"timeout" yaml:"timeout,omitempty"`
+	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes,omitempty"`
+	Routes                       []ManifestRoute              `json:"routes" yaml:"routes,omitempty"`
+	Buildpacks                   []string                     `yaml:"buildpacks,omitempty"`
 	// Deprecated: Use Buildpacks instead
-	Buildpack *string                      `json:"buildpack" yaml:"buildpack"`
-	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
-	Services  []ManifestApplicationService `json:"services" yaml:"services"`
+	Buildpack *string                      `json:"buildpack" yaml:"buildpack,omitempty"`
+	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata,omitempty"`
+	Services  []ManifestApplicationService `json:"services" yaml:"services,omitempty"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
 }
 
 // TODO: Why is kebab-case used everywhere anyway and we have a deprecated field that claims to use
 // it for backwards compatibility?
 type ManifestApplicationProcess struct {
-	Type      string  `json:"type" yaml:"type"`
-	Command   *string `yaml:"command"`
-	DiskQuota *string `json:"disk_quota" yaml:"disk_quota"`
+	Type      string  `json:"type" yaml:"type,omitempty"`
+	Command   *string `yaml:"command,omitempty"`
+	DiskQuota *string `json:"disk_quota" yaml:"disk_quota,omitempty"`
 	// AltDiskQuota supports `disk-quota` with a hyphen for backwards compatibility.
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type"`
-	Instances                    *int32  `json:"instances" yaml:"instances"`
-	Memory                       *string `json:"memory" yaml:"memory"`
-	Timeout                      *int32  `json:"timeout" yaml:"timeout"`
+	AltDiskQuota                 *string `json:"disk-quota" yaml:"disk-quota,omitempty"`
+	HealthCheckHTTPEndpoint      *string `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type,omitempty"`
+	Instances                    *int32  `json:"instances" yaml:"instances,omitempty"`
+	Memory                       *string `json:"memory" yaml:"memory,omitempty"`
+	Timeout                      *int32  `json:"timeout" yaml:"timeout,omitempty"`
 }
 
 type ManifestApplicationService struct {
-	Name        string         `json:"name" yaml:"name"`
-	BindingName *string        `json:"binding_name" yaml:"binding_name"`
-	Parameters  map[string]any `json:"parameters" yaml:"parameters"`
+	Name        string         `json:"name" yaml:"name,omitempty"`
+	BindingName *string        `json:"binding_name" yaml:"binding_name,omitempty"`
+	Parameters  map[string]any `json:"parameters" yaml:"parameters,omitempty"`
 }
 
 func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
@@ -89,7 +89,7 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 }
 
 type ManifestRoute struct {
-	Route *string `json:"route" yaml:"route"`
+	Route *string `json:"route" yaml:"route,omitempty"`
 }
 
 func (a ManifestApplication) ToAppCreateMessage(spaceGUID string) repositories.CreateAppMessage {
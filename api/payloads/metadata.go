@@ -51,13 +51,19 @@ func cloudfoundryKeyCheck(key any) error {
 		return fmt.Errorf("expected string key, got %T", key)
 	}
 
-	u, err := url.ParseRequestURI("https://" + keyStr) // without the scheme, the hostname will be parsed as a path
-	if err != nil {
-		return nil
-	}
-
-	if strings.HasSuffix(u.Hostname(), "cloudfoundry.org") {
+	if IsCloudFoundryKey(keyStr) {
 		return errors.New("label/annotation key cannot use the cloudfoundry.org domain")
 	}
 	return nil
 }
+
+// IsCloudFoundryKey returns true for label and annotation keys in the
+// cloudfoundry.org domain, which users cannot set
+func IsCloudFoundryKey(key string) bool {
+	u, err := url.ParseRequestURI("https://" + key) // without the scheme, the hostname will be parsed as a path
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(u.Hostname(), "cloudfoundry.org")
+}
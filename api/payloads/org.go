@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
@@ -53,20 +54,25 @@ func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMessage {
 }
 
 type OrgList struct {
-	Names string
+	Names      string
+	Timestamps repositories.TimestampFilters
 }
 
 func (d *OrgList) ToMessage() repositories.ListOrgsMessage {
 	return repositories.ListOrgsMessage{
-		Names: parse.ArrayParam(d.Names),
+		Names:      parse.ArrayParam(d.Names),
+		Timestamps: d.Timestamps,
 	}
 }
 
 func (d *OrgList) SupportedKeys() []string {
-	return []string{"names", "order_by", "per_page", "page"}
+	return append([]string{"names", "order_by", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (d *OrgList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
-	return nil
+
+	var err error
+	d.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -48,6 +49,32 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 	return message
 }
 
+type PackageCopy struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopy) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopy) ToMessage(sourceRecord repositories.PackageRecord, appRecord repositories.AppRecord) repositories.CreatePackageMessage {
+	message := repositories.CreatePackageMessage{
+		Type:      sourceRecord.Type,
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+	}
+
+	if sourceRecord.Type == "docker" {
+		message.Data = &repositories.PackageData{
+			Image: sourceRecord.SourceImageRef,
+		}
+	}
+
+	return message
+}
+
 type PackageData struct {
 	Image    string  `json:"image"`
 	Username *string `json:"username"`
@@ -90,23 +117,25 @@ func (u *PackageUpdate) ToMessage(packageGUID string) repositories.UpdatePackage
 }
 
 type PackageList struct {
-	GUIDs    string
-	AppGUIDs string
-	States   string
-	OrderBy  string
+	GUIDs      string
+	AppGUIDs   string
+	States     string
+	OrderBy    string
+	Timestamps repositories.TimestampFilters
 }
 
 func (p *PackageList) ToMessage() repositories.ListPackagesMessage {
 	return repositories.ListPackagesMessage{
-		GUIDs:    parse.ArrayParam(p.GUIDs),
-		AppGUIDs: parse.ArrayParam(p.AppGUIDs),
-		States:   parse.ArrayParam(p.States),
-		OrderBy:  p.OrderBy,
+		GUIDs:      parse.ArrayParam(p.GUIDs),
+		AppGUIDs:   parse.ArrayParam(p.AppGUIDs),
+		States:     parse.ArrayParam(p.States),
+		OrderBy:    p.OrderBy,
+		Timestamps: p.Timestamps,
 	}
 }
 
 func (p *PackageList) SupportedKeys() []string {
-	return []string{"guids", "app_guids", "states", "order_by", "per_page", "page"}
+	return append([]string{"guids", "app_guids", "states", "order_by", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (p *PackageList) DecodeFromURLValues(values url.Values) error {
@@ -114,7 +143,10 @@ func (p *PackageList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
 	p.States = values.Get("states")
 	p.OrderBy = values.Get("order_by")
-	return nil
+
+	var err error
+	p.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (p PackageList) Validate() error {
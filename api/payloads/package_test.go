@@ -236,6 +236,82 @@ var _ = Describe("PackageCreate", func() {
 	})
 })
 
+var _ = Describe("PackageCopy", func() {
+	var (
+		copyPayload  payloads.PackageCopy
+		packageCopy  *payloads.PackageCopy
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		packageCopy = new(payloads.PackageCopy)
+		copyPayload = payloads.PackageCopy{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), packageCopy)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(packageCopy).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		var (
+			sourceRecord repositories.PackageRecord
+			appRecord    repositories.AppRecord
+		)
+
+		BeforeEach(func() {
+			sourceRecord = repositories.PackageRecord{
+				Type:           "bits",
+				SourceImageRef: "some/source-image",
+			}
+			appRecord = repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+			}
+		})
+
+		It("creates a package of the same type for the app", func() {
+			Expect(copyPayload.ToMessage(sourceRecord, appRecord)).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		When("the source is a docker package", func() {
+			BeforeEach(func() {
+				sourceRecord.Type = "docker"
+			})
+
+			It("references the source image", func() {
+				Expect(copyPayload.ToMessage(sourceRecord, appRecord).Data).To(Equal(&repositories.PackageData{
+					Image: "some/source-image",
+				}))
+			})
+		})
+	})
+})
+
 var _ = Describe("PackageUpdate", func() {
 	var payload payloads.PackageUpdate
 
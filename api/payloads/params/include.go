@@ -33,7 +33,7 @@ func ParseFields(values url.Values) []IncludeResourceRule {
 }
 
 func ParseIncludes(values url.Values) []IncludeResourceRule {
-	includes := []IncludeResourceRule{}
+	var includes []IncludeResourceRule
 
 	for param, values := range values {
 		if param != "include" {
@@ -41,10 +41,16 @@ func ParseIncludes(values url.Values) []IncludeResourceRule {
 		}
 
 		for _, value := range values {
-			includes = append(includes, IncludeResourceRule{
-				RelationshipPath: strings.Split(value, "."),
-				Fields:           []string{},
-			})
+			for _, relationshipPath := range strings.Split(value, ",") {
+				if relationshipPath == "" {
+					continue
+				}
+
+				includes = append(includes, IncludeResourceRule{
+					RelationshipPath: strings.Split(relationshipPath, "."),
+					Fields:           []string{},
+				})
+			}
 		}
 	}
 
@@ -50,5 +50,16 @@ var _ = Describe("IncludeResourceRules", func() {
 				Fields:           []string{},
 			}),
 		),
+
+		Entry("comma separated includes query", "include=space,space.organization", ConsistOf(
+			params.IncludeResourceRule{
+				RelationshipPath: []string{"space"},
+				Fields:           []string{},
+			},
+			params.IncludeResourceRule{
+				RelationshipPath: []string{"space", "organization"},
+				Fields:           []string{},
+			}),
+		),
 	)
 })
@@ -0,0 +1,103 @@
+package params
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+var timestampOperators = []repositories.TimestampOperator{
+	repositories.TimestampEqual,
+	repositories.TimestampGreaterThan,
+	repositories.TimestampGreaterThanOrEqual,
+	repositories.TimestampLessThan,
+	repositories.TimestampLessThanOrEqual,
+}
+
+func TimestampFilterKeys() []string {
+	keys := []string{}
+	for _, param := range []string{"created_ats", "updated_ats"} {
+		for _, operator := range timestampOperators {
+			keys = append(keys, timestampFilterKey(param, operator))
+		}
+	}
+
+	return keys
+}
+
+func ParseTimestampFilters(values url.Values) (repositories.TimestampFilters, error) {
+	createdAts, err := parseTimestampFilters(values, "created_ats")
+	if err != nil {
+		return repositories.TimestampFilters{}, err
+	}
+
+	updatedAts, err := parseTimestampFilters(values, "updated_ats")
+	if err != nil {
+		return repositories.TimestampFilters{}, err
+	}
+
+	return repositories.TimestampFilters{
+		CreatedAts: createdAts,
+		UpdatedAts: updatedAts,
+	}, nil
+}
+
+func parseTimestampFilters(values url.Values, param string) ([]repositories.TimestampFilter, error) {
+	var filters []repositories.TimestampFilter
+
+	for _, operator := range timestampOperators {
+		key := timestampFilterKey(param, operator)
+		if !values.Has(key) {
+			continue
+		}
+
+		timestamps, err := parseTimestamps(values.Get(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		if operator != repositories.TimestampEqual && len(timestamps) != 1 {
+			return nil, fmt.Errorf("invalid %s: exactly one timestamp is expected", key)
+		}
+
+		filters = append(filters, repositories.TimestampFilter{
+			Operator: operator,
+			Values:   timestamps,
+		})
+	}
+
+	return filters, nil
+}
+
+func parseTimestamps(value string) ([]time.Time, error) {
+	timestamps := []time.Time{}
+	for _, v := range strings.Split(value, ",") {
+		if v == "" {
+			continue
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid RFC3339 timestamp", v)
+		}
+
+		timestamps = append(timestamps, timestamp.Truncate(time.Second))
+	}
+
+	if len(timestamps) == 0 {
+		return nil, fmt.Errorf("a timestamp is expected")
+	}
+
+	return timestamps, nil
+}
+
+func timestampFilterKey(param string, operator repositories.TimestampOperator) string {
+	if operator == repositories.TimestampEqual {
+		return param
+	}
+
+	return fmt.Sprintf("%s[%s]", param, operator)
+}
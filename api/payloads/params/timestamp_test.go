@@ -0,0 +1,65 @@
+package params_test
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("TimestampFilters", func() {
+	var (
+		t1 = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		t2 = time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	DescribeTable("ParseTimestampFilters",
+		func(query string, match types.GomegaMatcher) {
+			queryValues, err := url.ParseQuery(query)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(params.ParseTimestampFilters(queryValues)).To(match)
+		},
+		Entry("unrelated query", "foo=bar", BeZero()),
+		Entry("equality", "created_ats=2024-01-01T10:00:00Z,2024-02-01T10:00:00Z", Equal(repositories.TimestampFilters{
+			CreatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampEqual,
+				Values:   []time.Time{t1, t2},
+			}},
+		})),
+		Entry("range", "updated_ats[gt]=2024-01-01T10:00:00Z&updated_ats[lte]=2024-02-01T10:00:00Z", Equal(repositories.TimestampFilters{
+			UpdatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampGreaterThan,
+				Values:   []time.Time{t1},
+			}, {
+				Operator: repositories.TimestampLessThanOrEqual,
+				Values:   []time.Time{t2},
+			}},
+		})),
+	)
+
+	DescribeTable("ParseTimestampFilters errors",
+		func(query string, errMessage string) {
+			queryValues, err := url.ParseQuery(query)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = params.ParseTimestampFilters(queryValues)
+			Expect(err).To(MatchError(ContainSubstring(errMessage)))
+		},
+		Entry("invalid timestamp", "created_ats=yesterday", `"yesterday" is not a valid RFC3339 timestamp`),
+		Entry("empty timestamp", "updated_ats[lt]=", "a timestamp is expected"),
+		Entry("multiple timestamps with operator", "created_ats[gte]=2024-01-01T10:00:00Z,2024-02-01T10:00:00Z", "exactly one timestamp is expected"),
+	)
+
+	It("lists all supported keys", func() {
+		Expect(params.TimestampFilterKeys()).To(ConsistOf(
+			"created_ats", "created_ats[gt]", "created_ats[gte]", "created_ats[lt]", "created_ats[lte]",
+			"updated_ats", "updated_ats[gt]", "updated_ats[gte]", "updated_ats[lt]", "updated_ats[lte]",
+		))
+	})
+
+})
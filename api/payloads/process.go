@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
@@ -48,22 +49,35 @@ func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 }
 
 type ProcessList struct {
-	AppGUIDs string
+	AppGUIDs             string
+	IncludeResourceRules []params.IncludeResourceRule
+	Timestamps           repositories.TimestampFilters
+}
+
+func (p ProcessList) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.IncludeResourceRules, includeResourceRulesOneOf("app")),
+	)
 }
 
 func (p *ProcessList) ToMessage() repositories.ListProcessesMessage {
 	return repositories.ListProcessesMessage{
-		AppGUIDs: parse.ArrayParam(p.AppGUIDs),
+		AppGUIDs:   parse.ArrayParam(p.AppGUIDs),
+		Timestamps: p.Timestamps,
 	}
 }
 
 func (p *ProcessList) SupportedKeys() []string {
-	return []string{"app_guids", "per_page", "page"}
+	return append([]string{"app_guids", "include", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (p *ProcessList) DecodeFromURLValues(values url.Values) error {
 	p.AppGUIDs = values.Get("app_guids")
-	return nil
+	p.IncludeResourceRules = params.ParseIncludes(values)
+
+	var err error
+	p.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -23,6 +24,20 @@ var _ = Describe("ProcessList", func() {
 				AppGUIDs: "app_guid",
 			}))
 		})
+
+		It("decodes the app include", func() {
+			processList, err := decodeQuery[payloads.ProcessList]("include=app")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processList.IncludeResourceRules).To(ConsistOf(
+				params.IncludeResourceRule{RelationshipPath: []string{"app"}, Fields: []string{}},
+			))
+		})
+
+		It("rejects unsupported includes", func() {
+			_, err := decodeQuery[payloads.ProcessList]("include=space")
+			Expect(err).To(MatchError(ContainSubstring("value must be one of")))
+		})
 	})
 })
 
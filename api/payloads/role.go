@@ -2,10 +2,13 @@ package payloads
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	jellidation "github.com/jellydator/validation"
 
@@ -75,6 +78,9 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 
 	if p.Relationships.User.Data.GUID != "" {
 		message.User = p.Relationships.User.Data.GUID
+	} else {
+		message.Username = p.Relationships.User.Data.Username
+		message.Origin = p.Relationships.User.Data.Origin
 	}
 
 	if authorization.HasServiceAccountPrefix(message.User) {
@@ -137,16 +143,20 @@ type RoleList struct {
 	OrgGUIDs   map[string]bool
 	UserGUIDs  map[string]bool
 	OrderBy    string
+	Timestamps repositories.TimestampFilters
+
+	IncludeResourceRules []params.IncludeResourceRule
 }
 
 func (r RoleList) ToMessage() repositories.ListRolesMessage {
 	return repositories.ListRolesMessage{
-		OrderBy: r.OrderBy,
+		OrderBy:    r.OrderBy,
+		Timestamps: r.Timestamps,
 	}
 }
 
 func (r RoleList) SupportedKeys() []string {
-	return []string{"guids", "types", "space_guids", "organization_guids", "user_guids", "order_by", "include", "per_page", "page"}
+	return append([]string{"guids", "types", "space_guids", "organization_guids", "user_guids", "order_by", "include", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (r *RoleList) DecodeFromURLValues(values url.Values) error {
@@ -156,12 +166,30 @@ func (r *RoleList) DecodeFromURLValues(values url.Values) error {
 	r.OrgGUIDs = commaSepToSet(values.Get("organization_guids"))
 	r.UserGUIDs = commaSepToSet(values.Get("user_guids"))
 	r.OrderBy = values.Get("order_by")
-	return nil
+
+	for _, includeVal := range parse.ArrayParam(values.Get("include")) {
+		r.IncludeResourceRules = append(r.IncludeResourceRules, params.IncludeResourceRule{
+			RelationshipPath: []string{includeVal},
+			Fields:           []string{},
+		})
+	}
+
+	var err error
+	r.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (r RoleList) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&r.IncludeResourceRules, jellidation.Each(jellidation.By(func(value any) error {
+			rule, ok := value.(params.IncludeResourceRule)
+			if !ok {
+				return fmt.Errorf("%T is not supported, IncludeResourceRule is expected", value)
+			}
+
+			return jellidation.Validate(rule.RelationshipPath[0], validation.OneOf("user", "space", "organization"))
+		}))),
 	)
 }
 
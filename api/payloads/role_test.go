@@ -9,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -102,6 +103,8 @@ var _ = Describe("RoleCreate", func() {
 			Expect(msg.Type).To(Equal("space_manager"))
 			Expect(msg.Space).To(Equal("cf-space-guid"))
 			Expect(msg.User).To(Equal("cf-service-account"))
+			Expect(msg.Username).To(Equal("cf-service-account"))
+			Expect(msg.Origin).To(BeEmpty())
 			Expect(msg.Kind).To(Equal(rbacv1.UserKind))
 		})
 
@@ -112,6 +115,21 @@ var _ = Describe("RoleCreate", func() {
 
 			It("uses the origin in the message user", func() {
 				Expect(msg.User).To(Equal("my-origin:cf-service-account"))
+				Expect(msg.Username).To(Equal("cf-service-account"))
+				Expect(msg.Origin).To(Equal("my-origin"))
+			})
+		})
+
+		When("the user guid is specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.User.Data.Username = ""
+				createPayload.Relationships.User.Data.GUID = "https://issuer.example.com#user-id"
+			})
+
+			It("leaves resolving the username and origin to the repository", func() {
+				Expect(msg.User).To(Equal("https://issuer.example.com#user-id"))
+				Expect(msg.Username).To(BeEmpty())
+				Expect(msg.Origin).To(BeEmpty())
 			})
 		})
 	})
@@ -212,7 +230,10 @@ var _ = Describe("role list", func() {
 		Entry("order_by2", "order_by=-created_at", payloads.RoleList{OrderBy: "-created_at"}),
 		Entry("order_by3", "order_by=updated_at", payloads.RoleList{OrderBy: "updated_at"}),
 		Entry("order_by4", "order_by=-updated_at", payloads.RoleList{OrderBy: "-updated_at"}),
-		Entry("include", "include=foo", payloads.RoleList{}),
+		Entry("include", "include=user,space", payloads.RoleList{IncludeResourceRules: []params.IncludeResourceRule{
+			{RelationshipPath: []string{"user"}, Fields: []string{}},
+			{RelationshipPath: []string{"space"}, Fields: []string{}},
+		}}),
 	)
 
 	DescribeTable("invalid query",
@@ -221,6 +242,7 @@ var _ = Describe("role list", func() {
 			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 		},
 		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid include", "include=foo", "value must be one of"),
 	)
 
 	DescribeTable("ToMessage",
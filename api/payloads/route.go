@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -50,12 +51,39 @@ func (r RouteRelationships) Validate() error {
 	)
 }
 
+type RouteGet struct {
+	IncludeResourceRules []params.IncludeResourceRule
+}
+
+func (g RouteGet) Validate() error {
+	return jellidation.ValidateStruct(&g,
+		jellidation.Field(&g.IncludeResourceRules, includeResourceRulesOneOf("domain", "space", "space.organization")),
+	)
+}
+
+func (g *RouteGet) SupportedKeys() []string {
+	return []string{"include"}
+}
+
+func (g *RouteGet) DecodeFromURLValues(values url.Values) error {
+	g.IncludeResourceRules = params.ParseIncludes(values)
+	return nil
+}
+
 type RouteList struct {
-	AppGUIDs    string
-	SpaceGUIDs  string
-	DomainGUIDs string
-	Hosts       string
-	Paths       string
+	AppGUIDs             string
+	SpaceGUIDs           string
+	DomainGUIDs          string
+	Hosts                string
+	Paths                string
+	IncludeResourceRules []params.IncludeResourceRule
+	Timestamps           repositories.TimestampFilters
+}
+
+func (p RouteList) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.IncludeResourceRules, includeResourceRulesOneOf("domain", "space", "space.organization")),
+	)
 }
 
 func (p RouteList) ToMessage() repositories.ListRoutesMessage {
@@ -65,11 +93,12 @@ func (p RouteList) ToMessage() repositories.ListRoutesMessage {
 		DomainGUIDs: parse.ArrayParam(p.DomainGUIDs),
 		Hosts:       parse.ArrayParam(p.Hosts),
 		Paths:       parse.ArrayParam(p.Paths),
+		Timestamps:  p.Timestamps,
 	}
 }
 
 func (p RouteList) SupportedKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "per_page", "page"}
+	return append([]string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "include", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (p *RouteList) DecodeFromURLValues(values url.Values) error {
@@ -78,7 +107,11 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 	p.DomainGUIDs = values.Get("domain_guids")
 	p.Hosts = values.Get("hosts")
 	p.Paths = values.Get("paths")
-	return nil
+	p.IncludeResourceRules = params.ParseIncludes(values)
+
+	var err error
+	p.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type RoutePatch struct {
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,16 +17,16 @@ var _ = Describe("RouteList", func() {
 		var (
 			routeList payloads.RouteList
 			decodeErr error
-			params    string
+			query     string
 		)
 
 		BeforeEach(func() {
 			routeList = payloads.RouteList{}
-			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
+			query = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
 		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", "http://foo.com/bar?"+params, nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 			decodeErr = validator.DecodeAndValidateURLValues(req, &routeList)
 		})
@@ -43,16 +44,63 @@ var _ = Describe("RouteList", func() {
 
 		When("it contains an invalid key", func() {
 			BeforeEach(func() {
-				params = "foo=bar"
+				query = "foo=bar"
 			})
 
 			It("fails", func() {
 				Expect(decodeErr).To(MatchError("unsupported query parameter: foo"))
 			})
 		})
+
+		When("it contains includes", func() {
+			BeforeEach(func() {
+				query = "include=domain,space.organization"
+			})
+
+			It("decodes the include resource rules", func() {
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(routeList.IncludeResourceRules).To(ConsistOf(
+					params.IncludeResourceRule{RelationshipPath: []string{"domain"}, Fields: []string{}},
+					params.IncludeResourceRule{RelationshipPath: []string{"space", "organization"}, Fields: []string{}},
+				))
+			})
+		})
+
+		When("it contains an invalid include", func() {
+			BeforeEach(func() {
+				query = "include=app"
+			})
+
+			It("fails", func() {
+				Expect(decodeErr).To(MatchError(ContainSubstring("value must be one of")))
+			})
+		})
 	})
 })
 
+var _ = Describe("RouteGet", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRouteGet payloads.RouteGet) {
+			actualRouteGet, decodeErr := decodeQuery[payloads.RouteGet](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRouteGet).To(Equal(expectedRouteGet))
+		},
+		Entry("no include", "", payloads.RouteGet{}),
+		Entry("include", "include=domain", payloads.RouteGet{IncludeResourceRules: []params.IncludeResourceRule{
+			{RelationshipPath: []string{"domain"}, Fields: []string{}},
+		}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.RouteGet](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid include", "include=foo", "value must be one of"),
+	)
+})
+
 var _ = Describe("RouteCreate", func() {
 	var (
 		createPayload payloads.RouteCreate
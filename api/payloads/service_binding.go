@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -77,6 +78,7 @@ type ServiceBindingList struct {
 	Include              string
 	LabelSelector        string
 	PlanGUIDs            string
+	Timestamps           repositories.TimestampFilters
 }
 
 func (l ServiceBindingList) Validate() error {
@@ -93,11 +95,12 @@ func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage
 		LabelSelector:        l.LabelSelector,
 		PlanGUIDs:            parse.ArrayParam(l.PlanGUIDs),
 		Type:                 l.Type,
+		Timestamps:           l.Timestamps,
 	}
 }
 
 func (l *ServiceBindingList) SupportedKeys() []string {
-	return []string{"app_guids", "service_instance_guids", "include", "type", "per_page", "page", "label_selector", "service_plan_guids"}
+	return append([]string{"app_guids", "service_instance_guids", "include", "type", "per_page", "page", "label_selector", "service_plan_guids"}, params.TimestampFilterKeys()...)
 }
 
 func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
@@ -107,7 +110,10 @@ func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
 	l.Include = values.Get("include")
 	l.LabelSelector = values.Get("label_selector")
 	l.PlanGUIDs = values.Get("service_plan_guids")
-	return nil
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type ServiceBindingUpdate struct {
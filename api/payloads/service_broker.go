@@ -3,6 +3,7 @@ package payloads
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -65,21 +66,26 @@ func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage
 }
 
 type ServiceBrokerList struct {
-	Names string
+	Names      string
+	Timestamps repositories.TimestampFilters
 }
 
 func (b *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
 	b.Names = values.Get("names")
-	return nil
+
+	var err error
+	b.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func (b *ServiceBrokerList) SupportedKeys() []string {
-	return []string{"names", "page", "per_page"}
+	return append([]string{"names", "page", "per_page"}, params.TimestampFilterKeys()...)
 }
 
 func (b *ServiceBrokerList) ToMessage() repositories.ListServiceBrokerMessage {
 	return repositories.ListServiceBrokerMessage{
-		Names: parse.ArrayParam(b.Names),
+		Names:      parse.ArrayParam(b.Names),
+		Timestamps: b.Timestamps,
 	}
 }
 
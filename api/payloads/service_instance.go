@@ -12,17 +12,19 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]any                `json:"credentials"`
-	Parameters    map[string]any                `json:"parameters"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name           string                        `json:"name"`
+	Type           string                        `json:"type"`
+	Tags           []string                      `json:"tags"`
+	Credentials    map[string]any                `json:"credentials"`
+	SyslogDrainURL *string                       `json:"syslog_drain_url"`
+	Parameters     map[string]any                `json:"parameters"`
+	Relationships  *ServiceInstanceRelationships `json:"relationships"`
+	Metadata       Metadata                      `json:"metadata"`
 }
 
 const maxTagsLength = 2048
@@ -44,11 +46,38 @@ func validateTagLength(tags any) error {
 	return nil
 }
 
+func validateSyslogDrainURL(value any) error {
+	drainURL, ok := value.(*string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	if drainURL == nil || *drainURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(*drainURL)
+	if err != nil || u.Host == "" {
+		return errors.New("must be a valid url")
+	}
+
+	switch u.Scheme {
+	case "syslog", "syslog-tls", "https":
+		return nil
+	}
+
+	return errors.New("must use one of the syslog, syslog-tls or https schemes")
+}
+
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed")),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
+		jellidation.Field(&c.SyslogDrainURL,
+			jellidation.When(c.Type == "managed", jellidation.Nil.Error("is only supported for user-provided service instances")),
+			jellidation.By(validateSyslogDrainURL),
+		),
 		jellidation.Field(&c.Relationships, jellidation.NotNil, jellidation.By(func(r any) error {
 			rel := r.(*ServiceInstanceRelationships)
 			if c.Type == "user-provided" {
@@ -63,12 +92,13 @@ func (c ServiceInstanceCreate) Validate() error {
 
 func (p ServiceInstanceCreate) ToUPSICreateMessage() repositories.CreateUPSIMessage {
 	return repositories.CreateUPSIMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:           p.Name,
+		SpaceGUID:      p.Relationships.Space.Data.GUID,
+		Credentials:    p.Credentials,
+		SyslogDrainURL: p.SyslogDrainURL,
+		Tags:           p.Tags,
+		Labels:         p.Metadata.Labels,
+		Annotations:    p.Metadata.Annotations,
 	}
 }
 
@@ -172,25 +202,28 @@ func (g *ServiceInstanceGet) DecodeFromURLValues(values url.Values) error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name           *string         `json:"name,omitempty"`
+	Tags           *[]string       `json:"tags,omitempty"`
+	Credentials    *map[string]any `json:"credentials,omitempty"`
+	SyslogDrainURL *string         `json:"syslog_drain_url,omitempty"`
+	Metadata       MetadataPatch   `json:"metadata"`
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.SyslogDrainURL, jellidation.By(validateSyslogDrainURL)),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
 	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+		SpaceGUID:      spaceGUID,
+		GUID:           appGUID,
+		Name:           p.Name,
+		Credentials:    p.Credentials,
+		SyslogDrainURL: p.SyslogDrainURL,
+		Tags:           p.Tags,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
@@ -221,6 +254,10 @@ func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
 		patch.Credentials = &map[string]any{}
 	}
 
+	if v, ok := patchMap["syslog_drain_url"]; ok && v == nil {
+		patch.SyslogDrainURL = tools.PtrTo("")
+	}
+
 	*p = ServiceInstancePatch(patch)
 
 	return nil
@@ -235,6 +272,7 @@ type ServiceInstanceList struct {
 	OrderBy              string
 	LabelSelector        string
 	IncludeResourceRules []params.IncludeResourceRule
+	Timestamps           repositories.TimestampFilters
 }
 
 func (l ServiceInstanceList) Validate() error {
@@ -302,11 +340,12 @@ func (l *ServiceInstanceList) ToMessage() repositories.ListServiceInstanceMessag
 		OrderBy:       l.OrderBy,
 		LabelSelector: l.LabelSelector,
 		PlanGUIDs:     parse.ArrayParam(l.PlanGUIDs),
+		Timestamps:    l.Timestamps,
 	}
 }
 
 func (l *ServiceInstanceList) SupportedKeys() []string {
-	return []string{
+	return append([]string{
 		"names",
 		"space_guids",
 		"guids",
@@ -319,7 +358,7 @@ func (l *ServiceInstanceList) SupportedKeys() []string {
 		"fields[space]",
 		"fields[space.organization]",
 		"service_plan_guids",
-	}
+	}, params.TimestampFilterKeys()...)
 }
 
 func (l *ServiceInstanceList) IgnoredKeys() []*regexp.Regexp {
@@ -338,7 +377,10 @@ func (l *ServiceInstanceList) DecodeFromURLValues(values url.Values) error {
 	l.LabelSelector = values.Get("label_selector")
 	l.IncludeResourceRules = append(l.IncludeResourceRules, params.ParseFields(values)...)
 	l.PlanGUIDs = values.Get("service_plan_guids")
-	return nil
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type ServiceInstanceDelete struct {
@@ -269,6 +269,37 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			})
 		})
 
+		When("the syslog drain url is set", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("syslog-tls://logs.example.com:6514")
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+		})
+
+		When("the syslog drain url scheme is not supported", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("ftp://logs.example.com")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "must use one of the syslog, syslog-tls or https schemes")
+			})
+		})
+
+		When("the syslog drain url is not a url", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("not-a-url")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "must be a valid url")
+			})
+		})
+
 		When("metadata is invalid", func() {
 			BeforeEach(func() {
 				createPayload.Metadata = payloads.Metadata{
@@ -308,6 +339,16 @@ var _ = Describe("ServiceInstanceCreate", func() {
 					expectUnprocessableEntityError(validatorErr, "relationships.service_plan is required")
 				})
 			})
+
+			When("the syslog drain url is set", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "is only supported for user-provided service instances")
+				})
+			})
 		})
 	})
 
@@ -333,6 +374,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 						},
 					},
 				},
+				SyslogDrainURL: tools.PtrTo("syslog://logs.example.com:514"),
 				Metadata: payloads.Metadata{
 					Annotations: map[string]string{"ann1": "val_ann1"},
 					Labels:      map[string]string{"lab1": "val_lab1"},
@@ -348,6 +390,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			Expect(msg.Name).To(Equal("service-instance-name"))
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.Tags).To(ConsistOf("foo", "bar"))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
 			Expect(msg.Annotations).To(HaveLen(1))
 			Expect(msg.Annotations).To(HaveKeyWithValue("ann1", "val_ann1"))
 			Expect(msg.Labels).To(HaveLen(1))
@@ -464,6 +507,16 @@ var _ = Describe("ServiceInstancePatch custom unmarshalling", func() {
 			Expect(patch.Credentials).To(PointTo(HaveLen(0)))
 		})
 	})
+
+	When("the syslog drain url is present but null", func() {
+		BeforeEach(func() {
+			payload = `{"syslog_drain_url": null}`
+		})
+
+		It("defaults it to an empty string", func() {
+			Expect(patch.SyslogDrainURL).To(PointTo(BeEmpty()))
+		})
+	})
 })
 
 var _ = Describe("ServiceInstancePatch", func() {
@@ -476,8 +529,9 @@ var _ = Describe("ServiceInstancePatch", func() {
 	BeforeEach(func() {
 		serviceInstancePatch = new(payloads.ServiceInstancePatch)
 		patchPayload = payloads.ServiceInstancePatch{
-			Name: tools.PtrTo("service-instance-name"),
-			Tags: &[]string{"foo", "bar"},
+			Name:           tools.PtrTo("service-instance-name"),
+			Tags:           &[]string{"foo", "bar"},
+			SyslogDrainURL: tools.PtrTo("https://logs.example.com"),
 			Credentials: &map[string]any{
 				"object": map[string]any{
 					"a": "b",
@@ -510,6 +564,16 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the syslog drain url scheme is not supported", func() {
+		BeforeEach(func() {
+			patchPayload.SyslogDrainURL = tools.PtrTo("http://logs.example.com")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must use one of the syslog, syslog-tls or https schemes")
+		})
+	})
+
 	When("metadata is invalid", func() {
 		BeforeEach(func() {
 			patchPayload.Metadata.Labels["foo.cloudfoundry.org/bar"] = tools.PtrTo("baz")
@@ -527,6 +591,7 @@ var _ = Describe("ServiceInstancePatch", func() {
 			Expect(msg.GUID).To(Equal("app-guid"))
 			Expect(msg.Name).To(PointTo(Equal("service-instance-name")))
 			Expect(msg.Tags).To(PointTo(ConsistOf("foo", "bar")))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("https://logs.example.com")))
 			Expect(msg.Annotations).To(MatchAllKeys(Keys{
 				"ann1": PointTo(Equal("val_ann1")),
 			}))
@@ -50,6 +50,7 @@ func (l *ServiceOfferingGet) DecodeFromURLValues(values url.Values) error {
 type ServiceOfferingList struct {
 	Names                string
 	BrokerNames          string
+	Timestamps           repositories.TimestampFilters
 	IncludeResourceRules []params.IncludeResourceRule
 }
 
@@ -78,11 +79,12 @@ func (l *ServiceOfferingList) ToMessage() repositories.ListServiceOfferingMessag
 	return repositories.ListServiceOfferingMessage{
 		Names:       parse.ArrayParam(l.Names),
 		BrokerNames: parse.ArrayParam(l.BrokerNames),
+		Timestamps:  l.Timestamps,
 	}
 }
 
 func (l *ServiceOfferingList) SupportedKeys() []string {
-	return []string{"names", "service_broker_names", "fields[service_broker]", "page", "per_page"}
+	return append([]string{"names", "service_broker_names", "fields[service_broker]", "page", "per_page"}, params.TimestampFilterKeys()...)
 }
 
 func (l *ServiceOfferingList) IgnoredKeys() []*regexp.Regexp {
@@ -93,7 +95,10 @@ func (l *ServiceOfferingList) DecodeFromURLValues(values url.Values) error {
 	l.Names = values.Get("names")
 	l.BrokerNames = values.Get("service_broker_names")
 	l.IncludeResourceRules = append(l.IncludeResourceRules, params.ParseFields(values)...)
-	return nil
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type ServiceOfferingDelete struct {
@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -45,6 +47,12 @@ var _ = Describe("ServiceOfferingList", func() {
 		},
 		Entry("names", "names=b1,b2", payloads.ServiceOfferingList{Names: "b1,b2"}),
 		Entry("service_broker_names", "service_broker_names=b1,b2", payloads.ServiceOfferingList{BrokerNames: "b1,b2"}),
+		Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.ServiceOfferingList{Timestamps: repositories.TimestampFilters{
+			CreatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampGreaterThanOrEqual,
+				Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			}},
+		}}),
 		Entry("fields[service_broker]", "fields[service_broker]=guid,name", payloads.ServiceOfferingList{
 			IncludeResourceRules: []params.IncludeResourceRule{{
 				RelationshipPath: []string{"service_broker"},
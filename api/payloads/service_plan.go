@@ -25,6 +25,7 @@ type ServicePlanList struct {
 	Names                string
 	ServiceOfferingNames string
 	Available            *bool
+	Timestamps           repositories.TimestampFilters
 	IncludeResourceRules []params.IncludeResourceRule
 }
 
@@ -66,11 +67,12 @@ func (l *ServicePlanList) ToMessage() repositories.ListServicePlanMessage {
 		BrokerNames:          parse.ArrayParam(l.BrokerNames),
 		BrokerGUIDs:          parse.ArrayParam(l.BrokerGUIDs),
 		Available:            l.Available,
+		Timestamps:           l.Timestamps,
 	}
 }
 
 func (l *ServicePlanList) SupportedKeys() []string {
-	return []string{
+	return append([]string{
 		"service_offering_guids",
 		"names",
 		"available",
@@ -79,7 +81,7 @@ func (l *ServicePlanList) SupportedKeys() []string {
 		"service_broker_guids",
 		"include",
 		"service_offering_names",
-	}
+	}, params.TimestampFilterKeys()...)
 }
 
 func (l *ServicePlanList) IgnoredKeys() []*regexp.Regexp {
@@ -105,7 +107,8 @@ func (l *ServicePlanList) DecodeFromURLValues(values url.Values) error {
 	l.IncludeResourceRules = append(l.IncludeResourceRules, params.ParseFields(values)...)
 	l.IncludeResourceRules = append(l.IncludeResourceRules, params.ParseIncludes(values)...)
 
-	return nil
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 func parseBool(valueStr string) (*bool, error) {
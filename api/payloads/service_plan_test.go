@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -28,6 +30,12 @@ var _ = Describe("ServicePlan", func() {
 			Entry("not available", "available=false", payloads.ServicePlanList{Available: tools.PtrTo(false)}),
 			Entry("broker names", "service_broker_names=b1,b2", payloads.ServicePlanList{BrokerNames: "b1,b2"}),
 			Entry("broker guids", "service_broker_guids=b1,b2", payloads.ServicePlanList{BrokerGUIDs: "b1,b2"}),
+			Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.ServicePlanList{Timestamps: repositories.TimestampFilters{
+				CreatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampGreaterThanOrEqual,
+					Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+				}},
+			}}),
 			Entry("include", "include=service_offering&include=space.organization", payloads.ServicePlanList{
 				IncludeResourceRules: []params.IncludeResourceRule{
 					{
@@ -105,6 +105,7 @@ type SpaceList struct {
 	GUIDs                string
 	OrganizationGUIDs    string
 	IncludeResourceRules []params.IncludeResourceRule
+	Timestamps           repositories.TimestampFilters
 }
 
 func (s SpaceList) Validate() error {
@@ -129,11 +130,12 @@ func (l *SpaceList) ToMessage() repositories.ListSpacesMessage {
 		Names:             parse.ArrayParam(l.Names),
 		GUIDs:             parse.ArrayParam(l.GUIDs),
 		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		Timestamps:        l.Timestamps,
 	}
 }
 
 func (l *SpaceList) SupportedKeys() []string {
-	return []string{"names", "guids", "organization_guids", "order_by", "per_page", "page", "include"}
+	return append([]string{"names", "guids", "organization_guids", "order_by", "per_page", "page", "include"}, params.TimestampFilterKeys()...)
 }
 
 func (l *SpaceList) DecodeFromURLValues(values url.Values) error {
@@ -150,7 +152,9 @@ func (l *SpaceList) DecodeFromURLValues(values url.Values) error {
 		}
 	}
 
-	return nil
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type SpaceDeleteRoutes struct {
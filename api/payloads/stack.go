@@ -0,0 +1,96 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type StackCreate struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	StackID          string   `json:"stack_id"`
+	BuildRootfsImage string   `json:"build_rootfs_image"`
+	RunRootfsImage   string   `json:"run_rootfs_image"`
+	Metadata         Metadata `json:"metadata"`
+}
+
+func (c StackCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.StackID, validation.StrictlyRequired),
+		jellidation.Field(&c.BuildRootfsImage, validation.StrictlyRequired),
+		jellidation.Field(&c.RunRootfsImage, validation.StrictlyRequired),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c StackCreate) ToMessage() repositories.CreateStackMessage {
+	return repositories.CreateStackMessage{
+		Name:             c.Name,
+		Description:      c.Description,
+		StackID:          c.StackID,
+		BuildRootfsImage: c.BuildRootfsImage,
+		RunRootfsImage:   c.RunRootfsImage,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type StackUpdate struct {
+	Description      *string       `json:"description"`
+	BuildRootfsImage *string       `json:"build_rootfs_image"`
+	RunRootfsImage   *string       `json:"run_rootfs_image"`
+	Metadata         MetadataPatch `json:"metadata"`
+}
+
+func (u StackUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.BuildRootfsImage, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.RunRootfsImage, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u StackUpdate) ToMessage(guid string) repositories.UpdateStackMessage {
+	return repositories.UpdateStackMessage{
+		GUID:             guid,
+		Description:      u.Description,
+		BuildRootfsImage: u.BuildRootfsImage,
+		RunRootfsImage:   u.RunRootfsImage,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
+
+type StackList struct {
+	Names      string
+	Timestamps repositories.TimestampFilters
+}
+
+func (l StackList) ToMessage() repositories.ListStacksMessage {
+	return repositories.ListStacksMessage{
+		Names:      parse.ArrayParam(l.Names),
+		Timestamps: l.Timestamps,
+	}
+}
+
+func (l *StackList) SupportedKeys() []string {
+	return append([]string{"names", "per_page", "page"}, params.TimestampFilterKeys()...)
+}
+
+func (l *StackList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
+}
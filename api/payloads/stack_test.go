@@ -0,0 +1,155 @@
+package payloads_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("StackList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedStackList payloads.StackList) {
+			actualStackList, decodeErr := decodeQuery[payloads.StackList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualStackList).To(Equal(expectedStackList))
+		},
+		Entry("names", "names=a,b", payloads.StackList{Names: "a,b"}),
+		Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T10:00:00Z", payloads.StackList{Timestamps: repositories.TimestampFilters{
+			CreatedAts: []repositories.TimestampFilter{{
+				Operator: repositories.TimestampGreaterThanOrEqual,
+				Values:   []time.Time{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			}},
+		}}),
+		Entry("empty", "", payloads.StackList{}),
+	)
+
+	It("converts names to a message", func() {
+		Expect(payloads.StackList{Names: "a,b"}.ToMessage()).To(Equal(repositories.ListStacksMessage{Names: []string{"a", "b"}}))
+	})
+})
+
+var _ = Describe("StackCreate", func() {
+	var (
+		createPayload payloads.StackCreate
+		stackCreate   *payloads.StackCreate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		stackCreate = new(payloads.StackCreate)
+		createPayload = payloads.StackCreate{
+			Name:             "cflinuxfs4",
+			Description:      "Ubuntu Jammy",
+			StackID:          "io.buildpacks.stacks.jammy",
+			BuildRootfsImage: "my.repository/build:jammy",
+			RunRootfsImage:   "my.repository/run:jammy",
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), stackCreate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(stackCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the stack id is empty", func() {
+		BeforeEach(func() {
+			createPayload.StackID = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "stack_id cannot be blank")
+		})
+	})
+
+	When("the run rootfs image is empty", func() {
+		BeforeEach(func() {
+			createPayload.RunRootfsImage = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "run_rootfs_image cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateStackMessage{
+				Name:             "cflinuxfs4",
+				Description:      "Ubuntu Jammy",
+				StackID:          "io.buildpacks.stacks.jammy",
+				BuildRootfsImage: "my.repository/build:jammy",
+				RunRootfsImage:   "my.repository/run:jammy",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("StackUpdate", func() {
+	var (
+		updatePayload payloads.StackUpdate
+		stackUpdate   *payloads.StackUpdate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		stackUpdate = new(payloads.StackUpdate)
+		updatePayload = payloads.StackUpdate{
+			RunRootfsImage: tools.PtrTo("my.repository/run:patched"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), stackUpdate)
+	})
+
+	It("succeeds with a valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(stackUpdate).To(PointTo(Equal(updatePayload)))
+	})
+
+	When("the run rootfs image is empty", func() {
+		BeforeEach(func() {
+			updatePayload.RunRootfsImage = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "run_rootfs_image cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a repository message", func() {
+			Expect(updatePayload.ToMessage("stack-guid")).To(Equal(repositories.UpdateStackMessage{
+				GUID:           "stack-guid",
+				RunRootfsImage: tools.PtrTo("my.repository/run:patched"),
+			}))
+		})
+	})
+})
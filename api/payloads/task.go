@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
@@ -32,16 +33,18 @@ func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.Cre
 
 type TaskList struct {
 	SequenceIDs []int64
+	Timestamps  repositories.TimestampFilters
 }
 
 func (t *TaskList) ToMessage() repositories.ListTaskMessage {
 	return repositories.ListTaskMessage{
 		SequenceIDs: t.SequenceIDs,
+		Timestamps:  t.Timestamps,
 	}
 }
 
 func (t *TaskList) SupportedKeys() []string {
-	return []string{"sequence_ids", "per_page", "page"}
+	return append([]string{"sequence_ids", "per_page", "page"}, params.TimestampFilterKeys()...)
 }
 
 func (a *TaskList) DecodeFromURLValues(values url.Values) error {
@@ -60,7 +63,10 @@ func (a *TaskList) DecodeFromURLValues(values url.Values) error {
 	}
 
 	a.SequenceIDs = ids
-	return nil
+
+	var err error
+	a.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
 }
 
 type TaskUpdate struct {
@@ -0,0 +1,60 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AppUsageEventList struct {
+	GUIDs     string
+	AfterGUID string
+}
+
+func (l AppUsageEventList) ToMessage() repositories.ListUsageEventsMessage {
+	return repositories.ListUsageEventsMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		AfterGUID: l.AfterGUID,
+	}
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "per_page", "page"}
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	return nil
+}
+
+type ServiceUsageEventList struct {
+	GUIDs                string
+	AfterGUID            string
+	ServiceInstanceTypes string
+	ServiceOfferingGUIDs string
+}
+
+func (l ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		ListUsageEventsMessage: repositories.ListUsageEventsMessage{
+			GUIDs:     parse.ArrayParam(l.GUIDs),
+			AfterGUID: l.AfterGUID,
+		},
+		ServiceInstanceTypes: parse.ArrayParam(l.ServiceInstanceTypes),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+	}
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "service_instance_types", "service_offering_guids", "per_page", "page"}
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	return nil
+}
@@ -0,0 +1,70 @@
+package payloads_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.AppUsageEventList) {
+			actualList, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=a,b", payloads.AppUsageEventList{GUIDs: "a,b"}),
+		Entry("after_guid", "after_guid=a", payloads.AppUsageEventList{AfterGUID: "a"}),
+		Entry("empty", "", payloads.AppUsageEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	It("converts to a message", func() {
+		Expect(payloads.AppUsageEventList{GUIDs: "a,b", AfterGUID: "c"}.ToMessage()).To(Equal(repositories.ListUsageEventsMessage{
+			GUIDs:     []string{"a", "b"},
+			AfterGUID: "c",
+		}))
+	})
+})
+
+var _ = Describe("ServiceUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.ServiceUsageEventList) {
+			actualList, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=a,b", payloads.ServiceUsageEventList{GUIDs: "a,b"}),
+		Entry("after_guid", "after_guid=a", payloads.ServiceUsageEventList{AfterGUID: "a"}),
+		Entry("service_instance_types", "service_instance_types=managed_service_instance", payloads.ServiceUsageEventList{ServiceInstanceTypes: "managed_service_instance"}),
+		Entry("service_offering_guids", "service_offering_guids=a,b", payloads.ServiceUsageEventList{ServiceOfferingGUIDs: "a,b"}),
+		Entry("empty", "", payloads.ServiceUsageEventList{}),
+	)
+
+	It("converts to a message", func() {
+		Expect(payloads.ServiceUsageEventList{
+			GUIDs:                "a,b",
+			AfterGUID:            "c",
+			ServiceInstanceTypes: "managed_service_instance",
+			ServiceOfferingGUIDs: "d",
+		}.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+			ListUsageEventsMessage: repositories.ListUsageEventsMessage{
+				GUIDs:     []string{"a", "b"},
+				AfterGUID: "c",
+			},
+			ServiceInstanceTypes: []string{"managed_service_instance"},
+			ServiceOfferingGUIDs: []string{"d"},
+		}))
+	})
+})
@@ -0,0 +1,70 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/params"
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type UserCreate struct {
+	GUID     string `json:"guid"`
+	Username string `json:"username"`
+	Origin   string `json:"origin"`
+}
+
+func (c UserCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.GUID,
+			validation.When(c.Username == "", validation.Required.Error("or username is required")),
+			validation.When(c.Username != "", validation.Empty.Error("cannot be specified together with username")),
+		),
+		validation.Field(&c.Origin,
+			validation.When(c.Username != "", payload_validation.StrictlyRequired),
+		),
+	)
+}
+
+func (c UserCreate) ToMessage() repositories.CreateUserMessage {
+	if c.GUID != "" {
+		return repositories.CreateUserMessage{Username: c.GUID}
+	}
+
+	return repositories.CreateUserMessage{
+		Username: c.Username,
+		Origin:   c.Origin,
+	}
+}
+
+type UserList struct {
+	GUIDs      string
+	Usernames  string
+	Origins    string
+	Timestamps repositories.TimestampFilters
+}
+
+func (l UserList) ToMessage() repositories.ListUsersMessage {
+	return repositories.ListUsersMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Usernames:  parse.ArrayParam(l.Usernames),
+		Origins:    parse.ArrayParam(l.Origins),
+		Timestamps: l.Timestamps,
+	}
+}
+
+func (l *UserList) SupportedKeys() []string {
+	return append([]string{"guids", "usernames", "origins", "per_page", "page"}, params.TimestampFilterKeys()...)
+}
+
+func (l *UserList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Usernames = values.Get("usernames")
+	l.Origins = values.Get("origins")
+
+	var err error
+	l.Timestamps, err = params.ParseTimestampFilters(values)
+	return err
+}
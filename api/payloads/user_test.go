@@ -0,0 +1,105 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserCreate", func() {
+	var (
+		createPayload  payloads.UserCreate
+		decodedPayload *payloads.UserCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.UserCreate)
+		createPayload = payloads.UserCreate{
+			Username: "bob",
+			Origin:   "uaa",
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	It("converts to a message", func() {
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateUserMessage{
+			Username: "bob",
+			Origin:   "uaa",
+		}))
+	})
+
+	When("the origin is missing", func() {
+		BeforeEach(func() {
+			createPayload.Origin = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "origin cannot be blank")
+		})
+	})
+
+	When("only the guid is specified", func() {
+		BeforeEach(func() {
+			createPayload = payloads.UserCreate{GUID: "bob"}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+
+		It("uses the guid as username", func() {
+			Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateUserMessage{
+				Username: "bob",
+			}))
+		})
+	})
+
+	When("both the guid and the username are specified", func() {
+		BeforeEach(func() {
+			createPayload.GUID = "bob"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot be specified together with username")
+		})
+	})
+
+	When("neither the guid nor the username are specified", func() {
+		BeforeEach(func() {
+			createPayload = payloads.UserCreate{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "or username is required")
+		})
+	})
+})
+
+var _ = Describe("UserList", func() {
+	It("decodes from url values", func() {
+		userList := payloads.UserList{}
+		req, err := http.NewRequest("GET", "http://foo.com/bar?guids=g1,g2&usernames=bob,alice&origins=uaa", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validator.DecodeAndValidateURLValues(req, &userList)).To(Succeed())
+
+		Expect(userList.ToMessage()).To(Equal(repositories.ListUsersMessage{
+			GUIDs:     []string{"g1", "g2"},
+			Usernames: []string{"bob", "alice"},
+			Origins:   []string{"uaa"},
+		}))
+	})
+})
@@ -23,6 +23,7 @@ type AppResponse struct {
 	Lifecycle     Lifecycle                    `json:"lifecycle"`
 	Metadata      Metadata                     `json:"metadata"`
 	Links         AppLinks                     `json:"links"`
+	Included      map[string][]any             `json:"included,omitempty"`
 }
 
 type AppLinks struct {
@@ -103,6 +104,7 @@ func ForApp(responseApp repositories.AppRecord, baseURL url.URL, includes ...inc
 				HRef: buildURL(baseURL).appendPath(appsBase, responseApp.GUID, "features").build(),
 			},
 		},
+		Included: includedResources(includes...),
 	}
 }
 
@@ -167,8 +169,8 @@ type AppEnvResponse struct {
 func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
 	return AppEnvResponse{
 		EnvironmentVariables: envVarRecord.EnvironmentVariables,
-		StagingEnvJSON:       map[string]string{},
-		RunningEnvJSON:       map[string]string{},
+		StagingEnvJSON:       emptyMapIfNil(envVarRecord.StagingEnv),
+		RunningEnvJSON:       emptyMapIfNil(envVarRecord.RunningEnv),
 		SystemEnvJSON:        emptyMapToAnyIfEmpty(envVarRecord.SystemEnv),
 		ApplicationEnvJSON:   emptyMapToAnyIfEmpty(envVarRecord.AppEnv),
 	}
@@ -215,6 +215,7 @@ var _ = Describe("App", func() {
 		BeforeEach(func() {
 			record = repositories.AppEnvRecord{
 				EnvironmentVariables: map[string]string{"VAR": "VAL"},
+				RunningEnv:           map[string]string{"HTTP_PROXY": "http://proxy"},
 				SystemEnv: map[string]any{
 					"VCAP_SERVICES": map[string]any{
 						"mysql": map[string]any{
@@ -240,7 +241,9 @@ var _ = Describe("App", func() {
 		It("returns the expected output", func() {
 			Expect(output).To(MatchJSON(`{
 				"staging_env_json": {},
-				"running_env_json": {},
+				"running_env_json": {
+					"HTTP_PROXY": "http://proxy"
+				},
 				"environment_variables": {
 					"VAR": "VAL"
 				},
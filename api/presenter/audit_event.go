@@ -0,0 +1,86 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const auditEventsBase = "/v3/audit_events"
+
+type AuditEventResponse struct {
+	GUID         string                 `json:"guid"`
+	CreatedAt    string                 `json:"created_at"`
+	UpdatedAt    string                 `json:"updated_at"`
+	Type         string                 `json:"type"`
+	Actor        AuditEventResource     `json:"actor"`
+	Target       AuditEventResource     `json:"target"`
+	Data         map[string]any         `json:"data"`
+	Space        AuditEventRelationship `json:"space"`
+	Organization AuditEventRelationship `json:"organization"`
+	Links        AuditEventLinks        `json:"links"`
+}
+
+type AuditEventResource struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventRelationship struct {
+	GUID *string `json:"guid"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(record repositories.AuditEventRecord, baseURL url.URL, includes ...include.Resource) AuditEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&record.CreatedAt))
+
+	return AuditEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Type:      record.Type,
+		Actor: AuditEventResource{
+			GUID: record.ActorGUID,
+			Type: record.ActorType,
+			Name: record.ActorName,
+		},
+		Target: AuditEventResource{
+			GUID: record.TargetGUID,
+			Type: record.TargetType,
+			Name: record.TargetName,
+		},
+		Data: auditEventData(record),
+		Space: AuditEventRelationship{
+			GUID: nilIfEmpty(record.SpaceGUID),
+		},
+		Organization: AuditEventRelationship{
+			GUID: nilIfEmpty(record.OrgGUID),
+		},
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func auditEventData(record repositories.AuditEventRecord) map[string]any {
+	if crash := record.ProcessCrash; crash != nil {
+		return map[string]any{
+			"index":            crash.Index,
+			"reason":           crash.Reason,
+			"exit_status":      crash.ExitStatus,
+			"exit_description": crash.ExitDescription,
+			"crash_count":      crash.CrashCount,
+			"crash_timestamp":  crash.CrashTimestamp.UnixNano(),
+		}
+	}
+
+	return map[string]any{}
+}
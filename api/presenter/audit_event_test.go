@@ -0,0 +1,105 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Audit Events", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.AuditEventRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.AuditEventRecord{
+			GUID:       "event-guid",
+			CreatedAt:  time.UnixMilli(1000),
+			Type:       "audit.app.process.crash",
+			ActorGUID:  "process-guid",
+			ActorType:  "process",
+			ActorName:  "web",
+			TargetGUID: "app-guid",
+			TargetType: "app",
+			TargetName: "my-app",
+			SpaceGUID:  "space-guid",
+			OrgGUID:    "org-guid",
+			ProcessCrash: &korifiv1alpha1.ProcessCrashData{
+				Index:           2,
+				Reason:          "OOMKilled",
+				ExitStatus:      137,
+				ExitDescription: "Exited with status 137 (out of memory)",
+				CrashCount:      3,
+				CrashTimestamp:  metav1.NewTime(time.UnixMilli(3000)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForAuditEvent(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:01Z",
+			"type": "audit.app.process.crash",
+			"actor": {
+				"guid": "process-guid",
+				"type": "process",
+				"name": "web"
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {
+				"index": 2,
+				"reason": "OOMKilled",
+				"exit_status": 137,
+				"exit_description": "Exited with status 137 (out of memory)",
+				"crash_count": 3,
+				"crash_timestamp": 3000000000
+			},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event has no data", func() {
+		BeforeEach(func() {
+			record.ProcessCrash = nil
+		})
+
+		It("presents empty data", func() {
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+})
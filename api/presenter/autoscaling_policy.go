@@ -0,0 +1,128 @@
+package presenter
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+type AutoscalingPolicyResponse struct {
+	InstanceMinCount        int32                       `json:"instance_min_count"`
+	InstanceMaxCount        int32                       `json:"instance_max_count"`
+	CPUUtilizationTarget    *int32                      `json:"cpu_utilization_target"`
+	MemoryUtilizationTarget *int32                      `json:"memory_utilization_target"`
+	Schedules               *AutoscalingPolicySchedules `json:"schedules"`
+	Vertical                *AutoscalingPolicyVertical  `json:"vertical"`
+	Status                  AutoscalingPolicyStatus     `json:"status"`
+	CreatedAt               string                      `json:"created_at"`
+	UpdatedAt               string                      `json:"updated_at"`
+	Links                   AutoscalingPolicyLinks      `json:"links"`
+}
+
+type AutoscalingPolicySchedules struct {
+	Timezone          string                         `json:"timezone"`
+	RecurringSchedule []AutoscalingRecurringSchedule `json:"recurring_schedule"`
+}
+
+type AutoscalingRecurringSchedule struct {
+	DaysOfWeek       []int32 `json:"days_of_week"`
+	StartTime        string  `json:"start_time"`
+	EndTime          string  `json:"end_time"`
+	InstanceMinCount int32   `json:"instance_min_count"`
+	InstanceMaxCount int32   `json:"instance_max_count"`
+}
+
+type AutoscalingPolicyVertical struct {
+	MemoryInMBMin int64 `json:"memory_in_mb_min"`
+	MemoryInMBMax int64 `json:"memory_in_mb_max"`
+}
+
+type AutoscalingPolicyStatus struct {
+	CurrentInstances      int32                 `json:"current_instances"`
+	DesiredInstances      int32                 `json:"desired_instances"`
+	ActiveSchedule        *int32                `json:"active_schedule"`
+	RecommendedMemoryInMB *int64                `json:"recommended_memory_in_mb"`
+	RecentDecisions       []AutoscalingDecision `json:"recent_decisions"`
+}
+
+type AutoscalingDecision struct {
+	Time          string `json:"time"`
+	FromInstances int32  `json:"from_instances"`
+	ToInstances   int32  `json:"to_instances"`
+	Reason        string `json:"reason"`
+}
+
+type AutoscalingPolicyLinks struct {
+	Self    Link `json:"self"`
+	Process Link `json:"process"`
+}
+
+func ForAutoscalingPolicy(record repositories.AutoscalingPolicyRecord, baseURL url.URL) AutoscalingPolicyResponse {
+	response := AutoscalingPolicyResponse{
+		InstanceMinCount:        record.MinInstances,
+		InstanceMaxCount:        record.MaxInstances,
+		CPUUtilizationTarget:    record.CPUUtilizationPercent,
+		MemoryUtilizationTarget: record.MemoryUtilizationPercent,
+		Status: AutoscalingPolicyStatus{
+			CurrentInstances: record.CurrentInstances,
+			DesiredInstances: record.DesiredInstances,
+			ActiveSchedule:   record.ActiveSchedule,
+			RecentDecisions: slices.Collect(it.Map(slices.Values(record.RecentDecisions), func(d repositories.ScalingDecision) AutoscalingDecision {
+				return AutoscalingDecision{
+					Time:          tools.ZeroIfNil(formatTimestamp(&d.Time)),
+					FromInstances: d.FromInstances,
+					ToInstances:   d.ToInstances,
+					Reason:        d.Reason,
+				}
+			})),
+		},
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		Links: AutoscalingPolicyLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, record.ProcessGUID, "autoscaling_policy").build(),
+			},
+			Process: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, record.ProcessGUID).build(),
+			},
+		},
+	}
+
+	if response.Status.RecentDecisions == nil {
+		response.Status.RecentDecisions = []AutoscalingDecision{}
+	}
+
+	if record.Timezone != "" || len(record.Schedules) > 0 {
+		response.Schedules = &AutoscalingPolicySchedules{
+			Timezone: record.Timezone,
+			RecurringSchedule: slices.Collect(it.Map(slices.Values(record.Schedules), func(s repositories.AutoscalingSchedule) AutoscalingRecurringSchedule {
+				return AutoscalingRecurringSchedule{
+					DaysOfWeek:       s.DaysOfWeek,
+					StartTime:        s.StartTime,
+					EndTime:          s.EndTime,
+					InstanceMinCount: s.MinInstances,
+					InstanceMaxCount: s.MaxInstances,
+				}
+			})),
+		}
+		if response.Schedules.RecurringSchedule == nil {
+			response.Schedules.RecurringSchedule = []AutoscalingRecurringSchedule{}
+		}
+	}
+
+	if record.Vertical != nil {
+		response.Vertical = &AutoscalingPolicyVertical{
+			MemoryInMBMin: record.Vertical.MinMemoryMB,
+			MemoryInMBMax: record.Vertical.MaxMemoryMB,
+		}
+	}
+
+	if record.RecommendedMemoryMB > 0 {
+		response.Status.RecommendedMemoryInMB = tools.PtrTo(record.RecommendedMemoryMB)
+	}
+
+	return response
+}
@@ -0,0 +1,134 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoscalingPolicy", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AutoscalingPolicyRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AutoscalingPolicyRecord{
+			ProcessGUID:           "process-guid",
+			SpaceGUID:             "space-guid",
+			MinInstances:          1,
+			MaxInstances:          5,
+			CPUUtilizationPercent: tools.PtrTo[int32](70),
+			Timezone:              "Europe/London",
+			Schedules: []repositories.AutoscalingSchedule{{
+				DaysOfWeek:   []int32{1, 2, 3, 4, 5},
+				StartTime:    "08:00",
+				EndTime:      "18:00",
+				MinInstances: 3,
+				MaxInstances: 10,
+			}},
+			Vertical: &repositories.VerticalAutoscaling{
+				MinMemoryMB: 256,
+				MaxMemoryMB: 2048,
+			},
+			CurrentInstances:    2,
+			DesiredInstances:    3,
+			ActiveSchedule:      tools.PtrTo[int32](0),
+			RecommendedMemoryMB: 512,
+			RecentDecisions: []repositories.ScalingDecision{{
+				Time:          time.UnixMilli(3000),
+				FromInstances: 2,
+				ToInstances:   3,
+				Reason:        "cpu utilization 140% (target 70%)",
+			}},
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAutoscalingPolicy(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected autoscaling policy json", func() {
+		Expect(output).To(MatchJSON(`{
+			"instance_min_count": 1,
+			"instance_max_count": 5,
+			"cpu_utilization_target": 70,
+			"memory_utilization_target": null,
+			"schedules": {
+				"timezone": "Europe/London",
+				"recurring_schedule": [{
+					"days_of_week": [1, 2, 3, 4, 5],
+					"start_time": "08:00",
+					"end_time": "18:00",
+					"instance_min_count": 3,
+					"instance_max_count": 10
+				}]
+			},
+			"vertical": {
+				"memory_in_mb_min": 256,
+				"memory_in_mb_max": 2048
+			},
+			"status": {
+				"current_instances": 2,
+				"desired_instances": 3,
+				"active_schedule": 0,
+				"recommended_memory_in_mb": 512,
+				"recent_decisions": [{
+					"time": "1970-01-01T00:00:03Z",
+					"from_instances": 2,
+					"to_instances": 3,
+					"reason": "cpu utilization 140% (target 70%)"
+				}]
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/processes/process-guid/autoscaling_policy"
+				},
+				"process": {
+					"href": "https://api.example.org/v3/processes/process-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the policy has no schedules, vertical scaling or decisions", func() {
+		BeforeEach(func() {
+			record.Timezone = ""
+			record.Schedules = nil
+			record.Vertical = nil
+			record.ActiveSchedule = nil
+			record.RecommendedMemoryMB = 0
+			record.RecentDecisions = nil
+		})
+
+		It("presents them as empty", func() {
+			var response map[string]any
+			Expect(json.Unmarshal(output, &response)).To(Succeed())
+			Expect(response).To(HaveKeyWithValue("schedules", BeNil()))
+			Expect(response).To(HaveKeyWithValue("vertical", BeNil()))
+			Expect(response).To(HaveKeyWithValue("status", SatisfyAll(
+				HaveKeyWithValue("active_schedule", BeNil()),
+				HaveKeyWithValue("recommended_memory_in_mb", BeNil()),
+				HaveKeyWithValue("recent_decisions", BeEmpty()),
+			)))
+		})
+	})
+})
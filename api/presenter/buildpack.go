@@ -8,11 +8,14 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 )
 
+const buildpacksBase = "/v3/buildpacks"
+
 type BuildpackResponse struct {
 	GUID      string          `json:"guid"`
 	CreatedAt string          `json:"created_at"`
 	UpdatedAt string          `json:"updated_at"`
 	Name      string          `json:"name"`
+	State     string          `json:"state"`
 	Filename  string          `json:"filename"`
 	Stack     string          `json:"stack"`
 	Position  int             `json:"position"`
@@ -22,23 +25,36 @@ type BuildpackResponse struct {
 	Links     map[string]Link `json:"links"`
 }
 
-func ForBuildpack(buildpackRecord repositories.BuildpackRecord, _ url.URL, includes ...include.Resource) BuildpackResponse {
+func ForBuildpack(buildpackRecord repositories.BuildpackRecord, baseURL url.URL, includes ...include.Resource) BuildpackResponse {
 	toReturn := BuildpackResponse{
-		GUID:      "",
+		GUID:      buildpackRecord.GUID,
 		CreatedAt: tools.ZeroIfNil(formatTimestamp(&buildpackRecord.CreatedAt)),
 		UpdatedAt: tools.ZeroIfNil(formatTimestamp(buildpackRecord.UpdatedAt)),
 		Name:      buildpackRecord.Name,
-		Filename:  buildpackRecord.Name + "@" + buildpackRecord.Version,
+		State:     buildpackRecord.State,
+		Filename:  buildpackRecord.Filename,
 		Stack:     buildpackRecord.Stack,
 		Position:  buildpackRecord.Position,
-		Enabled:   true,
-		Locked:    false,
+		Enabled:   buildpackRecord.Enabled,
+		Locked:    buildpackRecord.Locked,
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      emptyMapIfNil(buildpackRecord.Labels),
+			Annotations: emptyMapIfNil(buildpackRecord.Annotations),
 		},
 		Links: map[string]Link{},
 	}
 
+	// buildpacks configured directly on the builder cannot be managed
+	// through the API and therefore have no links
+	if buildpackRecord.GUID != "" {
+		toReturn.Links["self"] = Link{
+			HRef: buildURL(baseURL).appendPath(buildpacksBase, buildpackRecord.GUID).build(),
+		}
+		toReturn.Links["upload"] = Link{
+			HRef:   buildURL(baseURL).appendPath(buildpacksBase, buildpackRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	}
+
 	return toReturn
 }
@@ -21,17 +21,21 @@ var _ = Describe("Buildpacks", func() {
 	BeforeEach(func() {
 		record = repositories.BuildpackRecord{
 			Name:      "paketo-foopacks/bar",
+			Filename:  "paketo-foopacks/bar@1.0.0",
+			State:     "READY",
 			Position:  1,
 			Stack:     "waffle-house",
 			Version:   "1.0.0",
+			Enabled:   true,
 			CreatedAt: time.UnixMilli(1000),
 			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
 		}
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForBuildpack(record, url.URL{})
-		var err error
+		baseURL, err := url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		response := presenter.ForBuildpack(record, *baseURL)
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -42,6 +46,7 @@ var _ = Describe("Buildpacks", func() {
 			"created_at": "1970-01-01T00:00:01Z",
 			"updated_at": "1970-01-01T00:00:02Z",
 			"name": "paketo-foopacks/bar",
+			"state": "READY",
 			"filename": "paketo-foopacks/bar@1.0.0",
 			"stack": "waffle-house",
 			"position": 1,
@@ -54,4 +59,42 @@ var _ = Describe("Buildpacks", func() {
 			"links": {}
 		}`))
 	})
+
+	When("the buildpack is managed through the API", func() {
+		BeforeEach(func() {
+			record.GUID = "buildpack-guid"
+			record.Locked = true
+			record.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("includes the guid, metadata and links", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "buildpack-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "paketo-foopacks/bar",
+				"state": "READY",
+				"filename": "paketo-foopacks/bar@1.0.0",
+				"stack": "waffle-house",
+				"position": 1,
+				"enabled": true,
+				"locked": true,
+				"metadata": {
+					"labels": {
+						"foo": "bar"
+					},
+					"annotations": {}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/buildpacks/buildpack-guid"
+					},
+					"upload": {
+						"href": "https://api.example.org/v3/buildpacks/buildpack-guid/upload",
+						"method": "POST"
+					}
+				}
+			}`))
+		})
+	})
 })
@@ -77,6 +77,20 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 			"download": nil,
 		},
 	}
+	if dropletRecord.PackageGUID == "" {
+		toReturn.Links["package"] = nil
+	}
+	if dropletRecord.Lifecycle.Type == "buildpack" && dropletRecord.State == repositories.DropletStateStaged {
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	}
+	if dropletRecord.State == repositories.DropletStateAwaitingUpload {
+		toReturn.Links["upload"] = &Link{
+			HRef:   buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	}
 	if dropletRecord.DropletErrorMsg != "" {
 		toReturn.Error = &dropletRecord.DropletErrorMsg
 	}
@@ -95,7 +95,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
@@ -174,6 +176,26 @@ var _ = Describe("Droplet", func() {
 		})
 	})
 
+	When("the droplet is awaiting upload", func() {
+		BeforeEach(func() {
+			record.State = "AWAITING_UPLOAD"
+			record.PackageGUID = ""
+		})
+
+		It("presents the upload link", func() {
+			Expect(output).To(MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/droplets/the-droplet-guid/upload"))
+			Expect(output).To(MatchJSONPath("$.links.upload.method", "POST"))
+		})
+
+		It("does not present the download link", func() {
+			Expect(output).To(MatchJSONPath("$.links.download", BeNil()))
+		})
+
+		It("does not present the package link", func() {
+			Expect(output).To(MatchJSONPath("$.links.package", BeNil()))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
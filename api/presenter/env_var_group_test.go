@@ -0,0 +1,74 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Environment Variable Groups", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.EnvVarGroupRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.EnvVarGroupRecord{
+			Name:                 "running",
+			EnvironmentVariables: map[string]string{"HTTP_PROXY": "http://proxy"},
+			UpdatedAt:            tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForEnvVarGroup(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"updated_at": "1970-01-01T00:00:02Z",
+			"name": "running",
+			"var": {
+				"HTTP_PROXY": "http://proxy"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/environment_variable_groups/running"
+				}
+			}
+		}`))
+	})
+
+	When("the group has never been set", func() {
+		BeforeEach(func() {
+			record = repositories.EnvVarGroupRecord{Name: "staging"}
+		})
+
+		It("returns an empty var and a null updated_at", func() {
+			Expect(output).To(MatchJSON(`{
+				"updated_at": null,
+				"name": "staging",
+				"var": {},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/environment_variable_groups/staging"
+					}
+				}
+			}`))
+		})
+	})
+})
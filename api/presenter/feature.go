@@ -0,0 +1,24 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+type FeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type SSHCodeResponse struct {
+	Code      string `json:"code"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func ForSSHCode(record repositories.SSHCodeRecord) SSHCodeResponse {
+	return SSHCodeResponse{
+		Code:      record.Code,
+		ExpiresAt: tools.ZeroIfNil(formatTimestamp(&record.ExpiresAt)),
+	}
+}
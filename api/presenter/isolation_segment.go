@@ -0,0 +1,113 @@
+package presenter
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+const isolationSegmentsBase = "/v3/isolation_segments"
+
+type IsolationSegmentResponse struct {
+	GUID      string                                 `json:"guid"`
+	Name      string                                 `json:"name"`
+	CreatedAt string                                 `json:"created_at"`
+	UpdatedAt string                                 `json:"updated_at"`
+	Placement repositories.IsolationSegmentPlacement `json:"placement"`
+	Metadata  Metadata                               `json:"metadata"`
+	Links     IsolationSegmentLinks                  `json:"links"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+func ForIsolationSegment(record repositories.IsolationSegmentRecord, baseURL url.URL, includes ...include.Resource) IsolationSegmentResponse {
+	tolerations := record.Placement.Tolerations
+	if tolerations == nil {
+		tolerations = []repositories.IsolationSegmentToleration{}
+	}
+
+	return IsolationSegmentResponse{
+		GUID:      record.GUID,
+		Name:      record.Name,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		Placement: repositories.IsolationSegmentPlacement{
+			NodeSelector: emptyMapIfNil(record.Placement.NodeSelector),
+			Tolerations:  tolerations,
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type IsolationSegmentOrganizationsResponse struct {
+	Data  []Relationship                     `json:"data"`
+	Links IsolationSegmentRelationshipsLinks `json:"links"`
+}
+
+type IsolationSegmentRelationshipsLinks struct {
+	Self    Link `json:"self"`
+	Related Link `json:"related"`
+}
+
+func ForIsolationSegmentOrganizations(record repositories.IsolationSegmentRecord, baseURL url.URL) IsolationSegmentOrganizationsResponse {
+	return IsolationSegmentOrganizationsResponse{
+		Data: slices.Collect(it.Map(slices.Values(emptySliceIfNil(record.OrganizationGUIDs)), func(guid string) Relationship {
+			return Relationship{GUID: guid}
+		})),
+		Links: IsolationSegmentRelationshipsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "relationships", "organizations").build(),
+			},
+			Related: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type SpaceIsolationSegmentResponse struct {
+	Data  *Relationship              `json:"data"`
+	Links SpaceIsolationSegmentLinks `json:"links"`
+}
+
+type SpaceIsolationSegmentLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+func ForSpaceIsolationSegment(record repositories.SpaceRecord, baseURL url.URL) SpaceIsolationSegmentResponse {
+	response := SpaceIsolationSegmentResponse{
+		Links: SpaceIsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, record.GUID, "relationships", "isolation_segment").build(),
+			},
+		},
+	}
+
+	if record.IsolationSegmentGUID != "" {
+		response.Data = &Relationship{GUID: record.IsolationSegmentGUID}
+		response.Links.Related = &Link{
+			HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.IsolationSegmentGUID).build(),
+		}
+	}
+
+	return response
+}
@@ -24,6 +24,10 @@ const (
 	SpaceDeleteUnmappedRoutesOperation = "space.delete_unapped_routes"
 	DomainDeleteOperation              = "domain.delete"
 	RoleDeleteOperation                = "role.delete"
+	UserDeleteOperation                = "user.delete"
+	BuildpackDeleteOperation           = "buildpack.delete"
+	StackDeleteOperation               = "stack.delete"
+	DropletUploadOperation             = "droplet.upload"
 	ServiceBrokerCreateOperation       = "service_broker.create"
 	ServiceBrokerDeleteOperation       = "service_broker.delete"
 	ServiceBrokerUpdateOperation       = "service_broker.update"
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/promql"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 )
@@ -147,3 +148,74 @@ func ForStats(appRecord repositories.AppRecord, appPodStats []actions.PodStatsRe
 		},
 	}
 }
+
+type PromQLResponse struct {
+	Status string     `json:"status"`
+	Data   PromQLData `json:"data"`
+}
+
+type PromQLData struct {
+	ResultType string `json:"resultType"`
+	Result     []any  `json:"result"`
+}
+
+type PromQLSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  PromQLPoint       `json:"value"`
+}
+
+type PromQLSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []PromQLPoint     `json:"values"`
+}
+
+// PromQLPoint is serialized as a [<unix seconds>, "<value>"] pair, same as
+// the prometheus HTTP API does
+type PromQLPoint promql.Point
+
+func (p PromQLPoint) MarshalJSON() ([]byte, error) {
+	timestamp := strconv.FormatFloat(float64(p.Timestamp.UnixMilli())/1000, 'f', -1, 64)
+	value := strconv.Quote(strconv.FormatFloat(p.Value, 'f', -1, 64))
+	return []byte("[" + timestamp + "," + value + "]"), nil
+}
+
+func ForPromQLVector(samples []promql.Sample) PromQLResponse {
+	result := []any{}
+	for _, sample := range samples {
+		result = append(result, PromQLSample{
+			Metric: sample.Labels,
+			Value:  PromQLPoint(sample.Point),
+		})
+	}
+
+	return PromQLResponse{
+		Status: "success",
+		Data: PromQLData{
+			ResultType: "vector",
+			Result:     result,
+		},
+	}
+}
+
+func ForPromQLMatrix(series []promql.Series) PromQLResponse {
+	result := []any{}
+	for _, s := range series {
+		values := []PromQLPoint{}
+		for _, point := range s.Points {
+			values = append(values, PromQLPoint(point))
+		}
+
+		result = append(result, PromQLSeries{
+			Metric: s.Labels,
+			Values: values,
+		})
+	}
+
+	return PromQLResponse{
+		Status: "success",
+		Data: PromQLData{
+			ResultType: "matrix",
+			Result:     result,
+		},
+	}
+}
@@ -148,5 +148,5 @@ func ForProcessList(processRecordList []repositories.ProcessRecord, baseURL, req
 		processResponse := ForProcess(process, baseURL)
 		processResponse.Command = "[PRIVATE DATA HIDDEN IN LISTS]"
 		return processResponse
-	}, processRecordList, baseURL, requestURL)
+	}, processRecordList, baseURL, requestURL, includes...)
 }
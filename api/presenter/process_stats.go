@@ -22,6 +22,19 @@ type ProcessStatsResource struct {
 	MemQuota  *int64        `json:"mem_quota,omitempty"`
 	DiskQuota *int64        `json:"disk_quota,omitempty"`
 	Uptime    *int64        `json:"uptime,omitempty"`
+	Details   *string       `json:"details,omitempty"`
+
+	// Korifi specific, the crash history of the instance
+	CrashCount    int32         `json:"crash_count,omitempty"`
+	LastCrash     *ProcessCrash `json:"last_crash,omitempty"`
+	CrashingSince *string       `json:"crashing_since,omitempty"`
+}
+
+type ProcessCrash struct {
+	Reason          string `json:"reason"`
+	ExitStatus      int32  `json:"exit_status"`
+	ExitDescription string `json:"exit_description"`
+	Time            string `json:"time"`
 }
 
 type ProcessUsage struct {
@@ -40,10 +53,25 @@ func ForProcessStats(gauges []stats.ProcessGauges, instancesState []stats.Proces
 	resources := []ProcessStatsResource{}
 	for _, instanceState := range instancesState {
 		statsResource := ProcessStatsResource{
-			Type:   instanceState.Type,
-			Index:  instanceState.ID,
-			State:  string(instanceState.State),
-			Uptime: computeUptime(now, instanceState),
+			Type:       instanceState.Type,
+			Index:      instanceState.ID,
+			State:      string(instanceState.State),
+			Uptime:     computeUptime(now, instanceState),
+			Details:    nilIfEmpty(instanceState.Details),
+			CrashCount: instanceState.CrashCount,
+		}
+
+		if lastCrash := instanceState.LastCrash; lastCrash != nil {
+			statsResource.LastCrash = &ProcessCrash{
+				Reason:          lastCrash.Reason,
+				ExitStatus:      lastCrash.ExitStatus,
+				ExitDescription: lastCrash.ExitDescription,
+				Time:            tools.ZeroIfNil(formatTimestamp(&lastCrash.Timestamp.Time)),
+			}
+		}
+
+		if crashingSince := instanceState.CrashingSince; crashingSince != nil {
+			statsResource.CrashingSince = formatTimestamp(&crashingSince.Time)
 		}
 
 		if gauge, hasGauge := gaugesMap[instanceState.ID]; hasGauge {
@@ -94,6 +94,49 @@ var _ = Describe("Process Stats", func() {
 		}`))
 	})
 
+	When("an instance has crashed", func() {
+		BeforeEach(func() {
+			instancesState = []stats.ProcessInstanceState{
+				{
+					ID:         2,
+					Type:       "web",
+					State:      korifiv1alpha1.InstanceStateCrashed,
+					Timestamp:  tools.PtrTo(metav1.NewTime(time.UnixMilli(3000).UTC())),
+					Details:    "CrashLoopBackOff: back-off 40s restarting failed container",
+					CrashCount: 3,
+					LastCrash: &korifiv1alpha1.InstanceCrash{
+						Reason:          "OOMKilled",
+						ExitStatus:      137,
+						ExitDescription: "Exited with status 137 (out of memory)",
+						Timestamp:       metav1.NewTime(time.UnixMilli(3000).UTC()),
+					},
+					CrashingSince: tools.PtrTo(metav1.NewTime(time.UnixMilli(1000).UTC())),
+				},
+			}
+		})
+
+		It("presents the crash details", func() {
+			Expect(output).To(MatchJSON(`{
+				"resources": [
+					{
+						"type": "web",
+						"index": 2,
+						"state": "CRASHED",
+						"details": "CrashLoopBackOff: back-off 40s restarting failed container",
+						"crash_count": 3,
+						"last_crash": {
+							"reason": "OOMKilled",
+							"exit_status": 137,
+							"exit_description": "Exited with status 137 (out of memory)",
+							"time": "1970-01-01T00:00:03Z"
+						},
+						"crashing_since": "1970-01-01T00:00:01Z"
+					}
+				]
+			}`))
+		})
+	})
+
 	When("there are gauges for instance that is not available", func() {
 		BeforeEach(func() {
 			instancesState = []stats.ProcessInstanceState{
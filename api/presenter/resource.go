@@ -29,6 +29,12 @@ func (r *Resource) PresentResource(resource relationships.Resource) any {
 		return ForSpace(res, r.serverURL)
 	case repositories.OrgRecord:
 		return ForOrg(res, r.serverURL)
+	case repositories.UserRecord:
+		return ForUser(res, r.serverURL)
+	case repositories.DomainRecord:
+		return ForDomain(res, r.serverURL)
+	case repositories.AppRecord:
+		return ForApp(res, r.serverURL)
 	default:
 		return resource
 	}
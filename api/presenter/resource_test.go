@@ -69,4 +69,34 @@ var _ = Describe("Resource", func() {
 			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.ServicePlanResponse{}))
 		})
 	})
+
+	When("the resource is a user", func() {
+		BeforeEach(func() {
+			resource = repositories.UserRecord{}
+		})
+
+		It("returns presented user", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.UserResponse{}))
+		})
+	})
+
+	When("the resource is a domain", func() {
+		BeforeEach(func() {
+			resource = repositories.DomainRecord{}
+		})
+
+		It("returns presented domain", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.DomainResponse{}))
+		})
+	})
+
+	When("the resource is an app", func() {
+		BeforeEach(func() {
+			resource = repositories.AppRecord{}
+		})
+
+		It("returns presented app", func() {
+			Expect(presentedResource).To(BeAssignableToTypeOf(presenter.AppResponse{}))
+		})
+	})
 })
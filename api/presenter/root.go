@@ -12,7 +12,14 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
+}
+
+type AppSSH struct {
+	Address            string
+	HostKeyFingerprint string
 }
 
 type RootResponse struct {
@@ -20,9 +27,12 @@ type RootResponse struct {
 	CFOnK8s bool                `json:"cf_on_k8s"`
 }
 
-const V3APIVersion = "3.117.0+cf-k8s"
+const (
+	V3APIVersion        = "3.117.0+cf-k8s"
+	SSHProxyOAuthClient = "ssh-proxy"
+)
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootResponse {
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, appSSH AppSSH) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -76,6 +86,18 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 		}
 	}
 
+	if appSSH.Address != "" {
+		rootResponse.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: appSSH.Address,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: appSSH.HostKeyFingerprint,
+				OAuthClient:        SSHProxyOAuthClient,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
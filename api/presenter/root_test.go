@@ -9,6 +9,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Root endpoints", func() {
@@ -27,14 +28,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig config.UAA
+			appSSH    presenter.AppSSH
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			appSSH = presenter.AppSSH{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL)
+			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL, appSSH)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -137,6 +142,28 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 			})
 		})
+
+		When("app ssh is enabled", func() {
+			BeforeEach(func() {
+				appSSH = presenter.AppSSH{
+					Address:            "ssh.example.org:2222",
+					HostKeyFingerprint: "my-fingerprint",
+				}
+			})
+
+			It("presents the app_ssh link", func() {
+				var resp map[string]any
+				Expect(json.Unmarshal(output, &resp)).To(Succeed())
+				Expect(resp).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", MatchAllKeys(Keys{
+					"href": Equal("ssh.example.org:2222"),
+					"meta": MatchAllKeys(Keys{
+						"version":              BeEmpty(),
+						"host_key_fingerprint": Equal("my-fingerprint"),
+						"oauth_client":         Equal("ssh-proxy"),
+					}),
+				}))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
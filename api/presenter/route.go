@@ -27,6 +27,7 @@ type RouteResponse struct {
 	Relationships map[string]ToOneRelationship `json:"relationships"`
 	Metadata      Metadata                     `json:"metadata"`
 	Links         routeLinks                   `json:"links"`
+	Included      map[string][]any             `json:"included,omitempty"`
 }
 
 type RouteDestinationsResponse struct {
@@ -96,6 +97,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...inclu
 				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "destinations").build(),
 			},
 		},
+		Included: includedResources(includes...),
 	}
 }
 
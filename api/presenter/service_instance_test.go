@@ -133,6 +133,16 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 
+	When("the service instance has a syslog drain url", func() {
+		BeforeEach(func() {
+			record.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+		})
+
+		It("returns the syslog drain url", func() {
+			Expect(output).To(MatchJSONPath("$.syslog_drain_url", Equal("syslog://logs.example.com:514")))
+		})
+	})
+
 	When("the service instance is managed", func() {
 		BeforeEach(func() {
 			record.Type = "managed"
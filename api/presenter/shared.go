@@ -153,3 +153,10 @@ func formatTimestamp(t *time.Time) *string {
 	}
 	return tools.PtrTo(t.UTC().Format(time.RFC3339))
 }
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
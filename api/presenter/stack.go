@@ -13,13 +13,16 @@ const (
 )
 
 type StackResponse struct {
-	GUID        string     `json:"guid"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Metadata    Metadata   `json:"metadata"`
-	Links       StackLinks `json:"links"`
+	GUID             string     `json:"guid"`
+	CreatedAt        string     `json:"created_at"`
+	UpdatedAt        string     `json:"updated_at"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	BuildRootfsImage string     `json:"build_rootfs_image"`
+	RunRootfsImage   string     `json:"run_rootfs_image"`
+	Default          bool       `json:"default"`
+	Metadata         Metadata   `json:"metadata"`
+	Links            StackLinks `json:"links"`
 }
 
 type StackLinks struct {
@@ -28,10 +31,18 @@ type StackLinks struct {
 
 func ForStack(stackRecord repositories.StackRecord, baseURL url.URL, includes ...include.Resource) StackResponse {
 	return StackResponse{
-		GUID:      stackRecord.GUID,
-		CreatedAt: tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
-		UpdatedAt: tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
-		Name:      stackRecord.Name,
+		GUID:             stackRecord.GUID,
+		CreatedAt:        tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
+		UpdatedAt:        tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
+		Name:             stackRecord.Name,
+		Description:      stackRecord.Description,
+		BuildRootfsImage: stackRecord.BuildRootfsImage,
+		RunRootfsImage:   stackRecord.RunRootfsImage,
+		Default:          stackRecord.Default,
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(stackRecord.Labels),
+			Annotations: emptyMapIfNil(stackRecord.Annotations),
+		},
 		Links: StackLinks{
 			Self: Link{
 				HRef: buildURL(baseURL).appendPath(stacksBase, stackRecord.GUID).build(),
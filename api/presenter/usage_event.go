@@ -0,0 +1,155 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                    `json:"guid"`
+	CreatedAt             string                    `json:"created_at"`
+	UpdatedAt             string                    `json:"updated_at"`
+	State                 UsageEventChange[*string] `json:"state"`
+	App                   UsageEventResource        `json:"app"`
+	Process               UsageEventProcess         `json:"process"`
+	Space                 UsageEventResource        `json:"space"`
+	Organization          UsageEventOrganization    `json:"organization"`
+	Buildpack             UsageEventResource        `json:"buildpack"`
+	Task                  UsageEventResource        `json:"task"`
+	MemoryInMBPerInstance UsageEventChange[int64]   `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventChange[int32]   `json:"instance_count"`
+	Links                 UsageEventLinks           `json:"links"`
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                 `json:"guid"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
+	State           string                 `json:"state"`
+	Space           UsageEventResource     `json:"space"`
+	Organization    UsageEventOrganization `json:"organization"`
+	ServiceInstance UsageEventInstance     `json:"service_instance"`
+	ServicePlan     UsageEventResource     `json:"service_plan"`
+	ServiceOffering UsageEventResource     `json:"service_offering"`
+	ServiceBroker   UsageEventResource     `json:"service_broker"`
+	Links           UsageEventLinks        `json:"links"`
+}
+
+type UsageEventChange[T any] struct {
+	Current  T `json:"current"`
+	Previous T `json:"previous"`
+}
+
+type UsageEventResource struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+}
+
+type UsageEventProcess struct {
+	GUID *string `json:"guid"`
+	Type *string `json:"type"`
+}
+
+type UsageEventOrganization struct {
+	GUID *string `json:"guid"`
+}
+
+type UsageEventInstance struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+	Type *string `json:"type"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(record repositories.AppUsageEventRecord, baseURL url.URL, includes ...include.Resource) AppUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&record.CreatedAt))
+
+	return AppUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		State: UsageEventChange[*string]{
+			Current:  nilIfEmpty(record.State),
+			Previous: nilIfEmpty(record.PreviousState),
+		},
+		App: UsageEventResource{
+			GUID: nilIfEmpty(record.AppGUID),
+			Name: nilIfEmpty(record.AppName),
+		},
+		Process: UsageEventProcess{
+			GUID: nilIfEmpty(record.ProcessGUID),
+			Type: nilIfEmpty(record.ProcessType),
+		},
+		Space: UsageEventResource{
+			GUID: nilIfEmpty(record.SpaceGUID),
+			Name: nilIfEmpty(record.SpaceName),
+		},
+		Organization: UsageEventOrganization{
+			GUID: nilIfEmpty(record.OrgGUID),
+		},
+		MemoryInMBPerInstance: UsageEventChange[int64]{
+			Current:  record.MemoryInMBPerInstance,
+			Previous: record.PreviousMemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventChange[int32]{
+			Current:  record.InstanceCount,
+			Previous: record.PreviousInstanceCount,
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceUsageEvent(record repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...include.Resource) ServiceUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&record.CreatedAt))
+
+	return ServiceUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		State:     record.State,
+		Space: UsageEventResource{
+			GUID: nilIfEmpty(record.SpaceGUID),
+			Name: nilIfEmpty(record.SpaceName),
+		},
+		Organization: UsageEventOrganization{
+			GUID: nilIfEmpty(record.OrgGUID),
+		},
+		ServiceInstance: UsageEventInstance{
+			GUID: nilIfEmpty(record.ServiceInstanceGUID),
+			Name: nilIfEmpty(record.ServiceInstanceName),
+			Type: nilIfEmpty(record.ServiceInstanceType),
+		},
+		ServicePlan: UsageEventResource{
+			GUID: nilIfEmpty(record.ServicePlanGUID),
+			Name: nilIfEmpty(record.ServicePlanName),
+		},
+		ServiceOffering: UsageEventResource{
+			GUID: nilIfEmpty(record.ServiceOfferingGUID),
+			Name: nilIfEmpty(record.ServiceOfferingName),
+		},
+		ServiceBroker: UsageEventResource{
+			GUID: nilIfEmpty(record.ServiceBrokerGUID),
+			Name: nilIfEmpty(record.ServiceBrokerName),
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppUsageEvent", func() {
+		var record repositories.AppUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.AppUsageEventRecord{
+				GUID:                          "event-guid",
+				CreatedAt:                     time.UnixMilli(1000),
+				State:                         "STARTED",
+				PreviousState:                 "STOPPED",
+				AppGUID:                       "app-guid",
+				AppName:                       "my-app",
+				ProcessGUID:                   "process-guid",
+				ProcessType:                   "web",
+				SpaceGUID:                     "space-guid",
+				SpaceName:                     "my-space",
+				OrgGUID:                       "org-guid",
+				InstanceCount:                 3,
+				PreviousInstanceCount:         1,
+				MemoryInMBPerInstance:         512,
+				PreviousMemoryInMBPerInstance: 256,
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": {
+					"current": "STARTED",
+					"previous": "STOPPED"
+				},
+				"app": {
+					"guid": "app-guid",
+					"name": "my-app"
+				},
+				"process": {
+					"guid": "process-guid",
+					"type": "web"
+				},
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"buildpack": {
+					"guid": null,
+					"name": null
+				},
+				"task": {
+					"guid": null,
+					"name": null
+				},
+				"memory_in_mb_per_instance": {
+					"current": 512,
+					"previous": 256
+				},
+				"instance_count": {
+					"current": 3,
+					"previous": 1
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/app_usage_events/event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("there is no previous state", func() {
+			BeforeEach(func() {
+				record.PreviousState = ""
+			})
+
+			It("presents it as null", func() {
+				Expect(output).To(MatchJSONPath("$.state.previous", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForServiceUsageEvent", func() {
+		var record repositories.ServiceUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				CreatedAt:           time.UnixMilli(1000),
+				State:               "CREATED",
+				SpaceGUID:           "space-guid",
+				SpaceName:           "my-space",
+				OrgGUID:             "org-guid",
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "my-instance",
+				ServiceInstanceType: "managed_service_instance",
+				ServicePlanGUID:     "plan-guid",
+				ServicePlanName:     "my-plan",
+				ServiceOfferingGUID: "offering-guid",
+				ServiceOfferingName: "my-offering",
+				ServiceBrokerGUID:   "broker-guid",
+				ServiceBrokerName:   "my-broker",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": "CREATED",
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"service_instance": {
+					"guid": "instance-guid",
+					"name": "my-instance",
+					"type": "managed_service_instance"
+				},
+				"service_plan": {
+					"guid": "plan-guid",
+					"name": "my-plan"
+				},
+				"service_offering": {
+					"guid": "offering-guid",
+					"name": "my-offering"
+				},
+				"service_broker": {
+					"guid": "broker-guid",
+					"name": "my-broker"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_usage_events/event-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
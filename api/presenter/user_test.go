@@ -3,8 +3,11 @@ package presenter_test
 import (
 	"encoding/json"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -14,18 +17,24 @@ var _ = Describe("User", func() {
 	var (
 		baseURL *url.URL
 		output  []byte
-		name    string
+		record  repositories.UserRecord
 	)
 
 	BeforeEach(func() {
 		var err error
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
-		name = "bob"
+		record = repositories.UserRecord{
+			GUID:      "uaa:bob",
+			Username:  "bob",
+			Origin:    "uaa",
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForUser(name, *baseURL)
+		response := presenter.ForUser(record, *baseURL)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -33,8 +42,31 @@ var _ = Describe("User", func() {
 
 	It("produces expected user json", func() {
 		Expect(output).To(MatchJSON(`{
-			"guid": "bob",
-			"username": "bob"
+			"guid": "uaa:bob",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"username": "bob",
+			"presentation_name": "bob",
+			"origin": "uaa",
+			"metadata": {
+				"labels": {},
+				"annotations": {}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/users/uaa:bob"
+				}
+			}
 		}`))
 	})
+
+	When("the user has no origin", func() {
+		BeforeEach(func() {
+			record.Origin = ""
+		})
+
+		It("renders a null origin", func() {
+			Expect(string(output)).To(ContainSubstring(`"origin":null`))
+		})
+	})
 })
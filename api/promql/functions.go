@@ -0,0 +1,67 @@
+package promql
+
+import "math"
+
+func avgOverTime(points []Point) float64 {
+	return avg(values(points))
+}
+
+func minOverTime(points []Point) float64 {
+	return minimum(values(points))
+}
+
+func maxOverTime(points []Point) float64 {
+	return maximum(values(points))
+}
+
+func sumOverTime(points []Point) float64 {
+	return sum(values(points))
+}
+
+func countOverTime(points []Point) float64 {
+	return float64(len(points))
+}
+
+func lastOverTime(points []Point) float64 {
+	return points[len(points)-1].Value
+}
+
+func values(points []Point) []float64 {
+	result := make([]float64, 0, len(points))
+	for _, p := range points {
+		result = append(result, p.Value)
+	}
+	return result
+}
+
+func sum(values []float64) float64 {
+	var result float64
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+func avg(values []float64) float64 {
+	return sum(values) / float64(len(values))
+}
+
+func minimum(values []float64) float64 {
+	result := math.Inf(1)
+	for _, v := range values {
+		result = math.Min(result, v)
+	}
+	return result
+}
+
+func maximum(values []float64) float64 {
+	result := math.Inf(-1)
+	for _, v := range values {
+		result = math.Max(result, v)
+	}
+	return result
+}
+
+func count(values []float64) float64 {
+	return float64(len(values))
+}
@@ -0,0 +1,460 @@
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expr is a parsed query. Only the subset of PromQL used by the CF CLI and
+// app autoscalers against log-cache is supported: vector selectors, the
+// *_over_time range functions and the sum, avg, min, max and count
+// aggregations with optional by/without grouping.
+type Expr interface {
+	isExpr()
+}
+
+type MatchType string
+
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
+)
+
+type LabelMatcher struct {
+	Name  string
+	Type  MatchType
+	Value string
+
+	re *regexp.Regexp
+}
+
+func (m LabelMatcher) matches(value string) bool {
+	switch m.Type {
+	case MatchEqual:
+		return value == m.Value
+	case MatchNotEqual:
+		return value != m.Value
+	case MatchRegexp:
+		return m.re.MatchString(value)
+	case MatchNotRegexp:
+		return !m.re.MatchString(value)
+	}
+
+	return false
+}
+
+type VectorSelector struct {
+	Metric   string
+	Matchers []LabelMatcher
+}
+
+func (*VectorSelector) isExpr() {}
+
+type RangeFunction struct {
+	Name     string
+	Selector *VectorSelector
+	Range    time.Duration
+}
+
+func (*RangeFunction) isExpr() {}
+
+type Aggregation struct {
+	Op       string
+	Grouping []string
+	Without  bool
+	Expr     Expr
+}
+
+func (*Aggregation) isExpr() {}
+
+var (
+	rangeFunctions = map[string]func([]Point) float64{
+		"avg_over_time":   avgOverTime,
+		"min_over_time":   minOverTime,
+		"max_over_time":   maxOverTime,
+		"sum_over_time":   sumOverTime,
+		"count_over_time": countOverTime,
+		"last_over_time":  lastOverTime,
+	}
+
+	aggregations = map[string]func([]float64) float64{
+		"sum":   sum,
+		"avg":   avg,
+		"min":   minimum,
+		"max":   maximum,
+		"count": count,
+	}
+)
+
+func Parse(query string) (Expr, error) {
+	p := &parser{tokens: tokenize(query)}
+
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+
+	return expr, nil
+}
+
+// SourceIDs returns the source ids the query selects. Every selector in the
+// query has to match on a single source_id so that access to the underlying
+// app can be checked.
+func SourceIDs(expr Expr) ([]string, error) {
+	var sourceIDs []string
+	for _, selector := range selectors(expr) {
+		sourceID, ok := selector.sourceID()
+		if !ok {
+			return nil, fmt.Errorf("selector %q must specify a source_id", selector.Metric)
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+
+	return sourceIDs, nil
+}
+
+func (s *VectorSelector) sourceID() (string, bool) {
+	for _, m := range s.Matchers {
+		if m.Name == "source_id" && m.Type == MatchEqual && m.Value != "" {
+			return m.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func selectors(expr Expr) []*VectorSelector {
+	switch e := expr.(type) {
+	case *VectorSelector:
+		return []*VectorSelector{e}
+	case *RangeFunction:
+		return []*VectorSelector{e.Selector}
+	case *Aggregation:
+		return selectors(e.Expr)
+	}
+
+	return nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, value string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind || (value != "" && tok.value != value) {
+		if tok.kind == tokenEOF {
+			return tok, fmt.Errorf("unexpected end of query, expected %q", value)
+		}
+		return tok, fmt.Errorf("unexpected %q at position %d", tok.value, tok.pos)
+	}
+
+	return tok, nil
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	tok, err := p.expect(tokenIdentifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := aggregations[tok.value]; ok {
+		return p.parseAggregation(tok.value)
+	}
+
+	if _, ok := rangeFunctions[tok.value]; ok {
+		return p.parseRangeFunction(tok.value)
+	}
+
+	return p.parseVectorSelector(tok.value)
+}
+
+func (p *parser) parseAggregation(op string) (Expr, error) {
+	agg := &Aggregation{Op: op}
+
+	var err error
+	if agg.Grouping, agg.Without, err = p.parseGrouping(); err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(tokenPunctuation, "("); err != nil {
+		return nil, err
+	}
+
+	if agg.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(tokenPunctuation, ")"); err != nil {
+		return nil, err
+	}
+
+	if agg.Grouping == nil {
+		if agg.Grouping, agg.Without, err = p.parseGrouping(); err != nil {
+			return nil, err
+		}
+	}
+
+	return agg, nil
+}
+
+func (p *parser) parseGrouping() ([]string, bool, error) {
+	tok := p.peek()
+	if tok.kind != tokenIdentifier || (tok.value != "by" && tok.value != "without") {
+		return nil, false, nil
+	}
+	p.next()
+
+	if _, err := p.expect(tokenPunctuation, "("); err != nil {
+		return nil, false, err
+	}
+
+	labels := []string{}
+	for p.peek().value != ")" {
+		label, err := p.expect(tokenIdentifier, "")
+		if err != nil {
+			return nil, false, err
+		}
+		labels = append(labels, label.value)
+
+		if p.peek().value == "," {
+			p.next()
+		}
+	}
+	p.next()
+
+	return labels, tok.value == "without", nil
+}
+
+func (p *parser) parseRangeFunction(name string) (Expr, error) {
+	if _, err := p.expect(tokenPunctuation, "("); err != nil {
+		return nil, err
+	}
+
+	metric, err := p.expect(tokenIdentifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := p.parseVectorSelector(metric.value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(tokenPunctuation, "["); err != nil {
+		return nil, err
+	}
+
+	durationToken, err := p.expect(tokenIdentifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := ParseDuration(durationToken.value)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(tokenPunctuation, "]"); err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(tokenPunctuation, ")"); err != nil {
+		return nil, err
+	}
+
+	return &RangeFunction{
+		Name:     name,
+		Selector: selector.(*VectorSelector),
+		Range:    duration,
+	}, nil
+}
+
+func (p *parser) parseVectorSelector(metric string) (Expr, error) {
+	selector := &VectorSelector{Metric: metric}
+
+	if p.peek().value != "{" {
+		return selector, nil
+	}
+	p.next()
+
+	for p.peek().value != "}" {
+		name, err := p.expect(tokenIdentifier, "")
+		if err != nil {
+			return nil, err
+		}
+
+		op, err := p.expect(tokenOperator, "")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := p.expect(tokenString, "")
+		if err != nil {
+			return nil, err
+		}
+
+		matcher := LabelMatcher{Name: name.value, Type: MatchType(op.value), Value: value.value}
+		if matcher.Type == MatchRegexp || matcher.Type == MatchNotRegexp {
+			if matcher.re, err = regexp.Compile("^(?:" + value.value + ")$"); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", value.value, err)
+			}
+		}
+		selector.Matchers = append(selector.Matchers, matcher)
+
+		if p.peek().value == "," {
+			p.next()
+		}
+	}
+	p.next()
+
+	return selector, nil
+}
+
+// ParseDuration parses PromQL durations such as 30s, 5m or 1h30m
+func ParseDuration(s string) (time.Duration, error) {
+	if !durationRegexp.MatchString(s) {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total time.Duration
+	for _, part := range durationPartRegexp.FindAllStringSubmatch(s, -1) {
+		n, err := strconv.ParseInt(part[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		total += time.Duration(n) * durationUnits[part[2]]
+	}
+
+	if total <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+
+	return total, nil
+}
+
+var (
+	durationRegexp     = regexp.MustCompile(`^(\d+(ms|s|m|h|d|w|y))+$`)
+	durationPartRegexp = regexp.MustCompile(`(\d+)(ms|s|m|h|d|w|y)`)
+	durationUnits      = map[string]time.Duration{
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+	}
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenOperator
+	tokenPunctuation
+	tokenInvalid
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(query string) []token {
+	var tokens []token
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isIdentifierRune(r):
+			start := i
+			for i < len(runes) && isIdentifierRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			value, end, ok := readString(runes, i)
+			if !ok {
+				return append(tokens, token{kind: tokenInvalid, value: string(runes[start:]), pos: start})
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: start})
+			i = end
+		case r == '=' || r == '!':
+			start := i
+			i++
+			if i < len(runes) && (runes[i] == '=' || runes[i] == '~') {
+				i++
+			}
+			op := string(runes[start:i])
+			kind := tokenOperator
+			if op == "!" || op == "==" {
+				kind = tokenInvalid
+			}
+			tokens = append(tokens, token{kind: kind, value: op, pos: start})
+		case strings.ContainsRune("(){}[],", r):
+			tokens = append(tokens, token{kind: tokenPunctuation, value: string(r), pos: i})
+			i++
+		default:
+			tokens = append(tokens, token{kind: tokenInvalid, value: string(r), pos: i})
+			i++
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)})
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == ':' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func readString(runes []rune, start int) (string, int, bool) {
+	quote := runes[start]
+	var sb strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				sb.WriteRune(runes[i])
+			}
+		case quote:
+			return sb.String(), i + 1, true
+		default:
+			sb.WriteRune(runes[i])
+		}
+	}
+
+	return "", len(runes), false
+}
@@ -0,0 +1,81 @@
+package promql_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/promql"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Parse", func() {
+	It("parses vector selectors", func() {
+		expr, err := promql.Parse(`memory{source_id="app-guid", instance_id!='1'}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expr).To(PointTo(MatchAllFields(Fields{
+			"Metric": Equal("memory"),
+			"Matchers": ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("source_id"), "Type": Equal(promql.MatchEqual), "Value": Equal("app-guid")}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("instance_id"), "Type": Equal(promql.MatchNotEqual), "Value": Equal("1")}),
+			),
+		})))
+	})
+
+	It("parses range functions", func() {
+		expr, err := promql.Parse(`max_over_time(cpu{source_id="app-guid"}[1m30s])`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expr).To(PointTo(MatchAllFields(Fields{
+			"Name":     Equal("max_over_time"),
+			"Selector": PointTo(MatchFields(IgnoreExtras, Fields{"Metric": Equal("cpu")})),
+			"Range":    Equal(90 * time.Second),
+		})))
+	})
+
+	DescribeTable("aggregations",
+		func(query string, grouping []string, without bool) {
+			expr, err := promql.Parse(query)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expr).To(PointTo(MatchAllFields(Fields{
+				"Op":       Equal("avg"),
+				"Grouping": Equal(grouping),
+				"Without":  Equal(without),
+				"Expr":     BeAssignableToTypeOf(&promql.RangeFunction{}),
+			})))
+		},
+		Entry("no grouping", `avg(avg_over_time(cpu{source_id="a"}[1m]))`, nil, false),
+		Entry("trailing by", `avg(avg_over_time(cpu{source_id="a"}[1m])) by (instance_id)`, []string{"instance_id"}, false),
+		Entry("leading by", `avg by (instance_id, process_type) (avg_over_time(cpu{source_id="a"}[1m]))`, []string{"instance_id", "process_type"}, false),
+		Entry("without", `avg without (instance_id) (avg_over_time(cpu{source_id="a"}[1m]))`, []string{"instance_id"}, true),
+	)
+
+	DescribeTable("invalid queries",
+		func(query string, expectedErr string) {
+			_, err := promql.Parse(query)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("empty", ``, "unexpected end of query"),
+		Entry("missing matcher value", `cpu{source_id=}`, "unexpected"),
+		Entry("unterminated string", `cpu{source_id="a}`, "unexpected"),
+		Entry("invalid regexp", `cpu{source_id=~"("}`, "invalid regular expression"),
+		Entry("invalid duration", `avg_over_time(cpu{source_id="a"}[1x])`, "invalid duration"),
+		Entry("binary operators", `cpu{source_id="a"} / 2`, "unexpected"),
+		Entry("unbalanced parens", `avg(cpu{source_id="a"}`, "unexpected end of query"),
+	)
+})
+
+var _ = Describe("SourceIDs", func() {
+	It("returns the source ids of all selectors", func() {
+		expr, err := promql.Parse(`sum(max_over_time(cpu{source_id="app-guid"}[1m]))`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(promql.SourceIDs(expr)).To(ConsistOf("app-guid"))
+	})
+
+	It("requires every selector to match a single source id", func() {
+		expr, err := promql.Parse(`cpu{source_id=~"app-.*"}`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = promql.SourceIDs(expr)
+		Expect(err).To(MatchError(ContainSubstring("must specify a source_id")))
+	})
+})
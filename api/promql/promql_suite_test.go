@@ -0,0 +1,13 @@
+package promql_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPromQL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PromQL Suite")
+}
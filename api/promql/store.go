@@ -0,0 +1,286 @@
+package promql
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MetricNameLabel = "__name__"
+
+	// instant selectors consider the latest sample that is not older than
+	// the lookback delta, same as prometheus does
+	lookbackDelta = 5 * time.Minute
+
+	defaultRetention   = time.Hour
+	maxPointsPerSeries = 1024
+)
+
+type Labels map[string]string
+
+func (l Labels) key() string {
+	names := slices.Sorted(maps.Keys(l))
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteString("\xff")
+		sb.WriteString(l[name])
+		sb.WriteString("\xff")
+	}
+
+	return sb.String()
+}
+
+func (l Labels) without(names ...string) Labels {
+	result := maps.Clone(l)
+	for _, name := range names {
+		delete(result, name)
+	}
+	return result
+}
+
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+type Sample struct {
+	Labels Labels
+	Point
+}
+
+type Series struct {
+	Labels Labels
+	Points []Point
+}
+
+// Store is an in-memory buffer of metric samples. Samples older than the
+// retention are dropped whenever new samples are recorded.
+type Store struct {
+	retention time.Duration
+
+	mutex  sync.RWMutex
+	series map[string]*Series
+}
+
+func NewStore() *Store {
+	return &Store{
+		retention: defaultRetention,
+		series:    map[string]*Series{},
+	}
+}
+
+func (s *Store) WithRetention(retention time.Duration) *Store {
+	s.retention = retention
+	return s
+}
+
+func (s *Store) Record(now time.Time, samples ...Sample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, sample := range samples {
+		key := sample.Labels.key()
+		series, ok := s.series[key]
+		if !ok {
+			series = &Series{Labels: maps.Clone(sample.Labels)}
+			s.series[key] = series
+		}
+
+		series.Points = insertPoint(series.Points, sample.Point)
+		if len(series.Points) > maxPointsPerSeries {
+			series.Points = series.Points[len(series.Points)-maxPointsPerSeries:]
+		}
+	}
+
+	cutoff := now.Add(-s.retention)
+	for key, series := range s.series {
+		series.Points = slices.DeleteFunc(series.Points, func(p Point) bool {
+			return p.Timestamp.Before(cutoff)
+		})
+		if len(series.Points) == 0 {
+			delete(s.series, key)
+		}
+	}
+}
+
+// insertPoint keeps points ordered by timestamp and replaces points with the
+// same timestamp, as the same metrics snapshot may be recorded multiple times
+func insertPoint(points []Point, point Point) []Point {
+	i := sort.Search(len(points), func(i int) bool {
+		return !points[i].Timestamp.Before(point.Timestamp)
+	})
+
+	if i < len(points) && points[i].Timestamp.Equal(point.Timestamp) {
+		points[i] = point
+		return points
+	}
+
+	return slices.Insert(points, i, point)
+}
+
+func (s *Store) Eval(expr Expr, at time.Time) []Sample {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.eval(expr, at)
+}
+
+func (s *Store) EvalRange(expr Expr, start, end time.Time, step time.Duration) []Series {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := map[string]*Series{}
+	for t := start; !t.After(end); t = t.Add(step) {
+		for _, sample := range s.eval(expr, t) {
+			key := sample.Labels.key()
+			series, ok := result[key]
+			if !ok {
+				series = &Series{Labels: sample.Labels}
+				result[key] = series
+			}
+			series.Points = append(series.Points, Point{Timestamp: t, Value: sample.Value})
+		}
+	}
+
+	series := []Series{}
+	for _, r := range result {
+		series = append(series, *r)
+	}
+
+	return sortSeries(series)
+}
+
+func (s *Store) eval(expr Expr, at time.Time) []Sample {
+	switch e := expr.(type) {
+	case *VectorSelector:
+		return s.evalSelector(e, at)
+	case *RangeFunction:
+		return s.evalRangeFunction(e, at)
+	case *Aggregation:
+		return evalAggregation(e, s.eval(e.Expr, at))
+	}
+
+	return nil
+}
+
+func (s *Store) evalSelector(selector *VectorSelector, at time.Time) []Sample {
+	var result []Sample
+	for _, series := range s.selectSeries(selector) {
+		points := pointsInRange(series.Points, at.Add(-lookbackDelta), at)
+		if len(points) == 0 {
+			continue
+		}
+
+		result = append(result, Sample{
+			Labels: series.Labels,
+			Point:  Point{Timestamp: at, Value: points[len(points)-1].Value},
+		})
+	}
+
+	return sortSamples(result)
+}
+
+func (s *Store) evalRangeFunction(fn *RangeFunction, at time.Time) []Sample {
+	var result []Sample
+	for _, series := range s.selectSeries(fn.Selector) {
+		points := pointsInRange(series.Points, at.Add(-fn.Range), at)
+		if len(points) == 0 {
+			continue
+		}
+
+		result = append(result, Sample{
+			Labels: series.Labels.without(MetricNameLabel),
+			Point:  Point{Timestamp: at, Value: rangeFunctions[fn.Name](points)},
+		})
+	}
+
+	return sortSamples(result)
+}
+
+func (s *Store) selectSeries(selector *VectorSelector) []*Series {
+	var result []*Series
+	for _, series := range s.series {
+		if series.Labels[MetricNameLabel] != selector.Metric {
+			continue
+		}
+
+		if !slices.ContainsFunc(selector.Matchers, func(m LabelMatcher) bool {
+			return !m.matches(series.Labels[m.Name])
+		}) {
+			result = append(result, series)
+		}
+	}
+
+	return result
+}
+
+// pointsInRange returns the points in the left-open interval (from, to]
+func pointsInRange(points []Point, from, to time.Time) []Point {
+	var result []Point
+	for _, p := range points {
+		if p.Timestamp.After(from) && !p.Timestamp.After(to) {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func evalAggregation(agg *Aggregation, samples []Sample) []Sample {
+	groups := map[string]Labels{}
+	values := map[string][]float64{}
+	var timestamp time.Time
+
+	for _, sample := range samples {
+		groupLabels := groupingLabels(sample.Labels, agg.Grouping, agg.Without)
+		key := groupLabels.key()
+		groups[key] = groupLabels
+		values[key] = append(values[key], sample.Value)
+		timestamp = sample.Timestamp
+	}
+
+	var result []Sample
+	for key, groupLabels := range groups {
+		result = append(result, Sample{
+			Labels: groupLabels,
+			Point:  Point{Timestamp: timestamp, Value: aggregations[agg.Op](values[key])},
+		})
+	}
+
+	return sortSamples(result)
+}
+
+func groupingLabels(labels Labels, grouping []string, without bool) Labels {
+	if without {
+		return labels.without(slices.Concat(grouping, []string{MetricNameLabel})...)
+	}
+
+	result := Labels{}
+	for _, name := range grouping {
+		if value, ok := labels[name]; ok {
+			result[name] = value
+		}
+	}
+
+	return result
+}
+
+func sortSamples(samples []Sample) []Sample {
+	slices.SortFunc(samples, func(a, b Sample) int {
+		return strings.Compare(a.Labels.key(), b.Labels.key())
+	})
+	return samples
+}
+
+func sortSeries(series []Series) []Series {
+	slices.SortFunc(series, func(a, b Series) int {
+		return strings.Compare(a.Labels.key(), b.Labels.key())
+	})
+	return series
+}
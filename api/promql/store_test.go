@@ -0,0 +1,158 @@
+package promql_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/promql"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		store *promql.Store
+		now   time.Time
+	)
+
+	sample := func(metric, instance string, offset time.Duration, value float64) promql.Sample {
+		return promql.Sample{
+			Labels: promql.Labels{
+				promql.MetricNameLabel: metric,
+				"source_id":            "app-guid",
+				"instance_id":          instance,
+			},
+			Point: promql.Point{Timestamp: now.Add(offset), Value: value},
+		}
+	}
+
+	eval := func(query string, at time.Time) []promql.Sample {
+		GinkgoHelper()
+
+		expr, err := promql.Parse(query)
+		Expect(err).NotTo(HaveOccurred())
+		return store.Eval(expr, at)
+	}
+
+	BeforeEach(func() {
+		now = time.Unix(1700000000, 0)
+		store = promql.NewStore()
+		store.Record(now,
+			sample("cpu", "0", -90*time.Second, 1),
+			sample("cpu", "0", -30*time.Second, 3),
+			sample("cpu", "1", -30*time.Second, 5),
+			sample("memory", "0", -30*time.Second, 100),
+		)
+	})
+
+	Describe("instant selectors", func() {
+		It("returns the latest value of each matching series", func() {
+			Expect(eval(`cpu{source_id="app-guid"}`, now)).To(Equal([]promql.Sample{
+				{
+					Labels: promql.Labels{promql.MetricNameLabel: "cpu", "source_id": "app-guid", "instance_id": "0"},
+					Point:  promql.Point{Timestamp: now, Value: 3},
+				},
+				{
+					Labels: promql.Labels{promql.MetricNameLabel: "cpu", "source_id": "app-guid", "instance_id": "1"},
+					Point:  promql.Point{Timestamp: now, Value: 5},
+				},
+			}))
+		})
+
+		It("applies label matchers", func() {
+			Expect(eval(`cpu{source_id="app-guid", instance_id=~"1|2"}`, now)).To(HaveLen(1))
+			Expect(eval(`cpu{source_id="app-guid", instance_id!="1"}`, now)).To(HaveLen(1))
+			Expect(eval(`cpu{source_id="other-app"}`, now)).To(BeEmpty())
+		})
+
+		It("does not return samples from the future", func() {
+			Expect(eval(`cpu{source_id="app-guid", instance_id="0"}`, now.Add(-time.Minute))).To(ConsistOf(
+				HaveField("Point.Value", 1.0),
+			))
+		})
+
+		It("does not return stale samples", func() {
+			Expect(eval(`cpu{source_id="app-guid"}`, now.Add(10*time.Minute))).To(BeEmpty())
+		})
+	})
+
+	Describe("range functions", func() {
+		DescribeTable("evaluates the function over the range",
+			func(function string, expected float64) {
+				Expect(eval(function+`(cpu{source_id="app-guid", instance_id="0"}[2m])`, now)).To(Equal([]promql.Sample{{
+					Labels: promql.Labels{"source_id": "app-guid", "instance_id": "0"},
+					Point:  promql.Point{Timestamp: now, Value: expected},
+				}}))
+			},
+			Entry("avg", "avg_over_time", 2.0),
+			Entry("min", "min_over_time", 1.0),
+			Entry("max", "max_over_time", 3.0),
+			Entry("sum", "sum_over_time", 4.0),
+			Entry("count", "count_over_time", 2.0),
+			Entry("last", "last_over_time", 3.0),
+		)
+
+		It("only considers samples in the range", func() {
+			Expect(eval(`max_over_time(cpu{source_id="app-guid", instance_id="0"}[1m])`, now)).To(ConsistOf(
+				HaveField("Point.Value", 3.0),
+			))
+		})
+	})
+
+	Describe("aggregations", func() {
+		It("aggregates all series", func() {
+			Expect(eval(`sum(cpu{source_id="app-guid"})`, now)).To(Equal([]promql.Sample{{
+				Labels: promql.Labels{},
+				Point:  promql.Point{Timestamp: now, Value: 8},
+			}}))
+		})
+
+		It("aggregates by labels", func() {
+			Expect(eval(`max(max_over_time(cpu{source_id="app-guid"}[5m])) by (instance_id)`, now)).To(Equal([]promql.Sample{
+				{Labels: promql.Labels{"instance_id": "0"}, Point: promql.Point{Timestamp: now, Value: 3}},
+				{Labels: promql.Labels{"instance_id": "1"}, Point: promql.Point{Timestamp: now, Value: 5}},
+			}))
+		})
+
+		It("aggregates without labels", func() {
+			Expect(eval(`avg without (instance_id) (cpu{source_id="app-guid"})`, now)).To(Equal([]promql.Sample{{
+				Labels: promql.Labels{"source_id": "app-guid"},
+				Point:  promql.Point{Timestamp: now, Value: 4},
+			}}))
+		})
+	})
+
+	Describe("EvalRange", func() {
+		It("evaluates the query at every step", func() {
+			expr, err := promql.Parse(`cpu{source_id="app-guid", instance_id="0"}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(store.EvalRange(expr, now.Add(-2*time.Minute), now, time.Minute)).To(Equal([]promql.Series{{
+				Labels: promql.Labels{promql.MetricNameLabel: "cpu", "source_id": "app-guid", "instance_id": "0"},
+				Points: []promql.Point{
+					{Timestamp: now.Add(-time.Minute), Value: 1},
+					{Timestamp: now, Value: 3},
+				},
+			}}))
+		})
+	})
+
+	Describe("Record", func() {
+		It("replaces samples with the same timestamp", func() {
+			store.Record(now, sample("cpu", "0", -30*time.Second, 7))
+			Expect(eval(`count_over_time(cpu{source_id="app-guid", instance_id="0"}[5m])`, now)).To(ConsistOf(
+				HaveField("Point.Value", 2.0),
+			))
+			Expect(eval(`cpu{source_id="app-guid", instance_id="0"}`, now)).To(ConsistOf(
+				HaveField("Point.Value", 7.0),
+			))
+		})
+
+		It("drops samples older than the retention", func() {
+			store.WithRetention(time.Minute).Record(now)
+			Expect(eval(`count_over_time(cpu{source_id="app-guid", instance_id="0"}[5m])`, now)).To(ConsistOf(
+				HaveField("Point.Value", 1.0),
+			))
+		})
+	})
+})
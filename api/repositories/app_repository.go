@@ -36,17 +36,20 @@ const (
 )
 
 type AppRepo struct {
-	klient     Klient
-	appAwaiter Awaiter[*korifiv1alpha1.CFApp]
+	klient          Klient
+	appAwaiter      Awaiter[*korifiv1alpha1.CFApp]
+	envVarGroupRepo *EnvVarGroupRepo
 }
 
 func NewAppRepo(
 	klient Klient,
 	appAwaiter Awaiter[*korifiv1alpha1.CFApp],
+	envVarGroupRepo *EnvVarGroupRepo,
 ) *AppRepo {
 	return &AppRepo{
-		klient:     klient,
-		appAwaiter: appAwaiter,
+		klient:          klient,
+		appAwaiter:      appAwaiter,
+		envVarGroupRepo: envVarGroupRepo,
 	}
 }
 
@@ -65,6 +68,7 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	SSHEnabled            bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -113,6 +117,8 @@ type AppEnvRecord struct {
 	AppGUID              string
 	SpaceGUID            string
 	EnvironmentVariables map[string]string
+	StagingEnv           map[string]string
+	RunningEnv           map[string]string
 	SystemEnv            map[string]interface{}
 	AppEnv               map[string]interface{}
 }
@@ -137,6 +143,7 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	SSHEnabled           *bool
 	MetadataPatch
 }
 
@@ -176,6 +183,7 @@ type ListAppsMessage struct {
 	SpaceGUIDs    []string
 	LabelSelector string
 	OrderBy       string
+	Timestamps    TimestampFilters
 }
 
 func (m *ListAppsMessage) toListOptions() []ListOption {
@@ -184,6 +192,7 @@ func (m *ListAppsMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.Guids),
 		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, m.SpaceGUIDs),
 		WithLabelIn(korifiv1alpha1.CFAppDisplayNameKey, tools.EncodeValuesToSha224(m.Names...)),
+		WithTimestamps(m.Timestamps),
 		m.toSortOption(),
 	}
 }
@@ -456,10 +465,22 @@ func (f *AppRepo) GetAppEnv(ctx context.Context, authInfo authorization.Info, ap
 		return AppEnvRecord{}, err
 	}
 
+	stagingEnv, err := f.envVarGroupRepo.getEnvironmentVariables(ctx, StagingEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
+	runningEnv, err := f.envVarGroupRepo.getEnvironmentVariables(ctx, RunningEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
 	appEnvRecord := AppEnvRecord{
 		AppGUID:              appGUID,
 		SpaceGUID:            app.SpaceGUID,
 		EnvironmentVariables: appEnvVarMap,
+		StagingEnv:           stagingEnv,
+		RunningEnv:           runningEnv,
 		SystemEnv:            systemEnvMap,
 		AppEnv:               appEnvMap,
 	}
@@ -584,6 +605,10 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.SSHEnabled != nil {
+		app.Spec.DisableSSH = !*m.SSHEnabled
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -614,6 +639,7 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) (AppRecord, error) {
 		UpdatedAt:             updatedAt,
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              cfApp.Spec.CurrentDropletRef.Name != "",
+		SSHEnabled:            !cfApp.Spec.DisableSSH,
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -52,7 +52,7 @@ var _ = Describe("AppRepository", func() {
 			korifiv1alpha1.CFAppList,
 			*korifiv1alpha1.CFAppList,
 		]{}
-		appRepo = repositories.NewAppRepo(klient, appAwaiter)
+		appRepo = repositories.NewAppRepo(klient, appAwaiter, repositories.NewEnvVarGroupRepo(klient, k8sClient, rootNamespace))
 
 		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
@@ -199,7 +199,7 @@ var _ = Describe("AppRepository", func() {
 
 				BeforeEach(func() {
 					fakeKlient = new(fake.Klient)
-					appRepo = repositories.NewAppRepo(fakeKlient, appAwaiter)
+					appRepo = repositories.NewAppRepo(fakeKlient, appAwaiter, repositories.NewEnvVarGroupRepo(fakeKlient, k8sClient, rootNamespace))
 				})
 
 				Describe("filter parameters to list options", func() {
@@ -231,7 +231,7 @@ var _ = Describe("AppRepository", func() {
 			DescribeTable("ordering",
 				func(msg repositories.ListAppsMessage, match types.GomegaMatcher) {
 					fakeKlient := new(fake.Klient)
-					appRepo = repositories.NewAppRepo(fakeKlient, appAwaiter)
+					appRepo = repositories.NewAppRepo(fakeKlient, appAwaiter, repositories.NewEnvVarGroupRepo(fakeKlient, k8sClient, rootNamespace))
 
 					_, err := appRepo.ListApps(ctx, authInfo, msg)
 					Expect(err).NotTo(HaveOccurred())
@@ -977,6 +977,35 @@ var _ = Describe("AppRepository", func() {
 				Expect(appEnvRecord.EnvironmentVariables).To(Equal(envVars))
 				Expect(appEnvRecord.SystemEnv).To(BeEmpty())
 				Expect(appEnvRecord.AppEnv).To(BeEmpty())
+				Expect(appEnvRecord.RunningEnv).To(BeEmpty())
+				Expect(appEnvRecord.StagingEnv).To(BeEmpty())
+			})
+
+			When("the environment variable groups are set", func() {
+				BeforeEach(func() {
+					for secretName, value := range map[string]string{
+						korifiv1alpha1.RunningEnvVarGroupSecretName: "running-proxy",
+						korifiv1alpha1.StagingEnvVarGroupSecretName: "staging-proxy",
+					} {
+						secret := &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: rootNamespace,
+								Name:      secretName,
+							},
+							StringData: map[string]string{"HTTP_PROXY": value},
+						}
+						Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+						DeferCleanup(func() {
+							Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+						})
+					}
+				})
+
+				It("returns the group env vars even though the user cannot read them directly", func() {
+					Expect(getAppEnvErr).NotTo(HaveOccurred())
+					Expect(appEnvRecord.RunningEnv).To(Equal(map[string]string{"HTTP_PROXY": "running-proxy"}))
+					Expect(appEnvRecord.StagingEnv).To(Equal(map[string]string{"HTTP_PROXY": "staging-proxy"}))
+				})
 			})
 
 			When("the app has a service-binding secret", func() {
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+const AuditEventResourceType = "Audit Event"
+
+type AuditEventRepository struct {
+	klient Klient
+}
+
+type AuditEventRecord struct {
+	GUID         string
+	CreatedAt    time.Time
+	Type         string
+	ActorGUID    string
+	ActorType    string
+	ActorName    string
+	TargetGUID   string
+	TargetType   string
+	TargetName   string
+	SpaceGUID    string
+	OrgGUID      string
+	ProcessCrash *korifiv1alpha1.ProcessCrashData
+}
+
+type ListAuditEventsMessage struct {
+	GUIDs       []string
+	Types       []string
+	TargetGUIDs []string
+	SpaceGUIDs  []string
+	Timestamps  TimestampFilters
+}
+
+func NewAuditEventRepository(klient Klient) *AuditEventRepository {
+	return &AuditEventRepository{
+		klient: klient,
+	}
+}
+
+func (r *AuditEventRepository) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	auditEventList := &korifiv1alpha1.CFAuditEventList{}
+	if err := r.klient.List(ctx, auditEventList,
+		WithLabelIn(korifiv1alpha1.GUIDLabelKey, message.GUIDs),
+		WithLabelIn(korifiv1alpha1.CFAuditEventTypeLabelKey, message.Types),
+		WithLabelIn(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, message.TargetGUIDs),
+		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, message.SpaceGUIDs),
+		WithTimestamps(message.Timestamps),
+	); err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	auditEvents := auditEventList.Items
+	slices.SortFunc(auditEvents, func(a, b korifiv1alpha1.CFAuditEvent) int {
+		return cmp.Or(
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return slices.Collect(it.Map(slices.Values(auditEvents), toAuditEventRecord)), nil
+}
+
+func (r *AuditEventRepository) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	auditEvents, err := r.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return AuditEventRecord{}, err
+	}
+
+	if len(auditEvents) == 0 {
+		return AuditEventRecord{}, apierrors.NewNotFoundError(nil, AuditEventResourceType)
+	}
+
+	return auditEvents[0], nil
+}
+
+func toAuditEventRecord(auditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	return AuditEventRecord{
+		GUID:         auditEvent.Name,
+		CreatedAt:    auditEvent.CreationTimestamp.Time,
+		Type:         auditEvent.Spec.Type,
+		ActorGUID:    auditEvent.Spec.Actor.GUID,
+		ActorType:    auditEvent.Spec.Actor.Type,
+		ActorName:    auditEvent.Spec.Actor.Name,
+		TargetGUID:   auditEvent.Spec.Target.GUID,
+		TargetType:   auditEvent.Spec.Target.Type,
+		TargetName:   auditEvent.Spec.Target.Name,
+		SpaceGUID:    auditEvent.Namespace,
+		OrgGUID:      auditEvent.Spec.OrgGUID,
+		ProcessCrash: auditEvent.Spec.ProcessCrash,
+	}
+}
@@ -0,0 +1,202 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *AuditEventRepository
+		org            *korifiv1alpha1.CFOrg
+		space          *korifiv1alpha1.CFSpace
+		otherSpace     *korifiv1alpha1.CFSpace
+		crash          *korifiv1alpha1.CFAuditEvent
+		otherCrash     *korifiv1alpha1.CFAuditEvent
+	)
+
+	BeforeEach(func() {
+		auditEventRepo = NewAuditEventRepository(klient)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, "my-space")
+		otherSpace = createSpaceWithCleanup(ctx, org.Name, "other-space")
+
+		crash = createAuditEvent(space.Name, org.Name, "app-guid")
+		otherCrash = createAuditEvent(otherSpace.Name, org.Name, "other-app-guid")
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			message     ListAuditEventsMessage
+			auditEvents []AuditEventRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			message = ListAuditEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			auditEvents, listErr = auditEventRepo.ListAuditEvents(ctx, authInfo, message)
+		})
+
+		It("returns an empty list to users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(auditEvents).To(BeEmpty())
+		})
+
+		When("the user is a space auditor in one of the spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("returns the audit events of that space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(auditEvents).To(ConsistOf(MatchAllFields(Fields{
+					"GUID":       Equal(crash.Name),
+					"CreatedAt":  Not(BeZero()),
+					"Type":       Equal(korifiv1alpha1.AppProcessCrashAuditEventType),
+					"ActorGUID":  Equal("process-guid"),
+					"ActorType":  Equal("process"),
+					"ActorName":  Equal("web"),
+					"TargetGUID": Equal("app-guid"),
+					"TargetType": Equal("app"),
+					"TargetName": Equal("my-app"),
+					"SpaceGUID":  Equal(space.Name),
+					"OrgGUID":    Equal(org.Name),
+					"ProcessCrash": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Index":      BeEquivalentTo(1),
+						"ExitStatus": BeEquivalentTo(137),
+					})),
+				})))
+			})
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+			})
+
+			It("returns the audit events of both spaces", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(auditEvents).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(crash.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherCrash.Name)}),
+				))
+			})
+
+			When("filtering by target guid", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{"other-app-guid"}
+				})
+
+				It("returns the matching audit events only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherCrash.Name)})))
+				})
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the matching audit events only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(crash.Name)})))
+				})
+			})
+
+			When("filtering by type", func() {
+				BeforeEach(func() {
+					message.Types = []string{"audit.app.update"}
+				})
+
+				It("returns the matching audit events only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			auditEvent AuditEventRecord
+			getErr     error
+			guid       string
+		)
+
+		BeforeEach(func() {
+			guid = crash.Name
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			auditEvent, getErr = auditEventRepo.GetAuditEvent(ctx, authInfo, guid)
+		})
+
+		It("returns the audit event", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(auditEvent.GUID).To(Equal(crash.Name))
+		})
+
+		When("the audit event is in a space the user has no access to", func() {
+			BeforeEach(func() {
+				guid = otherCrash.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
+
+func createAuditEvent(spaceGUID, orgGUID, appGUID string) *korifiv1alpha1.CFAuditEvent {
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: spaceGUID,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: korifiv1alpha1.AppProcessCrashAuditEventType,
+			Actor: korifiv1alpha1.AuditEventResource{
+				GUID: "process-guid",
+				Type: "process",
+				Name: "web",
+			},
+			Target: korifiv1alpha1.AuditEventResource{
+				GUID: appGUID,
+				Type: "app",
+				Name: "my-app",
+			},
+			OrgGUID: orgGUID,
+			ProcessCrash: &korifiv1alpha1.ProcessCrashData{
+				Index:          1,
+				Reason:         "OOMKilled",
+				ExitStatus:     137,
+				CrashCount:     1,
+				CrashTimestamp: metav1.Now(),
+			},
+		},
+	}
+	auditEvent.Labels = map[string]string{
+		korifiv1alpha1.GUIDLabelKey:                   auditEvent.Name,
+		korifiv1alpha1.SpaceGUIDKey:                   spaceGUID,
+		korifiv1alpha1.CFAuditEventTypeLabelKey:       auditEvent.Spec.Type,
+		korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: appGUID,
+	}
+	Expect(k8sClient.Create(ctx, auditEvent)).To(Succeed())
+
+	return auditEvent
+}
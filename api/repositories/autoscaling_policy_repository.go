@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const AutoscalingPolicyResourceType = "Autoscaling Policy"
+
+type AutoscalingPolicyRepo struct {
+	klient Klient
+}
+
+func NewAutoscalingPolicyRepo(klient Klient) *AutoscalingPolicyRepo {
+	return &AutoscalingPolicyRepo{
+		klient: klient,
+	}
+}
+
+type AutoscalingPolicyRecord struct {
+	ProcessGUID              string
+	SpaceGUID                string
+	MinInstances             int32
+	MaxInstances             int32
+	CPUUtilizationPercent    *int32
+	MemoryUtilizationPercent *int32
+	Timezone                 string
+	Schedules                []AutoscalingSchedule
+	Vertical                 *VerticalAutoscaling
+	CurrentInstances         int32
+	DesiredInstances         int32
+	ActiveSchedule           *int32
+	RecommendedMemoryMB      int64
+	RecentDecisions          []ScalingDecision
+	CreatedAt                time.Time
+	UpdatedAt                *time.Time
+}
+
+type AutoscalingSchedule struct {
+	DaysOfWeek   []int32
+	StartTime    string
+	EndTime      string
+	MinInstances int32
+	MaxInstances int32
+}
+
+type VerticalAutoscaling struct {
+	MinMemoryMB int64
+	MaxMemoryMB int64
+}
+
+type ScalingDecision struct {
+	Time          time.Time
+	FromInstances int32
+	ToInstances   int32
+	Reason        string
+}
+
+type SetAutoscalingPolicyMessage struct {
+	ProcessGUID              string
+	SpaceGUID                string
+	MinInstances             int32
+	MaxInstances             int32
+	CPUUtilizationPercent    *int32
+	MemoryUtilizationPercent *int32
+	Timezone                 string
+	Schedules                []AutoscalingSchedule
+	Vertical                 *VerticalAutoscaling
+}
+
+func (m SetAutoscalingPolicyMessage) toSpec() korifiv1alpha1.CFAutoscalingPolicySpec {
+	spec := korifiv1alpha1.CFAutoscalingPolicySpec{
+		ProcessRef:               corev1.LocalObjectReference{Name: m.ProcessGUID},
+		MinInstances:             m.MinInstances,
+		MaxInstances:             m.MaxInstances,
+		CPUUtilizationPercent:    m.CPUUtilizationPercent,
+		MemoryUtilizationPercent: m.MemoryUtilizationPercent,
+		Timezone:                 m.Timezone,
+	}
+
+	for _, schedule := range m.Schedules {
+		spec.Schedules = append(spec.Schedules, korifiv1alpha1.AutoscalingSchedule(schedule))
+	}
+
+	if m.Vertical != nil {
+		spec.Vertical = &korifiv1alpha1.VerticalAutoscaling{
+			MinMemoryMB: m.Vertical.MinMemoryMB,
+			MaxMemoryMB: m.Vertical.MaxMemoryMB,
+		}
+	}
+
+	return spec
+}
+
+func (r *AutoscalingPolicyRepo) GetAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, spaceGUID, processGUID string) (AutoscalingPolicyRecord, error) {
+	policy := autoscalingPolicyObject(spaceGUID, processGUID)
+
+	err := r.klient.Get(ctx, policy)
+	if err != nil {
+		return AutoscalingPolicyRecord{}, fmt.Errorf("failed to get autoscaling policy of process %q: %w", processGUID, apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	return cfAutoscalingPolicyToRecord(*policy)
+}
+
+// SetAutoscalingPolicy creates the autoscaling policy of the process, or
+// replaces it if it already exists
+func (r *AutoscalingPolicyRepo) SetAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, message SetAutoscalingPolicyMessage) (AutoscalingPolicyRecord, error) {
+	policy := autoscalingPolicyObject(message.SpaceGUID, message.ProcessGUID)
+
+	err := r.klient.Get(ctx, policy)
+	if k8serrors.IsNotFound(err) {
+		policy.Spec = message.toSpec()
+		if err = r.klient.Create(ctx, policy); err != nil {
+			return AutoscalingPolicyRecord{}, fmt.Errorf("failed to create autoscaling policy: %w", apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+		}
+		return cfAutoscalingPolicyToRecord(*policy)
+	}
+	if err != nil {
+		return AutoscalingPolicyRecord{}, fmt.Errorf("failed to get autoscaling policy: %w", apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	err = r.klient.Patch(ctx, policy, func() error {
+		policy.Spec = message.toSpec()
+		return nil
+	})
+	if err != nil {
+		return AutoscalingPolicyRecord{}, fmt.Errorf("failed to patch autoscaling policy: %w", apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	return cfAutoscalingPolicyToRecord(*policy)
+}
+
+func (r *AutoscalingPolicyRepo) DeleteAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, spaceGUID, processGUID string) error {
+	err := r.klient.Delete(ctx, autoscalingPolicyObject(spaceGUID, processGUID))
+	if err != nil {
+		return fmt.Errorf("failed to delete autoscaling policy of process %q: %w", processGUID, apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	return nil
+}
+
+func autoscalingPolicyObject(spaceGUID, processGUID string) *korifiv1alpha1.CFAutoscalingPolicy {
+	return &korifiv1alpha1.CFAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: spaceGUID,
+			Name:      processGUID,
+		},
+	}
+}
+
+func cfAutoscalingPolicyToRecord(policy korifiv1alpha1.CFAutoscalingPolicy) (AutoscalingPolicyRecord, error) {
+	createdAt, updatedAt, err := getCreatedUpdatedAt(&policy)
+	if err != nil {
+		return AutoscalingPolicyRecord{}, fmt.Errorf("failed to parse timestamps for autoscaling policy %q: %w", policy.Name, err)
+	}
+
+	record := AutoscalingPolicyRecord{
+		ProcessGUID:              policy.Spec.ProcessRef.Name,
+		SpaceGUID:                policy.Namespace,
+		MinInstances:             policy.Spec.MinInstances,
+		MaxInstances:             policy.Spec.MaxInstances,
+		CPUUtilizationPercent:    policy.Spec.CPUUtilizationPercent,
+		MemoryUtilizationPercent: policy.Spec.MemoryUtilizationPercent,
+		Timezone:                 policy.Spec.Timezone,
+		CurrentInstances:         policy.Status.CurrentInstances,
+		DesiredInstances:         policy.Status.DesiredInstances,
+		ActiveSchedule:           policy.Status.ActiveSchedule,
+		RecommendedMemoryMB:      policy.Status.RecommendedMemoryMB,
+		CreatedAt:                createdAt,
+		UpdatedAt:                updatedAt,
+	}
+
+	for _, schedule := range policy.Spec.Schedules {
+		record.Schedules = append(record.Schedules, AutoscalingSchedule(schedule))
+	}
+
+	if policy.Spec.Vertical != nil {
+		record.Vertical = &VerticalAutoscaling{
+			MinMemoryMB: policy.Spec.Vertical.MinMemoryMB,
+			MaxMemoryMB: policy.Spec.Vertical.MaxMemoryMB,
+		}
+	}
+
+	for _, decision := range policy.Status.RecentDecisions {
+		record.RecentDecisions = append(record.RecentDecisions, ScalingDecision{
+			Time:          decision.Time.Time,
+			FromInstances: decision.FromInstances,
+			ToInstances:   decision.ToInstances,
+			Reason:        decision.Reason,
+		})
+	}
+
+	return record, nil
+}
@@ -0,0 +1,262 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AutoscalingPolicyRepo", func() {
+	var (
+		repo        *repositories.AutoscalingPolicyRepo
+		space       *korifiv1alpha1.CFSpace
+		processGUID string
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewAutoscalingPolicyRepo(klient)
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		processGUID = prefixedGUID("process")
+	})
+
+	Describe("GetAutoscalingPolicy", func() {
+		var (
+			record repositories.AutoscalingPolicyRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			policy := &korifiv1alpha1.CFAutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      processGUID,
+				},
+				Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:            corev1.LocalObjectReference{Name: processGUID},
+					MinInstances:          1,
+					MaxInstances:          4,
+					CPUUtilizationPercent: tools.PtrTo[int32](70),
+					Timezone:              "Europe/London",
+					Schedules: []korifiv1alpha1.AutoscalingSchedule{{
+						DaysOfWeek:   []int32{1, 2},
+						StartTime:    "08:00",
+						EndTime:      "18:00",
+						MinInstances: 2,
+						MaxInstances: 8,
+					}},
+					Vertical: &korifiv1alpha1.VerticalAutoscaling{
+						MinMemoryMB: 128,
+						MaxMemoryMB: 1024,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, policy, func() {
+				policy.Status.CurrentInstances = 2
+				policy.Status.DesiredInstances = 3
+				policy.Status.ActiveSchedule = tools.PtrTo[int32](0)
+				policy.Status.RecommendedMemoryMB = 512
+				policy.Status.RecentDecisions = []korifiv1alpha1.ScalingDecision{{
+					Time:          metav1.Now(),
+					FromInstances: 2,
+					ToInstances:   3,
+					Reason:        "cpu utilization 90% (target 70%)",
+				}}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetAutoscalingPolicy(ctx, authInfo, space.Name, processGUID)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the policy", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.ProcessGUID).To(Equal(processGUID))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.MinInstances).To(BeEquivalentTo(1))
+				Expect(record.MaxInstances).To(BeEquivalentTo(4))
+				Expect(record.CPUUtilizationPercent).To(Equal(tools.PtrTo[int32](70)))
+				Expect(record.MemoryUtilizationPercent).To(BeNil())
+				Expect(record.Timezone).To(Equal("Europe/London"))
+				Expect(record.Schedules).To(ConsistOf(repositories.AutoscalingSchedule{
+					DaysOfWeek:   []int32{1, 2},
+					StartTime:    "08:00",
+					EndTime:      "18:00",
+					MinInstances: 2,
+					MaxInstances: 8,
+				}))
+				Expect(record.Vertical).To(Equal(&repositories.VerticalAutoscaling{MinMemoryMB: 128, MaxMemoryMB: 1024}))
+				Expect(record.CurrentInstances).To(BeEquivalentTo(2))
+				Expect(record.DesiredInstances).To(BeEquivalentTo(3))
+				Expect(record.ActiveSchedule).To(Equal(tools.PtrTo[int32](0)))
+				Expect(record.RecommendedMemoryMB).To(BeEquivalentTo(512))
+				Expect(record.RecentDecisions).To(HaveLen(1))
+				Expect(record.RecentDecisions[0].Reason).To(Equal("cpu utilization 90% (target 70%)"))
+				Expect(record.CreatedAt).NotTo(BeZero())
+			})
+
+			When("the policy does not exist", func() {
+				BeforeEach(func() {
+					processGUID = prefixedGUID("another-process")
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("SetAutoscalingPolicy", func() {
+		var (
+			message repositories.SetAutoscalingPolicyMessage
+			record  repositories.AutoscalingPolicyRecord
+			setErr  error
+		)
+
+		BeforeEach(func() {
+			message = repositories.SetAutoscalingPolicyMessage{
+				ProcessGUID:              processGUID,
+				SpaceGUID:                space.Name,
+				MinInstances:             2,
+				MaxInstances:             6,
+				MemoryUtilizationPercent: tools.PtrTo[int32](80),
+				Schedules: []repositories.AutoscalingSchedule{{
+					DaysOfWeek:   []int32{6, 7},
+					StartTime:    "00:00",
+					EndTime:      "23:59",
+					MinInstances: 1,
+					MaxInstances: 2,
+				}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, setErr = repo.SetAutoscalingPolicy(ctx, authInfo, message)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates the policy", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(record.ProcessGUID).To(Equal(processGUID))
+				Expect(record.MinInstances).To(BeEquivalentTo(2))
+
+				policy := &korifiv1alpha1.CFAutoscalingPolicy{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: processGUID}, policy)).To(Succeed())
+				Expect(policy.Spec).To(Equal(korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:               corev1.LocalObjectReference{Name: processGUID},
+					MinInstances:             2,
+					MaxInstances:             6,
+					MemoryUtilizationPercent: tools.PtrTo[int32](80),
+					Schedules: []korifiv1alpha1.AutoscalingSchedule{{
+						DaysOfWeek:   []int32{6, 7},
+						StartTime:    "00:00",
+						EndTime:      "23:59",
+						MinInstances: 1,
+						MaxInstances: 2,
+					}},
+				}))
+			})
+
+			When("the policy already exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAutoscalingPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      processGUID,
+						},
+						Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+							ProcessRef:            corev1.LocalObjectReference{Name: processGUID},
+							MinInstances:          1,
+							MaxInstances:          3,
+							CPUUtilizationPercent: tools.PtrTo[int32](50),
+							Vertical: &korifiv1alpha1.VerticalAutoscaling{
+								MinMemoryMB: 128,
+								MaxMemoryMB: 1024,
+							},
+						},
+					})).To(Succeed())
+				})
+
+				It("replaces the policy", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+
+					policy := &korifiv1alpha1.CFAutoscalingPolicy{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: processGUID}, policy)).To(Succeed())
+					Expect(policy.Spec.MaxInstances).To(BeEquivalentTo(6))
+					Expect(policy.Spec.CPUUtilizationPercent).To(BeNil())
+					Expect(policy.Spec.MemoryUtilizationPercent).To(Equal(tools.PtrTo[int32](80)))
+					Expect(policy.Spec.Vertical).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("DeleteAutoscalingPolicy", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      processGUID,
+				},
+				Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:   corev1.LocalObjectReference{Name: processGUID},
+					MinInstances: 1,
+					MaxInstances: 3,
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteAutoscalingPolicy(ctx, authInfo, space.Name, processGUID)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the policy", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: processGUID}, &korifiv1alpha1.CFAutoscalingPolicy{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
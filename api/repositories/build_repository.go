@@ -213,6 +213,7 @@ type ListBuildsMessage struct {
 	AppGUIDs     []string
 	States       []string
 	OrderBy      string
+	Timestamps   TimestampFilters
 }
 
 func (m *ListBuildsMessage) toListOptions() []ListOption {
@@ -220,6 +221,7 @@ func (m *ListBuildsMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.CFPackageGUIDLabelKey, m.PackageGUIDs),
 		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUIDs),
 		WithLabelIn(korifiv1alpha1.CFBuildStateLabelKey, m.States),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
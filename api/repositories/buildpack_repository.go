@@ -12,6 +12,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,22 +20,39 @@ import (
 
 const (
 	BuildpackResourceType = "Buildpack"
+
+	BuildpackStateAwaitingUpload   = "AWAITING_UPLOAD"
+	BuildpackStateProcessingUpload = "PROCESSING_UPLOAD"
+	BuildpackStateReady            = "READY"
 )
 
 type BuildpackRepository struct {
-	builderName   string
-	klient        Klient
-	rootNamespace string
-	sorter        BuildpackSorter
+	builderName       string
+	klient            Klient
+	rootNamespace     string
+	sorter            BuildpackSorter
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
 }
 
 type BuildpackRecord struct {
-	Name      string
-	Position  int
-	Stack     string
-	Version   string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	// GUID is empty for buildpacks that are configured directly on the
+	// ClusterBuilder rather than managed through the API
+	GUID        string
+	Name        string
+	Filename    string
+	State       string
+	Position    int
+	Stack       string
+	Version     string
+	Enabled     bool
+	Locked      bool
+	ImageRef    string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   *time.Time
 }
 
 //counterfeiter:generate -o fake -fake-name BuildpackSorter . BuildpackSorter
@@ -77,7 +95,36 @@ func BuildpackComparator(fieldName string) func(BuildpackRecord, BuildpackRecord
 }
 
 type ListBuildpacksMessage struct {
-	OrderBy string
+	OrderBy    string
+	Timestamps TimestampFilters
+}
+
+func (m ListBuildpacksMessage) matches(record BuildpackRecord) bool {
+	return m.Timestamps.Matches(record.CreatedAt, record.UpdatedAt)
+}
+
+type CreateBuildpackMessage struct {
+	Name     string
+	Stack    string
+	Position int
+	Enabled  bool
+	Locked   bool
+	Metadata Metadata
+}
+
+type UpdateBuildpackMessage struct {
+	GUID          string
+	Name          *string
+	Stack         *string
+	Position      *int
+	Enabled       *bool
+	Locked        *bool
+	MetadataPatch MetadataPatch
+}
+
+type UpdateBuildpackImageMessage struct {
+	GUID  string
+	Image string
 }
 
 func NewBuildpackRepository(
@@ -85,12 +132,16 @@ func NewBuildpackRepository(
 	builderName string,
 	rootNamespace string,
 	sorter BuildpackSorter,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *BuildpackRepository {
 	return &BuildpackRepository{
-		klient:        klient,
-		builderName:   builderName,
-		rootNamespace: rootNamespace,
-		sorter:        sorter,
+		klient:            klient,
+		builderName:       builderName,
+		rootNamespace:     rootNamespace,
+		sorter:            sorter,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
 	}
 }
 
@@ -126,18 +177,218 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
 	}
 
-	return r.sorter.Sort(builderInfoToBuildpackRecords(*builderInfo), message.OrderBy), nil
+	cfBuildpackList := &korifiv1alpha1.CFBuildpackList{}
+	if err = r.klient.List(ctx, cfBuildpackList, InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list buildpacks: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	records := slices.Collect(it.Filter(slices.Values(r.mergeBuildpackRecords(*builderInfo, cfBuildpackList.Items)), message.matches))
+	return r.sorter.Sort(records, message.OrderBy), nil
 }
 
-func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackRecord {
-	return slices.Collect(it.Right(it.Map2(slices.All(info.Status.Buildpacks), func(i int, b korifiv1alpha1.BuilderInfoStatusBuildpack) (int, BuildpackRecord) {
-		return i, BuildpackRecord{
-			Name:      b.Name,
-			Version:   b.Version,
-			Position:  i + 1,
-			Stack:     b.Stack,
-			CreatedAt: b.CreationTimestamp.Time,
-			UpdatedAt: &b.UpdatedTimestamp.Time,
+// mergeBuildpackRecords lists the buildpacks in the builder detection order,
+// presenting those that are managed through the API as their CFBuildpack.
+// Managed buildpacks that are not (yet) part of the order, e.g. because they
+// are disabled or still awaiting bits, are listed at their desired position.
+func (r *BuildpackRepository) mergeBuildpackRecords(info korifiv1alpha1.BuilderInfo, cfBuildpacks []korifiv1alpha1.CFBuildpack) []BuildpackRecord {
+	records := []BuildpackRecord{}
+	inOrder := map[string]bool{}
+
+	for i, b := range info.Status.Buildpacks {
+		cfBuildpackIndex := slices.IndexFunc(cfBuildpacks, func(cfBuildpack korifiv1alpha1.CFBuildpack) bool {
+			// stock buildpacks might share the id of a managed one, but the
+			// managed ones are pinned to their resolved version
+			return cfBuildpack.Spec.Enabled &&
+				!inOrder[cfBuildpack.Name] &&
+				cfBuildpack.Status.BuildpackID == b.Name &&
+				cfBuildpack.Status.Version == b.Version
+		})
+		if cfBuildpackIndex < 0 {
+			records = append(records, builderInfoBuildpackToBuildpackRecord(i, b))
+			continue
 		}
-	})))
+
+		cfBuildpack := cfBuildpacks[cfBuildpackIndex]
+		inOrder[cfBuildpack.Name] = true
+
+		record := r.cfBuildpackToBuildpackRecord(cfBuildpack)
+		record.Position = i + 1
+		record.Version = b.Version
+		records = append(records, record)
+	}
+
+	for _, cfBuildpack := range cfBuildpacks {
+		if !inOrder[cfBuildpack.Name] {
+			records = append(records, r.cfBuildpackToBuildpackRecord(cfBuildpack))
+		}
+	}
+
+	return records
+}
+
+func (r *BuildpackRepository) GetBuildpack(ctx context.Context, authInfo authorization.Info, guid string) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to get buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) CreateBuildpack(ctx context.Context, authInfo authorization.Info, message CreateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: message.Name,
+			Stack:       message.Stack,
+			Position:    message.Position,
+			Enabled:     message.Enabled,
+			Locked:      message.Locked,
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(*cfBuildpack)); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to create buildpack repository: %w", err)
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) UpdateBuildpack(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfBuildpack, func() error {
+		if message.Name != nil {
+			cfBuildpack.Spec.DisplayName = *message.Name
+		}
+		if message.Stack != nil {
+			cfBuildpack.Spec.Stack = *message.Stack
+		}
+		if message.Position != nil {
+			cfBuildpack.Spec.Position = *message.Position
+		}
+		if message.Enabled != nil {
+			cfBuildpack.Spec.Enabled = *message.Enabled
+		}
+		if message.Locked != nil {
+			cfBuildpack.Spec.Locked = *message.Locked
+		}
+		message.MetadataPatch.Apply(cfBuildpack)
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) UpdateBuildpackImage(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackImageMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfBuildpack, func() error {
+		cfBuildpack.Spec.Image = message.Image
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) DeleteBuildpack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	err := r.klient.Delete(ctx, &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	})
+
+	return apierrors.FromK8sError(err, BuildpackResourceType)
+}
+
+func (r *BuildpackRepository) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	buildpack, err := r.GetBuildpack(ctx, authInfo, guid)
+	return buildpack.DeletedAt, err
+}
+
+func (r *BuildpackRepository) repositoryRef(cfBuildpack korifiv1alpha1.CFBuildpack) string {
+	return r.repositoryPrefix + cfBuildpack.Name + "-buildpack"
+}
+
+func (r *BuildpackRepository) cfBuildpackToBuildpackRecord(cfBuildpack korifiv1alpha1.CFBuildpack) BuildpackRecord {
+	record := BuildpackRecord{
+		GUID:        cfBuildpack.Name,
+		Name:        cfBuildpack.Spec.DisplayName,
+		State:       buildpackState(cfBuildpack),
+		Position:    cfBuildpack.Spec.Position,
+		Stack:       cfBuildpack.Spec.Stack,
+		Version:     cfBuildpack.Status.Version,
+		Enabled:     cfBuildpack.Spec.Enabled,
+		Locked:      cfBuildpack.Spec.Locked,
+		ImageRef:    r.repositoryRef(cfBuildpack),
+		Labels:      cfBuildpack.Labels,
+		Annotations: cfBuildpack.Annotations,
+		CreatedAt:   cfBuildpack.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfBuildpack),
+		DeletedAt:   golangTime(cfBuildpack.DeletionTimestamp),
+	}
+
+	if cfBuildpack.Status.BuildpackID != "" {
+		record.Filename = cfBuildpack.Status.BuildpackID + "@" + cfBuildpack.Status.Version
+	}
+
+	return record
+}
+
+func buildpackState(cfBuildpack korifiv1alpha1.CFBuildpack) string {
+	if cfBuildpack.Spec.Image == "" {
+		return BuildpackStateAwaitingUpload
+	}
+
+	if meta.IsStatusConditionTrue(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
+		return BuildpackStateReady
+	}
+
+	return BuildpackStateProcessingUpload
+}
+
+func builderInfoBuildpackToBuildpackRecord(i int, b korifiv1alpha1.BuilderInfoStatusBuildpack) BuildpackRecord {
+	return BuildpackRecord{
+		Name:      b.Name,
+		Filename:  b.Name + "@" + b.Version,
+		State:     BuildpackStateReady,
+		Version:   b.Version,
+		Position:  i + 1,
+		Stack:     b.Stack,
+		Enabled:   true,
+		CreatedAt: b.CreationTimestamp.Time,
+		UpdatedAt: &b.UpdatedTimestamp.Time,
+	}
 }
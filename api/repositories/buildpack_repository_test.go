@@ -2,27 +2,34 @@ package repositories_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	gomega_types "github.com/onsi/gomega/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("BuildpackRepository", func() {
 	var (
 		buildpackRepo *BuildpackRepository
 		sorter        *fake.BuildpackSorter
+		repoCreator   *fake.RepositoryCreator
 	)
 
 	BeforeEach(func() {
@@ -31,7 +38,9 @@ var _ = Describe("BuildpackRepository", func() {
 			return records
 		}
 
-		buildpackRepo = NewBuildpackRepository(klientUnfiltered, builderName, rootNamespace, sorter)
+		repoCreator = new(fake.RepositoryCreator)
+
+		buildpackRepo = NewBuildpackRepository(klientUnfiltered, builderName, rootNamespace, sorter, repoCreator, "container.registry/foo/my/prefix-")
 	})
 
 	Describe("ListBuildpacks", func() {
@@ -97,6 +106,97 @@ var _ = Describe("BuildpackRepository", func() {
 			})
 		})
 
+		When("there are buildpacks managed through the API", func() {
+			var (
+				buildpacks       []BuildpackRecord
+				orderedBuildpack *korifiv1alpha1.CFBuildpack
+				pendingBuildpack *korifiv1alpha1.CFBuildpack
+			)
+
+			BeforeEach(func() {
+				createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", []buildpackInfo{
+					{name: "paketo-buildpacks/buildpack-1-1", version: "1.1"},
+					{name: "my-org/custom", version: "0.0.1"},
+				})
+
+				orderedBuildpack = createCFBuildpack(ctx, "custom-buildpack", 2)
+				Expect(k8s.Patch(ctx, k8sClient, orderedBuildpack, func() {
+					orderedBuildpack.Status.BuildpackID = "my-org/custom"
+					orderedBuildpack.Status.Version = "0.0.1"
+				})).To(Succeed())
+
+				pendingBuildpack = createCFBuildpack(ctx, "pending-buildpack", 1)
+
+				var err error
+				buildpacks, err = buildpackRepo.ListBuildpacks(ctx, authInfo, message)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("presents them alongside the builder buildpacks", func() {
+				Expect(buildpacks).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     BeEmpty(),
+						"Name":     Equal("paketo-buildpacks/buildpack-1-1"),
+						"Position": Equal(1),
+						"State":    Equal(BuildpackStateReady),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     Equal(orderedBuildpack.Name),
+						"Name":     Equal("custom-buildpack"),
+						"Filename": Equal("my-org/custom@0.0.1"),
+						"Position": Equal(2),
+						"Version":  Equal("0.0.1"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     Equal(pendingBuildpack.Name),
+						"Name":     Equal("pending-buildpack"),
+						"Position": Equal(1),
+						"State":    Equal(BuildpackStateAwaitingUpload),
+					}),
+				))
+			})
+		})
+
+		When("a managed buildpack has the id of a stock buildpack", func() {
+			var (
+				buildpacks  []BuildpackRecord
+				cfBuildpack *korifiv1alpha1.CFBuildpack
+			)
+
+			BeforeEach(func() {
+				createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", []buildpackInfo{
+					{name: "my-org/custom", version: "0.0.1"},
+					{name: "my-org/custom", version: "0.0.2"},
+				})
+
+				cfBuildpack = createCFBuildpack(ctx, "custom-buildpack", 1)
+				Expect(k8s.Patch(ctx, k8sClient, cfBuildpack, func() {
+					cfBuildpack.Status.BuildpackID = "my-org/custom"
+					cfBuildpack.Status.Version = "0.0.1"
+				})).To(Succeed())
+
+				var err error
+				buildpacks, err = buildpackRepo.ListBuildpacks(ctx, authInfo, message)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("presents the stock buildpack separately", func() {
+				Expect(buildpacks).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     Equal(cfBuildpack.Name),
+						"Position": Equal(1),
+						"Version":  Equal("0.0.1"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     BeEmpty(),
+						"Name":     Equal("my-org/custom"),
+						"Position": Equal(2),
+						"Version":  Equal("0.0.2"),
+					}),
+				))
+			})
+		})
+
 		When("no build reconcilers exist", func() {
 			It("errors", func() {
 				_, err := buildpackRepo.ListBuildpacks(ctx, authInfo, message)
@@ -166,8 +266,306 @@ var _ = Describe("BuildpackRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateBuildpack", func() {
+		var (
+			record    BuildpackRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = buildpackRepo.CreateBuildpack(ctx, authInfo, CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "cflinuxfs4",
+				Position: 2,
+				Enabled:  true,
+				Metadata: Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(repoCreator.CreateRepositoryCallCount()).To(BeZero())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFBuildpack awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("my-buildpack"))
+				Expect(record.Stack).To(Equal("cflinuxfs4"))
+				Expect(record.Position).To(Equal(2))
+				Expect(record.Enabled).To(BeTrue())
+				Expect(record.State).To(Equal(BuildpackStateAwaitingUpload))
+				Expect(record.ImageRef).To(Equal("container.registry/foo/my/prefix-" + record.GUID + "-buildpack"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfBuildpack := &korifiv1alpha1.CFBuildpack{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      record.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				Expect(cfBuildpack.Spec.DisplayName).To(Equal("my-buildpack"))
+				Expect(cfBuildpack.Spec.Position).To(Equal(2))
+			})
+
+			It("creates the buildpack image repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + record.GUID + "-buildpack"))
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo-create-error"))
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("repo-create-error")))
+				})
+			})
+		})
+	})
+
+	Describe("GetBuildpack", func() {
+		var (
+			cfBuildpack *korifiv1alpha1.CFBuildpack
+			record      BuildpackRecord
+			getErr      error
+			guid        string
+		)
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1)
+			guid = cfBuildpack.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = buildpackRepo.GetBuildpack(ctx, authInfo, guid)
+		})
+
+		It("returns the buildpack", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(cfBuildpack.Name))
+			Expect(record.Name).To(Equal("my-buildpack"))
+			Expect(record.State).To(Equal(BuildpackStateAwaitingUpload))
+			Expect(record.Filename).To(BeEmpty())
+		})
+
+		When("the buildpack has been uploaded and resolved", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfBuildpack, func() {
+					cfBuildpack.Spec.Image = "my/buildpack@sha256:abc"
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, k8sClient, cfBuildpack, func() {
+					cfBuildpack.Status.BuildpackID = "my-org/my-buildpack"
+					cfBuildpack.Status.Version = "1.2.3"
+					meta.SetStatusCondition(&cfBuildpack.Status.Conditions, metav1.Condition{
+						Type:   korifiv1alpha1.StatusConditionReady,
+						Status: metav1.ConditionTrue,
+						Reason: "Ready",
+					})
+				})).To(Succeed())
+			})
+
+			It("is ready", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.State).To(Equal(BuildpackStateReady))
+				Expect(record.Filename).To(Equal("my-org/my-buildpack@1.2.3"))
+				Expect(record.Version).To(Equal("1.2.3"))
+			})
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				guid = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateBuildpack", func() {
+		var (
+			cfBuildpack *korifiv1alpha1.CFBuildpack
+			record      BuildpackRecord
+			updateErr   error
+		)
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1)
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = buildpackRepo.UpdateBuildpack(ctx, authInfo, UpdateBuildpackMessage{
+				GUID:     cfBuildpack.Name,
+				Name:     tools.PtrTo("new-name"),
+				Position: tools.PtrTo(4),
+				Enabled:  tools.PtrTo(false),
+				Locked:   tools.PtrTo(true),
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the buildpack", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.Position).To(Equal(4))
+				Expect(record.Enabled).To(BeFalse())
+				Expect(record.Locked).To(BeTrue())
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				Expect(cfBuildpack.Spec.DisplayName).To(Equal("new-name"))
+				Expect(cfBuildpack.Spec.Position).To(Equal(4))
+				Expect(cfBuildpack.Spec.Enabled).To(BeFalse())
+				Expect(cfBuildpack.Spec.Locked).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateBuildpackImage", func() {
+		var (
+			cfBuildpack *korifiv1alpha1.CFBuildpack
+			record      BuildpackRecord
+			updateErr   error
+		)
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1)
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = buildpackRepo.UpdateBuildpackImage(ctx, authInfo, UpdateBuildpackImageMessage{
+				GUID:  cfBuildpack.Name,
+				Image: "my/buildpack@sha256:abc",
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("sets the buildpack image", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.State).To(Equal(BuildpackStateProcessingUpload))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				Expect(cfBuildpack.Spec.Image).To(Equal("my/buildpack@sha256:abc"))
+			})
+		})
+	})
+
+	Describe("DeleteBuildpack", func() {
+		var (
+			cfBuildpack *korifiv1alpha1.CFBuildpack
+			deleteErr   error
+		)
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = buildpackRepo.DeleteBuildpack(ctx, authInfo, cfBuildpack.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the buildpack", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("GetDeletedAt", func() {
+		var (
+			cfBuildpack *korifiv1alpha1.CFBuildpack
+			deletedAt   *time.Time
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1)
+		})
+
+		JustBeforeEach(func() {
+			deletedAt, getErr = buildpackRepo.GetDeletedAt(ctx, authInfo, cfBuildpack.Name)
+		})
+
+		It("returns nil", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(deletedAt).To(BeNil())
+		})
+
+		When("the buildpack is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfBuildpack, func() {
+					cfBuildpack.Finalizers = append(cfBuildpack.Finalizers, "foo")
+				})).To(Succeed())
+
+				Expect(k8sClient.Delete(ctx, cfBuildpack)).To(Succeed())
+			})
+
+			It("returns the deletion time", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(deletedAt).To(PointTo(BeTemporally("~", time.Now(), time.Minute)))
+			})
+		})
+	})
 })
 
+func createCFBuildpack(ctx context.Context, displayName string, position int) *korifiv1alpha1.CFBuildpack {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: displayName,
+			Position:    position,
+			Enabled:     true,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfBuildpack)).To(Succeed())
+
+	return cfBuildpack
+}
+
 type buildpackInfo struct {
 	name    string
 	version string
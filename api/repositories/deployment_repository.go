@@ -66,6 +66,7 @@ type ListDeploymentsMessage struct {
 	AppGUIDs     []string
 	StatusValues []DeploymentStatusValue
 	OrderBy      string
+	Timestamps   TimestampFilters
 }
 
 func (m ListDeploymentsMessage) toListOptions() []ListOption {
@@ -74,6 +75,7 @@ func (m ListDeploymentsMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.CFAppDeploymentStatusKey, slices.Collect(it.Map(slices.Values(m.StatusValues), func(s DeploymentStatusValue) string {
 			return string(s)
 		}))),
+		WithTimestamps(m.Timestamps),
 		m.toSortOption(),
 	}
 }
@@ -48,6 +48,10 @@ type DomainRecord struct {
 	DeletedAt   *time.Time
 }
 
+func (r DomainRecord) Relationships() map[string]string {
+	return map[string]string{}
+}
+
 func (r DomainRecord) GetResourceType() string {
 	return DomainResourceType
 }
@@ -63,13 +67,20 @@ type UpdateDomainMessage struct {
 }
 
 type ListDomainsMessage struct {
-	Names []string
+	Names      []string
+	GUIDs      []string
+	Timestamps TimestampFilters
+}
+
+func (m *ListDomainsMessage) matches(cfDomain korifiv1alpha1.CFDomain) bool {
+	return tools.EmptyOrContains(m.GUIDs, cfDomain.Name)
 }
 
 func (m *ListDomainsMessage) toListOptions(rootNamespace string) []ListOption {
 	return []ListOption{
 		WithLabelIn(korifiv1alpha1.CFEncodedDomainNameLabelKey, tools.EncodeValuesToSha224(m.Names...)),
 		InNamespace(rootNamespace),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
@@ -142,7 +153,7 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 		return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	domainRecords := slices.Collect(it.Map(slices.Values(cfdomainList.Items), cfDomainToDomainRecord))
+	domainRecords := slices.Collect(it.Map(it.Filter(slices.Values(cfdomainList.Items), message.matches), cfDomainToDomainRecord))
 	sort.Slice(domainRecords, func(i, j int) bool {
 		return domainRecords[i].CreatedAt.Before(domainRecords[j].CreatedAt)
 	})
@@ -289,6 +289,22 @@ var _ = Describe("DomainRepository", func() {
 			})
 		})
 
+		When("a guids filter is provided", func() {
+			BeforeEach(func() {
+				domainListMessage = ListDomainsMessage{
+					GUIDs: []string{domainGUID1},
+				}
+			})
+
+			It("returns the domainRecords with matching guids", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+
+				Expect(domainRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1)}),
+				))
+			})
+		})
+
 		When("the user has no permission to list domains in the root namespace", func() {
 			BeforeEach(func() {
 				userName = uuid.NewString()
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,17 +22,30 @@ import (
 
 const (
 	DropletResourceType = "Droplet"
+
+	DropletStateAwaitingUpload   = "AWAITING_UPLOAD"
+	DropletStateProcessingUpload = "PROCESSING_UPLOAD"
+	DropletStateStaged           = "STAGED"
 )
 
 type DropletRepo struct {
-	klient Klient
+	klient            Klient
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
+	awaiter           Awaiter[*korifiv1alpha1.CFBuild]
 }
 
 func NewDropletRepo(
 	klient Klient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
+	awaiter Awaiter[*korifiv1alpha1.CFBuild],
 ) *DropletRepo {
 	return &DropletRepo{
-		klient: klient,
+		klient:            klient,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
+		awaiter:           awaiter,
 	}
 }
 
@@ -43,11 +59,18 @@ type DropletRecord struct {
 	Stack           string
 	ProcessTypes    map[string]string
 	AppGUID         string
+	SpaceGUID       string
 	PackageGUID     string
 	Labels          map[string]string
 	Annotations     map[string]string
 	Image           string
 	Ports           []int32
+	// ImageRef is the droplet image in the registry, empty until the droplet
+	// is staged or uploaded
+	ImageRef string
+	// RepositoryRef is the registry repository where uploaded droplet images
+	// of the app are pushed to
+	RepositoryRef string
 }
 
 func (r DropletRecord) Relationships() map[string]string {
@@ -61,6 +84,7 @@ type ListDropletsMessage struct {
 	PackageGUIDs []string
 	AppGUIDs     []string
 	SpaceGUIDs   []string
+	Timestamps   TimestampFilters
 }
 
 func (m *ListDropletsMessage) toListOptions() []ListOption {
@@ -69,6 +93,7 @@ func (m *ListDropletsMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUIDs),
 		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, m.SpaceGUIDs),
 		WithLabelIn(korifiv1alpha1.CFDropletGUIDLabelKey, m.GUIDs),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
@@ -78,7 +103,7 @@ func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Inf
 		return DropletRecord{}, err
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, error) {
@@ -94,24 +119,32 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return build, nil
 }
 
-func cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
-	if cfBuild.Status.State == korifiv1alpha1.BuildStateStaged {
-		return cfBuildToDropletRecord(*cfBuild), nil
+func (r *DropletRepo) cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+	if cfBuild.Status.State == korifiv1alpha1.BuildStateStaged || cfBuild.Spec.Droplet != nil {
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
 
 	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
 }
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+	droplet := cfBuild.Status.Droplet
+	if droplet == nil {
+		droplet = cfBuild.Spec.Droplet
+	}
+	if droplet == nil {
+		droplet = &korifiv1alpha1.BuildDropletStatus{}
+	}
+
 	processTypesMap := make(map[string]string)
-	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
+	processTypesArrayObject := droplet.ProcessTypes
 	for index := range processTypesArrayObject {
 		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
 	}
 
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		State:     dropletState(cfBuild),
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -121,23 +154,42 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
-		ProcessTypes: processTypesMap,
-		AppGUID:      cfBuild.Spec.AppRef.Name,
-		PackageGUID:  cfBuild.Spec.PackageRef.Name,
-		Labels:       cfBuild.Labels,
-		Annotations:  cfBuild.Annotations,
-		Ports:        cfBuild.Status.Droplet.Ports,
+		Stack:         droplet.Stack,
+		ProcessTypes:  processTypesMap,
+		AppGUID:       cfBuild.Spec.AppRef.Name,
+		SpaceGUID:     cfBuild.Namespace,
+		PackageGUID:   cfBuild.Spec.PackageRef.Name,
+		Labels:        cfBuild.Labels,
+		Annotations:   cfBuild.Annotations,
+		Ports:         droplet.Ports,
+		ImageRef:      droplet.Registry.Image,
+		RepositoryRef: r.repositoryRef(cfBuild.Spec.AppRef.Name),
 	}
 
 	if cfBuild.Spec.Lifecycle.Type == "docker" {
 		result.Lifecycle.Data = LifecycleData{}
-		result.Image = cfBuild.Status.Droplet.Registry.Image
+		result.Image = droplet.Registry.Image
 	}
 
 	return result
 }
 
+func dropletState(cfBuild korifiv1alpha1.CFBuild) string {
+	if cfBuild.Spec.Droplet == nil || cfBuild.Status.State == korifiv1alpha1.BuildStateStaged {
+		return DropletStateStaged
+	}
+
+	if cfBuild.Spec.Droplet.Registry.Image == "" {
+		return DropletStateAwaitingUpload
+	}
+
+	return DropletStateProcessingUpload
+}
+
+func (r *DropletRepo) repositoryRef(appGUID string) string {
+	return r.repositoryPrefix + appGUID + "-droplets"
+}
+
 func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.Info, message ListDropletsMessage) ([]DropletRecord, error) {
 	buildList := &korifiv1alpha1.CFBuildList{}
 	err := r.klient.List(ctx, buildList, message.toListOptions()...)
@@ -145,7 +197,7 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 		return []DropletRecord{}, apierrors.FromK8sError(err, BuildResourceType)
 	}
 
-	return slices.Collect(it.Map(slices.Values(buildList.Items), cfBuildToDropletRecord)), nil
+	return slices.Collect(it.Map(slices.Values(buildList.Items), r.cfBuildToDropletRecord)), nil
 }
 
 type UpdateDropletMessage struct {
@@ -168,5 +220,149 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Lifecycle    Lifecycle
+	ProcessTypes map[string]string
+	Metadata     Metadata
+}
+
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   message.SpaceGUID,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: korifiv1alpha1.LifecycleType(message.Lifecycle.Type),
+				Data: korifiv1alpha1.LifecycleData{
+					Buildpacks: message.Lifecycle.Data.Buildpacks,
+					Stack:      message.Lifecycle.Data.Stack,
+				},
+			},
+			Droplet: &korifiv1alpha1.BuildDropletStatus{
+				Stack:        message.Lifecycle.Data.Stack,
+				ProcessTypes: toProcessTypes(message.ProcessTypes),
+			},
+		},
+	}
+
+	if err := r.klient.Create(ctx, build); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID)); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	return r.cfBuildToDropletRecord(*build), nil
+}
+
+func toProcessTypes(processTypes map[string]string) []korifiv1alpha1.ProcessType {
+	result := []korifiv1alpha1.ProcessType{}
+	for _, processType := range slices.Sorted(maps.Keys(processTypes)) {
+		result = append(result, korifiv1alpha1.ProcessType{
+			Type:    processType,
+			Command: processTypes[processType],
+		})
+	}
+
+	return result
+}
+
+type UpdateDropletImageMessage struct {
+	GUID                string
+	ImageRef            string
+	RegistrySecretNames []string
+}
+
+func (r *DropletRepo) UpdateDropletImage(ctx context.Context, authInfo authorization.Info, message UpdateDropletImageMessage) (DropletRecord, error) {
+	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.GUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if build.Spec.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Droplet was not created for upload.")
+	}
+
+	err = r.klient.Patch(ctx, build, func() error {
+		build.Spec.Droplet.Registry = korifiv1alpha1.Registry{
+			Image: message.ImageRef,
+			ImagePullSecrets: slices.Collect(it.Map(slices.Values(message.RegistrySecretNames), func(secret string) corev1.LocalObjectReference {
+				return corev1.LocalObjectReference{Name: secret}
+			})),
+		}
+		return nil
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to update droplet image: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfBuildToDropletRecord(*build), nil
+}
+
+func (r *DropletRepo) GetState(ctx context.Context, authInfo authorization.Info, dropletGUID string) (ResourceState, error) {
+	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err != nil {
+		return ResourceStateUnknown, err
+	}
+
+	if build.Status.State == korifiv1alpha1.BuildStateStaged {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+type CopyDropletMessage struct {
+	SourceGUID string
+	AppGUID    string
+	SpaceGUID  string
+}
+
+func (r *DropletRepo) CopyDroplet(ctx context.Context, authInfo authorization.Info, message CopyDropletMessage) (DropletRecord, error) {
+	source, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.SourceGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if source.Status.State != korifiv1alpha1.BuildStateStaged || source.Status.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source droplet must be staged.")
+	}
+
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.SpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Lifecycle: *source.Spec.Lifecycle.DeepCopy(),
+			Droplet:   source.Status.Droplet.DeepCopy(),
+		},
+	}
+
+	if err = r.klient.Create(ctx, build); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	build, err = r.awaiter.AwaitCondition(ctx, r.klient, build, korifiv1alpha1.SucceededConditionType)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed awaiting Succeeded status condition: %w", err)
+	}
+
+	return r.cfBuildToDropletRecord(*build), nil
 }
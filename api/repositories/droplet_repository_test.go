@@ -6,6 +6,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -28,18 +29,32 @@ var _ = Describe("DropletRepository", func() {
 	)
 
 	var (
-		dropletRepo *repositories.DropletRepo
-		build       *korifiv1alpha1.CFBuild
+		dropletRepo      *repositories.DropletRepo
+		repoCreator      *fake.RepositoryCreator
+		conditionAwaiter *fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFBuild,
+			korifiv1alpha1.CFBuildList,
+			*korifiv1alpha1.CFBuildList,
+		]
+		build   *korifiv1alpha1.CFBuild
+		space   *korifiv1alpha1.CFSpace
+		appGUID string
 	)
 
 	BeforeEach(func() {
 		org := createOrgWithCleanup(ctx, uuid.NewString())
-		space := createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
 
-		dropletRepo = repositories.NewDropletRepo(klient)
+		repoCreator = new(fake.RepositoryCreator)
+		conditionAwaiter = &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFBuild,
+			korifiv1alpha1.CFBuildList,
+			*korifiv1alpha1.CFBuildList,
+		]{}
+		dropletRepo = repositories.NewDropletRepo(klient, repoCreator, "container.registry/foo/my/prefix-", conditionAwaiter)
 
 		packageGUID := uuid.NewString()
-		appGUID := uuid.NewString()
+		appGUID = uuid.NewString()
 		build = &korifiv1alpha1.CFBuild{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      uuid.NewString(),
@@ -254,7 +269,7 @@ var _ = Describe("DropletRepository", func() {
 
 					BeforeEach(func() {
 						fakeKlient = new(fake.Klient)
-						dropletRepo = repositories.NewDropletRepo(fakeKlient)
+						dropletRepo = repositories.NewDropletRepo(fakeKlient, repoCreator, "container.registry/foo/my/prefix-", conditionAwaiter)
 
 						message = repositories.ListDropletsMessage{
 							GUIDs:        []string{"a1", "a2"},
@@ -374,4 +389,241 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			createErr     error
+		)
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(ctx, authInfo, repositories.CreateDropletMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+				ProcessTypes: map[string]string{"web": "run-web", "worker": "run-worker"},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a droplet awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).To(matchers.BeValidUUID())
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateAwaitingUpload))
+				Expect(dropletRecord.AppGUID).To(Equal(appGUID))
+				Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "run-web", "worker": "run-worker"}))
+				Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+				Expect(dropletRecord.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfBuild := &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      dropletRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(cfBuild.Spec.Lifecycle.Data.Stack).To(Equal("cflinuxfs4"))
+				Expect(cfBuild.Spec.Droplet).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Stack":    Equal("cflinuxfs4"),
+					"Registry": Equal(korifiv1alpha1.Registry{}),
+					"ProcessTypes": Equal([]korifiv1alpha1.ProcessType{
+						{Type: "web", Command: "run-web"},
+						{Type: "worker", Command: "run-worker"},
+					}),
+				})))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+			})
+		})
+	})
+
+	Describe("UpdateDropletImage", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+				build.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletImage(ctx, authInfo, repositories.UpdateDropletImageMessage{
+				GUID:                build.Name,
+				ImageRef:            "my-droplet@sha256:abc",
+				RegistrySecretNames: []string{"registry-secret"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the droplet image", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.ImageRef).To(Equal("my-droplet@sha256:abc"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(build), build)).To(Succeed())
+				Expect(build.Spec.Droplet.Registry).To(Equal(korifiv1alpha1.Registry{
+					Image:            "my-droplet@sha256:abc",
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+				}))
+			})
+
+			It("reports the droplet as processing the upload", func() {
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateProcessingUpload))
+			})
+
+			When("the build was staged from a package", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+						build.Spec.Droplet = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			state    repositories.ResourceState
+			stateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = dropletRepo.GetState(ctx, authInfo, build.Name)
+		})
+
+		It("returns unknown state", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(repositories.ResourceStateUnknown))
+		})
+
+		When("the build is staged", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, build, func() {
+					build.Status.State = korifiv1alpha1.BuildStateStaged
+				})).To(Succeed())
+			})
+
+			It("returns ready state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceStateReady))
+			})
+		})
+	})
+
+	Describe("CopyDroplet", func() {
+		var (
+			targetSpace   *korifiv1alpha1.CFSpace
+			dropletRecord repositories.DropletRecord
+			copyErr       error
+		)
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, uuid.NewString())
+			targetSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+
+			Expect(k8s.Patch(ctx, k8sClient, build, func() {
+				build.Status.State = korifiv1alpha1.BuildStateStaged
+				build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+					Stack: dropletStack,
+					Registry: korifiv1alpha1.Registry{
+						Image:            registryImage,
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryImageSecret}},
+					},
+					ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run-web"}},
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, copyErr = dropletRepo.CopyDroplet(ctx, authInfo, repositories.CopyDropletMessage{
+				SourceGUID: build.Name,
+				AppGUID:    "target-app-guid",
+				SpaceGUID:  targetSpace.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+			})
+
+			It("creates a build in the target app with the source droplet", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).NotTo(Equal(build.Name))
+				Expect(dropletRecord.AppGUID).To(Equal("target-app-guid"))
+				Expect(dropletRecord.SpaceGUID).To(Equal(targetSpace.Name))
+
+				cfBuild := &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: targetSpace.Name,
+						Name:      dropletRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Spec.Lifecycle).To(Equal(build.Spec.Lifecycle))
+				Expect(cfBuild.Spec.Droplet).To(Equal(build.Status.Droplet))
+			})
+
+			It("awaits the Succeeded condition", func() {
+				Expect(conditionAwaiter.AwaitConditionCallCount()).To(Equal(1))
+				_, conditionType := conditionAwaiter.AwaitConditionArgsForCall(0)
+				Expect(conditionType).To(Equal(korifiv1alpha1.SucceededConditionType))
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Status.State = korifiv1alpha1.BuildStateStaging
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	EnvVarGroupResourceType = "Environment Variable Group"
+
+	RunningEnvVarGroupName = "running"
+	StagingEnvVarGroupName = "staging"
+)
+
+var envVarGroupSecretNames = map[string]string{
+	RunningEnvVarGroupName: korifiv1alpha1.RunningEnvVarGroupSecretName,
+	StagingEnvVarGroupName: korifiv1alpha1.StagingEnvVarGroupSecretName,
+}
+
+type EnvVarGroupRecord struct {
+	Name                 string
+	EnvironmentVariables map[string]string
+	UpdatedAt            *time.Time
+}
+
+type PatchEnvVarGroupMessage struct {
+	Name                 string
+	EnvironmentVariables map[string]*string
+}
+
+// EnvVarGroupRepo manages the platform wide running and staging environment
+// variable groups. They are stored as secrets in the root namespace which
+// only admins can access. Apps still need to see the group values in their
+// environment, so those are read with the privileged client.
+type EnvVarGroupRepo struct {
+	klient           Klient
+	privilegedClient client.Client
+	rootNamespace    string
+}
+
+func NewEnvVarGroupRepo(klient Klient, privilegedClient client.Client, rootNamespace string) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		rootNamespace:    rootNamespace,
+	}
+}
+
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	secret, err := r.envVarGroupSecret(name)
+	if err != nil {
+		return EnvVarGroupRecord{}, err
+	}
+
+	err = r.klient.Get(ctx, secret)
+	if k8serrors.IsNotFound(err) {
+		return EnvVarGroupRecord{Name: name, EnvironmentVariables: map[string]string{}}, nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(name, secret), nil
+}
+
+func (r *EnvVarGroupRepo) PatchEnvVarGroup(ctx context.Context, authInfo authorization.Info, message PatchEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	secret, err := r.envVarGroupSecret(message.Name)
+	if err != nil {
+		return EnvVarGroupRecord{}, err
+	}
+
+	err = r.klient.Get(ctx, secret)
+	if k8serrors.IsNotFound(err) {
+		secret.Data = patchEnvVarGroupData(map[string][]byte{}, message.EnvironmentVariables)
+		if err = r.klient.Create(ctx, secret); err != nil {
+			return EnvVarGroupRecord{}, fmt.Errorf("failed to create environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+		}
+		return toEnvVarGroupRecord(message.Name, secret), nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	err = r.klient.Patch(ctx, secret, func() error {
+		secret.Data = patchEnvVarGroupData(secret.Data, message.EnvironmentVariables)
+		return nil
+	})
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to patch environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(message.Name, secret), nil
+}
+
+func (r *EnvVarGroupRepo) getEnvironmentVariables(ctx context.Context, name string) (map[string]string, error) {
+	secret, err := r.envVarGroupSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.privilegedClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if k8serrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment variable group %q: %w", name, err)
+	}
+
+	return convertByteSliceValuesToStrings(secret.Data), nil
+}
+
+func (r *EnvVarGroupRepo) envVarGroupSecret(name string) (*corev1.Secret, error) {
+	secretName, ok := envVarGroupSecretNames[name]
+	if !ok {
+		return nil, apierrors.NewNotFoundError(nil, EnvVarGroupResourceType)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      secretName,
+		},
+	}, nil
+}
+
+func patchEnvVarGroupData(data map[string][]byte, envVars map[string]*string) map[string][]byte {
+	if data == nil {
+		data = map[string][]byte{}
+	}
+
+	for k, v := range envVars {
+		if v == nil {
+			delete(data, k)
+		} else {
+			data[k] = []byte(*v)
+		}
+	}
+
+	return data
+}
+
+func toEnvVarGroupRecord(name string, secret *corev1.Secret) EnvVarGroupRecord {
+	return EnvVarGroupRecord{
+		Name:                 name,
+		EnvironmentVariables: convertByteSliceValuesToStrings(secret.Data),
+		UpdatedAt:            getLastUpdatedTime(secret),
+	}
+}
@@ -0,0 +1,161 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnvVarGroupRepo", func() {
+	var (
+		repo          *repositories.EnvVarGroupRepo
+		runningSecret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewEnvVarGroupRepo(klient, k8sClient, rootNamespace)
+
+		runningSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      korifiv1alpha1.RunningEnvVarGroupSecretName,
+			},
+		}
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, runningSecret))).To(Succeed())
+		})
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		var (
+			record repositories.EnvVarGroupRecord
+			getErr error
+			name   string
+		)
+
+		BeforeEach(func() {
+			name = repositories.RunningEnvVarGroupName
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetEnvVarGroup(ctx, authInfo, name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns an empty group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal(repositories.RunningEnvVarGroupName))
+				Expect(record.EnvironmentVariables).To(BeEmpty())
+			})
+
+			When("the group secret exists", func() {
+				BeforeEach(func() {
+					runningSecret.StringData = map[string]string{"HTTP_PROXY": "http://proxy"}
+					Expect(k8sClient.Create(ctx, runningSecret)).To(Succeed())
+				})
+
+				It("returns the group env vars", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.EnvironmentVariables).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy"}))
+					Expect(record.UpdatedAt).NotTo(BeNil())
+				})
+			})
+
+			When("the group does not exist", func() {
+				BeforeEach(func() {
+					name = "deploying"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("PatchEnvVarGroup", func() {
+		var (
+			record   repositories.EnvVarGroupRecord
+			patchErr error
+		)
+
+		JustBeforeEach(func() {
+			record, patchErr = repo.PatchEnvVarGroup(ctx, authInfo, repositories.PatchEnvVarGroupMessage{
+				Name: repositories.RunningEnvVarGroupName,
+				EnvironmentVariables: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://new-proxy"),
+					"NO_PROXY":   nil,
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the group secret", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(record.EnvironmentVariables).To(Equal(map[string]string{"HTTP_PROXY": "http://new-proxy"}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runningSecret), runningSecret)).To(Succeed())
+				Expect(runningSecret.Data).To(Equal(map[string][]byte{"HTTP_PROXY": []byte("http://new-proxy")}))
+			})
+
+			When("the group secret exists", func() {
+				BeforeEach(func() {
+					runningSecret.StringData = map[string]string{
+						"HTTP_PROXY": "http://proxy",
+						"NO_PROXY":   "localhost",
+						"OTHER":      "value",
+					}
+					Expect(k8sClient.Create(ctx, runningSecret)).To(Succeed())
+				})
+
+				It("merges the env vars, removing the null ones", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(record.EnvironmentVariables).To(Equal(map[string]string{
+						"HTTP_PROXY": "http://new-proxy",
+						"OTHER":      "value",
+					}))
+				})
+			})
+		})
+	})
+
+	It("stores the groups in secrets in the root namespace", func() {
+		createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		_, err := repo.PatchEnvVarGroup(ctx, authInfo, repositories.PatchEnvVarGroupMessage{
+			Name:                 repositories.StagingEnvVarGroupName,
+			EnvironmentVariables: map[string]*string{"FOO": tools.PtrTo("bar")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		stagingSecret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.StagingEnvVarGroupSecretName}, stagingSecret)).To(Succeed())
+		Expect(stagingSecret.Data).To(HaveKeyWithValue("FOO", []byte("bar")))
+		Expect(k8serrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(runningSecret), &corev1.Secret{}))).To(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, stagingSecret)).To(Succeed())
+	})
+})
@@ -11,6 +11,23 @@ import (
 )
 
 type ImagePusher struct {
+	CopyStub        func(context.Context, image.Creds, string, string, ...string) (string, error)
+	copyMutex       sync.RWMutex
+	copyArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}
+	copyReturns struct {
+		result1 string
+		result2 error
+	}
+	copyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	PushStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
 	pushMutex       sync.RWMutex
 	pushArgsForCall []struct {
@@ -28,10 +45,112 @@ type ImagePusher struct {
 		result1 string
 		result2 error
 	}
+	PushBuildpackageStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushBuildpackageMutex       sync.RWMutex
+	pushBuildpackageArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushBuildpackageReturns struct {
+		result1 string
+		result2 error
+	}
+	pushBuildpackageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PushTarballStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushTarballMutex       sync.RWMutex
+	pushTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushTarballReturns struct {
+		result1 string
+		result2 error
+	}
+	pushTarballReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImagePusher) Copy(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string, arg5 ...string) (string, error) {
+	fake.copyMutex.Lock()
+	ret, specificReturn := fake.copyReturnsOnCall[len(fake.copyArgsForCall)]
+	fake.copyArgsForCall = append(fake.copyArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CopyStub
+	fakeReturns := fake.copyReturns
+	fake.recordInvocation("Copy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.copyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) CopyCallCount() int {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	return len(fake.copyArgsForCall)
+}
+
+func (fake *ImagePusher) CopyCalls(stub func(context.Context, image.Creds, string, string, ...string) (string, error)) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = stub
+}
+
+func (fake *ImagePusher) CopyArgsForCall(i int) (context.Context, image.Creds, string, string, []string) {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	argsForCall := fake.copyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) CopyReturns(result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	fake.copyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) CopyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	if fake.copyReturnsOnCall == nil {
+		fake.copyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Push(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
 	fake.pushMutex.Lock()
 	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
@@ -100,11 +219,153 @@ func (fake *ImagePusher) PushReturnsOnCall(i int, result1 string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *ImagePusher) PushBuildpackage(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushBuildpackageMutex.Lock()
+	ret, specificReturn := fake.pushBuildpackageReturnsOnCall[len(fake.pushBuildpackageArgsForCall)]
+	fake.pushBuildpackageArgsForCall = append(fake.pushBuildpackageArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushBuildpackageStub
+	fakeReturns := fake.pushBuildpackageReturns
+	fake.recordInvocation("PushBuildpackage", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushBuildpackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushBuildpackageCallCount() int {
+	fake.pushBuildpackageMutex.RLock()
+	defer fake.pushBuildpackageMutex.RUnlock()
+	return len(fake.pushBuildpackageArgsForCall)
+}
+
+func (fake *ImagePusher) PushBuildpackageCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushBuildpackageMutex.Lock()
+	defer fake.pushBuildpackageMutex.Unlock()
+	fake.PushBuildpackageStub = stub
+}
+
+func (fake *ImagePusher) PushBuildpackageArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushBuildpackageMutex.RLock()
+	defer fake.pushBuildpackageMutex.RUnlock()
+	argsForCall := fake.pushBuildpackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushBuildpackageReturns(result1 string, result2 error) {
+	fake.pushBuildpackageMutex.Lock()
+	defer fake.pushBuildpackageMutex.Unlock()
+	fake.PushBuildpackageStub = nil
+	fake.pushBuildpackageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushBuildpackageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushBuildpackageMutex.Lock()
+	defer fake.pushBuildpackageMutex.Unlock()
+	fake.PushBuildpackageStub = nil
+	if fake.pushBuildpackageReturnsOnCall == nil {
+		fake.pushBuildpackageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushBuildpackageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushTarball(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushTarballMutex.Lock()
+	ret, specificReturn := fake.pushTarballReturnsOnCall[len(fake.pushTarballArgsForCall)]
+	fake.pushTarballArgsForCall = append(fake.pushTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushTarballStub
+	fakeReturns := fake.pushTarballReturns
+	fake.recordInvocation("PushTarball", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushTarballCallCount() int {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	return len(fake.pushTarballArgsForCall)
+}
+
+func (fake *ImagePusher) PushTarballCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = stub
+}
+
+func (fake *ImagePusher) PushTarballArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	argsForCall := fake.pushTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushTarballReturns(result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	fake.pushTarballReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushTarballReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	if fake.pushTarballReturnsOnCall == nil {
+		fake.pushTarballReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushTarballReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
 	fake.pushMutex.RLock()
 	defer fake.pushMutex.RUnlock()
+	fake.pushBuildpackageMutex.RLock()
+	defer fake.pushBuildpackageMutex.RUnlock()
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -23,11 +23,22 @@ const SourceImageResourceType = "SourceImage"
 
 type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	PushBuildpackage(ctx context.Context, creds image.Creds, repoRef string, archiveReader io.Reader, tags ...string) (string, error)
+	PushTarball(ctx context.Context, creds image.Creds, repoRef string, tarballReader io.Reader, tags ...string) (string, error)
+	Copy(ctx context.Context, creds image.Creds, srcRef string, repoRef string, tags ...string) (string, error)
+}
+
+//counterfeiter:generate -o fake -fake-name ImageDownloader . ImageDownloader
+
+type ImageDownloader interface {
+	Download(ctx context.Context, creds image.Creds, imageRef string, writer io.Writer) error
+	DownloadSource(ctx context.Context, creds image.Creds, imageRef string, writer io.Writer) error
 }
 
 type ImageRepository struct {
 	klient              Klient
 	pusher              ImagePusher
+	downloader          ImageDownloader
 	pushSecretNames     []string
 	pushSecretNamespace string
 }
@@ -35,19 +46,21 @@ type ImageRepository struct {
 func NewImageRepository(
 	klient Klient,
 	pusher ImagePusher,
+	downloader ImageDownloader,
 	pushSecretNames []string,
 	pushSecretNamespace string,
 ) *ImageRepository {
 	return &ImageRepository{
 		klient:              klient,
 		pusher:              pusher,
+		downloader:          downloader,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
 	}
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := canIPatch(ctx, r.klient, spaceGUID, "cfpackages", PackageResourceType)
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -72,19 +85,147 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+// CopySourceImage pushes the source image at srcRef to imageRef. The source
+// image is expected to be readable by the caller already.
+func (r *ImageRepository) CopySourceImage(ctx context.Context, authInfo authorization.Info, srcRef string, imageRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := canIPatch(ctx, r.klient, spaceGUID, "cfpackages", PackageResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy source image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	copiedRef, err := r.pusher.Copy(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, srcRef, imageRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcRef, imageRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+// DownloadSourceImage streams the source image as a zip archive. Only users
+// that can upload source to the space may download it.
+func (r *ImageRepository) DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error) {
+	authorized, err := canIPatch(ctx, r.klient, spaceGUID, "cfpackages", PackageResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download source image failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	if _, err = name.ParseReference(imageRef); err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(r.downloader.DownloadSource(ctx, image.Creds{
+			Namespace:   r.pushSecretNamespace,
+			SecretNames: r.pushSecretNames,
+		}, imageRef, pipeWriter))
+	}()
+
+	return pipeReader, nil
+}
+
+// UploadBuildpackImage pushes a buildpack archive as a buildpackage image.
+// Buildpacks live in the root namespace, alongside the push secrets.
+func (r *ImageRepository) UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, archiveReader io.Reader, tags ...string) (string, error) {
+	authorized, err := canIPatch(ctx, r.klient, r.pushSecretNamespace, "cfbuildpacks", BuildpackResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload buildpack image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuildpack"), BuildpackResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.pusher.PushBuildpackage(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef, archiveReader, tags...)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("failed to push buildpack: %s", err.Error()))
+	}
+
+	return pushedRef, nil
+}
+
+// UploadDropletImage pushes an image tarball as the droplet image of a build
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarballReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := canIPatch(ctx, r.klient, spaceGUID, "cfbuilds", DropletResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.pusher.PushTarball(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef, tarballReader, tags...)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("failed to push droplet: %s", err.Error()))
+	}
+
+	return pushedRef, nil
+}
+
+// DownloadDropletImage streams the droplet image as an image tarball. Errors
+// that occur while streaming are returned by the reader.
+func (r *ImageRepository) DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string) (io.ReadCloser, error) {
+	if _, err := name.ParseReference(imageRef); err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(r.downloader.Download(ctx, image.Creds{
+			Namespace:   r.pushSecretNamespace,
+			SecretNames: r.pushSecretNames,
+		}, imageRef, pipeWriter))
+	}()
+
+	return pipeReader, nil
+}
+
+func canIPatch(ctx context.Context, klient Klient, namespace string, resource string, resourceType string) (bool, error) {
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authv1.ResourceAttributes{
-				Namespace: spaceGUID,
+				Namespace: namespace,
 				Verb:      "patch",
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
-	if err := r.klient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+	if err := klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review for %s: %w", resource, apierrors.FromK8sError(err, resourceType))
 	}
 
 	return review.Status.Allowed, nil
@@ -2,6 +2,7 @@ package repositories_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 
@@ -9,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -45,6 +47,7 @@ var _ = Describe("ImageRepository", func() {
 		imageRepo = repositories.NewImageRepository(
 			klientUnfiltered,
 			imagePusher,
+			new(fake.ImageDownloader),
 			[]string{"push-secret-name"},
 			rootNamespace,
 		)
@@ -104,3 +107,331 @@ var _ = Describe("ImageRepository", func() {
 		})
 	})
 })
+
+var _ = Describe("ImageRepository.UploadBuildpackImage", func() {
+	var (
+		imagePusher   *fake.ImagePusher
+		archiveSource io.Reader
+		imageRepo     *repositories.ImageRepository
+		imageName     string
+		imageRef      string
+		uploadErr     error
+	)
+
+	BeforeEach(func() {
+		imageName = "my-buildpack-image"
+		imagePusher = new(fake.ImagePusher)
+		imagePusher.PushBuildpackageReturns("my-pushed-buildpack", nil)
+
+		archiveSource = bytes.NewBufferString("")
+
+		imageRepo = repositories.NewImageRepository(
+			klientUnfiltered,
+			imagePusher,
+			new(fake.ImageDownloader),
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+	})
+
+	JustBeforeEach(func() {
+		imageRef, uploadErr = imageRepo.UploadBuildpackImage(ctx, authInfo, imageName, archiveSource, "buildpack-guid")
+	})
+
+	It("fails with unauthorized error for users that are not admins", func() {
+		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		Expect(imagePusher.PushBuildpackageCallCount()).To(BeZero())
+	})
+
+	When("the user is a CF admin", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		It("pushes the buildpackage to the registry", func() {
+			Expect(uploadErr).NotTo(HaveOccurred())
+			Expect(imageRef).To(Equal("my-pushed-buildpack"))
+
+			Expect(imagePusher.PushBuildpackageCallCount()).To(Equal(1))
+			_, creds, actualRef, actualReader, actualTags := imagePusher.PushBuildpackageArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-buildpack-image"))
+			Expect(actualReader).To(Equal(archiveSource))
+			Expect(actualTags).To(ConsistOf("buildpack-guid"))
+		})
+
+		When("the archive is not a valid buildpack", func() {
+			BeforeEach(func() {
+				imagePusher.PushBuildpackageReturns("", errors.New("failed to read buildpack.toml"))
+			})
+
+			It("fails with an unprocessable entity error", func() {
+				var apiError apierrors.UnprocessableEntityError
+				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+				Expect(apiError.Detail()).To(ContainSubstring("failed to read buildpack.toml"))
+			})
+		})
+	})
+})
+
+var _ = Describe("ImageRepository.UploadDropletImage", func() {
+	var (
+		imagePusher   *fake.ImagePusher
+		tarballSource io.Reader
+		imageRepo     *repositories.ImageRepository
+		imageRef      string
+		uploadErr     error
+		space         *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		imagePusher = new(fake.ImagePusher)
+		imagePusher.PushTarballReturns("my-pushed-droplet", nil)
+
+		tarballSource = bytes.NewBufferString("")
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		imageRepo = repositories.NewImageRepository(
+			klientUnfiltered,
+			imagePusher,
+			new(fake.ImageDownloader),
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+	})
+
+	JustBeforeEach(func() {
+		imageRef, uploadErr = imageRepo.UploadDropletImage(ctx, authInfo, "my-droplet-image", tarballSource, space.Name, "droplet-guid")
+	})
+
+	It("fails with unauthorized error without a valid role in the space", func() {
+		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		Expect(imagePusher.PushTarballCallCount()).To(BeZero())
+	})
+
+	When("user has role SpaceDeveloper", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		It("pushes the droplet tarball to the registry", func() {
+			Expect(uploadErr).NotTo(HaveOccurred())
+			Expect(imageRef).To(Equal("my-pushed-droplet"))
+
+			Expect(imagePusher.PushTarballCallCount()).To(Equal(1))
+			_, creds, actualRef, actualReader, actualTags := imagePusher.PushTarballArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-droplet-image"))
+			Expect(actualReader).To(Equal(tarballSource))
+			Expect(actualTags).To(ConsistOf("droplet-guid"))
+		})
+
+		When("the tarball is not a valid image", func() {
+			BeforeEach(func() {
+				imagePusher.PushTarballReturns("", errors.New("failed to read image tarball"))
+			})
+
+			It("fails with an unprocessable entity error", func() {
+				var apiError apierrors.UnprocessableEntityError
+				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+				Expect(apiError.Detail()).To(ContainSubstring("failed to read image tarball"))
+			})
+		})
+	})
+})
+
+var _ = Describe("ImageRepository.DownloadDropletImage", func() {
+	var (
+		imageDownloader *fake.ImageDownloader
+		imageRepo       *repositories.ImageRepository
+		downloaded      []byte
+		downloadErr     error
+	)
+
+	BeforeEach(func() {
+		imageDownloader = new(fake.ImageDownloader)
+		imageDownloader.DownloadStub = func(_ context.Context, _ image.Creds, _ string, writer io.Writer) error {
+			_, err := writer.Write([]byte("droplet-tarball"))
+			return err
+		}
+
+		imageRepo = repositories.NewImageRepository(
+			klientUnfiltered,
+			new(fake.ImagePusher),
+			imageDownloader,
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+	})
+
+	JustBeforeEach(func() {
+		var reader io.ReadCloser
+		reader, downloadErr = imageRepo.DownloadDropletImage(ctx, authInfo, "my-droplet-image:my-tag")
+		if downloadErr != nil {
+			return
+		}
+		defer reader.Close()
+
+		downloaded, downloadErr = io.ReadAll(reader)
+	})
+
+	It("streams the droplet image tarball", func() {
+		Expect(downloadErr).NotTo(HaveOccurred())
+		Expect(string(downloaded)).To(Equal("droplet-tarball"))
+
+		Expect(imageDownloader.DownloadCallCount()).To(Equal(1))
+		_, creds, actualRef, _ := imageDownloader.DownloadArgsForCall(0)
+		Expect(creds.Namespace).To(Equal(rootNamespace))
+		Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+		Expect(actualRef).To(Equal("my-droplet-image:my-tag"))
+	})
+
+	When("downloading the image fails", func() {
+		BeforeEach(func() {
+			imageDownloader.DownloadStub = nil
+			imageDownloader.DownloadReturns(errors.New("download-error"))
+		})
+
+		It("returns the error from the reader", func() {
+			Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
+		})
+	})
+})
+
+var _ = Describe("ImageRepository.CopySourceImage", func() {
+	var (
+		imagePusher *fake.ImagePusher
+		imageRepo   *repositories.ImageRepository
+		space       *korifiv1alpha1.CFSpace
+		copiedRef   string
+		copyErr     error
+	)
+
+	BeforeEach(func() {
+		imagePusher = new(fake.ImagePusher)
+		imagePusher.CopyReturns("my-copied-image", nil)
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		imageRepo = repositories.NewImageRepository(
+			klientUnfiltered,
+			imagePusher,
+			new(fake.ImageDownloader),
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+	})
+
+	JustBeforeEach(func() {
+		copiedRef, copyErr = imageRepo.CopySourceImage(ctx, authInfo, "my-source-image:my-tag", "my-image", space.Name, "my-package")
+	})
+
+	It("fails with unauthorized error without a valid role in the space", func() {
+		Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	})
+
+	When("user has role SpaceDeveloper", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		It("copies the image in the registry", func() {
+			Expect(copyErr).NotTo(HaveOccurred())
+			Expect(copiedRef).To(Equal("my-copied-image"))
+
+			Expect(imagePusher.CopyCallCount()).To(Equal(1))
+			_, creds, srcRef, actualRef, actualTags := imagePusher.CopyArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(srcRef).To(Equal("my-source-image:my-tag"))
+			Expect(actualRef).To(Equal("my-image"))
+			Expect(actualTags).To(ConsistOf("my-package"))
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imagePusher.CopyReturns("", errors.New("boom"))
+			})
+
+			It("fails with a blobstore unavailable error", func() {
+				Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+			})
+		})
+	})
+})
+
+var _ = Describe("ImageRepository.DownloadSourceImage", func() {
+	var (
+		imageDownloader *fake.ImageDownloader
+		imageRepo       *repositories.ImageRepository
+		space           *korifiv1alpha1.CFSpace
+		downloaded      []byte
+		downloadErr     error
+	)
+
+	BeforeEach(func() {
+		imageDownloader = new(fake.ImageDownloader)
+		imageDownloader.DownloadSourceStub = func(_ context.Context, _ image.Creds, _ string, writer io.Writer) error {
+			_, err := writer.Write([]byte("source-zip"))
+			return err
+		}
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		imageRepo = repositories.NewImageRepository(
+			klientUnfiltered,
+			new(fake.ImagePusher),
+			imageDownloader,
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+	})
+
+	JustBeforeEach(func() {
+		var reader io.ReadCloser
+		reader, downloadErr = imageRepo.DownloadSourceImage(ctx, authInfo, "my-source-image:my-tag", space.Name)
+		if downloadErr != nil {
+			return
+		}
+		defer reader.Close()
+
+		downloaded, downloadErr = io.ReadAll(reader)
+	})
+
+	It("fails with unauthorized error without a valid role in the space", func() {
+		Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	})
+
+	When("user has role SpaceDeveloper", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		It("streams the source zip", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+			Expect(string(downloaded)).To(Equal("source-zip"))
+
+			Expect(imageDownloader.DownloadSourceCallCount()).To(Equal(1))
+			_, creds, actualRef, _ := imageDownloader.DownloadSourceArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-source-image:my-tag"))
+		})
+	})
+
+	When("user has role SpaceAuditor", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+		})
+
+		It("fails with unauthorized error", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+	})
+})
@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const IsolationSegmentResourceType = "Isolation Segment"
+
+type IsolationSegmentToleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+type IsolationSegmentPlacement struct {
+	NodeSelector map[string]string            `json:"node_selector"`
+	Tolerations  []IsolationSegmentToleration `json:"tolerations"`
+}
+
+type IsolationSegmentRecord struct {
+	GUID              string
+	Name              string
+	Placement         IsolationSegmentPlacement
+	OrganizationGUIDs []string
+	Labels            map[string]string
+	Annotations       map[string]string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	DeletedAt         *time.Time
+}
+
+func (r IsolationSegmentRecord) IsEntitled(orgGUID string) bool {
+	return slices.Contains(r.OrganizationGUIDs, orgGUID)
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name      string
+	Placement IsolationSegmentPlacement
+	Metadata  Metadata
+}
+
+type UpdateIsolationSegmentMessage struct {
+	GUID          string
+	Name          *string
+	Placement     *IsolationSegmentPlacement
+	MetadataPatch MetadataPatch
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+	Timestamps        TimestampFilters
+}
+
+func (m ListIsolationSegmentsMessage) matches(isolationSegment korifiv1alpha1.CFIsolationSegment) bool {
+	return tools.EmptyOrContains(m.GUIDs, isolationSegment.Name) &&
+		tools.EmptyOrContains(m.Names, isolationSegment.Spec.DisplayName) &&
+		(len(m.OrganizationGUIDs) == 0 || slices.ContainsFunc(isolationSegment.Spec.Organizations, func(o string) bool {
+			return slices.Contains(m.OrganizationGUIDs, o)
+		}))
+}
+
+type IsolationSegmentRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewIsolationSegmentRepo(
+	klient Klient,
+	rootNamespace string,
+) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: message.Name,
+			Placement:   toPlacement(message.Placement),
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfIsolationSegment); err != nil {
+		return IsolationSegmentRecord{}, toIsolationSegmentError(err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfIsolationSegment); err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to get isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) ([]IsolationSegmentRecord, error) {
+	cfIsolationSegmentList := &korifiv1alpha1.CFIsolationSegmentList{}
+	if err := r.klient.List(ctx, cfIsolationSegmentList, InNamespace(r.rootNamespace), WithTimestamps(message.Timestamps)); err != nil {
+		return nil, fmt.Errorf("failed to list isolation segments: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	records := slices.Collect(it.Map(
+		it.Filter(slices.Values(cfIsolationSegmentList.Items), message.matches),
+		toIsolationSegmentRecord,
+	))
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *IsolationSegmentRepo) UpdateIsolationSegment(ctx context.Context, authInfo authorization.Info, message UpdateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfIsolationSegment, func() error {
+		if message.Name != nil {
+			cfIsolationSegment.Spec.DisplayName = *message.Name
+		}
+		if message.Placement != nil {
+			cfIsolationSegment.Spec.Placement = toPlacement(*message.Placement)
+		}
+		message.MetadataPatch.Apply(cfIsolationSegment)
+		return nil
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, toIsolationSegmentError(err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	err := r.klient.Delete(ctx, &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	})
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func (r *IsolationSegmentRepo) EntitleOrganizations(ctx context.Context, authInfo authorization.Info, guid string, orgGUIDs []string) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfIsolationSegment, func() error {
+		for _, orgGUID := range orgGUIDs {
+			if !slices.Contains(cfIsolationSegment.Spec.Organizations, orgGUID) {
+				cfIsolationSegment.Spec.Organizations = append(cfIsolationSegment.Spec.Organizations, orgGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) RevokeOrganization(ctx context.Context, authInfo authorization.Info, guid string, orgGUID string) error {
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	if err := r.klient.List(ctx, cfSpaceList, InNamespace(orgGUID)); err != nil {
+		return fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	assignedSpaces := slices.Collect(it.Map(
+		it.Filter(slices.Values(cfSpaceList.Items), func(s korifiv1alpha1.CFSpace) bool {
+			return s.Spec.IsolationSegmentGUID == guid
+		}),
+		func(s korifiv1alpha1.CFSpace) string { return s.Spec.DisplayName },
+	))
+	if len(assignedSpaces) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot remove the entitlement while this Isolation Segment is assigned to any Spaces. Currently assigned to: %s",
+			strings.Join(assignedSpaces, ", "),
+		))
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfIsolationSegment, func() error {
+		cfIsolationSegment.Spec.Organizations = slices.DeleteFunc(cfIsolationSegment.Spec.Organizations, func(o string) bool {
+			return o == orgGUID
+		})
+		return nil
+	})
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func toIsolationSegmentError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func toPlacement(placement IsolationSegmentPlacement) korifiv1alpha1.Placement {
+	return korifiv1alpha1.Placement{
+		NodeSelector: placement.NodeSelector,
+		Tolerations: slices.Collect(it.Map(slices.Values(placement.Tolerations), func(t IsolationSegmentToleration) corev1.Toleration {
+			return corev1.Toleration{
+				Key:      t.Key,
+				Operator: corev1.TolerationOperator(t.Operator),
+				Value:    t.Value,
+				Effect:   corev1.TaintEffect(t.Effect),
+			}
+		})),
+	}
+}
+
+func toIsolationSegmentRecord(cfIsolationSegment korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	return IsolationSegmentRecord{
+		GUID: cfIsolationSegment.Name,
+		Name: cfIsolationSegment.Spec.DisplayName,
+		Placement: IsolationSegmentPlacement{
+			NodeSelector: cfIsolationSegment.Spec.Placement.NodeSelector,
+			Tolerations: slices.Collect(it.Map(slices.Values(cfIsolationSegment.Spec.Placement.Tolerations), func(t corev1.Toleration) IsolationSegmentToleration {
+				return IsolationSegmentToleration{
+					Key:      t.Key,
+					Operator: string(t.Operator),
+					Value:    t.Value,
+					Effect:   string(t.Effect),
+				}
+			})),
+		},
+		OrganizationGUIDs: cfIsolationSegment.Spec.Organizations,
+		Labels:            cfIsolationSegment.Labels,
+		Annotations:       cfIsolationSegment.Annotations,
+		CreatedAt:         cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(&cfIsolationSegment),
+		DeletedAt:         golangTime(cfIsolationSegment.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,319 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepo", func() {
+	var (
+		repo             *repositories.IsolationSegmentRepo
+		isolationSegment *korifiv1alpha1.CFIsolationSegment
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewIsolationSegmentRepo(klient, rootNamespace)
+
+		isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName: uuid.NewString(),
+				Placement: korifiv1alpha1.Placement{
+					NodeSelector: map[string]string{"pool": "regulated"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "regulated",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, isolationSegment)).To(Succeed())
+	})
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			record    repositories.IsolationSegmentRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateIsolationSegment(ctx, authInfo, repositories.CreateIsolationSegmentMessage{
+				Name: "my-segment",
+				Placement: repositories.IsolationSegmentPlacement{
+					NodeSelector: map[string]string{"pool": "mine"},
+					Tolerations: []repositories.IsolationSegmentToleration{{
+						Key:      "dedicated",
+						Operator: "Exists",
+						Effect:   "NoSchedule",
+					}},
+				},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFIsolationSegment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("my-segment"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      record.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.DisplayName).To(Equal("my-segment"))
+				Expect(cfIsolationSegment.Spec.Placement).To(Equal(korifiv1alpha1.Placement{
+					NodeSelector: map[string]string{"pool": "mine"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				}))
+			})
+		})
+	})
+
+	Describe("GetIsolationSegment", func() {
+		var (
+			record repositories.IsolationSegmentRecord
+			getErr error
+			guid   string
+		)
+
+		BeforeEach(func() {
+			guid = isolationSegment.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetIsolationSegment(ctx, authInfo, guid)
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(isolationSegment.Name))
+			Expect(record.Name).To(Equal(isolationSegment.Spec.DisplayName))
+			Expect(record.Placement).To(Equal(repositories.IsolationSegmentPlacement{
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []repositories.IsolationSegmentToleration{{
+					Key:      "dedicated",
+					Operator: "Equal",
+					Value:    "regulated",
+					Effect:   "NoSchedule",
+				}},
+			}))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				guid = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			records  []repositories.IsolationSegmentRecord
+			message  repositories.ListIsolationSegmentsMessage
+			otherSeg *korifiv1alpha1.CFIsolationSegment
+		)
+
+		BeforeEach(func() {
+			otherSeg = &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					DisplayName:   uuid.NewString(),
+					Organizations: []string{"org-guid"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, otherSeg)).To(Succeed())
+			message = repositories.ListIsolationSegmentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			records, err = repo.ListIsolationSegments(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(records).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(isolationSegment.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherSeg.Name)}),
+			))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{isolationSegment.Spec.DisplayName}
+			})
+
+			It("returns the matching isolation segments", func() {
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(isolationSegment.Name)})))
+			})
+		})
+
+		When("filtering by organization", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{"org-guid"}
+			})
+
+			It("returns the isolation segments entitled to the organization", func() {
+				Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherSeg.Name)})))
+			})
+		})
+	})
+
+	Describe("UpdateIsolationSegment", func() {
+		var (
+			record    repositories.IsolationSegmentRecord
+			updateErr error
+		)
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateIsolationSegment(ctx, authInfo, repositories.UpdateIsolationSegmentMessage{
+				GUID: isolationSegment.Name,
+				Name: tools.PtrTo("new-name"),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the name and metadata but keeps the placement", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(isolationSegment), isolationSegment)).To(Succeed())
+				Expect(isolationSegment.Spec.DisplayName).To(Equal("new-name"))
+				Expect(isolationSegment.Spec.Placement.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			})
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteIsolationSegment(ctx, authInfo, isolationSegment.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the isolation segment", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(isolationSegment), isolationSegment)).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("organization entitlements", func() {
+		var (
+			org   *korifiv1alpha1.CFOrg
+			space *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+		})
+
+		Describe("EntitleOrganizations", func() {
+			It("adds the organizations to the isolation segment", func() {
+				record, err := repo.EntitleOrganizations(ctx, authInfo, isolationSegment.Name, []string{org.Name, org.Name})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.OrganizationGUIDs).To(ConsistOf(org.Name))
+				Expect(record.IsEntitled(org.Name)).To(BeTrue())
+			})
+		})
+
+		Describe("RevokeOrganization", func() {
+			var revokeErr error
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, isolationSegment, func() {
+					isolationSegment.Spec.Organizations = []string{org.Name, "another-org"}
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				revokeErr = repo.RevokeOrganization(ctx, authInfo, isolationSegment.Name, org.Name)
+			})
+
+			It("removes the organization from the isolation segment", func() {
+				Expect(revokeErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(isolationSegment), isolationSegment)).To(Succeed())
+				Expect(isolationSegment.Spec.Organizations).To(ConsistOf("another-org"))
+			})
+
+			When("a space of the organization is assigned to the isolation segment", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, space, func() {
+						space.Spec.IsolationSegmentGUID = isolationSegment.Name
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(revokeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(revokeErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring(space.Spec.DisplayName))
+				})
+			})
+		})
+	})
+})
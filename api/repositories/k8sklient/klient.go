@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -60,7 +67,10 @@ func NewK8sKlient(
 	}
 }
 
-func (k *K8sKlient) Get(ctx context.Context, obj client.Object) error {
+func (k *K8sKlient) Get(ctx context.Context, obj client.Object) (err error) {
+	ctx, end := instrument(ctx, "get", obj)
+	defer end(&err)
+
 	authInfo, _ := authorization.InfoFromContext(ctx)
 
 	guid := obj.GetName()
@@ -77,17 +87,25 @@ func (k *K8sKlient) Get(ctx context.Context, obj client.Object) error {
 	return userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, obj)
 }
 
-func (k *K8sKlient) Create(ctx context.Context, obj client.Object) error {
+func (k *K8sKlient) Create(ctx context.Context, obj client.Object) (err error) {
+	ctx, end := instrument(ctx, "create", obj)
+	defer end(&err)
+
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	userClient, err := k.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	tracing.InjectIntoObject(ctx, obj)
+
 	return userClient.Create(ctx, obj)
 }
 
-func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func() error) error {
+func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func() error) (err error) {
+	ctx, end := instrument(ctx, "patch", obj)
+	defer end(&err)
+
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	userClient, err := k.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -101,6 +119,8 @@ func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func()
 		return err
 	}
 
+	tracing.InjectIntoObject(ctx, obj)
+
 	err = userClient.Patch(ctx, obj, client.MergeFrom(oldObject))
 	if err != nil {
 		return fmt.Errorf("failed to patch: %w", err)
@@ -109,7 +129,10 @@ func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func()
 	return nil
 }
 
-func (k *K8sKlient) List(ctx context.Context, list client.ObjectList, opts ...repositories.ListOption) error {
+func (k *K8sKlient) List(ctx context.Context, list client.ObjectList, opts ...repositories.ListOption) (err error) {
+	ctx, end := instrument(ctx, "list", list)
+	defer end(&err)
+
 	listOpts, err := unpackListOptions(opts...)
 	if err != nil {
 		return toStatusError(list, err)
@@ -149,11 +172,69 @@ func (k *K8sKlient) List(ctx context.Context, list client.ObjectList, opts ...re
 			return fmt.Errorf("failed to copy list items: %w", err)
 		}
 
+		return filterItems(list, listOpts.ObjectFilters)
+	}
+
+	if err = userClient.List(ctx, list, listOpts.AsClientListOptions()); err != nil {
+		return err
+	}
+
+	return filterItems(list, listOpts.ObjectFilters)
+}
+
+// instrument starts a span for a client call and returns a function that
+// ends it and records the call latency and failure in the metrics. The
+// function is meant to be deferred, hence the pointer to the returned error.
+func instrument(ctx context.Context, verb string, obj runtime.Object) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "k8s "+verb, trace.WithAttributes(
+		attribute.String("k8s.kind", reflect.TypeOf(obj).Elem().Name()),
+	))
+
+	return ctx, func(err *error) {
+		defer span.End()
+		metrics.K8sClientRequestDuration.WithLabelValues(verb).Observe(time.Since(start).Seconds())
+
+		if *err != nil {
+			span.SetStatus(codes.Error, (*err).Error())
+			metrics.K8sClientErrorsTotal.WithLabelValues(verb, string(k8serrors.ReasonForError(*err))).Inc()
+		}
+	}
+}
+
+func filterItems(list client.ObjectList, filters []func(client.Object) bool) error {
+	if len(filters) == 0 {
 		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
+	}
+
+	filteredItems := []runtime.Object{}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return fmt.Errorf("list item %T is not a client.Object", item)
+		}
 
+		if matchesAll(obj, filters) {
+			filteredItems = append(filteredItems, item)
+		}
 	}
 
-	return userClient.List(ctx, list, listOpts.AsClientListOptions())
+	return meta.SetList(list, filteredItems)
+}
+
+func matchesAll(obj client.Object, filters []func(client.Object) bool) bool {
+	for _, filter := range filters {
+		if !filter(obj) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func getObjectListItemsField(listObj client.ObjectList) (reflect.Value, error) {
@@ -220,7 +301,10 @@ func toStatusError(list client.ObjectList, err error) *k8serrors.StatusError {
 	}
 }
 
-func (k *K8sKlient) Watch(ctx context.Context, list client.ObjectList, opts ...repositories.ListOption) (watch.Interface, error) {
+func (k *K8sKlient) Watch(ctx context.Context, list client.ObjectList, opts ...repositories.ListOption) (_ watch.Interface, err error) {
+	ctx, end := instrument(ctx, "watch", list)
+	defer end(&err)
+
 	listOpts, err := unpackListOptions(opts...)
 	if err != nil {
 		return nil, toStatusError(list, err)
@@ -235,7 +319,10 @@ func (k *K8sKlient) Watch(ctx context.Context, list client.ObjectList, opts ...r
 	return userClient.Watch(ctx, list, listOpts.AsClientListOptions())
 }
 
-func (k *K8sKlient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+func (k *K8sKlient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, end := instrument(ctx, "delete", obj)
+	defer end(&err)
+
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	userClient, err := k.userClientFactory.BuildClient(authInfo)
 	if err != nil {
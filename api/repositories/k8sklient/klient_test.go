@@ -1,22 +1,32 @@
 package k8sklient_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	. "github.com/onsi/gomega/gstruct"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/metrics"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/tracing"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,6 +102,33 @@ var _ = Describe("Klient", func() {
 			})
 		})
 
+		Describe("metrics", func() {
+			var requestCountPre, errorCountPre uint64
+
+			BeforeEach(func() {
+				requestCountPre = getSampleCount(metrics.K8sClientRequestDuration, "get")
+				errorCountPre = uint64(testutil.ToFloat64(metrics.K8sClientErrorsTotal.WithLabelValues("get", string(metav1.StatusReasonNotFound))))
+			})
+
+			It("records the call latency", func() {
+				Expect(getSampleCount(metrics.K8sClientRequestDuration, "get")).To(Equal(requestCountPre + 1))
+			})
+
+			It("does not count an error", func() {
+				Expect(testutil.ToFloat64(metrics.K8sClientErrorsTotal.WithLabelValues("get", string(metav1.StatusReasonNotFound)))).To(BeEquivalentTo(errorCountPre))
+			})
+
+			When("the user client fails", func() {
+				BeforeEach(func() {
+					userClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "foo"))
+				})
+
+				It("counts the error by verb and reason", func() {
+					Expect(testutil.ToFloat64(metrics.K8sClientErrorsTotal.WithLabelValues("get", string(metav1.StatusReasonNotFound)))).To(BeEquivalentTo(errorCountPre + 1))
+				})
+			})
+		})
+
 		When("the object has no namespace", func() {
 			BeforeEach(func() {
 				obj = &korifiv1alpha1.CFApp{
@@ -189,6 +226,23 @@ var _ = Describe("Klient", func() {
 				Expect(err).To(MatchError(ContainSubstring("create-err")))
 			})
 		})
+
+		When("the request is traced", func() {
+			var span trace.Span
+
+			BeforeEach(func() {
+				provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracetest.NewSpanRecorder()))
+				ctx, span = provider.Tracer("test").Start(ctx, "request")
+			})
+
+			It("stamps the trace context onto the object", func() {
+				_, actualObject, _ := userClient.CreateArgsForCall(0)
+				Expect(actualObject.GetAnnotations()).To(HaveKeyWithValue(
+					tracing.TraceParentAnnotation,
+					ContainSubstring(span.SpanContext().TraceID().String()),
+				))
+			})
+		})
 	})
 
 	Describe("Patch", func() {
@@ -441,6 +495,36 @@ var _ = Describe("Klient", func() {
 			})
 		})
 
+		When("timestamp filters are requested", func() {
+			BeforeEach(func() {
+				userClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*korifiv1alpha1.CFAppList).Items = []korifiv1alpha1.CFApp{
+						{ObjectMeta: metav1.ObjectMeta{Name: "old-app", CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "new-app", CreationTimestamp: metav1.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))}},
+					}
+					return nil
+				}
+
+				listOpts = []repositories.ListOption{
+					repositories.WithTimestamps(repositories.TimestampFilters{
+						CreatedAts: []repositories.TimestampFilter{{
+							Operator: repositories.TimestampGreaterThan,
+							Values:   []time.Time{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+						}},
+					}),
+				}
+			})
+
+			It("filters the listed objects", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(objectList.Items).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal("new-app")}),
+					}),
+				))
+			})
+		})
+
 		When("a list option errors", func() {
 			BeforeEach(func() {
 				listOpt.ApplyToListReturns(errors.New("list-opt-err"))
@@ -574,3 +658,9 @@ func parseLabelSelector(selectorString string) labels.Selector {
 	Expect(err).NotTo(HaveOccurred())
 	return selector
 }
+
+func getSampleCount(histogram *prometheus.HistogramVec, labelValues ...string) uint64 {
+	metric := &dto.Metric{}
+	Expect(histogram.WithLabelValues(labelValues...).(prometheus.Histogram).Write(metric)).To(Succeed())
+	return metric.GetHistogram().GetSampleCount()
+}
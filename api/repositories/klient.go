@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"k8s.io/apimachinery/pkg/fields"
@@ -28,6 +29,9 @@ type ListOptions struct {
 	FieldSelector fields.Selector
 	Requrements   []labels.Requirement
 	Sort          *SortOpt
+	// Predicates that cannot be expressed as selectors, applied to the
+	// listed objects
+	ObjectFilters []func(client.Object) bool
 }
 
 func (o ListOptions) AsClientListOptions() *client.ListOptions {
@@ -191,3 +195,33 @@ func (o SortOpt) ApplyToList(opts *ListOptions) error {
 	opts.Sort = &o
 	return nil
 }
+
+type TimestampsOpt TimestampFilters
+
+func WithTimestamps(filters TimestampFilters) ListOption {
+	return TimestampsOpt(filters)
+}
+
+func (o TimestampsOpt) ApplyToList(opts *ListOptions) error {
+	for _, filter := range o.CreatedAts {
+		opts.ObjectFilters = append(opts.ObjectFilters, func(obj client.Object) bool {
+			return filter.Matches(obj.GetCreationTimestamp().Time)
+		})
+	}
+
+	for _, filter := range o.UpdatedAts {
+		opts.ObjectFilters = append(opts.ObjectFilters, func(obj client.Object) bool {
+			return filter.Matches(getUpdatedAt(obj))
+		})
+	}
+
+	return nil
+}
+
+func getUpdatedAt(obj client.Object) time.Time {
+	if updatedAt := getLastUpdatedTime(obj); updatedAt != nil {
+		return *updatedAt
+	}
+
+	return obj.GetCreationTimestamp().Time
+}
@@ -1,14 +1,19 @@
 package repositories_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/gomega/gstruct"
 )
@@ -262,6 +267,53 @@ var _ = Describe("Klient", func() {
 					})
 				})
 			})
+
+			Describe("WithTimestamps", func() {
+				var (
+					createdAt time.Time
+					obj       *korifiv1alpha1.CFApp
+				)
+
+				BeforeEach(func() {
+					createdAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+					obj = &korifiv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{
+							CreationTimestamp: metav1.NewTime(createdAt),
+							ManagedFields: []metav1.ManagedFieldsEntry{{
+								Time: tools.PtrTo(metav1.NewTime(createdAt.Add(time.Hour))),
+							}},
+						},
+					}
+
+					option = repositories.WithTimestamps(repositories.TimestampFilters{
+						CreatedAts: []repositories.TimestampFilter{{
+							Operator: repositories.TimestampLessThanOrEqual,
+							Values:   []time.Time{createdAt},
+						}},
+						UpdatedAts: []repositories.TimestampFilter{{
+							Operator: repositories.TimestampGreaterThan,
+							Values:   []time.Time{createdAt},
+						}},
+					})
+				})
+
+				It("adds object filters to the list options", func() {
+					Expect(applyToListErr).NotTo(HaveOccurred())
+					Expect(listOptions.ObjectFilters).To(HaveLen(2))
+					Expect(listOptions.ObjectFilters[0](obj)).To(BeTrue())
+					Expect(listOptions.ObjectFilters[1](obj)).To(BeTrue())
+				})
+
+				When("the object has not been updated since it was created", func() {
+					BeforeEach(func() {
+						obj.ManagedFields = nil
+					})
+
+					It("compares the updated timestamp against the creation timestamp", func() {
+						Expect(listOptions.ObjectFilters[1](obj)).To(BeFalse())
+					})
+				})
+			})
 		})
 	})
 })
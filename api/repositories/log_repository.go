@@ -19,12 +19,12 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const (
-	BuildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
-)
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 
 //counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
 type LogStreamer func(context.Context, k8sclient.Interface, corev1.Pod, corev1.PodLogOptions) (io.ReadCloser, error)
@@ -66,17 +66,20 @@ var descendingOrder logRecordSortOrder = func(r1, r2 LogRecord) int {
 
 type LogRepo struct {
 	klient               Klient
+	privilegedClient     client.Client
 	userClientsetFactory authorization.UserClientsetFactory
 	logStreamer          LogStreamer
 }
 
 func NewLogRepo(
 	klient Klient,
+	privilegedClient client.Client,
 	userClientsetFactory authorization.UserClientsetFactory,
 	logStreamer LogStreamer,
 ) *LogRepo {
 	return &LogRepo{
 		klient:               klient,
+		privilegedClient:     privilegedClient,
 		userClientsetFactory: userClientsetFactory,
 		logStreamer:          logStreamer,
 	}
@@ -93,7 +96,12 @@ func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, m
 		return nil, fmt.Errorf("failed to get app logs: %w", err)
 	}
 
-	logs := itx.From(buildLogs).Chain(appLogs).Filter(func(r LogRecord) bool {
+	retainedLogs, err := r.getRetainedLogs(ctx, message.App)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retained logs: %w", err)
+	}
+
+	logs := itx.From(buildLogs).Chain(appLogs, retainedLogs).Filter(func(r LogRecord) bool {
 		// Even though we have listed logs with `SinceTime` option, ensure that
 		// there are no log entries several milliseconds before the StartTime
 		// `SinceTime` log option has a precision of a second, therefore listed
@@ -106,6 +114,7 @@ func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, m
 		}
 		return r.Timestamp >= *message.StartTime
 	}).Collect()
+	logs = dedupeLogRecords(logs)
 
 	sortOrder := ascendingOrder
 	if message.Descending {
@@ -137,16 +146,14 @@ func (r *LogRepo) getBuildLogs(
 		startTime,
 		limit,
 		InNamespace(build.SpaceGUID),
-		WithLabel(BuildWorkloadLabelKey, build.GUID),
+		WithLabel(korifiv1alpha1.BuildWorkloadLabelKey, build.GUID),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return it.Map(logs, func(record LogRecord) LogRecord {
-		record.Tags = map[string]string{
-			"source_type": "STG",
-		}
+		record.Tags["source_type"] = "STG"
 		return record
 	}), nil
 }
@@ -172,13 +179,79 @@ func (r *LogRepo) getAppLogs(
 	}
 
 	return it.Map(logs, func(record LogRecord) LogRecord {
-		record.Tags = map[string]string{
-			"source_type": "APP",
-		}
+		record.Tags["source_type"] = "APP"
 		return record
 	}), nil
 }
 
+// getRetainedLogs returns the logs of staging and app containers that have
+// already terminated, as collected by the controllers. CF roles do not grant
+// access to config maps, so the logs are read with the privileged client. The
+// app record has been fetched on behalf of the user, which authorizes reading
+// its logs.
+func (r *LogRepo) getRetainedLogs(ctx context.Context, app AppRecord) (iter.Seq[LogRecord], error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.SpaceGUID,
+			Name:      tools.RetainedLogsConfigMapName(app.GUID),
+		},
+	}
+	if err := r.privilegedClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return it.Exhausted[LogRecord](), nil
+		}
+		return nil, err
+	}
+
+	retainedLogs, err := tools.FromRetainedLogsData(configMap.Data)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("ignoring corrupt retained logs", "reason", err)
+		return it.Exhausted[LogRecord](), nil
+	}
+
+	return it.Map(slices.Values(retainedLogs.Entries), func(entry tools.RetainedLogEntry) LogRecord {
+		tags := map[string]string{
+			"source_type": entry.SourceType,
+		}
+		if entry.InstanceID != "" {
+			tags["instance_id"] = entry.InstanceID
+		}
+
+		return LogRecord{
+			Message:   entry.Message,
+			Timestamp: entry.Timestamp,
+			Tags:      tags,
+		}
+	}), nil
+}
+
+// dedupeLogRecords drops retained log records that are also returned by
+// containers that are still around. Instances of the same process often log
+// identical lines at the same time, hence the instance is part of the key.
+func dedupeLogRecords(logs []LogRecord) []LogRecord {
+	type logKey struct {
+		timestamp  int64
+		message    string
+		sourceType string
+		instanceID string
+	}
+
+	seen := map[logKey]bool{}
+	return slices.DeleteFunc(logs, func(record LogRecord) bool {
+		key := logKey{
+			timestamp:  record.Timestamp,
+			message:    record.Message,
+			sourceType: record.Tags["source_type"],
+			instanceID: record.Tags["instance_id"],
+		}
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+		return false
+	})
+}
+
 func (r *LogRepo) getLogs(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -216,7 +289,13 @@ func (r *LogRepo) getLogsForPod(ctx context.Context, k8sClient k8sclient.Interfa
 		})
 	}))
 
-	return it.Chain(readyContainerLogs...)
+	return it.Map(it.Chain(readyContainerLogs...), func(record LogRecord) LogRecord {
+		record.Tags = map[string]string{}
+		if instanceID, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]; ok {
+			record.Tags["instance_id"] = instanceID
+		}
+		return record
+	})
 }
 
 func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) iter.Seq[LogRecord] {
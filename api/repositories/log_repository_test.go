@@ -49,7 +49,7 @@ var _ = Describe("LogRepository", func() {
 				Namespace: cfSpace.Name,
 				Name:      buildGUID,
 				Labels: map[string]string{
-					repositories.BuildWorkloadLabelKey: buildGUID,
+					korifiv1alpha1.BuildWorkloadLabelKey: buildGUID,
 				},
 			},
 			Spec: corev1.PodSpec{
@@ -118,7 +118,7 @@ var _ = Describe("LogRepository", func() {
 		}
 
 		userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(testEnv.Config)
-		logRepo = repositories.NewLogRepo(klientUnfiltered, userClientsetFactory, logStreamer.Spy)
+		logRepo = repositories.NewLogRepo(klientUnfiltered, k8sClient, userClientsetFactory, logStreamer.Spy)
 
 		message = repositories.GetLogsMessage{
 			App: repositories.AppRecord{
@@ -259,6 +259,57 @@ var _ = Describe("LogRepository", func() {
 			})
 		})
 
+		When("logs of terminated containers have been retained", func() {
+			BeforeEach(func() {
+				retainedLogs := tools.RetainedLogs{}
+				retainedLogs.Append("build-container-id",
+					tools.RetainedLogEntry{Timestamp: 500, SourceType: "STG", Message: "r0"},
+					tools.RetainedLogEntry{Timestamp: 1000, SourceType: "STG", Message: "b1"},
+				)
+				retainedLogs.Append("app-container-id",
+					tools.RetainedLogEntry{Timestamp: 1100, SourceType: "APP", InstanceID: "1", Message: "a1"},
+					tools.RetainedLogEntry{Timestamp: 1500, SourceType: "APP", InstanceID: "0", Message: "r1"},
+				)
+				data, err := retainedLogs.ToData()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      tools.RetainedLogsConfigMapName(message.App.GUID),
+					},
+					Data: data,
+				})).To(Succeed())
+			})
+
+			It("merges the retained log entries later than the start time, skipping duplicates", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logRecords).To(HaveLen(6))
+				Expect(logRecords[0]).To(matchLogRecord(1000, "b1", "STG"))
+				Expect(logRecords[1:3]).To(ConsistOf(
+					matchLogRecord(1100, "a1", "APP"),
+					Equal(repositories.LogRecord{
+						Message:   "a1",
+						Timestamp: 1100,
+						Tags: map[string]string{
+							"source_type": "APP",
+							"instance_id": "1",
+						},
+					}),
+				))
+				Expect(logRecords[3]).To(Equal(repositories.LogRecord{
+					Message:   "r1",
+					Timestamp: 1500,
+					Tags: map[string]string{
+						"source_type": "APP",
+						"instance_id": "0",
+					},
+				}))
+				Expect(logRecords[4]).To(matchLogRecord(2000, "b2", "STG"))
+				Expect(logRecords[5]).To(matchLogRecord(2100, "a2", "APP"))
+			})
+		})
+
 		When("descending is requested", func() {
 			BeforeEach(func() {
 				message.Descending = true
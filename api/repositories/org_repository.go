@@ -29,8 +29,9 @@ type CreateOrgMessage struct {
 }
 
 type ListOrgsMessage struct {
-	Names []string
-	GUIDs []string
+	Names      []string
+	GUIDs      []string
+	Timestamps TimestampFilters
 }
 
 func (m *ListOrgsMessage) toListOptions(rootNamespace string, authorizedOrgGuids []string) []ListOption {
@@ -46,6 +47,7 @@ func (m *ListOrgsMessage) toListOptions(rootNamespace string, authorizedOrgGuids
 		WithLabelIn(korifiv1alpha1.CFOrgDisplayNameKey, tools.EncodeValuesToSha224(m.Names...)),
 		WithLabel(korifiv1alpha1.ReadyLabelKey, string(metav1.ConditionTrue)),
 		InNamespace(rootNamespace),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
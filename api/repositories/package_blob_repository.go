@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+)
+
+// PackageBlobRepository stores package bits in the package blobstore instead
+// of the container registry
+type PackageBlobRepository struct {
+	klient Klient
+	store  blobstore.Store
+}
+
+func NewPackageBlobRepository(klient Klient, store blobstore.Store) *PackageBlobRepository {
+	return &PackageBlobRepository{
+		klient: klient,
+		store:  store,
+	}
+}
+
+func (r *PackageBlobRepository) UploadPackageBits(ctx context.Context, authInfo authorization.Info, packageGUID string, srcReader io.Reader, spaceGUID string) (string, error) {
+	if err := r.authorize(ctx, spaceGUID, "upload"); err != nil {
+		return "", err
+	}
+
+	blobURL, err := r.store.Put(ctx, packageBlobKey(packageGUID), srcReader)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("uploading package bits failed: %w", err))
+	}
+
+	return blobURL, nil
+}
+
+// CopyPackageBits copies the bits at srcBlobURL to the blob of the package.
+// The source blob is expected to be readable by the caller already.
+func (r *PackageBlobRepository) CopyPackageBits(ctx context.Context, authInfo authorization.Info, srcBlobURL string, packageGUID string, spaceGUID string) (string, error) {
+	if err := r.authorize(ctx, spaceGUID, "copy"); err != nil {
+		return "", err
+	}
+
+	blobURL, err := r.store.Copy(ctx, srcBlobURL, packageBlobKey(packageGUID))
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying package bits from %q failed: %w", srcBlobURL, err))
+	}
+
+	return blobURL, nil
+}
+
+// DownloadPackageBits returns the package bits zip. Only users that can
+// upload bits to the space may download them.
+func (r *PackageBlobRepository) DownloadPackageBits(ctx context.Context, authInfo authorization.Info, blobURL string, spaceGUID string) (io.ReadCloser, error) {
+	if err := r.authorize(ctx, spaceGUID, "download"); err != nil {
+		return nil, err
+	}
+
+	reader, err := r.store.Get(ctx, blobURL)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, apierrors.NewNotFoundError(err, PackageResourceType)
+		}
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading package bits failed: %w", err))
+	}
+
+	return reader, nil
+}
+
+func (r *PackageBlobRepository) authorize(ctx context.Context, spaceGUID string, action string) error {
+	authorized, err := canIPatch(ctx, r.klient, spaceGUID, "cfpackages", PackageResourceType)
+	if err != nil {
+		return fmt.Errorf("checking auth to %s package bits failed: %w", action, err)
+	}
+
+	if !authorized {
+		return apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	return nil
+}
+
+func packageBlobKey(packageGUID string) string {
+	return "packages/" + packageGUID + ".zip"
+}
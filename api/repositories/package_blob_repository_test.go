@@ -0,0 +1,181 @@
+package repositories_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	blobstorefake "code.cloudfoundry.org/korifi/tools/blobstore/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PackageBlobRepository", func() {
+	var (
+		store    *blobstorefake.Store
+		blobRepo *repositories.PackageBlobRepository
+		space    *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		store = new(blobstorefake.Store)
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		blobRepo = repositories.NewPackageBlobRepository(klientUnfiltered, store)
+	})
+
+	Describe("UploadPackageBits", func() {
+		var (
+			bits      io.Reader
+			blobURL   string
+			uploadErr error
+		)
+
+		BeforeEach(func() {
+			bits = strings.NewReader("my-bits")
+			store.PutReturns("https://blobstore.example.com/packages/my-package.zip", nil)
+		})
+
+		JustBeforeEach(func() {
+			blobURL, uploadErr = blobRepo.UploadPackageBits(ctx, authInfo, "my-package", bits, space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("uploads the bits to the blobstore", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(blobURL).To(Equal("https://blobstore.example.com/packages/my-package.zip"))
+
+				Expect(store.PutCallCount()).To(Equal(1))
+				_, key, reader := store.PutArgsForCall(0)
+				Expect(key).To(Equal("packages/my-package.zip"))
+				Expect(reader).To(Equal(bits))
+			})
+
+			When("uploading fails", func() {
+				BeforeEach(func() {
+					store.PutReturns("", errors.New("put-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("put-error")))
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+	})
+
+	Describe("CopyPackageBits", func() {
+		var (
+			blobURL string
+			copyErr error
+		)
+
+		BeforeEach(func() {
+			store.CopyReturns("https://blobstore.example.com/packages/my-package.zip", nil)
+		})
+
+		JustBeforeEach(func() {
+			blobURL, copyErr = blobRepo.CopyPackageBits(ctx, authInfo, "https://blobstore.example.com/packages/src-package.zip", "my-package", space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the blob", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(blobURL).To(Equal("https://blobstore.example.com/packages/my-package.zip"))
+
+				Expect(store.CopyCallCount()).To(Equal(1))
+				_, srcBlobURL, key := store.CopyArgsForCall(0)
+				Expect(srcBlobURL).To(Equal("https://blobstore.example.com/packages/src-package.zip"))
+				Expect(key).To(Equal("packages/my-package.zip"))
+			})
+
+			When("copying fails", func() {
+				BeforeEach(func() {
+					store.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DownloadPackageBits", func() {
+		var (
+			reader      io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			store.GetReturns(io.NopCloser(strings.NewReader("my-bits")), nil)
+		})
+
+		JustBeforeEach(func() {
+			reader, downloadErr = blobRepo.DownloadPackageBits(ctx, authInfo, "https://blobstore.example.com/packages/my-package.zip", space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the bits", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				Expect(io.ReadAll(reader)).To(BeEquivalentTo("my-bits"))
+
+				Expect(store.GetCallCount()).To(Equal(1))
+				_, blobURL := store.GetArgsForCall(0)
+				Expect(blobURL).To(Equal("https://blobstore.example.com/packages/my-package.zip"))
+			})
+
+			When("the blob does not exist", func() {
+				BeforeEach(func() {
+					store.GetReturns(nil, blobstore.ErrNotFound)
+				})
+
+				It("returns a not found error", func() {
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("downloading fails", func() {
+				BeforeEach(func() {
+					store.GetReturns(nil, errors.New("get-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+	})
+})
@@ -76,6 +76,12 @@ type PackageRecord struct {
 	Labels      map[string]string
 	Annotations map[string]string
 	ImageRef    string
+	// SourceImageRef is the image holding the package bits. It is empty for
+	// bits packages awaiting upload.
+	SourceImageRef string
+	// SourceBlobURL is the package blobstore blob holding the package bits,
+	// if the bits are not stored in the container registry
+	SourceBlobURL string
 }
 
 func (r PackageRecord) Relationships() map[string]string {
@@ -120,10 +126,11 @@ func PackageComparator(fieldName string) func(PackageRecord, PackageRecord) int
 }
 
 type ListPackagesMessage struct {
-	GUIDs    []string
-	AppGUIDs []string
-	States   []string
-	OrderBy  string
+	GUIDs      []string
+	AppGUIDs   []string
+	States     []string
+	OrderBy    string
+	Timestamps TimestampFilters
 }
 
 func (m *ListPackagesMessage) matches(p korifiv1alpha1.CFPackage) bool {
@@ -194,11 +201,19 @@ type UpdatePackageMessage struct {
 	MetadataPatch MetadataPatch
 }
 
+type DeletePackageMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
 type UpdatePackageSourceMessage struct {
 	GUID                string
 	SpaceGUID           string
 	ImageRef            string
 	RegistrySecretNames []string
+	// BlobURL is set instead of ImageRef when the bits are stored in the
+	// package blobstore
+	BlobURL string
 }
 
 func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.Info, message CreatePackageMessage) (PackageRecord, error) {
@@ -338,7 +353,7 @@ func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Inf
 
 func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.Info, message ListPackagesMessage) ([]PackageRecord, error) {
 	packageList := &korifiv1alpha1.CFPackageList{}
-	err := r.klient.List(ctx, packageList)
+	err := r.klient.List(ctx, packageList, WithTimestamps(message.Timestamps))
 	if err != nil {
 		return []PackageRecord{}, fmt.Errorf("failed to list packages: %w", apierrors.FromK8sError(err, PackageResourceType))
 	}
@@ -359,6 +374,11 @@ func (r *PackageRepo) UpdatePackageSource(ctx context.Context, authInfo authoriz
 	}
 
 	err := r.klient.Patch(ctx, cfPackage, func() error {
+		if message.BlobURL != "" {
+			cfPackage.Spec.Source.Blob = &korifiv1alpha1.Blob{URL: message.BlobURL}
+			return nil
+		}
+
 		cfPackage.Spec.Source.Registry.Image = message.ImageRef
 		cfPackage.Spec.Source.Registry.ImagePullSecrets = slices.Collect(
 			it.Map(slices.Values(message.RegistrySecretNames), func(secret string) corev1.LocalObjectReference {
@@ -380,26 +400,47 @@ func (r *PackageRepo) UpdatePackageSource(ctx context.Context, authInfo authoriz
 	return record, nil
 }
 
+func (r *PackageRepo) DeletePackage(ctx context.Context, authInfo authorization.Info, message DeletePackageMessage) error {
+	err := r.klient.Delete(ctx, &korifiv1alpha1.CFPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.GUID,
+		},
+	})
+
+	return apierrors.FromK8sError(err, PackageResourceType)
+}
+
 func (r *PackageRepo) cfPackageToPackageRecord(cfPackage korifiv1alpha1.CFPackage) PackageRecord {
 	state := PackageStateAwaitingUpload
 	if meta.IsStatusConditionTrue(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
 		state = PackageStateReady
 	}
 	return PackageRecord{
-		GUID:        cfPackage.Name,
-		UID:         cfPackage.UID,
-		SpaceGUID:   cfPackage.Namespace,
-		Type:        string(cfPackage.Spec.Type),
-		AppGUID:     cfPackage.Spec.AppRef.Name,
-		State:       state,
-		CreatedAt:   cfPackage.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfPackage),
-		Labels:      cfPackage.Labels,
-		Annotations: cfPackage.Annotations,
-		ImageRef:    r.repositoryRef(cfPackage),
+		GUID:           cfPackage.Name,
+		UID:            cfPackage.UID,
+		SpaceGUID:      cfPackage.Namespace,
+		Type:           string(cfPackage.Spec.Type),
+		AppGUID:        cfPackage.Spec.AppRef.Name,
+		State:          state,
+		CreatedAt:      cfPackage.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfPackage),
+		Labels:         cfPackage.Labels,
+		Annotations:    cfPackage.Annotations,
+		ImageRef:       r.repositoryRef(cfPackage),
+		SourceImageRef: cfPackage.Spec.Source.Registry.Image,
+		SourceBlobURL:  sourceBlobURL(cfPackage),
 	}
 }
 
+func sourceBlobURL(cfPackage korifiv1alpha1.CFPackage) string {
+	if cfPackage.Spec.Source.Blob == nil {
+		return ""
+	}
+
+	return cfPackage.Spec.Source.Blob.URL
+}
+
 func (r *PackageRepo) repositoryRef(cfPackage korifiv1alpha1.CFPackage) string {
 	if cfPackage.Spec.Type == "docker" {
 		return cfPackage.Spec.Source.Registry.Image
@@ -392,6 +392,7 @@ var _ = Describe("PackageRepository", func() {
 				Expect(packageRecord.Labels).To(HaveKeyWithValue("foo", "the-original-value"))
 				Expect(packageRecord.Annotations).To(HaveKeyWithValue("bar", "the-original-value"))
 				Expect(packageRecord.ImageRef).To(Equal(fmt.Sprintf("container.registry/foo/my/prefix-%s-packages", appGUID)))
+				Expect(packageRecord.SourceImageRef).To(BeEmpty())
 				Expect(packageRecord.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(packageRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
@@ -736,6 +737,7 @@ var _ = Describe("PackageRepository", func() {
 				Expect(returnedPackageRecord.Type).To(Equal(string(existingCFPackage.Spec.Type)))
 				Expect(returnedPackageRecord.AppGUID).To(Equal(existingCFPackage.Spec.AppRef.Name))
 				Expect(returnedPackageRecord.SpaceGUID).To(Equal(existingCFPackage.Namespace))
+				Expect(returnedPackageRecord.SourceImageRef).To(Equal(packageSourceImageRef))
 
 				Expect(returnedPackageRecord.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(returnedPackageRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
@@ -771,6 +773,27 @@ var _ = Describe("PackageRepository", func() {
 					})
 				})
 			})
+
+			When("the bits are stored in the package blobstore", func() {
+				BeforeEach(func() {
+					updateMessage = repositories.UpdatePackageSourceMessage{
+						GUID:      packageGUID,
+						SpaceGUID: space.Name,
+						BlobURL:   "https://blobstore.example.com/packages/" + packageGUID + ".zip",
+					}
+				})
+
+				It("sets the package blob source", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(returnedPackageRecord.SourceBlobURL).To(Equal("https://blobstore.example.com/packages/" + packageGUID + ".zip"))
+					Expect(returnedPackageRecord.SourceImageRef).To(BeEmpty())
+
+					Expect(updatedCFPackage.Spec.Source.Blob).To(PointTo(Equal(korifiv1alpha1.Blob{
+						URL: "https://blobstore.example.com/packages/" + packageGUID + ".zip",
+					})))
+					Expect(updatedCFPackage.Spec.Source.Registry.Image).To(BeEmpty())
+				})
+			})
 		})
 
 		When("user is not authorized to update a package", func() {
@@ -780,6 +803,54 @@ var _ = Describe("PackageRepository", func() {
 		})
 	})
 
+	Describe("DeletePackage", func() {
+		var (
+			cfPackage *korifiv1alpha1.CFPackage
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			cfPackage = &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					Type: "bits",
+					AppRef: corev1.LocalObjectReference{
+						Name: appGUID,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfPackage)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = packageRepo.DeletePackage(ctx, authInfo, repositories.DeletePackageMessage{
+				GUID:      cfPackage.Name,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("fails when the user is not auth'ed", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the package", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfPackage), cfPackage)
+					g.Expect(err).To(MatchError(ContainSubstring("not found")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("UpdatePackage", func() {
 		var (
 			packageGUID   string
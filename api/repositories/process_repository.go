@@ -107,6 +107,7 @@ type ListProcessesMessage struct {
 	AppGUIDs     []string
 	ProcessTypes []string
 	SpaceGUIDs   []string
+	Timestamps   TimestampFilters
 }
 
 func (m *ListProcessesMessage) toListOptions() []ListOption {
@@ -114,6 +115,7 @@ func (m *ListProcessesMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUIDs),
 		WithLabelIn(korifiv1alpha1.CFProcessTypeLabelKey, m.ProcessTypes),
 		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, m.SpaceGUIDs),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
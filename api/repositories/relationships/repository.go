@@ -36,6 +36,21 @@ type OrgRepository interface {
 	ListOrgs(context.Context, authorization.Info, repositories.ListOrgsMessage) ([]repositories.OrgRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name UserRepository . UserRepository
+type UserRepository interface {
+	ListUsers(context.Context, authorization.Info, repositories.ListUsersMessage) ([]repositories.UserRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name DomainRepository . DomainRepository
+type DomainRepository interface {
+	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) ([]repositories.DomainRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AppRepository . AppRepository
+type AppRepository interface {
+	ListApps(context.Context, authorization.Info, repositories.ListAppsMessage) ([]repositories.AppRecord, error)
+}
+
 //counterfeiter:generate -o fake -fake-name Resource . Resource
 type Resource interface {
 	Relationships() map[string]string
@@ -47,6 +62,9 @@ type ResourceRelationshipsRepo struct {
 	servicePlanRepo     ServicePlanRepository
 	spaceRepo           SpaceRepository
 	orgRepo             OrgRepository
+	userRepo            UserRepository
+	domainRepo          DomainRepository
+	appRepo             AppRepository
 }
 
 func NewResourseRelationshipsRepo(
@@ -55,6 +73,9 @@ func NewResourseRelationshipsRepo(
 	servicePlanRepo ServicePlanRepository,
 	spaceRepo SpaceRepository,
 	orgRepo OrgRepository,
+	userRepo UserRepository,
+	domainRepo DomainRepository,
+	appRepo AppRepository,
 ) *ResourceRelationshipsRepo {
 	return &ResourceRelationshipsRepo{
 		serviceOfferingRepo: serviceOfferingRepo,
@@ -62,6 +83,9 @@ func NewResourseRelationshipsRepo(
 		servicePlanRepo:     servicePlanRepo,
 		spaceRepo:           spaceRepo,
 		orgRepo:             orgRepo,
+		userRepo:            userRepo,
+		domainRepo:          domainRepo,
+		appRepo:             appRepo,
 	}
 }
 
@@ -109,6 +133,24 @@ func (r *ResourceRelationshipsRepo) ListRelatedResources(ctx context.Context, au
 			authInfo,
 			repositories.ListOrgsMessage{GUIDs: relatedResourceGUIDs},
 		))
+	case "user":
+		return asResources(r.userRepo.ListUsers(
+			ctx,
+			authInfo,
+			repositories.ListUsersMessage{GUIDs: relatedResourceGUIDs},
+		))
+	case "domain":
+		return asResources(r.domainRepo.ListDomains(
+			ctx,
+			authInfo,
+			repositories.ListDomainsMessage{GUIDs: relatedResourceGUIDs},
+		))
+	case "app":
+		return asResources(r.appRepo.ListApps(
+			ctx,
+			authInfo,
+			repositories.ListAppsMessage{Guids: relatedResourceGUIDs},
+		))
 	}
 
 	return nil, fmt.Errorf("no repository for type %q", relatedResourceType)
@@ -17,6 +17,9 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 		servicePlanRepo     *fake.ServicePlanRepository
 		spaceRepo           *fake.SpaceRepository
 		orgRepo             *fake.OrgRepository
+		userRepo            *fake.UserRepository
+		domainRepo          *fake.DomainRepository
+		appRepo             *fake.AppRepository
 		relationshipsRepo   relationships.ResourceRelationshipsRepo
 
 		resourceType   string
@@ -39,7 +42,10 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 		servicePlanRepo = new(fake.ServicePlanRepository)
 		spaceRepo = new(fake.SpaceRepository)
 		orgRepo = new(fake.OrgRepository)
-		relationshipsRepo = *relationships.NewResourseRelationshipsRepo(serviceOfferingRepo, serviceBrokerRepo, servicePlanRepo, spaceRepo, orgRepo)
+		userRepo = new(fake.UserRepository)
+		domainRepo = new(fake.DomainRepository)
+		appRepo = new(fake.AppRepository)
+		relationshipsRepo = *relationships.NewResourseRelationshipsRepo(serviceOfferingRepo, serviceBrokerRepo, servicePlanRepo, spaceRepo, orgRepo, userRepo, domainRepo, appRepo)
 	})
 
 	JustBeforeEach(func() {
@@ -229,4 +235,97 @@ var _ = Describe("ResourceRelationshipsRepository", func() {
 			})
 		})
 	})
+
+	Describe("resource type user", func() {
+		BeforeEach(func() {
+			resourceType = "user"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"user": "user-guid",
+			})
+
+			userRepo.ListUsersReturns([]repositories.UserRecord{{GUID: "user-guid"}}, nil)
+		})
+
+		It("returns a list of related users", func() {
+			Expect(listError).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(repositories.UserRecord{GUID: "user-guid"}))
+
+			Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+			_, _, actualMessage := userRepo.ListUsersArgsForCall(0)
+			Expect(actualMessage.GUIDs).To(ConsistOf("user-guid"))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				userRepo.ListUsersReturns(nil, errors.New("list-user-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-user-error"))
+			})
+		})
+	})
+
+	Describe("resource type domain", func() {
+		BeforeEach(func() {
+			resourceType = "domain"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"domain": "domain-guid",
+			})
+
+			domainRepo.ListDomainsReturns([]repositories.DomainRecord{{GUID: "domain-guid"}}, nil)
+		})
+
+		It("returns a list of related domains", func() {
+			Expect(listError).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(repositories.DomainRecord{GUID: "domain-guid"}))
+
+			Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+			_, _, actualMessage := domainRepo.ListDomainsArgsForCall(0)
+			Expect(actualMessage.GUIDs).To(ConsistOf("domain-guid"))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				domainRepo.ListDomainsReturns(nil, errors.New("list-domain-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-domain-error"))
+			})
+		})
+	})
+
+	Describe("resource type app", func() {
+		BeforeEach(func() {
+			resourceType = "app"
+
+			inputResource.RelationshipsReturns(map[string]string{
+				"app": "app-guid",
+			})
+
+			appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-guid"}}, nil)
+		})
+
+		It("returns a list of related apps", func() {
+			Expect(listError).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(repositories.AppRecord{GUID: "app-guid"}))
+
+			Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+			_, _, actualMessage := appRepo.ListAppsArgsForCall(0)
+			Expect(actualMessage.Guids).To(ConsistOf("app-guid"))
+		})
+
+		When("the underlying repo returns an error", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(nil, errors.New("list-app-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(listError).To(MatchError("list-app-error"))
+			})
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"fmt"
@@ -26,10 +27,14 @@ import (
 )
 
 const (
-	RoleGuidLabel         = "cloudfoundry.org/role-guid"
-	roleBindingNamePrefix = "cf"
-	cfUserRoleType        = "cf_user"
-	RoleResourceType      = "Role"
+	RoleGuidLabel = "cloudfoundry.org/role-guid"
+	// The subject of a role binding is the origin and the username joined
+	// with a ":", which is ambiguous when the origin is an issuer URL
+	RoleUsernameAnnotation = "cloudfoundry.org/username"
+	RoleOriginAnnotation   = "cloudfoundry.org/user-origin"
+	roleBindingNamePrefix  = "cf"
+	cfUserRoleType         = "cf_user"
+	RoleResourceType       = "Role"
 )
 
 //counterfeiter:generate -o fake -fake-name AuthorizedInChecker . AuthorizedInChecker
@@ -44,6 +49,8 @@ type CreateRoleMessage struct {
 	Space                   string
 	Org                     string
 	User                    string
+	Username                string
+	Origin                  string
 	Kind                    string
 	ServiceAccountNamespace string
 }
@@ -52,6 +59,9 @@ type DeleteRoleMessage struct {
 	GUID  string
 	Space string
 	Org   string
+	// Namespace locates roles that are neither org nor space roles, such as
+	// the cf_user role in the root namespace
+	Namespace string
 }
 
 type RoleRecord struct {
@@ -62,7 +72,10 @@ type RoleRecord struct {
 	Type      string
 	Space     string
 	Org       string
+	Namespace string
 	User      string
+	Username  string
+	Origin    string
 	Kind      string
 }
 
@@ -131,7 +144,8 @@ func RoleComparator(fieldName string) func(RoleRecord, RoleRecord) int {
 }
 
 type ListRolesMessage struct {
-	OrderBy string
+	OrderBy    string
+	Timestamps TimestampFilters
 }
 
 func NewRoleRepo(
@@ -180,7 +194,14 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 		ns = role.Org
 	}
 
+	if role.Kind == rbacv1.UserKind && role.Username == "" {
+		if err := r.resolveUser(ctx, &role); err != nil {
+			return RoleRecord{}, err
+		}
+	}
+
 	roleBinding := createRoleBinding(ns, role.Type, role.Kind, role.User, role.ServiceAccountNamespace, role.GUID, k8sRoleConfig.Name, k8sRoleConfig.Propagate)
+	maps.Copy(roleBinding.Annotations, userAnnotations(role))
 
 	err := r.klient.Create(ctx, &roleBinding)
 	if err != nil {
@@ -200,6 +221,7 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	}
 
 	cfUserRoleBinding := createRoleBinding(r.rootNamespace, cfUserRoleType, role.Kind, role.User, role.ServiceAccountNamespace, uuid.NewString(), cfUserk8sRoleConfig.Name, false)
+	maps.Copy(cfUserRoleBinding.Annotations, userAnnotations(role))
 	err = r.klient.Create(ctx, &cfUserRoleBinding)
 	if err != nil {
 		if !k8serrors.IsAlreadyExists(err) {
@@ -214,13 +236,52 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 		Type:      role.Type,
 		Space:     role.Space,
 		Org:       role.Org,
+		Namespace: ns,
 		User:      role.User,
+		Username:  role.Username,
+		Origin:    role.Origin,
 		Kind:      role.Kind,
 	}
 
 	return roleRecord, nil
 }
 
+// resolveUser looks up the username and origin of users that are referenced
+// by guid in the user registry. Unregistered users are named after their guid.
+func (r *RoleRepo) resolveUser(ctx context.Context, role *CreateRoleMessage) error {
+	role.Username = role.User
+
+	cfUser := &korifiv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      calculateUserObjectName(role.User),
+		},
+	}
+	if err := r.klient.Get(ctx, cfUser); err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user %q: %w", role.User, apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	role.Username = cfUser.Spec.Username
+	role.Origin = cfUser.Spec.Origin
+	return nil
+}
+
+func userAnnotations(role CreateRoleMessage) map[string]string {
+	if role.Username == "" {
+		return nil
+	}
+
+	annotations := map[string]string{RoleUsernameAnnotation: role.Username}
+	if role.Origin != "" {
+		annotations[RoleOriginAnnotation] = role.Origin
+	}
+
+	return annotations
+}
+
 func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleMessage, userIdentity authorization.Identity, authInfo authorization.Info) error {
 	space, err := r.spaceRepo.GetSpace(ctx, authInfo, role.Space)
 	if err != nil {
@@ -288,7 +349,7 @@ func (r *RoleRepo) ListRoles(ctx context.Context, authInfo authorization.Info, m
 	roleBindings := []rbacv1.RoleBinding{}
 	for _, ns := range authorizedNamespaces {
 		roleBindingsList := &rbacv1.RoleBindingList{}
-		err := r.klient.List(ctx, roleBindingsList, InNamespace(ns))
+		err := r.klient.List(ctx, roleBindingsList, InNamespace(ns), WithTimestamps(message.Timestamps))
 		if err != nil {
 			if k8serrors.IsForbidden(err) {
 				continue
@@ -329,6 +390,7 @@ func (r *RoleRepo) toRoleRecord(roleBinding rbacv1.RoleBinding) RoleRecord {
 		CreatedAt: roleBinding.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&roleBinding),
 		DeletedAt: golangTime(roleBinding.DeletionTimestamp),
+		Namespace: roleBinding.Namespace,
 		Type:      cfRoleName,
 		User:      roleBinding.Subjects[0].Name,
 		Kind:      roleBinding.Subjects[0].Kind,
@@ -338,6 +400,12 @@ func (r *RoleRepo) toRoleRecord(roleBinding rbacv1.RoleBinding) RoleRecord {
 		record.User = fmt.Sprintf("system:serviceaccount:%s:%s", roleBinding.Subjects[0].Namespace, roleBinding.Subjects[0].Name)
 	}
 
+	record.Username = roleBinding.Annotations[RoleUsernameAnnotation]
+	record.Origin = roleBinding.Annotations[RoleOriginAnnotation]
+	if record.Username == "" {
+		record.Username = record.User
+	}
+
 	switch r.roleMappings[cfRoleName].Level {
 	case config.OrgRole:
 		record.Org = roleBinding.Namespace
@@ -360,10 +428,7 @@ func (r *RoleRepo) GetRole(ctx context.Context, authInfo authorization.Info, rol
 }
 
 func (r *RoleRepo) DeleteRole(ctx context.Context, authInfo authorization.Info, deleteMsg DeleteRoleMessage) error {
-	ns := deleteMsg.Org
-	if ns == "" {
-		ns = deleteMsg.Space
-	}
+	ns := cmp.Or(deleteMsg.Org, deleteMsg.Space, deleteMsg.Namespace)
 
 	roleBindings := &rbacv1.RoleBindingList{}
 	err := r.klient.List(ctx, roleBindings,
@@ -102,6 +102,7 @@ type ListRoutesMessage struct {
 	Hosts       []string
 	Paths       []string
 	IsUnmapped  *bool
+	Timestamps  TimestampFilters
 }
 
 func (m *ListRoutesMessage) toListOptions() []ListOption {
@@ -110,6 +111,7 @@ func (m *ListRoutesMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, m.SpaceGUIDs),
 		WithLabelIn(korifiv1alpha1.CFRouteHostLabelKey, m.Hosts),
 		WithLabelIn(korifiv1alpha1.CFRoutePathLabelKey, tools.EncodeValuesToSha224(m.Paths...)),
+		WithTimestamps(m.Timestamps),
 	}
 
 	listOptions = append(listOptions, slices.Collect(it.Map(slices.Values(m.AppGUIDs), func(s string) ListOption {
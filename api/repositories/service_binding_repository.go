@@ -108,6 +108,7 @@ type ListServiceBindingsMessage struct {
 	LabelSelector        string
 	Type                 string
 	PlanGUIDs            []string
+	Timestamps           TimestampFilters
 }
 
 func (m *ListServiceBindingsMessage) matches(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
@@ -486,7 +487,7 @@ func (r *ServiceBindingRepo) GetDeletedAt(ctx context.Context, authInfo authoriz
 // nolint:dupl
 func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) ([]ServiceBindingRecord, error) {
 	serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
-	err := r.klient.List(ctx, serviceBindingList, WithLabelSelector(message.LabelSelector))
+	err := r.klient.List(ctx, serviceBindingList, WithLabelSelector(message.LabelSelector), WithTimestamps(message.Timestamps))
 	if err != nil {
 		return []ServiceBindingRecord{}, fmt.Errorf("failed to list service instances: %w",
 			apierrors.FromK8sError(err, ServiceBindingResourceType),
@@ -35,8 +35,9 @@ type CreateServiceBrokerMessage struct {
 }
 
 type ListServiceBrokerMessage struct {
-	Names []string
-	GUIDs []string
+	Names      []string
+	GUIDs      []string
+	Timestamps TimestampFilters
 }
 
 func (l ListServiceBrokerMessage) matches(b korifiv1alpha1.CFServiceBroker) bool {
@@ -178,7 +179,7 @@ func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization
 
 func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo authorization.Info, message ListServiceBrokerMessage) ([]ServiceBrokerRecord, error) {
 	brokersList := &korifiv1alpha1.CFServiceBrokerList{}
-	err := r.klient.List(ctx, brokersList, InNamespace(r.rootNamespace))
+	err := r.klient.List(ctx, brokersList, InNamespace(r.rootNamespace), WithTimestamps(message.Timestamps))
 	if err != nil {
 		// All authenticated users are allowed to list brokers. Therefore, the
 		// usual pattern of checking for forbidden error and return an empty
@@ -95,12 +95,13 @@ func NewServiceInstanceRepo(
 }
 
 type CreateUPSIMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]any
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name           string
+	SpaceGUID      string
+	Credentials    map[string]any
+	SyslogDrainURL *string
+	Tags           []string
+	Labels         map[string]string
+	Annotations    map[string]string
 }
 
 type CreateManagedSIMessage struct {
@@ -114,11 +115,12 @@ type CreateManagedSIMessage struct {
 }
 
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]any
-	Tags        *[]string
+	GUID           string
+	SpaceGUID      string
+	Name           *string
+	Credentials    *map[string]any
+	SyslogDrainURL *string
+	Tags           *[]string
 	MetadataPatch
 }
 
@@ -129,6 +131,12 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.SyslogDrainURL != nil {
+		cfServiceInstance.Spec.SyslogDrainURL = nil
+		if *p.SyslogDrainURL != "" {
+			cfServiceInstance.Spec.SyslogDrainURL = p.SyslogDrainURL
+		}
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -140,6 +148,7 @@ type ListServiceInstanceMessage struct {
 	LabelSelector string
 	OrderBy       string
 	PlanGUIDs     []string
+	Timestamps    TimestampFilters
 }
 
 func (m *ListServiceInstanceMessage) matches(serviceInstance korifiv1alpha1.CFServiceInstance) bool {
@@ -162,6 +171,7 @@ type ServiceInstanceRecord struct {
 	PlanGUID         string
 	Tags             []string
 	Type             string
+	SyslogDrainURL   *string
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -193,10 +203,11 @@ func (r *ServiceInstanceRepo) CreateUserProvidedServiceInstance(ctx context.Cont
 			Annotations: message.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: message.Name,
-			SecretName:  uuid.NewString(),
-			Type:        korifiv1alpha1.UserProvidedType,
-			Tags:        message.Tags,
+			DisplayName:    message.Name,
+			SecretName:     uuid.NewString(),
+			Type:           korifiv1alpha1.UserProvidedType,
+			Tags:           message.Tags,
+			SyslogDrainURL: message.SyslogDrainURL,
 		},
 	}
 	err := r.klient.Create(ctx, cfServiceInstance)
@@ -406,7 +417,7 @@ func (r *ServiceInstanceRepo) createCredentialsSecret(
 // nolint:dupl
 func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo authorization.Info, message ListServiceInstanceMessage) ([]ServiceInstanceRecord, error) {
 	serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
-	err := r.klient.List(ctx, serviceInstanceList, WithLabelSelector(message.LabelSelector))
+	err := r.klient.List(ctx, serviceInstanceList, WithLabelSelector(message.LabelSelector), WithTimestamps(message.Timestamps))
 	if err != nil {
 		return []ServiceInstanceRecord{}, fmt.Errorf("failed to list service instances: %w",
 			apierrors.FromK8sError(err, ServiceInstanceResourceType),
@@ -514,19 +525,20 @@ func (r *ServiceInstanceRepo) GetDeletedAt(ctx context.Context, authInfo authori
 
 func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
 	return ServiceInstanceRecord{
-		Name:          cfServiceInstance.Spec.DisplayName,
-		GUID:          cfServiceInstance.Name,
-		SpaceGUID:     cfServiceInstance.Namespace,
-		PlanGUID:      cfServiceInstance.Spec.PlanGUID,
-		Tags:          cfServiceInstance.Spec.Tags,
-		Type:          string(cfServiceInstance.Spec.Type),
-		Labels:        cfServiceInstance.Labels,
-		Annotations:   cfServiceInstance.Annotations,
-		CreatedAt:     cfServiceInstance.CreationTimestamp.Time,
-		UpdatedAt:     getLastUpdatedTime(&cfServiceInstance),
-		DeletedAt:     golangTime(cfServiceInstance.DeletionTimestamp),
-		LastOperation: cfServiceInstance.Status.LastOperation,
-		Ready:         isInstanceReady(cfServiceInstance),
+		Name:           cfServiceInstance.Spec.DisplayName,
+		GUID:           cfServiceInstance.Name,
+		SpaceGUID:      cfServiceInstance.Namespace,
+		PlanGUID:       cfServiceInstance.Spec.PlanGUID,
+		Tags:           cfServiceInstance.Spec.Tags,
+		Type:           string(cfServiceInstance.Spec.Type),
+		SyslogDrainURL: cfServiceInstance.Spec.SyslogDrainURL,
+		Labels:         cfServiceInstance.Labels,
+		Annotations:    cfServiceInstance.Annotations,
+		CreatedAt:      cfServiceInstance.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfServiceInstance),
+		DeletedAt:      golangTime(cfServiceInstance.DeletionTimestamp),
+		LastOperation:  cfServiceInstance.Status.LastOperation,
+		Ready:          isInstanceReady(cfServiceInstance),
 		MaintenanceInfo: MaintenanceInfo{
 			Version: cfServiceInstance.Status.MaintenanceInfo.Version,
 		},
@@ -81,7 +81,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Credentials: map[string]any{
 					"object": map[string]any{"a": "b"},
 				},
-				Tags: []string{"foo", "bar"},
+				Tags:           []string{"foo", "bar"},
+				SyslogDrainURL: tools.PtrTo("syslog://logs.example.com:514"),
 			}
 		})
 
@@ -106,6 +107,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(record.Name).To(Equal(serviceInstanceName))
 				Expect(record.Type).To(Equal("user-provided"))
 				Expect(record.Tags).To(ConsistOf([]string{"foo", "bar"}))
+				Expect(record.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
 				Expect(record.Relationships()).To(Equal(map[string]string{
 					"space": space.Name,
 				}))
@@ -129,6 +131,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.SecretName).NotTo(BeEmpty())
 				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.UserProvidedType))
 				Expect(cfServiceInstance.Spec.Tags).To(ConsistOf("foo", "bar"))
+				Expect(cfServiceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
 			})
 
 			It("creates the credentials secret", func() {
@@ -599,6 +602,35 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the syslog drain url is set", func() {
+				BeforeEach(func() {
+					patchMessage.SyslogDrainURL = tools.PtrTo("https://logs.example.com")
+				})
+
+				It("updates the syslog drain url", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.SyslogDrainURL).To(PointTo(Equal("https://logs.example.com")))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("https://logs.example.com")))
+				})
+
+				When("the syslog drain url is empty", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+							cfServiceInstance.Spec.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+						})).To(Succeed())
+						patchMessage.SyslogDrainURL = tools.PtrTo("")
+					})
+
+					It("clears the syslog drain url", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(serviceInstanceRecord.SyslogDrainURL).To(BeNil())
+					})
+				})
+			})
+
 			When("tags is nil", func() {
 				BeforeEach(func() {
 					patchMessage.Tags = nil
@@ -63,6 +63,7 @@ type ListServiceOfferingMessage struct {
 	Names       []string
 	GUIDs       []string
 	BrokerNames []string
+	Timestamps  TimestampFilters
 }
 
 type DeleteServiceOfferingMessage struct {
@@ -76,6 +77,7 @@ func (m *ListServiceOfferingMessage) toListOptions(rootNamespace string) []ListO
 		WithLabelIn(korifiv1alpha1.CFServiceOfferingNameKey, tools.EncodeValuesToSha224(m.Names...)),
 		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.GUIDs),
 		WithLabelIn(korifiv1alpha1.RelServiceBrokerNameLabel, tools.EncodeValuesToSha224(m.BrokerNames...)),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
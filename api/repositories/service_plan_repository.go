@@ -98,6 +98,7 @@ type ListServicePlanMessage struct {
 	BrokerNames          []string
 	BrokerGUIDs          []string
 	Available            *bool
+	Timestamps           TimestampFilters
 }
 
 func (m *ListServicePlanMessage) toListOptions(rootNamespace string) []ListOption {
@@ -109,6 +110,7 @@ func (m *ListServicePlanMessage) toListOptions(rootNamespace string) []ListOptio
 		WithLabelIn(korifiv1alpha1.RelServiceBrokerNameLabel, tools.EncodeValuesToSha224(m.BrokerNames...)),
 		WithLabelIn(korifiv1alpha1.RelServiceBrokerGUIDLabel, m.BrokerGUIDs),
 		WithLabelIn(korifiv1alpha1.RelServiceOfferingNameLabel, tools.EncodeValuesToSha224(m.ServiceOfferingNames...)),
+		WithTimestamps(m.Timestamps),
 		m.toAvailableListOption(),
 	}
 }
@@ -31,6 +31,7 @@ type ListSpacesMessage struct {
 	Names             []string
 	GUIDs             []string
 	OrganizationGUIDs []string
+	Timestamps        TimestampFilters
 }
 
 func (m *ListSpacesMessage) toListOptions(orgNs string, authorizedSpaceGuids []string) []ListOption {
@@ -46,6 +47,7 @@ func (m *ListSpacesMessage) toListOptions(orgNs string, authorizedSpaceGuids []s
 		WithLabelStrictlyIn(korifiv1alpha1.GUIDLabelKey, selectedGuids),
 		WithLabelIn(korifiv1alpha1.CFSpaceDisplayNameKey, tools.EncodeValuesToSha224(m.Names...)),
 		WithLabel(korifiv1alpha1.ReadyLabelKey, string(metav1.ConditionTrue)),
+		WithTimestamps(m.Timestamps),
 	}
 }
 
@@ -60,15 +62,35 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type PatchSpaceFeaturesMessage struct {
+	GUID       string
+	OrgGUID    string
+	SSHEnabled *bool
+}
+
+func (m PatchSpaceFeaturesMessage) Apply(space *korifiv1alpha1.CFSpace) {
+	if m.SSHEnabled != nil {
+		space.Spec.DisableSSH = !*m.SSHEnabled
+	}
+}
+
+type PatchSpaceIsolationSegmentMessage struct {
+	GUID                 string
+	OrgGUID              string
+	IsolationSegmentGUID string
+}
+
 type SpaceRecord struct {
-	Name             string
-	GUID             string
-	OrganizationGUID string
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	DeletedAt        *time.Time
+	Name                 string
+	GUID                 string
+	OrganizationGUID     string
+	Labels               map[string]string
+	Annotations          map[string]string
+	SSHEnabled           bool
+	IsolationSegmentGUID string
+	CreatedAt            time.Time
+	UpdatedAt            *time.Time
+	DeletedAt            *time.Time
 }
 
 func (r SpaceRecord) Relationships() map[string]string {
@@ -174,14 +196,16 @@ func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, space
 
 func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 	return SpaceRecord{
-		Name:             cfSpace.Spec.DisplayName,
-		GUID:             cfSpace.Name,
-		OrganizationGUID: cfSpace.Namespace,
-		Annotations:      cfSpace.Annotations,
-		Labels:           cfSpace.Labels,
-		CreatedAt:        cfSpace.CreationTimestamp.Time,
-		UpdatedAt:        getLastUpdatedTime(&cfSpace),
-		DeletedAt:        golangTime(cfSpace.DeletionTimestamp),
+		Name:                 cfSpace.Spec.DisplayName,
+		GUID:                 cfSpace.Name,
+		OrganizationGUID:     cfSpace.Namespace,
+		Annotations:          cfSpace.Annotations,
+		Labels:               cfSpace.Labels,
+		SSHEnabled:           !cfSpace.Spec.DisableSSH,
+		IsolationSegmentGUID: cfSpace.Spec.IsolationSegmentGUID,
+		CreatedAt:            cfSpace.CreationTimestamp.Time,
+		UpdatedAt:            getLastUpdatedTime(&cfSpace),
+		DeletedAt:            golangTime(cfSpace.DeletionTimestamp),
 	}
 }
 
@@ -219,6 +243,44 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(*cfSpace), nil
 }
 
+func (r *SpaceRepo) PatchSpaceFeatures(ctx context.Context, authInfo authorization.Info, message PatchSpaceFeaturesMessage) (SpaceRecord, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.OrgGUID,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfSpace, func() error {
+		message.Apply(cfSpace)
+		return nil
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
+func (r *SpaceRepo) PatchSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, message PatchSpaceIsolationSegmentMessage) (SpaceRecord, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.OrgGUID,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfSpace, func() error {
+		cfSpace.Spec.IsolationSegmentGUID = message.IsolationSegmentGUID
+		return nil
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
@@ -622,6 +622,46 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("PatchSpaceIsolationSegment", func() {
+		var (
+			cfOrg       *korifiv1alpha1.CFOrg
+			cfSpace     *korifiv1alpha1.CFSpace
+			spaceRecord repositories.SpaceRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, patchErr = spaceRepo.PatchSpaceIsolationSegment(ctx, authInfo, repositories.PatchSpaceIsolationSegmentMessage{
+				GUID:                 cfSpace.Name,
+				OrgGUID:              cfOrg.Name,
+				IsolationSegmentGUID: "segment-guid",
+			})
+		})
+
+		It("returns a forbidden error for unauthorized users", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("assigns the isolation segment to the space", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.IsolationSegmentGUID).To(Equal("segment-guid"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.IsolationSegmentGUID).To(Equal("segment-guid"))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const SSHCodeResourceType = "SSH Code"
+
+type CreateSSHCodeMessage struct {
+	User    string
+	Groups  []string
+	AppGUID string
+}
+
+type SSHCodeRecord struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
+// SSHCodeRepo issues the one-time codes users authenticate to the ssh proxy
+// with. Users cannot create secrets in the namespace dedicated to the codes,
+// so the codes are stored with the privileged client once the caller has
+// been authorized. The API is granted access to that namespace by the ssh
+// proxy helm templates.
+type SSHCodeRepo struct {
+	privilegedClient client.Client
+	codesNamespace   string
+	codeTTL          time.Duration
+}
+
+func NewSSHCodeRepo(privilegedClient client.Client, codesNamespace string, codeTTL time.Duration) *SSHCodeRepo {
+	return &SSHCodeRepo{
+		privilegedClient: privilegedClient,
+		codesNamespace:   codesNamespace,
+		codeTTL:          codeTTL,
+	}
+}
+
+func (r *SSHCodeRepo) CreateSSHCode(ctx context.Context, authInfo authorization.Info, message CreateSSHCodeMessage) (SSHCodeRecord, error) {
+	code, err := tools.NewSSHCode()
+	if err != nil {
+		return SSHCodeRecord{}, err
+	}
+
+	sshCode := tools.SSHCode{
+		User:      message.User,
+		Groups:    message.Groups,
+		AppGUID:   message.AppGUID,
+		ExpiresAt: time.Now().Add(r.codeTTL),
+	}
+
+	err = r.privilegedClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.codesNamespace,
+			Name:      tools.SSHCodeSecretName(code),
+			Labels: map[string]string{
+				tools.SSHCodeLabelKey: "true",
+			},
+		},
+		Data: sshCode.ToData(),
+	})
+	if err != nil {
+		return SSHCodeRecord{}, fmt.Errorf("failed to store ssh code: %w", apierrors.FromK8sError(err, SSHCodeResourceType))
+	}
+
+	return SSHCodeRecord{
+		Code:      code,
+		ExpiresAt: sshCode.ExpiresAt,
+	}, nil
+}
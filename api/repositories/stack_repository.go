@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -23,29 +28,67 @@ type StackRepository struct {
 	klient        Klient
 	builderName   string
 	rootNamespace string
+	defaultStack  string
 }
 
 type StackRecord struct {
-	GUID        string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	Name        string
-	Description string
+	// GUID is empty for the stacks of the default ClusterBuilder, which are
+	// not managed through the API
+	GUID             string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *time.Time
+	Name             string
+	Description      string
+	BuildRootfsImage string
+	RunRootfsImage   string
+	Default          bool
+	Labels           map[string]string
+	Annotations      map[string]string
+}
+
+type ListStacksMessage struct {
+	Names      []string
+	Timestamps TimestampFilters
+}
+
+func (m ListStacksMessage) matches(record StackRecord) bool {
+	return tools.EmptyOrContains(m.Names, record.Name) &&
+		m.Timestamps.Matches(record.CreatedAt, record.UpdatedAt)
+}
+
+type CreateStackMessage struct {
+	Name             string
+	Description      string
+	StackID          string
+	BuildRootfsImage string
+	RunRootfsImage   string
+	Metadata         Metadata
+}
+
+type UpdateStackMessage struct {
+	GUID             string
+	Description      *string
+	BuildRootfsImage *string
+	RunRootfsImage   *string
+	MetadataPatch    MetadataPatch
 }
 
 func NewStackRepository(
 	klient Klient,
 	builderName string,
 	rootNamespace string,
+	defaultStack string,
 ) *StackRepository {
 	return &StackRepository{
 		klient:        klient,
 		builderName:   builderName,
 		rootNamespace: rootNamespace,
+		defaultStack:  defaultStack,
 	}
 }
 
-func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization.Info) ([]StackRecord, error) {
+func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization.Info, message ListStacksMessage) ([]StackRecord, error) {
 	builderInfo := &korifiv1alpha1.BuilderInfo{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.rootNamespace,
@@ -62,16 +105,144 @@ func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization
 		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready", r.builderName))
 	}
 
-	return builderInfoToStackRecords(*builderInfo), nil
+	cfStackList := &korifiv1alpha1.CFStackList{}
+	if err = r.klient.List(ctx, cfStackList, InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	stackRecords := slices.Collect(it.Map(slices.Values(cfStackList.Items), r.cfStackToStackRecord))
+	sort.Slice(stackRecords, func(i, j int) bool {
+		return stackRecords[i].CreatedAt.Before(stackRecords[j].CreatedAt)
+	})
+
+	// the BuilderInfo reports the stacks of the CFStacks as well, only the
+	// ones of the default ClusterBuilder are missing
+	builderStackRecords := slices.DeleteFunc(r.builderInfoToStackRecords(*builderInfo), func(builderStack StackRecord) bool {
+		return slices.ContainsFunc(stackRecords, func(stack StackRecord) bool {
+			return strings.EqualFold(stack.Name, builderStack.Name)
+		})
+	})
+
+	return slices.Collect(it.Filter(slices.Values(append(builderStackRecords, stackRecords...)), message.matches)), nil
+}
+
+func (r *StackRepository) GetStack(ctx context.Context, authInfo authorization.Info, guid string) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfStack); err != nil {
+		return StackRecord{}, fmt.Errorf("failed to get stack: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return r.cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) CreateStack(ctx context.Context, authInfo authorization.Info, message CreateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFStackSpec{
+			DisplayName: message.Name,
+			Description: message.Description,
+			ID:          message.StackID,
+			BuildImage:  message.BuildRootfsImage,
+			RunImage:    message.RunRootfsImage,
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfStack); err != nil {
+		return StackRecord{}, toStackError(err)
+	}
+
+	return r.cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) UpdateStack(ctx context.Context, authInfo authorization.Info, message UpdateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfStack, func() error {
+		if message.Description != nil {
+			cfStack.Spec.Description = *message.Description
+		}
+		if message.BuildRootfsImage != nil {
+			cfStack.Spec.BuildImage = *message.BuildRootfsImage
+		}
+		if message.RunRootfsImage != nil {
+			cfStack.Spec.RunImage = *message.RunRootfsImage
+		}
+		message.MetadataPatch.Apply(cfStack)
+		return nil
+	})
+	if err != nil {
+		return StackRecord{}, apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	return r.cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	err := r.klient.Delete(ctx, &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	})
+
+	return apierrors.FromK8sError(err, StackResourceType)
+}
+
+func (r *StackRepository) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	stack, err := r.GetStack(ctx, authInfo, guid)
+	return stack.DeletedAt, err
+}
+
+func toStackError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, StackResourceType)
+}
+
+func (r *StackRepository) cfStackToStackRecord(cfStack korifiv1alpha1.CFStack) StackRecord {
+	return StackRecord{
+		GUID:             cfStack.Name,
+		CreatedAt:        cfStack.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfStack),
+		DeletedAt:        golangTime(cfStack.DeletionTimestamp),
+		Name:             cfStack.Spec.DisplayName,
+		Description:      cfStack.Spec.Description,
+		BuildRootfsImage: cfStack.Spec.BuildImage,
+		RunRootfsImage:   cfStack.Spec.RunImage,
+		Default:          strings.EqualFold(cfStack.Spec.DisplayName, r.defaultStack),
+		Labels:           cfStack.Labels,
+		Annotations:      cfStack.Annotations,
+	}
 }
 
-func builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
+func (r *StackRepository) builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
 	return slices.Collect(it.Map(slices.Values(info.Status.Stacks), func(s korifiv1alpha1.BuilderInfoStatusStack) StackRecord {
 		return StackRecord{
 			Name:        s.Name,
 			Description: s.Description,
 			CreatedAt:   s.CreationTimestamp.Time,
 			UpdatedAt:   &s.UpdatedTimestamp.Time,
+			Default:     strings.EqualFold(s.Name, r.defaultStack),
 		}
 	}))
 }
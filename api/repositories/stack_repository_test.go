@@ -0,0 +1,300 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("StackRepository", func() {
+	var stackRepo *StackRepository
+
+	BeforeEach(func() {
+		stackRepo = NewStackRepository(klientUnfiltered, builderName, rootNamespace, "cflinuxfs4")
+	})
+
+	Describe("ListStacks", func() {
+		var (
+			message   ListStacksMessage
+			stacks    []StackRecord
+			listErr   error
+			jammy     *korifiv1alpha1.CFStack
+			cflinuxfs *korifiv1alpha1.CFStack
+		)
+
+		BeforeEach(func() {
+			message = ListStacksMessage{}
+			createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", nil)
+			jammy = createCFStack(ctx, "io.buildpacks.stacks.jammy")
+			cflinuxfs = createCFStack(ctx, "cflinuxfs4")
+		})
+
+		JustBeforeEach(func() {
+			stacks, listErr = stackRepo.ListStacks(ctx, authInfo, message)
+		})
+
+		It("returns the stacks of the default builder followed by the CFStacks", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(stacks).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":    BeEmpty(),
+					"Name":    Equal("io.buildpacks.stacks.bionic"),
+					"Default": BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":             Equal(jammy.Name),
+					"Name":             Equal("io.buildpacks.stacks.jammy"),
+					"BuildRootfsImage": Equal("my.repository/build:io.buildpacks.stacks.jammy"),
+					"RunRootfsImage":   Equal("my.repository/run:io.buildpacks.stacks.jammy"),
+					"Default":          BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":    Equal(cflinuxfs.Name),
+					"Name":    Equal("cflinuxfs4"),
+					"Default": BeTrue(),
+				}),
+			))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"cflinuxfs4"}
+			})
+
+			It("returns the matching stacks only", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(stacks).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(cflinuxfs.Name),
+				})))
+			})
+		})
+	})
+
+	Describe("CreateStack", func() {
+		var (
+			record    StackRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = stackRepo.CreateStack(ctx, authInfo, CreateStackMessage{
+				Name:             "cflinuxfs4",
+				Description:      "Ubuntu Jammy",
+				StackID:          "io.buildpacks.stacks.jammy",
+				BuildRootfsImage: "my.repository/build:jammy",
+				RunRootfsImage:   "my.repository/run:jammy",
+				Metadata: Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFStack", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("cflinuxfs4"))
+				Expect(record.Description).To(Equal("Ubuntu Jammy"))
+				Expect(record.Default).To(BeTrue())
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfStack := &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      record.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				Expect(cfStack.Spec).To(Equal(korifiv1alpha1.CFStackSpec{
+					DisplayName: "cflinuxfs4",
+					Description: "Ubuntu Jammy",
+					ID:          "io.buildpacks.stacks.jammy",
+					BuildImage:  "my.repository/build:jammy",
+					RunImage:    "my.repository/run:jammy",
+				}))
+			})
+		})
+	})
+
+	Describe("GetStack", func() {
+		var (
+			cfStack *korifiv1alpha1.CFStack
+			record  StackRecord
+			getErr  error
+			guid    string
+		)
+
+		BeforeEach(func() {
+			cfStack = createCFStack(ctx, "cflinuxfs4")
+			guid = cfStack.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = stackRepo.GetStack(ctx, authInfo, guid)
+		})
+
+		It("returns the stack", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(cfStack.Name))
+			Expect(record.Name).To(Equal("cflinuxfs4"))
+			Expect(record.RunRootfsImage).To(Equal("my.repository/run:cflinuxfs4"))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				guid = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateStack", func() {
+		var (
+			cfStack   *korifiv1alpha1.CFStack
+			record    StackRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			cfStack = createCFStack(ctx, "cflinuxfs4")
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = stackRepo.UpdateStack(ctx, authInfo, UpdateStackMessage{
+				GUID:           cfStack.Name,
+				RunRootfsImage: tools.PtrTo("my.repository/run:patched"),
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the stack", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.RunRootfsImage).To(Equal("my.repository/run:patched"))
+				Expect(record.BuildRootfsImage).To(Equal("my.repository/build:cflinuxfs4"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				Expect(cfStack.Spec.RunImage).To(Equal("my.repository/run:patched"))
+			})
+		})
+	})
+
+	Describe("DeleteStack", func() {
+		var (
+			cfStack   *korifiv1alpha1.CFStack
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			cfStack = createCFStack(ctx, "cflinuxfs4")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = stackRepo.DeleteStack(ctx, authInfo, cfStack.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the stack", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("GetDeletedAt", func() {
+		var (
+			cfStack   *korifiv1alpha1.CFStack
+			deletedAt *time.Time
+			getErr    error
+		)
+
+		BeforeEach(func() {
+			cfStack = createCFStack(ctx, "cflinuxfs4")
+		})
+
+		JustBeforeEach(func() {
+			deletedAt, getErr = stackRepo.GetDeletedAt(ctx, authInfo, cfStack.Name)
+		})
+
+		It("returns nil", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(deletedAt).To(BeNil())
+		})
+
+		When("the stack is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfStack, func() {
+					cfStack.Finalizers = append(cfStack.Finalizers, "foo")
+				})).To(Succeed())
+
+				Expect(k8sClient.Delete(ctx, cfStack)).To(Succeed())
+			})
+
+			It("returns the deletion time", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(deletedAt).To(PointTo(BeTemporally("~", time.Now(), time.Minute)))
+			})
+		})
+	})
+})
+
+func createCFStack(ctx context.Context, displayName string) *korifiv1alpha1.CFStack {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFStackSpec{
+			DisplayName: displayName,
+			ID:          "io.buildpacks.stacks.jammy",
+			BuildImage:  "my.repository/build:" + displayName,
+			RunImage:    "my.repository/run:" + displayName,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfStack)).To(Succeed())
+
+	return cfStack
+}
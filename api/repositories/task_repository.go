@@ -63,6 +63,7 @@ type CreateTaskMessage struct {
 type ListTaskMessage struct {
 	AppGUIDs    []string
 	SequenceIDs []int64
+	Timestamps  TimestampFilters
 }
 
 func (m *ListTaskMessage) matches(task korifiv1alpha1.CFTask) bool {
@@ -154,7 +155,7 @@ func (r *TaskRepo) awaitCondition(ctx context.Context, task *korifiv1alpha1.CFTa
 
 func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, msg ListTaskMessage) ([]TaskRecord, error) {
 	taskList := &korifiv1alpha1.CFTaskList{}
-	err := r.klient.List(ctx, taskList)
+	err := r.klient.List(ctx, taskList, WithTimestamps(msg.Timestamps))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", apierrors.FromK8sError(err, TaskResourceType))
 	}
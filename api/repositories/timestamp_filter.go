@@ -0,0 +1,77 @@
+package repositories
+
+import "time"
+
+type TimestampOperator string
+
+const (
+	TimestampEqual              TimestampOperator = ""
+	TimestampGreaterThan        TimestampOperator = "gt"
+	TimestampGreaterThanOrEqual TimestampOperator = "gte"
+	TimestampLessThan           TimestampOperator = "lt"
+	TimestampLessThanOrEqual    TimestampOperator = "lte"
+)
+
+type TimestampFilter struct {
+	Operator TimestampOperator
+	Values   []time.Time
+}
+
+// Matches compares timestamps at second granularity, as this is the
+// resolution timestamps are presented with
+func (f TimestampFilter) Matches(t time.Time) bool {
+	t = t.Truncate(time.Second)
+
+	switch f.Operator {
+	case TimestampGreaterThan:
+		return t.After(f.Values[0])
+	case TimestampGreaterThanOrEqual:
+		return !t.Before(f.Values[0])
+	case TimestampLessThan:
+		return t.Before(f.Values[0])
+	case TimestampLessThanOrEqual:
+		return !t.After(f.Values[0])
+	}
+
+	for _, value := range f.Values {
+		if t.Equal(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TimestampFilters filter list results by the created_at and updated_at
+// timestamps of the resources
+type TimestampFilters struct {
+	CreatedAts []TimestampFilter
+	UpdatedAts []TimestampFilter
+}
+
+func (f TimestampFilters) IsEmpty() bool {
+	return len(f.CreatedAts) == 0 && len(f.UpdatedAts) == 0
+}
+
+// Matches checks records that are not backed by a single k8s object, such as
+// the users derived from role bindings. Records that have never been updated
+// are matched on their creation time, as the objects the list options filter.
+func (f TimestampFilters) Matches(createdAt time.Time, updatedAt *time.Time) bool {
+	if updatedAt == nil {
+		updatedAt = &createdAt
+	}
+
+	for _, filter := range f.CreatedAts {
+		if !filter.Matches(createdAt) {
+			return false
+		}
+	}
+
+	for _, filter := range f.UpdatedAts {
+		if !filter.Matches(*updatedAt) {
+			return false
+		}
+	}
+
+	return true
+}
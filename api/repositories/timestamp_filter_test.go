@@ -0,0 +1,62 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TimestampFilter", func() {
+	var (
+		t1 = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		t2 = time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	DescribeTable("Matches",
+		func(filter repositories.TimestampFilter, t time.Time, match bool) {
+			Expect(filter.Matches(t)).To(Equal(match))
+		},
+		Entry("equal", repositories.TimestampFilter{Values: []time.Time{t1, t2}}, t2, true),
+		Entry("equal with sub-second precision", repositories.TimestampFilter{Values: []time.Time{t1}}, t1.Add(500*time.Millisecond), true),
+		Entry("not equal", repositories.TimestampFilter{Values: []time.Time{t1}}, t2, false),
+		Entry("greater than", repositories.TimestampFilter{Operator: repositories.TimestampGreaterThan, Values: []time.Time{t1}}, t2, true),
+		Entry("not greater than", repositories.TimestampFilter{Operator: repositories.TimestampGreaterThan, Values: []time.Time{t1}}, t1, false),
+		Entry("greater than or equal", repositories.TimestampFilter{Operator: repositories.TimestampGreaterThanOrEqual, Values: []time.Time{t1}}, t1, true),
+		Entry("less than", repositories.TimestampFilter{Operator: repositories.TimestampLessThan, Values: []time.Time{t2}}, t1, true),
+		Entry("not less than", repositories.TimestampFilter{Operator: repositories.TimestampLessThan, Values: []time.Time{t2}}, t2, false),
+		Entry("less than or equal", repositories.TimestampFilter{Operator: repositories.TimestampLessThanOrEqual, Values: []time.Time{t2}}, t2, true),
+	)
+
+	Describe("TimestampFilters.Matches", func() {
+		var filters repositories.TimestampFilters
+
+		BeforeEach(func() {
+			filters = repositories.TimestampFilters{
+				CreatedAts: []repositories.TimestampFilter{{Operator: repositories.TimestampLessThan, Values: []time.Time{t2}}},
+				UpdatedAts: []repositories.TimestampFilter{{Operator: repositories.TimestampGreaterThanOrEqual, Values: []time.Time{t2}}},
+			}
+		})
+
+		It("matches records satisfying all filters", func() {
+			Expect(filters.Matches(t1, &t2)).To(BeTrue())
+		})
+
+		It("does not match records failing any filter", func() {
+			Expect(filters.Matches(t2, &t2)).To(BeFalse())
+			Expect(filters.Matches(t1, &t1)).To(BeFalse())
+		})
+
+		It("matches records that have never been updated on their creation time", func() {
+			Expect(filters.Matches(t1, nil)).To(BeFalse())
+			Expect(repositories.TimestampFilters{
+				UpdatedAts: []repositories.TimestampFilter{{Values: []time.Time{t1}}},
+			}.Matches(t1, nil)).To(BeTrue())
+		})
+
+		It("matches everything when empty", func() {
+			Expect(repositories.TimestampFilters{}.Matches(t1, nil)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,406 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	AppUsageEventResourceType     = "App Usage Event"
+	ServiceUsageEventResourceType = "Service Usage Event"
+
+	// UsageEventSettleWindow is how long usage events are held back from
+	// lists. Usage event GUIDs are generated before the events are created
+	// by concurrent writers, so a recent event can still be created with a
+	// GUID that is lower than the GUID of an event that has already been
+	// listed. Holding events back until no such event can appear anymore
+	// makes sure that consumers paging with after_guid do not miss events.
+	UsageEventSettleWindow = 30 * time.Second
+)
+
+type UsageEventRepository struct {
+	klient        Klient
+	rootNamespace string
+	settleWindow  time.Duration
+}
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	CreatedAt                     time.Time
+	State                         string
+	PreviousState                 string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrgGUID                       string
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	CreatedAt           time.Time
+	State               string
+	SpaceGUID           string
+	SpaceName           string
+	OrgGUID             string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	ServicePlanGUID     string
+	ServicePlanName     string
+	ServiceOfferingGUID string
+	ServiceOfferingName string
+	ServiceBrokerGUID   string
+	ServiceBrokerName   string
+}
+
+type ListUsageEventsMessage struct {
+	GUIDs     []string
+	AfterGUID string
+}
+
+func (m ListUsageEventsMessage) matches(usageEvent korifiv1alpha1.CFUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, usageEvent.Name) &&
+		(m.AfterGUID == "" || usageEvent.Name > m.AfterGUID)
+}
+
+type ListServiceUsageEventsMessage struct {
+	ListUsageEventsMessage
+	ServiceInstanceTypes []string
+	ServiceOfferingGUIDs []string
+}
+
+func (m ListServiceUsageEventsMessage) matches(usageEvent korifiv1alpha1.CFUsageEvent) bool {
+	serviceInstance := tools.ZeroIfNil(usageEvent.Spec.ServiceInstance)
+
+	return m.ListUsageEventsMessage.matches(usageEvent) &&
+		tools.EmptyOrContains(m.ServiceInstanceTypes, serviceInstance.Type) &&
+		tools.EmptyOrContains(m.ServiceOfferingGUIDs, serviceInstance.OfferingGUID)
+}
+
+func NewUsageEventRepository(klient Klient, rootNamespace string, settleWindow time.Duration) *UsageEventRepository {
+	return &UsageEventRepository{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+		settleWindow:  settleWindow,
+	}
+}
+
+func (r *UsageEventRepository) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListUsageEventsMessage) ([]AppUsageEventRecord, error) {
+	usageEvents, err := r.listUsageEvents(ctx, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	settledUsageEvents := it.Filter(slices.Values(usageEvents), r.isSettled)
+	return slices.Collect(it.Map(it.Filter(settledUsageEvents, message.matches), toAppUsageEventRecord)), nil
+}
+
+func (r *UsageEventRepository) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	usageEvent, err := r.getUsageEvent(ctx, guid, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType)
+	if err != nil {
+		return AppUsageEventRecord{}, err
+	}
+
+	return toAppUsageEventRecord(usageEvent), nil
+}
+
+func (r *UsageEventRepository) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) ([]ServiceUsageEventRecord, error) {
+	usageEvents, err := r.listUsageEvents(ctx, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	settledUsageEvents := it.Filter(slices.Values(usageEvents), r.isSettled)
+	return slices.Collect(it.Map(it.Filter(settledUsageEvents, message.matches), toServiceUsageEventRecord)), nil
+}
+
+func (r *UsageEventRepository) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	usageEvent, err := r.getUsageEvent(ctx, guid, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType)
+	if err != nil {
+		return ServiceUsageEventRecord{}, err
+	}
+
+	return toServiceUsageEventRecord(usageEvent), nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all app usage events and records a
+// STARTED event for every process that is currently started
+func (r *UsageEventRepository) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	if err := r.purgeUsageEvents(ctx, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType); err != nil {
+		return err
+	}
+
+	spaces, err := r.listSpaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	appList := &korifiv1alpha1.CFAppList{}
+	if err = r.klient.List(ctx, appList); err != nil {
+		return fmt.Errorf("failed to list apps: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+	appNames := map[string]string{}
+	for _, app := range appList.Items {
+		appNames[app.Name] = app.Spec.DisplayName
+	}
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	if err = r.klient.List(ctx, processList); err != nil {
+		return fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for _, process := range processList.Items {
+		recordedUsage := process.Status.RecordedUsage
+		if recordedUsage == nil || recordedUsage.State != korifiv1alpha1.UsageStateStarted {
+			continue
+		}
+
+		spec := spaceUsage(spaces, process.Namespace)
+		spec.Type = korifiv1alpha1.AppUsageEventType
+		spec.State = korifiv1alpha1.UsageStateStarted
+		spec.App = &korifiv1alpha1.AppUsage{
+			AppGUID:                       process.Spec.AppRef.Name,
+			AppName:                       appNames[process.Spec.AppRef.Name],
+			ProcessGUID:                   process.Name,
+			ProcessType:                   process.Spec.ProcessType,
+			InstanceCount:                 recordedUsage.Instances,
+			PreviousInstanceCount:         recordedUsage.Instances,
+			MemoryInMBPerInstance:         recordedUsage.MemoryMB,
+			PreviousMemoryInMBPerInstance: recordedUsage.MemoryMB,
+		}
+
+		if err = r.createUsageEvent(ctx, spec, AppUsageEventResourceType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all service usage events and
+// records a CREATED event for every existing service instance
+func (r *UsageEventRepository) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	if err := r.purgeUsageEvents(ctx, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType); err != nil {
+		return err
+	}
+
+	spaces, err := r.listSpaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	planList := &korifiv1alpha1.CFServicePlanList{}
+	if err = r.klient.List(ctx, planList, InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to list service plans: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+	plans := map[string]korifiv1alpha1.CFServicePlan{}
+	for _, plan := range planList.Items {
+		plans[plan.Name] = plan
+	}
+
+	instanceList := &korifiv1alpha1.CFServiceInstanceList{}
+	if err = r.klient.List(ctx, instanceList); err != nil {
+		return fmt.Errorf("failed to list service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	for _, instance := range instanceList.Items {
+		if !instance.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		spec := spaceUsage(spaces, instance.Namespace)
+		spec.Type = korifiv1alpha1.ServiceUsageEventType
+		spec.State = korifiv1alpha1.UsageStateCreated
+		spec.ServiceInstance = &korifiv1alpha1.ServiceInstanceUsage{
+			GUID: instance.Name,
+			Name: instance.Spec.DisplayName,
+			Type: usage.UserProvidedServiceInstanceType,
+		}
+
+		if instance.Spec.Type == korifiv1alpha1.ManagedType {
+			spec.ServiceInstance.Type = usage.ManagedServiceInstanceType
+			spec.ServiceInstance.PlanGUID = instance.Spec.PlanGUID
+			if plan, ok := plans[instance.Spec.PlanGUID]; ok {
+				spec.ServiceInstance.PlanName = plan.Spec.Name
+				spec.ServiceInstance.OfferingGUID = plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+				spec.ServiceInstance.OfferingName = plan.Labels[korifiv1alpha1.RelServiceOfferingNameLabel]
+				spec.ServiceInstance.BrokerGUID = plan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]
+				spec.ServiceInstance.BrokerName = plan.Labels[korifiv1alpha1.RelServiceBrokerNameLabel]
+			}
+		}
+
+		if err = r.createUsageEvent(ctx, spec, ServiceUsageEventResourceType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *UsageEventRepository) listUsageEvents(ctx context.Context, eventType, resourceType string) ([]korifiv1alpha1.CFUsageEvent, error) {
+	usageEventList := &korifiv1alpha1.CFUsageEventList{}
+	if err := r.klient.List(ctx, usageEventList,
+		InNamespace(r.rootNamespace),
+		WithLabel(korifiv1alpha1.CFUsageEventTypeLabelKey, eventType),
+	); err != nil {
+		return nil, fmt.Errorf("failed to list usage events: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	// usage event names are time ordered UUIDs
+	usageEvents := usageEventList.Items
+	slices.SortFunc(usageEvents, func(a, b korifiv1alpha1.CFUsageEvent) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return usageEvents, nil
+}
+
+// isSettled tells whether the usage event has been recorded longer than the
+// settle window ago. The time encoded in the time ordered GUID is used rather
+// than the creation timestamp, as it is the GUID that determines the order.
+func (r *UsageEventRepository) isSettled(usageEvent korifiv1alpha1.CFUsageEvent) bool {
+	recordedAt := usageEvent.CreationTimestamp.Time
+	if guid, err := uuid.Parse(usageEvent.Name); err == nil && guid.Version() == 7 {
+		recordedAt = time.Unix(guid.Time().UnixTime())
+	}
+
+	return time.Since(recordedAt) >= r.settleWindow
+}
+
+func (r *UsageEventRepository) getUsageEvent(ctx context.Context, guid, eventType, resourceType string) (korifiv1alpha1.CFUsageEvent, error) {
+	usageEvent := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, usageEvent); err != nil {
+		return korifiv1alpha1.CFUsageEvent{}, fmt.Errorf("failed to get usage event: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	if usageEvent.Spec.Type != eventType {
+		return korifiv1alpha1.CFUsageEvent{}, apierrors.NewNotFoundError(nil, resourceType)
+	}
+
+	return *usageEvent, nil
+}
+
+func (r *UsageEventRepository) purgeUsageEvents(ctx context.Context, eventType, resourceType string) error {
+	usageEvents, err := r.listUsageEvents(ctx, eventType, resourceType)
+	if err != nil {
+		return err
+	}
+
+	for i := range usageEvents {
+		if err = client.IgnoreNotFound(r.klient.Delete(ctx, &usageEvents[i])); err != nil {
+			return fmt.Errorf("failed to delete usage event: %w", apierrors.FromK8sError(err, resourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *UsageEventRepository) createUsageEvent(ctx context.Context, spec korifiv1alpha1.CFUsageEventSpec, resourceType string) error {
+	usageEvent, err := usage.NewUsageEvent(r.rootNamespace, spec)
+	if err != nil {
+		return err
+	}
+
+	if err = r.klient.Create(ctx, usageEvent); err != nil {
+		return fmt.Errorf("failed to create usage event: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	return nil
+}
+
+func (r *UsageEventRepository) listSpaces(ctx context.Context) (map[string]korifiv1alpha1.CFSpace, error) {
+	spaceList := &korifiv1alpha1.CFSpaceList{}
+	if err := r.klient.List(ctx, spaceList); err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	spaces := map[string]korifiv1alpha1.CFSpace{}
+	for _, space := range spaceList.Items {
+		spaces[space.Name] = space
+	}
+
+	return spaces, nil
+}
+
+func spaceUsage(spaces map[string]korifiv1alpha1.CFSpace, spaceGUID string) korifiv1alpha1.CFUsageEventSpec {
+	spec := korifiv1alpha1.CFUsageEventSpec{
+		SpaceGUID: spaceGUID,
+	}
+
+	if space, ok := spaces[spaceGUID]; ok {
+		spec.SpaceName = space.Spec.DisplayName
+		spec.OrgGUID = space.Namespace
+	}
+
+	return spec
+}
+
+func toAppUsageEventRecord(usageEvent korifiv1alpha1.CFUsageEvent) AppUsageEventRecord {
+	app := tools.ZeroIfNil(usageEvent.Spec.App)
+
+	return AppUsageEventRecord{
+		GUID:                          usageEvent.Name,
+		CreatedAt:                     usageEvent.CreationTimestamp.Time,
+		State:                         usageEvent.Spec.State,
+		PreviousState:                 usageEvent.Spec.PreviousState,
+		AppGUID:                       app.AppGUID,
+		AppName:                       app.AppName,
+		ProcessGUID:                   app.ProcessGUID,
+		ProcessType:                   app.ProcessType,
+		SpaceGUID:                     usageEvent.Spec.SpaceGUID,
+		SpaceName:                     usageEvent.Spec.SpaceName,
+		OrgGUID:                       usageEvent.Spec.OrgGUID,
+		InstanceCount:                 app.InstanceCount,
+		PreviousInstanceCount:         app.PreviousInstanceCount,
+		MemoryInMBPerInstance:         app.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: app.PreviousMemoryInMBPerInstance,
+	}
+}
+
+func toServiceUsageEventRecord(usageEvent korifiv1alpha1.CFUsageEvent) ServiceUsageEventRecord {
+	serviceInstance := tools.ZeroIfNil(usageEvent.Spec.ServiceInstance)
+
+	return ServiceUsageEventRecord{
+		GUID:                usageEvent.Name,
+		CreatedAt:           usageEvent.CreationTimestamp.Time,
+		State:               usageEvent.Spec.State,
+		SpaceGUID:           usageEvent.Spec.SpaceGUID,
+		SpaceName:           usageEvent.Spec.SpaceName,
+		OrgGUID:             usageEvent.Spec.OrgGUID,
+		ServiceInstanceGUID: serviceInstance.GUID,
+		ServiceInstanceName: serviceInstance.Name,
+		ServiceInstanceType: serviceInstance.Type,
+		ServicePlanGUID:     serviceInstance.PlanGUID,
+		ServicePlanName:     serviceInstance.PlanName,
+		ServiceOfferingGUID: serviceInstance.OfferingGUID,
+		ServiceOfferingName: serviceInstance.OfferingName,
+		ServiceBrokerGUID:   serviceInstance.BrokerGUID,
+		ServiceBrokerName:   serviceInstance.BrokerName,
+	}
+}
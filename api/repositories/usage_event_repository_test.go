@@ -0,0 +1,455 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UsageEventRepository", func() {
+	var (
+		usageEventRepo *UsageEventRepository
+		org            *korifiv1alpha1.CFOrg
+		space          *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		usageEventRepo = NewUsageEventRepository(klient, rootNamespace, 0)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, "my-space")
+	})
+
+	Describe("app usage events", func() {
+		var started, stopped *korifiv1alpha1.CFUsageEvent
+
+		BeforeEach(func() {
+			started = createUsageEvent(ctx, korifiv1alpha1.CFUsageEventSpec{
+				Type:          korifiv1alpha1.AppUsageEventType,
+				State:         korifiv1alpha1.UsageStateStarted,
+				PreviousState: korifiv1alpha1.UsageStateStopped,
+				SpaceGUID:     space.Name,
+				SpaceName:     "my-space",
+				OrgGUID:       org.Name,
+				App: &korifiv1alpha1.AppUsage{
+					AppGUID:                       "app-guid",
+					AppName:                       "my-app",
+					ProcessGUID:                   "process-guid",
+					ProcessType:                   "web",
+					InstanceCount:                 2,
+					PreviousInstanceCount:         2,
+					MemoryInMBPerInstance:         256,
+					PreviousMemoryInMBPerInstance: 256,
+				},
+			})
+			stopped = createUsageEvent(ctx, korifiv1alpha1.CFUsageEventSpec{
+				Type:          korifiv1alpha1.AppUsageEventType,
+				State:         korifiv1alpha1.UsageStateStopped,
+				PreviousState: korifiv1alpha1.UsageStateStarted,
+				SpaceGUID:     space.Name,
+				App:           &korifiv1alpha1.AppUsage{AppGUID: "app-guid"},
+			})
+			createUsageEvent(ctx, korifiv1alpha1.CFUsageEventSpec{
+				Type:            korifiv1alpha1.ServiceUsageEventType,
+				State:           korifiv1alpha1.UsageStateCreated,
+				SpaceGUID:       space.Name,
+				ServiceInstance: &korifiv1alpha1.ServiceInstanceUsage{GUID: "instance-guid"},
+			})
+		})
+
+		Describe("ListAppUsageEvents", func() {
+			var (
+				message     ListUsageEventsMessage
+				usageEvents []AppUsageEventRecord
+				listErr     error
+			)
+
+			BeforeEach(func() {
+				message = ListUsageEventsMessage{}
+			})
+
+			JustBeforeEach(func() {
+				usageEvents, listErr = usageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+			})
+
+			It("returns forbidden for users with no permissions", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a CF admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("returns the app usage events in the order they have been recorded", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(HaveExactElements(
+						MatchAllFields(Fields{
+							"GUID":                          Equal(started.Name),
+							"CreatedAt":                     Not(BeZero()),
+							"State":                         Equal(korifiv1alpha1.UsageStateStarted),
+							"PreviousState":                 Equal(korifiv1alpha1.UsageStateStopped),
+							"AppGUID":                       Equal("app-guid"),
+							"AppName":                       Equal("my-app"),
+							"ProcessGUID":                   Equal("process-guid"),
+							"ProcessType":                   Equal("web"),
+							"SpaceGUID":                     Equal(space.Name),
+							"SpaceName":                     Equal("my-space"),
+							"OrgGUID":                       Equal(org.Name),
+							"InstanceCount":                 BeEquivalentTo(2),
+							"PreviousInstanceCount":         BeEquivalentTo(2),
+							"MemoryInMBPerInstance":         BeEquivalentTo(256),
+							"PreviousMemoryInMBPerInstance": BeEquivalentTo(256),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":  Equal(stopped.Name),
+							"State": Equal(korifiv1alpha1.UsageStateStopped),
+						}),
+					))
+				})
+
+				When("filtering by guids", func() {
+					BeforeEach(func() {
+						message.GUIDs = []string{stopped.Name}
+					})
+
+					It("returns the matching usage events only", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(stopped.Name),
+						})))
+					})
+				})
+
+				When("listing the usage events after a guid", func() {
+					BeforeEach(func() {
+						message.AfterGUID = started.Name
+					})
+
+					It("returns the usage events recorded after it", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(stopped.Name),
+						})))
+					})
+				})
+
+				When("the usage events have been recorded within the settle window", func() {
+					BeforeEach(func() {
+						usageEventRepo = NewUsageEventRepository(klient, rootNamespace, time.Hour)
+					})
+
+					It("holds them back", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(usageEvents).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Describe("GetAppUsageEvent", func() {
+			var (
+				guid       string
+				usageEvent AppUsageEventRecord
+				getErr     error
+			)
+
+			BeforeEach(func() {
+				guid = started.Name
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			JustBeforeEach(func() {
+				usageEvent, getErr = usageEventRepo.GetAppUsageEvent(ctx, authInfo, guid)
+			})
+
+			It("returns the usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(usageEvent.GUID).To(Equal(started.Name))
+				Expect(usageEvent.AppName).To(Equal("my-app"))
+			})
+
+			When("the usage event does not exist", func() {
+				BeforeEach(func() {
+					guid = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the usage event is a service usage event", func() {
+				BeforeEach(func() {
+					events, err := usageEventRepo.ListServiceUsageEvents(ctx, authInfo, ListServiceUsageEventsMessage{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(events).To(HaveLen(1))
+					guid = events[0].GUID
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		Describe("PurgeAndReseedAppUsageEvents", func() {
+			var (
+				startedProcess *korifiv1alpha1.CFProcess
+				purgeErr       error
+			)
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, space.Name)
+
+				cfApp := createAppWithGUID(space.Name, uuid.NewString())
+				startedProcess = createProcessWithRecordedUsage(ctx, cfApp, &korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.UsageStateStarted,
+					Instances: 3,
+					MemoryMB:  512,
+				})
+				createProcessWithRecordedUsage(ctx, cfApp, &korifiv1alpha1.ProcessUsage{
+					State: korifiv1alpha1.UsageStateStopped,
+				})
+			})
+
+			JustBeforeEach(func() {
+				purgeErr = usageEventRepo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+			})
+
+			It("replaces the app usage events with the ones of the started processes", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				usageEvents, err := usageEventRepo.ListAppUsageEvents(ctx, authInfo, ListUsageEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  matchers.BeValidUUID(),
+					"State":                 Equal(korifiv1alpha1.UsageStateStarted),
+					"AppGUID":               Equal(startedProcess.Spec.AppRef.Name),
+					"AppName":               Not(BeEmpty()),
+					"ProcessGUID":           Equal(startedProcess.Name),
+					"SpaceGUID":             Equal(space.Name),
+					"SpaceName":             Equal("my-space"),
+					"OrgGUID":               Equal(org.Name),
+					"InstanceCount":         BeEquivalentTo(3),
+					"MemoryInMBPerInstance": BeEquivalentTo(512),
+				})))
+			})
+
+			It("keeps the service usage events", func() {
+				usageEvents, err := usageEventRepo.ListServiceUsageEvents(ctx, authInfo, ListServiceUsageEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usageEvents).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("service usage events", func() {
+		var created, deleted *korifiv1alpha1.CFUsageEvent
+
+		BeforeEach(func() {
+			created = createUsageEvent(ctx, korifiv1alpha1.CFUsageEventSpec{
+				Type:      korifiv1alpha1.ServiceUsageEventType,
+				State:     korifiv1alpha1.UsageStateCreated,
+				SpaceGUID: space.Name,
+				SpaceName: "my-space",
+				OrgGUID:   org.Name,
+				ServiceInstance: &korifiv1alpha1.ServiceInstanceUsage{
+					GUID:         "instance-guid",
+					Name:         "my-instance",
+					Type:         usage.ManagedServiceInstanceType,
+					PlanGUID:     "plan-guid",
+					PlanName:     "my-plan",
+					OfferingGUID: "offering-guid",
+					OfferingName: "my-offering",
+					BrokerGUID:   "broker-guid",
+					BrokerName:   "my-broker",
+				},
+			})
+			deleted = createUsageEvent(ctx, korifiv1alpha1.CFUsageEventSpec{
+				Type:      korifiv1alpha1.ServiceUsageEventType,
+				State:     korifiv1alpha1.UsageStateDeleted,
+				SpaceGUID: space.Name,
+				ServiceInstance: &korifiv1alpha1.ServiceInstanceUsage{
+					GUID: "upsi-guid",
+					Type: usage.UserProvidedServiceInstanceType,
+				},
+			})
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		Describe("ListServiceUsageEvents", func() {
+			var (
+				message     ListServiceUsageEventsMessage
+				usageEvents []ServiceUsageEventRecord
+				listErr     error
+			)
+
+			BeforeEach(func() {
+				message = ListServiceUsageEventsMessage{}
+			})
+
+			JustBeforeEach(func() {
+				usageEvents, listErr = usageEventRepo.ListServiceUsageEvents(ctx, authInfo, message)
+			})
+
+			It("returns the service usage events in the order they have been recorded", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(usageEvents).To(HaveExactElements(
+					MatchAllFields(Fields{
+						"GUID":                Equal(created.Name),
+						"CreatedAt":           Not(BeZero()),
+						"State":               Equal(korifiv1alpha1.UsageStateCreated),
+						"SpaceGUID":           Equal(space.Name),
+						"SpaceName":           Equal("my-space"),
+						"OrgGUID":             Equal(org.Name),
+						"ServiceInstanceGUID": Equal("instance-guid"),
+						"ServiceInstanceName": Equal("my-instance"),
+						"ServiceInstanceType": Equal(usage.ManagedServiceInstanceType),
+						"ServicePlanGUID":     Equal("plan-guid"),
+						"ServicePlanName":     Equal("my-plan"),
+						"ServiceOfferingGUID": Equal("offering-guid"),
+						"ServiceOfferingName": Equal("my-offering"),
+						"ServiceBrokerGUID":   Equal("broker-guid"),
+						"ServiceBrokerName":   Equal("my-broker"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(deleted.Name),
+					}),
+				))
+			})
+
+			When("filtering by service instance type", func() {
+				BeforeEach(func() {
+					message.ServiceInstanceTypes = []string{usage.UserProvidedServiceInstanceType}
+				})
+
+				It("returns the matching usage events only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(deleted.Name),
+					})))
+				})
+			})
+
+			When("filtering by service offering guid", func() {
+				BeforeEach(func() {
+					message.ServiceOfferingGUIDs = []string{"offering-guid"}
+				})
+
+				It("returns the matching usage events only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(created.Name),
+					})))
+				})
+			})
+
+			When("listing the usage events after a guid", func() {
+				BeforeEach(func() {
+					message.AfterGUID = created.Name
+				})
+
+				It("returns the usage events recorded after it", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(deleted.Name),
+					})))
+				})
+			})
+
+			When("the usage events have been recorded within the settle window", func() {
+				BeforeEach(func() {
+					usageEventRepo = NewUsageEventRepository(klient, rootNamespace, time.Hour)
+				})
+
+				It("holds them back", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("GetServiceUsageEvent", func() {
+			It("returns the usage event", func() {
+				usageEvent, err := usageEventRepo.GetServiceUsageEvent(ctx, authInfo, created.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usageEvent.ServiceInstanceName).To(Equal("my-instance"))
+			})
+		})
+
+		Describe("PurgeAndReseedServiceUsageEvents", func() {
+			var serviceInstance *korifiv1alpha1.CFServiceInstance
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, space.Name)
+
+				serviceInstance = createServiceInstanceCR(ctx, k8sClient, uuid.NewString(), space.Name, "my-upsi", "secret-name")
+			})
+
+			It("replaces the service usage events with the ones of the existing service instances", func() {
+				Expect(usageEventRepo.PurgeAndReseedServiceUsageEvents(ctx, authInfo)).To(Succeed())
+
+				usageEvents, err := usageEventRepo.ListServiceUsageEvents(ctx, authInfo, ListServiceUsageEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"State":               Equal(korifiv1alpha1.UsageStateCreated),
+					"ServiceInstanceGUID": Equal(serviceInstance.Name),
+					"ServiceInstanceName": Equal("my-upsi"),
+					"ServiceInstanceType": Equal(usage.UserProvidedServiceInstanceType),
+					"SpaceName":           Equal("my-space"),
+					"OrgGUID":             Equal(org.Name),
+				})))
+			})
+		})
+	})
+})
+
+func createUsageEvent(ctx context.Context, spec korifiv1alpha1.CFUsageEventSpec) *korifiv1alpha1.CFUsageEvent {
+	GinkgoHelper()
+
+	usageEvent, err := usage.NewUsageEvent(rootNamespace, spec)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient.Create(ctx, usageEvent)).To(Succeed())
+
+	return usageEvent
+}
+
+func createProcessWithRecordedUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, recordedUsage *korifiv1alpha1.ProcessUsage) *korifiv1alpha1.CFProcess {
+	GinkgoHelper()
+
+	cfProcess := &korifiv1alpha1.CFProcess{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFProcessSpec{
+			AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+			ProcessType: "web",
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+
+	Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+		cfProcess.Status.RecordedUsage = recordedUsage
+	})).To(Succeed())
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+
+	return cfProcess
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/tools/singleton"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	UserResourceType = "User"
+	userNamePrefix   = "cf-user"
+)
+
+type UserRecord struct {
+	GUID      string
+	Username  string
+	Origin    string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+func (r UserRecord) Relationships() map[string]string {
+	return map[string]string{}
+}
+
+func (r UserRecord) GetResourceType() string {
+	return UserResourceType
+}
+
+type ListUsersMessage struct {
+	GUIDs      []string
+	Usernames  []string
+	Origins    []string
+	Timestamps TimestampFilters
+}
+
+func (m ListUsersMessage) matches(user UserRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, user.GUID) &&
+		tools.EmptyOrContains(m.Usernames, user.Username) &&
+		tools.EmptyOrContains(m.Origins, user.Origin) &&
+		m.Timestamps.Matches(user.CreatedAt, user.UpdatedAt)
+}
+
+type CreateUserMessage struct {
+	Username string
+	Origin   string
+}
+
+// UserRepo derives users from the subjects of the CF role bindings visible to
+// the caller. Users can additionally be registered as CFUsers in the root
+// namespace, so that they are known before any roles are assigned to them.
+type UserRepo struct {
+	klient        Klient
+	roleRepo      *RoleRepo
+	rootNamespace string
+}
+
+func NewUserRepo(klient Klient, roleRepo *RoleRepo, rootNamespace string) *UserRepo {
+	return &UserRepo{
+		klient:        klient,
+		roleRepo:      roleRepo,
+		rootNamespace: rootNamespace,
+	}
+}
+
+// UserGUID returns the guid of a user, which is the name of the subject of
+// its role bindings
+func UserGUID(username, origin string) string {
+	if origin == "" {
+		return username
+	}
+
+	return origin + ":" + username
+}
+
+func (r *UserRepo) CreateUser(ctx context.Context, authInfo authorization.Info, message CreateUserMessage) (UserRecord, error) {
+	cfUser := &korifiv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      calculateUserObjectName(UserGUID(message.Username, message.Origin)),
+		},
+		Spec: korifiv1alpha1.CFUserSpec{
+			Username: message.Username,
+			Origin:   message.Origin,
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfUser); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return UserRecord{}, apierrors.NewUniquenessError(err, fmt.Sprintf("User with guid '%s' already exists.", UserGUID(message.Username, message.Origin)))
+		}
+		return UserRecord{}, apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	return cfUserToUserRecord(*cfUser), nil
+}
+
+func (r *UserRepo) ListUsers(ctx context.Context, authInfo authorization.Info, message ListUsersMessage) ([]UserRecord, error) {
+	roles, err := r.roleRepo.ListRoles(ctx, authInfo, ListRolesMessage{})
+	if err != nil {
+		return nil, err
+	}
+
+	users := map[string]UserRecord{}
+	for _, role := range roles {
+		user, ok := users[role.User]
+		if !ok {
+			user = UserRecord{
+				GUID:      role.User,
+				Username:  role.Username,
+				Origin:    role.Origin,
+				CreatedAt: role.CreatedAt,
+				UpdatedAt: role.UpdatedAt,
+			}
+		}
+
+		if role.CreatedAt.Before(user.CreatedAt) {
+			user.CreatedAt = role.CreatedAt
+		}
+		if tools.CompareTimePtr(role.UpdatedAt, user.UpdatedAt) > 0 {
+			user.UpdatedAt = role.UpdatedAt
+		}
+
+		users[role.User] = user
+	}
+
+	cfUsers := &korifiv1alpha1.CFUserList{}
+	err = r.klient.List(ctx, cfUsers, InNamespace(r.rootNamespace))
+	if err != nil && !k8serrors.IsForbidden(err) {
+		return nil, apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	for _, cfUser := range cfUsers.Items {
+		user := cfUserToUserRecord(cfUser)
+		users[user.GUID] = user
+	}
+
+	result := itx.From(maps.Values(users)).Filter(message.matches).Collect()
+	slices.SortFunc(result, func(u1, u2 UserRecord) int {
+		return cmp.Or(u1.CreatedAt.Compare(u2.CreatedAt), strings.Compare(u1.GUID, u2.GUID))
+	})
+
+	return result, nil
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, authInfo authorization.Info, guid string) (UserRecord, error) {
+	users, err := r.ListUsers(ctx, authInfo, ListUsersMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return UserRecord{}, err
+	}
+
+	return singleton.Get(users)
+}
+
+// DeleteUser deletes the user registration and all roles of the user
+func (r *UserRepo) DeleteUser(ctx context.Context, authInfo authorization.Info, guid string) error {
+	if _, err := r.GetUser(ctx, authInfo, guid); err != nil {
+		return err
+	}
+
+	roles, err := r.roleRepo.ListRoles(ctx, authInfo, ListRolesMessage{})
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if role.User != guid {
+			continue
+		}
+
+		deleteMessage := DeleteRoleMessage{GUID: role.GUID, Space: role.Space, Org: role.Org, Namespace: role.Namespace}
+		if err = r.roleRepo.DeleteRole(ctx, authInfo, deleteMessage); err != nil {
+			return fmt.Errorf("failed to delete role %q of user %q: %w", role.GUID, guid, err)
+		}
+	}
+
+	err = r.klient.Delete(ctx, &korifiv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      calculateUserObjectName(guid),
+		},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return apierrors.FromK8sError(err, UserResourceType)
+	}
+
+	return nil
+}
+
+func (r *UserRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	user, err := r.GetUser(ctx, authInfo, guid)
+	return user.DeletedAt, err
+}
+
+func cfUserToUserRecord(cfUser korifiv1alpha1.CFUser) UserRecord {
+	return UserRecord{
+		GUID:      UserGUID(cfUser.Spec.Username, cfUser.Spec.Origin),
+		Username:  cfUser.Spec.Username,
+		Origin:    cfUser.Spec.Origin,
+		CreatedAt: cfUser.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfUser),
+		DeletedAt: golangTime(cfUser.DeletionTimestamp),
+	}
+}
+
+// calculateUserObjectName hashes the user guid, as user names such as email
+// addresses are not valid kubernetes object names
+func calculateUserObjectName(guid string) string {
+	return fmt.Sprintf("%s-%x", userNamePrefix, sha256.Sum256([]byte(guid)))
+}
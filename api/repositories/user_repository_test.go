@@ -0,0 +1,256 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UserRepository", func() {
+	var (
+		userRepo *repositories.UserRepo
+		cfOrg    *korifiv1alpha1.CFOrg
+		cfSpace  *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		orgRepo := repositories.NewOrgRepo(klient, rootNamespace, nsPerms, &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFOrg,
+			korifiv1alpha1.CFOrgList,
+			*korifiv1alpha1.CFOrgList,
+		]{})
+		spaceRepo := repositories.NewSpaceRepo(klient, orgRepo, nsPerms, &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFSpace,
+			korifiv1alpha1.CFSpaceList,
+			*korifiv1alpha1.CFSpaceList,
+		]{})
+		roleRepo := repositories.NewRoleRepo(
+			klient,
+			spaceRepo,
+			new(fake.AuthorizedInChecker),
+			nsPerms,
+			rootNamespace,
+			map[string]config.Role{
+				"space_developer":   {Name: spaceDeveloperRole.Name, Level: config.SpaceRole},
+				"organization_user": {Name: orgUserRole.Name, Level: config.OrgRole},
+				"admin":             {Name: adminRole.Name},
+			},
+			repositories.NewRoleSorter(),
+		)
+		userRepo = repositories.NewUserRepo(klient, roleRepo, rootNamespace)
+
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+	})
+
+	Describe("CreateUser", func() {
+		var (
+			user      repositories.UserRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			user, createErr = userRepo.CreateUser(ctx, authInfo, repositories.CreateUserMessage{
+				Username: "bob",
+				Origin:   "uaa",
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("registers the user", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(user.GUID).To(Equal("uaa:bob"))
+				Expect(user.Username).To(Equal("bob"))
+				Expect(user.Origin).To(Equal("uaa"))
+
+				cfUsers := &korifiv1alpha1.CFUserList{}
+				Expect(k8sClient.List(ctx, cfUsers, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfUsers.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFUserSpec{Username: "bob", Origin: "uaa"}),
+				})))
+			})
+
+			When("the user is already registered", func() {
+				BeforeEach(func() {
+					_, err := userRepo.CreateUser(ctx, authInfo, repositories.CreateUserMessage{
+						Username: "bob",
+						Origin:   "uaa",
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListUsers", func() {
+		var (
+			users   []repositories.UserRecord
+			message repositories.ListUsersMessage
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListUsersMessage{}
+			createUserRoleBinding(ctx, "alice", "https://issuer.example.com", orgUserRole.Name, cfOrg.Name)
+			createUserRoleBinding(ctx, "alice", "https://issuer.example.com", spaceDeveloperRole.Name, cfSpace.Name)
+			createRoleBinding(ctx, "carol", spaceDeveloperRole.Name, cfSpace.Name, repositories.RoleGuidLabel, uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			users, listErr = userRepo.ListUsers(ctx, authInfo, message)
+		})
+
+		It("returns an empty list when the user cannot see any roles", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(users).To(BeEmpty())
+		})
+
+		When("the user can see the roles", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name, repositories.RoleGuidLabel, uuid.NewString())
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name, repositories.RoleGuidLabel, uuid.NewString())
+			})
+
+			It("returns the subjects of the role bindings", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     Equal("https://issuer.example.com:alice"),
+						"Username": Equal("alice"),
+						"Origin":   Equal("https://issuer.example.com"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     Equal("carol"),
+						"Username": Equal("carol"),
+						"Origin":   BeEmpty(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(userName),
+					}),
+				))
+			})
+
+			When("filtering by username", func() {
+				BeforeEach(func() {
+					message.Usernames = []string{"alice"}
+				})
+
+				It("returns the matching users only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(users).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal("https://issuer.example.com:alice"),
+					})))
+				})
+			})
+
+			When("filtering by guid", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{"carol"}
+				})
+
+				It("returns the matching users only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(users).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal("carol"),
+					})))
+				})
+			})
+		})
+
+		When("there are registered users", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				_, err := userRepo.CreateUser(ctx, authInfo, repositories.CreateUserMessage{Username: "dave", Origin: "ldap"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("includes them", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"GUID":      Equal("ldap:dave"),
+					"CreatedAt": BeTemporally("~", time.Now(), time.Minute),
+				})))
+			})
+		})
+	})
+
+	Describe("GetUser", func() {
+		var getErr error
+
+		JustBeforeEach(func() {
+			_, getErr = userRepo.GetUser(ctx, authInfo, "carol")
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+
+	Describe("DeleteUser", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			createRoleBinding(ctx, "carol", orgUserRole.Name, cfOrg.Name, repositories.RoleGuidLabel, uuid.NewString())
+			createRoleBinding(ctx, "carol", spaceDeveloperRole.Name, cfSpace.Name, repositories.RoleGuidLabel, uuid.NewString())
+			createRoleBinding(ctx, "carol", adminRole.Name, cfOrg.Name, repositories.RoleGuidLabel, uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = userRepo.DeleteUser(ctx, authInfo, "carol")
+		})
+
+		It("deletes the roles of the user", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			for _, namespace := range []string{cfOrg.Name, cfSpace.Name} {
+				roleBindings := &rbacv1.RoleBindingList{}
+				Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+				for _, roleBinding := range roleBindings.Items {
+					Expect(roleBinding.Subjects).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Name": Equal("carol"),
+					})))
+				}
+			}
+		})
+	})
+})
+
+func createUserRoleBinding(ctx context.Context, username, origin, roleName, namespace string) {
+	GinkgoHelper()
+
+	roleBinding := createRoleBinding(ctx, repositories.UserGUID(username, origin), roleName, namespace, repositories.RoleGuidLabel, uuid.NewString())
+	Expect(k8s.PatchResource(ctx, k8sClient, &roleBinding, func() {
+		roleBinding.Annotations = map[string]string{
+			repositories.RoleUsernameAnnotation: username,
+			repositories.RoleOriginAnnotation:   origin,
+		}
+	})).To(Succeed())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -13,9 +14,10 @@ import (
 )
 
 type Response struct {
-	httpStatus int
-	body       interface{}
-	headers    map[string][]string
+	httpStatus   int
+	body         interface{}
+	streamedBody io.Reader
+	headers      map[string][]string
 }
 
 func NewResponse(httpStatus int) *Response {
@@ -35,6 +37,13 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+// WithStreamedBody copies the body to the response as is, closing it when
+// done if it is an io.Closer
+func (r *Response) WithStreamedBody(contentType string, body io.Reader) *Response {
+	r.streamedBody = body
+	return r.WithHeader("Content-Type", contentType)
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -86,6 +95,10 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.streamedBody != nil {
+		return response.writeStreamTo(w)
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -104,3 +117,17 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 
 	return nil
 }
+
+func (response *Response) writeStreamTo(w http.ResponseWriter) error {
+	if closer, ok := response.streamedBody.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	w.WriteHeader(response.httpStatus)
+
+	if _, err := io.Copy(w, response.streamedBody); err != nil {
+		return fmt.Errorf("failed to stream response: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package routing_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -59,6 +60,20 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response body is streamed", func() {
+		BeforeEach(func() {
+			response = response.WithStreamedBody("application/x-tar", strings.NewReader("some-bytes"))
+		})
+
+		It("sets the given content type in the response", func() {
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+		})
+
+		It("copies the body to the response", func() {
+			Expect(rr).To(HaveHTTPBody("some-bytes"))
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
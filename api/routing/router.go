@@ -8,6 +8,17 @@ import (
 
 var URLParam = chi.URLParam
 
+// RoutePattern returns the pattern of the route matched by the request, or an
+// empty string if no route has been matched (yet)
+func RoutePattern(r *http.Request) string {
+	routeContext := chi.RouteContext(r.Context())
+	if routeContext == nil {
+		return ""
+	}
+
+	return routeContext.RoutePattern()
+}
+
 type Route struct {
 	Method  string
 	Pattern string
@@ -135,6 +135,38 @@ var _ = Describe("Router", func() {
 		})
 	})
 
+	When("a middleware inspects the route pattern", func() {
+		var routePattern string
+
+		BeforeEach(func() {
+			routePattern = ""
+			routerBuilder.UseMiddleware(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					next.ServeHTTP(w, r)
+					routePattern = routing.RoutePattern(r)
+				})
+			})
+		})
+
+		It("returns the pattern of the matched unauthenticated route", func() {
+			_, err := mkReq(router, http.MethodGet, "/hello/world")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routePattern).To(Equal("/hello/{name}"))
+		})
+
+		It("returns the pattern of the matched authenticated route", func() {
+			_, err := mkReq(router, http.MethodGet, "/auth")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routePattern).To(Equal("/auth"))
+		})
+
+		It("returns an empty pattern when no route matches", func() {
+			_, err := mkReq(router, http.MethodGet, "/does-not-exist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routePattern).To(BeEmpty())
+		})
+	})
+
 	When("a 404 Not Found handler is specified", func() {
 		BeforeEach(func() {
 			routerBuilder.SetNotFoundHandler(func(_ *http.Request) (*routing.Response, error) {
@@ -56,6 +56,10 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// The node selector and tolerations of the isolation segment the app's space is assigned to
+	// +kubebuilder:validation:Optional
+	Placement Placement `json:"placement,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -23,6 +23,10 @@ import (
 
 const (
 	BuildWorkloadFinalizerName = "kpack-image-builder.korifi.cloudfoundry.org/buildworkload"
+
+	// BuildWorkloadLabelKey labels the pods building a BuildWorkload with
+	// its name
+	BuildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
 )
 
 // BuildWorkloadSpec defines the desired state of BuildWorkload
@@ -45,6 +49,14 @@ type BuildWorkloadSpec struct {
 	// The name of the builder that should reconcile this BuildWorkload resource and execute the image building
 	// +kubebuilder:validation:Required
 	BuilderName string `json:"builderName"`
+
+	// The name of the stack to build the app image on. The default builder stack is used when empty.
+	// +kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
+	// The node selector and tolerations of the isolation segment the app's space is assigned to
+	// +kubebuilder:validation:Optional
+	Placement Placement `json:"placement,omitempty"`
 }
 
 // BuildWorkloadStatus defines the observed state of BuildWorkload
@@ -49,6 +49,10 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef corev1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Prevents users from opening ssh sessions to the app instances
+	//+kubebuilder:validation:Optional
+	DisableSSH bool `json:"disableSSH,omitempty"`
 }
 
 // AppState defines the desired state of CFApp.
@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFAuditEventTypeLabelKey       = "korifi.cloudfoundry.org/audit-event-type"
+	CFAuditEventTargetGUIDLabelKey = "korifi.cloudfoundry.org/audit-event-target-guid"
+
+	AppProcessCrashAuditEventType = "audit.app.process.crash"
+)
+
+// CFAuditEventSpec defines an audit event. Audit events are immutable and
+// live in the namespace of the space of the resource they are about
+type CFAuditEventSpec struct {
+	// The type of the event, e.g. audit.app.process.crash
+	Type string `json:"type"`
+
+	// The resource that caused the event
+	Actor AuditEventResource `json:"actor"`
+
+	// The resource the event happened to
+	Target AuditEventResource `json:"target"`
+
+	OrgGUID string `json:"orgGUID"`
+
+	// Only set for audit.app.process.crash events
+	//+kubebuilder:validation:Optional
+	ProcessCrash *ProcessCrashData `json:"processCrash,omitempty"`
+}
+
+type AuditEventResource struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type ProcessCrashData struct {
+	// The index of the instance that crashed
+	Index int32 `json:"index"`
+
+	// The reason the instance container terminated, e.g. Error or OOMKilled
+	Reason string `json:"reason"`
+
+	// The exit code of the instance container
+	ExitStatus int32 `json:"exitStatus"`
+
+	// A human readable description of the crash
+	ExitDescription string `json:"exitDescription"`
+
+	// The number of times the instance has crashed so far
+	CrashCount int32 `json:"crashCount"`
+
+	// The time the instance container terminated
+	CrashTimestamp metav1.Time `json:"crashTimestamp"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEvent records something that happened to a resource of a space,
+// e.g. the crash of an app instance
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAuditEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
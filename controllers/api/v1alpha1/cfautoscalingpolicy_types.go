@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CFAutoscalingPolicySpec struct {
+	// A reference to the CFProcess scaled by this policy. The CFProcess must be in the same namespace.
+	ProcessRef v1.LocalObjectReference `json:"processRef"`
+
+	// The lower and upper bounds of the number of instances of the process
+	//+kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+	//+kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
+
+	// The target average CPU utilization of the instances, as a percentage of the requested CPU
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	CPUUtilizationPercent *int32 `json:"cpuUtilizationPercent,omitempty"`
+
+	// The target average memory utilization of the instances, as a percentage of the process memory
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	MemoryUtilizationPercent *int32 `json:"memoryUtilizationPercent,omitempty"`
+
+	// The IANA time zone the schedules are evaluated in. Defaults to UTC
+	//+kubebuilder:validation:Optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Recurring time windows overriding the instance bounds. The first
+	// matching schedule wins
+	//+kubebuilder:validation:Optional
+	Schedules []AutoscalingSchedule `json:"schedules,omitempty"`
+
+	// Bounds for resizing the memory of the instances, requires the
+	// VerticalPodAutoscaler to be installed
+	//+kubebuilder:validation:Optional
+	Vertical *VerticalAutoscaling `json:"vertical,omitempty"`
+}
+
+type AutoscalingSchedule struct {
+	// ISO days of the week the schedule applies on, 1 is Monday and 7 is Sunday
+	//+kubebuilder:validation:MinItems=1
+	DaysOfWeek []int32 `json:"daysOfWeek"`
+
+	// The start and end of the window in 24h HH:MM format
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+
+	//+kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+	//+kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
+}
+
+type VerticalAutoscaling struct {
+	//+kubebuilder:validation:Minimum=1
+	MinMemoryMB int64 `json:"minMemoryMB"`
+	//+kubebuilder:validation:Minimum=1
+	MaxMemoryMB int64 `json:"maxMemoryMB"`
+}
+
+type CFAutoscalingPolicyStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAutoscalingPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The number of instances as last observed by the autoscaler
+	//+kubebuilder:validation:Optional
+	CurrentInstances int32 `json:"currentInstances"`
+
+	// The number of instances the autoscaler last decided on
+	//+kubebuilder:validation:Optional
+	DesiredInstances int32 `json:"desiredInstances"`
+
+	// The index of the schedule currently overriding the instance bounds, if any
+	//+kubebuilder:validation:Optional
+	ActiveSchedule *int32 `json:"activeSchedule,omitempty"`
+
+	// The memory recommended by the vertical autoscaler
+	//+kubebuilder:validation:Optional
+	RecommendedMemoryMB int64 `json:"recommendedMemoryMB,omitempty"`
+
+	// The most recent scaling decisions, newest first
+	//+kubebuilder:validation:Optional
+	RecentDecisions []ScalingDecision `json:"recentDecisions,omitempty"`
+}
+
+type ScalingDecision struct {
+	Time          metav1.Time `json:"time"`
+	FromInstances int32       `json:"fromInstances"`
+	ToInstances   int32       `json:"toInstances"`
+	Reason        string      `json:"reason"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Process",type=string,JSONPath=`.spec.processRef.name`
+//+kubebuilder:printcolumn:name="Min",type=integer,JSONPath=`.spec.minInstances`
+//+kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxInstances`
+//+kubebuilder:printcolumn:name="Current",type=integer,JSONPath=`.status.currentInstances`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAutoscalingPolicy is the Schema for the cfautoscalingpolicies API
+type CFAutoscalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAutoscalingPolicySpec   `json:"spec,omitempty"`
+	Status CFAutoscalingPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *CFAutoscalingPolicy) StatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAutoscalingPolicyList contains a list of CFAutoscalingPolicy
+type CFAutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAutoscalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAutoscalingPolicy{}, &CFAutoscalingPolicyList{})
+}
@@ -24,14 +24,16 @@ import (
 const (
 	CFBuildStateLabelKey = "korifi.cloudfoundry.org/build-state"
 
-	BuildStateStaging = "STAGING"
-	BuildStateStaged  = "STAGED"
-	BuildStateFailed  = "FAILED"
+	BuildStateStaging        = "STAGING"
+	BuildStateStaged         = "STAGED"
+	BuildStateFailed         = "FAILED"
+	BuildStateAwaitingUpload = "AWAITING_UPLOAD"
 )
 
 // CFBuildSpec defines the desired state of CFBuild
 type CFBuildSpec struct {
-	// The CFPackage associated with this build. Must be in the same namespace
+	// The CFPackage associated with this build. Must be in the same namespace.
+	// Empty for builds whose droplet is uploaded or copied
 	PackageRef v1.LocalObjectReference `json:"packageRef"`
 	// The CFApp associated with this build. Must be in the same namespace
 	AppRef v1.LocalObjectReference `json:"appRef"`
@@ -43,6 +45,12 @@ type CFBuildSpec struct {
 
 	// Specifies the buildpacks and stack for the build
 	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// The droplet of the build, when it is uploaded or copied from another
+	// build instead of being staged from the package. An empty registry
+	// image means that the droplet bits are yet to be uploaded
+	//+kubebuilder:validation:Optional
+	Droplet *BuildDropletStatus `json:"droplet,omitempty"`
 }
 
 // CFBuildStatus defines the observed state of CFBuild
@@ -55,7 +63,7 @@ type CFBuildStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	//+kubebuilder:validation:Optional
-	//+kubebuilder:validation:Enum=STAGING;STAGED;FAILED
+	//+kubebuilder:validation:Enum=STAGING;STAGED;FAILED;AWAITING_UPLOAD
 	State string `json:"state,omitempty"`
 }
 
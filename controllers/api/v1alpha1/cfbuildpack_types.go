@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFBuildpackFinalizerName = "cfBuildpack.korifi.cloudfoundry.org"
+)
+
+type CFBuildpackSpec struct {
+	DisplayName string `json:"displayName"`
+
+	// The stack the buildpack is meant to run on. Informational only, kpack
+	// checks the buildpack is compatible with the builder stack.
+	//+kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
+	// The 1-based position of the buildpack in the builder detection order
+	//+kubebuilder:validation:Minimum=1
+	Position int `json:"position"`
+
+	// Disabled buildpacks are kept in the store but left out of the builder order
+	Enabled bool `json:"enabled"`
+
+	// Locked buildpacks cannot have new bits uploaded
+	//+kubebuilder:validation:Optional
+	Locked bool `json:"locked,omitempty"`
+
+	// The buildpackage image added to the ClusterStore. Empty until bits are uploaded.
+	//+kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+}
+
+type CFBuildpackStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The id of the buildpack the ClusterStore resolved from the image
+	//+kubebuilder:validation:Optional
+	BuildpackID string `json:"buildpackId,omitempty"`
+
+	// The version of the buildpack the ClusterStore resolved from the image
+	//+kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// The image last added to the ClusterStore sources, so that it can be
+	// removed from the store when new bits are uploaded
+	//+kubebuilder:validation:Optional
+	StoreImage string `json:"storeImage,omitempty"`
+}
+
+//+kubebuilder:subresource:status
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="DisplayName",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Position",type=integer,JSONPath=`.spec.position`
+//+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFBuildpack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFBuildpackSpec `json:"spec,omitempty"`
+
+	Status CFBuildpackStatus `json:"status,omitempty"`
+}
+
+func (b *CFBuildpack) StatusConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFBuildpackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFBuildpack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFBuildpack{}, &CFBuildpackList{})
+}
@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CFIsolationSegmentSpec struct {
+	DisplayName string `json:"displayName"`
+
+	// The node selector and tolerations applied to the pods of the spaces assigned to the segment
+	//+kubebuilder:validation:Optional
+	Placement Placement `json:"placement,omitempty"`
+
+	// The GUIDs of the orgs entitled to use the segment
+	//+kubebuilder:validation:Optional
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+type CFIsolationSegmentStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:subresource:status
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="DisplayName",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFIsolationSegmentSpec `json:"spec,omitempty"`
+
+	Status CFIsolationSegmentStatus `json:"status,omitempty"`
+}
+
+func (s CFIsolationSegment) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFIsolationSegment) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Isolation Segment names are case insensitive and must be unique: '%s' already exists.", s.Spec.DisplayName)
+}
+
+func (s *CFIsolationSegment) StatusConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+func (s CFIsolationSegment) IsEntitled(orgGUID string) bool {
+	for _, o := range s.Spec.Organizations {
+		if o == orgGUID {
+			return true
+		}
+	}
+	return false
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
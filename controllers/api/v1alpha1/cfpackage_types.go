@@ -44,6 +44,10 @@ type PackageType string
 type PackageSource struct {
 	// registry (i.e an OCI image in a registry that contains application source)
 	Registry Registry `json:"registry"`
+
+	// blob (i.e. a zip file in the package blobstore that contains application source)
+	//+kubebuilder:validation:Optional
+	Blob *Blob `json:"blob,omitempty"`
 }
 
 // CFPackageStatus defines the observed state of CFPackage
@@ -23,6 +23,8 @@ import (
 
 const (
 	ProcessTypeWeb = "web"
+
+	CFProcessFinalizerName = "cfProcess.korifi.cloudfoundry.org"
 )
 
 // CFProcessSpec defines the desired state of CFProcess
@@ -93,10 +95,19 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// The usage of the process as recorded in the last app usage event
+	//+kubebuilder:validation:Optional
+	RecordedUsage *ProcessUsage `json:"recordedUsage,omitempty"`
+
+	// The label selector of the process instances, used by autoscalers through the scale subresource
+	//+kubebuilder:validation:Optional
+	InstanceSelector string `json:"instanceSelector,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.desiredInstances,statuspath=.status.actualInstances,selectorpath=.status.instanceSelector
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // CFProcess is the Schema for the cfprocesses API
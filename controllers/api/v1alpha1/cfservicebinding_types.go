@@ -28,6 +28,8 @@ const (
 
 	UnbindingFailedCondition = "UnbindingFailed"
 
+	SyslogDrainHealthyCondition = "SyslogDrainHealthy"
+
 	CFServiceBindingTypeKey = "key"
 	CFServiceBindingTypeApp = "app"
 
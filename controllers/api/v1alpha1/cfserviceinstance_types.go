@@ -58,6 +58,12 @@ type CFServiceInstanceSpec struct {
 	PlanGUID string `json:"planGuid"`
 
 	Parameters corev1.LocalObjectReference `json:"parameters,omitempty"`
+
+	// URL of a syslog endpoint that the logs of apps bound to this service
+	// instance are drained to. Supported schemes are `syslog`, `syslog-tls`
+	// and `https`. Only makes sense for user-provided service instances
+	// +optional
+	SyslogDrainURL *string `json:"syslogDrainURL,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
@@ -92,6 +98,11 @@ type CFServiceInstanceStatus struct {
 	// True if there is an upgrade available for for the service instance (i.e. the plan has a new version). Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	UpgradeAvailable bool `json:"upgradeAvailable"`
+
+	// The generation of the service instance that the last service usage
+	// event has been recorded for. Zero if no event has been recorded yet
+	//+kubebuilder:validation:Optional
+	RecordedUsageGeneration int64 `json:"recordedUsageGeneration,omitempty"`
 }
 
 type LastOperation struct {
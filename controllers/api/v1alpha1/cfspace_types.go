@@ -35,6 +35,14 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// Prevents users from opening ssh sessions to the instances of the apps in the space
+	//+kubebuilder:validation:Optional
+	DisableSSH bool `json:"disableSSH,omitempty"`
+
+	// The GUID of the CFIsolationSegment the workloads of the space are placed on
+	//+kubebuilder:validation:Optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -44,6 +52,14 @@ type CFSpaceStatus struct {
 
 	GUID string `json:"guid"`
 
+	// The placement of the isolation segment the space is assigned to
+	//+kubebuilder:validation:Optional
+	Placement Placement `json:"placement,omitempty"`
+
+	// The GUID of the CFIsolationSegment the placement has been resolved from
+	//+kubebuilder:validation:Optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
+
 	// ObservedGeneration captures the latest generation of the CFSpace that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
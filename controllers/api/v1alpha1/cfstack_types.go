@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFStackFinalizerName = "cfStack.korifi.cloudfoundry.org"
+
+	CFStackGUIDLabelKey = "korifi.cloudfoundry.org/stack-guid"
+)
+
+type CFStackSpec struct {
+	// The name apps select the stack by in lifecycle.data.stack
+	DisplayName string `json:"displayName"`
+
+	//+kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// The buildpacks stack id, which must match the io.buildpacks.stack.id
+	// label of both the build and run images
+	ID string `json:"id"`
+
+	// The image buildpacks run on during staging
+	BuildImage string `json:"buildImage"`
+
+	// The base image of the droplets built on the stack
+	RunImage string `json:"runImage"`
+}
+
+type CFStackStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The ClusterBuilder that builds apps on the stack
+	//+kubebuilder:validation:Optional
+	ClusterBuilderName string `json:"clusterBuilderName,omitempty"`
+}
+
+//+kubebuilder:subresource:status
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="DisplayName",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.spec.id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFStack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFStackSpec `json:"spec,omitempty"`
+
+	Status CFStackStatus `json:"status,omitempty"`
+}
+
+func (s CFStack) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFStack) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Stack names are case insensitive and must be unique: '%s' already exists.", s.Spec.DisplayName)
+}
+
+func (s *CFStack) StatusConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CFStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFStack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFStack{}, &CFStackList{})
+}
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFUsageEventTypeLabelKey = "korifi.cloudfoundry.org/usage-event-type"
+
+	AppUsageEventType     = "app"
+	ServiceUsageEventType = "service"
+
+	UsageStateStarted = "STARTED"
+	UsageStateStopped = "STOPPED"
+	UsageStateCreated = "CREATED"
+	UsageStateUpdated = "UPDATED"
+	UsageStateDeleted = "DELETED"
+)
+
+// CFUsageEventSpec defines a usage event. Usage events are immutable and are
+// named with time ordered UUIDs, so that sorting them by name yields the
+// order they have been recorded in
+type CFUsageEventSpec struct {
+	// The kind of resource whose usage changed
+	// +kubebuilder:validation:Enum=app;service
+	Type string `json:"type"`
+
+	// The state of the resource after the change, e.g. STARTED or STOPPED
+	// for apps and CREATED, UPDATED or DELETED for service instances
+	State string `json:"state"`
+
+	// The state of the resource before the change. Only set for app events
+	//+kubebuilder:validation:Optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	SpaceGUID string `json:"spaceGUID"`
+	SpaceName string `json:"spaceName"`
+	OrgGUID   string `json:"orgGUID"`
+
+	//+kubebuilder:validation:Optional
+	App *AppUsage `json:"app,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	ServiceInstance *ServiceInstanceUsage `json:"serviceInstance,omitempty"`
+}
+
+type AppUsage struct {
+	AppGUID     string `json:"appGUID"`
+	AppName     string `json:"appName"`
+	ProcessGUID string `json:"processGUID"`
+	ProcessType string `json:"processType"`
+
+	InstanceCount         int32 `json:"instanceCount"`
+	PreviousInstanceCount int32 `json:"previousInstanceCount"`
+
+	MemoryInMBPerInstance         int64 `json:"memoryInMBPerInstance"`
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance"`
+}
+
+type ServiceInstanceUsage struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// The plan, offering and broker are only set for managed service instances
+	//+kubebuilder:validation:Optional
+	PlanGUID string `json:"planGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	PlanName string `json:"planName,omitempty"`
+	//+kubebuilder:validation:Optional
+	OfferingGUID string `json:"offeringGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	OfferingName string `json:"offeringName,omitempty"`
+	//+kubebuilder:validation:Optional
+	BrokerGUID string `json:"brokerGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	BrokerName string `json:"brokerName,omitempty"`
+}
+
+// ProcessUsage is the usage of a process as last recorded in a usage event
+type ProcessUsage struct {
+	State     string `json:"state"`
+	Instances int32  `json:"instances"`
+	MemoryMB  int64  `json:"memoryMB"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEvent records a change of the usage of an app process or service
+// instance in the root namespace
+type CFUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEventList contains a list of CFUsageEvent
+type CFUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUsageEvent{}, &CFUsageEventList{})
+}
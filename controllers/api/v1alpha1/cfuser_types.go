@@ -0,0 +1,63 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFUserSpec defines the desired state of CFUser
+type CFUserSpec struct {
+	// The name of the user, without the origin prefix
+	Username string `json:"username"`
+
+	// The identity provider the user authenticates with, e.g. the OIDC
+	// username prefix of a UAA. Empty for users authenticated via
+	// certificates or service account tokens
+	//+kubebuilder:validation:Optional
+	Origin string `json:"origin,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.spec.username`
+//+kubebuilder:printcolumn:name="Origin",type=string,JSONPath=`.spec.origin`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUser registers a user in the root namespace, so that it is known
+// before any roles have been assigned to it
+type CFUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUserSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUserList contains a list of CFUser
+type CFUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUser{}, &CFUserList{})
+}
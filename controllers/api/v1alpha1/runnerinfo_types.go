@@ -40,6 +40,9 @@ type RunnerInfoStatus struct {
 
 type RunnerInfoCapabilities struct {
 	RollingDeploy bool `json:"rollingDeploy,omitempty"`
+	// ScaleToZero is set by runners that stop all instances of idle processes
+	// and start them again when they receive requests
+	ScaleToZero bool `json:"scaleToZero,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -42,6 +42,9 @@ const (
 
 	PodIndexLabelKey = "apps.kubernetes.io/pod-index"
 
+	RunningEnvVarGroupSecretName = "cf-running-env-var-group"
+	StagingEnvVarGroupSecretName = "cf-staging-env-var-group"
+
 	StagingConditionType   = "Staging"
 	SucceededConditionType = "Succeeded"
 
@@ -93,12 +96,27 @@ type Registry struct {
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
+// Blob is used by CFPackage and BuildWorkload to identify application source stored in the package blobstore
+type Blob struct {
+	// The URL of the blob. It is not downloadable without credentials, builders sign it before use.
+	URL string `json:"url"`
+}
+
 // RequiredLocalObjectReference is a reference to an object in the same namespace.
 // Unlike k8s.io/api/core/v1/LocalObjectReference, name is required.
 type RequiredLocalObjectReference struct {
 	Name string `json:"name"`
 }
 
+// Placement constrains the nodes workload pods are scheduled on
+type Placement struct {
+	// Labels the nodes running the pods must have
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Taints the pods tolerate
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
 // +kubebuilder:validation:Enum=DOWN;CRASHED;STARTING;RUNNING
 type InstanceState string
 
@@ -106,9 +124,43 @@ type InstanceStatus struct {
 	// The state of the instance
 	State InstanceState `json:"state"`
 
-	// The time the instance got into this status; nil if unknown
+	// The time the instance got into this status; nil if unknown. For
+	// crashed instances this is the time of the last crash
 	// +kubebuilder:validation:Optional
 	Timestamp *metav1.Time `json:"timestamp"`
+
+	// Why the instance is in this state, e.g. the reason it is not being
+	// restarted when crashed
+	// +kubebuilder:validation:Optional
+	Details string `json:"details,omitempty"`
+
+	// The number of times the instance has crashed and been restarted
+	// +kubebuilder:validation:Optional
+	CrashCount int32 `json:"crashCount,omitempty"`
+
+	// The last crash of the instance; nil if it never crashed
+	// +kubebuilder:validation:Optional
+	LastCrash *InstanceCrash `json:"lastCrash,omitempty"`
+
+	// The time of the first crash since the instance has last been running,
+	// i.e. since when the instance is crash looping; nil if the instance is
+	// running or has never crashed
+	// +kubebuilder:validation:Optional
+	CrashingSince *metav1.Time `json:"crashingSince,omitempty"`
+}
+
+type InstanceCrash struct {
+	// The reason the instance container terminated, e.g. Error or OOMKilled
+	Reason string `json:"reason"`
+
+	// The exit code of the instance container
+	ExitStatus int32 `json:"exitStatus"`
+
+	// A human readable description of the crash
+	ExitDescription string `json:"exitDescription"`
+
+	// The time the instance container terminated
+	Timestamp metav1.Time `json:"timestamp"`
 }
 
 type ServiceBinding struct {
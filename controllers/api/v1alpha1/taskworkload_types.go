@@ -37,6 +37,9 @@ type TaskWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Env []corev1.EnvVar `json:"env"`
+
+	// +kubebuilder:validation:Optional
+	Placement Placement `json:"placement,omitempty"`
 }
 
 // TaskWorkloadStatus defines the observed state of TaskWorkload
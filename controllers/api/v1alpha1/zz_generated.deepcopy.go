@@ -26,6 +26,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppUsage) DeepCopyInto(out *AppUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppUsage.
+func (in *AppUsage) DeepCopy() *AppUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(AppUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkload) DeepCopyInto(out *AppWorkload) {
 	*out = *in
@@ -131,6 +146,7 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 		*out = make([]ServiceBinding, len(*in))
 		copy(*out, *in)
 	}
+	in.Placement.DeepCopyInto(&out.Placement)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSpec.
@@ -172,6 +188,56 @@ func (in *AppWorkloadStatus) DeepCopy() *AppWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditEventResource) DeepCopyInto(out *AuditEventResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditEventResource.
+func (in *AuditEventResource) DeepCopy() *AuditEventResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditEventResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSchedule) DeepCopyInto(out *AutoscalingSchedule) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSchedule.
+func (in *AutoscalingSchedule) DeepCopy() *AutoscalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Blob) DeepCopyInto(out *Blob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Blob.
+func (in *Blob) DeepCopy() *Blob {
+	if in == nil {
+		return nil
+	}
+	out := new(Blob)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCatalogFeatures) DeepCopyInto(out *BrokerCatalogFeatures) {
 	*out = *in
@@ -294,6 +360,7 @@ func (in *BuildWorkloadSpec) DeepCopyInto(out *BuildWorkloadSpec) {
 		*out = make([]v1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	in.Placement.DeepCopyInto(&out.Placement)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWorkloadSpec.
@@ -596,26 +663,25 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuild) DeepCopyInto(out *CFBuild) {
+func (in *CFAuditEvent) DeepCopyInto(out *CFAuditEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
-func (in *CFBuild) DeepCopy() *CFBuild {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEvent.
+func (in *CFAuditEvent) DeepCopy() *CFAuditEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuild)
+	out := new(CFAuditEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuild) DeepCopyObject() runtime.Object {
+func (in *CFAuditEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -623,31 +689,31 @@ func (in *CFBuild) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
+func (in *CFAuditEventList) DeepCopyInto(out *CFAuditEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFBuild, len(*in))
+		*out = make([]CFAuditEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
-func (in *CFBuildList) DeepCopy() *CFBuildList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventList.
+func (in *CFAuditEventList) DeepCopy() *CFAuditEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildList)
+	out := new(CFAuditEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuildList) DeepCopyObject() runtime.Object {
+func (in *CFAuditEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -655,71 +721,48 @@ func (in *CFBuildList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
-	*out = *in
-	out.PackageRef = in.PackageRef
-	out.AppRef = in.AppRef
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
-func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFBuildSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
+func (in *CFAuditEventSpec) DeepCopyInto(out *CFAuditEventSpec) {
 	*out = *in
-	if in.Droplet != nil {
-		in, out := &in.Droplet, &out.Droplet
-		*out = new(BuildDropletStatus)
+	out.Actor = in.Actor
+	out.Target = in.Target
+	if in.ProcessCrash != nil {
+		in, out := &in.ProcessCrash, &out.ProcessCrash
+		*out = new(ProcessCrashData)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
-func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventSpec.
+func (in *CFAuditEventSpec) DeepCopy() *CFAuditEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildStatus)
+	out := new(CFAuditEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomain) DeepCopyInto(out *CFDomain) {
+func (in *CFAutoscalingPolicy) DeepCopyInto(out *CFAutoscalingPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
-func (in *CFDomain) DeepCopy() *CFDomain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicy.
+func (in *CFAutoscalingPolicy) DeepCopy() *CFAutoscalingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomain)
+	out := new(CFAutoscalingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomain) DeepCopyObject() runtime.Object {
+func (in *CFAutoscalingPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -727,31 +770,31 @@ func (in *CFDomain) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
+func (in *CFAutoscalingPolicyList) DeepCopyInto(out *CFAutoscalingPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFDomain, len(*in))
+		*out = make([]CFAutoscalingPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
-func (in *CFDomainList) DeepCopy() *CFDomainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicyList.
+func (in *CFAutoscalingPolicyList) DeepCopy() *CFAutoscalingPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainList)
+	out := new(CFAutoscalingPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomainList) DeepCopyObject() runtime.Object {
+func (in *CFAutoscalingPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -759,22 +802,45 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
+func (in *CFAutoscalingPolicySpec) DeepCopyInto(out *CFAutoscalingPolicySpec) {
 	*out = *in
+	out.ProcessRef = in.ProcessRef
+	if in.CPUUtilizationPercent != nil {
+		in, out := &in.CPUUtilizationPercent, &out.CPUUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUtilizationPercent != nil {
+		in, out := &in.MemoryUtilizationPercent, &out.MemoryUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AutoscalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Vertical != nil {
+		in, out := &in.Vertical, &out.Vertical
+		*out = new(VerticalAutoscaling)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
-func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicySpec.
+func (in *CFAutoscalingPolicySpec) DeepCopy() *CFAutoscalingPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainSpec)
+	out := new(CFAutoscalingPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+func (in *CFAutoscalingPolicyStatus) DeepCopyInto(out *CFAutoscalingPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -783,39 +849,51 @@ func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActiveSchedule != nil {
+		in, out := &in.ActiveSchedule, &out.ActiveSchedule
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecentDecisions != nil {
+		in, out := &in.RecentDecisions, &out.RecentDecisions
+		*out = make([]ScalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
-func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicyStatus.
+func (in *CFAutoscalingPolicyStatus) DeepCopy() *CFAutoscalingPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainStatus)
+	out := new(CFAutoscalingPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
-func (in *CFOrg) DeepCopy() *CFOrg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
+func (in *CFBuild) DeepCopy() *CFBuild {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrg)
+	out := new(CFBuild)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrg) DeepCopyObject() runtime.Object {
+func (in *CFBuild) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -823,31 +901,31 @@ func (in *CFOrg) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFOrg, len(*in))
+		*out = make([]CFBuild, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
-func (in *CFOrgList) DeepCopy() *CFOrgList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
+func (in *CFBuildList) DeepCopy() *CFBuildList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgList)
+	out := new(CFBuildList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrgList) DeepCopyObject() runtime.Object {
+func (in *CFBuildList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -855,23 +933,36 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
 	*out = *in
+	out.PackageRef = in.PackageRef
+	out.AppRef = in.AppRef
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
-func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
+func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgSpec)
+	out := new(CFBuildSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
 	*out = *in
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -881,37 +972,37 @@ func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
-func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
+func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgStatus)
+	out := new(CFBuildStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+func (in *CFBuildpack) DeepCopyInto(out *CFBuildpack) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
-func (in *CFPackage) DeepCopy() *CFPackage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpack.
+func (in *CFBuildpack) DeepCopy() *CFBuildpack {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackage)
+	out := new(CFBuildpack)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackage) DeepCopyObject() runtime.Object {
+func (in *CFBuildpack) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -919,31 +1010,31 @@ func (in *CFPackage) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+func (in *CFBuildpackList) DeepCopyInto(out *CFBuildpackList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFPackage, len(*in))
+		*out = make([]CFBuildpack, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
-func (in *CFPackageList) DeepCopy() *CFPackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackList.
+func (in *CFBuildpackList) DeepCopy() *CFBuildpackList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageList)
+	out := new(CFBuildpackList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackageList) DeepCopyObject() runtime.Object {
+func (in *CFBuildpackList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -951,24 +1042,22 @@ func (in *CFPackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+func (in *CFBuildpackSpec) DeepCopyInto(out *CFBuildpackSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	in.Source.DeepCopyInto(&out.Source)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
-func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackSpec.
+func (in *CFBuildpackSpec) DeepCopy() *CFBuildpackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageSpec)
+	out := new(CFBuildpackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+func (in *CFBuildpackStatus) DeepCopyInto(out *CFBuildpackStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -979,37 +1068,37 @@ func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
-func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackStatus.
+func (in *CFBuildpackStatus) DeepCopy() *CFBuildpackStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageStatus)
+	out := new(CFBuildpackStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+func (in *CFDomain) DeepCopyInto(out *CFDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
-func (in *CFProcess) DeepCopy() *CFProcess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
+func (in *CFDomain) DeepCopy() *CFDomain {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcess)
+	out := new(CFDomain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcess) DeepCopyObject() runtime.Object {
+func (in *CFDomain) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1017,46 +1106,31 @@ func (in *CFProcess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
-func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
-	if in == nil {
-		return nil
-	}
-	out := new(CFProcessDefaulter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFProcess, len(*in))
+		*out = make([]CFDomain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
-func (in *CFProcessList) DeepCopy() *CFProcessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
+func (in *CFDomainList) DeepCopy() *CFDomainList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessList)
+	out := new(CFDomainList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcessList) DeepCopyObject() runtime.Object {
+func (in *CFDomainList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1064,16 +1138,423 @@ func (in *CFProcessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	out.HealthCheck = in.HealthCheck
-	if in.DesiredInstances != nil {
-		in, out := &in.DesiredInstances, &out.DesiredInstances
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Ports != nil {
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
+func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
+func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegment) DeepCopyInto(out *CFIsolationSegment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegment.
+func (in *CFIsolationSegment) DeepCopy() *CFIsolationSegment {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFIsolationSegment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentList) DeepCopyInto(out *CFIsolationSegmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFIsolationSegment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentList.
+func (in *CFIsolationSegmentList) DeepCopy() *CFIsolationSegmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFIsolationSegmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentSpec) DeepCopyInto(out *CFIsolationSegmentSpec) {
+	*out = *in
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentSpec.
+func (in *CFIsolationSegmentSpec) DeepCopy() *CFIsolationSegmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentStatus) DeepCopyInto(out *CFIsolationSegmentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentStatus.
+func (in *CFIsolationSegmentStatus) DeepCopy() *CFIsolationSegmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
+func (in *CFOrg) DeepCopy() *CFOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrg) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
+func (in *CFOrgList) DeepCopy() *CFOrgList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
+func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
+func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
+func (in *CFPackage) DeepCopy() *CFPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
+func (in *CFPackageList) DeepCopy() *CFPackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
+func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
+func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
+func (in *CFProcess) DeepCopy() *CFProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
+func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
+func (in *CFProcessList) DeepCopy() *CFProcessList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.HealthCheck = in.HealthCheck
+	if in.DesiredInstances != nil {
+		in, out := &in.DesiredInstances, &out.DesiredInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]int32, len(*in))
 		copy(*out, *in)
@@ -1107,6 +1588,11 @@ func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.RecordedUsage != nil {
+		in, out := &in.RecordedUsage, &out.RecordedUsage
+		*out = new(ProcessUsage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
@@ -1615,6 +2101,11 @@ func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 		copy(*out, *in)
 	}
 	out.Parameters = in.Parameters
+	if in.SyslogDrainURL != nil {
+		in, out := &in.SyslogDrainURL, &out.SyslogDrainURL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
@@ -1728,39 +2219,214 @@ func (in *CFServiceOfferingSpec) DeepCopyInto(out *CFServiceOfferingSpec) {
 		*out = new(string)
 		**out = **in
 	}
-	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
+func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceOfferingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
+func (in *CFServicePlan) DeepCopy() *CFServicePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServicePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
+func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
+	*out = *in
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
+	in.Schemas.DeepCopyInto(&out.Schemas)
+	out.MaintenanceInfo = in.MaintenanceInfo
+	in.Visibility.DeepCopyInto(&out.Visibility)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
+func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpace) DeepCopyInto(out *CFSpace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
+func (in *CFSpace) DeepCopy() *CFSpace {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSpace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
+func (in *CFSpaceList) DeepCopy() *CFSpaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
+func (in *CFSpaceSpec) DeepCopy() *CFSpaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Placement.DeepCopyInto(&out.Placement)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
-func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceStatus.
+func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceOfferingSpec)
+	out := new(CFSpaceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
+func (in *CFStack) DeepCopyInto(out *CFStack) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
-func (in *CFServicePlan) DeepCopy() *CFServicePlan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStack.
+func (in *CFStack) DeepCopy() *CFStack {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServicePlan)
+	out := new(CFStack)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServicePlan) DeepCopyObject() runtime.Object {
+func (in *CFStack) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1768,31 +2434,31 @@ func (in *CFServicePlan) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
+func (in *CFStackList) DeepCopyInto(out *CFStackList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServicePlan, len(*in))
+		*out = make([]CFStack, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
-func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackList.
+func (in *CFStackList) DeepCopy() *CFStackList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServicePlanList)
+	out := new(CFStackList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
+func (in *CFStackList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1800,26 +2466,44 @@ func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
+func (in *CFStackSpec) DeepCopyInto(out *CFStackSpec) {
 	*out = *in
-	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
-	in.Schemas.DeepCopyInto(&out.Schemas)
-	out.MaintenanceInfo = in.MaintenanceInfo
-	in.Visibility.DeepCopyInto(&out.Visibility)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
-func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackSpec.
+func (in *CFStackSpec) DeepCopy() *CFStackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServicePlanSpec)
+	out := new(CFStackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpace) DeepCopyInto(out *CFSpace) {
+func (in *CFStackStatus) DeepCopyInto(out *CFStackStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackStatus.
+func (in *CFStackStatus) DeepCopy() *CFStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTask) DeepCopyInto(out *CFTask) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1827,18 +2511,18 @@ func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
-func (in *CFSpace) DeepCopy() *CFSpace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
+func (in *CFTask) DeepCopy() *CFTask {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpace)
+	out := new(CFTask)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpace) DeepCopyObject() runtime.Object {
+func (in *CFTask) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1846,31 +2530,31 @@ func (in *CFSpace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
+func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFSpace, len(*in))
+		*out = make([]CFTask, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
-func (in *CFSpaceList) DeepCopy() *CFSpaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
+func (in *CFTaskList) DeepCopy() *CFTaskList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceList)
+	out := new(CFTaskList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+func (in *CFTaskList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1878,22 +2562,23 @@ func (in *CFSpaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
+func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
 	*out = *in
+	out.AppRef = in.AppRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
-func (in *CFSpaceSpec) DeepCopy() *CFSpaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
+func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceSpec)
+	out := new(CFTaskSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
+func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1902,39 +2587,39 @@ func (in *CFSpaceStatus) DeepCopyInto(out *CFSpaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.DropletRef = in.DropletRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceStatus.
-func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
+func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceStatus)
+	out := new(CFTaskStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTask) DeepCopyInto(out *CFTask) {
+func (in *CFUsageEvent) DeepCopyInto(out *CFUsageEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
-func (in *CFTask) DeepCopy() *CFTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEvent.
+func (in *CFUsageEvent) DeepCopy() *CFUsageEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTask)
+	out := new(CFUsageEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFTask) DeepCopyObject() runtime.Object {
+func (in *CFUsageEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1942,31 +2627,31 @@ func (in *CFTask) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
+func (in *CFUsageEventList) DeepCopyInto(out *CFUsageEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFTask, len(*in))
+		*out = make([]CFUsageEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
-func (in *CFTaskList) DeepCopy() *CFTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventList.
+func (in *CFUsageEventList) DeepCopy() *CFUsageEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskList)
+	out := new(CFUsageEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFTaskList) DeepCopyObject() runtime.Object {
+func (in *CFUsageEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1974,40 +2659,99 @@ func (in *CFTaskList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
+func (in *CFUsageEventSpec) DeepCopyInto(out *CFUsageEventSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
+	if in.App != nil {
+		in, out := &in.App, &out.App
+		*out = new(AppUsage)
+		**out = **in
+	}
+	if in.ServiceInstance != nil {
+		in, out := &in.ServiceInstance, &out.ServiceInstance
+		*out = new(ServiceInstanceUsage)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
-func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventSpec.
+func (in *CFUsageEventSpec) DeepCopy() *CFUsageEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskSpec)
+	out := new(CFUsageEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
+func (in *CFUser) DeepCopyInto(out *CFUser) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUser.
+func (in *CFUser) DeepCopy() *CFUser {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUserList) DeepCopyInto(out *CFUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.DropletRef = in.DropletRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
-func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserList.
+func (in *CFUserList) DeepCopy() *CFUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskStatus)
+	out := new(CFUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUserSpec) DeepCopyInto(out *CFUserSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserSpec.
+func (in *CFUserSpec) DeepCopy() *CFUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2089,6 +2833,22 @@ func (in *InputParameterSchema) DeepCopy() *InputParameterSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCrash) DeepCopyInto(out *InstanceCrash) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCrash.
+func (in *InstanceCrash) DeepCopy() *InstanceCrash {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCrash)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 	*out = *in
@@ -2096,6 +2856,15 @@ func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 		in, out := &in.Timestamp, &out.Timestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.LastCrash != nil {
+		in, out := &in.LastCrash, &out.LastCrash
+		*out = new(InstanceCrash)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashingSince != nil {
+		in, out := &in.CrashingSince, &out.CrashingSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
@@ -2178,6 +2947,11 @@ func (in *MaintenanceInfo) DeepCopy() *MaintenanceInfo {
 func (in *PackageSource) DeepCopyInto(out *PackageSource) {
 	*out = *in
 	in.Registry.DeepCopyInto(&out.Registry)
+	if in.Blob != nil {
+		in, out := &in.Blob, &out.Blob
+		*out = new(Blob)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSource.
@@ -2190,6 +2964,51 @@ func (in *PackageSource) DeepCopy() *PackageSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Placement) DeepCopyInto(out *Placement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
+func (in *Placement) DeepCopy() *Placement {
+	if in == nil {
+		return nil
+	}
+	out := new(Placement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessCrashData) DeepCopyInto(out *ProcessCrashData) {
+	*out = *in
+	in.CrashTimestamp.DeepCopyInto(&out.CrashTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessCrashData.
+func (in *ProcessCrashData) DeepCopy() *ProcessCrashData {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessCrashData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessType) DeepCopyInto(out *ProcessType) {
 	*out = *in
@@ -2205,6 +3024,21 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessUsage) DeepCopyInto(out *ProcessUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessUsage.
+func (in *ProcessUsage) DeepCopy() *ProcessUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -2352,6 +3186,22 @@ func (in *RunnerInfoStatus) DeepCopy() *RunnerInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingDecision) DeepCopyInto(out *ScalingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingDecision.
+func (in *ScalingDecision) DeepCopy() *ScalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
 	*out = *in
@@ -2451,6 +3301,21 @@ func (in *ServiceInstanceSchema) DeepCopy() *ServiceInstanceSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceUsage) DeepCopyInto(out *ServiceInstanceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceUsage.
+func (in *ServiceInstanceUsage) DeepCopy() *ServiceInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePlanBrokerCatalog) DeepCopyInto(out *ServicePlanBrokerCatalog) {
 	*out = *in
@@ -2604,6 +3469,7 @@ func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Placement.DeepCopyInto(&out.Placement)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadSpec.
@@ -2638,6 +3504,21 @@ func (in *TaskWorkloadStatus) DeepCopy() *TaskWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalAutoscaling) DeepCopyInto(out *VerticalAutoscaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalAutoscaling.
+func (in *VerticalAutoscaling) DeepCopy() *VerticalAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VisibilityOrganization) DeepCopyInto(out *VisibilityOrganization) {
 	*out = *in
@@ -0,0 +1,285 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+type RegistryClient struct {
+	DeleteStub        func(context.Context, image.Creds, string, ...string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 []string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListRepositoriesStub        func(context.Context, image.Creds, string) ([]string, error)
+	listRepositoriesMutex       sync.RWMutex
+	listRepositoriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	listRepositoriesReturns struct {
+		result1 []string
+		result2 error
+	}
+	listRepositoriesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ListTagsStub        func(context.Context, image.Creds, string) (map[string]string, error)
+	listTagsMutex       sync.RWMutex
+	listTagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	listTagsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	listTagsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RegistryClient) Delete(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 ...string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RegistryClient) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *RegistryClient) DeleteCalls(stub func(context.Context, image.Creds, string, ...string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *RegistryClient) DeleteArgsForCall(i int) (context.Context, image.Creds, string, []string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *RegistryClient) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) ListRepositories(arg1 context.Context, arg2 image.Creds, arg3 string) ([]string, error) {
+	fake.listRepositoriesMutex.Lock()
+	ret, specificReturn := fake.listRepositoriesReturnsOnCall[len(fake.listRepositoriesArgsForCall)]
+	fake.listRepositoriesArgsForCall = append(fake.listRepositoriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListRepositoriesStub
+	fakeReturns := fake.listRepositoriesReturns
+	fake.recordInvocation("ListRepositories", []interface{}{arg1, arg2, arg3})
+	fake.listRepositoriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegistryClient) ListRepositoriesCallCount() int {
+	fake.listRepositoriesMutex.RLock()
+	defer fake.listRepositoriesMutex.RUnlock()
+	return len(fake.listRepositoriesArgsForCall)
+}
+
+func (fake *RegistryClient) ListRepositoriesCalls(stub func(context.Context, image.Creds, string) ([]string, error)) {
+	fake.listRepositoriesMutex.Lock()
+	defer fake.listRepositoriesMutex.Unlock()
+	fake.ListRepositoriesStub = stub
+}
+
+func (fake *RegistryClient) ListRepositoriesArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.listRepositoriesMutex.RLock()
+	defer fake.listRepositoriesMutex.RUnlock()
+	argsForCall := fake.listRepositoriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RegistryClient) ListRepositoriesReturns(result1 []string, result2 error) {
+	fake.listRepositoriesMutex.Lock()
+	defer fake.listRepositoriesMutex.Unlock()
+	fake.ListRepositoriesStub = nil
+	fake.listRepositoriesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryClient) ListRepositoriesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.listRepositoriesMutex.Lock()
+	defer fake.listRepositoriesMutex.Unlock()
+	fake.ListRepositoriesStub = nil
+	if fake.listRepositoriesReturnsOnCall == nil {
+		fake.listRepositoriesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listRepositoriesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryClient) ListTags(arg1 context.Context, arg2 image.Creds, arg3 string) (map[string]string, error) {
+	fake.listTagsMutex.Lock()
+	ret, specificReturn := fake.listTagsReturnsOnCall[len(fake.listTagsArgsForCall)]
+	fake.listTagsArgsForCall = append(fake.listTagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListTagsStub
+	fakeReturns := fake.listTagsReturns
+	fake.recordInvocation("ListTags", []interface{}{arg1, arg2, arg3})
+	fake.listTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegistryClient) ListTagsCallCount() int {
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	return len(fake.listTagsArgsForCall)
+}
+
+func (fake *RegistryClient) ListTagsCalls(stub func(context.Context, image.Creds, string) (map[string]string, error)) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = stub
+}
+
+func (fake *RegistryClient) ListTagsArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	argsForCall := fake.listTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RegistryClient) ListTagsReturns(result1 map[string]string, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	fake.listTagsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryClient) ListTagsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	if fake.listTagsReturnsOnCall == nil {
+		fake.listTagsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.listTagsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegistryClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.listRepositoriesMutex.RLock()
+	defer fake.listRepositoriesMutex.RUnlock()
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RegistryClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ cleanup.RegistryClient = new(RegistryClient)
@@ -0,0 +1,314 @@
+package cleanup
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	packageRepositorySuffix = "-packages"
+	dropletRepositorySuffix = "-droplets"
+
+	packageImageKind = "package"
+	dropletImageKind = "droplet"
+)
+
+var (
+	RegistryGCDeletedImagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "korifi",
+		Subsystem: "registry_gc",
+		Name:      "deleted_images_total",
+		Help:      "Number of unreferenced images deleted from the registry, partitioned by kind (package or droplet) and dry run.",
+	}, []string{"kind", "dry_run"})
+
+	RegistryGCEmptiedRepositoriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "korifi",
+		Subsystem: "registry_gc",
+		Name:      "emptied_repositories_total",
+		Help:      "Number of registry repositories emptied because their app no longer exists, partitioned by kind (package or droplet) and dry run.",
+	}, []string{"kind", "dry_run"})
+
+	RegistryGCErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "korifi",
+		Subsystem: "registry_gc",
+		Name:      "errors_total",
+		Help:      "Number of errors listing or deleting registry images.",
+	})
+
+	RegistryGCLastRunTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "korifi",
+		Subsystem: "registry_gc",
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Time of the last completed registry garbage collection.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		RegistryGCDeletedImagesTotal,
+		RegistryGCEmptiedRepositoriesTotal,
+		RegistryGCErrorsTotal,
+		RegistryGCLastRunTimestamp,
+	)
+}
+
+//counterfeiter:generate -o fake -fake-name RegistryClient . RegistryClient
+
+type RegistryClient interface {
+	ListRepositories(ctx context.Context, creds image.Creds, repositoryPrefix string) ([]string, error)
+	ListTags(ctx context.Context, creds image.Creds, repoRef string) (map[string]string, error)
+	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
+}
+
+// RegistryGarbageCollector periodically deletes the package and droplet
+// images that are no longer referenced by any CFPackage or CFBuild, e.g.
+// because their app, space or org has been deleted. It only looks at the
+// <app-guid>-packages and <app-guid>-droplets repositories under the
+// repository prefix. It is registered as a controller manager runnable so that
+// it only runs on the leader.
+type RegistryGarbageCollector struct {
+	k8sClient        client.Client
+	registryClient   RegistryClient
+	creds            image.Creds
+	repositoryPrefix string
+	interval         time.Duration
+	dryRun           bool
+	log              logr.Logger
+}
+
+func NewRegistryGarbageCollector(
+	k8sClient client.Client,
+	registryClient RegistryClient,
+	creds image.Creds,
+	repositoryPrefix string,
+	interval time.Duration,
+	log logr.Logger,
+) *RegistryGarbageCollector {
+	return &RegistryGarbageCollector{
+		k8sClient:        k8sClient,
+		registryClient:   registryClient,
+		creds:            creds,
+		repositoryPrefix: repositoryPrefix,
+		interval:         interval,
+		log:              log.WithName("registry-garbage-collector"),
+	}
+}
+
+// WithDryRun makes the collector only log and count the images it would
+// delete
+func (c *RegistryGarbageCollector) WithDryRun(dryRun bool) *RegistryGarbageCollector {
+	c.dryRun = dryRun
+	return c
+}
+
+func (c *RegistryGarbageCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RegistryGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+type appRepository struct {
+	ref     string
+	appGUID string
+	kind    string
+	// digestTags maps every manifest digest to its tags
+	digestTags map[string][]string
+}
+
+type liveReferences struct {
+	apps        map[string]bool
+	stagingApps map[string]bool
+	packages    map[string]bool
+	digests     map[string]bool
+}
+
+func (c *RegistryGarbageCollector) Collect(ctx context.Context) {
+	repositories, err := c.registryClient.ListRepositories(ctx, c.creds, c.repositoryPrefix)
+	if err != nil {
+		c.log.Info("failed to list repositories", "reason", err)
+		RegistryGCErrorsTotal.Inc()
+		return
+	}
+
+	// Tags are listed before the live objects, so that images pushed in
+	// between are always referenced by an object we know about
+	appRepositories := []appRepository{}
+	for _, repoRef := range repositories {
+		repo, ok := c.parseRepository(repoRef)
+		if !ok {
+			continue
+		}
+
+		var tags map[string]string
+		tags, err = c.registryClient.ListTags(ctx, c.creds, repoRef)
+		if err != nil {
+			c.log.Info("failed to list tags", "repository", repoRef, "reason", err)
+			RegistryGCErrorsTotal.Inc()
+			continue
+		}
+
+		for tag, digest := range tags {
+			repo.digestTags[digest] = append(repo.digestTags[digest], tag)
+		}
+		appRepositories = append(appRepositories, repo)
+	}
+
+	refs, err := c.listReferences(ctx)
+	if err != nil {
+		c.log.Info("failed to list live objects", "reason", err)
+		RegistryGCErrorsTotal.Inc()
+		return
+	}
+
+	for _, repo := range appRepositories {
+		c.collectRepository(ctx, repo, refs)
+	}
+
+	RegistryGCLastRunTimestamp.SetToCurrentTime()
+}
+
+func (c *RegistryGarbageCollector) parseRepository(repoRef string) (appRepository, bool) {
+	repoName, ok := strings.CutPrefix(repoRef, c.repositoryPrefix)
+	if !ok {
+		return appRepository{}, false
+	}
+
+	repo := appRepository{ref: repoRef, digestTags: map[string][]string{}}
+	if appGUID, isPackage := strings.CutSuffix(repoName, packageRepositorySuffix); isPackage {
+		repo.appGUID, repo.kind = appGUID, packageImageKind
+	} else if appGUID, isDroplet := strings.CutSuffix(repoName, dropletRepositorySuffix); isDroplet {
+		repo.appGUID, repo.kind = appGUID, dropletImageKind
+	} else {
+		return appRepository{}, false
+	}
+
+	return repo, repo.appGUID != "" && !strings.Contains(repo.appGUID, "/")
+}
+
+func (c *RegistryGarbageCollector) listReferences(ctx context.Context) (liveReferences, error) {
+	refs := liveReferences{
+		apps:        map[string]bool{},
+		stagingApps: map[string]bool{},
+		packages:    map[string]bool{},
+		digests:     map[string]bool{},
+	}
+
+	var cfApps korifiv1alpha1.CFAppList
+	if err := c.k8sClient.List(ctx, &cfApps); err != nil {
+		return liveReferences{}, err
+	}
+	for _, cfApp := range cfApps.Items {
+		refs.apps[cfApp.Name] = true
+	}
+
+	var cfPackages korifiv1alpha1.CFPackageList
+	if err := c.k8sClient.List(ctx, &cfPackages); err != nil {
+		return liveReferences{}, err
+	}
+	for _, cfPackage := range cfPackages.Items {
+		refs.packages[cfPackage.Name] = true
+		refs.addDigest(cfPackage.Spec.Source.Registry.Image)
+	}
+
+	var cfBuilds korifiv1alpha1.CFBuildList
+	if err := c.k8sClient.List(ctx, &cfBuilds); err != nil {
+		return liveReferences{}, err
+	}
+	for _, cfBuild := range cfBuilds.Items {
+		// the droplet image of a build is pushed before the build status
+		// refers to it
+		succeeded := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+		if succeeded == nil || succeeded.Status == metav1.ConditionUnknown {
+			refs.stagingApps[cfBuild.Spec.AppRef.Name] = true
+		}
+
+		if cfBuild.Status.Droplet != nil {
+			refs.addDigest(cfBuild.Status.Droplet.Registry.Image)
+		}
+	}
+
+	return refs, nil
+}
+
+func (r liveReferences) addDigest(imageRef string) {
+	if _, digest, ok := strings.Cut(imageRef, "@"); ok {
+		r.digests[digest] = true
+	}
+}
+
+func (r liveReferences) isReferenced(repo appRepository, digest string) bool {
+	if r.digests[digest] {
+		return true
+	}
+
+	// package images are tagged with the package GUID when uploaded, before
+	// the package refers to their digest
+	if repo.kind == packageImageKind {
+		for _, tag := range repo.digestTags[digest] {
+			if r.packages[tag] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *RegistryGarbageCollector) collectRepository(ctx context.Context, repo appRepository, refs liveReferences) {
+	log := c.log.WithValues("repository", repo.ref, "dryRun", c.dryRun)
+
+	if repo.kind == dropletImageKind && refs.stagingApps[repo.appGUID] {
+		log.V(1).Info("skipping repository of staging app")
+		return
+	}
+
+	dryRun := strconv.FormatBool(c.dryRun)
+	remaining := len(repo.digestTags)
+	for digest, tags := range repo.digestTags {
+		if refs.isReferenced(repo, digest) {
+			continue
+		}
+
+		log.Info("deleting unreferenced image", "digest", digest, "tags", tags)
+		if !c.dryRun {
+			if err := c.registryClient.Delete(ctx, c.creds, repo.ref+"@"+digest, tags...); err != nil {
+				log.Info("failed to delete image", "digest", digest, "reason", err)
+				RegistryGCErrorsTotal.Inc()
+				continue
+			}
+		}
+
+		RegistryGCDeletedImagesTotal.WithLabelValues(repo.kind, dryRun).Inc()
+		remaining--
+	}
+
+	if len(repo.digestTags) > 0 && remaining == 0 && !refs.apps[repo.appGUID] {
+		log.Info("emptied repository of deleted app", "app", repo.appGUID)
+		RegistryGCEmptiedRepositoriesTotal.WithLabelValues(repo.kind, dryRun).Inc()
+	}
+}
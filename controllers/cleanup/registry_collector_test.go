@@ -0,0 +1,244 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/controllers/cleanup/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RegistryGarbageCollector", func() {
+	const prefix = "my-registry.io/korifi/"
+
+	var (
+		collector      *cleanup.RegistryGarbageCollector
+		registryClient *fake.RegistryClient
+		creds          image.Creds
+		namespace      string
+		appGUID        string
+		deletedAppGUID string
+		repoTags       map[string]map[string]string
+		dryRun         bool
+
+		deletedPackageImagesBefore float64
+		emptiedRepositoriesBefore  float64
+		dryRunDeletedImagesBefore  float64
+	)
+
+	BeforeEach(func() {
+		dryRun = false
+		namespace = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		appGUID = uuid.NewString()
+		deletedAppGUID = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appGUID,
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName: "an-app",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+				DesiredState: "STOPPED",
+			},
+		})).To(Succeed())
+
+		uploadedPackage := createPackage(namespace, appGUID, uuid.NewString())
+		Expect(k8sClient.Patch(ctx, uploadedPackage, patchImage(uploadedPackage, prefix+appGUID+"-packages@sha256:uploaded"))).To(Succeed())
+		uploadingPackage := createPackage(namespace, appGUID, uuid.NewString())
+
+		createDropletBuild(namespace, appGUID, metav1.ConditionTrue, prefix+appGUID+"-droplets@sha256:current")
+
+		repoTags = map[string]map[string]string{
+			prefix + appGUID + "-packages": {
+				uploadedPackage.Name:  "sha256:uploaded",
+				uploadingPackage.Name: "sha256:uploading",
+				"deleted-package":     "sha256:deleted-package",
+			},
+			prefix + appGUID + "-droplets": {
+				"latest":       "sha256:current",
+				"b1.20240101":  "sha256:current",
+				"b0.20230101":  "sha256:old",
+				"b0.20230101a": "sha256:old",
+			},
+			prefix + deletedAppGUID + "-packages": {
+				"a-package": "sha256:orphaned",
+			},
+			prefix + "builders-cf-kpack-builder": {
+				"latest": "sha256:builder",
+			},
+		}
+
+		registryClient = new(fake.RegistryClient)
+		registryClient.ListRepositoriesStub = func(_ context.Context, _ image.Creds, _ string) ([]string, error) {
+			repos := []string{}
+			for repo := range repoTags {
+				repos = append(repos, repo)
+			}
+			return repos, nil
+		}
+		registryClient.ListTagsStub = func(_ context.Context, _ image.Creds, repoRef string) (map[string]string, error) {
+			return repoTags[repoRef], nil
+		}
+
+		creds = image.Creds{Namespace: "root-ns", SecretNames: []string{"registry-secret"}}
+
+		deletedPackageImagesBefore = testutil.ToFloat64(cleanup.RegistryGCDeletedImagesTotal.WithLabelValues("package", "false"))
+		emptiedRepositoriesBefore = testutil.ToFloat64(cleanup.RegistryGCEmptiedRepositoriesTotal.WithLabelValues("package", "false"))
+		dryRunDeletedImagesBefore = testutil.ToFloat64(cleanup.RegistryGCDeletedImagesTotal.WithLabelValues("droplet", "true"))
+	})
+
+	JustBeforeEach(func() {
+		collector = cleanup.NewRegistryGarbageCollector(controllersClient, registryClient, creds, prefix, time.Hour, ctrl.Log).WithDryRun(dryRun)
+		collector.Collect(ctx)
+	})
+
+	deletedImages := func() []string {
+		images := []string{}
+		for i := range registryClient.DeleteCallCount() {
+			_, actualCreds, imageRef, tags := registryClient.DeleteArgsForCall(i)
+			Expect(actualCreds).To(Equal(creds))
+			Expect(tags).NotTo(BeEmpty())
+			images = append(images, imageRef)
+		}
+		return images
+	}
+
+	It("lists the repositories under the prefix", func() {
+		Expect(registryClient.ListRepositoriesCallCount()).To(Equal(1))
+		_, actualCreds, actualPrefix := registryClient.ListRepositoriesArgsForCall(0)
+		Expect(actualCreds).To(Equal(creds))
+		Expect(actualPrefix).To(Equal(prefix))
+	})
+
+	It("deletes the unreferenced images", func() {
+		Expect(deletedImages()).To(ConsistOf(
+			prefix+appGUID+"-packages@sha256:deleted-package",
+			prefix+appGUID+"-droplets@sha256:old",
+			prefix+deletedAppGUID+"-packages@sha256:orphaned",
+		))
+	})
+
+	It("deletes all the tags of an unreferenced image", func() {
+		for i := range registryClient.DeleteCallCount() {
+			_, _, imageRef, tags := registryClient.DeleteArgsForCall(i)
+			if imageRef == prefix+appGUID+"-droplets@sha256:old" {
+				Expect(tags).To(ConsistOf("b0.20230101", "b0.20230101a"))
+			}
+		}
+	})
+
+	It("updates the metrics", func() {
+		Expect(testutil.ToFloat64(cleanup.RegistryGCDeletedImagesTotal.WithLabelValues("package", "false"))).To(Equal(deletedPackageImagesBefore + 2))
+		Expect(testutil.ToFloat64(cleanup.RegistryGCEmptiedRepositoriesTotal.WithLabelValues("package", "false"))).To(Equal(emptiedRepositoriesBefore + 1))
+	})
+
+	When("the app is staging", func() {
+		BeforeEach(func() {
+			createDropletBuild(namespace, appGUID, metav1.ConditionUnknown, "")
+		})
+
+		It("does not delete droplet images", func() {
+			Expect(deletedImages()).NotTo(ContainElement(prefix + appGUID + "-droplets@sha256:old"))
+		})
+	})
+
+	When("dry run is enabled", func() {
+		BeforeEach(func() {
+			dryRun = true
+		})
+
+		It("does not delete any image", func() {
+			Expect(registryClient.DeleteCallCount()).To(BeZero())
+		})
+
+		It("counts the images it would have deleted", func() {
+			Expect(testutil.ToFloat64(cleanup.RegistryGCDeletedImagesTotal.WithLabelValues("droplet", "true"))).To(Equal(dryRunDeletedImagesBefore + 1))
+		})
+	})
+
+	When("listing the tags of a repository fails", func() {
+		BeforeEach(func() {
+			registryClient.ListTagsStub = func(_ context.Context, _ image.Creds, repoRef string) (map[string]string, error) {
+				if repoRef == prefix+deletedAppGUID+"-packages" {
+					return nil, errors.New("list-tags-err")
+				}
+				return repoTags[repoRef], nil
+			}
+		})
+
+		It("still collects the other repositories", func() {
+			Expect(deletedImages()).To(ConsistOf(
+				prefix+appGUID+"-packages@sha256:deleted-package",
+				prefix+appGUID+"-droplets@sha256:old",
+			))
+		})
+	})
+
+	When("listing the repositories fails", func() {
+		BeforeEach(func() {
+			registryClient.ListRepositoriesStub = nil
+			registryClient.ListRepositoriesReturns(nil, errors.New("catalog-err"))
+		})
+
+		It("does not delete any image", func() {
+			Expect(registryClient.ListTagsCallCount()).To(BeZero())
+			Expect(registryClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+})
+
+func patchImage(cfPackage *korifiv1alpha1.CFPackage, imageRef string) client.Patch {
+	patch := client.MergeFrom(cfPackage.DeepCopy())
+	cfPackage.Spec.Source.Registry.Image = imageRef
+	return patch
+}
+
+func createDropletBuild(namespace, appGUID string, succeeded metav1.ConditionStatus, imageRef string) *korifiv1alpha1.CFBuild {
+	bld := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: namespace,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef:    corev1.LocalObjectReference{Name: appGUID},
+			Lifecycle: korifiv1alpha1.Lifecycle{Type: "buildpack"},
+		},
+	}
+	Expect(k8sClient.Create(ctx, bld)).To(Succeed())
+
+	meta.SetStatusCondition(&bld.Status.Conditions, metav1.Condition{
+		Type:   korifiv1alpha1.SucceededConditionType,
+		Status: succeeded,
+		Reason: "Staging",
+	})
+	if imageRef != "" {
+		bld.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+			Registry: korifiv1alpha1.Registry{Image: imageRef},
+		}
+	}
+	Expect(k8sClient.Status().Update(ctx, bld)).To(Succeed())
+
+	return bld
+}
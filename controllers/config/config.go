@@ -1,11 +1,14 @@
 package config
 
 import (
+	"errors"
 	"time"
 
 	"go.uber.org/zap/zapcore"
 
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 )
 
 type ControllerConfig struct {
@@ -14,7 +17,10 @@ type ControllerConfig struct {
 	CFStagingResources               CFStagingResources `yaml:"cfStagingResources"`
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
+	ContainerRepositoryPrefix        string             `yaml:"containerRepositoryPrefix"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	UsageEventRetention              string             `yaml:"usageEventRetention"`
+	AuditEventRetention              string             `yaml:"auditEventRetention"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
@@ -24,7 +30,10 @@ type ControllerConfig struct {
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int32             `yaml:"spaceFinalizerAppDeletionTimeout"`
 
-	Networking Networking `yaml:"networking"`
+	Networking                Networking                `yaml:"networking"`
+	Tracing                   tracing.Config            `yaml:"tracing"`
+	RegistryGarbageCollection RegistryGarbageCollection `yaml:"registryGarbageCollection"`
+	PackageBlobstore          blobstore.Config          `yaml:"packageBlobstore"`
 
 	ExperimentalManagedServicesEnabled bool `yaml:"experimentalManagedServicesEnabled"`
 	TrustInsecureServiceBrokers        bool `yaml:"trustInsecureServiceBrokers"`
@@ -43,16 +52,27 @@ type CFStagingResources struct {
 	MemoryMB     int64 `yaml:"memoryMB"`
 }
 
+// RegistryGarbageCollection configures the periodic deletion of package and
+// droplet images that are no longer referenced by any CFPackage or CFBuild
+type RegistryGarbageCollection struct {
+	Enabled  bool   `yaml:"enabled"`
+	DryRun   bool   `yaml:"dryRun"`
+	Interval string `yaml:"interval"`
+}
+
 type Networking struct {
 	GatewayName      string `yaml:"gatewayName"`
 	GatewayNamespace string `yaml:"gatewayNamespace"`
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                   = 30 * 24 * time.Hour
+	defaultUsageEventRetention       = 31 * 24 * time.Hour
+	defaultAuditEventRetention       = 31 * 24 * time.Hour
+	defaultTimeout             int32 = 60
+	defaultJobTTL                    = 24 * time.Hour
+	defaultBuildCacheMB              = 2048
+	defaultRegistryGCInterval        = 24 * time.Hour
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -74,6 +94,18 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFStagingResources.BuildCacheMB = defaultBuildCacheMB
 	}
 
+	if err = config.Tracing.Validate(); err != nil {
+		return nil, err
+	}
+
+	if config.RegistryGarbageCollection.Enabled && config.ContainerRepositoryPrefix == "" {
+		return nil, errors.New("registry garbage collection requires a container repository prefix")
+	}
+
+	if err = config.PackageBlobstore.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
@@ -93,3 +125,27 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseUsageEventRetention() (time.Duration, error) {
+	if c.UsageEventRetention == "" {
+		return defaultUsageEventRetention, nil
+	}
+
+	return tools.ParseDuration(c.UsageEventRetention)
+}
+
+func (c ControllerConfig) ParseAuditEventRetention() (time.Duration, error) {
+	if c.AuditEventRetention == "" {
+		return defaultAuditEventRetention, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventRetention)
+}
+
+func (c ControllerConfig) ParseRegistryGCInterval() (time.Duration, error) {
+	if c.RegistryGarbageCollection.Interval == "" {
+		return defaultRegistryGCInterval, nil
+	}
+
+	return tools.ParseDuration(c.RegistryGarbageCollection.Interval)
+}
@@ -7,6 +7,8 @@ import (
 
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -147,6 +149,48 @@ var _ = Describe("LoadFromPath", func() {
 			Expect(retConfig.CFStagingResources.BuildCacheMB).To(Equal(int64(2048)))
 		})
 	})
+
+	When("tracing is enabled without an endpoint", func() {
+		BeforeEach(func() {
+			cfg.Tracing = tracing.Config{Enabled: true}
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError("tracing requires an endpoint"))
+		})
+	})
+
+	When("registry garbage collection is enabled", func() {
+		BeforeEach(func() {
+			cfg.RegistryGarbageCollection = config.RegistryGarbageCollection{Enabled: true}
+			cfg.ContainerRepositoryPrefix = "my-registry.io/korifi/"
+		})
+
+		It("succeeds", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.RegistryGarbageCollection.Enabled).To(BeTrue())
+		})
+
+		When("the container repository prefix is not set", func() {
+			BeforeEach(func() {
+				cfg.ContainerRepositoryPrefix = ""
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(MatchError("registry garbage collection requires a container repository prefix"))
+			})
+		})
+	})
+
+	When("the package blobstore is invalid", func() {
+		BeforeEach(func() {
+			cfg.PackageBlobstore = blobstore.Config{Type: "Filesystem", URL: "https://api.example.com"}
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError("the Filesystem blobstore requires a credentials path"))
+		})
+	})
 })
 
 var _ = Describe("ParseTaskTTL", func() {
@@ -194,3 +238,143 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseUsageEventRetention", func() {
+	var (
+		retentionString string
+		retention       time.Duration
+		parseErr        error
+	)
+
+	BeforeEach(func() {
+		retentionString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			UsageEventRetention: retentionString,
+		}
+
+		retention, parseErr = cfg.ParseUsageEventRetention()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(retention).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			retentionString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(retention).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			retentionString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseAuditEventRetention", func() {
+	var (
+		retentionString string
+		retention       time.Duration
+		parseErr        error
+	)
+
+	BeforeEach(func() {
+		retentionString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventRetention: retentionString,
+		}
+
+		retention, parseErr = cfg.ParseAuditEventRetention()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(retention).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			retentionString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(retention).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			retentionString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseRegistryGCInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			RegistryGarbageCollection: config.RegistryGarbageCollection{
+				Interval: intervalString,
+			},
+		}
+
+		interval, parseErr = cfg.ParseRegistryGCInterval()
+	})
+
+	It("returns 24 hours by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			intervalString = "6h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(6 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
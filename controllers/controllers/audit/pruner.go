@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewPruner returns a pruner that deletes the audit events of all spaces that
+// are older than the retention period
+func NewPruner(k8sClient client.Client, retention time.Duration, log logr.Logger) *shared.Pruner {
+	return shared.NewPruner(
+		k8sClient,
+		func() client.ObjectList { return &korifiv1alpha1.CFAuditEventList{} },
+		retention,
+		log.WithName("audit-event-pruner"),
+	)
+}
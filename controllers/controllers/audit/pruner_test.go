@@ -0,0 +1,83 @@
+package audit_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/audit"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Pruner", func() {
+	var (
+		spaceGUID   string
+		retention   time.Duration
+		stopPruning context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		retention = time.Hour
+
+		spaceGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceGUID,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: korifiv1alpha1.AppProcessCrashAuditEventType,
+			},
+		})).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var pruningCtx context.Context
+		pruningCtx, stopPruning = context.WithCancel(ctx)
+
+		pruner := audit.NewPruner(k8sManager.GetClient(), retention, ctrl.Log).WithInterval(100 * time.Millisecond)
+		go func() {
+			defer GinkgoRecover()
+			Expect(pruner.Start(pruningCtx)).To(Succeed())
+		}()
+	})
+
+	AfterEach(func() {
+		stopPruning()
+	})
+
+	listAuditEvents := func(g Gomega) []korifiv1alpha1.CFAuditEvent {
+		auditEvents := korifiv1alpha1.CFAuditEventList{}
+		g.Expect(adminClient.List(ctx, &auditEvents, client.InNamespace(spaceGUID))).To(Succeed())
+		return auditEvents.Items
+	}
+
+	It("keeps the audit events within the retention period", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(listAuditEvents(g)).To(HaveLen(1))
+		}, "2s").Should(Succeed())
+	})
+
+	When("the audit events are older than the retention period", func() {
+		BeforeEach(func() {
+			retention = time.Nanosecond
+		})
+
+		It("deletes them", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAuditEvents(g)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Recorder records audit events as CFAuditEvents in the space namespace of
+// the resource they are about
+type Recorder struct {
+	k8sClient client.Client
+}
+
+func NewRecorder(k8sClient client.Client) *Recorder {
+	return &Recorder{
+		k8sClient: k8sClient,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+
+// RecordProcessCrash records an audit.app.process.crash event for the last
+// crash of a process instance. The event is named after the crash, so that
+// recording the same crash again is a no-op.
+func (r *Recorder) RecordProcessCrash(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	index string,
+	instanceStatus korifiv1alpha1.InstanceStatus,
+) error {
+	if instanceStatus.LastCrash == nil {
+		return nil
+	}
+
+	indexInt, err := strconv.Atoi(index)
+	if err != nil {
+		return fmt.Errorf("invalid instance index %q: %w", index, err)
+	}
+
+	space, err := shared.FindSpace(ctx, r.k8sClient, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	orgGUID := ""
+	if space != nil {
+		orgGUID = space.Namespace
+	}
+
+	lastCrash := instanceStatus.LastCrash
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfProcess.Namespace,
+			Name: tools.NamespacedUUID(
+				cfProcess.Name,
+				korifiv1alpha1.AppProcessCrashAuditEventType,
+				index,
+				lastCrash.Timestamp.UTC().Format(time.RFC3339Nano),
+			),
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: korifiv1alpha1.AppProcessCrashAuditEventType,
+			Actor: korifiv1alpha1.AuditEventResource{
+				GUID: cfProcess.Name,
+				Type: "process",
+				Name: cfProcess.Spec.ProcessType,
+			},
+			Target: korifiv1alpha1.AuditEventResource{
+				GUID: cfApp.Name,
+				Type: "app",
+				Name: cfApp.Spec.DisplayName,
+			},
+			OrgGUID: orgGUID,
+			ProcessCrash: &korifiv1alpha1.ProcessCrashData{
+				Index:           int32(indexInt),
+				Reason:          lastCrash.Reason,
+				ExitStatus:      lastCrash.ExitStatus,
+				ExitDescription: lastCrash.ExitDescription,
+				CrashCount:      instanceStatus.CrashCount,
+				CrashTimestamp:  lastCrash.Timestamp,
+			},
+		},
+	}
+	auditEvent.Labels = map[string]string{
+		korifiv1alpha1.GUIDLabelKey:                   auditEvent.Name,
+		korifiv1alpha1.SpaceGUIDKey:                   auditEvent.Namespace,
+		korifiv1alpha1.CFAuditEventTypeLabelKey:       auditEvent.Spec.Type,
+		korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: auditEvent.Spec.Target.GUID,
+	}
+
+	// audit events go away together with the app they are about
+	if err = controllerutil.SetOwnerReference(cfApp, auditEvent, r.k8sClient.Scheme()); err != nil {
+		return err
+	}
+
+	if err = r.k8sClient.Create(ctx, auditEvent); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("audit event recorded", "guid", auditEvent.Name, "type", auditEvent.Spec.Type)
+	return nil
+}
@@ -0,0 +1,164 @@
+package audit_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/audit"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		recorder       *audit.Recorder
+		orgGUID        string
+		spaceGUID      string
+		cfApp          *korifiv1alpha1.CFApp
+		cfProcess      *korifiv1alpha1.CFProcess
+		instanceStatus korifiv1alpha1.InstanceStatus
+	)
+
+	BeforeEach(func() {
+		recorder = audit.NewRecorder(k8sManager.GetClient())
+
+		orgGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: orgGUID},
+		})).To(Succeed())
+
+		spaceGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgGUID,
+				Name:      spaceGUID,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		})).To(Succeed())
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceGUID,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceGUID,
+				Name:      "process-guid",
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				ProcessType: "web",
+			},
+		}
+
+		instanceStatus = korifiv1alpha1.InstanceStatus{
+			State:      korifiv1alpha1.InstanceStateCrashed,
+			CrashCount: 3,
+			LastCrash: &korifiv1alpha1.InstanceCrash{
+				Reason:          "OOMKilled",
+				ExitStatus:      137,
+				ExitDescription: "Exited with status 137 (out of memory)",
+				Timestamp:       metav1.NewTime(time.UnixMilli(3000).UTC()),
+			},
+		}
+	})
+
+	Describe("RecordProcessCrash", func() {
+		JustBeforeEach(func() {
+			Expect(recorder.RecordProcessCrash(ctx, cfApp, cfProcess, "2", instanceStatus)).To(Succeed())
+		})
+
+		It("records a process crash audit event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAuditEvents(g, spaceGUID)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": SatisfyAll(
+							HaveKeyWithValue(korifiv1alpha1.CFAuditEventTypeLabelKey, korifiv1alpha1.AppProcessCrashAuditEventType),
+							HaveKeyWithValue(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, cfApp.Name),
+							HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, spaceGUID),
+						),
+						"OwnerReferences": ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Name": Equal(cfApp.Name),
+						})),
+					}),
+					"Spec": MatchAllFields(Fields{
+						"Type": Equal(korifiv1alpha1.AppProcessCrashAuditEventType),
+						"Actor": Equal(korifiv1alpha1.AuditEventResource{
+							GUID: "process-guid",
+							Type: "process",
+							Name: "web",
+						}),
+						"Target": Equal(korifiv1alpha1.AuditEventResource{
+							GUID: cfApp.Name,
+							Type: "app",
+							Name: "my-app",
+						}),
+						"OrgGUID": Equal(orgGUID),
+						"ProcessCrash": PointTo(MatchAllFields(Fields{
+							"Index":           BeEquivalentTo(2),
+							"Reason":          Equal("OOMKilled"),
+							"ExitStatus":      BeEquivalentTo(137),
+							"ExitDescription": Equal("Exited with status 137 (out of memory)"),
+							"CrashCount":      BeEquivalentTo(3),
+							"CrashTimestamp": MatchAllFields(Fields{
+								"Time": BeTemporally("==", time.UnixMilli(3000).UTC()),
+							}),
+						})),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the crash is recorded again", func() {
+			JustBeforeEach(func() {
+				Expect(recorder.RecordProcessCrash(ctx, cfApp, cfProcess, "2", instanceStatus)).To(Succeed())
+			})
+
+			It("records it only once", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(listAuditEvents(g, spaceGUID)).To(HaveLen(1))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the instance has never crashed", func() {
+			BeforeEach(func() {
+				instanceStatus.LastCrash = nil
+			})
+
+			It("does not record an audit event", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(listAuditEvents(g, spaceGUID)).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
+
+func listAuditEvents(g Gomega, namespace string) []korifiv1alpha1.CFAuditEvent {
+	auditEvents := korifiv1alpha1.CFAuditEventList{}
+	g.Expect(adminClient.List(ctx, &auditEvents, client.InNamespace(namespace))).To(Succeed())
+	return auditEvents.Items
+}
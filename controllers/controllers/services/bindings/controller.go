@@ -18,13 +18,17 @@ package bindings
 
 import (
 	"context"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,16 +38,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const syslogDrainHealthCheckInterval = 30 * time.Second
+
 type DelegateReconciler interface {
 	ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error)
 }
 
+//counterfeiter:generate -o fake -fake-name SyslogDrainer . SyslogDrainer
+
+type SyslogDrainer interface {
+	EnsureDrain(drain drains.Drain) error
+	StopDrain(bindingGUID string)
+	Health(bindingGUID string) drains.Health
+}
+
 type Reconciler struct {
 	k8sClient         client.Client
 	scheme            *runtime.Scheme
 	log               logr.Logger
 	upsiReconciler    DelegateReconciler
 	managedReconciler DelegateReconciler
+	syslogDrainer     SyslogDrainer
 }
 
 func NewReconciler(
@@ -52,6 +67,7 @@ func NewReconciler(
 	log logr.Logger,
 	upsiCredentialsReconciler DelegateReconciler,
 	managedCredentialsReconciler DelegateReconciler,
+	syslogDrainer SyslogDrainer,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding] {
 	cfBindingReconciler := &Reconciler{
 		k8sClient:         k8sClient,
@@ -59,6 +75,7 @@ func NewReconciler(
 		log:               log,
 		upsiReconciler:    upsiCredentialsReconciler,
 		managedReconciler: managedCredentialsReconciler,
+		syslogDrainer:     syslogDrainer,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, cfBindingReconciler)
 }
@@ -106,6 +123,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	cfServiceBinding.Status.ObservedGeneration = cfServiceBinding.Generation
 	log.V(1).Info("set observed generation", "generation", cfServiceBinding.Status.ObservedGeneration)
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		r.syslogDrainer.StopDrain(cfServiceBinding.Name)
+	}
+
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
 	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
 	if err != nil {
@@ -123,7 +144,63 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 		return res, err
 	}
 
-	return ctrl.Result{}, nil
+	return r.reconcileSyslogDrain(ctx, cfServiceInstance, cfServiceBinding)
+}
+
+func (r *Reconciler) reconcileSyslogDrain(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	if !needsSyslogDrain(cfServiceInstance, cfServiceBinding) {
+		r.syslogDrainer.StopDrain(cfServiceBinding.Name)
+		meta.RemoveStatusCondition(&cfServiceBinding.Status.Conditions, korifiv1alpha1.SyslogDrainHealthyCondition)
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("AppNotFound")
+	}
+
+	err = r.syslogDrainer.EnsureDrain(drains.Drain{
+		BindingGUID: cfServiceBinding.Name,
+		Namespace:   cfServiceBinding.Namespace,
+		AppGUID:     cfApp.Name,
+		Hostname:    cfApp.Spec.DisplayName,
+		URL:         *cfServiceInstance.Spec.SyslogDrainURL,
+	})
+	if err != nil {
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SyslogDrainHealthyCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "DrainFailed",
+			Message:            err.Error(),
+			ObservedGeneration: cfServiceBinding.Generation,
+		})
+		return ctrl.Result{RequeueAfter: syslogDrainHealthCheckInterval}, nil
+	}
+
+	health := r.syslogDrainer.Health(cfServiceBinding.Name)
+	healthyCondition := metav1.Condition{
+		Type:               korifiv1alpha1.SyslogDrainHealthyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Draining",
+		ObservedGeneration: cfServiceBinding.Generation,
+	}
+	if !health.Healthy {
+		healthyCondition.Status = metav1.ConditionFalse
+		healthyCondition.Reason = "DeliveryFailed"
+		healthyCondition.Message = health.LastError
+	}
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, healthyCondition)
+
+	return ctrl.Result{RequeueAfter: syslogDrainHealthCheckInterval}, nil
+}
+
+func needsSyslogDrain(cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) bool {
+	return cfServiceBinding.GetDeletionTimestamp().IsZero() &&
+		cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeApp &&
+		cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType &&
+		cfServiceInstance.Spec.SyslogDrainURL != nil &&
+		*cfServiceInstance.Spec.SyslogDrainURL != ""
 }
 
 func (r *Reconciler) reconcileByType(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
@@ -8,6 +8,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/tools"
@@ -263,6 +264,99 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		It("does not start a syslog drain", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(syslogDrainer.EnsureDrainCallCount()).To(BeZero())
+			}).Should(Succeed())
+		})
+
+		When("the service instance has a syslog drain url", func() {
+			var cfApp *korifiv1alpha1.CFApp
+
+			BeforeEach(func() {
+				cfApp = &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      cfAppGUID,
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						DisplayName:  "my-app",
+						DesiredState: korifiv1alpha1.StoppedState,
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.SyslogDrainURL = tools.PtrTo("syslog-tls://logs.example.com:6514")
+				})).To(Succeed())
+			})
+
+			It("starts a drain for the app", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(syslogDrainer.EnsureDrainCallCount()).NotTo(BeZero())
+					drain := syslogDrainer.EnsureDrainArgsForCall(syslogDrainer.EnsureDrainCallCount() - 1)
+					g.Expect(drain).To(Equal(drains.Drain{
+						BindingGUID: binding.Name,
+						Namespace:   testNamespace,
+						AppGUID:     cfAppGUID,
+						Hostname:    "my-app",
+						URL:         "syslog-tls://logs.example.com:6514",
+					}))
+				}).Should(Succeed())
+			})
+
+			It("sets the SyslogDrainHealthy condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.SyslogDrainHealthyCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+
+			When("the drain cannot deliver logs", func() {
+				BeforeEach(func() {
+					syslogDrainer.HealthReturns(drains.Health{
+						Active:    true,
+						Healthy:   false,
+						LastError: "connection refused",
+					})
+				})
+
+				It("sets the SyslogDrainHealthy condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.SyslogDrainHealthyCondition)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("DeliveryFailed")),
+							HasMessage(Equal("connection refused")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the binding is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(syslogDrainer.EnsureDrainCallCount()).NotTo(BeZero())
+					}).Should(Succeed())
+					Expect(adminClient.Delete(ctx, binding)).To(Succeed())
+				})
+
+				It("stops the drain", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(syslogDrainer.StopDrainCallCount()).NotTo(BeZero())
+						g.Expect(syslogDrainer.StopDrainArgsForCall(syslogDrainer.StopDrainCallCount() - 1)).To(Equal(binding.Name))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the service instance is not available", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Delete(ctx, instance)).To(Succeed())
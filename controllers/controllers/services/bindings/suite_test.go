@@ -24,8 +24,10 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
+	bindingsfake "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -52,6 +54,7 @@ var (
 	adminClient         client.Client
 	k8sManager          manager.Manager
 	brokerClientFactory *fake.BrokerClientFactory
+	syslogDrainer       *bindingsfake.SyslogDrainer
 	rootNamespace       string
 )
 
@@ -101,6 +104,8 @@ var _ = BeforeEach(func() {
 	})).To(Succeed())
 
 	brokerClientFactory = new(fake.BrokerClientFactory)
+	syslogDrainer = new(bindingsfake.SyslogDrainer)
+	syslogDrainer.HealthReturns(drains.Health{Active: true, Healthy: true})
 
 	err := bindings.NewReconciler(
 		k8sManager.GetClient(),
@@ -108,6 +113,7 @@ var _ = BeforeEach(func() {
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		upsi.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
 		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, k8sManager.GetScheme()),
+		syslogDrainer,
 	).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
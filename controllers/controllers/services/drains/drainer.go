@@ -0,0 +1,288 @@
+package drains
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	maxSendAttempts  = 3
+	sendRetryBackoff = time.Second
+)
+
+type drainer struct {
+	drain           Drain
+	clientset       kubernetes.Interface
+	writer          Writer
+	podPollInterval time.Duration
+	startTime       time.Time
+	log             logr.Logger
+	cancel          context.CancelFunc
+
+	mutex         sync.Mutex
+	tails         map[string]struct{}
+	lastSent      map[string]time.Time
+	lastError     error
+	lastErrorTime time.Time
+	lastSuccess   time.Time
+}
+
+func newDrainer(
+	drain Drain,
+	clientset kubernetes.Interface,
+	writer Writer,
+	podPollInterval time.Duration,
+	cancel context.CancelFunc,
+	log logr.Logger,
+) *drainer {
+	return &drainer{
+		drain:           drain,
+		clientset:       clientset,
+		writer:          writer,
+		podPollInterval: podPollInterval,
+		startTime:       time.Now(),
+		log:             log,
+		cancel:          cancel,
+		tails:           map[string]struct{}{},
+		lastSent:        map[string]time.Time{},
+	}
+}
+
+func (d *drainer) run(ctx context.Context) {
+	defer func() {
+		_ = d.writer.Close()
+	}()
+
+	ticker := time.NewTicker(d.podPollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.tailNewContainers(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *drainer) stop() {
+	d.cancel()
+}
+
+func (d *drainer) health() Health {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	health := Health{
+		Active:  true,
+		Healthy: true,
+	}
+
+	if d.lastError != nil && d.lastErrorTime.After(d.lastSuccess) {
+		health.Healthy = false
+		health.LastError = d.lastError.Error()
+		health.LastErrorTime = d.lastErrorTime
+	}
+
+	return health
+}
+
+func (d *drainer) tailNewContainers(ctx context.Context) {
+	pods, err := d.clientset.CoreV1().Pods(d.drain.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			korifiv1alpha1.CFAppGUIDLabelKey: d.drain.AppGUID,
+		}).String(),
+	})
+	if err != nil {
+		d.log.Info("failed to list app pods", "reason", err)
+		return
+	}
+
+	runningContainers := map[string]struct{}{}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running == nil || status.ContainerID == "" {
+				continue
+			}
+			runningContainers[status.ContainerID] = struct{}{}
+
+			if !d.startTailing(status.ContainerID) {
+				continue
+			}
+
+			sinceTime := d.startTime
+			if status.State.Running.StartedAt.After(sinceTime) {
+				sinceTime = status.State.Running.StartedAt.Time
+			}
+			if lastSent := d.getLastSent(status.ContainerID); lastSent.After(sinceTime) {
+				sinceTime = lastSent
+			}
+
+			go d.tail(ctx, pod, status, sinceTime)
+		}
+	}
+
+	d.forgetStoppedContainers(runningContainers)
+}
+
+func (d *drainer) startTailing(containerID string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.tails[containerID]; ok {
+		return false
+	}
+	d.tails[containerID] = struct{}{}
+
+	return true
+}
+
+func (d *drainer) stopTailing(containerID string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	delete(d.tails, containerID)
+}
+
+func (d *drainer) getLastSent(containerID string) time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.lastSent[containerID]
+}
+
+func (d *drainer) setLastSent(containerID string, timestamp time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.lastSent[containerID] = timestamp
+}
+
+func (d *drainer) forgetStoppedContainers(runningContainers map[string]struct{}) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for containerID := range d.lastSent {
+		if _, ok := runningContainers[containerID]; !ok {
+			delete(d.lastSent, containerID)
+		}
+	}
+}
+
+func (d *drainer) tail(ctx context.Context, pod corev1.Pod, status corev1.ContainerStatus, sinceTime time.Time) {
+	defer d.stopTailing(status.ContainerID)
+
+	log := d.log.WithValues("pod", pod.Name, "container", status.Name)
+
+	stream, err := d.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  status.Name,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &metav1.Time{Time: sinceTime},
+	}).Stream(ctx)
+	if err != nil {
+		log.Info("failed to stream container logs", "reason", err)
+		return
+	}
+	defer stream.Close()
+
+	// SinceTime has a precision of seconds, hence lines that have already
+	// been sent before the stream was resumed are skipped by their timestamp
+	lastSent := d.getLastSent(status.ContainerID)
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		msg := toMessage(d.drain, pod, line)
+		if !lastSent.IsZero() && !msg.Timestamp.After(lastSent) {
+			continue
+		}
+
+		if err = d.send(ctx, msg); err != nil {
+			// the next poll tails the container again from the last sent line
+			log.Info("failed to send log line", "reason", err)
+			return
+		}
+		d.setLastSent(status.ContainerID, msg.Timestamp)
+	}
+}
+
+// send retries failed writes with an exponential back-off, so that short
+// outages of the drain do not lose log lines
+func (d *drainer) send(ctx context.Context, msg Message) error {
+	backoff := sendRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := d.writer.Write(ctx, msg)
+		d.recordSendResult(ctx, err)
+		if err == nil || attempt == maxSendAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (d *drainer) recordSendResult(ctx context.Context, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err != nil {
+		if ctx.Err() == nil {
+			d.lastError = err
+			d.lastErrorTime = time.Now()
+		}
+		return
+	}
+
+	d.lastSuccess = time.Now()
+}
+
+func toMessage(drain Drain, pod corev1.Pod, line string) Message {
+	timestamp := time.Now()
+	if ts, body, found := strings.Cut(line, " "); found {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			timestamp = t
+			line = body
+		}
+	}
+
+	processType := pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]
+
+	return Message{
+		Timestamp:     timestamp,
+		Hostname:      drain.Hostname,
+		AppGUID:       drain.AppGUID,
+		ProcessType:   processType,
+		InstanceIndex: instanceIndex(pod),
+		Body:          line,
+	}
+}
+
+func instanceIndex(pod corev1.Pod) string {
+	if index, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]; ok {
+		return index
+	}
+
+	return "0"
+}
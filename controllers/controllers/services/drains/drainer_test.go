@@ -0,0 +1,151 @@
+package drains_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type failingWriter struct {
+	mutex    sync.Mutex
+	failures int
+	attempts []drains.Message
+	sent     []drains.Message
+}
+
+func (w *failingWriter) Write(_ context.Context, msg drains.Message) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.attempts = append(w.attempts, msg)
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("write failed")
+	}
+
+	w.sent = append(w.sent, msg)
+	return nil
+}
+
+func (w *failingWriter) Close() error {
+	return nil
+}
+
+func (w *failingWriter) getAttempts() []drains.Message {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return append([]drains.Message{}, w.attempts...)
+}
+
+func (w *failingWriter) getSent() []drains.Message {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return append([]drains.Message{}, w.sent...)
+}
+
+var _ = Describe("Drainer", func() {
+	var (
+		writer      *failingWriter
+		manager     *drains.Manager
+		stopManager context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		writer = &failingWriter{failures: 1}
+	})
+
+	JustBeforeEach(func() {
+		clientset := k8sfake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "space-guid",
+				Name:      "app-pod-0",
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     "app-guid",
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "0",
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:        "application",
+					ContainerID: "containerd://app",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()},
+					},
+				}},
+			},
+		})
+
+		manager = drains.NewManager(clientset, func(string) (drains.Writer, error) {
+			return writer, nil
+		}, ctrl.Log).WithPodPollInterval(time.Hour)
+
+		var managerCtx context.Context
+		managerCtx, stopManager = context.WithCancel(context.Background())
+		go func() {
+			defer GinkgoRecover()
+			Expect(manager.Start(managerCtx)).To(Succeed())
+		}()
+		DeferCleanup(func() {
+			stopManager()
+		})
+
+		Eventually(func() error {
+			return manager.EnsureDrain(drains.Drain{
+				BindingGUID: "binding-guid",
+				Namespace:   "space-guid",
+				AppGUID:     "app-guid",
+				URL:         "syslog://drain.example.com",
+			})
+		}).Should(Succeed())
+	})
+
+	When("the writer fails once", func() {
+		It("retries sending the same log line", func() {
+			Eventually(writer.getSent, "5s").Should(HaveLen(1))
+
+			attempts := writer.getAttempts()
+			Expect(attempts).To(HaveLen(2))
+			Expect(attempts[1]).To(Equal(attempts[0]))
+			Expect(attempts[1].Body).To(Equal("fake logs"))
+		})
+
+		It("reports the drain as healthy once the log line has been sent", func() {
+			Eventually(writer.getSent, "5s").Should(HaveLen(1))
+			Expect(manager.Health("binding-guid")).To(MatchFields(IgnoreExtras, Fields{
+				"Active":  BeTrue(),
+				"Healthy": BeTrue(),
+			}))
+		})
+	})
+
+	When("the writer keeps failing", func() {
+		BeforeEach(func() {
+			writer.failures = 100
+		})
+
+		It("gives up after a bounded number of attempts and reports the drain as unhealthy", func() {
+			Eventually(writer.getAttempts, "5s").Should(HaveLen(3))
+			Consistently(writer.getAttempts, "2s").Should(HaveLen(3))
+			Expect(writer.getSent()).To(BeEmpty())
+			Expect(manager.Health("binding-guid")).To(MatchFields(IgnoreExtras, Fields{
+				"Healthy":   BeFalse(),
+				"LastError": Equal("write failed"),
+			}))
+		})
+	})
+})
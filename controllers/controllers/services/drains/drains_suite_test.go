@@ -0,0 +1,13 @@
+package drains_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDrains(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Syslog Drains Suite")
+}
@@ -0,0 +1,129 @@
+package drains
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultPodPollInterval = 10 * time.Second
+
+type Drain struct {
+	BindingGUID string
+	Namespace   string
+	AppGUID     string
+	Hostname    string
+	URL         string
+}
+
+type Health struct {
+	Active        bool
+	Healthy       bool
+	LastError     string
+	LastErrorTime time.Time
+}
+
+// Manager runs a drain for every app binding to a service instance with a
+// syslog drain url. It is registered as a controller manager runnable so
+// that drains only run on the leader and are stopped on shutdown.
+type Manager struct {
+	clientset       kubernetes.Interface
+	writerFactory   WriterFactory
+	podPollInterval time.Duration
+	log             logr.Logger
+
+	mutex  sync.Mutex
+	ctx    context.Context
+	drains map[string]*drainer
+}
+
+func NewManager(clientset kubernetes.Interface, writerFactory WriterFactory, log logr.Logger) *Manager {
+	return &Manager{
+		clientset:       clientset,
+		writerFactory:   writerFactory,
+		podPollInterval: defaultPodPollInterval,
+		log:             log.WithName("syslog-drains"),
+		drains:          map[string]*drainer{},
+	}
+}
+
+func (m *Manager) WithPodPollInterval(interval time.Duration) *Manager {
+	m.podPollInterval = interval
+	return m
+}
+
+func (m *Manager) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	m.ctx = ctx
+	m.mutex.Unlock()
+
+	<-ctx.Done()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for guid, d := range m.drains {
+		d.stop()
+		delete(m.drains, guid)
+	}
+
+	return nil
+}
+
+func (m *Manager) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *Manager) EnsureDrain(drain Drain) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ctx == nil {
+		return errors.New("syslog drain manager has not been started yet")
+	}
+
+	if existing, ok := m.drains[drain.BindingGUID]; ok {
+		if existing.drain == drain {
+			return nil
+		}
+		existing.stop()
+		delete(m.drains, drain.BindingGUID)
+	}
+
+	writer, err := m.writerFactory(drain.URL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	d := newDrainer(drain, m.clientset, writer, m.podPollInterval, cancel, m.log.WithValues("binding", drain.BindingGUID, "app", drain.AppGUID))
+	m.drains[drain.BindingGUID] = d
+	go d.run(ctx)
+
+	return nil
+}
+
+func (m *Manager) StopDrain(bindingGUID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if d, ok := m.drains[bindingGUID]; ok {
+		d.stop()
+		delete(m.drains, bindingGUID)
+	}
+}
+
+func (m *Manager) Health(bindingGUID string) Health {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	d, ok := m.drains[bindingGUID]
+	if !ok {
+		return Health{}
+	}
+
+	return d.health()
+}
@@ -0,0 +1,109 @@
+package drains
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Private enterprise number used by Cloud Foundry for the structured data
+	// carried along with drained log messages
+	cfEnterpriseNumber = 47450
+
+	facilityUser   = 1
+	severityInfo   = 6
+	rfc5424Version = 1
+	nilValue       = "-"
+
+	maxHostnameLength = 255
+	maxAppNameLength  = 48
+	maxProcIDLength   = 128
+
+	rfc5424TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+type Message struct {
+	Timestamp     time.Time
+	Hostname      string
+	AppGUID       string
+	ProcessType   string
+	InstanceIndex string
+	SourceType    string
+	Body          string
+}
+
+func (m Message) procID() string {
+	return fmt.Sprintf("[%s/%s]", m.sourceTypeOrDefault(), m.InstanceIndex)
+}
+
+func (m Message) sourceTypeOrDefault() string {
+	if m.SourceType != "" {
+		return m.SourceType
+	}
+
+	if m.ProcessType == "" {
+		return "APP"
+	}
+
+	return "APP/PROC/" + strings.ToUpper(m.ProcessType)
+}
+
+// RFC5424 formats the message as specified in
+// https://datatracker.ietf.org/doc/html/rfc5424
+func (m Message) RFC5424() []byte {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<%d>%d %s %s %s %s %s ",
+		facilityUser*8+severityInfo,
+		rfc5424Version,
+		m.Timestamp.UTC().Format(rfc5424TimeFormat),
+		headerField(m.Hostname, maxHostnameLength),
+		headerField(m.AppGUID, maxAppNameLength),
+		headerField(m.procID(), maxProcIDLength),
+		nilValue,
+	)
+
+	fmt.Fprintf(&sb, `[tags@%d app_id="%s" instance_id="%s" process_type="%s" source_type="%s"] `,
+		cfEnterpriseNumber,
+		escapeParamValue(m.AppGUID),
+		escapeParamValue(m.InstanceIndex),
+		escapeParamValue(m.ProcessType),
+		escapeParamValue(m.sourceTypeOrDefault()),
+	)
+
+	sb.WriteString(strings.TrimRight(m.Body, "\r\n"))
+	sb.WriteString("\n")
+
+	return []byte(sb.String())
+}
+
+// OctetCounted frames the message for stream transports as described in
+// https://datatracker.ietf.org/doc/html/rfc6587#section-3.4.1
+func (m Message) OctetCounted() []byte {
+	msg := m.RFC5424()
+	return append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+}
+
+func headerField(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '-'
+		}
+		return r
+	}, value)
+
+	if value == "" {
+		return nilValue
+	}
+
+	if len(value) > maxLength {
+		return value[:maxLength]
+	}
+
+	return value
+}
+
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,85 @@
+package drains_test
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	var msg drains.Message
+
+	BeforeEach(func() {
+		msg = drains.Message{
+			Timestamp:     time.Date(2024, 3, 5, 10, 11, 12, 123456789, time.UTC),
+			Hostname:      "my-app",
+			AppGUID:       "app-guid",
+			ProcessType:   "web",
+			InstanceIndex: "2",
+			Body:          "hello world\n",
+		}
+	})
+
+	Describe("RFC5424", func() {
+		It("formats the message", func() {
+			Expect(string(msg.RFC5424())).To(Equal(
+				`<14>1 2024-03-05T10:11:12.123456Z my-app app-guid [APP/PROC/WEB/2] - ` +
+					`[tags@47450 app_id="app-guid" instance_id="2" process_type="web" source_type="APP/PROC/WEB"] hello world` + "\n",
+			))
+		})
+
+		When("the hostname contains non printable characters", func() {
+			BeforeEach(func() {
+				msg.Hostname = "my app"
+			})
+
+			It("replaces them", func() {
+				Expect(string(msg.RFC5424())).To(HavePrefix("<14>1 2024-03-05T10:11:12.123456Z my-app "))
+			})
+		})
+
+		When("the hostname is empty", func() {
+			BeforeEach(func() {
+				msg.Hostname = ""
+			})
+
+			It("uses the nil value", func() {
+				Expect(string(msg.RFC5424())).To(HavePrefix("<14>1 2024-03-05T10:11:12.123456Z - app-guid "))
+			})
+		})
+
+		When("the process type is not known", func() {
+			BeforeEach(func() {
+				msg.ProcessType = ""
+			})
+
+			It("uses the APP source type", func() {
+				Expect(string(msg.RFC5424())).To(ContainSubstring(`[APP/2]`))
+				Expect(string(msg.RFC5424())).To(ContainSubstring(`source_type="APP"`))
+			})
+		})
+
+		When("the structured data values contain special characters", func() {
+			BeforeEach(func() {
+				msg.ProcessType = `w"e]b\`
+			})
+
+			It("escapes them", func() {
+				Expect(string(msg.RFC5424())).To(ContainSubstring(`process_type="w\"e\]b\\"`))
+			})
+		})
+	})
+
+	Describe("OctetCounted", func() {
+		It("prefixes the message with its length", func() {
+			formatted := msg.RFC5424()
+			Expect(string(msg.OctetCounted())).To(Equal(
+				fmt.Sprintf("%d %s", len(formatted), formatted),
+			))
+		})
+	})
+})
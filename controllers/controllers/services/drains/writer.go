@@ -0,0 +1,161 @@
+package drains
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	SyslogScheme    = "syslog"
+	SyslogTLSScheme = "syslog-tls"
+	HTTPSScheme     = "https"
+
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 5 * time.Second
+)
+
+type Writer interface {
+	Write(ctx context.Context, msg Message) error
+	Close() error
+}
+
+type WriterFactory func(drainURL string) (Writer, error)
+
+func NewWriterFactory(tlsConfig *tls.Config) WriterFactory {
+	return func(drainURL string) (Writer, error) {
+		u, err := url.Parse(drainURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog drain url %q: %w", drainURL, err)
+		}
+
+		switch u.Scheme {
+		case SyslogScheme:
+			return newStreamWriter(u.Host, func(ctx context.Context, addr string) (net.Conn, error) {
+				dialer := &net.Dialer{Timeout: dialTimeout}
+				return dialer.DialContext(ctx, "tcp", addr)
+			}), nil
+		case SyslogTLSScheme:
+			return newStreamWriter(u.Host, func(ctx context.Context, addr string) (net.Conn, error) {
+				dialer := &tls.Dialer{
+					NetDialer: &net.Dialer{Timeout: dialTimeout},
+					Config:    tlsConfig,
+				}
+				return dialer.DialContext(ctx, "tcp", addr)
+			}), nil
+		case HTTPSScheme:
+			return newHTTPSWriter(u.String(), tlsConfig), nil
+		}
+
+		return nil, fmt.Errorf("unsupported syslog drain scheme %q", u.Scheme)
+	}
+}
+
+type dialFunc func(ctx context.Context, addr string) (net.Conn, error)
+
+// streamWriter sends octet-counted messages over a long lived connection and
+// redials lazily after the connection has failed
+type streamWriter struct {
+	addr string
+	dial dialFunc
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func newStreamWriter(addr string, dial dialFunc) *streamWriter {
+	return &streamWriter{
+		addr: addr,
+		dial: dial,
+	}
+}
+
+func (w *streamWriter) Write(ctx context.Context, msg Message) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn == nil {
+		conn, err := w.dial(ctx, w.addr)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", w.addr, err)
+		}
+		w.conn = conn
+	}
+
+	if err := w.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return w.reset(err)
+	}
+
+	if _, err := w.conn.Write(msg.OctetCounted()); err != nil {
+		return w.reset(err)
+	}
+
+	return nil
+}
+
+func (w *streamWriter) reset(err error) error {
+	_ = w.conn.Close()
+	w.conn = nil
+	return fmt.Errorf("failed to write to %s: %w", w.addr, err)
+}
+
+func (w *streamWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+type httpsWriter struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newHTTPSWriter(drainURL string, tlsConfig *tls.Config) *httpsWriter {
+	return &httpsWriter{
+		url: drainURL,
+		httpClient: &http.Client{
+			Timeout: writeTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}
+}
+
+func (w *httpsWriter) Write(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(msg.RFC5424()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to syslog drain: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("syslog drain responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (w *httpsWriter) Close() error {
+	w.httpClient.CloseIdleConnections()
+	return nil
+}
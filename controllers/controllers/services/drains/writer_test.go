@@ -0,0 +1,156 @@
+package drains_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writer", func() {
+	var (
+		writerFactory drains.WriterFactory
+		msg           drains.Message
+	)
+
+	BeforeEach(func() {
+		writerFactory = drains.NewWriterFactory(&tls.Config{InsecureSkipVerify: true}) //#nosec G402
+		msg = drains.Message{
+			Timestamp:     time.Now(),
+			Hostname:      "my-app",
+			AppGUID:       "app-guid",
+			ProcessType:   "web",
+			InstanceIndex: "0",
+			Body:          "hello",
+		}
+	})
+
+	When("the drain url scheme is not supported", func() {
+		It("returns an error", func() {
+			_, err := writerFactory("ftp://example.com")
+			Expect(err).To(MatchError(ContainSubstring("unsupported syslog drain scheme")))
+		})
+	})
+
+	Describe("syslog", func() {
+		var (
+			listener net.Listener
+			received chan string
+			writer   drains.Writer
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				_ = listener.Close()
+			})
+
+			received = make(chan string, 10)
+			go func() {
+				defer GinkgoRecover()
+
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					length, err := reader.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(length))
+					Expect(err).NotTo(HaveOccurred())
+
+					frame := make([]byte, n)
+					if _, err := io.ReadFull(reader, frame); err != nil {
+						return
+					}
+					received <- string(frame)
+				}
+			}()
+
+			writer, err = writerFactory("syslog://" + listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(writer.Close)
+		})
+
+		It("sends octet counted messages", func() {
+			Expect(writer.Write(context.Background(), msg)).To(Succeed())
+			Expect(writer.Write(context.Background(), msg)).To(Succeed())
+
+			Eventually(received).Should(Receive(Equal(string(msg.RFC5424()))))
+			Eventually(received).Should(Receive(Equal(string(msg.RFC5424()))))
+		})
+
+		When("the endpoint is not reachable", func() {
+			BeforeEach(func() {
+				Expect(listener.Close()).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(writer.Write(context.Background(), msg)).To(MatchError(ContainSubstring("failed to connect")))
+			})
+		})
+	})
+
+	Describe("https", func() {
+		var (
+			server     *httptest.Server
+			statusCode int
+			received   chan string
+			writer     drains.Writer
+		)
+
+		BeforeEach(func() {
+			statusCode = http.StatusOK
+			received = make(chan string, 10)
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				received <- string(body)
+
+				w.WriteHeader(statusCode)
+			}))
+			DeferCleanup(server.Close)
+
+			var err error
+			writer, err = writerFactory(server.URL + "/drain")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(writer.Close)
+		})
+
+		It("posts the message", func() {
+			Expect(writer.Write(context.Background(), msg)).To(Succeed())
+			Eventually(received).Should(Receive(Equal(string(msg.RFC5424()))))
+		})
+
+		When("the endpoint responds with an error", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(writer.Write(context.Background(), msg)).To(MatchError(ContainSubstring("status 500")))
+			})
+		})
+	})
+})
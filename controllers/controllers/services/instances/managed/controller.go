@@ -52,6 +52,7 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       instances.UsageRecorder
 }
 
 func NewReconciler(
@@ -60,6 +61,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	rootNamespace string,
 	log logr.Logger,
+	usageRecorder instances.UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance] {
 	return k8s.NewPatchingReconciler(log, client, &Reconciler{
 		k8sClient:           client,
@@ -68,6 +70,7 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usageRecorder,
 	})
 }
 
@@ -129,6 +132,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		return r.finalize(ctx, serviceInstance)
 	}
 
+	if err := instances.RecordUsage(ctx, r.usageRecorder, serviceInstance); err != nil {
+		log.Info("failed to record service usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	serviceInstanceAssets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
 	if err != nil {
 		log.Error(err, "failed to get service instance assets")
@@ -279,6 +287,10 @@ func (r *Reconciler) finalize(
 		return ctrl.Result{}, err
 	}
 
+	if err := instances.RecordDeletedUsage(ctx, r.usageRecorder, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceInstance").V(1).Info("finalizer removed")
 	return ctrl.Result{}, nil
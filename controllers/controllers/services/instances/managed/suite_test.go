@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	instancesfake "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
@@ -51,6 +52,7 @@ var (
 	k8sManager          manager.Manager
 	rootNamespace       string
 	brokerClientFactory *fake.BrokerClientFactory
+	usageRecorder       *instancesfake.UsageRecorder
 )
 
 func TestAPIs(t *testing.T) {
@@ -99,6 +101,7 @@ var _ = BeforeEach(func() {
 	})).To(Succeed())
 
 	brokerClientFactory = new(fake.BrokerClientFactory)
+	usageRecorder = new(instancesfake.UsageRecorder)
 
 	err := (managed.NewReconciler(
 		k8sManager.GetClient(),
@@ -106,6 +109,7 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		rootNamespace,
 		ctrl.Log.WithName("controllers").WithName("ManagedCFServiceInstance"),
+		usageRecorder,
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
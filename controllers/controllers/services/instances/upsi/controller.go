@@ -44,17 +44,19 @@ import (
 )
 
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	log           logr.Logger
+	usageRecorder instances.UsageRecorder
 }
 
 func NewReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	usageRecorder instances.UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
 }
 
@@ -115,6 +117,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 		return r.finalizeCFServiceInstance(ctx, cfServiceInstance)
 	}
 
+	if err := instances.RecordUsage(ctx, r.usageRecorder, cfServiceInstance); err != nil {
+		log.Info("failed to record service usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfServiceInstance.Namespace,
@@ -162,6 +169,10 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return ctrl.Result{}, err
 	}
 
+	if err := instances.RecordDeletedUsage(ctx, r.usageRecorder, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	log.V(1).Info("finalizer removed")
 
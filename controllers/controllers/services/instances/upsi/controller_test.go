@@ -56,6 +56,15 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		It("records the creation of the service instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(recordedUsageStates(instance.Name)).To(Equal([]string{korifiv1alpha1.UsageStateCreated}))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.RecordedUsageGeneration).To(Equal(instance.Generation))
+			}).Should(Succeed())
+		})
+
 		It("sets the CredentialsSecretAvailable condition to false", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
@@ -134,6 +143,12 @@ var _ = Describe("CFServiceInstance", func() {
 				})
 			})
 
+			It("records the update of the service instance", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(recordedUsageStates(instance.Name)).To(ContainElement(korifiv1alpha1.UsageStateUpdated))
+				}).Should(Succeed())
+			})
+
 			It("sets the instance last operation succeed state", func() {
 				Eventually(func(g Gomega) {
 					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
@@ -217,6 +232,12 @@ var _ = Describe("CFServiceInstance", func() {
 					}).Should(Succeed())
 				})
 
+				It("records the deletion of the service instance", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(recordedUsageStates(instance.Name)).To(ContainElement(korifiv1alpha1.UsageStateDeleted))
+					}).Should(Succeed())
+				})
+
 				When("the instance has bindings", func() {
 					var binding *korifiv1alpha1.CFServiceBinding
 
@@ -281,3 +302,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+// recordedUsageStates returns the states of the usages recorded for a
+// service instance, as the usage recorder is shared by all tests
+func recordedUsageStates(serviceInstanceGUID string) []string {
+	states := []string{}
+	for i := range usageRecorder.RecordServiceUsageCallCount() {
+		_, serviceInstance, state := usageRecorder.RecordServiceUsageArgsForCall(i)
+		if serviceInstance.Name == serviceInstanceGUID {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -43,6 +44,7 @@ var (
 	stopClientCache context.CancelFunc
 	testEnv         *envtest.Environment
 	adminClient     client.Client
+	usageRecorder   *fake.UsageRecorder
 )
 
 func TestAPIs(t *testing.T) {
@@ -75,10 +77,13 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	usageRecorder = new(fake.UsageRecorder)
+
 	err = (upsi.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("UPSICFServiceInstance"),
+		usageRecorder,
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
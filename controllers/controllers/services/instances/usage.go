@@ -0,0 +1,48 @@
+package instances
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+//counterfeiter:generate -o fake -fake-name UsageRecorder . UsageRecorder
+
+type UsageRecorder interface {
+	RecordServiceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance, state string) error
+}
+
+// RecordUsage records a service usage event when the service instance is
+// created and whenever its spec changes
+func RecordUsage(ctx context.Context, usageRecorder UsageRecorder, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Status.RecordedUsageGeneration == serviceInstance.Generation {
+		return nil
+	}
+
+	state := korifiv1alpha1.UsageStateUpdated
+	if serviceInstance.Status.RecordedUsageGeneration == 0 {
+		state = korifiv1alpha1.UsageStateCreated
+	}
+
+	if err := usageRecorder.RecordServiceUsage(ctx, serviceInstance, state); err != nil {
+		return err
+	}
+
+	serviceInstance.Status.RecordedUsageGeneration = serviceInstance.Generation
+	return nil
+}
+
+// RecordDeletedUsage records a service usage event when a service instance
+// whose creation has been recorded is deleted
+func RecordDeletedUsage(ctx context.Context, usageRecorder UsageRecorder, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Status.RecordedUsageGeneration == 0 {
+		return nil
+	}
+
+	if err := usageRecorder.RecordServiceUsage(ctx, serviceInstance, korifiv1alpha1.UsageStateDeleted); err != nil {
+		return err
+	}
+
+	serviceInstance.Status.RecordedUsageGeneration = 0
+	return nil
+}
@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSpacePlacement returns the placement of the isolation segment the space backed by the namespace is assigned to.
+// It fails while the placement of the isolation segment has not been resolved, so that workloads are never placed on the shared nodes by mistake.
+func GetSpacePlacement(ctx context.Context, k8sClient client.Client, namespace string) (korifiv1alpha1.Placement, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := k8sClient.List(ctx, &spaces, client.MatchingFields{
+		IndexSpaceNamespaceName: namespace,
+	}); err != nil {
+		return korifiv1alpha1.Placement{}, fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+
+	if len(spaces.Items) == 0 {
+		return korifiv1alpha1.Placement{}, nil
+	}
+
+	if len(spaces.Items) > 1 {
+		return korifiv1alpha1.Placement{}, fmt.Errorf("expected a unique CFSpace for namespace %q, got %d", namespace, len(spaces.Items))
+	}
+
+	space := spaces.Items[0]
+	if space.Status.IsolationSegmentGUID != space.Spec.IsolationSegmentGUID {
+		return korifiv1alpha1.Placement{}, fmt.Errorf("the placement of isolation segment %q of space %q is not resolved yet", space.Spec.IsolationSegmentGUID, space.Name)
+	}
+
+	return space.Status.Placement, nil
+}
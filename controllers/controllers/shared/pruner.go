@@ -0,0 +1,90 @@
+package shared
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultPruneInterval = time.Hour
+
+// Pruner periodically deletes the objects that are older than the retention
+// period. It is registered as a controller manager runnable so that it only
+// runs on the leader.
+type Pruner struct {
+	k8sClient client.Client
+	newList   func() client.ObjectList
+	listOpts  []client.ListOption
+	retention time.Duration
+	interval  time.Duration
+	log       logr.Logger
+}
+
+func NewPruner(
+	k8sClient client.Client,
+	newList func() client.ObjectList,
+	retention time.Duration,
+	log logr.Logger,
+	listOpts ...client.ListOption,
+) *Pruner {
+	return &Pruner{
+		k8sClient: k8sClient,
+		newList:   newList,
+		listOpts:  listOpts,
+		retention: retention,
+		interval:  defaultPruneInterval,
+		log:       log,
+	}
+}
+
+func (p *Pruner) WithInterval(interval time.Duration) *Pruner {
+	p.interval = interval
+	return p
+}
+
+func (p *Pruner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pruner) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *Pruner) prune(ctx context.Context) {
+	list := p.newList()
+	if err := p.k8sClient.List(ctx, list, p.listOpts...); err != nil {
+		p.log.Info("failed to list objects", "reason", err)
+		return
+	}
+
+	expiry := time.Now().Add(-p.retention)
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, ok := item.(client.Object)
+		if !ok || !obj.GetCreationTimestamp().Time.Before(expiry) {
+			return nil
+		}
+
+		if err := client.IgnoreNotFound(p.k8sClient.Delete(ctx, obj)); err != nil {
+			p.log.Info("failed to delete object", "namespace", obj.GetNamespace(), "name", obj.GetName(), "reason", err)
+		}
+		return nil
+	})
+	if err != nil {
+		p.log.Info("failed to prune objects", "reason", err)
+	}
+}
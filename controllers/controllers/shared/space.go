@@ -0,0 +1,27 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FindSpace returns the CFSpace backed by the namespace. It returns nil rather
+// than failing when the space cannot be found, as events are still recorded
+// for the resources of a space that is being deleted.
+func FindSpace(ctx context.Context, k8sClient client.Client, namespace string) (*korifiv1alpha1.CFSpace, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := k8sClient.List(ctx, &spaces, client.MatchingFields{
+		IndexSpaceNamespaceName: namespace,
+	}); err != nil {
+		return nil, fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 {
+		return nil, nil
+	}
+
+	return &spaces.Items[0], nil
+}
@@ -0,0 +1,70 @@
+package usage_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Pruner", func() {
+	var (
+		retention   time.Duration
+		stopPruning context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		retention = time.Hour
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFUsageEventSpec{
+				Type:  korifiv1alpha1.AppUsageEventType,
+				State: korifiv1alpha1.UsageStateStarted,
+			},
+		})).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var pruningCtx context.Context
+		pruningCtx, stopPruning = context.WithCancel(ctx)
+
+		pruner := usage.NewPruner(k8sManager.GetClient(), rootNamespace, retention, ctrl.Log).WithInterval(100 * time.Millisecond)
+		go func() {
+			defer GinkgoRecover()
+			Expect(pruner.Start(pruningCtx)).To(Succeed())
+		}()
+	})
+
+	AfterEach(func() {
+		stopPruning()
+	})
+
+	It("keeps the usage events within the retention period", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(listUsageEvents(g)).To(HaveLen(1))
+		}, "2s").Should(Succeed())
+	})
+
+	When("the usage events are older than the retention period", func() {
+		BeforeEach(func() {
+			retention = time.Nanosecond
+		})
+
+		It("deletes them", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ManagedServiceInstanceType      = "managed_service_instance"
+	UserProvidedServiceInstanceType = "user_provided_service_instance"
+)
+
+// Recorder records app and service usage events as CFUsageEvents in the root
+// namespace
+type Recorder struct {
+	k8sClient     client.Client
+	rootNamespace string
+}
+
+func NewRecorder(k8sClient client.Client, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfusageevents,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch
+
+func (r *Recorder) RecordAppUsage(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	previous *korifiv1alpha1.ProcessUsage,
+	current korifiv1alpha1.ProcessUsage,
+) error {
+	if previous == nil {
+		previous = &korifiv1alpha1.ProcessUsage{
+			State:     korifiv1alpha1.UsageStateStopped,
+			Instances: current.Instances,
+			MemoryMB:  current.MemoryMB,
+		}
+	}
+
+	spec, err := r.spaceUsage(ctx, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	spec.Type = korifiv1alpha1.AppUsageEventType
+	spec.State = current.State
+	spec.PreviousState = previous.State
+	spec.App = &korifiv1alpha1.AppUsage{
+		AppGUID:                       cfApp.Name,
+		AppName:                       cfApp.Spec.DisplayName,
+		ProcessGUID:                   cfProcess.Name,
+		ProcessType:                   cfProcess.Spec.ProcessType,
+		InstanceCount:                 current.Instances,
+		PreviousInstanceCount:         previous.Instances,
+		MemoryInMBPerInstance:         current.MemoryMB,
+		PreviousMemoryInMBPerInstance: previous.MemoryMB,
+	}
+
+	return r.record(ctx, spec)
+}
+
+func (r *Recorder) RecordServiceUsage(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	state string,
+) error {
+	spec, err := r.spaceUsage(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	spec.Type = korifiv1alpha1.ServiceUsageEventType
+	spec.State = state
+	spec.ServiceInstance = &korifiv1alpha1.ServiceInstanceUsage{
+		GUID: serviceInstance.Name,
+		Name: serviceInstance.Spec.DisplayName,
+		Type: UserProvidedServiceInstanceType,
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		spec.ServiceInstance.Type = ManagedServiceInstanceType
+		if err = r.setPlanUsage(ctx, serviceInstance.Spec.PlanGUID, spec.ServiceInstance); err != nil {
+			return err
+		}
+	}
+
+	return r.record(ctx, spec)
+}
+
+func (r *Recorder) record(ctx context.Context, spec korifiv1alpha1.CFUsageEventSpec) error {
+	usageEvent, err := NewUsageEvent(r.rootNamespace, spec)
+	if err != nil {
+		return err
+	}
+
+	if err = r.k8sClient.Create(ctx, usageEvent); err != nil {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("usage event recorded", "guid", usageEvent.Name, "type", spec.Type, "state", spec.State)
+	return nil
+}
+
+// NewUsageEvent builds a usage event named with a time ordered UUID, so that
+// listing usage events sorted by name returns them in the order they have
+// been created
+func NewUsageEvent(rootNamespace string, spec korifiv1alpha1.CFUsageEventSpec) (*korifiv1alpha1.CFUsageEvent, error) {
+	guid, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate usage event guid: %w", err)
+	}
+
+	return &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      guid.String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFUsageEventTypeLabelKey: spec.Type,
+			},
+		},
+		Spec: spec,
+	}, nil
+}
+
+func (r *Recorder) spaceUsage(ctx context.Context, namespace string) (korifiv1alpha1.CFUsageEventSpec, error) {
+	spec := korifiv1alpha1.CFUsageEventSpec{
+		SpaceGUID: namespace,
+	}
+
+	space, err := shared.FindSpace(ctx, r.k8sClient, namespace)
+	if err != nil {
+		return korifiv1alpha1.CFUsageEventSpec{}, err
+	}
+
+	if space != nil {
+		spec.SpaceName = space.Spec.DisplayName
+		spec.OrgGUID = space.Namespace
+	}
+
+	return spec, nil
+}
+
+func (r *Recorder) setPlanUsage(ctx context.Context, planGUID string, serviceInstanceUsage *korifiv1alpha1.ServiceInstanceUsage) error {
+	serviceInstanceUsage.PlanGUID = planGUID
+
+	plan := &korifiv1alpha1.CFServicePlan{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: planGUID}, plan); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	serviceInstanceUsage.PlanName = plan.Spec.Name
+	serviceInstanceUsage.OfferingGUID = plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+	serviceInstanceUsage.OfferingName = plan.Labels[korifiv1alpha1.RelServiceOfferingNameLabel]
+	serviceInstanceUsage.BrokerGUID = plan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]
+	serviceInstanceUsage.BrokerName = plan.Labels[korifiv1alpha1.RelServiceBrokerNameLabel]
+
+	return nil
+}
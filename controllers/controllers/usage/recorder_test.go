@@ -0,0 +1,239 @@
+package usage_test
+
+import (
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		recorder  *usage.Recorder
+		orgGUID   string
+		spaceGUID string
+	)
+
+	BeforeEach(func() {
+		recorder = usage.NewRecorder(k8sManager.GetClient(), rootNamespace)
+
+		orgGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: orgGUID},
+		})).To(Succeed())
+
+		spaceGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgGUID,
+				Name:      spaceGUID,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		})).To(Succeed())
+	})
+
+	Describe("RecordAppUsage", func() {
+		var (
+			cfApp     *korifiv1alpha1.CFApp
+			cfProcess *korifiv1alpha1.CFProcess
+			previous  *korifiv1alpha1.ProcessUsage
+		)
+
+		BeforeEach(func() {
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      "app-guid",
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					DisplayName: "my-app",
+				},
+			}
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      "process-guid",
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					ProcessType: "web",
+				},
+			}
+			previous = &korifiv1alpha1.ProcessUsage{
+				State:     korifiv1alpha1.UsageStateStarted,
+				Instances: 1,
+				MemoryMB:  256,
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(recorder.RecordAppUsage(ctx, cfApp, cfProcess, previous, korifiv1alpha1.ProcessUsage{
+				State:     korifiv1alpha1.UsageStateStarted,
+				Instances: 3,
+				MemoryMB:  512,
+			})).To(Succeed())
+		})
+
+		It("records an app usage event in the root namespace", func() {
+			Eventually(func(g Gomega) {
+				usageEvents := listUsageEvents(g)
+				g.Expect(usageEvents).To(HaveLen(1))
+
+				g.Expect(usageEvents[0].Labels).To(HaveKeyWithValue(korifiv1alpha1.CFUsageEventTypeLabelKey, korifiv1alpha1.AppUsageEventType))
+				g.Expect(usageEvents[0].Spec).To(Equal(korifiv1alpha1.CFUsageEventSpec{
+					Type:          korifiv1alpha1.AppUsageEventType,
+					State:         korifiv1alpha1.UsageStateStarted,
+					PreviousState: korifiv1alpha1.UsageStateStarted,
+					SpaceGUID:     spaceGUID,
+					SpaceName:     "my-space",
+					OrgGUID:       orgGUID,
+					App: &korifiv1alpha1.AppUsage{
+						AppGUID:                       "app-guid",
+						AppName:                       "my-app",
+						ProcessGUID:                   "process-guid",
+						ProcessType:                   "web",
+						InstanceCount:                 3,
+						PreviousInstanceCount:         1,
+						MemoryInMBPerInstance:         512,
+						PreviousMemoryInMBPerInstance: 256,
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		When("there is no previous usage", func() {
+			BeforeEach(func() {
+				previous = nil
+			})
+
+			It("records the process as previously stopped", func() {
+				Eventually(func(g Gomega) {
+					usageEvents := listUsageEvents(g)
+					g.Expect(usageEvents).To(HaveLen(1))
+					g.Expect(usageEvents[0].Spec.PreviousState).To(Equal(korifiv1alpha1.UsageStateStopped))
+					g.Expect(usageEvents[0].Spec.App.PreviousInstanceCount).To(BeEquivalentTo(3))
+					g.Expect(usageEvents[0].Spec.App.PreviousMemoryInMBPerInstance).To(BeEquivalentTo(512))
+				}).Should(Succeed())
+			})
+		})
+
+		When("more usage is recorded", func() {
+			JustBeforeEach(func() {
+				Expect(recorder.RecordAppUsage(ctx, cfApp, cfProcess, previous, korifiv1alpha1.ProcessUsage{
+					State: korifiv1alpha1.UsageStateStopped,
+				})).To(Succeed())
+			})
+
+			It("names the events in the order they have been recorded", func() {
+				Eventually(func(g Gomega) {
+					usageEvents := listUsageEvents(g)
+					g.Expect(usageEvents).To(HaveLen(2))
+
+					started, stopped := usageEvents[0], usageEvents[1]
+					if started.Spec.State != korifiv1alpha1.UsageStateStarted {
+						started, stopped = stopped, started
+					}
+					g.Expect(started.Name < stopped.Name).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("RecordServiceUsage", func() {
+		var serviceInstance *korifiv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      "instance-guid",
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "my-instance",
+					Type:        korifiv1alpha1.UserProvidedType,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(recorder.RecordServiceUsage(ctx, serviceInstance, korifiv1alpha1.UsageStateCreated)).To(Succeed())
+		})
+
+		It("records a service usage event in the root namespace", func() {
+			Eventually(func(g Gomega) {
+				usageEvents := listUsageEvents(g)
+				g.Expect(usageEvents).To(HaveLen(1))
+
+				g.Expect(usageEvents[0].Labels).To(HaveKeyWithValue(korifiv1alpha1.CFUsageEventTypeLabelKey, korifiv1alpha1.ServiceUsageEventType))
+				g.Expect(usageEvents[0].Spec).To(Equal(korifiv1alpha1.CFUsageEventSpec{
+					Type:      korifiv1alpha1.ServiceUsageEventType,
+					State:     korifiv1alpha1.UsageStateCreated,
+					SpaceGUID: spaceGUID,
+					SpaceName: "my-space",
+					OrgGUID:   orgGUID,
+					ServiceInstance: &korifiv1alpha1.ServiceInstanceUsage{
+						GUID: "instance-guid",
+						Name: "my-instance",
+						Type: usage.UserProvidedServiceInstanceType,
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				planGUID := uuid.NewString()
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      planGUID,
+						Labels: map[string]string{
+							korifiv1alpha1.RelServiceOfferingGUIDLabel: "offering-guid",
+							korifiv1alpha1.RelServiceOfferingNameLabel: "my-offering",
+							korifiv1alpha1.RelServiceBrokerGUIDLabel:   "broker-guid",
+							korifiv1alpha1.RelServiceBrokerNameLabel:   "my-broker",
+						},
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						Name: "my-plan",
+						Visibility: korifiv1alpha1.ServicePlanVisibility{
+							Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+						},
+					},
+				})).To(Succeed())
+
+				serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+				serviceInstance.Spec.PlanGUID = planGUID
+				serviceInstance.Spec.ServiceLabel = tools.PtrTo("my-offering")
+			})
+
+			It("records the plan, offering and broker of the service instance", func() {
+				Eventually(func(g Gomega) {
+					usageEvents := listUsageEvents(g)
+					g.Expect(usageEvents).To(HaveLen(1))
+					g.Expect(usageEvents[0].Spec.ServiceInstance).To(Equal(&korifiv1alpha1.ServiceInstanceUsage{
+						GUID:         "instance-guid",
+						Name:         "my-instance",
+						Type:         usage.ManagedServiceInstanceType,
+						PlanGUID:     serviceInstance.Spec.PlanGUID,
+						PlanName:     "my-plan",
+						OfferingGUID: "offering-guid",
+						OfferingName: "my-offering",
+						BrokerGUID:   "broker-guid",
+						BrokerName:   "my-broker",
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
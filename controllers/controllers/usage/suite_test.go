@@ -0,0 +1,90 @@
+package usage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var (
+	ctx             context.Context
+	stopManager     context.CancelFunc
+	stopClientCache context.CancelFunc
+	testEnv         *envtest.Environment
+	adminClient     client.Client
+	k8sManager      manager.Manager
+	rootNamespace   string
+)
+
+func TestUsage(t *testing.T) {
+	SetDefaultEventuallyTimeout(10 * time.Second)
+	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Usage Events Integration Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true), zap.Level(zapcore.DebugLevel)))
+
+	ctx = context.Background()
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "..", "helm", "korifi", "controllers", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	_, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	k8sManager = helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
+	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
+
+	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
+
+	stopManager = helpers.StartK8sManager(k8sManager)
+})
+
+var _ = BeforeEach(func() {
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	stopManager()
+	stopClientCache()
+	Expect(testEnv.Stop()).To(Succeed())
+})
+
+func listUsageEvents(g Gomega) []korifiv1alpha1.CFUsageEvent {
+	usageEvents := korifiv1alpha1.CFUsageEventList{}
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+	return usageEvents.Items
+}
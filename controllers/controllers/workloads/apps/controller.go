@@ -369,6 +369,11 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return sbFinalizationResult, nil
 	}
 
+	err = r.ensureProcessUsageStopped(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if controllerutil.RemoveFinalizer(cfApp, korifiv1alpha1.CFAppFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -376,6 +381,27 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return ctrl.Result{}, nil
 }
 
+// ensureProcessUsageStopped waits for the process controller to record the
+// stop of the app processes, so that their usage does not outlive the app
+func (r *Reconciler) ensureProcessUsageStopped(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	cfProcessList := korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, &cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return fmt.Errorf("error listing app CFProcesses: %w", err)
+	}
+
+	for _, cfProcess := range cfProcessList.Items {
+		if cfProcess.Status.RecordedUsage != nil && cfProcess.Status.RecordedUsage.State == korifiv1alpha1.UsageStateStarted {
+			return k8s.NewNotReadyError().
+				WithReason("ProcessUsageNotStopped").
+				WithMessage(fmt.Sprintf("The stop of process %q has not been recorded yet", cfProcess.Name)).
+				WithRequeueAfter(time.Second)
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
 	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
 	if err != nil {
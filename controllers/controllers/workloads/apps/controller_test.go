@@ -564,7 +564,9 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				},
 			}
 			Expect(adminClient.Create(ctx, &cfServiceBinding)).To(Succeed())
+		})
 
+		JustBeforeEach(func() {
 			Expect(k8sManager.GetClient().Delete(ctx, cfApp)).To(Succeed())
 		})
 
@@ -589,5 +591,54 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				g.Expect(sbList.Items).To(BeEmpty())
 			}).Should(Succeed())
 		})
+
+		When("the stop of an app process has not been recorded yet", func() {
+			var cfProcess *korifiv1alpha1.CFProcess
+
+			BeforeEach(func() {
+				cfProcess = &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType: "worker",
+					},
+				}
+				Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Status.RecordedUsage = &korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.UsageStateStarted,
+						Instances: 1,
+						MemoryMB:  256,
+					}
+				})).To(Succeed())
+			})
+
+			It("does not delete the app", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				}).Should(Succeed())
+			})
+
+			When("the stop of the process gets recorded", func() {
+				JustBeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+						cfProcess.Status.RecordedUsage.State = korifiv1alpha1.UsageStateStopped
+					})).To(Succeed())
+				})
+
+				It("deletes the app", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
+						g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,392 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const maxRecentDecisions = 10
+
+var verticalPodAutoscalerGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscaler",
+}
+
+type Reconciler struct {
+	k8sClient client.Client
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	clock     clock.PassiveClock
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	clock clock.PassiveClock,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAutoscalingPolicy] {
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAutoscalingPolicy](log, client, &Reconciler{
+		k8sClient: client,
+		scheme:    scheme,
+		log:       log,
+		clock:     clock,
+	})
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAutoscalingPolicy{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Watches(
+			&korifiv1alpha1.CFProcess{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueuePolicyRequestsForProcess),
+		)
+}
+
+func (r *Reconciler) enqueuePolicyRequestsForProcess(ctx context.Context, o client.Object) []reconcile.Request {
+	policyList := &korifiv1alpha1.CFAutoscalingPolicyList{}
+	err := r.k8sClient.List(ctx, policyList, client.InNamespace(o.GetNamespace()))
+	if err != nil {
+		r.log.Error(err, "listing CFAutoscalingPolicies for CFProcess failed", "processGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, policy := range policyList.Items {
+		if policy.Spec.ProcessRef.Name == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfautoscalingpolicies,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfautoscalingpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, policy *korifiv1alpha1.CFAutoscalingPolicy) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	policy.Status.ObservedGeneration = policy.Generation
+	log.V(1).Info("set observed generation", "generation", policy.Status.ObservedGeneration)
+
+	if !policy.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfProcess := new(korifiv1alpha1.CFProcess)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.ProcessRef.Name}, cfProcess)
+	if err != nil {
+		log.Info("error when fetching CFProcess", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetControllerReference(cfProcess, policy, r.scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	location, err := time.LoadLocation(policy.Spec.Timezone)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("InvalidTimezone").WithNoRequeue()
+	}
+
+	now := r.clock.Now().In(location)
+	bounds := currentBounds(policy.Spec, now)
+	policy.Status.ActiveSchedule = bounds.activeSchedule
+
+	if hasMetrics(policy) {
+		err = r.reconcileHPA(ctx, policy, cfProcess, bounds)
+	} else {
+		err = r.clampInstances(ctx, policy, cfProcess, bounds, now)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileVPA(ctx, policy, cfProcess)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if next, ok := nextTransition(policy.Spec.Schedules, now); ok {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func hasMetrics(policy *korifiv1alpha1.CFAutoscalingPolicy) bool {
+	return policy.Spec.CPUUtilizationPercent != nil || policy.Spec.MemoryUtilizationPercent != nil
+}
+
+// reconcileHPA scales the process through its scale subresource with a
+// HorizontalPodAutoscaler targeting the utilization of its instances
+func (r *Reconciler) reconcileHPA(
+	ctx context.Context,
+	policy *korifiv1alpha1.CFAutoscalingPolicy,
+	cfProcess *korifiv1alpha1.CFProcess,
+	bounds instanceBounds,
+) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: policy.Namespace,
+			Name:      policy.Name,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, hpa, func() error {
+		hpa.Labels = tools.SetMapValue(hpa.Labels, korifiv1alpha1.CFProcessGUIDLabelKey, cfProcess.Name)
+		hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: korifiv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "CFProcess",
+			Name:       cfProcess.Name,
+		}
+		hpa.Spec.MinReplicas = tools.PtrTo(bounds.min)
+		hpa.Spec.MaxReplicas = bounds.max
+		hpa.Spec.Metrics = nil
+		if policy.Spec.CPUUtilizationPercent != nil {
+			hpa.Spec.Metrics = append(hpa.Spec.Metrics, utilizationMetric(corev1.ResourceCPU, *policy.Spec.CPUUtilizationPercent))
+		}
+		if policy.Spec.MemoryUtilizationPercent != nil {
+			hpa.Spec.Metrics = append(hpa.Spec.Metrics, utilizationMetric(corev1.ResourceMemory, *policy.Spec.MemoryUtilizationPercent))
+		}
+
+		return controllerutil.SetControllerReference(policy, hpa, r.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch HorizontalPodAutoscaler: %w", err)
+	}
+
+	if hpa.Status.LastScaleTime != nil && isNewDecision(policy, *hpa.Status.LastScaleTime) {
+		from := policy.Status.DesiredInstances
+		if from == 0 {
+			from = hpa.Status.CurrentReplicas
+		}
+		recordDecision(policy, korifiv1alpha1.ScalingDecision{
+			Time:          *hpa.Status.LastScaleTime,
+			FromInstances: from,
+			ToInstances:   hpa.Status.DesiredReplicas,
+			Reason:        hpaDecisionReason(hpa),
+		})
+	}
+
+	policy.Status.CurrentInstances = hpa.Status.CurrentReplicas
+	policy.Status.DesiredInstances = hpa.Status.DesiredReplicas
+
+	return nil
+}
+
+func utilizationMetric(resourceName corev1.ResourceName, percent int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: tools.PtrTo(percent),
+			},
+		},
+	}
+}
+
+func hpaDecisionReason(hpa *autoscalingv2.HorizontalPodAutoscaler) string {
+	var reasons []string
+	for _, metric := range hpa.Status.CurrentMetrics {
+		if metric.Resource == nil || metric.Resource.Current.AverageUtilization == nil {
+			continue
+		}
+
+		reason := fmt.Sprintf("%s utilization %d%%", metric.Resource.Name, *metric.Resource.Current.AverageUtilization)
+		for _, specMetric := range hpa.Spec.Metrics {
+			if specMetric.Resource != nil && specMetric.Resource.Name == metric.Resource.Name && specMetric.Resource.Target.AverageUtilization != nil {
+				reason += fmt.Sprintf(" (target %d%%)", *specMetric.Resource.Target.AverageUtilization)
+			}
+		}
+		reasons = append(reasons, reason)
+	}
+
+	for _, condition := range hpa.Status.Conditions {
+		if condition.Type == autoscalingv2.ScalingLimited && condition.Status == corev1.ConditionTrue {
+			reasons = append(reasons, condition.Message)
+		}
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// clampInstances keeps the desired instances of the process within the
+// bounds when the policy has no utilization targets
+func (r *Reconciler) clampInstances(
+	ctx context.Context,
+	policy *korifiv1alpha1.CFAutoscalingPolicy,
+	cfProcess *korifiv1alpha1.CFProcess,
+	bounds instanceBounds,
+	now time.Time,
+) error {
+	err := r.k8sClient.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: policy.Namespace,
+			Name:      policy.Name,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete HorizontalPodAutoscaler: %w", err)
+	}
+
+	// like the HorizontalPodAutoscaler, processes scaled to zero are left alone
+	current := tools.ZeroIfNil(cfProcess.Spec.DesiredInstances)
+	desired := current
+	if current > 0 {
+		desired = min(max(current, bounds.min), bounds.max)
+	}
+
+	if desired != current {
+		err = k8s.Patch(ctx, r.k8sClient, cfProcess, func() {
+			cfProcess.Spec.DesiredInstances = tools.PtrTo(desired)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scale CFProcess: %w", err)
+		}
+
+		reason := fmt.Sprintf("instances outside of the bounds %d-%d", bounds.min, bounds.max)
+		if bounds.activeSchedule != nil {
+			reason += fmt.Sprintf(" of schedule %d", *bounds.activeSchedule)
+		}
+		recordDecision(policy, korifiv1alpha1.ScalingDecision{
+			Time:          metav1.NewTime(now),
+			FromInstances: current,
+			ToInstances:   desired,
+			Reason:        reason,
+		})
+	}
+
+	policy.Status.CurrentInstances = cfProcess.Status.ActualInstances
+	policy.Status.DesiredInstances = desired
+
+	return nil
+}
+
+func isNewDecision(policy *korifiv1alpha1.CFAutoscalingPolicy, decisionTime metav1.Time) bool {
+	if len(policy.Status.RecentDecisions) == 0 {
+		return true
+	}
+
+	return policy.Status.RecentDecisions[0].Time.Before(&decisionTime)
+}
+
+func recordDecision(policy *korifiv1alpha1.CFAutoscalingPolicy, decision korifiv1alpha1.ScalingDecision) {
+	policy.Status.RecentDecisions = append([]korifiv1alpha1.ScalingDecision{decision}, policy.Status.RecentDecisions...)
+	if len(policy.Status.RecentDecisions) > maxRecentDecisions {
+		policy.Status.RecentDecisions = policy.Status.RecentDecisions[:maxRecentDecisions]
+	}
+}
+
+// reconcileVPA resizes the memory of the process instances with a
+// VerticalPodAutoscaler. The VerticalPodAutoscaler is optional, so it is
+// handled as an unstructured object
+func (r *Reconciler) reconcileVPA(ctx context.Context, policy *korifiv1alpha1.CFAutoscalingPolicy, cfProcess *korifiv1alpha1.CFProcess) error {
+	vpa := new(unstructured.Unstructured)
+	vpa.SetGroupVersionKind(verticalPodAutoscalerGVK)
+	vpa.SetNamespace(policy.Namespace)
+	vpa.SetName(policy.Name)
+
+	if policy.Spec.Vertical == nil {
+		policy.Status.RecommendedMemoryMB = 0
+
+		err := r.k8sClient.Delete(ctx, vpa)
+		if client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to delete VerticalPodAutoscaler: %w", err)
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, vpa, func() error {
+		vpa.SetLabels(tools.SetMapValue(vpa.GetLabels(), korifiv1alpha1.CFProcessGUIDLabelKey, cfProcess.Name))
+		vpa.Object["spec"] = map[string]any{
+			"targetRef": map[string]any{
+				"apiVersion": korifiv1alpha1.SchemeGroupVersion.String(),
+				"kind":       "CFProcess",
+				"name":       cfProcess.Name,
+			},
+			"updatePolicy": map[string]any{
+				"updateMode": "Auto",
+			},
+			"resourcePolicy": map[string]any{
+				"containerPolicies": []any{
+					map[string]any{
+						"containerName":       "*",
+						"controlledResources": []any{string(corev1.ResourceMemory)},
+						"minAllowed":          map[string]any{string(corev1.ResourceMemory): fmt.Sprintf("%dMi", policy.Spec.Vertical.MinMemoryMB)},
+						"maxAllowed":          map[string]any{string(corev1.ResourceMemory): fmt.Sprintf("%dMi", policy.Spec.Vertical.MaxMemoryMB)},
+					},
+				},
+			},
+		}
+
+		return controllerutil.SetControllerReference(policy, vpa, r.scheme)
+	})
+	if err != nil {
+		if meta.IsNoMatchError(err) || k8serrors.IsNotFound(err) {
+			return k8s.NewNotReadyError().
+				WithReason("VerticalPodAutoscalerNotInstalled").
+				WithMessage("vertical autoscaling requires the VerticalPodAutoscaler to be installed").
+				WithNoRequeue()
+		}
+		return fmt.Errorf("failed to create or patch VerticalPodAutoscaler: %w", err)
+	}
+
+	policy.Status.RecommendedMemoryMB = recommendedMemoryMB(vpa)
+
+	return nil
+}
+
+func recommendedMemoryMB(vpa *unstructured.Unstructured) int64 {
+	recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+
+	var recommendedMB int64
+	for _, recommendation := range recommendations {
+		recommendationMap, ok := recommendation.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		target, _, _ := unstructured.NestedString(recommendationMap, "target", string(corev1.ResourceMemory))
+		quantity, err := resource.ParseQuantity(target)
+		if err != nil {
+			continue
+		}
+
+		recommendedMB = max(recommendedMB, quantity.Value()/(1024*1024))
+	}
+
+	return recommendedMB
+}
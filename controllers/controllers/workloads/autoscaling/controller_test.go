@@ -0,0 +1,298 @@
+package autoscaling_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAutoscalingPolicyReconciler Integration Tests", func() {
+	var (
+		cfProcess *korifiv1alpha1.CFProcess
+		policy    *korifiv1alpha1.CFAutoscalingPolicy
+	)
+
+	BeforeEach(func() {
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: uuid.NewString()},
+				ProcessType:      "web",
+				DesiredInstances: tools.PtrTo[int32](2),
+				MemoryMB:         256,
+				DiskQuotaMB:      512,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+		policy = &korifiv1alpha1.CFAutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfProcess.Name,
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+				ProcessRef:            corev1.LocalObjectReference{Name: cfProcess.Name},
+				MinInstances:          1,
+				MaxInstances:          5,
+				CPUUtilizationPercent: tools.PtrTo[int32](70),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	getHPA := func(g Gomega) *autoscalingv2.HorizontalPodAutoscaler {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), hpa)).To(Succeed())
+		return hpa
+	}
+
+	It("sets the process as the owner of the policy", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+			g.Expect(policy.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFProcess"),
+				"Name": Equal(cfProcess.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a HorizontalPodAutoscaler scaling the process", func() {
+		Eventually(func(g Gomega) {
+			hpa := getHPA(g)
+			g.Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+				Kind:       "CFProcess",
+				Name:       cfProcess.Name,
+			}))
+			g.Expect(hpa.Spec.MinReplicas).To(PointTo(BeEquivalentTo(1)))
+			g.Expect(hpa.Spec.MaxReplicas).To(BeEquivalentTo(5))
+			g.Expect(hpa.Spec.Metrics).To(ConsistOf(autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: tools.PtrTo[int32](70),
+					},
+				},
+			}))
+			g.Expect(hpa.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFProcessGUIDLabelKey, cfProcess.Name))
+			g.Expect(hpa.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFAutoscalingPolicy"),
+				"Name": Equal(policy.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("sets the ready condition", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+			g.Expect(policy.Status.ObservedGeneration).To(Equal(policy.Generation))
+			g.Expect(policy.Status.Conditions).To(ContainElement(SatisfyAll(
+				matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+				matchers.HasStatus(Equal(metav1.ConditionTrue)),
+			)))
+		}).Should(Succeed())
+	})
+
+	When("the policy targets memory utilization", func() {
+		BeforeEach(func() {
+			policy.Spec.MemoryUtilizationPercent = tools.PtrTo[int32](80)
+		})
+
+		It("adds a memory metric to the HorizontalPodAutoscaler", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getHPA(g).Spec.Metrics).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Resource": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Name": Equal(corev1.ResourceMemory),
+						"Target": MatchFields(IgnoreExtras, Fields{
+							"AverageUtilization": PointTo(BeEquivalentTo(80)),
+						}),
+					})),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("a schedule is active", func() {
+		BeforeEach(func() {
+			policy.Spec.Schedules = []korifiv1alpha1.AutoscalingSchedule{
+				{DaysOfWeek: []int32{1, 2}, StartTime: "08:00", EndTime: "18:00", MinInstances: 7, MaxInstances: 8},
+				{DaysOfWeek: []int32{3}, StartTime: "11:00", EndTime: "13:00", MinInstances: 3, MaxInstances: 10},
+			}
+		})
+
+		It("uses the bounds of the schedule", func() {
+			Eventually(func(g Gomega) {
+				hpa := getHPA(g)
+				g.Expect(hpa.Spec.MinReplicas).To(PointTo(BeEquivalentTo(3)))
+				g.Expect(hpa.Spec.MaxReplicas).To(BeEquivalentTo(10))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.ActiveSchedule).To(PointTo(BeEquivalentTo(1)))
+			}).Should(Succeed())
+		})
+
+		When("the schedule is in another time zone", func() {
+			BeforeEach(func() {
+				policy.Spec.Timezone = "America/New_York"
+			})
+
+			It("uses the default bounds", func() {
+				Eventually(func(g Gomega) {
+					hpa := getHPA(g)
+					g.Expect(hpa.Spec.MinReplicas).To(PointTo(BeEquivalentTo(1)))
+					g.Expect(hpa.Spec.MaxReplicas).To(BeEquivalentTo(5))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the time zone is invalid", func() {
+		BeforeEach(func() {
+			policy.Spec.Timezone = "Nowhere/Special"
+		})
+
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.Conditions).To(ContainElement(SatisfyAll(
+					matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					matchers.HasStatus(Equal(metav1.ConditionFalse)),
+					matchers.HasReason(Equal("InvalidTimezone")),
+				)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the HorizontalPodAutoscaler scales the process", func() {
+		var lastScaleTime metav1.Time
+
+		JustBeforeEach(func() {
+			lastScaleTime = metav1.NewTime(time.Now().Truncate(time.Second))
+
+			Eventually(func(g Gomega) {
+				hpa := getHPA(g)
+				g.Expect(k8s.Patch(ctx, adminClient, hpa, func() {
+					hpa.Status = autoscalingv2.HorizontalPodAutoscalerStatus{
+						LastScaleTime:   &lastScaleTime,
+						CurrentReplicas: 2,
+						DesiredReplicas: 4,
+						CurrentMetrics: []autoscalingv2.MetricStatus{{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricStatus{
+								Name: corev1.ResourceCPU,
+								Current: autoscalingv2.MetricValueStatus{
+									AverageUtilization: tools.PtrTo[int32](140),
+								},
+							},
+						}},
+					}
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("records the scaling decision", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.CurrentInstances).To(BeEquivalentTo(2))
+				g.Expect(policy.Status.DesiredInstances).To(BeEquivalentTo(4))
+				g.Expect(policy.Status.RecentDecisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Time":          Equal(lastScaleTime),
+					"FromInstances": BeEquivalentTo(2),
+					"ToInstances":   BeEquivalentTo(4),
+					"Reason":        Equal("cpu utilization 140% (target 70%)"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the policy has no utilization targets", func() {
+		BeforeEach(func() {
+			policy.Spec.CPUUtilizationPercent = nil
+			policy.Spec.MinInstances = 3
+		})
+
+		It("does not create a HorizontalPodAutoscaler", func() {
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(policy), &autoscalingv2.HorizontalPodAutoscaler{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("scales the process into the bounds", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(3)))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.DesiredInstances).To(BeEquivalentTo(3))
+				g.Expect(policy.Status.RecentDecisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"FromInstances": BeEquivalentTo(2),
+					"ToInstances":   BeEquivalentTo(3),
+					"Reason":        Equal("instances outside of the bounds 3-5"),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process is scaled to zero", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](0)
+				})).To(Succeed())
+			})
+
+			It("leaves the process alone", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(0)))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the policy has vertical bounds", func() {
+		BeforeEach(func() {
+			policy.Spec.Vertical = &korifiv1alpha1.VerticalAutoscaling{
+				MinMemoryMB: 128,
+				MaxMemoryMB: 1024,
+			}
+		})
+
+		It("still creates the HorizontalPodAutoscaler", func() {
+			Eventually(func(g Gomega) {
+				getHPA(g)
+			}).Should(Succeed())
+		})
+
+		It("reports that the VerticalPodAutoscaler is not installed", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.Conditions).To(ContainElement(SatisfyAll(
+					matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					matchers.HasStatus(Equal(metav1.ConditionFalse)),
+					matchers.HasReason(Equal("VerticalPodAutoscalerNotInstalled")),
+				)))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package autoscaling
+
+import (
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+type instanceBounds struct {
+	min            int32
+	max            int32
+	activeSchedule *int32
+}
+
+// currentBounds returns the instance bounds of the policy at the given time,
+// taking the first matching schedule into account
+func currentBounds(spec korifiv1alpha1.CFAutoscalingPolicySpec, now time.Time) instanceBounds {
+	for i, schedule := range spec.Schedules {
+		if isActive(schedule, now) {
+			return instanceBounds{
+				min:            schedule.MinInstances,
+				max:            schedule.MaxInstances,
+				activeSchedule: tools.PtrTo(int32(i)),
+			}
+		}
+	}
+
+	return instanceBounds{min: spec.MinInstances, max: spec.MaxInstances}
+}
+
+// nextTransition returns the next time any of the schedules starts or ends
+func nextTransition(schedules []korifiv1alpha1.AutoscalingSchedule, now time.Time) (time.Time, bool) {
+	var next time.Time
+
+	for days := 0; days <= 7; days++ {
+		day := now.AddDate(0, 0, days)
+		for _, schedule := range schedules {
+			if !slices.Contains(schedule.DaysOfWeek, isoWeekday(day)) {
+				continue
+			}
+
+			for _, t := range []string{schedule.StartTime, schedule.EndTime} {
+				candidate, err := atTimeOfDay(day, t)
+				if err != nil || !candidate.After(now) {
+					continue
+				}
+				if next.IsZero() || candidate.Before(next) {
+					next = candidate
+				}
+			}
+		}
+
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func isActive(schedule korifiv1alpha1.AutoscalingSchedule, now time.Time) bool {
+	if !slices.Contains(schedule.DaysOfWeek, isoWeekday(now)) {
+		return false
+	}
+
+	start, err := atTimeOfDay(now, schedule.StartTime)
+	if err != nil {
+		return false
+	}
+
+	end, err := atTimeOfDay(now, schedule.EndTime)
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(start) && now.Before(end)
+}
+
+func isoWeekday(t time.Time) int32 {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int32(t.Weekday())
+}
+
+func atTimeOfDay(day time.Time, hhmm string) (time.Time, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
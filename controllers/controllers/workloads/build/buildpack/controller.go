@@ -23,8 +23,10 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
@@ -232,9 +234,11 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 					Image:            cfPackage.Spec.Source.Registry.Image,
 					ImagePullSecrets: cfPackage.Spec.Source.Registry.ImagePullSecrets,
 				},
+				Blob: cfPackage.Spec.Source.Blob,
 			},
 			BuilderName: r.controllerConfig.BuilderName,
 			Buildpacks:  cfBuild.Spec.Lifecycle.Data.Buildpacks,
+			Stack:       cfBuild.Spec.Lifecycle.Data.Stack,
 			Services: slices.Collect(it.Map(slices.Values(cfApp.Status.ServiceBindings),
 				func(binding korifiv1alpha1.ServiceBinding) corev1.ObjectReference {
 					return corev1.ObjectReference{
@@ -254,6 +258,15 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 	}
 	desiredWorkload.Spec.Env = imageEnvironment
 
+	placement, err := shared.GetSpacePlacement(ctx, r.k8sClient, namespace)
+	if err != nil {
+		log.Info("failed to get space placement", "reason", err)
+		return err
+	}
+	desiredWorkload.Spec.Placement = placement
+
+	tracing.InjectIntoObject(ctx, &desiredWorkload)
+
 	err = controllerutil.SetControllerReference(cfBuild, &desiredWorkload, r.scheme)
 	if err != nil {
 		log.Info("failed to set OwnerRef on BuildWorkload", "reason", err)
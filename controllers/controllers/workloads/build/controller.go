@@ -97,6 +97,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if cfBuild.Spec.Droplet != nil {
+		reconcileProvidedDroplet(cfBuild)
+		return ctrl.Result{}, nil
+	}
+
 	cfPackage := new(korifiv1alpha1.CFPackage)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
 	if err != nil {
@@ -127,6 +132,33 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 	return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
 }
 
+// reconcileProvidedDroplet handles builds whose droplet has been uploaded or
+// copied from another build, so there is no package to stage
+func reconcileProvidedDroplet(cfBuild *korifiv1alpha1.CFBuild) {
+	if cfBuild.Spec.Droplet.Registry.Image == "" {
+		cfBuild.Status.State = korifiv1alpha1.BuildStateAwaitingUpload
+		return
+	}
+
+	cfBuild.Status.Droplet = cfBuild.Spec.Droplet.DeepCopy()
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DropletProvided",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	cfBuild.Status.State = korifiv1alpha1.BuildStateStaged
+}
+
 func validateLifecycleTypes(
 	cfApp *korifiv1alpha1.CFApp,
 	cfPackage *korifiv1alpha1.CFPackage,
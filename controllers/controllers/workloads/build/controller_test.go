@@ -215,6 +215,47 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("the build droplet is provided", func() {
+		BeforeEach(func() {
+			cfBuild.Spec.PackageRef = v1.LocalObjectReference{}
+			cfBuild.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				Registry: korifiv1alpha1.Registry{
+					Image:            "my-image@sha256:abc",
+					ImagePullSecrets: []v1.LocalObjectReference{{Name: "my-secret"}},
+				},
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run-me"}},
+				Ports:        []int32{8080},
+			}
+		})
+
+		It("stages the build with the provided droplet without invoking the delegate", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(cfBuild.Status.State).To(Equal(korifiv1alpha1.BuildStateStaged))
+				g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+				g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+				g.Expect(cfBuild.Status.Droplet).To(Equal(cfBuild.Spec.Droplet))
+			}).Should(Succeed())
+
+			Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+		})
+
+		When("the droplet is awaiting upload", func() {
+			BeforeEach(func() {
+				cfBuild.Spec.Droplet.Registry.Image = ""
+			})
+
+			It("sets the awaiting upload state", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(cfBuild.Status.State).To(Equal(korifiv1alpha1.BuildStateAwaitingUpload))
+					g.Expect(cfBuild.Status.Droplet).To(BeNil())
+					g.Expect(meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeNil())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the build succeeds", func() {
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
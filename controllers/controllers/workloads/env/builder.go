@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -32,11 +33,17 @@ type ServiceDetails struct {
 }
 
 type AppEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient             client.Client
+	rootNamespace         string
+	envVarGroupSecretName string
 }
 
-func NewAppEnvBuilder(k8sClient client.Client) *AppEnvBuilder {
-	return &AppEnvBuilder{k8sClient: k8sClient}
+func NewAppEnvBuilder(k8sClient client.Client, rootNamespace string, envVarGroupSecretName string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:             k8sClient,
+		rootNamespace:         rootNamespace,
+		envVarGroupSecretName: envVarGroupSecretName,
+	}
 }
 
 func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
@@ -63,8 +70,49 @@ func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp)
 		}
 	}
 
+	envVarGroupEnv, err := b.buildEnvVarGroupEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return sortEnvVars(envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)), nil
+	appEnv := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	return sortEnvVars(overrideEnvVars(envVarGroupEnv, appEnv)), nil
+}
+
+func (b *AppEnvBuilder) buildEnvVarGroupEnv(ctx context.Context) ([]corev1.EnvVar, error) {
+	var envVarGroupSecret corev1.Secret
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: b.envVarGroupSecretName}, &envVarGroupSecret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error when trying to fetch environment variable group secret %s/%s: %w", b.rootNamespace, b.envVarGroupSecretName, err)
+	}
+
+	var envVars []corev1.EnvVar
+	for k, v := range envVarGroupSecret.Data {
+		envVars = append(envVars, corev1.EnvVar{Name: k, Value: string(v)})
+	}
+	return envVars, nil
+}
+
+// overrideEnvVars returns the overrides together with the base env vars
+// that are not overridden
+func overrideEnvVars(base []corev1.EnvVar, overrides []corev1.EnvVar) []corev1.EnvVar {
+	overridden := map[string]bool{}
+	for _, envVar := range overrides {
+		overridden[envVar.Name] = true
+	}
+
+	result := slices.Clone(overrides)
+	for _, envVar := range base {
+		if !overridden[envVar.Name] {
+			result = append(result, envVar)
+		}
+	}
+	return result
 }
 
 func sortEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
@@ -98,9 +146,9 @@ type ProcessEnvBuilder struct {
 	k8sClient     client.Client
 }
 
-func NewProcessEnvBuilder(k8sClient client.Client) *ProcessEnvBuilder {
+func NewProcessEnvBuilder(k8sClient client.Client, rootNamespace string) *ProcessEnvBuilder {
 	return &ProcessEnvBuilder{
-		appEnvBuilder: NewAppEnvBuilder(k8sClient),
+		appEnvBuilder: NewAppEnvBuilder(k8sClient, rootNamespace, korifiv1alpha1.RunningEnvVarGroupSecretName),
 		k8sClient:     k8sClient,
 	}
 }
@@ -111,18 +159,17 @@ func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFA
 		return nil, err
 	}
 
-	env = append(env,
-		corev1.EnvVar{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
-		corev1.EnvVar{Name: "MEMORY_LIMIT", Value: fmt.Sprintf("%dM", cfProcess.Spec.MemoryMB)},
-	)
-
 	portEnv, err := b.buildPortEnv(ctx, cfApp, cfProcess)
 	if err != nil {
 		return nil, err
 	}
-	env = append(env, portEnv...)
 
-	return sortEnvVars(env), nil
+	systemEnv := append([]corev1.EnvVar{
+		{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
+		{Name: "MEMORY_LIMIT", Value: fmt.Sprintf("%dM", cfProcess.Spec.MemoryMB)},
+	}, portEnv...)
+
+	return sortEnvVars(overrideEnvVars(env, systemEnv)), nil
 }
 
 func (b *ProcessEnvBuilder) buildPortEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error) {
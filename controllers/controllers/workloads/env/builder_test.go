@@ -101,7 +101,7 @@ var _ = Describe("EnvBuilder", func() {
 		var builder *env.AppEnvBuilder
 
 		BeforeEach(func() {
-			builder = env.NewAppEnvBuilder(controllersClient)
+			builder = env.NewAppEnvBuilder(controllersClient, rootNamespace, korifiv1alpha1.StagingEnvVarGroupSecretName)
 		})
 
 		JustBeforeEach(func() {
@@ -127,6 +127,32 @@ var _ = Describe("EnvBuilder", func() {
 			Expect(slices.IsSorted(envVarNames)).To(BeTrue())
 		})
 
+		When("the environment variable group secret exists", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupSecretName,
+					},
+					Data: map[string][]byte{
+						"HTTP_PROXY":    []byte("http://proxy.example.com"),
+						"app-secret":    []byte("from-the-group"),
+						"VCAP_SERVICES": []byte("from-the-group"),
+					},
+				})
+			})
+
+			It("adds the group env vars that are not overridden by the app", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+					Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com"}),
+				))
+			})
+		})
+
 		When("the app env secret does not exist", func() {
 			BeforeEach(func() {
 				helpers.EnsureDelete(controllersClient, appSecret)
@@ -277,7 +303,7 @@ var _ = Describe("EnvBuilder", func() {
 				},
 			}
 			helpers.EnsureCreate(controllersClient, cfProcess)
-			builder = env.NewProcessEnvBuilder(controllersClient)
+			builder = env.NewProcessEnvBuilder(controllersClient, rootNamespace)
 		})
 
 		JustBeforeEach(func() {
@@ -311,6 +337,30 @@ var _ = Describe("EnvBuilder", func() {
 			Expect(slices.IsSorted(envVarNames)).To(BeTrue())
 		})
 
+		When("the running environment variable group secret exists", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupSecretName,
+					},
+					Data: map[string][]byte{
+						"HTTP_PROXY":   []byte("http://proxy.example.com"),
+						"MEMORY_LIMIT": []byte("1M"),
+					},
+				})
+			})
+
+			It("adds the group env vars that are not overridden by the system", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ContainElements(
+					Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com"}),
+					Equal(corev1.EnvVar{Name: "MEMORY_LIMIT", Value: "789M"}),
+				))
+				Expect(envVars).NotTo(ContainElement(Equal(corev1.EnvVar{Name: "MEMORY_LIMIT", Value: "1M"})))
+			})
+		})
+
 		Describe("ports env vars", func() {
 			var cfRoute *korifiv1alpha1.CFRoute
 
@@ -137,7 +137,7 @@ func fromServiceBinding(
 		BindingGUID:    serviceBinding.Name,
 		BindingName:    bindingName,
 		Credentials:    creds,
-		SyslogDrainURL: nil,
+		SyslogDrainURL: serviceInstance.Spec.SyslogDrainURL,
 		VolumeMounts:   []string{},
 	}, nil
 }
@@ -186,6 +186,22 @@ var _ = Describe("Builder", func() {
 			}))
 		})
 
+		When("the service instance has a syslog drain url", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceInstance, func(si *korifiv1alpha1.CFServiceInstance) {
+					si.Spec.SyslogDrainURL = tools.PtrTo("syslog-tls://logs.example.com:6514")
+				})
+			})
+
+			It("sets the syslog drain url", func() {
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"sb-1-type": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"syslog_drain_url": Equal("syslog-tls://logs.example.com:6514"),
+					})),
+				}))
+			})
+		})
+
 		When("the service binding has no name", func() {
 			BeforeEach(func() {
 				helpers.EnsurePatch(controllersClient, serviceBinding, func(s *korifiv1alpha1.CFServiceBinding) {
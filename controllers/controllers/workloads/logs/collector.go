@@ -0,0 +1,307 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	appSourceType     = "APP"
+	stagingSourceType = "STG"
+
+	maxCollectedLinesPerContainer = 200
+)
+
+//counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
+type LogStreamer func(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) (string, error)
+
+func DefaultLogStreamer(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) (string, error) {
+	logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &logOpts).DoRaw(ctx)
+	return string(logs), err
+}
+
+// Collector retains the logs of terminated staging and app containers in a
+// per app config map, so that they are still available once the pod they
+// ran in is gone
+type Collector struct {
+	k8sClient   client.Client
+	podReader   podReaders
+	clientset   kubernetes.Interface
+	logStreamer LogStreamer
+	scheme      *runtime.Scheme
+	log         logr.Logger
+}
+
+func NewCollector(
+	k8sClient client.Client,
+	clientset kubernetes.Interface,
+	logStreamer LogStreamer,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *Collector {
+	return &Collector{
+		k8sClient:   k8sClient,
+		clientset:   clientset,
+		logStreamer: logStreamer,
+		scheme:      scheme,
+		log:         log,
+	}
+}
+
+// SetupWithManager watches staging and app pods through dedicated caches,
+// filtered by their labels, so that the collector does not cache every pod
+// in the cluster. Label selectors cannot express "either of", hence the two
+// caches.
+func (c *Collector) SetupWithManager(mgr ctrl.Manager) error {
+	stagingPods, err := newPodCache(mgr, korifiv1alpha1.BuildWorkloadLabelKey)
+	if err != nil {
+		return err
+	}
+
+	appPods, err := newPodCache(mgr, korifiv1alpha1.CFAppGUIDLabelKey, korifiv1alpha1.CFProcessTypeLabelKey)
+	if err != nil {
+		return err
+	}
+
+	c.podReader = podReaders{stagingPods, appPods}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("retained-logs-collector").
+		WatchesRawSource(source.Kind(stagingPods, &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{})).
+		WatchesRawSource(source.Kind(appPods, &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{})).
+		Complete(c)
+}
+
+func newPodCache(mgr ctrl.Manager, labelKeys ...string) (cache.Cache, error) {
+	selector := labels.NewSelector()
+	for _, key := range labelKeys {
+		requirement, err := labels.NewRequirement(key, selection.Exists, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod label requirement: %w", err)
+		}
+		selector = selector.Add(*requirement)
+	}
+
+	podCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {Label: selector},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod cache: %w", err)
+	}
+
+	if err = mgr.Add(podCache); err != nil {
+		return nil, fmt.Errorf("failed to add pod cache to manager: %w", err)
+	}
+
+	return podCache, nil
+}
+
+// podReaders gets pods from the first cache that has them
+type podReaders []client.Reader
+
+func (r podReaders) Get(ctx context.Context, key client.ObjectKey, pod *corev1.Pod) error {
+	var err error
+	for _, reader := range r {
+		if err = reader.Get(ctx, key, pod); !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+
+func (c *Collector) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := c.log.WithName("retained-logs-collector").WithValues("namespace", req.Namespace, "name", req.Name)
+	ctx = logr.NewContext(ctx, log)
+
+	pod := &corev1.Pod{}
+	if err := c.podReader.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	containers := terminatedContainers(*pod)
+	if len(containers) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	appGUID, sourceType, err := c.podApp(ctx, *pod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if appGUID == "" {
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	if err = c.k8sClient.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: appGUID}, cfApp); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      tools.RetainedLogsConfigMapName(appGUID),
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, c.k8sClient, configMap, func() error {
+		if err := controllerutil.SetOwnerReference(cfApp, configMap, c.scheme); err != nil {
+			return err
+		}
+
+		retainedLogs, err := tools.FromRetainedLogsData(configMap.Data)
+		if err != nil {
+			log.Info("discarding corrupt retained logs", "reason", err)
+			retainedLogs = tools.RetainedLogs{}
+		}
+
+		for _, container := range containers {
+			if retainedLogs.HasContainer(container.id) {
+				continue
+			}
+
+			entries, err := c.collect(ctx, *pod, container, sourceType)
+			if err != nil {
+				return err
+			}
+			retainedLogs.Append(container.id, entries...)
+		}
+
+		configMap.Data, err = retainedLogs.ToData()
+		return err
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to retain logs: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (c *Collector) podApp(ctx context.Context, pod corev1.Pod) (string, string, error) {
+	buildWorkloadName, ok := pod.Labels[korifiv1alpha1.BuildWorkloadLabelKey]
+	if !ok {
+		return pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey], appSourceType, nil
+	}
+
+	buildWorkload := &korifiv1alpha1.BuildWorkload{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: buildWorkloadName}, buildWorkload)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("failed to get build workload: %w", err)
+	}
+
+	return buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey], stagingSourceType, nil
+}
+
+func (c *Collector) collect(ctx context.Context, pod corev1.Pod, container terminatedContainer, sourceType string) ([]tools.RetainedLogEntry, error) {
+	logs, err := c.logStreamer(ctx, c.clientset, pod, corev1.PodLogOptions{
+		Container:  container.name,
+		Previous:   container.previous,
+		Timestamps: true,
+		TailLines:  tools.PtrTo[int64](maxCollectedLinesPerContainer),
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsBadRequest(err) {
+			// the container logs have already been rotated away
+			logr.FromContextOrDiscard(ctx).Info("container logs not available", "container", container.name, "reason", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get logs of container %q: %w", container.name, err)
+	}
+
+	instanceID := ""
+	if sourceType == appSourceType {
+		instanceID = pod.Labels[korifiv1alpha1.PodIndexLabelKey]
+	}
+
+	var entries []tools.RetainedLogEntry
+	scanner := bufio.NewScanner(strings.NewReader(logs))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if line == "" {
+			continue
+		}
+
+		timestamp := container.finishedAt
+		if ts, message, found := strings.Cut(line, " "); found {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				timestamp = t
+				line = message
+			}
+		}
+
+		entries = append(entries, tools.RetainedLogEntry{
+			Timestamp:  timestamp.UnixNano(),
+			SourceType: sourceType,
+			InstanceID: instanceID,
+			Message:    line,
+		})
+	}
+
+	return entries, nil
+}
+
+type terminatedContainer struct {
+	id         string
+	name       string
+	previous   bool
+	finishedAt time.Time
+}
+
+// terminatedContainers returns the containers of the pod that have
+// terminated, including the previous incarnations of restarted containers
+func terminatedContainers(pod corev1.Pod) []terminatedContainer {
+	var result []terminatedContainer
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.ContainerID != "" {
+			result = append(result, terminatedContainer{
+				id:         terminated.ContainerID,
+				name:       status.Name,
+				finishedAt: terminated.FinishedAt.Time,
+			})
+		}
+
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.ContainerID != "" {
+			result = append(result, terminatedContainer{
+				id:         terminated.ContainerID,
+				name:       status.Name,
+				previous:   true,
+				finishedAt: terminated.FinishedAt.Time,
+			})
+		}
+	}
+
+	return result
+}
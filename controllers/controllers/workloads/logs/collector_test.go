@@ -0,0 +1,227 @@
+package logs_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Collector", func() {
+	var (
+		cfApp     *korifiv1alpha1.CFApp
+		pod       *corev1.Pod
+		configMap *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StoppedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfApp)
+
+		logStreamer.Returns("2024-03-05T10:11:12.000000001Z out of memory\n2024-03-05T10:11:13Z bye\n", nil)
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "1",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "application", Image: "app-image"}},
+			},
+		}
+		helpers.EnsureCreate(adminClient, pod)
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      tools.RetainedLogsConfigMapName(cfApp.Name),
+			},
+		}
+	})
+
+	retainedLogs := func(g Gomega) tools.RetainedLogs {
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+		logs, err := tools.FromRetainedLogsData(configMap.Data)
+		g.Expect(err).NotTo(HaveOccurred())
+		return logs
+	}
+
+	It("does not retain logs of running containers", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(logStreamer.CallCount()).To(BeZero())
+		}, "1s").Should(Succeed())
+	})
+
+	When("an app container has crashed", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, pod, func(p *corev1.Pod) {
+				p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:         "application",
+					RestartCount: 1,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: "containerd://crashed",
+							ExitCode:    137,
+							FinishedAt:  metav1.NewTime(time.Now()),
+						},
+					},
+				}}
+			})
+		})
+
+		It("retains the logs of the previous container", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(retainedLogs(g).Entries).To(HaveExactElements(
+					MatchAllFields(Fields{
+						"Timestamp":  BeEquivalentTo(time.Date(2024, 3, 5, 10, 11, 12, 1, time.UTC).UnixNano()),
+						"SourceType": Equal("APP"),
+						"InstanceID": Equal("1"),
+						"Message":    Equal("out of memory"),
+					}),
+					MatchFields(IgnoreExtras, Fields{"Message": Equal("bye")}),
+				))
+			}).Should(Succeed())
+
+			Expect(logStreamer.CallCount()).To(BeNumerically(">", 0))
+			_, _, actualPod, logOpts := logStreamer.ArgsForCall(0)
+			Expect(actualPod.Name).To(Equal(pod.Name))
+			Expect(logOpts.Container).To(Equal("application"))
+			Expect(logOpts.Previous).To(BeTrue())
+			Expect(logOpts.Timestamps).To(BeTrue())
+		})
+
+		It("owns the config map by the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+				g.Expect(configMap.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFApp"),
+					"Name": Equal(cfApp.Name),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("retains the logs of each container only once", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(retainedLogs(g).Containers).To(ConsistOf("containerd://crashed"))
+			}).Should(Succeed())
+
+			helpers.EnsurePatch(adminClient, pod, func(p *corev1.Pod) {
+				p.Labels["foo"] = "bar"
+			})
+
+			Consistently(func(g Gomega) {
+				g.Expect(retainedLogs(g).Entries).To(HaveLen(2))
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("a container of a pod that is neither an app nor a staging pod has crashed", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, pod, func(p *corev1.Pod) {
+				delete(p.Labels, korifiv1alpha1.CFProcessTypeLabelKey)
+				p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name: "application",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: "containerd://crashed",
+							ExitCode:    1,
+							FinishedAt:  metav1.NewTime(time.Now()),
+						},
+					},
+				}}
+			})
+		})
+
+		It("does not retain its logs", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(logStreamer.CallCount()).To(BeZero())
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("a staging container has terminated", func() {
+		BeforeEach(func() {
+			buildWorkload := &korifiv1alpha1.BuildWorkload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					},
+				},
+				Spec: korifiv1alpha1.BuildWorkloadSpec{
+					BuilderName: "kpack-image-builder",
+				},
+			}
+			helpers.EnsureCreate(adminClient, buildWorkload)
+
+			stagingPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.BuildWorkloadLabelKey: buildWorkload.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "completion", Image: "completion-image"}},
+				},
+			}
+			helpers.EnsureCreate(adminClient, stagingPod)
+			helpers.EnsurePatch(adminClient, stagingPod, func(p *corev1.Pod) {
+				p.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+					Name: "build",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: "containerd://build",
+							ExitCode:    1,
+						},
+					},
+				}}
+			})
+		})
+
+		It("retains the staging logs", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(retainedLogs(g).Entries).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"SourceType": Equal("STG"),
+					"InstanceID": BeEmpty(),
+					"Message":    Equal("out of memory"),
+				})))
+			}).Should(Succeed())
+
+			_, _, _, logOpts := logStreamer.ArgsForCall(0)
+			Expect(logOpts.Container).To(Equal("build"))
+			Expect(logOpts.Previous).To(BeFalse())
+		})
+	})
+})
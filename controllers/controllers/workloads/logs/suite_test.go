@@ -0,0 +1,100 @@
+package logs_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logs"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logs/fake"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	ctx             context.Context
+	stopManager     context.CancelFunc
+	stopClientCache context.CancelFunc
+	testEnv         *envtest.Environment
+	adminClient     client.Client
+	testNamespace   string
+	logStreamer     *fake.LogStreamer
+)
+
+func TestRetainedLogsCollector(t *testing.T) {
+	SetDefaultEventuallyTimeout(10 * time.Second)
+	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Retained Logs Collector Integration Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true), zap.Level(zapcore.DebugLevel)))
+
+	ctx = context.Background()
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "..", "..", "helm", "korifi", "controllers", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	_, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
+	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
+
+	clientset, err := kubernetes.NewForConfig(testEnv.Config)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = logs.NewCollector(
+		k8sManager.GetClient(),
+		clientset,
+		func(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) (string, error) {
+			return logStreamer.Spy(ctx, clientset, pod, logOpts)
+		},
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("controllers"),
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	stopManager = helpers.StartK8sManager(k8sManager)
+})
+
+var _ = BeforeEach(func() {
+	logStreamer = new(fake.LogStreamer)
+
+	testNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testNamespace,
+		},
+	})).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	stopManager()
+	stopClientCache()
+	Expect(testEnv.Stop()).To(Succeed())
+})
@@ -111,6 +111,7 @@ func (r *Reconciler) enqueueCFOrgRequests(ctx context.Context, object client.Obj
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;update
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=pods/exec;pods/portforward,verbs=create
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
 //+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=create;patch
 //+kubebuilder:rbac:groups="batch",resources=jobs,verbs=create;delete;deletecollection
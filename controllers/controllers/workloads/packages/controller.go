@@ -44,6 +44,12 @@ type ImageDeleter interface {
 	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
 }
 
+//counterfeiter:generate -o fake -fake-name BlobDeleter . BlobDeleter
+
+type BlobDeleter interface {
+	Delete(ctx context.Context, blobURL string) error
+}
+
 //counterfeiter:generate -o fake -fake-name PackageCleaner . PackageCleaner
 
 type PackageCleaner interface {
@@ -54,6 +60,7 @@ type Reconciler struct {
 	k8sClient              client.Client
 	scheme                 *runtime.Scheme
 	imageDeleter           ImageDeleter
+	blobDeleter            BlobDeleter
 	packageCleaner         PackageCleaner
 	packageRepoSecretNames []string
 	log                    logr.Logger
@@ -64,6 +71,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	imageDeleter ImageDeleter,
+	blobDeleter BlobDeleter,
 	packageCleaner PackageCleaner,
 	packageRepoSecretNames []string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFPackage] {
@@ -72,6 +80,7 @@ func NewReconciler(
 		scheme:                 scheme,
 		log:                    log,
 		imageDeleter:           imageDeleter,
+		blobDeleter:            blobDeleter,
 		packageCleaner:         packageCleaner,
 		packageRepoSecretNames: packageRepoSecretNames,
 	})
@@ -125,7 +134,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfPackage *korifiv1a
 		}
 	}()
 
-	if cfPackage.Spec.Source.Registry.Image == "" {
+	if cfPackage.Spec.Source.Registry.Image == "" && cfPackage.Spec.Source.Blob == nil {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("Initialized").WithNoRequeue()
 	}
 
@@ -148,6 +157,14 @@ func (r *Reconciler) finalize(ctx context.Context, cfPackage *korifiv1alpha1.CFP
 		}
 	}
 
+	if cfPackage.Spec.Source.Blob != nil {
+		if r.blobDeleter == nil {
+			log.Info("cannot delete blob as the package blobstore is not configured", "blobURL", cfPackage.Spec.Source.Blob.URL)
+		} else if err := r.blobDeleter.Delete(ctx, cfPackage.Spec.Source.Blob.URL); err != nil {
+			log.Info("failed to delete blob", "reason", err)
+		}
+	}
+
 	if controllerutil.RemoveFinalizer(cfPackage, korifiv1alpha1.CFPackageFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -133,6 +133,21 @@ var _ = Describe("CFPackageReconciler Integration Tests", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the package source is a blob", func() {
+			BeforeEach(func() {
+				cfPackage.Spec.Source = korifiv1alpha1.PackageSource{
+					Blob: &korifiv1alpha1.Blob{URL: "https://blobstore.example.com/packages/my-package.zip"},
+				}
+			})
+
+			It("sets the Ready condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfPackage.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("finalization", func() {
@@ -191,6 +206,30 @@ var _ = Describe("CFPackageReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the package source is a blob", func() {
+			var blobDeleteCount int
+
+			BeforeEach(func() {
+				blobDeleteCount = blobDeleter.DeleteCallCount()
+				cfPackage.Spec.Source = korifiv1alpha1.PackageSource{
+					Blob: &korifiv1alpha1.Blob{URL: "https://blobstore.example.com/packages/my-package.zip"},
+				}
+			})
+
+			It("deletes the blob instead of an image", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(blobDeleter.DeleteCallCount()).To(BeNumerically(">", blobDeleteCount))
+
+					_, blobURL := blobDeleter.DeleteArgsForCall(blobDeleteCount)
+					g.Expect(blobURL).To(Equal("https://blobstore.example.com/packages/my-package.zip"))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(imageDeleter.DeleteCallCount()).To(Equal(deleteCount))
+				}).Should(Succeed())
+			})
+		})
+
 		When("deletion fails", func() {
 			BeforeEach(func() {
 				imageDeleter.DeleteReturns(errors.New("oops"))
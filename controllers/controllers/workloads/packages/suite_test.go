@@ -36,6 +36,7 @@ var (
 	adminClient     client.Client
 	testNamespace   string
 	imageDeleter    *fake.ImageDeleter
+	blobDeleter     *fake.BlobDeleter
 	packageCleaner  *fake.PackageCleaner
 	imageClient     image.Client
 )
@@ -76,12 +77,14 @@ var _ = BeforeSuite(func() {
 	imageClient = image.NewClient(k8sClient)
 
 	imageDeleter = new(fake.ImageDeleter)
+	blobDeleter = new(fake.BlobDeleter)
 	packageCleaner = new(fake.PackageCleaner)
 	err = packages.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFPackage"),
 		imageDeleter,
+		blobDeleter,
 		packageCleaner,
 		[]string{"package-repo-secret-name"},
 	).SetupWithManager(k8sManager)
@@ -30,11 +30,14 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/tools/k8s/conditions"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -52,12 +55,26 @@ type ProcessEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error)
 }
 
+//counterfeiter:generate -o fake -fake-name UsageRecorder . UsageRecorder
+
+type UsageRecorder interface {
+	RecordAppUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, previous *korifiv1alpha1.ProcessUsage, current korifiv1alpha1.ProcessUsage) error
+}
+
+//counterfeiter:generate -o fake -fake-name AuditRecorder . AuditRecorder
+
+type AuditRecorder interface {
+	RecordProcessCrash(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, index string, instanceStatus korifiv1alpha1.InstanceStatus) error
+}
+
 type Reconciler struct {
 	k8sClient        client.Client
 	scheme           *runtime.Scheme
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       ProcessEnvBuilder
+	usageRecorder    UsageRecorder
+	auditRecorder    AuditRecorder
 }
 
 func NewReconciler(
@@ -66,8 +83,10 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
+	usageRecorder UsageRecorder,
+	auditRecorder AuditRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder, usageRecorder: usageRecorder, auditRecorder: auditRecorder}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -132,7 +151,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 	log := logr.FromContextOrDiscard(ctx)
 
 	if !cfProcess.GetDeletionTimestamp().IsZero() {
-		return ctrl.Result{}, nil
+		return r.finalizeCFProcess(ctx, cfProcess)
 	}
 
 	cfProcess.Status.ObservedGeneration = cfProcess.Generation
@@ -162,13 +181,27 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	err = r.recordUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("error when trying to record the process usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	appWorkloads, err := r.fetchAppWorkloadsForProcess(ctx, cfProcess)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	instancesStatus := getCurrentInstancesStatus(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
+	err = r.recordCrashes(ctx, cfApp, cfProcess, instancesStatus)
+	if err != nil {
+		log.Info("error when trying to record the process crashes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	cfProcess.Status.ActualInstances = getActualInstances(appWorkloads)
-	cfProcess.Status.InstancesStatus = getCurrentInstancesStatus(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
+	cfProcess.Status.InstancesStatus = instancesStatus
+	cfProcess.Status.InstanceSelector = korifiv1alpha1.GUIDLabelKey + "=" + cfProcess.Name
 
 	if !allReady(appWorkloads) {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("AppWorkloadsNotReady").WithRequeue()
@@ -177,6 +210,112 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 	return ctrl.Result{}, nil
 }
 
+// finalizeCFProcess records the stop of a process that is deleted while
+// started, as its usage would otherwise never end
+func (r *Reconciler) finalizeCFProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFProcess")
+
+	if !controllerutil.ContainsFinalizer(cfProcess, korifiv1alpha1.CFProcessFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	previous := cfProcess.Status.RecordedUsage
+	if previous != nil && previous.State == korifiv1alpha1.UsageStateStarted {
+		cfApp, err := r.getAppForUsage(ctx, cfProcess)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		current := korifiv1alpha1.ProcessUsage{
+			State:     korifiv1alpha1.UsageStateStopped,
+			Instances: previous.Instances,
+			MemoryMB:  previous.MemoryMB,
+		}
+		if err = r.usageRecorder.RecordAppUsage(ctx, cfApp, cfProcess, previous, current); err != nil {
+			log.Info("error when trying to record the process usage", "reason", err)
+			return ctrl.Result{}, err
+		}
+		cfProcess.Status.RecordedUsage = &current
+	}
+
+	controllerutil.RemoveFinalizer(cfProcess, korifiv1alpha1.CFProcessFinalizerName)
+	log.V(1).Info("finalizer removed")
+
+	return ctrl.Result{}, nil
+}
+
+// getAppForUsage falls back to an app carrying only its guid when the app has
+// already been deleted, so that the stop of its processes is still recorded
+func (r *Reconciler) getAppForUsage(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (*korifiv1alpha1.CFApp, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfProcess.Namespace,
+			Name:      cfProcess.Spec.AppRef.Name,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	return cfApp, nil
+}
+
+// recordUsage records an app usage event whenever the process is started,
+// stopped or scaled. Scaling a stopped process is not recorded.
+func (r *Reconciler) recordUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	previous := cfProcess.Status.RecordedUsage
+	current := getCurrentUsage(cfApp, cfProcess)
+
+	if previous != nil && *previous == current {
+		return nil
+	}
+
+	if current.State == korifiv1alpha1.UsageStateStarted || (previous != nil && previous.State == korifiv1alpha1.UsageStateStarted) {
+		if err := r.usageRecorder.RecordAppUsage(ctx, cfApp, cfProcess, previous, current); err != nil {
+			return err
+		}
+	}
+
+	cfProcess.Status.RecordedUsage = &current
+	return nil
+}
+
+// recordCrashes records an audit event for every instance that crashed since
+// the instances status has last been updated
+func (r *Reconciler) recordCrashes(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, instancesStatus map[string]korifiv1alpha1.InstanceStatus) error {
+	for index, current := range instancesStatus {
+		if current.LastCrash == nil {
+			continue
+		}
+
+		previous, ok := cfProcess.Status.InstancesStatus[index]
+		if ok && previous.LastCrash != nil && !previous.LastCrash.Timestamp.Before(&current.LastCrash.Timestamp) {
+			continue
+		}
+
+		if err := r.auditRecorder.RecordProcessCrash(ctx, cfApp, cfProcess, index, current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getCurrentUsage(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) korifiv1alpha1.ProcessUsage {
+	state := korifiv1alpha1.UsageStateStopped
+	if needsAppWorkload(cfApp, cfProcess) {
+		state = korifiv1alpha1.UsageStateStarted
+	}
+
+	return korifiv1alpha1.ProcessUsage{
+		State:     state,
+		Instances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		MemoryMB:  cfProcess.Spec.MemoryMB,
+	}
+}
+
 func allReady(appWorkloads []korifiv1alpha1.AppWorkload) bool {
 	return it.All(it.Map(slices.Values(appWorkloads), func(w korifiv1alpha1.AppWorkload) bool {
 		return conditions.CheckConditionIsTrue(&w, korifiv1alpha1.StatusConditionReady) == nil
@@ -210,7 +349,7 @@ func getCurrentInstancesStatus(desiredAppWorkloadName string, appWorkloads []kor
 }
 
 func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) bool {
-	if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+	if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState || !cfApp.GetDeletionTimestamp().IsZero() {
 		return false
 	}
 
@@ -254,6 +393,12 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		return err
 	}
 
+	placement, err := shared.GetSpacePlacement(ctx, r.k8sClient, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to get the space placement", "namespace", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
 	appWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      getDesiredAppWorkloadName(cfApp, cfProcess),
@@ -262,6 +407,8 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	}
 
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, appWorkload, func() error {
+		existingAppWorkload := appWorkload.DeepCopy()
+
 		appWorkload.Labels = make(map[string]string)
 		appWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey] = cfApp.Name
 		appWorkload.Labels[korifiv1alpha1.CFAppRevisionKey] = getRevision(cfApp)
@@ -296,11 +443,14 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		appWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 		appWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
 		appWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
+		appWorkload.Spec.Placement = placement
 
 		if appWorkload.CreationTimestamp.IsZero() {
 			appWorkload.Spec.Services = cfApp.Status.ServiceBindings
 		}
 
+		stampTraceContext(ctx, existingAppWorkload, appWorkload)
+
 		return controllerutil.SetControllerReference(cfProcess, appWorkload, r.scheme)
 	})
 	if err != nil {
@@ -337,6 +487,7 @@ func needsToDeleteAppWorkload(
 	appWorkload korifiv1alpha1.AppWorkload,
 ) bool {
 	return cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState ||
+		!cfApp.GetDeletionTimestamp().IsZero() ||
 		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) ||
 		appWorkload.Name != getDesiredAppWorkloadName(cfApp, cfProcess)
 }
@@ -446,3 +597,15 @@ func livenessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.P
 func mebibyteQuantity(miB int64) resource.Quantity {
 	return *resource.NewQuantity(miB*1024*1024, resource.BinarySI)
 }
+
+// stampTraceContext stamps the trace of the reconcile onto the workload only
+// when the reconcile changes the workload spec. Stamping every reconcile would
+// update the workload, and hence trigger its runner and this controller, on
+// every reconcile.
+func stampTraceContext(ctx context.Context, existingAppWorkload, appWorkload *korifiv1alpha1.AppWorkload) {
+	if equality.Semantic.DeepEqual(existingAppWorkload.Spec, appWorkload.Spec) {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, tracing.SpanContextFromObject(existingAppWorkload))
+	}
+
+	tracing.InjectIntoObject(ctx, appWorkload)
+}
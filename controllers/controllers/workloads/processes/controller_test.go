@@ -2,6 +2,7 @@ package processes_test
 
 import (
 	"context"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
@@ -13,6 +14,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -142,6 +144,9 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
 					korifiv1alpha1.CFProcessTypeLabelKey: korifiv1alpha1.ProcessTypeWeb,
 				},
+				Finalizers: []string{
+					korifiv1alpha1.CFProcessFinalizerName,
+				},
 			},
 			Spec: korifiv1alpha1.CFProcessSpec{
 				AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
@@ -180,6 +185,18 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("does not record usage of the stopped process", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Status.RecordedUsage).To(PointTo(Equal(korifiv1alpha1.ProcessUsage{
+				State:     korifiv1alpha1.UsageStateStopped,
+				Instances: 1,
+				MemoryMB:  1024,
+			})))
+		}).Should(Succeed())
+		Expect(recordedUsages(cfProcess.Name)).To(BeEmpty())
+	})
+
 	When("the process is being deleted gracefully", func() {
 		BeforeEach(func() {
 			cfProcess.Finalizers = []string{"do-not-delete-yet"}
@@ -263,6 +280,152 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("records the start of the process", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(recordedUsages(cfProcess.Name)).To(ConsistOf(recordedUsage{
+					Previous: nil,
+					Current: korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.UsageStateStarted,
+						Instances: 1,
+						MemoryMB:  1024,
+					},
+				}))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Status.RecordedUsage).To(PointTo(Equal(korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.UsageStateStarted,
+					Instances: 1,
+					MemoryMB:  1024,
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process is scaled", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				})).To(Succeed())
+			})
+
+			It("records the scaling of the process", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(recordedUsages(cfProcess.Name)).To(ContainElement(recordedUsage{
+						Previous: &korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStarted,
+							Instances: 1,
+							MemoryMB:  1024,
+						},
+						Current: korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStarted,
+							Instances: 3,
+							MemoryMB:  1024,
+						},
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app is being deleted", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
+				Expect(adminClient.Delete(ctx, cfApp)).To(Succeed())
+			})
+
+			It("deletes the app workload and records the stop of the process", func() {
+				Eventually(func(g Gomega) {
+					var appWorkloads korifiv1alpha1.AppWorkloadList
+					g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(appWorkloads.Items).To(BeEmpty())
+
+					g.Expect(recordedUsages(cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Current": MatchFields(IgnoreExtras, Fields{
+							"State": Equal(korifiv1alpha1.UsageStateStopped),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the process is deleted", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Status.RecordedUsage).NotTo(BeNil())
+				}).Should(Succeed())
+				Expect(adminClient.Delete(ctx, cfProcess)).To(Succeed())
+			})
+
+			It("records the stop of the process before it is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					g.Expect(recordedUsages(cfProcess.Name)).To(ContainElement(recordedUsage{
+						Previous: &korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStarted,
+							Instances: 1,
+							MemoryMB:  1024,
+						},
+						Current: korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStopped,
+							Instances: 1,
+							MemoryMB:  1024,
+						},
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the space is assigned to an isolation segment", func() {
+			var cfSpace *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				cfSpace = &korifiv1alpha1.CFSpace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFSpaceSpec{
+						DisplayName:          "test-space",
+						IsolationSegmentGUID: "iso-seg-guid",
+					},
+				}
+				Expect(adminClient.Create(ctx, cfSpace)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfSpace, func() {
+					cfSpace.Status.GUID = testNamespace
+					cfSpace.Status.IsolationSegmentGUID = "iso-seg-guid"
+					cfSpace.Status.Placement = korifiv1alpha1.Placement{
+						NodeSelector: map[string]string{"pool": "regulated"},
+					}
+				})).To(Succeed())
+			})
+
+			It("places the app workload on the isolation segment nodes", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Placement.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				})
+			})
+
+			When("the placement of the isolation segment is not resolved yet", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, cfSpace, func() {
+						cfSpace.Status.IsolationSegmentGUID = ""
+						cfSpace.Status.Placement = korifiv1alpha1.Placement{}
+					})).To(Succeed())
+				})
+
+				It("does not create the app workload", func() {
+					Consistently(func(g Gomega) {
+						var appWorkloads korifiv1alpha1.AppWorkloadList
+						g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+						g.Expect(appWorkloads.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the CFApp status is outdated", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
@@ -382,6 +545,13 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("sets the instance selector used by the scale subresource", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Status.InstanceSelector).To(Equal("korifi.cloudfoundry.org/guid=" + cfProcess.Name))
+			}).Should(Succeed())
+		})
+
 		When("the app workload instance state is set", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -407,6 +577,39 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("an app workload instance has crashed", func() {
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(k8s.Patch(ctx, adminClient, &appWorkload, func() {
+						appWorkload.Status.InstancesStatus = map[string]korifiv1alpha1.InstanceStatus{
+							"1": {
+								State:      korifiv1alpha1.InstanceStateCrashed,
+								CrashCount: 2,
+								LastCrash: &korifiv1alpha1.InstanceCrash{
+									Reason:     "Error",
+									ExitStatus: 1,
+									Timestamp:  metav1.NewTime(time.UnixMilli(3000).UTC()),
+								},
+							},
+						}
+					})).To(Succeed())
+				})
+			})
+
+			It("records the crash once", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(recordedCrashes(cfProcess.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Index":      Equal("1"),
+						"CrashCount": BeEquivalentTo(2),
+					})))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(recordedCrashes(cfProcess.Name)).To(HaveLen(1))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the app workload is not ready", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -528,6 +731,23 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkloads.Items).To(BeEmpty())
 				}).Should(Succeed())
 			})
+
+			It("records the stop of the process", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(recordedUsages(cfProcess.Name)).To(ContainElement(recordedUsage{
+						Previous: &korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStarted,
+							Instances: 1,
+							MemoryMB:  1024,
+						},
+						Current: korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.UsageStateStopped,
+							Instances: 1,
+							MemoryMB:  1024,
+						},
+					}))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the app process instances are scaled down to 0", func() {
@@ -618,3 +838,41 @@ func withAppWorkload(shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 		shouldFn(g, appWorkloads.Items[0])
 	}).Should(Succeed())
 }
+
+type recordedUsage struct {
+	Previous *korifiv1alpha1.ProcessUsage
+	Current  korifiv1alpha1.ProcessUsage
+}
+
+// recordedUsages returns the usages recorded for a process, as the usage
+// recorder is shared by all tests
+func recordedUsages(processGUID string) []recordedUsage {
+	usages := []recordedUsage{}
+	for i := range usageRecorder.RecordAppUsageCallCount() {
+		_, _, process, previous, current := usageRecorder.RecordAppUsageArgsForCall(i)
+		if process.Name == processGUID {
+			usages = append(usages, recordedUsage{Previous: previous, Current: current})
+		}
+	}
+
+	return usages
+}
+
+type recordedCrash struct {
+	Index      string
+	CrashCount int32
+}
+
+// recordedCrashes returns the crashes recorded for a process, as the audit
+// recorder is shared by all tests
+func recordedCrashes(processGUID string) []recordedCrash {
+	crashes := []recordedCrash{}
+	for i := range auditRecorder.RecordProcessCrashCallCount() {
+		_, _, process, index, instanceStatus := auditRecorder.RecordProcessCrashArgsForCall(i)
+		if process.Name == processGUID {
+			crashes = append(crashes, recordedCrash{Index: index, CrashCount: instanceStatus.CrashCount})
+		}
+	}
+
+	return crashes
+}
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"github.com/google/uuid"
@@ -36,6 +37,8 @@ var (
 	adminClient     client.Client
 	testNamespace   string
 	k8sManager      manager.Manager
+	usageRecorder   *fake.UsageRecorder
+	auditRecorder   *fake.AuditRecorder
 )
 
 func TestWorkloadsControllers(t *testing.T) {
@@ -73,12 +76,17 @@ var _ = BeforeSuite(func() {
 		RunnerName: "cf-process-controller-test",
 	}
 
+	usageRecorder = new(fake.UsageRecorder)
+	auditRecorder = new(fake.AuditRecorder)
+
 	err = processes.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		controllerConfig,
-		env.NewProcessEnvBuilder(k8sManager.GetClient()),
+		env.NewProcessEnvBuilder(k8sManager.GetClient(), "cf"),
+		usageRecorder,
+		auditRecorder,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -18,6 +18,7 @@ package spaces
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -31,6 +32,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -91,6 +93,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&corev1.ServiceAccount{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+		).
+		Watches(
+			&korifiv1alpha1.CFIsolationSegment{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForIsolationSegment),
 		)
 }
 
@@ -128,9 +134,27 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 	return requests
 }
 
+func (r *Reconciler) enqueueCFSpaceRequestsForIsolationSegment(ctx context.Context, object client.Object) []reconcile.Request {
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	err := r.client.List(ctx, cfSpaceList)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for i := range cfSpaceList.Items {
+		if cfSpaceList.Items[i].Spec.IsolationSegmentGUID == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfSpaceList.Items[i])})
+		}
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
@@ -151,9 +175,40 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ServiceAccountPropagation")
 	}
 
+	err = r.reconcilePlacement(ctx, cfSpace)
+	if err != nil {
+		log.Info("not ready yet", "reason", "error resolving isolation segment", "error", err)
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("IsolationSegmentNotResolved")
+	}
+
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) reconcilePlacement(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) error {
+	// workloads are not placed until the placement of the isolation segment
+	// the space is assigned to is resolved, see shared.GetSpacePlacement
+	cfSpace.Status.Placement = korifiv1alpha1.Placement{}
+	cfSpace.Status.IsolationSegmentGUID = ""
+
+	if cfSpace.Spec.IsolationSegmentGUID == "" {
+		return nil
+	}
+
+	isolationSegment := &korifiv1alpha1.CFIsolationSegment{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: cfSpace.Spec.IsolationSegmentGUID}, isolationSegment)
+	if err != nil {
+		return fmt.Errorf("failed to get isolation segment %q: %w", cfSpace.Spec.IsolationSegmentGUID, err)
+	}
+
+	if !isolationSegment.IsEntitled(cfSpace.Namespace) {
+		return fmt.Errorf("org %q is not entitled to isolation segment %q", cfSpace.Namespace, isolationSegment.Name)
+	}
+
+	cfSpace.Status.Placement = isolationSegment.Spec.Placement
+	cfSpace.Status.IsolationSegmentGUID = isolationSegment.Name
+	return nil
+}
+
 func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, space client.Object) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("reconcileServiceAccounts").
 		WithValues("rootNamespace", r.rootNamespace, "targetNamespace", space.GetName())
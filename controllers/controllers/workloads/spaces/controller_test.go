@@ -324,4 +324,83 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 	})
+
+	Describe("isolation segment placement", func() {
+		var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					DisplayName: uuid.NewString(),
+					Placement: korifiv1alpha1.Placement{
+						NodeSelector: map[string]string{"pool": "regulated"},
+					},
+					Organizations: []string{testNamespace},
+				},
+			}
+			Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.IsolationSegmentGUID = isolationSegment.Name
+			})).To(Succeed())
+		})
+
+		It("sets the isolation segment placement on the space status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				g.Expect(cfSpace.Status.Placement.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				g.Expect(cfSpace.Status.IsolationSegmentGUID).To(Equal(isolationSegment.Name))
+			}).Should(Succeed())
+		})
+
+		When("the isolation segment placement changes", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					g.Expect(cfSpace.Status.Placement.NodeSelector).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, isolationSegment, func() {
+					isolationSegment.Spec.Placement.NodeSelector = map[string]string{"pool": "another"}
+				})).To(Succeed())
+			})
+
+			It("updates the space status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					g.Expect(cfSpace.Status.Placement.NodeSelector).To(Equal(map[string]string{"pool": "another"}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the org is not entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, isolationSegment, func() {
+					isolationSegment.Spec.Organizations = nil
+				})).To(Succeed())
+			})
+
+			It("sets the ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					readyCondition := meta.FindStatusCondition(cfSpace.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+					g.Expect(readyCondition).NotTo(BeNil())
+					g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(readyCondition.Reason).To(Equal("IsolationSegmentNotResolved"))
+				}).Should(Succeed())
+			})
+
+			It("clears the placement of the space", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+					g.Expect(cfSpace.Status.Placement).To(BeZero())
+					g.Expect(cfSpace.Status.IsolationSegmentGUID).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -251,6 +252,12 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
+	placement, err := shared.GetSpacePlacement(ctx, r.k8sClient, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to get space placement", "reason", err)
+		return nil, err
+	}
+
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfTask.Name,
@@ -283,6 +290,7 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
 		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
 		taskWorkload.Spec.Env = env
+		taskWorkload.Spec.Placement = placement
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
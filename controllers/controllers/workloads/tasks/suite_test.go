@@ -77,7 +77,7 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
-		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		env.NewAppEnvBuilder(k8sManager.GetClient(), "cf", korifiv1alpha1.RunningEnvVarGroupSecretName),
 		2*time.Second,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
@@ -27,21 +28,26 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/audit"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logs"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/packages"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
@@ -61,12 +67,16 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	versionwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/version"
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
+	isolationsegmentswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
+	stackswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/stacks"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
 	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 	"code.cloudfoundry.org/korifi/version"
 
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -76,6 +86,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	admission "k8s.io/pod-security-admission/api"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -142,6 +153,19 @@ func main() {
 
 	ctrl.Log.Info("starting Korifi controllers", "version", version.Version)
 
+	if controllerConfig.Tracing.Enabled {
+		traceExporter, err := tracing.NewOTLPExporter(context.Background(), controllerConfig.Tracing)
+		if err != nil {
+			panic(fmt.Sprintf("could not create trace exporter: %v", err))
+		}
+		tracerProvider := tracing.Setup("korifi-controllers", traceExporter)
+		defer func() {
+			if err := tracerProvider.Shutdown(context.Background()); err != nil {
+				setupLog.Error(err, "error shutting down tracer provider")
+			}
+		}()
+	}
+
 	conf := ctrl.GetConfigOrDie()
 	k8sClient, err := k8sclient.NewForConfig(conf)
 	if err != nil {
@@ -171,6 +195,61 @@ func main() {
 		controllersLog := ctrl.Log.WithName("controllers")
 		imageClient := image.NewClient(k8sClient)
 
+		syslogDrainManager := drains.NewManager(k8sClient, drains.NewWriterFactory(&tls.Config{MinVersion: tls.VersionTLS12}), controllersLog)
+		if err = mgr.Add(syslogDrainManager); err != nil {
+			setupLog.Error(err, "unable to add syslog drain manager")
+			os.Exit(1)
+		}
+
+		var usageEventRetention time.Duration
+		usageEventRetention, err = controllerConfig.ParseUsageEventRetention()
+		if err != nil {
+			setupLog.Error(err, "failed to parse usage event retention", "usageEventRetention", controllerConfig.UsageEventRetention)
+			os.Exit(1)
+		}
+
+		usageRecorder := usage.NewRecorder(controllersClient, controllerConfig.CFRootNamespace)
+		if err = mgr.Add(usage.NewPruner(controllersClient, controllerConfig.CFRootNamespace, usageEventRetention, controllersLog)); err != nil {
+			setupLog.Error(err, "unable to add usage event pruner")
+			os.Exit(1)
+		}
+
+		var auditEventRetention time.Duration
+		auditEventRetention, err = controllerConfig.ParseAuditEventRetention()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event retention", "auditEventRetention", controllerConfig.AuditEventRetention)
+			os.Exit(1)
+		}
+
+		if err = mgr.Add(audit.NewPruner(controllersClient, auditEventRetention, controllersLog)); err != nil {
+			setupLog.Error(err, "unable to add audit event pruner")
+			os.Exit(1)
+		}
+
+		if controllerConfig.RegistryGarbageCollection.Enabled {
+			var registryGCInterval time.Duration
+			registryGCInterval, err = controllerConfig.ParseRegistryGCInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse registry garbage collection interval", "interval", controllerConfig.RegistryGarbageCollection.Interval)
+				os.Exit(1)
+			}
+
+			if err = mgr.Add(cleanup.NewRegistryGarbageCollector(
+				controllersClient,
+				imageClient,
+				image.Creds{
+					Namespace:   controllerConfig.CFRootNamespace,
+					SecretNames: controllerConfig.ContainerRegistrySecretNames,
+				},
+				controllerConfig.ContainerRepositoryPrefix,
+				registryGCInterval,
+				controllersLog,
+			).WithDryRun(controllerConfig.RegistryGarbageCollection.DryRun)); err != nil {
+				setupLog.Error(err, "unable to add registry garbage collector")
+				os.Exit(1)
+			}
+		}
+
 		if err = apps.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -189,7 +268,7 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewAppEnvBuilder(controllersClient),
+			env.NewAppEnvBuilder(controllersClient, controllerConfig.CFRootNamespace, korifiv1alpha1.StagingEnvVarGroupSecretName),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -206,11 +285,21 @@ func main() {
 			os.Exit(1)
 		}
 
+		var packageBlobDeleter packages.BlobDeleter
+		if controllerConfig.PackageBlobstore.IsEnabled() {
+			packageBlobDeleter, err = blobstore.NewStore(controllerConfig.PackageBlobstore)
+			if err != nil {
+				setupLog.Error(err, "unable to create package blobstore")
+				os.Exit(1)
+			}
+		}
+
 		if err = packages.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
 			controllersLog,
 			imageClient,
+			packageBlobDeleter,
 			cleanup.NewPackageCleaner(controllersClient, controllerConfig.MaxRetainedPackagesPerApp),
 			controllerConfig.ContainerRegistrySecretNames,
 		).SetupWithManager(mgr); err != nil {
@@ -223,16 +312,29 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewProcessEnvBuilder(controllersClient),
+			env.NewProcessEnvBuilder(controllersClient, controllerConfig.CFRootNamespace),
+			usageRecorder,
+			audit.NewRecorder(controllersClient),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
 		}
 
+		if err = autoscaling.NewReconciler(
+			controllersClient,
+			mgr.GetScheme(),
+			controllersLog,
+			clock.RealClock{},
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAutoscalingPolicy")
+			os.Exit(1)
+		}
+
 		if err = (upsi_instances.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
 			controllersLog,
+			usageRecorder,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
 			os.Exit(1)
@@ -249,6 +351,7 @@ func main() {
 				controllerConfig.CFRootNamespace,
 				mgr.GetScheme(),
 			),
+			syslogDrainManager,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 			os.Exit(1)
@@ -295,13 +398,24 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			controllersLog,
-			env.NewAppEnvBuilder(controllersClient),
+			env.NewAppEnvBuilder(controllersClient, controllerConfig.CFRootNamespace, korifiv1alpha1.RunningEnvVarGroupSecretName),
 			taskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		if err = logs.NewCollector(
+			controllersClient,
+			k8sClient,
+			logs.DefaultLogStreamer,
+			mgr.GetScheme(),
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "RetainedLogsCollector")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -328,6 +442,7 @@ func main() {
 				mgr.GetScheme(),
 				controllerConfig.CFRootNamespace,
 				controllersLog,
+				usageRecorder,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "ManagedCFServiceInstance")
 				os.Exit(1)
@@ -451,6 +566,22 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = isolationsegmentswebhook.NewValidator(
+			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, isolationsegmentswebhook.IsolationSegmentEntityType)),
+			controllerConfig.CFRootNamespace,
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFIsolationSegment")
+			os.Exit(1)
+		}
+
+		if err = stackswebhook.NewValidator(
+			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, stackswebhook.StackEntityType)),
+			controllerConfig.CFRootNamespace,
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFStack")
+			os.Exit(1)
+		}
+
 		if err = taskswebhook.NewDefaulter(controllerConfig.CFProcessDefaults).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
@@ -145,5 +145,21 @@ var _ = Describe("Controllers Finalizers Webhook", func() {
 			},
 			korifiv1alpha1.CFServiceBindingFinalizerName,
 		),
+		Entry("cfprocess",
+			&korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:      corev1.LocalObjectReference{Name: "cfapp"},
+					ProcessType: korifiv1alpha1.ProcessTypeWeb,
+					HealthCheck: korifiv1alpha1.HealthCheck{
+						Type: "process",
+					},
+				},
+			},
+			korifiv1alpha1.CFProcessFinalizerName,
+		),
 	)
 })
@@ -0,0 +1,13 @@
+package isolationsegments_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIsolationSegmentsWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFIsolationSegment Webhook Unit Test Suite")
+}
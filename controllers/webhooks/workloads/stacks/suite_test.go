@@ -0,0 +1,13 @@
+package stacks_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStacksWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFStack Webhook Unit Test Suite")
+}
@@ -0,0 +1,78 @@
+package stacks
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const StackEntityType = "stack"
+
+var cfstacklog = logf.Log.WithName("cfstack-validation")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfstack,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=create;update;delete,versions=v1alpha1,name=vcfstack.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	duplicateValidator webhooks.NameValidator
+	rootNamespace      string
+}
+
+var _ webhook.CustomValidator = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, rootNamespace string) *Validator {
+	return &Validator{
+		duplicateValidator: duplicateValidator,
+		rootNamespace:      rootNamespace,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFStack{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfstacklog, v.rootNamespace, stack)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	if !stack.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldStack, ok := oldObj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", oldObj))
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfstacklog, v.rootNamespace, oldStack, stack)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfstacklog, v.rootNamespace, stack)
+}
@@ -0,0 +1,121 @@
+package stacks_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/stacks"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFStackValidatingWebhook", func() {
+	const rootNamespace = "cf"
+
+	var (
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		stack              *korifiv1alpha1.CFStack
+		validatingWebhook  *stacks.Validator
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		stack = &korifiv1alpha1.CFStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFStackSpec{
+				DisplayName: "My-Stack",
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = stacks.NewValidator(duplicateValidator, rootNamespace)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, stack)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(stack))
+			Expect(actualResource.UniqueName()).To(Equal("my-stack"))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Stack names are case insensitive and must be unique: 'My-Stack' already exists."))
+		})
+
+		When("the name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedStack *korifiv1alpha1.CFStack
+
+		BeforeEach(func() {
+			updatedStack = stack.DeepCopy()
+			updatedStack.Spec.DisplayName = "another-name"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, stack, updatedStack)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualOld, actualNew := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualOld).To(Equal(stack))
+			Expect(actualNew).To(Equal(updatedStack))
+		})
+
+		When("the stack is being deleted", func() {
+			BeforeEach(func() {
+				updatedStack.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not validate the name", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, stack)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(stack))
+		})
+	})
+})
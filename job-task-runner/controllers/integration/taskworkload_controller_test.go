@@ -46,6 +46,14 @@ var _ = Describe("Job TaskWorkload Controller Integration Test", func() {
 				ImagePullSecrets: []corev1.LocalObjectReference{{
 					Name: "my-image-secret",
 				}},
+				Placement: korifiv1alpha1.Placement{
+					NodeSelector: map[string]string{"pool": "regulated"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				},
 			},
 		}
 	})
@@ -83,6 +91,8 @@ var _ = Describe("Job TaskWorkload Controller Integration Test", func() {
 		}))
 		Expect(podSpec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 		Expect(podSpec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "my-image-secret"}))
+		Expect(podSpec.NodeSelector).To(Equal(taskWorkload.Spec.Placement.NodeSelector))
+		Expect(podSpec.Tolerations).To(Equal(taskWorkload.Spec.Placement.Tolerations))
 		Expect(podSpec.Containers).To(HaveLen(1))
 		Expect(podSpec.Containers[0].Name).To(Equal("workload"))
 		Expect(podSpec.Containers[0].Image).To(Equal("my-image"))
@@ -177,6 +177,8 @@ func (r *TaskWorkloadReconciler) workloadToJob(taskWorkload *korifiv1alpha1.Task
 					},
 					AutomountServiceAccountToken: tools.PtrTo(false),
 					ImagePullSecrets:             taskWorkload.Spec.ImagePullSecrets,
+					NodeSelector:                 taskWorkload.Spec.Placement.NodeSelector,
+					Tolerations:                  taskWorkload.Spec.Placement.Tolerations,
 					Containers: []corev1.Container{{
 						Name:      workloadContainerName,
 						Image:     taskWorkload.Spec.Image,
@@ -0,0 +1,262 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload/state"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Environment Variable Names
+	EnvPort               = "PORT"
+	EnvServiceBindingRoot = "SERVICE_BINDING_ROOT"
+
+	// Knative Service Keys
+	AnnotationVersion     = "korifi.cloudfoundry.org/version"
+	AnnotationAppID       = "korifi.cloudfoundry.org/application-id"
+	AnnotationProcessGUID = "korifi.cloudfoundry.org/process-guid"
+
+	LabelVersion         = "korifi.cloudfoundry.org/version"
+	LabelAppGUID         = "korifi.cloudfoundry.org/app-guid"
+	LabelAppWorkloadGUID = "korifi.cloudfoundry.org/appworkload-guid"
+	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
+
+	AnnotationMinScale = "autoscaling.knative.dev/min-scale"
+	AnnotationMaxScale = "autoscaling.knative.dev/max-scale"
+
+	ApplicationContainerName = "application"
+	ServiceAccountName       = "korifi-app"
+)
+
+var KnativeServiceGVK = schema.GroupVersionKind{
+	Group:   "serving.knative.dev",
+	Version: "v1",
+	Kind:    "Service",
+}
+
+// AppWorkloadReconciler reconciles a AppWorkload object into a Knative Service
+type AppWorkloadReconciler struct {
+	k8sClient      client.Client
+	scheme         *runtime.Scheme
+	workloadToKsvc *AppWorkloadToKnativeServiceConverter
+	log            logr.Logger
+}
+
+func NewAppWorkloadReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	workloadToKsvc *AppWorkloadToKnativeServiceConverter,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload] {
+	appWorkloadReconciler := AppWorkloadReconciler{
+		k8sClient:      c,
+		scheme:         scheme,
+		workloadToKsvc: workloadToKsvc,
+		log:            log,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.AppWorkload](log, c, &appWorkloadReconciler)
+}
+
+func (r *AppWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	ksvc := new(unstructured.Unstructured)
+	ksvc.SetGroupVersionKind(KnativeServiceGVK)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.AppWorkload{}).
+		Owns(ksvc).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAppWorkloadRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(filterAppWorkloads))
+}
+
+func (r *AppWorkloadReconciler) enqueueAppWorkloadRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	if appWorkloadName, ok := o.GetLabels()[LabelAppWorkloadGUID]; ok {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      appWorkloadName,
+				Namespace: o.GetNamespace(),
+			},
+		})
+	}
+
+	return requests
+}
+
+func filterAppWorkloads(object client.Object) bool {
+	appWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+	if !ok {
+		return true
+	}
+
+	return appWorkload.Spec.RunnerName == controllers.AppWorkloadReconcilerName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=create;patch;get;list;watch
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;get;watch;patch
+
+func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	appWorkload.Status.ObservedGeneration = appWorkload.Generation
+	log.V(1).Info("set observed generation", "generation", appWorkload.Status.ObservedGeneration)
+
+	// The Knative Service is owned by the AppWorkload and garbage collected
+	// with it
+	if !appWorkload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	desiredKsvc, err := r.workloadToKsvc.Convert(appWorkload)
+	if err != nil {
+		log.Info("error when converting AppWorkload", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	ksvc := new(unstructured.Unstructured)
+	ksvc.SetGroupVersionKind(KnativeServiceGVK)
+	ksvc.SetNamespace(desiredKsvc.GetNamespace())
+	ksvc.SetName(desiredKsvc.GetName())
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, ksvc, func() error {
+		// Knative keeps track of the service creator in its annotations,
+		// hence the desired labels and annotations are merged into the
+		// existing ones rather than replacing them
+		ksvc.SetLabels(mergeMaps(ksvc.GetLabels(), desiredKsvc.GetLabels()))
+		ksvc.SetAnnotations(mergeMaps(ksvc.GetAnnotations(), desiredKsvc.GetAnnotations()))
+		ksvc.Object["spec"] = desiredKsvc.Object["spec"]
+
+		return controllerutil.SetControllerReference(appWorkload, ksvc, r.scheme)
+	})
+	if err != nil {
+		if meta.IsNoMatchError(err) || k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("KnativeServingNotInstalled").
+				WithMessage("the knative runner requires Knative Serving to be installed").
+				WithRequeue()
+		}
+		log.Info("error when creating or updating Knative Service", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	workloadPods := &corev1.PodList{}
+	err = r.k8sClient.List(ctx, workloadPods, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{
+		LabelAppWorkloadGUID: appWorkload.Name,
+	})
+	if err != nil {
+		log.Info("error when listing workload pods", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if err = r.indexInstances(ctx, workloadPods.Items); err != nil {
+		log.Info("error when indexing workload pods", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	previousInstancesStatus := appWorkload.Status.InstancesStatus
+	appWorkload.Status.ActualInstances = 0
+	appWorkload.Status.InstancesStatus = map[string]korifiv1alpha1.InstanceStatus{}
+	for _, pod := range workloadPods.Items {
+		index, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]
+		if !ok {
+			continue
+		}
+
+		instanceState := state.GetPodState(pod, previousInstancesStatus[index])
+		if instanceState.State == korifiv1alpha1.InstanceStateRunning {
+			appWorkload.Status.ActualInstances++
+		}
+		appWorkload.Status.InstancesStatus[index] = instanceState
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// indexInstances labels every workload pod that has not been indexed yet
+// with the lowest free instance index. Knative pods have no stable identity,
+// while Korifi identifies the instances of a process by their index label
+func (r *AppWorkloadReconciler) indexInstances(ctx context.Context, pods []corev1.Pod) error {
+	usedIndexes := map[string]bool{}
+	for _, pod := range pods {
+		if index, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]; ok {
+			usedIndexes[index] = true
+		}
+	}
+
+	nextIndex := 0
+	for i := range pods {
+		pod := &pods[i]
+		if _, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]; ok || !pod.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		for usedIndexes[strconv.Itoa(nextIndex)] {
+			nextIndex++
+		}
+		index := strconv.Itoa(nextIndex)
+		usedIndexes[index] = true
+
+		if err := k8s.PatchResource(ctx, r.k8sClient, pod, func() {
+			pod.Labels = tools.SetMapValue(pod.Labels, korifiv1alpha1.PodIndexLabelKey, index)
+		}); err != nil {
+			return fmt.Errorf("failed to label pod %q with index %s: %w", pod.Name, index, err)
+		}
+	}
+
+	return nil
+}
+
+func mergeMaps(existing, desired map[string]string) map[string]string {
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	maps.Copy(existing, desired)
+
+	return existing
+}
@@ -0,0 +1,228 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkload Reconcile", func() {
+	var (
+		reconciler      *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload]
+		reconcileResult ctrl.Result
+		reconcileErr    error
+		ctx             context.Context
+		req             ctrl.Request
+		appWorkload     *korifiv1alpha1.AppWorkload
+		pods            []corev1.Pod
+		createKsvcError error
+	)
+
+	BeforeEach(func() {
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       uuid.NewString(),
+				Namespace:  uuid.NewString(),
+				Generation: 2,
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:        "process-guid",
+				AppGUID:     "app-guid",
+				ProcessType: "web",
+				Instances:   2,
+				RunnerName:  "knative-runner",
+			},
+		}
+
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      appWorkload.Name,
+				Namespace: appWorkload.Namespace,
+			},
+		}
+
+		pods = []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "ready-pod",
+					Labels: map[string]string{korifiv1alpha1.PodIndexLabelKey: "0"},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-pod",
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		}
+		createKsvcError = nil
+
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.AppWorkload:
+				appWorkload.DeepCopyInto(obj)
+				return nil
+			case *unstructured.Unstructured:
+				return apierrors.NewNotFound(schema.GroupResource{
+					Group:    "serving.knative.dev",
+					Resource: "services",
+				}, obj.GetName())
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			switch obj.(type) {
+			case *unstructured.Unstructured:
+				return createKsvcError
+			default:
+				panic("TestClient Create provided an unexpected object type")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			podList, ok := list.(*corev1.PodList)
+			if !ok {
+				return nil
+			}
+
+			podList.Items = pods
+			return nil
+		}
+
+		reconciler = appworkload.NewAppWorkloadReconciler(
+			fakeClient,
+			scheme.Scheme,
+			appworkload.NewAppWorkloadToKnativeServiceConverter(),
+			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
+		)
+	})
+
+	JustBeforeEach(func() {
+		reconcileResult, reconcileErr = reconciler.Reconcile(ctx, req)
+	})
+
+	It("returns an empty result and does not return error", func() {
+		Expect(reconcileResult).To(Equal(ctrl.Result{}))
+		Expect(reconcileErr).NotTo(HaveOccurred())
+	})
+
+	It("creates a Knative Service owned by the appworkload", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(1))
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		ksvc, ok := obj.(*unstructured.Unstructured)
+		Expect(ok).To(BeTrue())
+		Expect(ksvc.GroupVersionKind()).To(Equal(appworkload.KnativeServiceGVK))
+		Expect(ksvc.GetName()).To(Equal("cf-" + appWorkload.Name))
+		Expect(ksvc.GetOwnerReferences()).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Kind":       Equal("AppWorkload"),
+			"Name":       Equal(appWorkload.Name),
+			"Controller": PointTo(BeTrue()),
+		})))
+	})
+
+	It("lists the pods of the appworkload", func() {
+		Expect(fakeClient.ListCallCount()).To(Equal(1))
+		_, _, listOptions := fakeClient.ListArgsForCall(0)
+		Expect(listOptions).To(ContainElements(
+			client.InNamespace(appWorkload.Namespace),
+			client.MatchingLabels{appworkload.LabelAppWorkloadGUID: appWorkload.Name},
+		))
+	})
+
+	It("labels new pods with the lowest free instance index", func() {
+		var patchedPods []*corev1.Pod
+		for i := range fakeClient.PatchCallCount() {
+			_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+			if pod, ok := obj.(*corev1.Pod); ok {
+				patchedPods = append(patchedPods, pod)
+			}
+		}
+
+		Expect(patchedPods).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+			"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+				"Name":   Equal("new-pod"),
+				"Labels": HaveKeyWithValue(korifiv1alpha1.PodIndexLabelKey, "1"),
+			}),
+		}))))
+	})
+
+	It("reports the instances state", func() {
+		Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+		_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+		patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+		Expect(ok).To(BeTrue())
+		Expect(patchedAppWorkload.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(patchedAppWorkload.Status.ActualInstances).To(Equal(int32(1)))
+		Expect(patchedAppWorkload.Status.InstancesStatus).To(MatchAllKeys(Keys{
+			"0": MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.InstanceStateRunning)}),
+			"1": MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.InstanceStateStarting)}),
+		}))
+	})
+
+	When("the process has been scaled to zero", func() {
+		BeforeEach(func() {
+			pods = nil
+		})
+
+		It("reports no instances", func() {
+			_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+			Expect(ok).To(BeTrue())
+			Expect(patchedAppWorkload.Status.ActualInstances).To(BeZero())
+			Expect(patchedAppWorkload.Status.InstancesStatus).To(BeEmpty())
+		})
+	})
+
+	When("creating the Knative Service fails", func() {
+		BeforeEach(func() {
+			createKsvcError = errors.New("big sad")
+		})
+
+		It("returns an error", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("big sad")))
+		})
+	})
+
+	When("the appworkload is being deleted", func() {
+		BeforeEach(func() {
+			appWorkload.DeletionTimestamp = tools.PtrTo(metav1.Now())
+			appWorkload.Finalizers = []string{"some-finalizer"}
+		})
+
+		It("does not create the Knative Service", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,211 @@
+package appworkload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	bindingRootPath = "/bindings"
+
+	// Knative appends the revision suffix (e.g. "-00001") and then the
+	// "-private" suffix to the service name when naming the Kubernetes
+	// services of a revision, which must still be valid DNS labels
+	maxServiceNameLength = 49
+)
+
+type AppWorkloadToKnativeServiceConverter struct{}
+
+func NewAppWorkloadToKnativeServiceConverter() *AppWorkloadToKnativeServiceConverter {
+	return &AppWorkloadToKnativeServiceConverter{}
+}
+
+func (c *AppWorkloadToKnativeServiceConverter) Convert(appWorkload *korifiv1alpha1.AppWorkload) (*unstructured.Unstructured, error) {
+	container := corev1.Container{
+		Name:            ApplicationContainerName,
+		Image:           appWorkload.Spec.Image,
+		ImagePullPolicy: corev1.PullAlways,
+		Command:         appWorkload.Spec.Command,
+		Env:             envs(appWorkload),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: tools.PtrTo(false),
+			RunAsNonRoot:             tools.PtrTo(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		Resources:      appWorkload.Spec.Resources,
+		StartupProbe:   appWorkload.Spec.StartupProbe,
+		LivenessProbe:  appWorkload.Spec.LivenessProbe,
+		ReadinessProbe: appWorkload.Spec.ReadinessProbe,
+		VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+			return corev1.VolumeMount{
+				Name:      s.Name,
+				ReadOnly:  true,
+				MountPath: filepath.Join(bindingRootPath, s.Name),
+			}
+		})),
+	}
+
+	// Knative only supports a single port per container and routes all
+	// requests to it
+	if port, ok := containerPort(appWorkload); ok {
+		container.Ports = []corev1.ContainerPort{{ContainerPort: port}}
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers:                   []corev1.Container{container},
+		ImagePullSecrets:             appWorkload.Spec.ImagePullSecrets,
+		NodeSelector:                 appWorkload.Spec.Placement.NodeSelector,
+		Tolerations:                  appWorkload.Spec.Placement.Tolerations,
+		ServiceAccountName:           ServiceAccountName,
+		AutomountServiceAccountToken: tools.PtrTo(false),
+		Volumes: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
+			return corev1.Volume{
+				Name: s.Name,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  s.Secret,
+						DefaultMode: tools.PtrTo[int32](0o644),
+					},
+				},
+			}
+		})),
+	}
+
+	unstructuredPodSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&podSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pod spec: %w", err)
+	}
+
+	labels := map[string]string{
+		controllers.LabelGUID: appWorkload.Spec.GUID,
+		LabelProcessType:      appWorkload.Spec.ProcessType,
+		LabelVersion:          appWorkload.Spec.Version,
+		LabelAppGUID:          appWorkload.Spec.AppGUID,
+		LabelAppWorkloadGUID:  appWorkload.Name,
+	}
+
+	annotations := map[string]string{
+		AnnotationAppID:       appWorkload.Spec.AppGUID,
+		AnnotationVersion:     appWorkload.Spec.Version,
+		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
+	}
+
+	// The desired instances are the upper bound of the Knative autoscaler,
+	// which scales idle processes down to zero
+	templateAnnotations := maps.Clone(annotations)
+	templateAnnotations[AnnotationMinScale] = strconv.Itoa(int(minScale(appWorkload)))
+	templateAnnotations[AnnotationMaxScale] = strconv.Itoa(int(appWorkload.Spec.Instances))
+
+	ksvc := new(unstructured.Unstructured)
+	ksvc.SetGroupVersionKind(KnativeServiceGVK)
+	ksvc.SetNamespace(appWorkload.Namespace)
+	ksvc.SetName(knativeServiceName(appWorkload))
+	ksvc.SetLabels(labels)
+	ksvc.SetAnnotations(annotations)
+	ksvc.Object["spec"] = map[string]any{
+		"template": map[string]any{
+			"metadata": map[string]any{
+				"labels":      toUnstructuredMap(labels),
+				"annotations": toUnstructuredMap(templateAnnotations),
+			},
+			"spec": unstructuredPodSpec,
+		},
+	}
+
+	return ksvc, nil
+}
+
+// minScale keeps the desired instances of processes that Knative cannot scale
+// up again once they are idle. CF routes send requests straight to the app
+// pods, bypassing the Knative activator and autoscaler, and processes without
+// a port never receive requests.
+func minScale(appWorkload *korifiv1alpha1.AppWorkload) int32 {
+	_, hasPort := containerPort(appWorkload)
+	if len(appWorkload.Spec.Ports) > 0 || !hasPort {
+		return appWorkload.Spec.Instances
+	}
+
+	return 0
+}
+
+// envs drops the PORT environment variable, as Knative reserves it and sets
+// it to the container port
+func envs(appWorkload *korifiv1alpha1.AppWorkload) []corev1.EnvVar {
+	envs := slices.DeleteFunc(slices.Clone(appWorkload.Spec.Env), func(env corev1.EnvVar) bool {
+		return env.Name == EnvPort
+	})
+
+	if len(appWorkload.Spec.Services) != 0 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  EnvServiceBindingRoot,
+			Value: bindingRootPath,
+		})
+	}
+	// Sort env vars to guarantee idempotency
+	sort.SliceStable(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+
+	return envs
+}
+
+func containerPort(appWorkload *korifiv1alpha1.AppWorkload) (int32, bool) {
+	if len(appWorkload.Spec.Ports) > 0 {
+		return appWorkload.Spec.Ports[0], true
+	}
+
+	for _, env := range appWorkload.Spec.Env {
+		if env.Name != EnvPort {
+			continue
+		}
+
+		port, err := strconv.ParseInt(env.Value, 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(port), true
+	}
+
+	return 0, false
+}
+
+// knativeServiceName prefixes the AppWorkload name, as Knative Service names
+// must start with a letter, falling back to a hash of the name when it is too
+// long
+func knativeServiceName(appWorkload *korifiv1alpha1.AppWorkload) string {
+	name := "cf-" + appWorkload.Name
+	if len(name) <= maxServiceNameLength {
+		return name
+	}
+
+	sha := sha256.Sum256([]byte(appWorkload.Name))
+	return "cf-" + hex.EncodeToString(sha[:])[:maxServiceNameLength-3]
+}
+
+func toUnstructuredMap(m map[string]string) map[string]any {
+	result := map[string]any{}
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
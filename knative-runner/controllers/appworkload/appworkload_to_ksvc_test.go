@@ -0,0 +1,239 @@
+package appworkload_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers"
+	"code.cloudfoundry.org/korifi/knative-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("AppWorkload to Knative Service Converter", func() {
+	var (
+		ksvc        *unstructured.Unstructured
+		podSpec     corev1.PodSpec
+		appWorkload *korifiv1alpha1.AppWorkload
+		converter   *appworkload.AppWorkloadToKnativeServiceConverter
+	)
+
+	BeforeEach(func() {
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "guid-1234",
+				Namespace: "some-namespace",
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				AppGUID:          "app-guid-1234",
+				GUID:             "process-guid-1234",
+				Version:          "version-1234",
+				Image:            "gcr.io/foo/bar",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "some-secret-name"}},
+				Command:          []string{"/bin/sh", "-c", "serve"},
+				ProcessType:      "web",
+				Env: []corev1.EnvVar{
+					{Name: "VCAP_APP_PORT", Value: "8080"},
+					{Name: "PORT", Value: "8080"},
+					{Name: "BAR", Value: "bar"},
+				},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/healthz",
+							Port: intstr.FromInt32(8888),
+						},
+					},
+				},
+				Ports:      []int32{8888, 9999},
+				Instances:  3,
+				RunnerName: "knative-runner",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1024Mi"),
+					},
+				},
+			},
+		}
+
+		converter = appworkload.NewAppWorkloadToKnativeServiceConverter()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ksvc, err = converter.Convert(appWorkload)
+		Expect(err).NotTo(HaveOccurred())
+
+		unstructuredPodSpec, found, err := unstructured.NestedMap(ksvc.Object, "spec", "template", "spec")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		podSpec = corev1.PodSpec{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredPodSpec, &podSpec)).To(Succeed())
+	})
+
+	It("creates a Knative Service named after the appworkload", func() {
+		Expect(ksvc.GroupVersionKind()).To(Equal(appworkload.KnativeServiceGVK))
+		Expect(ksvc.GetNamespace()).To(Equal("some-namespace"))
+		Expect(ksvc.GetName()).To(Equal("cf-guid-1234"))
+	})
+
+	When("the appworkload name is too long", func() {
+		BeforeEach(func() {
+			appWorkload.Name = "a-very-long-appworkload-name-that-does-not-fit-into-a-knative-service-name"
+		})
+
+		It("uses a hash of the name", func() {
+			Expect(ksvc.GetName()).To(HavePrefix("cf-"))
+			Expect(len(ksvc.GetName())).To(BeNumerically("<=", 49))
+		})
+	})
+
+	It("labels the service and its pods", func() {
+		expectedLabels := map[string]string{
+			controllers.LabelGUID:            "process-guid-1234",
+			appworkload.LabelProcessType:     "web",
+			appworkload.LabelVersion:         "version-1234",
+			appworkload.LabelAppGUID:         "app-guid-1234",
+			appworkload.LabelAppWorkloadGUID: "guid-1234",
+		}
+		Expect(ksvc.GetLabels()).To(Equal(expectedLabels))
+
+		templateLabels, _, err := unstructured.NestedStringMap(ksvc.Object, "spec", "template", "metadata", "labels")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templateLabels).To(Equal(expectedLabels))
+	})
+
+	It("keeps the desired instances of the routed process", func() {
+		templateAnnotations, _, err := unstructured.NestedStringMap(ksvc.Object, "spec", "template", "metadata", "annotations")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templateAnnotations).To(MatchAllKeys(Keys{
+			appworkload.AnnotationMinScale:    Equal("3"),
+			appworkload.AnnotationMaxScale:    Equal("3"),
+			appworkload.AnnotationProcessGUID: Equal("process-guid-1234-version-1234"),
+			appworkload.AnnotationAppID:       Equal("app-guid-1234"),
+			appworkload.AnnotationVersion:     Equal("version-1234"),
+		}))
+
+		Expect(ksvc.GetAnnotations()).NotTo(HaveKey(appworkload.AnnotationMinScale))
+	})
+
+	When("the process is not routed", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Ports = nil
+		})
+
+		It("scales the process between zero and the desired instances", func() {
+			templateAnnotations, _, err := unstructured.NestedStringMap(ksvc.Object, "spec", "template", "metadata", "annotations")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(templateAnnotations).To(HaveKeyWithValue(appworkload.AnnotationMinScale, "0"))
+			Expect(templateAnnotations).To(HaveKeyWithValue(appworkload.AnnotationMaxScale, "3"))
+		})
+
+		When("the process has no port", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Env = []corev1.EnvVar{{Name: "BAR", Value: "bar"}}
+			})
+
+			It("keeps the desired instances", func() {
+				templateAnnotations, _, err := unstructured.NestedStringMap(ksvc.Object, "spec", "template", "metadata", "annotations")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(templateAnnotations).To(HaveKeyWithValue(appworkload.AnnotationMinScale, "3"))
+			})
+		})
+	})
+
+	It("runs the application container", func() {
+		Expect(podSpec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Name":          Equal(appworkload.ApplicationContainerName),
+			"Image":         Equal("gcr.io/foo/bar"),
+			"Command":       Equal([]string{"/bin/sh", "-c", "serve"}),
+			"LivenessProbe": Equal(appWorkload.Spec.LivenessProbe),
+			"SecurityContext": PointTo(MatchFields(IgnoreExtras, Fields{
+				"AllowPrivilegeEscalation": PointTo(BeFalse()),
+				"RunAsNonRoot":             PointTo(BeTrue()),
+			})),
+		})))
+		Expect(podSpec.Containers[0].Resources.Limits.Memory().Equal(resource.MustParse("1024Mi"))).To(BeTrue())
+		Expect(podSpec.ImagePullSecrets).To(Equal(appWorkload.Spec.ImagePullSecrets))
+		Expect(podSpec.ServiceAccountName).To(Equal(appworkload.ServiceAccountName))
+		Expect(podSpec.AutomountServiceAccountToken).To(PointTo(BeFalse()))
+	})
+
+	It("exposes the first appworkload port only", func() {
+		Expect(podSpec.Containers[0].Ports).To(ConsistOf(corev1.ContainerPort{ContainerPort: 8888}))
+	})
+
+	When("the appworkload has no ports", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Ports = nil
+		})
+
+		It("exposes the port from the PORT env var", func() {
+			Expect(podSpec.Containers[0].Ports).To(ConsistOf(corev1.ContainerPort{ContainerPort: 8080}))
+		})
+	})
+
+	It("drops the PORT env var reserved by Knative and sorts the env", func() {
+		Expect(podSpec.Containers[0].Env).To(Equal([]corev1.EnvVar{
+			{Name: "BAR", Value: "bar"},
+			{Name: "VCAP_APP_PORT", Value: "8080"},
+		}))
+	})
+
+	When("the appworkload has service bindings", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				Name:   "my-binding",
+				Secret: "my-binding-secret",
+			}}
+		})
+
+		It("projects the binding secrets onto the container", func() {
+			Expect(podSpec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name:  appworkload.EnvServiceBindingRoot,
+				Value: "/bindings",
+			}))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name:      "my-binding",
+				ReadOnly:  true,
+				MountPath: "/bindings/my-binding",
+			}))
+			Expect(podSpec.Volumes).To(ConsistOf(corev1.Volume{
+				Name: "my-binding",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  "my-binding-secret",
+						DefaultMode: tools.PtrTo[int32](0o644),
+					},
+				},
+			}))
+		})
+	})
+
+	When("the appworkload has a placement", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Placement = korifiv1alpha1.Placement{
+				NodeSelector: map[string]string{"segment": "my-segment"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "segment",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "my-segment",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			}
+		})
+
+		It("schedules the pods accordingly", func() {
+			Expect(podSpec.NodeSelector).To(Equal(appWorkload.Spec.Placement.NodeSelector))
+			Expect(podSpec.Tolerations).To(Equal(appWorkload.Spec.Placement.Tolerations))
+		})
+	})
+})
@@ -0,0 +1,6 @@
+package controllers
+
+const (
+	LabelGUID                 = "korifi.cloudfoundry.org/guid"
+	AppWorkloadReconcilerName = "knative-runner"
+)
@@ -26,6 +26,7 @@ import (
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -73,12 +74,16 @@ func (r *BuilderInfoReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Buil
 			new(buildv1alpha2.ClusterBuilder),
 			handler.EnqueueRequestsFromMapFunc(r.enqueueBuilderInfoRequests),
 		).
+		Watches(
+			new(korifiv1alpha1.CFStack),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueBuilderInfoRequests),
+		).
 		WithEventFilter(predicate.NewPredicateFuncs(r.filterBuilderInfos))
 }
 
 func (r *BuilderInfoReconciler) enqueueBuilderInfoRequests(ctx context.Context, o client.Object) []reconcile.Request {
 	var requests []reconcile.Request
-	if o.GetName() == r.clusterBuilderName {
+	if r.isStackResource(o) || o.GetName() == r.clusterBuilderName {
 		requests = append(requests, reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Name:      BuilderInfoName,
@@ -89,6 +94,15 @@ func (r *BuilderInfoReconciler) enqueueBuilderInfoRequests(ctx context.Context,
 	return requests
 }
 
+func (r *BuilderInfoReconciler) isStackResource(o client.Object) bool {
+	if _, ok := o.(*korifiv1alpha1.CFStack); ok {
+		return o.GetNamespace() == r.rootNamespaceName
+	}
+
+	_, hasStackLabel := o.GetLabels()[korifiv1alpha1.CFStackGUIDLabelKey]
+	return hasStackLabel
+}
+
 func (r *BuilderInfoReconciler) filterBuilderInfos(object client.Object) bool {
 	builderInfo, ok := object.(*korifiv1alpha1.BuilderInfo)
 	if !ok {
@@ -104,6 +118,8 @@ func (r *BuilderInfoReconciler) filterBuilderInfos(object client.Object) bool {
 //+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders/status,verbs=get
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch
+
 func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *korifiv1alpha1.BuilderInfo) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -131,6 +147,12 @@ func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *kor
 	info.Status.Stacks = clusterBuilderToStacks(clusterBuilder, updatedTimestamp)
 	info.Status.Buildpacks = clusterBuilderToBuildpacks(clusterBuilder, updatedTimestamp)
 
+	cfStackStacks, err := r.cfStacksToStacks(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	info.Status.Stacks = append(info.Status.Stacks, cfStackStacks...)
+
 	clusterBuilderReadyCondition := clusterBuilder.Status.GetCondition(corev1alpha1.ConditionReady)
 	if clusterBuilderReadyCondition == nil || clusterBuilderReadyCondition.Status != corev1.ConditionTrue {
 		var msg string
@@ -161,6 +183,44 @@ func clusterBuilderToStacks(clusterBuilder *buildv1alpha2.ClusterBuilder, update
 	}
 }
 
+// cfStacksToStacks reports the stacks of the CFStacks whose ClusterBuilder
+// has resolved its stack
+func (r *BuilderInfoReconciler) cfStacksToStacks(ctx context.Context) ([]korifiv1alpha1.BuilderInfoStatusStack, error) {
+	cfStacks := new(korifiv1alpha1.CFStackList)
+	if err := r.k8sClient.List(ctx, cfStacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list CFStacks: %w", err)
+	}
+
+	stacks := []korifiv1alpha1.BuilderInfoStatusStack{}
+	for _, cfStack := range cfStacks.Items {
+		if cfStack.Status.ClusterBuilderName == "" {
+			continue
+		}
+
+		clusterBuilder := new(buildv1alpha2.ClusterBuilder)
+		err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfStack.Status.ClusterBuilderName}, clusterBuilder)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get ClusterBuilder %q: %w", cfStack.Status.ClusterBuilderName, err)
+		}
+
+		if clusterBuilder.Status.Stack.ID == "" {
+			continue
+		}
+
+		stacks = append(stacks, korifiv1alpha1.BuilderInfoStatusStack{
+			Name:              cfStack.Spec.DisplayName,
+			Description:       cfStack.Spec.Description,
+			CreationTimestamp: cfStack.CreationTimestamp,
+			UpdatedTimestamp:  lastUpdatedTime(clusterBuilder.ObjectMeta),
+		})
+	}
+
+	return stacks, nil
+}
+
 func clusterBuilderToBuildpacks(builder *buildv1alpha2.ClusterBuilder, updatedTimestamp metav1.Time) []korifiv1alpha1.BuilderInfoStatusBuildpack {
 	buildpackRecords := make([]korifiv1alpha1.BuilderInfoStatusBuildpack, 0, len(builder.Status.Order))
 	for _, orderEntry := range builder.Status.Order {
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -284,6 +285,52 @@ var _ = Describe("BuilderInfoReconciler", func() {
 		})
 	})
 
+	When("there is a CFStack", func() {
+		var cfStack *v1alpha1.CFStack
+
+		BeforeEach(func() {
+			cfStack = &v1alpha1.CFStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace.Name,
+				},
+				Spec: v1alpha1.CFStackSpec{
+					DisplayName: "cflinuxfs4",
+					Description: "Ubuntu Jammy",
+					ID:          "io.buildpacks.stacks.jammy",
+					BuildImage:  "my.repository/build:jammy",
+					RunImage:    "my.repository/run:jammy",
+				},
+			}
+			Expect(adminClient.Create(ctx, cfStack)).To(Succeed())
+
+			stackClusterBuilder := &buildv1alpha2.ClusterBuilder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cf-stack-" + cfStack.Name,
+				},
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+			}).Should(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, stackClusterBuilder, func() {
+				stackClusterBuilder.Status.Stack = corev1alpha1.BuildStack{ID: "io.buildpacks.stacks.jammy"}
+			})).To(Succeed())
+		})
+
+		It("reports the stack of the CFStack along with the default one", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				g.Expect(info.Status.Stacks).To(ConsistOf(
+					HaveField("Name", stack),
+					MatchFields(IgnoreExtras, Fields{
+						"Name":        Equal("cflinuxfs4"),
+						"Description": Equal("Ubuntu Jammy"),
+					}),
+				))
+			}).Should(Succeed())
+		})
+	})
+
 	When("a BuilderInfo with the wrong name exists", func() {
 		BeforeEach(func() {
 			info.Name = "some-other-build-reconciler"
@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func NewBuildpackReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	clusterBuilderName string,
+	rootNamespaceName string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFBuildpack] {
+	buildpackReconciler := BuildpackReconciler{
+		k8sClient:          c,
+		scheme:             scheme,
+		log:                log,
+		clusterBuilderName: clusterBuilderName,
+		rootNamespaceName:  rootNamespaceName,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuildpack](log, c, &buildpackReconciler)
+}
+
+// BuildpackReconciler adds the images of CFBuildpacks to the ClusterStore of
+// the configured ClusterBuilder and places the buildpacks the store resolves
+// at their position in the ClusterBuilder order
+type BuildpackReconciler struct {
+	k8sClient          client.Client
+	scheme             *runtime.Scheme
+	log                logr.Logger
+	clusterBuilderName string
+	rootNamespaceName  string
+}
+
+func (r *BuildpackReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFBuildpack{}).
+		Watches(
+			new(buildv1alpha2.ClusterBuilder),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueBuildpackRequests),
+		).
+		Watches(
+			new(buildv1alpha2.ClusterStore),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueBuildpackRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterBuildpacks))
+}
+
+func (r *BuildpackReconciler) enqueueBuildpackRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfBuildpacks := new(korifiv1alpha1.CFBuildpackList)
+	if err := r.k8sClient.List(ctx, cfBuildpacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		r.log.Info("failed to list CFBuildpacks", "reason", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cfBuildpack := range cfBuildpacks.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      cfBuildpack.Name,
+				Namespace: cfBuildpack.Namespace,
+			},
+		})
+	}
+	return requests
+}
+
+func (r *BuildpackReconciler) filterBuildpacks(object client.Object) bool {
+	cfBuildpack, ok := object.(*korifiv1alpha1.CFBuildpack)
+	if !ok {
+		return true
+	}
+
+	return cfBuildpack.Namespace == r.rootNamespaceName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildpacks,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildpacks/status,verbs=get;patch
+
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterstores,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch;patch
+
+func (r *BuildpackReconciler) ReconcileResource(ctx context.Context, cfBuildpack *korifiv1alpha1.CFBuildpack) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfBuildpack.Status.ObservedGeneration = cfBuildpack.Generation
+	log.V(1).Info("set observed generation", "generation", cfBuildpack.Status.ObservedGeneration)
+
+	clusterBuilder := new(buildv1alpha2.ClusterBuilder)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: r.clusterBuilderName}, clusterBuilder)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("ClusterBuilderMissing").
+			WithMessage(fmt.Sprintf("Error fetching ClusterBuilder %q: %s", r.clusterBuilderName, err))
+	}
+
+	clusterStore := new(buildv1alpha2.ClusterStore)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: clusterBuilder.Spec.Store.Name}, clusterStore)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("ClusterStoreMissing").
+			WithMessage(fmt.Sprintf("Error fetching ClusterStore %q: %s", clusterBuilder.Spec.Store.Name, err))
+	}
+
+	if err = r.updateStoreSources(ctx, clusterStore, cfBuildpack); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cfBuildpack.Status.BuildpackID, cfBuildpack.Status.Version = resolveBuildpack(clusterStore, cfBuildpack.Spec.Image)
+
+	if err = r.updateBuilderOrder(ctx, clusterBuilder, cfBuildpack); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cfBuildpack.GetDeletionTimestamp().IsZero() {
+		if controllerutil.RemoveFinalizer(cfBuildpack, korifiv1alpha1.CFBuildpackFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if cfBuildpack.Spec.Image == "" {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("AwaitingUpload").
+			WithMessage("No buildpack bits have been uploaded").
+			WithNoRequeue()
+	}
+
+	if cfBuildpack.Status.BuildpackID == "" {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("BuildpackNotResolved").
+			WithMessage(fmt.Sprintf("ClusterStore %q has not resolved image %q yet", clusterStore.Name, cfBuildpack.Spec.Image))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// updateStoreSources adds the current buildpack image to the store and removes
+// the image that was added previously, if any
+func (r *BuildpackReconciler) updateStoreSources(ctx context.Context, clusterStore *buildv1alpha2.ClusterStore, cfBuildpack *korifiv1alpha1.CFBuildpack) error {
+	desiredImage := cfBuildpack.Spec.Image
+	if !cfBuildpack.GetDeletionTimestamp().IsZero() {
+		desiredImage = ""
+	}
+
+	err := k8s.PatchResource(ctx, r.k8sClient, clusterStore, func() {
+		if cfBuildpack.Status.StoreImage != "" && cfBuildpack.Status.StoreImage != desiredImage {
+			clusterStore.Spec.Sources = slices.DeleteFunc(clusterStore.Spec.Sources, func(source corev1alpha1.ImageSource) bool {
+				return source.Image == cfBuildpack.Status.StoreImage
+			})
+		}
+
+		if desiredImage != "" && !slices.ContainsFunc(clusterStore.Spec.Sources, func(source corev1alpha1.ImageSource) bool {
+			return source.Image == desiredImage
+		}) {
+			clusterStore.Spec.Sources = append(clusterStore.Spec.Sources, corev1alpha1.ImageSource{Image: desiredImage})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the sources of ClusterStore %q: %w", clusterStore.Name, err)
+	}
+
+	cfBuildpack.Status.StoreImage = desiredImage
+	return nil
+}
+
+// managedOrderAnnotation records the builder order entries added by this
+// controller, so that entries of the stock order are left alone even when
+// they refer to the same buildpack id
+const managedOrderAnnotation = "korifi.cloudfoundry.org/managed-buildpack-order"
+
+// updateBuilderOrder replaces the buildpacks managed by CFBuildpacks in the
+// builder order with the enabled ones, placed at their desired position
+func (r *BuildpackReconciler) updateBuilderOrder(ctx context.Context, clusterBuilder *buildv1alpha2.ClusterBuilder, cfBuildpack *korifiv1alpha1.CFBuildpack) error {
+	cfBuildpacks := new(korifiv1alpha1.CFBuildpackList)
+	if err := r.k8sClient.List(ctx, cfBuildpacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		return fmt.Errorf("failed to list CFBuildpacks: %w", err)
+	}
+
+	buildpacks := slices.DeleteFunc(cfBuildpacks.Items, func(b korifiv1alpha1.CFBuildpack) bool {
+		return b.Name == cfBuildpack.Name
+	})
+	buildpacks = append(buildpacks, *cfBuildpack)
+
+	orderedBuildpacks := slices.DeleteFunc(buildpacks, func(b korifiv1alpha1.CFBuildpack) bool {
+		return b.Status.BuildpackID == "" || !b.Spec.Enabled || !b.GetDeletionTimestamp().IsZero()
+	})
+	slices.SortStableFunc(orderedBuildpacks, func(b1, b2 korifiv1alpha1.CFBuildpack) int {
+		return b1.Spec.Position - b2.Spec.Position
+	})
+
+	var managedOrder []corev1alpha1.BuildpackInfo
+	if value, ok := clusterBuilder.Annotations[managedOrderAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &managedOrder); err != nil {
+			return fmt.Errorf("failed to parse the %q annotation of ClusterBuilder %q: %w", managedOrderAnnotation, clusterBuilder.Name, err)
+		}
+	}
+
+	err := k8s.PatchResource(ctx, r.k8sClient, clusterBuilder, func() {
+		// remove the entries added previously, once each
+		order := slices.DeleteFunc(clusterBuilder.Spec.Order, func(entry buildv1alpha2.BuilderOrderEntry) bool {
+			if len(entry.Group) != 1 {
+				return false
+			}
+
+			i := slices.Index(managedOrder, entry.Group[0].BuildpackInfo)
+			if i < 0 {
+				return false
+			}
+			managedOrder = slices.Delete(managedOrder, i, i+1)
+			return true
+		})
+
+		// pin the resolved version, so that the uploaded buildpack is used
+		// even if the store has higher versions of the same id
+		managedOrder = []corev1alpha1.BuildpackInfo{}
+		for _, b := range orderedBuildpacks {
+			buildpackInfo := corev1alpha1.BuildpackInfo{Id: b.Status.BuildpackID, Version: b.Status.Version}
+			order = slices.Insert(order, min(b.Spec.Position-1, len(order)), buildv1alpha2.BuilderOrderEntry{
+				Group: []buildv1alpha2.BuilderBuildpackRef{{
+					BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: buildpackInfo},
+				}},
+			})
+			managedOrder = append(managedOrder, buildpackInfo)
+		}
+
+		clusterBuilder.Spec.Order = order
+
+		managedOrderJSON, _ := json.Marshal(managedOrder)
+		clusterBuilder.Annotations = tools.SetMapValue(clusterBuilder.Annotations, managedOrderAnnotation, string(managedOrderJSON))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the order of ClusterBuilder %q: %w", clusterBuilder.Name, err)
+	}
+
+	return nil
+}
+
+// resolveBuildpack returns the id and version of the top-level buildpack of
+// the buildpackage image, as resolved by the store
+func resolveBuildpack(clusterStore *buildv1alpha2.ClusterStore, image string) (string, string) {
+	if image == "" {
+		return "", ""
+	}
+
+	for _, b := range clusterStore.Status.Buildpacks {
+		if b.StoreImage.Image == image && b.Buildpackage.Id == b.Id {
+			return b.Buildpackage.Id, b.Buildpackage.Version
+		}
+	}
+
+	return "", ""
+}
@@ -0,0 +1,249 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuildpackReconciler", func() {
+	var (
+		clusterStore   *buildv1alpha2.ClusterStore
+		clusterBuilder *buildv1alpha2.ClusterBuilder
+		cfBuildpack    *korifiv1alpha1.CFBuildpack
+	)
+
+	orderIDs := func() []string {
+		ids := []string{}
+		for _, entry := range clusterBuilder.Spec.Order {
+			ids = append(ids, entry.Group[0].Id)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		clusterStore = &buildv1alpha2.ClusterStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+			Spec: buildv1alpha2.ClusterStoreSpec{
+				Sources: []corev1alpha1.ImageSource{{Image: "paketobuildpacks/java"}},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterStore)).To(Succeed())
+
+		clusterBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterBuilderName,
+			},
+			Spec: buildv1alpha2.ClusterBuilderSpec{
+				BuilderSpec: buildv1alpha2.BuilderSpec{
+					Tag: "my.repository/my-builder",
+					Store: v1.ObjectReference{
+						Name: clusterStore.Name,
+						Kind: "ClusterStore",
+					},
+					Order: []buildv1alpha2.BuilderOrderEntry{
+						{Group: []buildv1alpha2.BuilderBuildpackRef{{
+							BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo-buildpacks/java"}},
+						}}},
+						{Group: []buildv1alpha2.BuilderBuildpackRef{{
+							BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo-buildpacks/go"}},
+						}}},
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterBuilder)).To(Succeed())
+
+		cfBuildpack = &korifiv1alpha1.CFBuildpack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace.Name,
+			},
+			Spec: korifiv1alpha1.CFBuildpackSpec{
+				DisplayName: "my-buildpack",
+				Position:    1,
+				Enabled:     true,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfBuildpack)).To(Succeed())
+	})
+
+	It("is not ready while awaiting upload", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+			g.Expect(cfBuildpack.Status.ObservedGeneration).To(Equal(cfBuildpack.Generation))
+			readyCondition := meta.FindStatusCondition(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+			g.Expect(readyCondition).NotTo(BeNil())
+			g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(readyCondition.Reason).To(Equal("AwaitingUpload"))
+		}).Should(Succeed())
+	})
+
+	When("the buildpack has an image", func() {
+		BeforeEach(func() {
+			cfBuildpack.Spec.Image = "my.repository/my-buildpack@sha256:abc"
+		})
+
+		It("adds the image to the cluster store", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+				g.Expect(clusterStore.Spec.Sources).To(ConsistOf(
+					corev1alpha1.ImageSource{Image: "paketobuildpacks/java"},
+					corev1alpha1.ImageSource{Image: "my.repository/my-buildpack@sha256:abc"},
+				))
+			}).Should(Succeed())
+		})
+
+		It("is not ready until the store resolves the buildpack", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				g.Expect(cfBuildpack.Status.StoreImage).To(Equal("my.repository/my-buildpack@sha256:abc"))
+				readyCondition := meta.FindStatusCondition(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Reason).To(Equal("BuildpackNotResolved"))
+			}).Should(Succeed())
+		})
+
+		When("the store resolves the buildpack", func() {
+			var resolvedID string
+
+			BeforeEach(func() {
+				resolvedID = "my-org/my-buildpack"
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, clusterStore, func() {
+					clusterStore.Status.Buildpacks = []corev1alpha1.BuildpackStatus{{
+						BuildpackInfo: corev1alpha1.BuildpackInfo{Id: resolvedID, Version: "1.2.3"},
+						Buildpackage:  corev1alpha1.BuildpackageInfo{Id: resolvedID, Version: "1.2.3"},
+						StoreImage:    corev1alpha1.ImageSource{Image: "my.repository/my-buildpack@sha256:abc"},
+					}}
+				})).To(Succeed())
+			})
+
+			It("becomes ready with the resolved id and version", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+					g.Expect(cfBuildpack.Status.BuildpackID).To(Equal("my-org/my-buildpack"))
+					g.Expect(cfBuildpack.Status.Version).To(Equal("1.2.3"))
+					g.Expect(meta.IsStatusConditionTrue(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("inserts the buildpack in the builder order at its position", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+					g.Expect(orderIDs()).To(Equal([]string{"my-org/my-buildpack", "paketo-buildpacks/java", "paketo-buildpacks/go"}))
+					g.Expect(clusterBuilder.Spec.Order[0].Group[0].Version).To(Equal("1.2.3"))
+				}).Should(Succeed())
+			})
+
+			When("the buildpack has the id of a stock buildpack", func() {
+				BeforeEach(func() {
+					resolvedID = "paketo-buildpacks/java"
+				})
+
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/java", "paketo-buildpacks/go"}))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfBuildpack, func() {
+						cfBuildpack.Spec.Enabled = false
+					})).To(Succeed())
+				})
+
+				It("keeps the stock buildpack in the builder order", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/go"}))
+						g.Expect(clusterBuilder.Spec.Order[0].Group[0].Version).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the buildpack is moved to another position", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+						g.Expect(cfBuildpack.Status.BuildpackID).NotTo(BeEmpty())
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfBuildpack, func() {
+						cfBuildpack.Spec.Position = 10
+					})).To(Succeed())
+				})
+
+				It("moves it in the builder order", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/go", "my-org/my-buildpack"}))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the buildpack is disabled", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+						g.Expect(cfBuildpack.Status.BuildpackID).NotTo(BeEmpty())
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfBuildpack, func() {
+						cfBuildpack.Spec.Enabled = false
+					})).To(Succeed())
+				})
+
+				It("removes it from the builder order", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/go"}))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the buildpack is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(ContainElement("my-org/my-buildpack"))
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, cfBuildpack)).To(Succeed())
+				})
+
+				It("removes it from the store and the builder order", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+						g.Expect(clusterStore.Spec.Sources).To(ConsistOf(corev1alpha1.ImageSource{Image: "paketobuildpacks/java"}))
+
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+						g.Expect(orderIDs()).To(Equal([]string{"paketo-buildpacks/java", "paketo-buildpacks/go"}))
+					}).Should(Succeed())
+				})
+
+				It("deletes the buildpack", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)
+						g.Expect(err).To(MatchError(ContainSubstring("not found")))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+})
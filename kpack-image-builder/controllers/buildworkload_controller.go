@@ -56,10 +56,10 @@ import (
 const (
 	clusterBuilderKind          = "ClusterBuilder"
 	clusterBuilderAPIVersion    = "kpack.io/v1alpha2"
-	BuildWorkloadLabelKey       = "korifi.cloudfoundry.org/build-workload-name"
 	ImageGenerationKey          = "korifi.cloudfoundry.org/kpack-image-generation"
 	KpackReconcilerName         = "kpack-image-builder"
 	buildpackBuildMetadataLabel = "io.buildpacks.build.metadata"
+	sourceBlobURLExpiry         = 24 * time.Hour
 )
 
 //counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
@@ -74,6 +74,12 @@ type RepositoryCreator interface {
 	CreateRepository(ctx context.Context, name string) error
 }
 
+//counterfeiter:generate -o fake -fake-name BlobURLSigner . BlobURLSigner
+
+type BlobURLSigner interface {
+	SignURL(blobURL string, expiry time.Duration) (string, error)
+}
+
 func NewBuildWorkloadReconciler(
 	c client.Client,
 	scheme *runtime.Scheme,
@@ -81,6 +87,7 @@ func NewBuildWorkloadReconciler(
 	config *config.Config,
 	imageConfigGetter ImageConfigGetter,
 	imageRepoCreator RepositoryCreator,
+	blobURLSigner BlobURLSigner,
 ) *k8s.PatchingReconciler[korifiv1alpha1.BuildWorkload] {
 	buildWorkloadReconciler := BuildWorkloadReconciler{
 		k8sClient:         c,
@@ -89,6 +96,7 @@ func NewBuildWorkloadReconciler(
 		controllerConfig:  config,
 		imageConfigGetter: imageConfigGetter,
 		imageRepoCreator:  imageRepoCreator,
+		blobURLSigner:     blobURLSigner,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.BuildWorkload](log, c, &buildWorkloadReconciler)
 }
@@ -101,6 +109,7 @@ type BuildWorkloadReconciler struct {
 	controllerConfig  *config.Config
 	imageConfigGetter ImageConfigGetter
 	imageRepoCreator  RepositoryCreator
+	blobURLSigner     BlobURLSigner
 }
 
 func (r *BuildWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -159,6 +168,8 @@ func filterBuildWorkloads(object client.Object) bool {
 //+kubebuilder:rbac:groups=kpack.io,resources=builds,verbs=deletecollection
 //+kubebuilder:rbac:groups=kpack.io,resources=builders,verbs=get;list;watch;create;patch;update
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=list;watch
 
 //+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch;patch
@@ -368,10 +379,64 @@ func (r *BuildWorkloadReconciler) getBuilderReadyCondition(ctx context.Context,
 	return condition, nil
 }
 
-func (r *BuildWorkloadReconciler) getDefaultClusterBuilder(ctx context.Context) (*buildv1alpha2.ClusterBuilder, error) {
-	var defaultBuilder buildv1alpha2.ClusterBuilder
-	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: r.controllerConfig.ClusterBuilderName}, &defaultBuilder)
-	return &defaultBuilder, err
+// getClusterBuilderName returns the ClusterBuilder of the CFStack the
+// BuildWorkload asks for. The default ClusterBuilder only builds the default
+// stack and its own stack, other stacks without a CFStack fail the
+// BuildWorkload.
+func (r *BuildWorkloadReconciler) getClusterBuilderName(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+	stack := buildWorkload.Spec.Stack
+	if stack == "" {
+		return r.controllerConfig.ClusterBuilderName, nil
+	}
+
+	cfStacks := new(korifiv1alpha1.CFStackList)
+	if err := r.k8sClient.List(ctx, cfStacks, client.InNamespace(r.controllerConfig.CFRootNamespace)); err != nil {
+		return "", fmt.Errorf("failed to list CFStacks: %w", err)
+	}
+
+	for _, cfStack := range cfStacks.Items {
+		if !strings.EqualFold(cfStack.Spec.DisplayName, stack) {
+			continue
+		}
+
+		if cfStack.Status.ClusterBuilderName == "" {
+			return "", fmt.Errorf("the ClusterBuilder of stack %q has not been created yet", stack)
+		}
+
+		return cfStack.Status.ClusterBuilderName, nil
+	}
+
+	if strings.EqualFold(stack, r.controllerConfig.DefaultStack) {
+		return r.controllerConfig.ClusterBuilderName, nil
+	}
+
+	clusterBuilder, err := r.getClusterBuilder(ctx, r.controllerConfig.ClusterBuilderName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ClusterBuilder %q: %w", r.controllerConfig.ClusterBuilderName, err)
+	}
+
+	if clusterBuilder.Status.Stack.ID == "" {
+		return "", fmt.Errorf("the stack of ClusterBuilder %q has not been resolved yet", clusterBuilder.Name)
+	}
+
+	if !strings.EqualFold(clusterBuilder.Status.Stack.ID, stack) {
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "StackNotFound",
+			Message:            fmt.Sprintf("Stack %q not found", stack),
+			ObservedGeneration: buildWorkload.Generation,
+		})
+		return "", newDoNotRetryError(fmt.Errorf("stack %q not found", stack))
+	}
+
+	return r.controllerConfig.ClusterBuilderName, nil
+}
+
+func (r *BuildWorkloadReconciler) getClusterBuilder(ctx context.Context, name string) (*buildv1alpha2.ClusterBuilder, error) {
+	var clusterBuilder buildv1alpha2.ClusterBuilder
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: name}, &clusterBuilder)
+	return &clusterBuilder, err
 }
 
 type doNotRetryError struct {
@@ -399,29 +464,29 @@ func ignoreDoNotRetryError(err error) error {
 	return err
 }
 
-func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilderName string) (string, error) {
 	var (
-		defaultBuilder *buildv1alpha2.ClusterBuilder
+		clusterBuilder *buildv1alpha2.ClusterBuilder
 		err            error
 	)
 
-	if defaultBuilder, err = r.getDefaultClusterBuilder(ctx); err != nil {
+	if clusterBuilder, err = r.getClusterBuilder(ctx, clusterBuilderName); err != nil {
 		if k8serrors.IsNotFound(err) {
 			meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 				Type:               korifiv1alpha1.SucceededConditionType,
 				Status:             metav1.ConditionFalse,
 				Reason:             "BuilderNotReady",
-				Message:            "Default ClusterBuilder not found",
+				Message:            "ClusterBuilder not found",
 				ObservedGeneration: buildWorkload.Generation,
 			})
-			return "", newDoNotRetryError(fmt.Errorf("default ClusterBuilder not found: %w", err))
+			return "", newDoNotRetryError(fmt.Errorf("ClusterBuilder %q not found: %w", clusterBuilderName, err))
 		}
 
-		log.Info("error when fetching default ClusterBuilder", "reason", err)
+		log.Info("error when fetching ClusterBuilder", "name", clusterBuilderName, "reason", err)
 		return "", err
 	}
 
-	if err = r.checkBuildpacks(ctx, buildWorkload, defaultBuilder); err != nil {
+	if err = r.checkBuildpacks(ctx, buildWorkload, clusterBuilder); err != nil {
 		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.SucceededConditionType,
 			Status:             metav1.ConditionFalse,
@@ -433,7 +498,7 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 		return "", newDoNotRetryError(err)
 	}
 
-	builderName := ComputeBuilderName(buildWorkload.Spec.Buildpacks)
+	builderName := r.computeCustomBuilderName(clusterBuilderName, buildWorkload.Spec.Buildpacks)
 	builderRepo := fmt.Sprintf("%sbuilders-%s", r.controllerConfig.ContainerRepositoryPrefix, builderName)
 	err = r.imageRepoCreator.CreateRepository(ctx, builderRepo)
 	if err != nil {
@@ -454,8 +519,8 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 		}
 
 		builder.Spec.Tag = builderRepo
-		builder.Spec.Stack = defaultBuilder.Spec.Stack
-		builder.Spec.Store = defaultBuilder.Spec.Store
+		builder.Spec.Stack = clusterBuilder.Spec.Stack
+		builder.Spec.Store = clusterBuilder.Spec.Store
 		builder.Spec.ServiceAccountName = r.controllerConfig.BuilderServiceAccount
 		builder.Spec.Order = nil
 		for _, bp := range buildWorkload.Spec.Buildpacks {
@@ -484,15 +549,26 @@ func ComputeBuilderName(bps []string) string {
 	return uuid.NewSHA1(uuid.Nil, []byte(strings.Join(bps, "\x00"))).String()
 }
 
-func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, defaultBuilder *buildv1alpha2.ClusterBuilder) error {
+// computeCustomBuilderName keeps the names of the builders based on the
+// default ClusterBuilder unchanged, and prefixes the buildpacks with the
+// ClusterBuilder name otherwise, so that every stack gets its own builders
+func (r *BuildWorkloadReconciler) computeCustomBuilderName(clusterBuilderName string, bps []string) string {
+	if clusterBuilderName == r.controllerConfig.ClusterBuilderName {
+		return ComputeBuilderName(bps)
+	}
+
+	return ComputeBuilderName(append([]string{clusterBuilderName}, bps...))
+}
+
+func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilder *buildv1alpha2.ClusterBuilder) error {
 	validIDs := map[string]bool{}
-	for _, bp := range clusterBuilderToBuildpacks(defaultBuilder, metav1.Now()) {
+	for _, bp := range clusterBuilderToBuildpacks(clusterBuilder, metav1.Now()) {
 		validIDs[bp.Name] = true
 	}
 
 	for _, bp := range buildWorkload.Spec.Buildpacks {
 		if !validIDs[bp] {
-			return fmt.Errorf("buildpack %q not present in default ClusterStore. See `cf buildpacks`", bp)
+			return fmt.Errorf("buildpack %q not present in ClusterBuilder %q. See `cf buildpacks`", bp, clusterBuilder.Name)
 		}
 	}
 	return nil
@@ -604,10 +680,15 @@ func latestBuild(builds []buildv1alpha2.Build) (*buildv1alpha2.Build, error) {
 
 func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (ctrl.Result, error) {
 	var builderName string
-	var err error
+
+	clusterBuilderName, err := r.getClusterBuilderName(ctx, buildWorkload)
+	if err != nil {
+		log.Info("failed to find the ClusterBuilder for the stack", "stack", buildWorkload.Spec.Stack, "reason", err)
+		return ctrl.Result{}, ignoreDoNotRetryError(err)
+	}
 
 	if len(buildWorkload.Spec.Buildpacks) > 0 {
-		builderName, err = r.ensureKpackBuilderForBuildpacks(ctx, log, buildWorkload)
+		builderName, err = r.ensureKpackBuilderForBuildpacks(ctx, log, buildWorkload, clusterBuilderName)
 		if err != nil {
 			log.Info("failed ensuring custom builder", "reason", err)
 			return ctrl.Result{}, ignoreDoNotRetryError(fmt.Errorf("failed ensuring custom builder: %w", err))
@@ -620,7 +701,34 @@ func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, builderName)
+	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, clusterBuilderName, builderName)
+}
+
+// kpackSourceConfig points kpack at the package bits. Blobs are downloaded
+// by the build pod without credentials, so kpack is given a signed URL. The
+// URL only has to be valid until the build has fetched the source.
+func (r *BuildWorkloadReconciler) kpackSourceConfig(buildWorkload *korifiv1alpha1.BuildWorkload) (corev1alpha1.SourceConfig, error) {
+	if buildWorkload.Spec.Source.Blob == nil {
+		return corev1alpha1.SourceConfig{
+			Registry: &corev1alpha1.Registry{
+				Image:            buildWorkload.Spec.Source.Registry.Image,
+				ImagePullSecrets: buildWorkload.Spec.Source.Registry.ImagePullSecrets,
+			},
+		}, nil
+	}
+
+	if r.blobURLSigner == nil {
+		return corev1alpha1.SourceConfig{}, errors.New("the package source is a blob but the package blobstore is not configured")
+	}
+
+	signedURL, err := r.blobURLSigner.SignURL(buildWorkload.Spec.Source.Blob.URL, sourceBlobURLExpiry)
+	if err != nil {
+		return corev1alpha1.SourceConfig{}, fmt.Errorf("failed to sign source blob url: %w", err)
+	}
+
+	return corev1alpha1.SourceConfig{
+		Blob: &corev1alpha1.Blob{URL: signedURL},
+	}, nil
 }
 
 func (r *BuildWorkloadReconciler) ensureRegistryImagePullSecretsExist(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) error {
@@ -638,6 +746,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 	ctx context.Context,
 	log logr.Logger,
 	buildWorkload *korifiv1alpha1.BuildWorkload,
+	clusterBuilderName string,
 	customBuilderName string,
 ) error {
 	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
@@ -655,6 +764,12 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 		return err
 	}
 
+	sourceConfig, err := r.kpackSourceConfig(buildWorkload)
+	if err != nil {
+		log.Info("failed to build kpack source config", "reason", err)
+		return err
+	}
+
 	cacheSize, err := resource.ParseQuantity(fmt.Sprintf("%dMi", r.controllerConfig.CFStagingResources.BuildCacheMB))
 	if err != nil {
 		log.Info("failed to parse image cache size", "reason", err)
@@ -685,27 +800,24 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 		}
 
 		desiredKpackImage.Labels = map[string]string{
-			BuildWorkloadLabelKey: buildWorkload.Name,
+			korifiv1alpha1.BuildWorkloadLabelKey: buildWorkload.Name,
 		}
 
 		desiredKpackImage.Spec = buildv1alpha2.ImageSpec{
 			Tag: kpackImageTag,
 			Builder: corev1.ObjectReference{
 				Kind:       clusterBuilderKind,
-				Name:       r.controllerConfig.ClusterBuilderName,
+				Name:       clusterBuilderName,
 				APIVersion: clusterBuilderAPIVersion,
 			},
 			ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
-			Source: corev1alpha1.SourceConfig{
-				Registry: &corev1alpha1.Registry{
-					Image:            buildWorkload.Spec.Source.Registry.Image,
-					ImagePullSecrets: buildWorkload.Spec.Source.Registry.ImagePullSecrets,
-				},
-			},
+			Source:             sourceConfig,
 			Build: &buildv1alpha2.ImageBuild{
-				Services:  buildWorkload.Spec.Services,
-				Env:       buildWorkload.Spec.Env,
-				Resources: GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+				Services:     buildWorkload.Spec.Services,
+				Env:          buildWorkload.Spec.Env,
+				Resources:    GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+				NodeSelector: buildWorkload.Spec.Placement.NodeSelector,
+				Tolerations:  buildWorkload.Spec.Placement.Tolerations,
 			},
 			Cache: &buildv1alpha2.ImageCacheConfig{
 				Volume: &buildv1alpha2.ImagePersistentVolumeCache{
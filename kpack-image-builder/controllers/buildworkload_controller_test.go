@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers"
@@ -45,6 +46,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		services                  []corev1.ObjectReference
 		reconcilerName            string
 		buildpacks                []string
+		placement                 korifiv1alpha1.Placement
+		stack                     string
 		imageRepoCreatorCallCount int
 		expectedCacheVolumeSize   string
 	)
@@ -96,6 +99,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 				{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "repo/my-buildpack"}}}},
 				{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "repo/another-buildpack"}}}},
 			}
+			clusterBuilder.Status.Stack.ID = "io.buildpacks.stacks.jammy"
 		})).To(Succeed())
 
 		buildWorkloadGUID = PrefixedGUID("build-workload")
@@ -125,6 +129,16 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		}
 
 		buildpacks = nil
+		stack = ""
+
+		placement = korifiv1alpha1.Placement{
+			NodeSelector: map[string]string{"pool": "regulated"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		}
 
 		fakeImageConfigGetter.ConfigReturns(image.Config{
 			Labels: map[string]string{
@@ -193,6 +207,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 	Describe("BuildWorkload initialization phase", func() {
 		JustBeforeEach(func() {
 			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Spec.Placement = placement
+			buildWorkload.Spec.Stack = stack
 			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
 		})
 
@@ -208,6 +224,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 					g.Expect(kpackImage.Spec.Source.Registry.ImagePullSecrets).To(BeEquivalentTo(source.Registry.ImagePullSecrets))
 					g.Expect(kpackImage.Spec.Build.Env).To(Equal(env))
 					g.Expect(kpackImage.Spec.Build.Services).To(BeEquivalentTo(services))
+					g.Expect(kpackImage.Spec.Build.NodeSelector).To(Equal(placement.NodeSelector))
+					g.Expect(kpackImage.Spec.Build.Tolerations).To(Equal(placement.Tolerations))
 					g.Expect(kpackImage.Spec.Build.Resources.Requests.StorageEphemeral().String()).To(Equal(fmt.Sprintf("%dM", 2048)))
 					g.Expect(kpackImage.Spec.Build.Resources.Requests.Memory().String()).To(Equal(fmt.Sprintf("%dM", 1234)))
 
@@ -240,6 +258,31 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			ItDoesInitialReconciliationWithDefaultBuilder()
 		})
 
+		When("the source is a package blobstore blob", func() {
+			BeforeEach(func() {
+				source = korifiv1alpha1.PackageSource{
+					Blob: &korifiv1alpha1.Blob{URL: "https://blobstore.example.com/packages/my-package.zip"},
+				}
+				blobURLSigner.SignURLReturns("https://blobstore.example.com/packages/my-package.zip?signature=abc", nil)
+			})
+
+			It("gives kpack a signed blob url", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Source.Registry).To(BeNil())
+					g.Expect(kpackImage.Spec.Source.Blob).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"URL": Equal("https://blobstore.example.com/packages/my-package.zip?signature=abc"),
+					})))
+				}).Should(Succeed())
+
+				Expect(blobURLSigner.SignURLCallCount()).To(BeNumerically(">", 0))
+				blobURL, expiry := blobURLSigner.SignURLArgsForCall(blobURLSigner.SignURLCallCount() - 1)
+				Expect(blobURL).To(Equal("https://blobstore.example.com/packages/my-package.zip"))
+				Expect(expiry).To(Equal(24 * time.Hour))
+			})
+		})
+
 		When("a kpack.Image already exists for the BuildWorkload", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &buildv1alpha2.Image{
@@ -247,7 +290,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 						Name:      appGUID,
 						Namespace: namespaceGUID,
 						Labels: map[string]string{
-							controllers.BuildWorkloadLabelKey: buildWorkloadGUID,
+							korifiv1alpha1.BuildWorkloadLabelKey: buildWorkloadGUID,
 						},
 					},
 					Spec: buildv1alpha2.ImageSpec{
@@ -278,7 +321,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 						Name:      appGUID,
 						Namespace: namespaceGUID,
 						Labels: map[string]string{
-							controllers.BuildWorkloadLabelKey: buildWorkloadGUID,
+							korifiv1alpha1.BuildWorkloadLabelKey: buildWorkloadGUID,
 						},
 					},
 					Spec: buildv1alpha2.ImageSpec{
@@ -443,6 +486,111 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			})
 		})
 
+		When("the default stack is specified", func() {
+			BeforeEach(func() {
+				stack = "cflinuxfs3"
+			})
+
+			ItDoesInitialReconciliationWithDefaultBuilder()
+		})
+
+		When("the stack of the default ClusterBuilder is specified", func() {
+			BeforeEach(func() {
+				stack = "io.buildpacks.stacks.jammy"
+			})
+
+			ItDoesInitialReconciliationWithDefaultBuilder()
+		})
+
+		When("a stack without a CFStack is specified", func() {
+			BeforeEach(func() {
+				stack = "unknown-stack"
+			})
+
+			It("fails the build", func() {
+				updatedWorkload := &korifiv1alpha1.BuildWorkload{ObjectMeta: metav1.ObjectMeta{Name: buildWorkloadGUID, Namespace: namespaceGUID}}
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(updatedWorkload), updatedWorkload)).To(Succeed())
+					foundCondition := mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded")
+					g.Expect(foundCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(foundCondition.Reason).To(Equal("StackNotFound"))
+					g.Expect(foundCondition.ObservedGeneration).To(Equal(updatedWorkload.Generation))
+				}).Should(Succeed())
+			})
+
+			It("does not create a kpack image", func() {
+				Consistently(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					err := adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the stack of a CFStack is specified", func() {
+			var stackBuilderName string
+
+			BeforeEach(func() {
+				stack = "cflinuxfs4"
+
+				cfStack := &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace.Name,
+					},
+					Spec: korifiv1alpha1.CFStackSpec{
+						DisplayName: "cflinuxfs4",
+						ID:          "io.buildpacks.stacks.jammy",
+						BuildImage:  "my.repository/build:jammy",
+						RunImage:    "my.repository/run:jammy",
+					},
+				}
+				Expect(adminClient.Create(ctx, cfStack)).To(Succeed())
+				stackBuilderName = "cf-stack-" + cfStack.Name
+
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+					g.Expect(cfStack.Status.ClusterBuilderName).To(Equal(stackBuilderName))
+				}).Should(Succeed())
+			})
+
+			It("builds the image with the ClusterBuilder of the stack", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+					g.Expect(kpackImage.Spec.Builder.Name).To(Equal(stackBuilderName))
+				}).Should(Succeed())
+			})
+
+			When("buildpacks are specified", func() {
+				BeforeEach(func() {
+					buildpacks = []string{"repo/my-buildpack"}
+
+					stackBuilder := &buildv1alpha2.ClusterBuilder{ObjectMeta: metav1.ObjectMeta{Name: stackBuilderName}}
+					Expect(k8s.Patch(ctx, adminClient, stackBuilder, func() {
+						stackBuilder.Status.Order = []corev1alpha1.OrderEntry{
+							{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "repo/my-buildpack"}}}},
+						}
+					})).To(Succeed())
+				})
+
+				It("creates a kpack Builder on the stack of the CFStack", func() {
+					builderName := controllers.ComputeBuilderName(append([]string{stackBuilderName}, buildpacks...))
+					builder := &buildv1alpha2.Builder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      builderName,
+							Namespace: namespaceGUID,
+						},
+					}
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(builder), builder)).To(Succeed())
+						g.Expect(builder.Spec.Stack).To(Equal(corev1.ObjectReference{Kind: "ClusterStack", Name: stackBuilderName}))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("reconciler name on BuildWorkload is not kpack-image-builder", func() {
 			BeforeEach(func() {
 				reconcilerName = "notkpackreconciler"
@@ -1100,11 +1248,11 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 					Name:      "build",
 					Namespace: namespaceGUID,
 					Labels: map[string]string{
-						korifiv1alpha1.CFAppGUIDLabelKey:   appGUID,
-						buildv1alpha2.ImageLabel:           appGUID,
-						buildv1alpha2.ImageGenerationLabel: "1",
-						buildv1alpha2.BuildNumberLabel:     "1",
-						controllers.BuildWorkloadLabelKey:  buildWorkload.Name,
+						korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+						buildv1alpha2.ImageLabel:             appGUID,
+						buildv1alpha2.ImageGenerationLabel:   "1",
+						buildv1alpha2.BuildNumberLabel:       "1",
+						korifiv1alpha1.BuildWorkloadLabelKey: buildWorkload.Name,
 					},
 				},
 			}
@@ -1286,7 +1434,7 @@ func buildKpackImageObject(name string, namespace string, source korifiv1alpha1.
 			Name:      name,
 			Namespace: namespace,
 			Labels: map[string]string{
-				controllers.BuildWorkloadLabelKey: name,
+				korifiv1alpha1.BuildWorkloadLabelKey: name,
 			},
 		},
 		Spec: buildv1alpha2.ImageSpec{
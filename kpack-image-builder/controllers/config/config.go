@@ -4,14 +4,21 @@ import (
 	"time"
 
 	controllersconfig "code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 )
 
 type Config struct {
-	CFRootNamespace           string                               `yaml:"cfRootNamespace"`
-	CFStagingResources        controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
-	ClusterBuilderName        string                               `yaml:"clusterBuilderName"`
-	BuilderServiceAccount     string                               `yaml:"builderServiceAccount"`
-	BuilderReadinessTimeout   time.Duration                        `yaml:"builderReadinessTimeout"`
-	ContainerRepositoryPrefix string                               `yaml:"containerRepositoryPrefix"`
-	ContainerRegistryType     string                               `yaml:"containerRegistryType"`
+	CFRootNamespace            string                               `yaml:"cfRootNamespace"`
+	CFStagingResources         controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
+	ClusterBuilderName         string                               `yaml:"clusterBuilderName"`
+	DefaultStack               string                               `yaml:"defaultStack"`
+	BuilderServiceAccount      string                               `yaml:"builderServiceAccount"`
+	BuilderReadinessTimeout    time.Duration                        `yaml:"builderReadinessTimeout"`
+	ContainerRepositoryPrefix  string                               `yaml:"containerRepositoryPrefix"`
+	ContainerRegistryType      string                               `yaml:"containerRegistryType"`
+	ContainerRepositoryCreator registry.RepositoryCreatorConfig     `yaml:"containerRepositoryCreator"`
+	PackageBlobstore           blobstore.Config                     `yaml:"packageBlobstore"`
+	Tracing                    tracing.Config                       `yaml:"tracing"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/go-logr/logr"
@@ -53,7 +54,7 @@ func (c *KpackBuildController) SetupWithManager(mgr manager.Manager) error {
 	labelSelector, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
 			{
-				Key:      BuildWorkloadLabelKey,
+				Key:      korifiv1alpha1.BuildWorkloadLabelKey,
 				Operator: metav1.LabelSelectorOpExists,
 				Values:   []string{},
 			},
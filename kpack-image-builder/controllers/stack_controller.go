@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const clusterStackKind = "ClusterStack"
+
+func NewStackReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	clusterBuilderName string,
+	rootNamespaceName string,
+	containerRepositoryPrefix string,
+	imageRepoCreator RepositoryCreator,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFStack] {
+	stackReconciler := StackReconciler{
+		k8sClient:                 c,
+		scheme:                    scheme,
+		log:                       log,
+		clusterBuilderName:        clusterBuilderName,
+		rootNamespaceName:         rootNamespaceName,
+		containerRepositoryPrefix: containerRepositoryPrefix,
+		imageRepoCreator:          imageRepoCreator,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFStack](log, c, &stackReconciler)
+}
+
+// StackReconciler creates a ClusterStack and a ClusterBuilder for every
+// CFStack. The ClusterBuilder uses the store and the buildpack order of the
+// default ClusterBuilder, so that apps can be built on any of the stacks.
+type StackReconciler struct {
+	k8sClient                 client.Client
+	scheme                    *runtime.Scheme
+	log                       logr.Logger
+	clusterBuilderName        string
+	rootNamespaceName         string
+	containerRepositoryPrefix string
+	imageRepoCreator          RepositoryCreator
+}
+
+func (r *StackReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFStack{}).
+		Watches(
+			new(buildv1alpha2.ClusterBuilder),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueStackRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterStacks))
+}
+
+func (r *StackReconciler) enqueueStackRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	if stackGUID, ok := o.GetLabels()[korifiv1alpha1.CFStackGUIDLabelKey]; ok {
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{
+				Name:      stackGUID,
+				Namespace: r.rootNamespaceName,
+			},
+		}}
+	}
+
+	if o.GetName() != r.clusterBuilderName {
+		return nil
+	}
+
+	cfStacks := new(korifiv1alpha1.CFStackList)
+	if err := r.k8sClient.List(ctx, cfStacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		r.log.Info("failed to list CFStacks", "reason", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cfStack := range cfStacks.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      cfStack.Name,
+				Namespace: cfStack.Namespace,
+			},
+		})
+	}
+	return requests
+}
+
+func (r *StackReconciler) filterStacks(object client.Object) bool {
+	cfStack, ok := object.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return true
+	}
+
+	return cfStack.Namespace == r.rootNamespaceName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks/status,verbs=get;patch
+
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterstacks,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch;create;patch;delete
+
+func (r *StackReconciler) ReconcileResource(ctx context.Context, cfStack *korifiv1alpha1.CFStack) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfStack.Status.ObservedGeneration = cfStack.Generation
+	log.V(1).Info("set observed generation", "generation", cfStack.Status.ObservedGeneration)
+
+	if !cfStack.GetDeletionTimestamp().IsZero() {
+		return r.finalize(ctx, log, cfStack)
+	}
+
+	defaultBuilder := new(buildv1alpha2.ClusterBuilder)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: r.clusterBuilderName}, defaultBuilder)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("ClusterBuilderMissing").
+			WithMessage(fmt.Sprintf("Error fetching ClusterBuilder %q: %s", r.clusterBuilderName, err))
+	}
+
+	clusterStack := &buildv1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: stackResourceName(cfStack),
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, clusterStack, func() error {
+		clusterStack.Labels = map[string]string{korifiv1alpha1.CFStackGUIDLabelKey: cfStack.Name}
+		clusterStack.Spec.Id = cfStack.Spec.ID
+		clusterStack.Spec.BuildImage = buildv1alpha2.ClusterStackSpecImage{Image: cfStack.Spec.BuildImage}
+		clusterStack.Spec.RunImage = buildv1alpha2.ClusterStackSpecImage{Image: cfStack.Spec.RunImage}
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create or patch ClusterStack", "reason", err)
+		return ctrl.Result{}, fmt.Errorf("failed to create or patch ClusterStack %q: %w", clusterStack.Name, err)
+	}
+
+	builderRepo := fmt.Sprintf("%sbuilders-%s", r.containerRepositoryPrefix, stackResourceName(cfStack))
+	if err = r.imageRepoCreator.CreateRepository(ctx, builderRepo); err != nil {
+		log.Info("failed creating builder repo", "reason", err)
+		return ctrl.Result{}, fmt.Errorf("failed to create builder repo: %w", err)
+	}
+
+	clusterBuilder := &buildv1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: stackResourceName(cfStack),
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, clusterBuilder, func() error {
+		clusterBuilder.Labels = map[string]string{korifiv1alpha1.CFStackGUIDLabelKey: cfStack.Name}
+		clusterBuilder.Spec.Tag = builderRepo
+		clusterBuilder.Spec.Stack = corev1.ObjectReference{
+			Kind: clusterStackKind,
+			Name: clusterStack.Name,
+		}
+		clusterBuilder.Spec.Store = defaultBuilder.Spec.Store
+		clusterBuilder.Spec.Order = defaultBuilder.Spec.Order
+		clusterBuilder.Spec.ServiceAccountRef = defaultBuilder.Spec.ServiceAccountRef
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create or patch ClusterBuilder", "reason", err)
+		return ctrl.Result{}, fmt.Errorf("failed to create or patch ClusterBuilder %q: %w", clusterBuilder.Name, err)
+	}
+
+	cfStack.Status.ClusterBuilderName = clusterBuilder.Name
+
+	clusterBuilderReadyCondition := clusterBuilder.Status.GetCondition(corev1alpha1.ConditionReady)
+	if clusterBuilderReadyCondition == nil || clusterBuilderReadyCondition.Status != corev1.ConditionTrue {
+		var msg string
+		if clusterBuilderReadyCondition != nil {
+			msg = clusterBuilderReadyCondition.Message
+		}
+
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("ClusterBuilderNotReady").
+			WithMessage(fmt.Sprintf("ClusterBuilder %q is not ready: %s", clusterBuilder.Name, msg))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *StackReconciler) finalize(ctx context.Context, log logr.Logger, cfStack *korifiv1alpha1.CFStack) (ctrl.Result, error) {
+	log = log.WithName("finalize")
+
+	for _, obj := range []client.Object{
+		&buildv1alpha2.ClusterBuilder{ObjectMeta: metav1.ObjectMeta{Name: stackResourceName(cfStack)}},
+		&buildv1alpha2.ClusterStack{ObjectMeta: metav1.ObjectMeta{Name: stackResourceName(cfStack)}},
+	} {
+		if err := r.k8sClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete stack resource", "name", obj.GetName(), "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if controllerutil.RemoveFinalizer(cfStack, korifiv1alpha1.CFStackFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func stackResourceName(cfStack *korifiv1alpha1.CFStack) string {
+	return "cf-stack-" + cfStack.Name
+}
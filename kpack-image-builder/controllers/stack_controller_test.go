@@ -0,0 +1,177 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("StackReconciler", func() {
+	var (
+		defaultBuilder      *buildv1alpha2.ClusterBuilder
+		cfStack             *korifiv1alpha1.CFStack
+		stackClusterStack   *buildv1alpha2.ClusterStack
+		stackClusterBuilder *buildv1alpha2.ClusterBuilder
+	)
+
+	BeforeEach(func() {
+		defaultBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterBuilderName,
+			},
+			Spec: buildv1alpha2.ClusterBuilderSpec{
+				BuilderSpec: buildv1alpha2.BuilderSpec{
+					Tag: "my.repository/my-builder",
+					Stack: v1.ObjectReference{
+						Kind: "ClusterStack",
+						Name: "cflinuxfs3-stack",
+					},
+					Store: v1.ObjectReference{
+						Kind: "ClusterStore",
+						Name: "my-store",
+					},
+					Order: []buildv1alpha2.BuilderOrderEntry{
+						{Group: []buildv1alpha2.BuilderBuildpackRef{{
+							BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo-buildpacks/java"}},
+						}}},
+					},
+				},
+				ServiceAccountRef: v1.ObjectReference{
+					Name:      "kpack-service-account",
+					Namespace: rootNamespace.Name,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, defaultBuilder)).To(Succeed())
+
+		cfStack = &korifiv1alpha1.CFStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace.Name,
+			},
+			Spec: korifiv1alpha1.CFStackSpec{
+				DisplayName: "cflinuxfs4",
+				ID:          "io.buildpacks.stacks.jammy",
+				BuildImage:  "my.repository/build:jammy",
+				RunImage:    "my.repository/run:jammy",
+			},
+		}
+		Expect(adminClient.Create(ctx, cfStack)).To(Succeed())
+
+		stackClusterStack = &buildv1alpha2.ClusterStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cf-stack-" + cfStack.Name,
+			},
+		}
+		stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cf-stack-" + cfStack.Name,
+			},
+		}
+	})
+
+	It("creates a ClusterStack with the stack images", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterStack), stackClusterStack)).To(Succeed())
+			g.Expect(stackClusterStack.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFStackGUIDLabelKey, cfStack.Name))
+			g.Expect(stackClusterStack.Spec.Id).To(Equal("io.buildpacks.stacks.jammy"))
+			g.Expect(stackClusterStack.Spec.BuildImage.Image).To(Equal("my.repository/build:jammy"))
+			g.Expect(stackClusterStack.Spec.RunImage.Image).To(Equal("my.repository/run:jammy"))
+		}).Should(Succeed())
+	})
+
+	It("creates a ClusterBuilder based on the default one", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+			g.Expect(stackClusterBuilder.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFStackGUIDLabelKey, cfStack.Name))
+			g.Expect(stackClusterBuilder.Spec.Tag).To(Equal("my.repository/my-prefix/builders-cf-stack-" + cfStack.Name))
+			g.Expect(stackClusterBuilder.Spec.Stack).To(Equal(v1.ObjectReference{Kind: "ClusterStack", Name: stackClusterStack.Name}))
+			g.Expect(stackClusterBuilder.Spec.Store).To(Equal(defaultBuilder.Spec.Store))
+			g.Expect(stackClusterBuilder.Spec.Order).To(Equal(defaultBuilder.Spec.Order))
+			g.Expect(stackClusterBuilder.Spec.ServiceAccountRef).To(Equal(defaultBuilder.Spec.ServiceAccountRef))
+		}).Should(Succeed())
+	})
+
+	It("records the ClusterBuilder name and is not ready until the builder is", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+			g.Expect(cfStack.Status.ObservedGeneration).To(Equal(cfStack.Generation))
+			g.Expect(cfStack.Status.ClusterBuilderName).To(Equal(stackClusterBuilder.Name))
+			readyCondition := meta.FindStatusCondition(cfStack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+			g.Expect(readyCondition).NotTo(BeNil())
+			g.Expect(readyCondition.Reason).To(Equal("ClusterBuilderNotReady"))
+		}).Should(Succeed())
+	})
+
+	When("the ClusterBuilder becomes ready", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, stackClusterBuilder, func() {
+				stackClusterBuilder.Status.Conditions = corev1alpha1.Conditions{{
+					Type:   corev1alpha1.ConditionReady,
+					Status: v1.ConditionTrue,
+				}}
+			})).To(Succeed())
+		})
+
+		It("becomes ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfStack.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the default ClusterBuilder order changes", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, defaultBuilder, func() {
+				defaultBuilder.Spec.Order = append(defaultBuilder.Spec.Order, buildv1alpha2.BuilderOrderEntry{
+					Group: []buildv1alpha2.BuilderBuildpackRef{{
+						BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo-buildpacks/go"}},
+					}},
+				})
+			})).To(Succeed())
+		})
+
+		It("updates the stack ClusterBuilder order", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+				g.Expect(stackClusterBuilder.Spec.Order).To(HaveLen(2))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the CFStack is deleted", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfStack)).To(Succeed())
+		})
+
+		It("deletes the ClusterStack, the ClusterBuilder and the CFStack", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterBuilder), stackClusterBuilder)).To(MatchError(ContainSubstring("not found")))
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterStack), stackClusterStack)).To(MatchError(ContainSubstring("not found")))
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(MatchError(ContainSubstring("not found")))
+			}).Should(Succeed())
+		})
+	})
+})
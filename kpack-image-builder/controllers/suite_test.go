@@ -64,6 +64,7 @@ var (
 	buildWorkloadReconciler *k8s.PatchingReconciler[korifiv1alpha1.BuildWorkload]
 	rootNamespace           *v1.Namespace
 	imageRepoCreator        *fake.RepositoryCreator
+	blobURLSigner           *fake.BlobURLSigner
 	k8sManager              manager.Manager
 	clusterBuilderName      string
 )
@@ -142,6 +143,7 @@ var _ = BeforeEach(func() {
 	controllerConfig := &config.Config{
 		CFRootNamespace:           rootNamespace.Name,
 		ClusterBuilderName:        clusterBuilderName,
+		DefaultStack:              "cflinuxfs3",
 		BuilderServiceAccount:     "builder-service-account",
 		BuilderReadinessTimeout:   4 * time.Second,
 		ContainerRepositoryPrefix: "my.repository/my-prefix/",
@@ -153,6 +155,7 @@ var _ = BeforeEach(func() {
 	}
 
 	imageRepoCreator = new(fake.RepositoryCreator)
+	blobURLSigner = new(fake.BlobURLSigner)
 	fakeImageConfigGetter = new(fake.ImageConfigGetter)
 	buildWorkloadReconciler = controllers.NewBuildWorkloadReconciler(
 		k8sManager.GetClient(),
@@ -161,6 +164,7 @@ var _ = BeforeEach(func() {
 		controllerConfig,
 		fakeImageConfigGetter,
 		imageRepoCreator,
+		blobURLSigner,
 	)
 
 	Expect(buildWorkloadReconciler.SetupWithManager(k8sManager)).To(Succeed())
@@ -173,6 +177,26 @@ var _ = BeforeEach(func() {
 			controllerConfig.CFRootNamespace,
 		).SetupWithManager(k8sManager),
 	).To(Succeed())
+	Expect(
+		controllers.NewBuildpackReconciler(
+			k8sManager.GetClient(),
+			k8sManager.GetScheme(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("CFBuildpack"),
+			clusterBuilderName,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
+	Expect(
+		controllers.NewStackReconciler(
+			k8sManager.GetClient(),
+			k8sManager.GetScheme(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("CFStack"),
+			clusterBuilderName,
+			controllerConfig.CFRootNamespace,
+			controllerConfig.ContainerRepositoryPrefix,
+			imageRepoCreator,
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
 
 	fakeImageDeleter = new(fake.ImageDeleter)
 	kpackBuildReconciler := controllers.NewKpackBuildController(
@@ -1,6 +1,6 @@
 package finalizer
 
-//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-kpack-image-builder-finalizer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org;kpack.io,resources=buildworkloads;builds,verbs=create,versions=v1alpha1;v1alpha2,name=mcf-kib-finalizer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-kpack-image-builder-finalizer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org;kpack.io,resources=buildworkloads;cfbuildpacks;cfstacks;builds,verbs=create,versions=v1alpha1;v1alpha2,name=mcf-kib-finalizer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 import (
 	"context"
@@ -23,12 +23,14 @@ func NewKpackImageBuilderFinalizerWebhook() *KpackImageBuilderFinalizerWebhook {
 		delegate: k8s.NewFinalizerWebhook(map[string]k8s.FinalizerDescriptor{
 			"BuildWorkload": {FinalizerName: korifiv1alpha1.BuildWorkloadFinalizerName, SetPolicy: kpackImageBuilderBuildWorkloadsOnly},
 			"Build":         {FinalizerName: controllers.KpackBuildFinalizer, SetPolicy: korifiBuildsOnly},
+			"CFBuildpack":   {FinalizerName: korifiv1alpha1.CFBuildpackFinalizerName, SetPolicy: k8s.Always},
+			"CFStack":       {FinalizerName: korifiv1alpha1.CFStackFinalizerName, SetPolicy: k8s.Always},
 		}),
 	}
 }
 
 func korifiBuildsOnly(obj unstructured.Unstructured) bool {
-	_, hasBuildWorkloadLabel := obj.GetLabels()[controllers.BuildWorkloadLabelKey]
+	_, hasBuildWorkloadLabel := obj.GetLabels()[korifiv1alpha1.BuildWorkloadLabelKey]
 	return hasBuildWorkloadLabel
 }
 
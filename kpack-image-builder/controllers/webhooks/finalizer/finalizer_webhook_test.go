@@ -49,7 +49,7 @@ var _ = Describe("KpackImageBuilder Finalizers Webhook", func() {
 					Name:      uuid.NewString(),
 					Namespace: rootNamespace,
 					Labels: map[string]string{
-						controllers.BuildWorkloadLabelKey: "my-build-workload",
+						korifiv1alpha1.BuildWorkloadLabelKey: "my-build-workload",
 					},
 				},
 			},
@@ -64,5 +64,33 @@ var _ = Describe("KpackImageBuilder Finalizers Webhook", func() {
 			},
 			nil,
 		),
+		Entry("cfbuildpack",
+			&korifiv1alpha1.CFBuildpack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFBuildpackSpec{
+					DisplayName: "my-buildpack",
+					Position:    1,
+				},
+			},
+			[]string{korifiv1alpha1.CFBuildpackFinalizerName},
+		),
+		Entry("cfstack",
+			&korifiv1alpha1.CFStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFStackSpec{
+					DisplayName: "my-stack",
+					ID:          "io.buildpacks.stacks.jammy",
+					BuildImage:  "my.repository/build:jammy",
+					RunImage:    "my.repository/run:jammy",
+				},
+			},
+			[]string{korifiv1alpha1.CFStackFinalizerName},
+		),
 	)
 })
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -11,8 +12,10 @@ import (
 	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers/config"
 	kpackimagebuilderfinalizer "code.cloudfoundry.org/korifi/kpack-image-builder/controllers/webhooks/finalizer"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/blobstore"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/registry"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 	"code.cloudfoundry.org/korifi/version"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"go.uber.org/zap/zapcore"
@@ -68,6 +71,27 @@ func main() {
 
 	ctrl.Log.Info("starting Korifi kpack image builder", "version", version.Version)
 
+	controllerConfig := &config.Config{}
+	err = tools.LoadConfigInto(controllerConfig, configPath)
+	if err != nil {
+		setupLog.Error(err, "config could not be read")
+		os.Exit(1)
+	}
+
+	if controllerConfig.Tracing.Enabled {
+		traceExporter, err := tracing.NewOTLPExporter(context.Background(), controllerConfig.Tracing)
+		if err != nil {
+			setupLog.Error(err, "unable to create trace exporter")
+			os.Exit(1)
+		}
+		tracerProvider := tracing.Setup("korifi-kpack-image-builder", traceExporter)
+		defer func() {
+			if err := tracerProvider.Shutdown(context.Background()); err != nil {
+				setupLog.Error(err, "error shutting down tracer provider")
+			}
+		}()
+	}
+
 	conf := ctrl.GetConfigOrDie()
 	mgr, err := ctrl.NewManager(conf, ctrl.Options{
 		Scheme: scheme,
@@ -86,7 +110,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = setupControllers(mgr, conf, configPath); err != nil {
+	if err = setupControllers(mgr, conf, controllerConfig); err != nil {
 		setupLog.Error(err, "unable to set up controllers")
 		os.Exit(1)
 	}
@@ -103,21 +127,23 @@ func main() {
 	}
 }
 
-func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath string) error {
+func setupControllers(mgr manager.Manager, restConf *rest.Config, controllerConfig *config.Config) error {
 	controllersLog := ctrl.Log.WithName("controllers")
 	imageClientSet, err := k8sclient.NewForConfig(restConf)
 	if err != nil {
 		return fmt.Errorf("could not create k8s client: %v", err)
 	}
 
-	controllerConfig := &config.Config{}
-	err = tools.LoadConfigInto(controllerConfig, configPath)
-	if err != nil {
-		return fmt.Errorf("config could not be read: %v", err)
-	}
-
 	controllersClient := k8s.IgnoreEmptyPatches(mgr.GetClient())
 
+	var blobURLSigner controllers.BlobURLSigner
+	if controllerConfig.PackageBlobstore.IsEnabled() {
+		blobURLSigner, err = blobstore.NewStore(controllerConfig.PackageBlobstore)
+		if err != nil {
+			return fmt.Errorf("could not create package blobstore: %v", err)
+		}
+	}
+
 	imageClient := image.NewClient(imageClientSet)
 	if err = controllers.NewBuildWorkloadReconciler(
 		controllersClient,
@@ -125,7 +151,8 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		controllersLog,
 		controllerConfig,
 		imageClient,
-		registry.NewRepositoryCreator(controllerConfig.ContainerRegistryType),
+		registry.NewRepositoryCreator(controllerConfig.ContainerRegistryType, controllerConfig.ContainerRepositoryCreator),
+		blobURLSigner,
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create BuildWorkload controller: %v", err)
 	}
@@ -140,6 +167,28 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		return fmt.Errorf("unable to create BuilderInfo controller: %v", err)
 	}
 
+	if err = controllers.NewBuildpackReconciler(
+		controllersClient,
+		mgr.GetScheme(),
+		controllersLog,
+		controllerConfig.ClusterBuilderName,
+		controllerConfig.CFRootNamespace,
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create CFBuildpack controller: %v", err)
+	}
+
+	if err = controllers.NewStackReconciler(
+		controllersClient,
+		mgr.GetScheme(),
+		controllersLog,
+		controllerConfig.ClusterBuilderName,
+		controllerConfig.CFRootNamespace,
+		controllerConfig.ContainerRepositoryPrefix,
+		registry.NewRepositoryCreator(controllerConfig.ContainerRegistryType, controllerConfig.ContainerRepositoryCreator),
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create CFStack controller: %v", err)
+	}
+
 	if err = controllers.NewKpackBuildController(
 		controllersClient,
 		controllersLog,
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/version"
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var (
+		listenAddress        string
+		hostKeyPath          string
+		codesNamespace       string
+		expiredCodesInterval time.Duration
+	)
+
+	flag.StringVar(&listenAddress, "listen-address", ":2222", "The address the ssh proxy listens on.")
+	flag.StringVar(&hostKeyPath, "host-key-path", "", "The path of the private host key of the ssh proxy.")
+	flag.StringVar(&codesNamespace, "codes-namespace", "korifi-ssh-codes", "The namespace where the API stores the ssh codes.")
+	flag.DurationVar(&expiredCodesInterval, "expired-codes-interval", time.Minute, "How often to delete expired ssh codes.")
+	flag.Parse()
+
+	logger, _, err := tools.NewZapLogger(zapcore.InfoLevel)
+	if err != nil {
+		panic(fmt.Sprintf("error creating new zap logger: %v", err))
+	}
+
+	ctrl.SetLogger(logger)
+	klog.SetLogger(ctrl.Log)
+
+	ctrl.Log.Info("starting Korifi ssh proxy", "version", version.Version)
+
+	hostKeyBytes, err := os.ReadFile(hostKeyPath)
+	if err != nil {
+		ctrl.Log.Error(err, "unable to read host key", "path", hostKeyPath)
+		os.Exit(1)
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		ctrl.Log.Error(err, "unable to parse host key")
+		os.Exit(1)
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		ctrl.Log.Error(err, "unable to create k8s client")
+		os.Exit(1)
+	}
+
+	ctx := logr.NewContext(ctrl.SetupSignalHandler(), ctrl.Log)
+
+	codeStore := proxy.NewSecretCodeStore(k8sClient, codesNamespace)
+	go wait.UntilWithContext(ctx, codeStore.DeleteExpired, expiredCodesInterval)
+
+	server := proxy.NewServer(
+		hostKey,
+		proxy.NewAuthenticator(codeStore, proxy.NewPodInstanceResolver(k8sClient), ctrl.Log.WithName("authenticator")),
+		proxy.NewK8sPodConnector(restConfig),
+		ctrl.Log.WithName("ssh-proxy"),
+	)
+
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		ctrl.Log.Error(err, "unable to listen", "address", listenAddress)
+		os.Exit(1)
+	}
+
+	ctrl.Log.Info("listening", "address", listenAddress)
+	if err = server.Serve(ctx, listener); err != nil {
+		ctrl.Log.Error(err, "error serving ssh")
+		os.Exit(1)
+	}
+}
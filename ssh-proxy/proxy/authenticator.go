@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	userExtension         = "korifi-user"
+	groupsExtension       = "korifi-groups"
+	podNamespaceExtension = "korifi-pod-namespace"
+	podNameExtension      = "korifi-pod-name"
+)
+
+//counterfeiter:generate -o fake -fake-name CodeStore . CodeStore
+
+type CodeStore interface {
+	Redeem(ctx context.Context, code string) (tools.SSHCode, error)
+}
+
+//counterfeiter:generate -o fake -fake-name InstanceResolver . InstanceResolver
+
+type InstanceResolver interface {
+	Resolve(ctx context.Context, appGUID string, index int) (Instance, error)
+}
+
+type Instance struct {
+	Namespace string
+	PodName   string
+}
+
+type Authenticator struct {
+	codeStore        CodeStore
+	instanceResolver InstanceResolver
+	logger           logr.Logger
+}
+
+func NewAuthenticator(codeStore CodeStore, instanceResolver InstanceResolver, logger logr.Logger) *Authenticator {
+	return &Authenticator{
+		codeStore:        codeStore,
+		instanceResolver: instanceResolver,
+		logger:           logger,
+	}
+}
+
+// PasswordCallback implements the cf ssh protocol: the user name identifies
+// the app instance as cf:<app-guid>/<index> and the password is a one-time
+// code issued by the API
+func (a *Authenticator) PasswordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	ctx := context.Background()
+	logger := a.logger.WithValues("user", conn.User(), "remoteAddr", conn.RemoteAddr().String())
+
+	appGUID, index, err := ParseUser(conn.User())
+	if err != nil {
+		logger.Info("invalid user", "reason", err.Error())
+		return nil, err
+	}
+
+	code, err := a.codeStore.Redeem(ctx, string(password))
+	if err != nil {
+		logger.Info("failed to redeem code", "reason", err.Error())
+		return nil, errors.New("invalid code")
+	}
+
+	if code.AppGUID != "" && code.AppGUID != appGUID {
+		logger.Info("code was issued for a different app", "codeAppGUID", code.AppGUID)
+		return nil, errors.New("invalid code")
+	}
+
+	instance, err := a.instanceResolver.Resolve(ctx, appGUID, index)
+	if err != nil {
+		logger.Info("failed to resolve app instance", "reason", err.Error())
+		return nil, err
+	}
+
+	logger.Info("authenticated", "codeUser", code.User, "namespace", instance.Namespace, "pod", instance.PodName)
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			userExtension:         code.User,
+			groupsExtension:       strings.Join(code.Groups, "\n"),
+			podNamespaceExtension: instance.Namespace,
+			podNameExtension:      instance.PodName,
+		},
+	}, nil
+}
+
+func ParseUser(user string) (string, int, error) {
+	target, found := strings.CutPrefix(user, "cf:")
+	if !found {
+		return "", 0, fmt.Errorf("user %q does not have the cf:<app-guid>/<index> format", user)
+	}
+
+	appGUID, indexString, found := strings.Cut(target, "/")
+	if !found || appGUID == "" {
+		return "", 0, fmt.Errorf("user %q does not have the cf:<app-guid>/<index> format", user)
+	}
+
+	index, err := strconv.Atoi(indexString)
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("user %q has an invalid instance index", user)
+	}
+
+	return appGUID, index, nil
+}
@@ -0,0 +1,158 @@
+package proxy_test
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy/fake"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authenticator", func() {
+	var (
+		codeStore        *fake.CodeStore
+		instanceResolver *fake.InstanceResolver
+		authenticator    *proxy.Authenticator
+		user             string
+		permissions      *ssh.Permissions
+		authErr          error
+	)
+
+	BeforeEach(func() {
+		codeStore = new(fake.CodeStore)
+		codeStore.RedeemReturns(tools.SSHCode{
+			User:      "bob",
+			Groups:    []string{"developers", "admins"},
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil)
+
+		instanceResolver = new(fake.InstanceResolver)
+		instanceResolver.ResolveReturns(proxy.Instance{
+			Namespace: "space-guid",
+			PodName:   "app-pod-1",
+		}, nil)
+
+		authenticator = proxy.NewAuthenticator(codeStore, instanceResolver, logr.Discard())
+		user = "cf:app-guid/1"
+	})
+
+	JustBeforeEach(func() {
+		permissions, authErr = authenticator.PasswordCallback(connMetadata{user: user}, []byte("the-code"))
+	})
+
+	It("authenticates the user", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+
+		Expect(codeStore.RedeemCallCount()).To(Equal(1))
+		_, actualCode := codeStore.RedeemArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+
+		Expect(instanceResolver.ResolveCallCount()).To(Equal(1))
+		_, actualAppGUID, actualIndex := instanceResolver.ResolveArgsForCall(0)
+		Expect(actualAppGUID).To(Equal("app-guid"))
+		Expect(actualIndex).To(Equal(1))
+
+		Expect(permissions.Extensions).To(Equal(map[string]string{
+			"korifi-user":          "bob",
+			"korifi-groups":        "developers\nadmins",
+			"korifi-pod-namespace": "space-guid",
+			"korifi-pod-name":      "app-pod-1",
+		}))
+	})
+
+	When("the user is invalid", func() {
+		BeforeEach(func() {
+			user = "app-guid"
+		})
+
+		It("fails without redeeming the code", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("cf:<app-guid>/<index>")))
+			Expect(codeStore.RedeemCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code cannot be redeemed", func() {
+		BeforeEach(func() {
+			codeStore.RedeemReturns(tools.SSHCode{}, errors.New("not-found"))
+		})
+
+		It("fails", func() {
+			Expect(authErr).To(MatchError("invalid code"))
+			Expect(instanceResolver.ResolveCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code is bound to the app", func() {
+		BeforeEach(func() {
+			codeStore.RedeemReturns(tools.SSHCode{User: "bob", AppGUID: "app-guid"}, nil)
+		})
+
+		It("authenticates the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the code is bound to another app", func() {
+		BeforeEach(func() {
+			codeStore.RedeemReturns(tools.SSHCode{User: "bob", AppGUID: "another-app-guid"}, nil)
+		})
+
+		It("fails", func() {
+			Expect(authErr).To(MatchError("invalid code"))
+			Expect(instanceResolver.ResolveCallCount()).To(BeZero())
+		})
+	})
+
+	When("the instance cannot be resolved", func() {
+		BeforeEach(func() {
+			instanceResolver.ResolveReturns(proxy.Instance{}, errors.New("ssh is disabled for the app"))
+		})
+
+		It("fails", func() {
+			Expect(authErr).To(MatchError("ssh is disabled for the app"))
+		})
+	})
+})
+
+var _ = DescribeTable("ParseUser",
+	func(user string, expectedAppGUID string, expectedIndex int, expectErr bool) {
+		appGUID, index, err := proxy.ParseUser(user)
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appGUID).To(Equal(expectedAppGUID))
+		Expect(index).To(Equal(expectedIndex))
+	},
+	Entry("valid", "cf:app-guid/0", "app-guid", 0, false),
+	Entry("valid with higher index", "cf:app-guid/12", "app-guid", 12, false),
+	Entry("missing prefix", "app-guid/0", "", 0, true),
+	Entry("missing index", "cf:app-guid", "", 0, true),
+	Entry("missing app guid", "cf:/0", "", 0, true),
+	Entry("non numeric index", "cf:app-guid/one", "", 0, true),
+	Entry("negative index", "cf:app-guid/-1", "", 0, true),
+)
+
+type connMetadata struct {
+	user string
+}
+
+func (m connMetadata) User() string          { return m.user }
+func (m connMetadata) SessionID() []byte     { return nil }
+func (m connMetadata) ClientVersion() []byte { return nil }
+func (m connMetadata) ServerVersion() []byte { return nil }
+func (m connMetadata) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}
+}
+func (m connMetadata) LocalAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SecretCodeStore redeems the ssh codes the API stores as secrets in the
+// namespace dedicated to them. Redeeming a code deletes its secret so that it
+// cannot be reused.
+type SecretCodeStore struct {
+	k8sClient client.Client
+	namespace string
+}
+
+func NewSecretCodeStore(k8sClient client.Client, namespace string) *SecretCodeStore {
+	return &SecretCodeStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+	}
+}
+
+func (s *SecretCodeStore) Redeem(ctx context.Context, code string) (tools.SSHCode, error) {
+	if code == "" {
+		return tools.SSHCode{}, errors.New("empty code")
+	}
+
+	secret := &corev1.Secret{}
+	err := s.k8sClient.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: tools.SSHCodeSecretName(code)}, secret)
+	if err != nil {
+		return tools.SSHCode{}, fmt.Errorf("failed to get code secret: %w", err)
+	}
+
+	err = s.k8sClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if err != nil {
+		return tools.SSHCode{}, fmt.Errorf("failed to delete code secret: %w", err)
+	}
+
+	sshCode, err := tools.FromSSHCodeData(secret.Data)
+	if err != nil {
+		return tools.SSHCode{}, err
+	}
+
+	if sshCode.IsExpired() {
+		return tools.SSHCode{}, errors.New("code has expired")
+	}
+
+	return sshCode, nil
+}
+
+// DeleteExpired removes the codes that have never been redeemed
+func (s *SecretCodeStore) DeleteExpired(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("delete-expired-codes")
+
+	secrets := &corev1.SecretList{}
+	err := s.k8sClient.List(ctx, secrets, client.InNamespace(s.namespace), client.HasLabels{tools.SSHCodeLabelKey})
+	if err != nil {
+		logger.Error(err, "failed to list code secrets")
+		return
+	}
+
+	for _, secret := range secrets.Items {
+		sshCode, err := tools.FromSSHCodeData(secret.Data)
+		if err == nil && !sshCode.IsExpired() {
+			continue
+		}
+
+		err = s.k8sClient.Delete(ctx, &secret)
+		if client.IgnoreNotFound(err) != nil && !k8serrors.IsConflict(err) {
+			logger.Error(err, "failed to delete code secret", "name", secret.Name)
+		}
+	}
+}
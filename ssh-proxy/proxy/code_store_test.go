@@ -0,0 +1,182 @@
+package proxy_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	k8sfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SecretCodeStore", func() {
+	var (
+		k8sClient *k8sfake.Client
+		codeStore *proxy.SecretCodeStore
+	)
+
+	BeforeEach(func() {
+		k8sClient = new(k8sfake.Client)
+		codeStore = proxy.NewSecretCodeStore(k8sClient, "ssh-codes-ns")
+	})
+
+	Describe("Redeem", func() {
+		var (
+			code      string
+			sshCode   tools.SSHCode
+			secret    *corev1.Secret
+			redeemed  tools.SSHCode
+			redeemErr error
+		)
+
+		BeforeEach(func() {
+			code = "the-code"
+			sshCode = tools.SSHCode{
+				User:      "bob",
+				Groups:    []string{"developers"},
+				AppGUID:   "app-guid",
+				ExpiresAt: time.Now().Add(time.Minute).Truncate(time.Second),
+			}
+
+			k8sClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ssh-codes-ns",
+						Name:      tools.SSHCodeSecretName(code),
+						UID:       "secret-uid",
+					},
+					Data: sshCode.ToData(),
+				}
+				secret.DeepCopyInto(obj.(*corev1.Secret))
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			redeemed, redeemErr = codeStore.Redeem(ctx, code)
+		})
+
+		It("returns the code", func() {
+			Expect(redeemErr).NotTo(HaveOccurred())
+			Expect(redeemed.User).To(Equal("bob"))
+			Expect(redeemed.Groups).To(Equal([]string{"developers"}))
+			Expect(redeemed.AppGUID).To(Equal("app-guid"))
+			Expect(redeemed.ExpiresAt).To(BeTemporally("==", sshCode.ExpiresAt))
+
+			Expect(k8sClient.GetCallCount()).To(Equal(1))
+			_, actualKey, _, _ := k8sClient.GetArgsForCall(0)
+			Expect(actualKey).To(Equal(types.NamespacedName{Namespace: "ssh-codes-ns", Name: tools.SSHCodeSecretName("the-code")}))
+		})
+
+		It("deletes the code secret so that the code cannot be reused", func() {
+			Expect(k8sClient.DeleteCallCount()).To(Equal(1))
+			_, actualObj, actualOpts := k8sClient.DeleteArgsForCall(0)
+			Expect(actualObj.GetName()).To(Equal(tools.SSHCodeSecretName("the-code")))
+			Expect(actualOpts).To(Equal([]client.DeleteOption{client.Preconditions{UID: &secret.UID}}))
+		})
+
+		When("the code is empty", func() {
+			BeforeEach(func() {
+				code = ""
+			})
+
+			It("fails without looking it up", func() {
+				Expect(redeemErr).To(MatchError("empty code"))
+				Expect(k8sClient.GetCallCount()).To(BeZero())
+			})
+		})
+
+		When("the code does not exist", func() {
+			BeforeEach(func() {
+				k8sClient.GetStub = nil
+				k8sClient.GetReturns(errors.New("not-found"))
+			})
+
+			It("fails", func() {
+				Expect(redeemErr).To(MatchError(ContainSubstring("not-found")))
+				Expect(k8sClient.DeleteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the code has been redeemed concurrently", func() {
+			BeforeEach(func() {
+				k8sClient.DeleteReturns(errors.New("conflict"))
+			})
+
+			It("fails", func() {
+				Expect(redeemErr).To(MatchError(ContainSubstring("conflict")))
+			})
+		})
+
+		When("the code has expired", func() {
+			BeforeEach(func() {
+				sshCode.ExpiresAt = time.Now().Add(-time.Minute)
+			})
+
+			It("fails and still deletes it", func() {
+				Expect(redeemErr).To(MatchError("code has expired"))
+				Expect(k8sClient.DeleteCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("DeleteExpired", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.SecretList).Items = []corev1.Secret{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+						Data:       tools.SSHCode{User: "bob", ExpiresAt: time.Now().Add(time.Minute)}.ToData(),
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "expired"},
+						Data:       tools.SSHCode{User: "bob", ExpiresAt: time.Now().Add(-time.Minute)}.ToData(),
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "corrupt"},
+					},
+				}
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			codeStore.DeleteExpired(ctx)
+		})
+
+		It("lists the code secrets in the root namespace", func() {
+			Expect(k8sClient.ListCallCount()).To(Equal(1))
+			_, _, actualOpts := k8sClient.ListArgsForCall(0)
+			Expect(actualOpts).To(Equal([]client.ListOption{
+				client.InNamespace("ssh-codes-ns"),
+				client.HasLabels{tools.SSHCodeLabelKey},
+			}))
+		})
+
+		It("deletes the expired and corrupt codes", func() {
+			Expect(k8sClient.DeleteCallCount()).To(Equal(2))
+			_, firstDeleted, _ := k8sClient.DeleteArgsForCall(0)
+			_, secondDeleted, _ := k8sClient.DeleteArgsForCall(1)
+			Expect([]string{firstDeleted.GetName(), secondDeleted.GetName()}).To(ConsistOf("expired", "corrupt"))
+		})
+
+		When("listing the secrets fails", func() {
+			BeforeEach(func() {
+				k8sClient.ListStub = nil
+				k8sClient.ListReturns(errors.New("list-error"))
+			})
+
+			It("does not delete anything", func() {
+				Expect(k8sClient.DeleteCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+type directTCPIPRequest struct {
+	Host       string
+	Port       uint32
+	OriginHost string
+	OriginPort uint32
+}
+
+// handleDirectTCPIP forwards the channel to a port of the pod network
+// namespace, regardless of the requested host, like cf ssh -L does on
+// Diego
+func handleDirectTCPIP(ctx context.Context, connector PodConnector, target PodTarget, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("direct-tcpip")
+
+	var req directTCPIPRequest
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &req); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid direct-tcpip request")
+		return
+	}
+	if req.Port == 0 || req.Port > 65535 {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid port")
+		return
+	}
+	logger = logger.WithValues("port", req.Port)
+
+	portStream, err := connector.PortForward(logr.NewContext(ctx, logger), target, int(req.Port))
+	if err != nil {
+		logger.Info("failed to forward port", "reason", err.Error())
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer portStream.Close()
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Error(err, "failed to accept direct-tcpip channel")
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(portStream, channel)
+		_ = portStream.CloseWrite()
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(channel, portStream)
+		_ = channel.CloseWrite()
+	}()
+	wg.Wait()
+}
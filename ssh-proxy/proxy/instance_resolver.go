@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodInstanceResolver maps app instances to the pods of the web process of
+// the app, provided that ssh is enabled for both the app and its space
+type PodInstanceResolver struct {
+	k8sClient client.Client
+}
+
+func NewPodInstanceResolver(k8sClient client.Client) *PodInstanceResolver {
+	return &PodInstanceResolver{
+		k8sClient: k8sClient,
+	}
+}
+
+func (r *PodInstanceResolver) Resolve(ctx context.Context, appGUID string, index int) (Instance, error) {
+	pods := &corev1.PodList{}
+	err := r.k8sClient.List(ctx, pods, client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+		korifiv1alpha1.CFProcessTypeLabelKey: korifiv1alpha1.ProcessTypeWeb,
+		korifiv1alpha1.PodIndexLabelKey:      strconv.Itoa(index),
+	})
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to list app pods: %w", err)
+	}
+
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning && pods.Items[i].DeletionTimestamp == nil {
+			pod = &pods.Items[i]
+			break
+		}
+	}
+	if pod == nil {
+		return Instance{}, fmt.Errorf("instance %d of app %s is not running", index, appGUID)
+	}
+
+	if err = r.checkSSHEnabled(ctx, pod.Namespace, appGUID); err != nil {
+		return Instance{}, err
+	}
+
+	return Instance{
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+	}, nil
+}
+
+func (r *PodInstanceResolver) checkSSHEnabled(ctx context.Context, spaceGUID, appGUID string) error {
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: appGUID}, cfApp)
+	if err != nil {
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+	if cfApp.Spec.DisableSSH {
+		return errors.New("ssh is disabled for the app")
+	}
+
+	cfSpaces := &korifiv1alpha1.CFSpaceList{}
+	err = r.k8sClient.List(ctx, cfSpaces, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector("metadata.name", spaceGUID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list spaces: %w", err)
+	}
+	if len(cfSpaces.Items) != 1 {
+		return fmt.Errorf("space %s not found", spaceGUID)
+	}
+	if cfSpaces.Items[0].Spec.DisableSSH {
+		return errors.New("ssh is disabled for the space")
+	}
+
+	return nil
+}
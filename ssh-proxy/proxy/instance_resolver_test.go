@@ -0,0 +1,170 @@
+package proxy_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	k8sfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("PodInstanceResolver", func() {
+	var (
+		k8sClient   *k8sfake.Client
+		resolver    *proxy.PodInstanceResolver
+		pods        []corev1.Pod
+		cfApp       *korifiv1alpha1.CFApp
+		cfSpaces    []korifiv1alpha1.CFSpace
+		instance    proxy.Instance
+		resolveErr  error
+		runningPod  corev1.Pod
+		deletingPod corev1.Pod
+	)
+
+	BeforeEach(func() {
+		k8sClient = new(k8sfake.Client)
+		resolver = proxy.NewPodInstanceResolver(k8sClient)
+
+		deletingPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "space-guid",
+				Name:              "old-pod",
+				DeletionTimestamp: &metav1.Time{},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		runningPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "space-guid",
+				Name:      "app-pod",
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		pods = []corev1.Pod{deletingPod, runningPod}
+
+		cfApp = &korifiv1alpha1.CFApp{}
+		cfSpaces = []korifiv1alpha1.CFSpace{{
+			ObjectMeta: metav1.ObjectMeta{Name: "space-guid"},
+		}}
+
+		k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *corev1.PodList:
+				list.Items = pods
+			case *korifiv1alpha1.CFSpaceList:
+				list.Items = cfSpaces
+			}
+			return nil
+		}
+		k8sClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			cfApp.DeepCopyInto(obj.(*korifiv1alpha1.CFApp))
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		instance, resolveErr = resolver.Resolve(ctx, "app-guid", 2)
+	})
+
+	It("resolves the running pod of the instance", func() {
+		Expect(resolveErr).NotTo(HaveOccurred())
+		Expect(instance).To(Equal(proxy.Instance{
+			Namespace: "space-guid",
+			PodName:   "app-pod",
+		}))
+	})
+
+	It("lists the pods of the web process instance", func() {
+		_, _, actualOpts := k8sClient.ListArgsForCall(0)
+		Expect(actualOpts).To(Equal([]client.ListOption{client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey:     "app-guid",
+			korifiv1alpha1.CFProcessTypeLabelKey: korifiv1alpha1.ProcessTypeWeb,
+			korifiv1alpha1.PodIndexLabelKey:      "2",
+		}}))
+	})
+
+	It("checks that ssh is enabled for the app and its space", func() {
+		Expect(k8sClient.GetCallCount()).To(Equal(1))
+		_, actualKey, _, _ := k8sClient.GetArgsForCall(0)
+		Expect(actualKey).To(Equal(types.NamespacedName{Namespace: "space-guid", Name: "app-guid"}))
+
+		Expect(k8sClient.ListCallCount()).To(Equal(2))
+		_, _, actualOpts := k8sClient.ListArgsForCall(1)
+		Expect(actualOpts).To(Equal([]client.ListOption{client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector("metadata.name", "space-guid"),
+		}}))
+	})
+
+	When("listing the pods fails", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = nil
+			k8sClient.ListReturns(errors.New("list-error"))
+		})
+
+		It("returns the error", func() {
+			Expect(resolveErr).To(MatchError(ContainSubstring("list-error")))
+		})
+	})
+
+	When("the instance is not running", func() {
+		BeforeEach(func() {
+			runningPod.Status.Phase = corev1.PodPending
+			pods = []corev1.Pod{deletingPod, runningPod}
+		})
+
+		It("fails", func() {
+			Expect(resolveErr).To(MatchError("instance 2 of app app-guid is not running"))
+			Expect(k8sClient.GetCallCount()).To(BeZero())
+		})
+	})
+
+	When("the app cannot be found", func() {
+		BeforeEach(func() {
+			k8sClient.GetStub = nil
+			k8sClient.GetReturns(errors.New("not-found"))
+		})
+
+		It("fails", func() {
+			Expect(resolveErr).To(MatchError(ContainSubstring("not-found")))
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			cfApp.Spec.DisableSSH = true
+		})
+
+		It("fails", func() {
+			Expect(resolveErr).To(MatchError("ssh is disabled for the app"))
+		})
+	})
+
+	When("the space cannot be found", func() {
+		BeforeEach(func() {
+			cfSpaces = nil
+		})
+
+		It("fails", func() {
+			Expect(resolveErr).To(MatchError("space space-guid not found"))
+		})
+	})
+
+	When("ssh is disabled for the space", func() {
+		BeforeEach(func() {
+			cfSpaces[0].Spec.DisableSSH = true
+		})
+
+		It("fails", func() {
+			Expect(resolveErr).To(MatchError("ssh is disabled for the space"))
+		})
+	})
+})
@@ -0,0 +1,186 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// K8sPodConnector execs into and forwards ports of the application container
+// via the exec and portforward subresources of the pod. The proxy connects
+// with its own service account, which is only bound to the exec and
+// portforward subresources in the org and space namespaces, once a subject
+// access review has confirmed that the user the ssh code was issued to is
+// allowed to connect to the pod.
+type K8sPodConnector struct {
+	restConfig *rest.Config
+}
+
+func NewK8sPodConnector(restConfig *rest.Config) *K8sPodConnector {
+	return &K8sPodConnector{
+		restConfig: restConfig,
+	}
+}
+
+func (c *K8sPodConnector) Exec(ctx context.Context, target PodTarget, command []string, streams remotecommand.StreamOptions) error {
+	clientset, err := kubernetes.NewForConfig(c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	if err = authorize(ctx, clientset, target, "exec"); err != nil {
+		return err
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.PodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: ApplicationContainerName,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    streams.Stderr != nil,
+			TTY:       streams.Tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.StreamWithContext(ctx, streams)
+}
+
+// PortForward connects to a port of the pod network namespace. Errors
+// reported by the kubelet, e.g. because nothing listens on the port, close
+// the connection.
+func (c *K8sPodConnector) PortForward(ctx context.Context, target PodTarget, port int) (PortStream, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	streamConn, err := c.dialPortForward(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, err
+	}
+	// the error stream is read only
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, err
+	}
+
+	go func() {
+		message, readErr := io.ReadAll(errorStream)
+		if readErr == nil && len(message) > 0 {
+			logger.Info("port forward failed", "reason", string(message))
+			streamConn.Close()
+		}
+	}()
+
+	return &portForwardStream{
+		Stream:     dataStream,
+		streamConn: streamConn,
+	}, nil
+}
+
+func (c *K8sPodConnector) dialPortForward(ctx context.Context, target PodTarget) (httpstream.Connection, error) {
+	clientset, err := kubernetes.NewForConfig(c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorize(ctx, clientset, target, "portforward"); err != nil {
+		return nil, err
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.PodName).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade connection: %w", err)
+	}
+
+	return streamConn, nil
+}
+
+type portForwardStream struct {
+	httpstream.Stream
+	streamConn httpstream.Connection
+}
+
+// CloseWrite half-closes the data stream, so that the pod still gets to
+// respond
+func (s *portForwardStream) CloseWrite() error {
+	return s.Stream.Close()
+}
+
+func (s *portForwardStream) Close() error {
+	return s.streamConn.Close()
+}
+
+// authorize makes sure that exec and port forward requests are authorized
+// against the roles of the user the ssh code was issued to, including the
+// roles bound to the groups of the user
+func authorize(ctx context.Context, clientset kubernetes.Interface, target PodTarget, subresource string) error {
+	review, err := clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   target.User,
+			Groups: target.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   target.Namespace,
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: subresource,
+				Name:        target.PodName,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to review access to pod %s: %w", subresource, err)
+	}
+
+	if !review.Status.Allowed {
+		return fmt.Errorf("user %q is not allowed to %s pod %s/%s", target.User, subresource, target.Namespace, target.PodName)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package proxy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+var _ = Describe("K8sPodConnector", func() {
+	var (
+		allowed   bool
+		lock      sync.Mutex
+		reviews   []authorizationv1.SubjectAccessReviewSpec
+		requests  []*http.Request
+		apiServer *httptest.Server
+		connector *proxy.K8sPodConnector
+		target    proxy.PodTarget
+	)
+
+	BeforeEach(func() {
+		allowed = false
+		reviews = nil
+		requests = nil
+
+		apiServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			lock.Lock()
+			defer lock.Unlock()
+
+			if r.URL.Path != "/apis/authorization.k8s.io/v1/subjectaccessreviews" {
+				requests = append(requests, r)
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			review := authorizationv1.SubjectAccessReview{}
+			Expect(json.NewDecoder(r.Body).Decode(&review)).To(Succeed())
+			reviews = append(reviews, review.Spec)
+
+			review.Status.Allowed = allowed
+			w.Header().Set("Content-Type", "application/json")
+			Expect(json.NewEncoder(w).Encode(review)).To(Succeed())
+		}))
+		DeferCleanup(apiServer.Close)
+
+		connector = proxy.NewK8sPodConnector(&rest.Config{
+			Host:          apiServer.URL,
+			ContentConfig: rest.ContentConfig{ContentType: "application/json"},
+		})
+		target = proxy.PodTarget{
+			Namespace: "space-guid",
+			PodName:   "app-pod-0",
+			User:      "alice",
+			Groups:    []string{"developers"},
+		}
+	})
+
+	Describe("Exec", func() {
+		var execErr error
+
+		JustBeforeEach(func() {
+			execErr = connector.Exec(context.Background(), target, []string{"/bin/bash"}, remotecommand.StreamOptions{})
+		})
+
+		It("reviews the access of the code owner to the pod exec subresource", func() {
+			Expect(reviews).To(ConsistOf(authorizationv1.SubjectAccessReviewSpec{
+				User:   "alice",
+				Groups: []string{"developers"},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   "space-guid",
+					Verb:        "create",
+					Resource:    "pods",
+					Subresource: "exec",
+					Name:        "app-pod-0",
+				},
+			}))
+		})
+
+		It("does not connect to pods the code owner cannot exec into", func() {
+			Expect(execErr).To(MatchError(ContainSubstring(`user "alice" is not allowed to exec pod space-guid/app-pod-0`)))
+			Expect(requests).To(BeEmpty())
+		})
+
+		When("the code owner is allowed to exec into the pod", func() {
+			BeforeEach(func() {
+				allowed = true
+			})
+
+			It("execs into the pod without impersonating the code owner", func() {
+				Expect(requests).NotTo(BeEmpty())
+				Expect(requests[0].URL.Path).To(Equal("/api/v1/namespaces/space-guid/pods/app-pod-0/exec"))
+				Expect(requests[0].Header).NotTo(HaveKey("Impersonate-User"))
+			})
+		})
+	})
+
+	Describe("PortForward", func() {
+		var forwardErr error
+
+		JustBeforeEach(func() {
+			_, forwardErr = connector.PortForward(context.Background(), target, 8080)
+		})
+
+		It("does not forward ports of pods the code owner cannot port forward to", func() {
+			Expect(forwardErr).To(MatchError(ContainSubstring(`user "alice" is not allowed to portforward pod space-guid/app-pod-0`)))
+			Expect(reviews).To(ConsistOf(HaveField("ResourceAttributes.Subresource", "portforward")))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const ApplicationContainerName = "application"
+
+//counterfeiter:generate -o fake -fake-name PodConnector . PodConnector
+
+type PodConnector interface {
+	Exec(ctx context.Context, target PodTarget, command []string, streams remotecommand.StreamOptions) error
+	PortForward(ctx context.Context, target PodTarget, port int) (PortStream, error)
+}
+
+// PortStream is a connection to a port of the pod network namespace
+type PortStream interface {
+	io.ReadWriteCloser
+	CloseWrite() error
+}
+
+// PodTarget is the pod the ssh user has been authenticated for
+type PodTarget struct {
+	Namespace string
+	PodName   string
+	User      string
+	Groups    []string
+}
+
+type Server struct {
+	sshConfig *ssh.ServerConfig
+	connector PodConnector
+	logger    logr.Logger
+}
+
+func NewServer(hostKey ssh.Signer, authenticator *Authenticator, connector PodConnector, logger logr.Logger) *Server {
+	sshConfig := &ssh.ServerConfig{
+		PasswordCallback: authenticator.PasswordCallback,
+	}
+	sshConfig.AddHostKey(hostKey)
+
+	return &Server{
+		sshConfig: sshConfig,
+		connector: connector,
+		logger:    logger,
+	}
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+func (s *Server) handleConn(ctx context.Context, netConn net.Conn) {
+	defer netConn.Close()
+
+	conn, channels, requests, err := ssh.NewServerConn(netConn, s.sshConfig)
+	if err != nil {
+		s.logger.V(1).Info("handshake failed", "remoteAddr", netConn.RemoteAddr().String(), "reason", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	go ssh.DiscardRequests(requests)
+
+	target := PodTarget{
+		Namespace: conn.Permissions.Extensions[podNamespaceExtension],
+		PodName:   conn.Permissions.Extensions[podNameExtension],
+		User:      conn.Permissions.Extensions[userExtension],
+	}
+	if groups := conn.Permissions.Extensions[groupsExtension]; groups != "" {
+		target.Groups = strings.Split(groups, "\n")
+	}
+	logger := s.logger.WithValues("user", conn.User(), "namespace", target.Namespace, "pod", target.PodName)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go handleSession(logr.NewContext(ctx, logger), s.connector, target, newChannel)
+		case "direct-tcpip":
+			go handleDirectTCPIP(logr.NewContext(ctx, logger), s.connector, target, newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package proxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy/fake"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		connector    *fake.PodConnector
+		serverCtx    context.Context
+		cancelServer context.CancelFunc
+		serveErr     chan error
+		clientConfig *ssh.ClientConfig
+		address      string
+		sshClient    *ssh.Client
+		dialErr      error
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err := ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		codeStore := new(fake.CodeStore)
+		codeStore.RedeemStub = func(_ context.Context, code string) (tools.SSHCode, error) {
+			if code != "the-code" {
+				return tools.SSHCode{}, errors.New("not-found")
+			}
+			return tools.SSHCode{
+				User:      "bob",
+				Groups:    []string{"developers", "admins"},
+				ExpiresAt: time.Now().Add(time.Minute),
+			}, nil
+		}
+
+		instanceResolver := new(fake.InstanceResolver)
+		instanceResolver.ResolveReturns(proxy.Instance{
+			Namespace: "space-guid",
+			PodName:   "app-pod-0",
+		}, nil)
+
+		connector = new(fake.PodConnector)
+
+		server := proxy.NewServer(
+			hostKey,
+			proxy.NewAuthenticator(codeStore, instanceResolver, logr.Discard()),
+			connector,
+			logr.Discard(),
+		)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		address = listener.Addr().String()
+
+		serverCtx, cancelServer = context.WithCancel(ctx)
+		serveErr = make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			serveErr <- server.Serve(serverCtx, listener)
+		}()
+
+		clientConfig = &ssh.ClientConfig{
+			User:            "cf:app-guid/0",
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		}
+	})
+
+	JustBeforeEach(func() {
+		sshClient, dialErr = ssh.Dial("tcp", address, clientConfig)
+	})
+
+	AfterEach(func() {
+		if sshClient != nil {
+			sshClient.Close()
+		}
+		cancelServer()
+		Eventually(serveErr).Should(Receive(BeNil()))
+	})
+
+	Describe("sessions", func() {
+		var session *ssh.Session
+
+		JustBeforeEach(func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			var err error
+			session, err = sshClient.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			session.Close()
+		})
+
+		It("runs the command in the application container as the ssh code owner", func() {
+			connector.ExecStub = func(_ context.Context, _ proxy.PodTarget, _ []string, streams remotecommand.StreamOptions) error {
+				_, err := io.WriteString(streams.Stdout, "hello")
+				return err
+			}
+
+			output, err := session.Output("echo hello")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("hello"))
+
+			Expect(connector.ExecCallCount()).To(Equal(1))
+			_, actualTarget, actualCommand, actualStreams := connector.ExecArgsForCall(0)
+			Expect(actualTarget).To(Equal(proxy.PodTarget{
+				Namespace: "space-guid",
+				PodName:   "app-pod-0",
+				User:      "bob",
+				Groups:    []string{"developers", "admins"},
+			}))
+			Expect(actualCommand).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+			Expect(actualStreams.Tty).To(BeFalse())
+			Expect(actualStreams.Stdin).NotTo(BeNil())
+			Expect(actualStreams.Stderr).NotTo(BeNil())
+			Expect(actualStreams.TerminalSizeQueue).To(BeNil())
+		})
+
+		It("exports the requested environment to the command", func() {
+			Expect(session.Setenv("FOO", "it's")).To(Succeed())
+			Expect(session.Setenv("1INVALID", "bar")).To(Succeed())
+
+			Expect(session.Run("env")).To(Succeed())
+			_, _, actualCommand, _ := connector.ExecArgsForCall(0)
+			Expect(actualCommand).To(Equal([]string{"/bin/sh", "-c", `export FOO='it'"'"'s'; env`}))
+		})
+
+		It("runs a login shell when no command is given", func() {
+			Expect(session.Shell()).To(Succeed())
+			Expect(session.Wait()).To(Succeed())
+
+			_, _, actualCommand, _ := connector.ExecArgsForCall(0)
+			Expect(actualCommand[2]).To(ContainSubstring("exec bash -l"))
+		})
+
+		When("the command exits with a non-zero status", func() {
+			BeforeEach(func() {
+				connector.ExecReturns(exec.CodeExitError{Err: errors.New("exited"), Code: 3})
+			})
+
+			It("returns the exit status of the command", func() {
+				err := session.Run("false")
+				var exitErr *ssh.ExitError
+				Expect(errors.As(err, &exitErr)).To(BeTrue())
+				Expect(exitErr.ExitStatus()).To(Equal(3))
+			})
+		})
+
+		When("the command cannot be run", func() {
+			BeforeEach(func() {
+				connector.ExecReturns(errors.New("forbidden"))
+			})
+
+			It("reports the error and exits with status 1", func() {
+				output, err := session.CombinedOutput("true")
+				var exitErr *ssh.ExitError
+				Expect(errors.As(err, &exitErr)).To(BeTrue())
+				Expect(exitErr.ExitStatus()).To(Equal(1))
+				Expect(string(output)).To(Equal("forbidden\r\n"))
+			})
+		})
+
+		When("a pty is requested", func() {
+			var sizes chan *remotecommand.TerminalSize
+
+			BeforeEach(func() {
+				terminalSizes := make(chan *remotecommand.TerminalSize, 10)
+				sizes = terminalSizes
+
+				connector.ExecStub = func(_ context.Context, _ proxy.PodTarget, _ []string, streams remotecommand.StreamOptions) error {
+					// the size queue is closed when the session ends
+					for size := streams.TerminalSizeQueue.Next(); size != nil; size = streams.TerminalSizeQueue.Next() {
+						terminalSizes <- size
+					}
+					return nil
+				}
+			})
+
+			JustBeforeEach(func() {
+				Expect(session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})).To(Succeed())
+				Expect(session.Start("top")).To(Succeed())
+			})
+
+			It("runs the command on a terminal of the requested size", func() {
+				Eventually(sizes).Should(Receive(Equal(&remotecommand.TerminalSize{Width: 80, Height: 24})))
+
+				_, _, actualCommand, actualStreams := connector.ExecArgsForCall(0)
+				Expect(actualCommand).To(Equal([]string{"/bin/sh", "-c", "export TERM='xterm'; top"}))
+				Expect(actualStreams.Tty).To(BeTrue())
+				Expect(actualStreams.Stderr).To(BeNil())
+			})
+
+			It("resizes the terminal when the window changes", func() {
+				Eventually(sizes).Should(Receive(Equal(&remotecommand.TerminalSize{Width: 80, Height: 24})))
+				Expect(session.WindowChange(40, 120)).To(Succeed())
+				Eventually(sizes).Should(Receive(Equal(&remotecommand.TerminalSize{Width: 120, Height: 40})))
+			})
+		})
+	})
+
+	Describe("port forwarding", func() {
+		BeforeEach(func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(listener.Close)
+
+			connector.PortForwardStub = func(context.Context, proxy.PodTarget, int) (proxy.PortStream, error) {
+				proxyConn, err := net.Dial("tcp", listener.Addr().String())
+				if err != nil {
+					return nil, err
+				}
+				return proxyConn.(*net.TCPConn), nil
+			}
+
+			go func() {
+				defer GinkgoRecover()
+
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				// an echo server that answers once the client is done writing
+				request, err := io.ReadAll(conn)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = fmt.Fprintf(conn, "echo: %s", request)
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		})
+
+		JustBeforeEach(func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+		})
+
+		It("forwards the connection to the port of the pod", func() {
+			conn, err := sshClient.Dial("tcp", "localhost:8080")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = io.WriteString(conn, "ping")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conn.(interface{ CloseWrite() error }).CloseWrite()).To(Succeed())
+
+			response, err := io.ReadAll(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("echo: ping"))
+
+			Expect(connector.PortForwardCallCount()).To(Equal(1))
+			_, actualTarget, actualPort := connector.PortForwardArgsForCall(0)
+			Expect(actualTarget.PodName).To(Equal("app-pod-0"))
+			Expect(actualTarget.User).To(Equal("bob"))
+			Expect(actualPort).To(Equal(8080))
+		})
+
+		When("the port cannot be forwarded", func() {
+			BeforeEach(func() {
+				connector.PortForwardStub = nil
+				connector.PortForwardReturns(nil, errors.New("forbidden"))
+			})
+
+			It("rejects the channel", func() {
+				_, err := sshClient.Dial("tcp", "localhost:8080")
+				Expect(err).To(MatchError(ContainSubstring("forbidden")))
+			})
+		})
+	})
+
+	It("rejects unsupported channel types", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		_, _, err := sshClient.OpenChannel("x11", nil)
+		var openErr *ssh.OpenChannelError
+		Expect(errors.As(err, &openErr)).To(BeTrue())
+		Expect(openErr.Reason).To(Equal(ssh.UnknownChannelType))
+	})
+
+	When("the code is invalid", func() {
+		BeforeEach(func() {
+			clientConfig.Auth = []ssh.AuthMethod{ssh.Password("another-code")}
+		})
+
+		It("refuses the connection", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(connector.ExecCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,258 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const defaultShell = "if command -v bash >/dev/null; then exec bash -l; else exec sh -l; fi"
+
+type ptyRequest struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	Width    uint32
+	Height   uint32
+	Modelist string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type envRequest struct {
+	Name  string
+	Value string
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+type session struct {
+	connector PodConnector
+	target    PodTarget
+	channel   ssh.Channel
+	env       map[string]string
+	tty       bool
+	sizes     *sizeQueue
+	started   bool
+}
+
+func handleSession(ctx context.Context, connector PodConnector, target PodTarget, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("session")
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Error(err, "failed to accept session channel")
+		return
+	}
+	defer channel.Close()
+
+	s := &session{
+		connector: connector,
+		target:    target,
+		channel:   channel,
+		env:       map[string]string{},
+		sizes:     newSizeQueue(),
+	}
+	defer s.sizes.close()
+
+	done := make(chan struct{})
+	for {
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				return
+			}
+			s.handleRequest(logr.NewContext(ctx, logger), req, done)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (s *session) handleRequest(ctx context.Context, req *ssh.Request, done chan struct{}) {
+	switch req.Type {
+	case "env":
+		var env envRequest
+		if err := ssh.Unmarshal(req.Payload, &env); err != nil {
+			_ = req.Reply(false, nil)
+			return
+		}
+		s.env[env.Name] = env.Value
+		_ = req.Reply(true, nil)
+	case "pty-req":
+		var pty ptyRequest
+		if err := ssh.Unmarshal(req.Payload, &pty); err != nil {
+			_ = req.Reply(false, nil)
+			return
+		}
+		s.tty = true
+		s.env["TERM"] = pty.Term
+		s.sizes.push(pty.Columns, pty.Rows)
+		_ = req.Reply(true, nil)
+	case "window-change":
+		var windowChange windowChangeRequest
+		if err := ssh.Unmarshal(req.Payload, &windowChange); err == nil {
+			s.sizes.push(windowChange.Columns, windowChange.Rows)
+		}
+	case "shell", "exec":
+		if s.started {
+			_ = req.Reply(false, nil)
+			return
+		}
+
+		command := defaultShell
+		if req.Type == "exec" {
+			var execReq execRequest
+			if err := ssh.Unmarshal(req.Payload, &execReq); err != nil {
+				_ = req.Reply(false, nil)
+				return
+			}
+			command = execReq.Command
+		}
+
+		s.started = true
+		_ = req.Reply(true, nil)
+
+		go func() {
+			defer close(done)
+			s.run(ctx, command)
+		}()
+	default:
+		if req.WantReply {
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *session) run(ctx context.Context, command string) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	err := s.exec(ctx, command)
+
+	status := uint32(0)
+	if err != nil {
+		status = 1
+
+		var exitErr exec.CodeExitError
+		if errors.As(err, &exitErr) {
+			status = uint32(exitErr.Code)
+		} else {
+			logger.Info("exec failed", "reason", err.Error())
+			fmt.Fprintf(s.channel.Stderr(), "%s\r\n", err.Error())
+		}
+	}
+
+	_, _ = s.channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: status}))
+}
+
+func (s *session) exec(ctx context.Context, command string) error {
+	streams := remotecommand.StreamOptions{
+		Stdin:  s.channel,
+		Stdout: s.channel,
+		Tty:    s.tty,
+	}
+	if s.tty {
+		streams.TerminalSizeQueue = s.sizes
+	} else {
+		streams.Stderr = s.channel.Stderr()
+	}
+
+	return s.connector.Exec(ctx, s.target, []string{"/bin/sh", "-c", withEnv(s.env, command)}, streams)
+}
+
+func withEnv(env map[string]string, command string) string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		if isValidEnvName(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var script strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&script, "export %s=%s; ", name, shellQuote(env[name]))
+	}
+	script.WriteString(command)
+
+	return script.String()
+}
+
+func isValidEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+type sizeQueue struct {
+	sizes     chan remotecommand.TerminalSize
+	closeOnce sync.Once
+}
+
+func newSizeQueue() *sizeQueue {
+	return &sizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+	}
+}
+
+func (q *sizeQueue) push(columns, rows uint32) {
+	size := remotecommand.TerminalSize{Width: uint16(columns), Height: uint16(rows)}
+
+	// only the most recent size matters, so drop any pending one
+	select {
+	case <-q.sizes:
+	default:
+	}
+
+	select {
+	case q.sizes <- size:
+	default:
+	}
+}
+
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+func (q *sizeQueue) close() {
+	q.closeOnce.Do(func() {
+		close(q.sizes)
+	})
+}
@@ -19,6 +19,7 @@ package appworkload
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
@@ -32,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -60,6 +62,15 @@ const (
 	LabelAppWorkloadGUID = "korifi.cloudfoundry.org/appworkload-guid"
 	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
 
+	AnnotationCrashInstanceIndex = "korifi.cloudfoundry.org/instance-index"
+	AnnotationCrashReason        = "korifi.cloudfoundry.org/crash-reason"
+	AnnotationCrashExitStatus    = "korifi.cloudfoundry.org/exit-status"
+
+	// CrashEventReason is the reason of the events recorded when an
+	// instance crashes, the equivalent of the CF audit.app.process.crash
+	// audit event
+	CrashEventReason = "Crashed"
+
 	ApplicationContainerName = "application"
 	ServiceAccountName       = "korifi-app"
 
@@ -68,6 +79,7 @@ const (
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate -o ./fake -fake-name EventRecorder k8s.io/client-go/tools/record.EventRecorder
 //counterfeiter:generate -o ./fake -fake-name PDB . PDB
 type PDB interface {
 	Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error
@@ -86,6 +98,7 @@ type AppWorkloadReconciler struct {
 	pdb              PDB
 	log              logr.Logger
 	stateCollector   *state.AppWorkloadStateCollector
+	recorder         record.EventRecorder
 }
 
 func NewAppWorkloadReconciler(
@@ -95,6 +108,7 @@ func NewAppWorkloadReconciler(
 	pdb PDB,
 	log logr.Logger,
 	stateCollector *state.AppWorkloadStateCollector,
+	recorder record.EventRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload] {
 	appWorkloadReconciler := AppWorkloadReconciler{
 		k8sClient:        c,
@@ -103,6 +117,7 @@ func NewAppWorkloadReconciler(
 		pdb:              pdb,
 		log:              log,
 		stateCollector:   stateCollector,
+		recorder:         recorder,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.AppWorkload](log, c, &appWorkloadReconciler)
 }
@@ -156,6 +171,8 @@ func filterAppWorkloads(object client.Object) bool {
 
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;patch;deletecollection
 
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
 func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -198,16 +215,45 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 
 	appWorkload.Status.ActualInstances = createdStSet.Status.ReadyReplicas
 
-	instancesState, err := r.stateCollector.CollectState(ctx, appWorkload.Spec.GUID)
+	instancesState, err := r.stateCollector.CollectState(ctx, appWorkload.Spec.GUID, appWorkload.Status.InstancesStatus)
 	if err != nil {
 		log.Info("error when collecting instances state", "reason", err)
 		return ctrl.Result{}, err
 	}
+	r.recordCrashes(appWorkload, appWorkload.Status.InstancesStatus, instancesState)
 	appWorkload.Status.InstancesStatus = instancesState
 
 	return ctrl.Result{}, nil
 }
 
+// recordCrashes records an event for every instance that crashed since the
+// instances state has last been collected
+func (r *AppWorkloadReconciler) recordCrashes(appWorkload *korifiv1alpha1.AppWorkload, previousState, currentState map[string]korifiv1alpha1.InstanceStatus) {
+	for index, current := range currentState {
+		if current.LastCrash == nil {
+			continue
+		}
+
+		previous, ok := previousState[index]
+		if ok && previous.LastCrash != nil && !previous.LastCrash.Timestamp.Before(&current.LastCrash.Timestamp) {
+			continue
+		}
+
+		r.recorder.AnnotatedEventf(appWorkload, map[string]string{
+			LabelAppGUID:                 appWorkload.Spec.AppGUID,
+			AnnotationProcessGUID:        appWorkload.Spec.GUID,
+			AnnotationCrashInstanceIndex: index,
+			AnnotationCrashReason:        current.LastCrash.Reason,
+			AnnotationCrashExitStatus:    strconv.Itoa(int(current.LastCrash.ExitStatus)),
+		},
+			corev1.EventTypeWarning,
+			CrashEventReason,
+			"Instance %s of process %s crashed (%s): %s",
+			index, appWorkload.Spec.ProcessType, current.LastCrash.Reason, current.LastCrash.ExitDescription,
+		)
+	}
+}
+
 func (r *AppWorkloadReconciler) finalize(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	if err := r.k8sClient.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{
 		LabelAppWorkloadGUID: appWorkload.Name,
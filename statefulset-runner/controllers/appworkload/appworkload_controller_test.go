@@ -3,6 +3,7 @@ package appworkload_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -37,6 +38,7 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		statefulSet            *v1.StatefulSet
 		fakeWorkloadToStSet    *fake.WorkloadToStatefulsetConverter
 		fakePDB                *fake.PDB
+		fakeRecorder           *fake.EventRecorder
 		getAppWorkloadError    error
 		getStatefulSetError    error
 		createStatefulSetError error
@@ -61,6 +63,7 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		fakeWorkloadToStSet.ConvertReturns(statefulSet, nil)
 
 		fakePDB = new(fake.PDB)
+		fakeRecorder = new(fake.EventRecorder)
 
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -108,6 +111,7 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			fakePDB,
 			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
 			state.NewAppWorkloadStateCollector(fakeClient),
+			fakeRecorder,
 		)
 	})
 
@@ -162,6 +166,77 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		})
 	})
 
+	When("an instance has crashed", func() {
+		var crashTime metav1.Time
+
+		BeforeEach(func() {
+			appWorkload.Spec.GUID = "process-guid"
+			appWorkload.Spec.AppGUID = "app-guid"
+			appWorkload.Spec.ProcessType = "web"
+
+			crashTime = metav1.NewTime(time.UnixMilli(2000).UTC())
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				podList, ok := list.(*corev1.PodList)
+				if !ok {
+					return nil
+				}
+
+				podList.Items = []corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"apps.kubernetes.io/pod-index": "0"},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							RestartCount: 1,
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Reason:     "OOMKilled",
+									ExitCode:   137,
+									FinishedAt: crashTime,
+								},
+							},
+						}},
+					},
+				}}
+				return nil
+			}
+		})
+
+		It("records a crash event", func() {
+			Expect(fakeRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+			object, annotations, eventType, reason, messageFmt, args := fakeRecorder.AnnotatedEventfArgsForCall(0)
+			Expect(object).To(BeAssignableToTypeOf(&korifiv1alpha1.AppWorkload{}))
+			Expect(annotations).To(SatisfyAll(
+				HaveKeyWithValue(appworkload.LabelAppGUID, "app-guid"),
+				HaveKeyWithValue(appworkload.AnnotationProcessGUID, "process-guid"),
+				HaveKeyWithValue(appworkload.AnnotationCrashInstanceIndex, "0"),
+				HaveKeyWithValue(appworkload.AnnotationCrashReason, "OOMKilled"),
+				HaveKeyWithValue(appworkload.AnnotationCrashExitStatus, "137"),
+			))
+			Expect(eventType).To(Equal(corev1.EventTypeWarning))
+			Expect(reason).To(Equal(appworkload.CrashEventReason))
+			Expect(fmt.Sprintf(messageFmt, args...)).To(Equal("Instance 0 of process web crashed (OOMKilled): Exited with status 137 (out of memory)"))
+		})
+
+		When("the crash has already been recorded", func() {
+			BeforeEach(func() {
+				appWorkload.Status.InstancesStatus = map[string]korifiv1alpha1.InstanceStatus{
+					"0": {
+						State: korifiv1alpha1.InstanceStateRunning,
+						LastCrash: &korifiv1alpha1.InstanceCrash{
+							Reason:    "OOMKilled",
+							Timestamp: crashTime,
+						},
+					},
+				}
+			})
+
+			It("does not record it again", func() {
+				Expect(fakeRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	When("the appworkload is being deleted", func() {
 		BeforeEach(func() {
 			getAppWorkloadError = apierrors.NewNotFound(schema.GroupResource{
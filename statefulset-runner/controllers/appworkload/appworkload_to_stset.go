@@ -156,6 +156,8 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 				Spec: corev1.PodSpec{
 					Containers:       containers,
 					ImagePullSecrets: appWorkload.Spec.ImagePullSecrets,
+					NodeSelector:     appWorkload.Spec.Placement.NodeSelector,
+					Tolerations:      appWorkload.Spec.Placement.Tolerations,
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: tools.PtrTo(true),
 						SeccompProfile: &corev1.SeccompProfile{
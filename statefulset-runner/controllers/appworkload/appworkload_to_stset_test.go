@@ -305,6 +305,35 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("korifi-app"))
 	})
 
+	It("should not constrain the nodes", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+	})
+
+	When("the app workload has a placement", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Placement = korifiv1alpha1.Placement{
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "regulated",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			}
+		})
+
+		It("sets the node selector and tolerations on the pod", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
 	When("the app has environment set", func() {
 		BeforeEach(func() {
 			appWorkload.Spec.Env = []corev1.EnvVar{
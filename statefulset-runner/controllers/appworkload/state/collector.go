@@ -3,6 +3,8 @@ package state
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
@@ -22,7 +24,10 @@ func NewAppWorkloadStateCollector(client client.Client) *AppWorkloadStateCollect
 	}
 }
 
-func (c *AppWorkloadStateCollector) CollectState(ctx context.Context, appWorkloadGUID string) (map[string]korifiv1alpha1.InstanceStatus, error) {
+// CollectState reports the state of the app workload instances. The
+// previously collected state is needed to tell since when instances are
+// crash looping, as pods only report their last crash.
+func (c *AppWorkloadStateCollector) CollectState(ctx context.Context, appWorkloadGUID string, previousState map[string]korifiv1alpha1.InstanceStatus) (map[string]korifiv1alpha1.InstanceStatus, error) {
 	workloadPods := &corev1.PodList{}
 	err := c.client.List(ctx, workloadPods,
 		client.MatchingLabels{
@@ -36,51 +41,128 @@ func (c *AppWorkloadStateCollector) CollectState(ctx context.Context, appWorkloa
 	result := map[string]korifiv1alpha1.InstanceStatus{}
 
 	for _, pod := range workloadPods.Items {
-		result[pod.Labels["apps.kubernetes.io/pod-index"]] = getPodState(pod)
+		index := pod.Labels["apps.kubernetes.io/pod-index"]
+		result[index] = GetPodState(pod, previousState[index])
 	}
 
 	return result, nil
 }
 
+// GetPodState reports the state of an app instance pod
+//
 // Logic from Kubernetes in Action 2nd Edition - Ch 6.
 // DOWN => !pod || !pod.conditions.PodScheduled
 // CRASHED => any(pod.ContainerStatuses.State isA Terminated)
 // RUNNING => pod.conditions.Ready
 // STARTING => default
-func getPodState(pod corev1.Pod) korifiv1alpha1.InstanceStatus {
+func GetPodState(pod corev1.Pod, previous korifiv1alpha1.InstanceStatus) korifiv1alpha1.InstanceStatus {
+	status := getPodCrashStatus(pod)
+
 	// return running when all containers are ready
 	if podConditionStatus(pod, corev1.PodReady) {
-		return korifiv1alpha1.InstanceStatus{
-			State:     korifiv1alpha1.InstanceStateRunning,
-			Timestamp: getPodStartTime(pod),
-		}
+		status.State = korifiv1alpha1.InstanceStateRunning
+		status.Timestamp = getPodStartTime(pod)
+		return status
 	}
 
+	status.CrashingSince = getCrashingSince(status, previous)
+
 	if !podConditionStatus(pod, corev1.PodScheduled) {
-		return korifiv1alpha1.InstanceStatus{
-			State: korifiv1alpha1.InstanceStateDown,
-		}
+		status.State = korifiv1alpha1.InstanceStateDown
+		return status
 	}
 
-	if podHasCrashedContainer(pod) {
-		return korifiv1alpha1.InstanceStatus{
-			State: korifiv1alpha1.InstanceStateCrashed,
+	if crashed, details := podHasCrashedContainer(pod); crashed {
+		status.State = korifiv1alpha1.InstanceStateCrashed
+		status.Details = details
+		if status.LastCrash != nil {
+			status.Timestamp = tools.PtrTo(status.LastCrash.Timestamp)
 		}
+		return status
 	}
 
-	return korifiv1alpha1.InstanceStatus{
-		State: korifiv1alpha1.InstanceStateStarting,
-	}
+	status.State = korifiv1alpha1.InstanceStateStarting
+	return status
 }
 
-func podHasCrashedContainer(pod corev1.Pod) bool {
+func podHasCrashedContainer(pod corev1.Pod) (bool, string) {
 	for _, cond := range pod.Status.ContainerStatuses {
 		if cond.State.Waiting != nil && cond.State.Waiting.Reason == "CrashLoopBackOff" {
-			return true
+			return true, joinNonEmpty(": ", cond.State.Waiting.Reason, cond.State.Waiting.Message)
+		}
+
+		if cond.State.Terminated != nil {
+			return true, joinNonEmpty(": ", cond.State.Terminated.Reason, cond.State.Terminated.Message)
 		}
 	}
 
-	return false
+	return false, ""
+}
+
+// getPodCrashStatus reports how many times the pod containers have been
+// restarted and the most recent container termination. The containers of pods
+// that are being deleted, e.g. when the app is stopped or scaled down, and
+// containers that have completed gracefully have not crashed.
+func getPodCrashStatus(pod corev1.Pod) korifiv1alpha1.InstanceStatus {
+	status := korifiv1alpha1.InstanceStatus{}
+
+	if !pod.DeletionTimestamp.IsZero() {
+		return status
+	}
+
+	for _, cond := range pod.Status.ContainerStatuses {
+		status.CrashCount += cond.RestartCount
+
+		for _, terminated := range []*corev1.ContainerStateTerminated{cond.LastTerminationState.Terminated, cond.State.Terminated} {
+			if terminated == nil || hasCompleted(terminated) {
+				continue
+			}
+
+			if status.LastCrash != nil && !status.LastCrash.Timestamp.Before(&terminated.FinishedAt) {
+				continue
+			}
+
+			status.LastCrash = &korifiv1alpha1.InstanceCrash{
+				Reason:          terminated.Reason,
+				ExitStatus:      terminated.ExitCode,
+				ExitDescription: exitDescription(terminated),
+				Timestamp:       terminated.FinishedAt,
+			}
+		}
+	}
+
+	return status
+}
+
+func hasCompleted(terminated *corev1.ContainerStateTerminated) bool {
+	return terminated.ExitCode == 0 && terminated.Reason == "Completed"
+}
+
+// getCrashingSince carries the first crash of a crash loop over from the
+// previous state of the instance, as the pod only reports its last crash
+func getCrashingSince(status, previous korifiv1alpha1.InstanceStatus) *metav1.Time {
+	if status.LastCrash == nil {
+		return nil
+	}
+
+	if previous.CrashingSince != nil {
+		return previous.CrashingSince
+	}
+
+	return tools.PtrTo(status.LastCrash.Timestamp)
+}
+
+func exitDescription(terminated *corev1.ContainerStateTerminated) string {
+	description := fmt.Sprintf("Exited with status %d", terminated.ExitCode)
+	if terminated.Reason == "OOMKilled" {
+		description += " (out of memory)"
+	}
+
+	return joinNonEmpty(": ", description, terminated.Message)
+}
+
+func joinNonEmpty(sep string, elems ...string) string {
+	return strings.Join(slices.DeleteFunc(elems, func(e string) bool { return e == "" }), sep)
 }
 
 func podConditionStatus(pod corev1.Pod, conditionType corev1.PodConditionType) bool {
@@ -19,12 +19,14 @@ var _ = Describe("AppWorkload State", func() {
 	var (
 		stateCollector  *state.AppWorkloadStateCollector
 		workloadState   map[string]korifiv1alpha1.InstanceStatus
+		previousState   map[string]korifiv1alpha1.InstanceStatus
 		appWorkloadGUID string
 		pod             *corev1.Pod
 	)
 
 	BeforeEach(func() {
 		stateCollector = state.NewAppWorkloadStateCollector(k8sClient)
+		previousState = nil
 
 		appWorkloadGUID = uuid.NewString()
 		pod = &corev1.Pod{
@@ -48,7 +50,7 @@ var _ = Describe("AppWorkload State", func() {
 
 	JustBeforeEach(func() {
 		var err error
-		workloadState, err = stateCollector.CollectState(ctx, appWorkloadGUID)
+		workloadState, err = stateCollector.CollectState(ctx, appWorkloadGUID, previousState)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -76,14 +78,33 @@ var _ = Describe("AppWorkload State", func() {
 		It("reports state RUNNING", func() {
 			Expect(workloadState).To(SatisfyAll(
 				HaveLen(1),
-				HaveKeyWithValue("4", MatchAllFields(Fields{
+				HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
 					"State": BeEquivalentTo(korifiv1alpha1.InstanceStateRunning),
 					"Timestamp": PointTo(MatchAllFields(Fields{
 						"Time": BeTemporally("==", time.UnixMilli(2000).UTC()),
 					})),
+					"CrashCount": BeZero(),
+					"LastCrash":  BeNil(),
 				})),
 			))
 		})
+
+		When("the instance has been crashing before", func() {
+			BeforeEach(func() {
+				previousState = map[string]korifiv1alpha1.InstanceStatus{
+					"4": {
+						State:         korifiv1alpha1.InstanceStateCrashed,
+						CrashingSince: &metav1.Time{Time: time.UnixMilli(1000).UTC()},
+					},
+				}
+			})
+
+			It("does not report it as crashing anymore", func() {
+				Expect(workloadState).To(HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
+					"CrashingSince": BeNil(),
+				})))
+			})
+		})
 	})
 
 	When("the pod is scheduled", func() {
@@ -122,10 +143,149 @@ var _ = Describe("AppWorkload State", func() {
 			It("reports state CRASHED", func() {
 				Expect(workloadState).To(Equal(map[string]korifiv1alpha1.InstanceStatus{
 					"4": {
-						State: korifiv1alpha1.InstanceStateCrashed,
+						State:   korifiv1alpha1.InstanceStateCrashed,
+						Details: "CrashLoopBackOff",
 					},
 				}))
 			})
+
+			When("the container has been restarted after crashing", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+						pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+							Name:         "pod-container",
+							Image:        "pod/image",
+							ImageID:      "pod/image@sha256:123",
+							RestartCount: 3,
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{
+									Reason:  "CrashLoopBackOff",
+									Message: "back-off 40s restarting failed container",
+								},
+							},
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Reason:     "OOMKilled",
+									ExitCode:   137,
+									StartedAt:  metav1.NewTime(time.UnixMilli(1000).UTC()),
+									FinishedAt: metav1.NewTime(time.UnixMilli(3000).UTC()),
+								},
+							},
+						}}
+					})).To(Succeed())
+				})
+
+				It("reports the crash count and the last crash", func() {
+					Expect(workloadState).To(HaveKeyWithValue("4", MatchAllFields(Fields{
+						"State":      BeEquivalentTo(korifiv1alpha1.InstanceStateCrashed),
+						"Details":    Equal("CrashLoopBackOff: back-off 40s restarting failed container"),
+						"CrashCount": BeEquivalentTo(3),
+						"Timestamp": PointTo(MatchAllFields(Fields{
+							"Time": BeTemporally("==", time.UnixMilli(3000).UTC()),
+						})),
+						"LastCrash": PointTo(MatchAllFields(Fields{
+							"Reason":          Equal("OOMKilled"),
+							"ExitStatus":      BeEquivalentTo(137),
+							"ExitDescription": Equal("Exited with status 137 (out of memory)"),
+							"Timestamp": MatchAllFields(Fields{
+								"Time": BeTemporally("==", time.UnixMilli(3000).UTC()),
+							}),
+						})),
+						"CrashingSince": PointTo(MatchAllFields(Fields{
+							"Time": BeTemporally("==", time.UnixMilli(3000).UTC()),
+						})),
+					})))
+				})
+
+				When("the instance has been crashing before", func() {
+					BeforeEach(func() {
+						previousState = map[string]korifiv1alpha1.InstanceStatus{
+							"4": {
+								State:         korifiv1alpha1.InstanceStateStarting,
+								CrashingSince: &metav1.Time{Time: time.UnixMilli(2000).UTC()},
+							},
+						}
+					})
+
+					It("reports the time of the first crash as crashing since", func() {
+						Expect(workloadState).To(HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
+							"Timestamp": PointTo(MatchAllFields(Fields{
+								"Time": BeTemporally("==", time.UnixMilli(3000).UTC()),
+							})),
+							"CrashingSince": PointTo(MatchAllFields(Fields{
+								"Time": BeTemporally("==", time.UnixMilli(2000).UTC()),
+							})),
+						})))
+					})
+				})
+			})
+		})
+
+		When("the pod container has terminated", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+					pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+						Name:    "pod-container",
+						Image:   "pod/image",
+						ImageID: "pod/image@sha256:123",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Reason:     "Error",
+								ExitCode:   1,
+								FinishedAt: metav1.NewTime(time.UnixMilli(3000).UTC()),
+							},
+						},
+					}}
+				})).To(Succeed())
+			})
+
+			It("reports state CRASHED", func() {
+				Expect(workloadState).To(HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
+					"State":   BeEquivalentTo(korifiv1alpha1.InstanceStateCrashed),
+					"Details": Equal("Error"),
+					"LastCrash": PointTo(MatchFields(IgnoreExtras, Fields{
+						"ExitStatus":      BeEquivalentTo(1),
+						"ExitDescription": Equal("Exited with status 1"),
+					})),
+				})))
+			})
+
+			When("the container has completed gracefully", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+						pod.Status.ContainerStatuses[0].State.Terminated.Reason = "Completed"
+						pod.Status.ContainerStatuses[0].State.Terminated.ExitCode = 0
+					})).To(Succeed())
+				})
+
+				It("does not report a crash", func() {
+					Expect(workloadState).To(HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
+						"LastCrash":  BeNil(),
+						"CrashCount": BeZero(),
+					})))
+				})
+			})
+
+			When("the pod is being deleted", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, pod, func() {
+						pod.Finalizers = append(pod.Finalizers, "korifi.cloudfoundry.org/test")
+					})).To(Succeed())
+					Expect(k8sClient.Delete(ctx, pod)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, pod, func() {
+							pod.Finalizers = nil
+						})).To(Succeed())
+					})
+				})
+
+				It("does not report a crash", func() {
+					Expect(workloadState).To(HaveKeyWithValue("4", MatchFields(IgnoreExtras, Fields{
+						"LastCrash":  BeNil(),
+						"CrashCount": BeZero(),
+					})))
+				})
+			})
 		})
 	})
 
@@ -89,6 +89,7 @@ var _ = BeforeEach(func() {
 		appworkload.NewPDBUpdater(k8sManager.GetClient()),
 		ctrl.Log.WithName("statefulset-runner").WithName("AppWorkload"),
 		state.NewAppWorkloadStateCollector(k8sManager.GetClient()),
+		k8sManager.GetEventRecorderFor("statefulset-runner"),
 	)
 	err := appWorkloadReconciler.SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
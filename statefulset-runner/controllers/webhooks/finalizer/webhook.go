@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,6 +40,11 @@ func (r *Webhook) Handle(ctx context.Context, req admission.Request) admission.R
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// workloads of other runners are finalized by those runners
+	if workload.Spec.RunnerName != controllers.AppWorkloadReconcilerName {
+		return admission.Allowed("")
+	}
+
 	if controllerutil.AddFinalizer(&workload, AppWorkloadFinalizerName) {
 		log.Info("adding-finalizer to appWorkload", "name", workload.Name)
 	}
@@ -19,7 +19,13 @@ var _ = Describe("ApprevWebhook", func() {
 				Namespace: testNamespace,
 				Name:      uuid.NewString(),
 			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				RunnerName: "statefulset-runner",
+			},
 		}
+	})
+
+	JustBeforeEach(func() {
 		Expect(adminClient.Create(ctx, workload)).To(Succeed())
 	})
 
@@ -27,4 +33,15 @@ var _ = Describe("ApprevWebhook", func() {
 		Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(workload), workload)).To(Succeed())
 		Expect(workload.Finalizers).To(ConsistOf(finalizer.AppWorkloadFinalizerName))
 	})
+
+	When("the workload is run by another runner", func() {
+		BeforeEach(func() {
+			workload.Spec.RunnerName = "another-runner"
+		})
+
+		It("does not set the finalizer", func() {
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(workload), workload)).To(Succeed())
+			Expect(workload.Finalizers).To(BeEmpty())
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/runnerinfo"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/webhooks/finalizer"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 	"code.cloudfoundry.org/korifi/version"
 	"go.uber.org/zap/zapcore"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -41,6 +43,7 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		tracingConfig        tracing.Config
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -48,7 +51,10 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&tracingConfig.Endpoint, "tracing-endpoint", "", "The OTLP collector endpoint spans are sent to. Tracing is disabled if not set.")
+	flag.BoolVar(&tracingConfig.Insecure, "tracing-insecure", false, "Send spans to the OTLP collector without TLS.")
 	flag.Parse()
+	tracingConfig.Enabled = tracingConfig.Endpoint != ""
 
 	logger, _, err := tools.NewZapLogger(zapcore.InfoLevel)
 	if err != nil {
@@ -60,6 +66,20 @@ func main() {
 
 	ctrl.Log.Info("starting Korifi statefulset runner", "version", version.Version)
 
+	if tracingConfig.Enabled {
+		traceExporter, err := tracing.NewOTLPExporter(context.Background(), tracingConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to create trace exporter")
+			os.Exit(1)
+		}
+		tracerProvider := tracing.Setup("korifi-statefulset-runner", traceExporter)
+		defer func() {
+			if err := tracerProvider.Shutdown(context.Background()); err != nil {
+				setupLog.Error(err, "error shutting down tracer provider")
+			}
+		}()
+	}
+
 	conf := ctrl.GetConfigOrDie()
 	mgr, err := ctrl.NewManager(conf, ctrl.Options{
 		Scheme: scheme,
@@ -108,6 +128,7 @@ func setupControllers(mgr manager.Manager) error {
 		appworkload.NewPDBUpdater(controllersClient),
 		controllersLog,
 		state.NewAppWorkloadStateCollector(controllersClient),
+		mgr.GetEventRecorderFor("statefulset-runner"),
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create AppWorkload controller: %w", err)
 	}
@@ -201,7 +201,7 @@ func uploadAppBits(appGUID, packageGUID string) {
 
 	kpackBuilderConfig := config.Config{}
 	Expect(yaml.Unmarshal([]byte(kpackBuilderConfigMap.Data["config.yaml"]), &kpackBuilderConfig)).To(Succeed())
-	repoCreator := registry.NewRepositoryCreator(kpackBuilderConfig.ContainerRegistryType)
+	repoCreator := registry.NewRepositoryCreator(kpackBuilderConfig.ContainerRegistryType, kpackBuilderConfig.ContainerRepositoryCreator)
 	Expect(repoCreator.CreateRepository(ctx, fmt.Sprintf("%s%s-packages",
 		kpackBuilderConfig.ContainerRepositoryPrefix,
 		appGUID,
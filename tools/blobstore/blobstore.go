@@ -0,0 +1,120 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	S3BlobstoreType         = "S3"
+	FilesystemBlobstoreType = "Filesystem"
+
+	httpClientTimeout = 5 * time.Minute
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Config configures the blobstore package bits are stored in, as an
+// alternative to the container registry
+type Config struct {
+	// Type is either S3 or Filesystem. Package bits are stored in the
+	// container registry if it is empty.
+	Type string `yaml:"type"`
+	// URL is the endpoint of the S3 compatible service, or the base URL the
+	// API serves filesystem blobs on
+	URL string `yaml:"url"`
+	// Bucket and Region of the S3 compatible service
+	Bucket string `yaml:"bucket"`
+	Region string `yaml:"region"`
+	// Path is the root directory of the filesystem blobstore, e.g. the mount
+	// path of a persistent volume
+	Path string `yaml:"path"`
+	// CredentialsPath is a directory containing `accessKeyID` and
+	// `secretAccessKey` files for S3, or a `signingKey` file for the
+	// filesystem blobstore
+	CredentialsPath string `yaml:"credentialsPath"`
+}
+
+func (c Config) IsEnabled() bool {
+	return c.Type != ""
+}
+
+func (c Config) Validate() error {
+	switch c.Type {
+	case "":
+		return nil
+	case S3BlobstoreType:
+		if c.URL == "" || c.Bucket == "" {
+			return errors.New("the S3 blobstore requires a url and a bucket")
+		}
+	case FilesystemBlobstoreType:
+		if c.URL == "" {
+			return errors.New("the filesystem blobstore requires a url")
+		}
+	default:
+		return fmt.Errorf("unsupported blobstore type %q", c.Type)
+	}
+
+	if c.CredentialsPath == "" {
+		return fmt.Errorf("the %s blobstore requires a credentials path", c.Type)
+	}
+
+	return nil
+}
+
+//counterfeiter:generate -o fake -fake-name Store . Store
+
+// Store stores blobs under keys. Blobs are identified by their URL, which is
+// stable and not meant to be downloaded from directly. SignURL turns it into
+// a temporary URL that can be downloaded from without credentials, e.g. by
+// kpack.
+type Store interface {
+	Put(ctx context.Context, key string, reader io.Reader) (blobURL string, err error)
+	Get(ctx context.Context, blobURL string) (io.ReadCloser, error)
+	Copy(ctx context.Context, srcBlobURL string, key string) (blobURL string, err error)
+	Delete(ctx context.Context, blobURL string) error
+	SignURL(blobURL string, expiry time.Duration) (string, error)
+}
+
+func NewStore(config Config) (Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch config.Type {
+	case S3BlobstoreType:
+		return NewS3Store(&http.Client{Timeout: httpClientTimeout}, config), nil
+	case FilesystemBlobstoreType:
+		return NewFilesystemStore(config), nil
+	}
+
+	return nil, errors.New("the blobstore is not enabled")
+}
+
+// keyFromURL returns the key of a blob URL under the base URL
+func keyFromURL(baseURL, blobURL string) (string, error) {
+	key, ok := strings.CutPrefix(blobURL, baseURL+"/")
+	if !ok || key == "" {
+		return "", fmt.Errorf("blob url %q is not under %q", blobURL, baseURL)
+	}
+
+	return key, nil
+}
+
+// readCredential reads a credential file on every use, so that secret
+// rotations are picked up without a restart
+func readCredential(credentialsPath, name string) (string, error) {
+	value, err := os.ReadFile(filepath.Join(credentialsPath, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read blobstore credential %q: %w", name, err)
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
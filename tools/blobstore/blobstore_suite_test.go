@@ -0,0 +1,27 @@
+package blobstore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx = context.Background()
+
+func TestBlobstore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Blobstore Suite")
+}
+
+func writeCredentials(credentials map[string]string) string {
+	credentialsPath := GinkgoT().TempDir()
+	for name, value := range credentials {
+		Expect(os.WriteFile(filepath.Join(credentialsPath, name), []byte(value+"\n"), 0o600)).To(Succeed())
+	}
+
+	return credentialsPath
+}
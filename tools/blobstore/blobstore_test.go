@@ -0,0 +1,27 @@
+package blobstore_test
+
+import (
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	DescribeTable("Validate",
+		func(config blobstore.Config, expectedErr string) {
+			err := config.Validate()
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("disabled", blobstore.Config{}, ""),
+		Entry("s3", blobstore.Config{Type: "S3", URL: "https://s3.example.com", Bucket: "packages", CredentialsPath: "/creds"}, ""),
+		Entry("s3 without bucket", blobstore.Config{Type: "S3", URL: "https://s3.example.com", CredentialsPath: "/creds"}, "the S3 blobstore requires a url and a bucket"),
+		Entry("filesystem", blobstore.Config{Type: "Filesystem", URL: "https://api.example.com", CredentialsPath: "/creds"}, ""),
+		Entry("filesystem without url", blobstore.Config{Type: "Filesystem", CredentialsPath: "/creds"}, "the filesystem blobstore requires a url"),
+		Entry("without credentials", blobstore.Config{Type: "Filesystem", URL: "https://api.example.com"}, "the Filesystem blobstore requires a credentials path"),
+		Entry("unknown type", blobstore.Config{Type: "WebDAV"}, `unsupported blobstore type "WebDAV"`),
+	)
+})
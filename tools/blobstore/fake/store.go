@@ -0,0 +1,444 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+)
+
+type Store struct {
+	CopyStub        func(context.Context, string, string) (string, error)
+	copyMutex       sync.RWMutex
+	copyArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	copyReturns struct {
+		result1 string
+		result2 error
+	}
+	copyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DeleteStub        func(context.Context, string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStub        func(context.Context, string) (io.ReadCloser, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	PutStub        func(context.Context, string, io.Reader) (string, error)
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}
+	putReturns struct {
+		result1 string
+		result2 error
+	}
+	putReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SignURLStub        func(string, time.Duration) (string, error)
+	signURLMutex       sync.RWMutex
+	signURLArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	signURLReturns struct {
+		result1 string
+		result2 error
+	}
+	signURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Store) Copy(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.copyMutex.Lock()
+	ret, specificReturn := fake.copyReturnsOnCall[len(fake.copyArgsForCall)]
+	fake.copyArgsForCall = append(fake.copyArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CopyStub
+	fakeReturns := fake.copyReturns
+	fake.recordInvocation("Copy", []interface{}{arg1, arg2, arg3})
+	fake.copyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Store) CopyCallCount() int {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	return len(fake.copyArgsForCall)
+}
+
+func (fake *Store) CopyCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = stub
+}
+
+func (fake *Store) CopyArgsForCall(i int) (context.Context, string, string) {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	argsForCall := fake.copyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Store) CopyReturns(result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	fake.copyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) CopyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	if fake.copyReturnsOnCall == nil {
+		fake.copyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) Delete(arg1 context.Context, arg2 string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Store) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *Store) DeleteCalls(stub func(context.Context, string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *Store) DeleteArgsForCall(i int) (context.Context, string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Store) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Store) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Store) Get(arg1 context.Context, arg2 string) (io.ReadCloser, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Store) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *Store) GetCalls(stub func(context.Context, string) (io.ReadCloser, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *Store) GetArgsForCall(i int) (context.Context, string) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Store) GetReturns(result1 io.ReadCloser, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) GetReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) Put(arg1 context.Context, arg2 string, arg3 io.Reader) (string, error) {
+	fake.putMutex.Lock()
+	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.PutStub
+	fakeReturns := fake.putReturns
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3})
+	fake.putMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Store) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *Store) PutCalls(stub func(context.Context, string, io.Reader) (string, error)) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = stub
+}
+
+func (fake *Store) PutArgsForCall(i int) (context.Context, string, io.Reader) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	argsForCall := fake.putArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Store) PutReturns(result1 string, result2 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) PutReturnsOnCall(i int, result1 string, result2 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	if fake.putReturnsOnCall == nil {
+		fake.putReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.putReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) SignURL(arg1 string, arg2 time.Duration) (string, error) {
+	fake.signURLMutex.Lock()
+	ret, specificReturn := fake.signURLReturnsOnCall[len(fake.signURLArgsForCall)]
+	fake.signURLArgsForCall = append(fake.signURLArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SignURLStub
+	fakeReturns := fake.signURLReturns
+	fake.recordInvocation("SignURL", []interface{}{arg1, arg2})
+	fake.signURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Store) SignURLCallCount() int {
+	fake.signURLMutex.RLock()
+	defer fake.signURLMutex.RUnlock()
+	return len(fake.signURLArgsForCall)
+}
+
+func (fake *Store) SignURLCalls(stub func(string, time.Duration) (string, error)) {
+	fake.signURLMutex.Lock()
+	defer fake.signURLMutex.Unlock()
+	fake.SignURLStub = stub
+}
+
+func (fake *Store) SignURLArgsForCall(i int) (string, time.Duration) {
+	fake.signURLMutex.RLock()
+	defer fake.signURLMutex.RUnlock()
+	argsForCall := fake.signURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Store) SignURLReturns(result1 string, result2 error) {
+	fake.signURLMutex.Lock()
+	defer fake.signURLMutex.Unlock()
+	fake.SignURLStub = nil
+	fake.signURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) SignURLReturnsOnCall(i int, result1 string, result2 error) {
+	fake.signURLMutex.Lock()
+	defer fake.signURLMutex.Unlock()
+	fake.SignURLStub = nil
+	if fake.signURLReturnsOnCall == nil {
+		fake.signURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.signURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	fake.signURLMutex.RLock()
+	defer fake.signURLMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Store) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ blobstore.Store = new(Store)
@@ -0,0 +1,212 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilesystemBlobsPath is the path the API serves filesystem blobs on
+const FilesystemBlobsPath = "/blobs/"
+
+// FilesystemStore stores blobs in a directory, typically a persistent volume
+// mounted by the API. As build pods cannot mount the volume, blobs are served
+// over HTTP on signed URLs, see ServeHTTP.
+type FilesystemStore struct {
+	root            string
+	baseURL         string
+	credentialsPath string
+}
+
+func NewFilesystemStore(config Config) *FilesystemStore {
+	return &FilesystemStore{
+		root:            config.Path,
+		baseURL:         strings.TrimSuffix(config.URL, "/") + strings.TrimSuffix(FilesystemBlobsPath, "/"),
+		credentialsPath: config.CredentialsPath,
+	}
+}
+
+func (s *FilesystemStore) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	blobPath, err := s.blobPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.write(blobPath, reader); err != nil {
+		return "", fmt.Errorf("failed to write blob %q: %w", key, err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// write writes the blob to a temp file first, so that partially written
+// blobs are never served
+func (s *FilesystemStore) write(blobPath string, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0o750); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(blobPath), ".blob-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err = io.Copy(tmpFile, reader); err != nil {
+		return err
+	}
+
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), blobPath)
+}
+
+func (s *FilesystemStore) Get(ctx context.Context, blobURL string) (io.ReadCloser, error) {
+	blobPath, err := s.blobPathFromURL(blobURL)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := os.Open(blobPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return blob, err
+}
+
+func (s *FilesystemStore) Copy(ctx context.Context, srcBlobURL string, key string) (string, error) {
+	src, err := s.Get(ctx, srcBlobURL)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	return s.Put(ctx, key, src)
+}
+
+func (s *FilesystemStore) Delete(ctx context.Context, blobURL string) error {
+	blobPath, err := s.blobPathFromURL(blobURL)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(blobPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// SignURL adds an expiry and an HMAC signature of the key and expiry to the
+// blob URL
+func (s *FilesystemStore) SignURL(blobURL string, expiry time.Duration) (string, error) {
+	key, err := keyFromURL(s.baseURL, blobURL)
+	if err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	signature, err := s.sign(key, expires)
+	if err != nil {
+		return "", err
+	}
+
+	return blobURL + "?" + url.Values{
+		"expires":   {expires},
+		"signature": {signature},
+	}.Encode(), nil
+}
+
+// ServeHTTP serves the blobs on signed URLs, so that build pods can download
+// them without credentials
+func (s *FilesystemStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, FilesystemBlobsPath)
+	if !ok || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !s.isValidSignature(key, r.URL.Query()) {
+		http.Error(w, "invalid or expired signature", http.StatusForbidden)
+		return
+	}
+
+	blobPath, err := s.blobPath(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	blob, err := os.Open(blobPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer blob.Close()
+
+	info, err := blob.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeContent(w, r, filepath.Base(blobPath), info.ModTime(), blob)
+}
+
+func (s *FilesystemStore) isValidSignature(key string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().After(time.Unix(expires, 0)) {
+		return false
+	}
+
+	signature, err := s.sign(key, query.Get("expires"))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(query.Get("signature")))
+}
+
+func (s *FilesystemStore) sign(key, expires string) (string, error) {
+	signingKey, err := readCredential(s.credentialsPath, "signingKey")
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (s *FilesystemStore) blobPathFromURL(blobURL string) (string, error) {
+	key, err := keyFromURL(s.baseURL, blobURL)
+	if err != nil {
+		return "", err
+	}
+
+	return s.blobPath(key)
+}
+
+func (s *FilesystemStore) blobPath(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.root, key), nil
+}
@@ -0,0 +1,165 @@
+package blobstore_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilesystemStore", func() {
+	var (
+		root    string
+		store   *blobstore.FilesystemStore
+		blobURL string
+	)
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+		store = blobstore.NewFilesystemStore(blobstore.Config{
+			Type:            blobstore.FilesystemBlobstoreType,
+			URL:             "https://api.example.com/",
+			Path:            root,
+			CredentialsPath: writeCredentials(map[string]string{"signingKey": "s3cr3t"}),
+		})
+
+		var err error
+		blobURL, err = store.Put(ctx, "packages/my-package.zip", strings.NewReader("my-bits"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	readBlob := func(blobURL string) string {
+		reader, err := store.Get(ctx, blobURL)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	Describe("Put", func() {
+		It("writes the blob under the root directory", func() {
+			Expect(blobURL).To(Equal("https://api.example.com/blobs/packages/my-package.zip"))
+			Expect(filepath.Join(root, "packages", "my-package.zip")).To(BeAnExistingFile())
+			Expect(readBlob(blobURL)).To(Equal("my-bits"))
+		})
+
+		When("the key is outside the root directory", func() {
+			It("fails", func() {
+				_, err := store.Put(ctx, "../my-package.zip", strings.NewReader("my-bits"))
+				Expect(err).To(MatchError(ContainSubstring("invalid blob key")))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		When("the blob does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := store.Get(ctx, "https://api.example.com/blobs/packages/another-package.zip")
+				Expect(err).To(MatchError(blobstore.ErrNotFound))
+			})
+		})
+
+		When("the blob url is not served by the store", func() {
+			It("fails", func() {
+				_, err := store.Get(ctx, "https://elsewhere.example.com/blobs/packages/my-package.zip")
+				Expect(err).To(MatchError(ContainSubstring("is not under")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		It("copies the blob", func() {
+			copiedURL, err := store.Copy(ctx, blobURL, "packages/another-package.zip")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(copiedURL).To(Equal("https://api.example.com/blobs/packages/another-package.zip"))
+			Expect(readBlob(copiedURL)).To(Equal("my-bits"))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the blob", func() {
+			Expect(store.Delete(ctx, blobURL)).To(Succeed())
+			Expect(filepath.Join(root, "packages", "my-package.zip")).NotTo(BeAnExistingFile())
+		})
+
+		When("the blob does not exist", func() {
+			It("succeeds", func() {
+				Expect(store.Delete(ctx, "https://api.example.com/blobs/packages/another-package.zip")).To(Succeed())
+			})
+		})
+	})
+
+	Describe("SignURL and ServeHTTP", func() {
+		var (
+			signedURL string
+			expiry    time.Duration
+			resp      *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			expiry = time.Hour
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			signedURL, err = store.SignURL(blobURL, expiry)
+			Expect(err).NotTo(HaveOccurred())
+
+			resp = httptest.NewRecorder()
+			store.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, signedURL, nil))
+		})
+
+		It("serves the blob", func() {
+			Expect(signedURL).To(HavePrefix(blobURL + "?"))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("my-bits"))
+		})
+
+		When("the signature has expired", func() {
+			BeforeEach(func() {
+				expiry = -time.Minute
+			})
+
+			It("is forbidden", func() {
+				Expect(resp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		When("the url is tampered with", func() {
+			JustBeforeEach(func() {
+				Expect(os.WriteFile(filepath.Join(root, "packages", "another-package.zip"), []byte("other-bits"), 0o600)).To(Succeed())
+
+				tamperedURL, err := url.Parse(signedURL)
+				Expect(err).NotTo(HaveOccurred())
+				tamperedURL.Path = "/blobs/packages/another-package.zip"
+
+				resp = httptest.NewRecorder()
+				store.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, tamperedURL.String(), nil))
+			})
+
+			It("is forbidden", func() {
+				Expect(resp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		When("the signed key is a directory", func() {
+			BeforeEach(func() {
+				blobURL = "https://api.example.com/blobs/packages"
+			})
+
+			It("is not found", func() {
+				Expect(resp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,228 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	s3Service          = "s3"
+	defaultS3Region    = "us-east-1"
+	unsignedPayload    = "UNSIGNED-PAYLOAD"
+	maxPresignedExpiry = 7 * 24 * time.Hour
+)
+
+// S3Store stores blobs in a bucket of an S3 compatible service, using path
+// style URLs (i.e. <url>/<bucket>/<key>) so that it works with services
+// that do not support virtual hosted buckets, such as MinIO
+type S3Store struct {
+	httpClient      *http.Client
+	bucket          string
+	bucketURL       string
+	region          string
+	credentialsPath string
+	signer          *v4.Signer
+}
+
+func NewS3Store(httpClient *http.Client, config Config) *S3Store {
+	region := config.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	return &S3Store{
+		httpClient:      httpClient,
+		bucket:          config.Bucket,
+		bucketURL:       strings.TrimSuffix(config.URL, "/") + "/" + config.Bucket,
+		region:          region,
+		credentialsPath: config.CredentialsPath,
+		signer:          v4.NewSigner(),
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	// S3 requires the content length of uploads up front
+	tmpFile, err := os.CreateTemp("", "blob-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for blob: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	size, err := io.Copy(tmpFile, reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy blob into temp file: %w", err)
+	}
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	blobURL := s.bucketURL + "/" + key
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL, tmpFile)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload blob %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", unexpectedResponseError(fmt.Sprintf("upload blob %q", key), resp)
+	}
+
+	return blobURL, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, blobURL string) (io.ReadCloser, error) {
+	if _, err := keyFromURL(s.bucketURL, blobURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, unexpectedResponseError("download blob", resp)
+	}
+}
+
+func (s *S3Store) Copy(ctx context.Context, srcBlobURL string, key string) (string, error) {
+	srcKey, err := keyFromURL(s.bucketURL, srcBlobURL)
+	if err != nil {
+		return "", err
+	}
+
+	blobURL := s.bucketURL + "/" + key
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("x-amz-copy-source", "/"+s.bucket+"/"+srcKey)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy blob %q: %w", srcKey, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return blobURL, nil
+	case http.StatusNotFound:
+		return "", ErrNotFound
+	default:
+		return "", unexpectedResponseError(fmt.Sprintf("copy blob %q", srcKey), resp)
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, blobURL string) error {
+	if _, err := keyFromURL(s.bucketURL, blobURL); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, blobURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return unexpectedResponseError("delete blob", resp)
+	}
+
+	return nil
+}
+
+// SignURL presigns a GET request for the blob. S3 limits the expiry of
+// presigned URLs to 7 days.
+func (s *S3Store) SignURL(blobURL string, expiry time.Duration) (string, error) {
+	if _, err := keyFromURL(s.bucketURL, blobURL); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, blobURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(min(expiry, maxPresignedExpiry)/time.Second), 10))
+	req.URL.RawQuery = query.Encode()
+
+	creds, err := s.credentials()
+	if err != nil {
+		return "", err
+	}
+
+	signedURL, _, err := s.signer.PresignHTTP(context.Background(), creds, req, unsignedPayload, s3Service, s.region, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to presign blob url: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	creds, err := s.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+	if err = s.signer.SignHTTP(req.Context(), creds, req, unsignedPayload, s3Service, s.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return s.httpClient.Do(req)
+}
+
+func (s *S3Store) credentials() (aws.Credentials, error) {
+	accessKeyID, err := readCredential(s.credentialsPath, "accessKeyID")
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	secretAccessKey, err := readCredential(s.credentialsPath, "secretAccessKey")
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	return aws.Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}, nil
+}
+
+func unexpectedResponseError(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("failed to %s: unexpected status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(body)))
+}
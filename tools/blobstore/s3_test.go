@@ -0,0 +1,215 @@
+package blobstore_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools/blobstore"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type s3Request struct {
+	Method        string
+	Path          string
+	Authorization string
+	CopySource    string
+	ContentLength int64
+}
+
+// fakeS3Server keeps objects in memory, it does not verify signatures
+type fakeS3Server struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	objects  map[string]string
+	requests []s3Request
+}
+
+func newFakeS3Server() *fakeS3Server {
+	s := &fakeS3Server{objects: map[string]string{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.requests = append(s.requests, s3Request{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Authorization: r.Header.Get("Authorization"),
+			CopySource:    r.Header.Get("x-amz-copy-source"),
+			ContentLength: r.ContentLength,
+		})
+
+		switch r.Method {
+		case http.MethodPut:
+			if copySource := r.Header.Get("x-amz-copy-source"); copySource != "" {
+				object, ok := s.objects[copySource]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				s.objects[r.URL.Path] = object
+				return
+			}
+			s.objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			object, ok := s.objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(object))
+		case http.MethodDelete:
+			delete(s.objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	return s
+}
+
+func (s *fakeS3Server) Requests() []s3Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests
+}
+
+var _ = Describe("S3Store", func() {
+	var (
+		server  *fakeS3Server
+		store   *blobstore.S3Store
+		blobURL string
+		putErr  error
+	)
+
+	BeforeEach(func() {
+		server = newFakeS3Server()
+		DeferCleanup(server.Close)
+
+		store = blobstore.NewS3Store(server.Client(), blobstore.Config{
+			Type:   blobstore.S3BlobstoreType,
+			URL:    server.URL,
+			Bucket: "packages",
+			Region: "eu-west-1",
+			CredentialsPath: writeCredentials(map[string]string{
+				"accessKeyID":     "my-access-key",
+				"secretAccessKey": "my-secret-key",
+			}),
+		})
+
+		blobURL, putErr = store.Put(ctx, "my-app/my-package.zip", strings.NewReader("my-bits"))
+	})
+
+	Describe("Put", func() {
+		It("uploads the object to the bucket", func() {
+			Expect(putErr).NotTo(HaveOccurred())
+			Expect(blobURL).To(Equal(server.URL + "/packages/my-app/my-package.zip"))
+			Expect(server.objects).To(HaveKeyWithValue("/packages/my-app/my-package.zip", "my-bits"))
+		})
+
+		It("signs the request", func() {
+			Expect(server.Requests()).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Method":        Equal(http.MethodPut),
+				"Authorization": HavePrefix("AWS4-HMAC-SHA256 Credential=my-access-key/"),
+				"ContentLength": BeEquivalentTo(len("my-bits")),
+			})))
+			Expect(server.Requests()[0].Authorization).To(ContainSubstring("/eu-west-1/s3/aws4_request"))
+		})
+
+		When("the credentials cannot be read", func() {
+			BeforeEach(func() {
+				store = blobstore.NewS3Store(server.Client(), blobstore.Config{
+					URL:             server.URL,
+					Bucket:          "packages",
+					CredentialsPath: "/not/a/path",
+				})
+			})
+
+			It("fails", func() {
+				_, err := store.Put(ctx, "my-app/another-package.zip", strings.NewReader("my-bits"))
+				Expect(err).To(MatchError(ContainSubstring("failed to read blobstore credential")))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		It("downloads the object", func() {
+			reader, err := store.Get(ctx, blobURL)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			Expect(io.ReadAll(reader)).To(BeEquivalentTo("my-bits"))
+		})
+
+		When("the object does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := store.Get(ctx, server.URL+"/packages/my-app/another-package.zip")
+				Expect(err).To(MatchError(blobstore.ErrNotFound))
+			})
+		})
+
+		When("the blob url is not in the bucket", func() {
+			It("fails", func() {
+				_, err := store.Get(ctx, server.URL+"/another-bucket/my-app/my-package.zip")
+				Expect(err).To(MatchError(ContainSubstring("is not under")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		It("copies the object server side", func() {
+			copiedURL, err := store.Copy(ctx, blobURL, "my-app/another-package.zip")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(copiedURL).To(Equal(server.URL + "/packages/my-app/another-package.zip"))
+			Expect(server.objects).To(HaveKeyWithValue("/packages/my-app/another-package.zip", "my-bits"))
+			Expect(server.Requests()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Method":     Equal(http.MethodPut),
+				"CopySource": Equal("/packages/my-app/my-package.zip"),
+			})))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the object", func() {
+			Expect(store.Delete(ctx, blobURL)).To(Succeed())
+			Expect(server.objects).NotTo(HaveKey("/packages/my-app/my-package.zip"))
+		})
+	})
+
+	Describe("SignURL", func() {
+		It("presigns a download url", func() {
+			signedURL, err := store.SignURL(blobURL, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsedURL, err := url.Parse(signedURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedURL.Path).To(Equal("/packages/my-app/my-package.zip"))
+			Expect(parsedURL.Query().Get("X-Amz-Expires")).To(Equal("3600"))
+			Expect(parsedURL.Query().Get("X-Amz-Credential")).To(HavePrefix("my-access-key/"))
+			Expect(parsedURL.Query().Get("X-Amz-Signature")).NotTo(BeEmpty())
+
+			resp, err := http.Get(signedURL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(io.ReadAll(resp.Body)).To(BeEquivalentTo("my-bits"))
+		})
+
+		It("caps the expiry to 7 days", func() {
+			signedURL, err := store.SignURL(blobURL, 30*24*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsedURL, err := url.Parse(signedURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedURL.Query().Get("X-Amz-Expires")).To(Equal("604800"))
+		})
+	})
+})
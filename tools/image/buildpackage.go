@@ -0,0 +1,234 @@
+package image
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+const (
+	buildpackDescriptorFile = "buildpack.toml"
+	BuildpackageLabel       = "io.buildpacks.buildpackage.metadata"
+)
+
+type buildpackageMetadata struct {
+	dist.ModuleInfo
+	Stacks []dist.Stack `json:"stacks"`
+}
+
+// PushBuildpackage turns a buildpack archive (a tarball, optionally gzipped,
+// with buildpack.toml at its root) into a buildpackage image that can be
+// added to a kpack ClusterStore, and pushes it to repoRef.
+func (c Client) PushBuildpackage(ctx context.Context, creds Creds, repoRef string, archiveReader io.Reader, tags ...string) (string, error) {
+	tmpDir, err := os.MkdirTemp(os.TempDir(), "buildpack-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp dir for buildpack: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := path.Join(tmpDir, "buildpack.tgz")
+	if err = copyToFile(archivePath, archiveReader); err != nil {
+		return "", fmt.Errorf("failed to copy buildpack archive into temp file '%s' %w", archivePath, err)
+	}
+
+	buildpackBlob := blob.NewBlob(archivePath)
+	descriptor, err := readBuildpackDescriptor(buildpackBlob)
+	if err != nil {
+		return "", err
+	}
+
+	layerPath := path.Join(tmpDir, "layer.tar")
+	if err = writeBuildpackLayer(layerPath, descriptor, buildpackBlob); err != nil {
+		return "", fmt.Errorf("failed to create the buildpack layer: %w", err)
+	}
+
+	layer, err := tarball.LayerFromFile(layerPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create a layer out of '%s': %w", layerPath, err)
+	}
+
+	diffID, err := layer.DiffID()
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the buildpack layer diff id: %w", err)
+	}
+
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", fmt.Errorf("failed to append layer: %w", err)
+	}
+
+	info := descriptor.Info()
+	packageMetadata, err := json.Marshal(buildpackageMetadata{
+		ModuleInfo: dist.ModuleInfo{ID: info.ID, Version: info.Version, Homepage: info.Homepage},
+		Stacks:     descriptor.Stacks(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal buildpackage metadata: %w", err)
+	}
+
+	layersMetadata, err := json.Marshal(dist.ModuleLayers{
+		info.ID: {
+			info.Version: dist.ModuleLayerInfo{
+				API:         descriptor.API(),
+				Stacks:      descriptor.Stacks(),
+				Targets:     descriptor.Targets(),
+				LayerDiffID: diffID.String(),
+				Homepage:    info.Homepage,
+				Name:        info.Name,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal buildpack layers metadata: %w", err)
+	}
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		return "", fmt.Errorf("failed to get image config: %w", err)
+	}
+	configFile = configFile.DeepCopy()
+	configFile.OS = dist.DefaultTargetOSLinux
+	configFile.Architecture = dist.DefaultTargetArch
+	configFile.Config.Labels = map[string]string{
+		BuildpackageLabel:         string(packageMetadata),
+		dist.BuildpackLayersLabel: string(layersMetadata),
+	}
+
+	image, err = mutate.ConfigFile(image, configFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to set image config: %w", err)
+	}
+
+	return c.write(ctx, creds, repoRef, image, tags...)
+}
+
+func copyToFile(filePath string, reader io.Reader) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+func readBuildpackDescriptor(buildpackBlob blob.Blob) (*dist.BuildpackDescriptor, error) {
+	reader, err := buildpackBlob.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open buildpack archive: %w", err)
+	}
+	defer reader.Close()
+
+	_, descriptorBytes, err := archive.ReadTarEntry(reader, buildpackDescriptorFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from the buildpack archive: %w", buildpackDescriptorFile, err)
+	}
+
+	descriptor := &dist.BuildpackDescriptor{WithAPI: api.MustParse(dist.AssumedBuildpackAPIVersion)}
+	if _, err = toml.Decode(string(descriptorBytes), descriptor); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", buildpackDescriptorFile, err)
+	}
+
+	if descriptor.Info().ID == "" || descriptor.Info().Version == "" {
+		return nil, errors.New("buildpack.id and buildpack.version are required")
+	}
+
+	if len(descriptor.Order()) > 0 {
+		return nil, errors.New("meta-buildpacks are not supported, upload their buildpackage image instead")
+	}
+
+	return descriptor, nil
+}
+
+// writeBuildpackLayer lays out the buildpack contents as per the buildpack
+// distribution spec, i.e. under /cnb/buildpacks/{escaped id}/{version}
+func writeBuildpackLayer(layerPath string, descriptor *dist.BuildpackDescriptor, buildpackBlob blob.Blob) error {
+	layerFile, err := os.Create(layerPath)
+	if err != nil {
+		return err
+	}
+	defer layerFile.Close()
+
+	reader, err := buildpackBlob.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tarWriter := tar.NewWriter(layerFile)
+	defer tarWriter.Close()
+
+	idDir := path.Join(dist.BuildpacksDir, descriptor.EscapedID())
+	versionDir := path.Join(idDir, descriptor.Info().Version)
+	for _, dir := range []string{idDir, versionDir} {
+		if err = tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     dir,
+			Mode:     0o755,
+			ModTime:  archive.NormalizedDateTime,
+		}); err != nil {
+			return err
+		}
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		archive.NormalizeHeader(header, true)
+		header.Name = path.Clean(header.Name)
+		if header.Name == "." || header.Name == "/" {
+			continue
+		}
+
+		header.Mode = buildpackFileMode(header)
+		header.Name = path.Join(versionDir, header.Name)
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = path.Join(versionDir, path.Clean(header.Linkname))
+		}
+
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if _, err = io.Copy(tarWriter, tarReader); err != nil { // #nosec G110
+			return err
+		}
+	}
+
+	return nil
+}
+
+func buildpackFileMode(header *tar.Header) int64 {
+	if header.Typeflag == tar.TypeDir || header.Mode&0o111 != 0 {
+		return 0o755
+	}
+
+	switch header.Name {
+	case path.Join("bin", "build"), path.Join("bin", "detect"):
+		return 0o755
+	}
+
+	return 0o644
+}
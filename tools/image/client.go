@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -72,6 +73,10 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("failed to append layer: %w", err)
 	}
 
+	return c.write(ctx, creds, repoRef, image, tags...)
+}
+
+func (c Client) write(ctx context.Context, creds Creds, repoRef string, image v1.Image, tags ...string) (string, error) {
 	ref, err := name.ParseReference(repoRef)
 	if err != nil {
 		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
@@ -206,6 +211,67 @@ func (c Client) Delete(ctx context.Context, creds Creds, imageRef string, tagsTo
 	return err
 }
 
+// ListRepositories returns the repositories whose name starts with the
+// repository prefix, e.g. "my-registry.io/korifi/". It relies on the registry
+// catalog API, which some registries (e.g. DockerHub) do not support.
+func (c Client) ListRepositories(ctx context.Context, creds Creds, repositoryPrefix string) ([]string, error) {
+	registryHost, pathPrefix, _ := strings.Cut(repositoryPrefix, "/")
+	registry, err := name.NewRegistry(registryHost)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry of repository prefix %s: %w", repositoryPrefix, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	repoNames, err := remote.Catalog(ctx, registry, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	repositories := []string{}
+	for _, repoName := range repoNames {
+		if strings.HasPrefix(repoName, pathPrefix) {
+			repositories = append(repositories, registry.Repo(repoName).Name())
+		}
+	}
+
+	return repositories, nil
+}
+
+// ListTags returns the digest of every tag in the repository
+func (c Client) ListTags(ctx context.Context, creds Creds, repoRef string) (map[string]string, error) {
+	repo, err := name.NewRepository(repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	tags, err := remote.List(repo, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tagDigests := map[string]string{}
+	for _, tag := range tags {
+		var descriptor *v1.Descriptor
+		descriptor, err = remote.Head(repo.Tag(tag), authOpt, remote.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get tag %s: %w", tag, err)
+		}
+
+		tagDigests[tag] = descriptor.Digest.String()
+	}
+
+	return tagDigests, nil
+}
+
 func (c Client) getTagSet(ref name.Reference, authOpt remote.Option) (map[string]bool, error) {
 	allTags, err := remote.List(ref.Context(), authOpt)
 	if err != nil {
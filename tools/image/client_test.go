@@ -1,7 +1,11 @@
 package image_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
@@ -129,6 +133,170 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("PushBuildpackage", func() {
+		var buildpackFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			buildpackFile, err = os.Open("fixtures/buildpack.tgz")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			imgRef, testErr = imgClient.PushBuildpackage(ctx, creds, pushRef, buildpackFile)
+		})
+
+		It("pushes the buildpack as a buildpackage image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(imgRef).To(HavePrefix(pushRef))
+
+			config, err := imgClient.Config(ctx, creds, imgRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Labels).To(HaveKeyWithValue(image.BuildpackageLabel, MatchJSON(`{"id":"korifi/test-buildpack","version":"1.2.3","stacks":[{"id":"*"}]}`)))
+			Expect(config.Labels).To(HaveKeyWithValue("io.buildpacks.buildpack.layers", ContainSubstring(`"korifi/test-buildpack":{"1.2.3":{"api":"0.8"`)))
+		})
+
+		When("the archive is not a buildpack", func() {
+			BeforeEach(func() {
+				buildpackFile = zipFile
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to read buildpack.toml")))
+			})
+		})
+
+		When("pushRef is invalid", func() {
+			BeforeEach(func() {
+				pushRef += ":bar:baz"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+	})
+
+	Describe("Download and PushTarball", func() {
+		var (
+			tarballBuffer *bytes.Buffer
+			downloadErr   error
+		)
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			tarballBuffer = new(bytes.Buffer)
+		})
+
+		JustBeforeEach(func() {
+			downloadErr = imgClient.Download(ctx, creds, imgRef, tarballBuffer)
+		})
+
+		It("round-trips the image through a tarball", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+
+			copyRef := containerRegistry.ImageRef("foo/copy")
+			pushedRef, err := imgClient.PushTarball(ctx, creds, copyRef, tarballBuffer, "jim")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pushedRef).To(HavePrefix(copyRef))
+			Expect(pushedRef[strings.Index(pushedRef, "@"):]).To(Equal(imgRef[strings.Index(imgRef, "@"):]))
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/does-not-exist")
+			})
+
+			It("fails", func() {
+				Expect(downloadErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+
+		When("the pushed tarball is not an image", func() {
+			It("fails", func() {
+				_, err := imgClient.PushTarball(ctx, creds, pushRef, otherZipFile)
+				Expect(err).To(MatchError(ContainSubstring("failed to read image tarball")))
+			})
+		})
+	})
+
+	Describe("DownloadSource", func() {
+		var zipBuffer *bytes.Buffer
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			zipBuffer = new(bytes.Buffer)
+		})
+
+		JustBeforeEach(func() {
+			testErr = imgClient.DownloadSource(ctx, creds, imgRef, zipBuffer)
+		})
+
+		It("writes the image filesystem as a zip archive", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			zipReader, err := zip.NewReader(bytes.NewReader(zipBuffer.Bytes()), int64(zipBuffer.Len()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zipReader.File).To(HaveLen(1))
+			Expect(zipReader.File[0].Name).To(Equal("foo"))
+
+			entryReader, err := zipReader.File[0].Open()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(entryReader)).To(BeEquivalentTo("hello\n"))
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/does-not-exist")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var (
+			copyRef   string
+			copiedRef string
+		)
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			copyRef = containerRegistry.ImageRef("foo/copy")
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, testErr = imgClient.Copy(ctx, creds, imgRef, copyRef, "jim")
+		})
+
+		It("pushes the same image to the target repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(copiedRef).To(HavePrefix(copyRef))
+			Expect(copiedRef[strings.Index(copiedRef, "@"):]).To(Equal(imgRef[strings.Index(imgRef, "@"):]))
+		})
+
+		When("the source image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/does-not-exist")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+	})
+
 	Describe("Config", func() {
 		var config image.Config
 
@@ -303,6 +471,82 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("ListRepositories", func() {
+		var (
+			prefix       string
+			repositories []string
+		)
+
+		BeforeEach(func() {
+			prefix = uuid.NewString() + "/"
+
+			_, err := imgClient.Push(ctx, creds, containerRegistry.ImageRef(prefix+"foo"), zipFile, "jim")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = imgClient.Push(ctx, creds, containerRegistry.ImageRef("another-"+prefix+"bar"), otherZipFile, "bob")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			repositories, testErr = imgClient.ListRepositories(ctx, creds, containerRegistry.ImageRef(prefix))
+		})
+
+		It("lists the repositories matching the prefix", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(repositories).To(ConsistOf(containerRegistry.ImageRef(prefix + "foo")))
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
+	Describe("ListTags", func() {
+		var (
+			tags          map[string]string
+			otherImgRef   string
+			repositoryRef string
+		)
+
+		BeforeEach(func() {
+			repositoryRef = containerRegistry.ImageRef(uuid.NewString())
+
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, repositoryRef, zipFile, "jim", "bob")
+			Expect(err).NotTo(HaveOccurred())
+			otherImgRef, err = imgClient.Push(ctx, creds, repositoryRef, otherZipFile, "alice")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			tags, testErr = imgClient.ListTags(ctx, creds, repositoryRef)
+		})
+
+		It("returns the digest of each tag", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(tags).To(Equal(map[string]string{
+				"jim":   strings.Split(imgRef, "@")[1],
+				"bob":   strings.Split(imgRef, "@")[1],
+				"alice": strings.Split(otherImgRef, "@")[1],
+			}))
+		})
+
+		When("the repository ref is invalid", func() {
+			BeforeEach(func() {
+				repositoryRef = "foo/Bar"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+	})
+
 	for _, reg := range registries {
 		// 'Serial` because we use the same image for ECR in this test and above
 		// and otherwise they interfere
@@ -0,0 +1,115 @@
+package image
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// DownloadSource writes the filesystem of a source image (as pushed by Push)
+// to writer as a zip archive
+func (c Client) DownloadSource(ctx context.Context, creds Creds, imageRef string, writer io.Writer) error {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	fsReader := mutate.Extract(img)
+	defer fsReader.Close()
+
+	if err = tarToZip(tar.NewReader(fsReader), zip.NewWriter(writer)); err != nil {
+		return fmt.Errorf("failed to write source zip: %w", err)
+	}
+
+	return nil
+}
+
+// Copy pushes the image at srcRef to repoRef, reusing the existing layers
+func (c Client) Copy(ctx context.Context, creds Creds, srcRef string, repoRef string, tags ...string) (string, error) {
+	ref, err := name.ParseReference(srcRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", srcRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return c.write(ctx, creds, repoRef, img, tags...)
+}
+
+func tarToZip(tarReader *tar.Reader, zipWriter *zip.Writer) error {
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		entryName := strings.TrimPrefix(header.Name, "/")
+		if entryName == "" {
+			continue
+		}
+
+		zipHeader := &zip.FileHeader{
+			Name:     entryName,
+			Method:   zip.Deflate,
+			Modified: header.ModTime,
+		}
+		zipHeader.SetMode(header.FileInfo().Mode())
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			zipHeader.Name = strings.TrimSuffix(entryName, "/") + "/"
+			zipHeader.Method = zip.Store
+			if _, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			entryWriter, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			if _, err = io.WriteString(entryWriter, header.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			entryWriter, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			// #nosec G110
+			if _, err = io.Copy(entryWriter, tarReader); err != nil {
+				return err
+			}
+		}
+	}
+
+	return zipWriter.Close()
+}
@@ -0,0 +1,60 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// PushTarball pushes an image tarball (as written by Download or `docker
+// save`) to repoRef. The tarball must contain exactly one image.
+func (c Client) PushTarball(ctx context.Context, creds Creds, repoRef string, tarballReader io.Reader, tags ...string) (string, error) {
+	tmpDir, err := os.MkdirTemp(os.TempDir(), "image-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp dir for image: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tarballPath := path.Join(tmpDir, "image.tar")
+	if err = copyToFile(tarballPath, tarballReader); err != nil {
+		return "", fmt.Errorf("failed to copy image tarball into temp file '%s' %w", tarballPath, err)
+	}
+
+	image, err := tarball.ImageFromPath(tarballPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image tarball: %w", err)
+	}
+
+	return c.write(ctx, creds, repoRef, image, tags...)
+}
+
+// Download writes imageRef as an image tarball that can be pushed back with
+// PushTarball
+func (c Client) Download(ctx context.Context, creds Creds, imageRef string, writer io.Writer) error {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if err = tarball.Write(ref, img, writer); err != nil {
+		return fmt.Errorf("failed to write image tarball: %w", err)
+	}
+
+	return nil
+}
@@ -9,8 +9,12 @@ import (
 
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s/conditions"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -54,6 +58,19 @@ func (r *PatchingReconciler[T]) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// Link to the trace of the request that created or last updated the
+	// object, if any
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"reconcile "+reflect.TypeFor[T]().Name(),
+		trace.WithLinks(tracing.LinkFromObject(runtimeObj)),
+		trace.WithAttributes(
+			attribute.String("k8s.namespace.name", req.Namespace),
+			attribute.String("k8s.object.name", req.Name),
+		),
+	)
+	defer span.End()
+
 	var (
 		result      ctrl.Result
 		delegateErr error
@@ -98,9 +115,14 @@ func (r *PatchingReconciler[T]) Reconcile(ctx context.Context, req ctrl.Request)
 	})
 	if err != nil {
 		log.Info("patch object failed", "reason", err)
+		span.SetStatus(codes.Error, err.Error())
 		return ctrl.Result{}, err
 	}
 
+	if delegateErr != nil {
+		span.SetStatus(codes.Error, delegateErr.Error())
+	}
+
 	return result, delegateErr
 }
 
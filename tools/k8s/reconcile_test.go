@@ -12,6 +12,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -24,12 +28,14 @@ import (
 
 	"code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/tools/tracing"
 )
 
 type fakeObjectReconciler struct {
 	reconcileResourceError     error
 	reconcileResourceCallCount int
 	reconcileResourceObj       *korifiv1alpha1.CFOrg
+	reconcileResourceCtx       context.Context
 }
 
 func (f *fakeObjectReconciler) ReconcileResource(ctx context.Context, obj *korifiv1alpha1.CFOrg) (ctrl.Result, error) {
@@ -38,6 +44,7 @@ func (f *fakeObjectReconciler) ReconcileResource(ctx context.Context, obj *korif
 
 	f.reconcileResourceCallCount++
 	f.reconcileResourceObj = obj
+	f.reconcileResourceCtx = ctx
 
 	obj.Spec.DisplayName = "reconciled-display-name"
 	obj.Status.GUID = "hello"
@@ -139,6 +146,34 @@ var _ = Describe("Reconcile", func() {
 		Expect(objectReconciler.reconcileResourceObj.Name).To(Equal(org.Name))
 	})
 
+	When("the object is stamped with a trace context", func() {
+		var spanRecorder *tracetest.SpanRecorder
+
+		BeforeEach(func() {
+			org.Annotations = map[string]string{
+				tracing.TraceParentAnnotation: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			}
+
+			spanRecorder = tracetest.NewSpanRecorder()
+			previousProvider := otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+			DeferCleanup(func() {
+				otel.SetTracerProvider(previousProvider)
+			})
+		})
+
+		It("links the reconcile span to the stamped trace", func() {
+			spanContext := trace.SpanContextFromContext(objectReconciler.reconcileResourceCtx)
+			Expect(spanContext.TraceID().String()).NotTo(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+
+			Expect(spanRecorder.Ended()).To(HaveLen(1))
+			links := spanRecorder.Ended()[0].Links()
+			Expect(links).To(HaveLen(1))
+			Expect(links[0].SpanContext.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(links[0].SpanContext.SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		})
+	})
+
 	It("patches the object via the k8s client", func() {
 		Expect(fakeClient.PatchCallCount()).To(Equal(1))
 		_, updatedObject, _, _ := fakeClient.PatchArgsForCall(0)
@@ -0,0 +1,221 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// HarborRepositoryCreator makes sure the Harbor project of a repository
+// exists. Harbor creates repositories on push, but only within existing
+// projects. The retention policy is applied to the project unless it already
+// has one.
+type HarborRepositoryCreator struct {
+	httpClient      *http.Client
+	apiURL          string
+	credentialsPath string
+	retention       RetentionPolicy
+	knownProjects   sync.Map
+}
+
+func NewHarborRepositoryCreator(httpClient *http.Client, config RepositoryCreatorConfig) *HarborRepositoryCreator {
+	return &HarborRepositoryCreator{
+		httpClient:      httpClient,
+		apiURL:          strings.TrimSuffix(config.URL, "/"),
+		credentialsPath: config.CredentialsPath,
+		retention:       config.Retention,
+	}
+}
+
+func (c *HarborRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+	project, err := harborProject(ref)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.knownProjects.Load(project); ok {
+		return nil
+	}
+
+	projectID, created, err := c.createProject(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	if c.retention.IsEnabled() {
+		if err = c.ensureRetentionPolicy(ctx, project, projectID, created); err != nil {
+			return err
+		}
+	}
+
+	c.knownProjects.Store(project, struct{}{})
+	return nil
+}
+
+// harborProject returns the project of a repository ref of the form
+// <host>/<project>/<repository>
+func harborProject(ref string) (string, error) {
+	segments := strings.Split(ref, "/")
+	if len(segments) < 3 || segments[1] == "" {
+		return "", fmt.Errorf("repository %q is not of the form <host>/<project>/<repository>", ref)
+	}
+
+	return segments[1], nil
+}
+
+func (c *HarborRepositoryCreator) createProject(ctx context.Context, project string) (int, bool, error) {
+	resp, err := c.post(ctx, "/projects", map[string]any{
+		"project_name": project,
+		"metadata": map[string]string{
+			"public": "false",
+		},
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create harbor project %q: %w", project, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+	case http.StatusConflict:
+		return 0, false, nil
+	default:
+		return 0, false, unexpectedResponseError(fmt.Sprintf("create harbor project %q", project), resp)
+	}
+
+	// Harbor returns the location of the new project, which ends with its ID
+	projectID, err := strconv.Atoi(path.Base(resp.Header.Get("Location")))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get the ID of harbor project %q from location %q", project, resp.Header.Get("Location"))
+	}
+
+	return projectID, true, nil
+}
+
+// ensureRetentionPolicy creates the retention policy of a project that has
+// none. Projects that already existed may have been created by a previous
+// attempt that failed to create the policy, or before retention was
+// configured. Existing policies are left untouched, as they may have been
+// changed by the Harbor admin.
+func (c *HarborRepositoryCreator) ensureRetentionPolicy(ctx context.Context, project string, projectID int, created bool) error {
+	if !created {
+		existingProject, err := c.getProject(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if existingProject.Metadata.RetentionID != "" {
+			return nil
+		}
+		projectID = existingProject.ProjectID
+	}
+
+	return c.createRetentionPolicy(ctx, projectID)
+}
+
+type harborProjectResponse struct {
+	ProjectID int `json:"project_id"`
+	Metadata  struct {
+		RetentionID string `json:"retention_id"`
+	} `json:"metadata"`
+}
+
+func (c *HarborRepositoryCreator) getProject(ctx context.Context, project string) (harborProjectResponse, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/projects/"+url.PathEscape(project), nil)
+	if err != nil {
+		return harborProjectResponse{}, fmt.Errorf("failed to get harbor project %q: %w", project, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return harborProjectResponse{}, unexpectedResponseError(fmt.Sprintf("get harbor project %q", project), resp)
+	}
+
+	var projectResponse harborProjectResponse
+	if err = json.NewDecoder(resp.Body).Decode(&projectResponse); err != nil {
+		return harborProjectResponse{}, fmt.Errorf("failed to decode harbor project %q: %w", project, err)
+	}
+
+	return projectResponse, nil
+}
+
+func (c *HarborRepositoryCreator) createRetentionPolicy(ctx context.Context, projectID int) error {
+	matchAll := func(decoration string) []map[string]string {
+		return []map[string]string{{
+			"kind":       "doublestar",
+			"decoration": decoration,
+			"pattern":    "**",
+		}}
+	}
+
+	resp, err := c.post(ctx, "/retentions", map[string]any{
+		"algorithm": "or",
+		"rules": []map[string]any{{
+			"action":   "retain",
+			"template": "latestPushedK",
+			"params": map[string]int{
+				"latestPushedK": c.retention.RetainLatestTags,
+			},
+			"tag_selectors": matchAll("matches"),
+			"scope_selectors": map[string]any{
+				"repository": matchAll("repoMatches"),
+			},
+		}},
+		"trigger": map[string]any{
+			"kind": "Schedule",
+			"settings": map[string]string{
+				"cron": c.retention.GetSchedule(),
+			},
+		},
+		"scope": map[string]any{
+			"level": "project",
+			"ref":   projectID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create retention policy for harbor project %d: %w", projectID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return unexpectedResponseError(fmt.Sprintf("create retention policy for harbor project %d", projectID), resp)
+	}
+
+	return nil
+}
+
+func (c *HarborRepositoryCreator) post(ctx context.Context, apiPath string, body any) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, http.MethodPost, apiPath, bytes.NewReader(bodyBytes))
+}
+
+func (c *HarborRepositoryCreator) do(ctx context.Context, method string, apiPath string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+"/api/v2.0"+apiPath, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// Project names may be numeric, make sure harbor does not take them
+	// for project IDs
+	req.Header.Set("X-Is-Resource-Name", "true")
+
+	if err = setBasicAuth(req, c.credentialsPath); err != nil {
+		return nil, err
+	}
+
+	return c.httpClient.Do(req)
+}
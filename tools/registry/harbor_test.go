@@ -0,0 +1,279 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordedRequest struct {
+	Method   string
+	Path     string
+	Body     map[string]any
+	Username string
+	Password string
+}
+
+type fakeHTTPServer struct {
+	*httptest.Server
+	mutex     sync.Mutex
+	requests  []recordedRequest
+	responses map[string]func(w http.ResponseWriter)
+}
+
+func newFakeHTTPServer() *fakeHTTPServer {
+	s := &fakeHTTPServer{responses: map[string]func(w http.ResponseWriter){}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, err := io.ReadAll(r.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		body := map[string]any{}
+		if len(bodyBytes) > 0 {
+			Expect(json.Unmarshal(bodyBytes, &body)).To(Succeed())
+		}
+
+		username, password, _ := r.BasicAuth()
+
+		s.mutex.Lock()
+		s.requests = append(s.requests, recordedRequest{
+			Method:   r.Method,
+			Path:     r.URL.Path,
+			Body:     body,
+			Username: username,
+			Password: password,
+		})
+		respond, ok := s.responses[r.URL.Path]
+		s.mutex.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		respond(w)
+	}))
+
+	return s
+}
+
+func (s *fakeHTTPServer) RespondTo(path string, status int, headers map[string]string) {
+	s.RespondWithBody(path, status, headers, "response-body")
+}
+
+func (s *fakeHTTPServer) RespondWithBody(path string, status int, headers map[string]string, body string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.responses[path] = func(w http.ResponseWriter) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func (s *fakeHTTPServer) Requests() []recordedRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests
+}
+
+func writeCredentials(username, password string) string {
+	dir, err := os.MkdirTemp("", "registry-credentials")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Expect(os.WriteFile(filepath.Join(dir, "username"), []byte(username+"\n"), 0o600)).To(Succeed())
+	Expect(os.WriteFile(filepath.Join(dir, "password"), []byte(password+"\n"), 0o600)).To(Succeed())
+
+	return dir
+}
+
+var _ = Describe("Harbor Repository Creator", func() {
+	var (
+		harbor    *fakeHTTPServer
+		config    registry.RepositoryCreatorConfig
+		creator   registry.RepositoryCreator
+		ref       string
+		createErr error
+	)
+
+	BeforeEach(func() {
+		harbor = newFakeHTTPServer()
+		DeferCleanup(harbor.Close)
+		harbor.RespondTo("/api/v2.0/projects", http.StatusCreated, map[string]string{"Location": "/api/v2.0/projects/42"})
+		harbor.RespondTo("/api/v2.0/retentions", http.StatusCreated, nil)
+
+		config = registry.RepositoryCreatorConfig{
+			URL:             harbor.URL + "/",
+			CredentialsPath: writeCredentials("admin", "s3cr3t"),
+		}
+		ref = "harbor.example.com/korifi/my-app-packages"
+	})
+
+	JustBeforeEach(func() {
+		creator = registry.NewHarborRepositoryCreator(harbor.Client(), config)
+		createErr = creator.CreateRepository(context.Background(), ref)
+	})
+
+	It("creates the private project of the repository", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(harbor.Requests()).To(ConsistOf(recordedRequest{
+			Method: http.MethodPost,
+			Path:   "/api/v2.0/projects",
+			Body: map[string]any{
+				"project_name": "korifi",
+				"metadata":     map[string]any{"public": "false"},
+			},
+			Username: "admin",
+			Password: "s3cr3t",
+		}))
+	})
+
+	When("the project has already been created by the creator", func() {
+		JustBeforeEach(func() {
+			Expect(creator.CreateRepository(context.Background(), "harbor.example.com/korifi/my-app-droplets")).To(Succeed())
+		})
+
+		It("does not call harbor again", func() {
+			Expect(harbor.Requests()).To(HaveLen(1))
+		})
+	})
+
+	When("a retention policy is configured", func() {
+		BeforeEach(func() {
+			config.Retention = registry.RetentionPolicy{RetainLatestTags: 5}
+		})
+
+		It("applies the retention policy to the project", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(harbor.Requests()).To(HaveLen(2))
+
+			retentionRequest := harbor.Requests()[1]
+			Expect(retentionRequest.Path).To(Equal("/api/v2.0/retentions"))
+			Expect(retentionRequest.Body).To(HaveKeyWithValue("scope", map[string]any{"level": "project", "ref": float64(42)}))
+			Expect(retentionRequest.Body).To(HaveKeyWithValue("trigger", map[string]any{
+				"kind":     "Schedule",
+				"settings": map[string]any{"cron": "0 0 0 * * *"},
+			}))
+			Expect(retentionRequest.Body["rules"]).To(ConsistOf(SatisfyAll(
+				HaveKeyWithValue("template", "latestPushedK"),
+				HaveKeyWithValue("params", map[string]any{"latestPushedK": float64(5)}),
+			)))
+		})
+
+		When("the project already exists", func() {
+			BeforeEach(func() {
+				harbor.RespondTo("/api/v2.0/projects", http.StatusConflict, nil)
+				harbor.RespondWithBody("/api/v2.0/projects/korifi", http.StatusOK, nil, `{"project_id": 43, "metadata": {"public": "false"}}`)
+			})
+
+			It("applies the retention policy to the existing project", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(harbor.Requests()).To(HaveLen(3))
+
+				Expect(harbor.Requests()[1].Method).To(Equal(http.MethodGet))
+				Expect(harbor.Requests()[1].Path).To(Equal("/api/v2.0/projects/korifi"))
+
+				retentionRequest := harbor.Requests()[2]
+				Expect(retentionRequest.Path).To(Equal("/api/v2.0/retentions"))
+				Expect(retentionRequest.Body).To(HaveKeyWithValue("scope", map[string]any{"level": "project", "ref": float64(43)}))
+			})
+
+			When("the project already has a retention policy", func() {
+				BeforeEach(func() {
+					harbor.RespondWithBody("/api/v2.0/projects/korifi", http.StatusOK, nil, `{"project_id": 43, "metadata": {"retention_id": "7"}}`)
+				})
+
+				It("succeeds without changing the retention policy", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(harbor.Requests()).To(HaveLen(2))
+				})
+			})
+
+			When("getting the project fails", func() {
+				BeforeEach(func() {
+					harbor.RespondTo("/api/v2.0/projects/korifi", http.StatusForbidden, nil)
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring(`failed to get harbor project "korifi": unexpected status 403`)))
+				})
+			})
+		})
+
+		When("creating the retention policy fails", func() {
+			BeforeEach(func() {
+				harbor.RespondTo("/api/v2.0/retentions", http.StatusForbidden, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("unexpected status 403: response-body")))
+			})
+		})
+	})
+
+	When("the project already exists", func() {
+		BeforeEach(func() {
+			harbor.RespondTo("/api/v2.0/projects", http.StatusConflict, nil)
+		})
+
+		It("succeeds without getting the project", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(harbor.Requests()).To(HaveLen(1))
+		})
+	})
+
+	When("creating the project fails", func() {
+		BeforeEach(func() {
+			harbor.RespondTo("/api/v2.0/projects", http.StatusUnauthorized, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring(`failed to create harbor project "korifi": unexpected status 401`)))
+		})
+	})
+
+	When("no credentials are configured", func() {
+		BeforeEach(func() {
+			config.CredentialsPath = ""
+		})
+
+		It("does not authenticate", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(harbor.Requests()[0].Username).To(BeEmpty())
+		})
+	})
+
+	When("the credentials cannot be read", func() {
+		BeforeEach(func() {
+			config.CredentialsPath = "/does/not/exist"
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("failed to read registry username")))
+		})
+	})
+
+	When("the repository has no project", func() {
+		BeforeEach(func() {
+			ref = "harbor.example.com/my-app-packages"
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("is not of the form <host>/<project>/<repository>")))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPHookRepositoryCreator delegates repository creation to an HTTP
+// endpoint, for registries that need repositories or organisations to exist
+// ahead of push but have no dedicated creator (e.g. Artifact Registry or
+// Quay). The hook receives a JSON POST request with the repository ref and
+// the retention policy, and is expected to respond with a 2xx status, or 409
+// if the repository already exists.
+type HTTPHookRepositoryCreator struct {
+	httpClient      *http.Client
+	url             string
+	credentialsPath string
+	retention       RetentionPolicy
+}
+
+type hookRequest struct {
+	Repository string         `json:"repository"`
+	Retention  *hookRetention `json:"retention,omitempty"`
+}
+
+type hookRetention struct {
+	RetainLatestTags int    `json:"retainLatestTags"`
+	Schedule         string `json:"schedule"`
+}
+
+func NewHTTPHookRepositoryCreator(httpClient *http.Client, config RepositoryCreatorConfig) HTTPHookRepositoryCreator {
+	return HTTPHookRepositoryCreator{
+		httpClient:      httpClient,
+		url:             config.URL,
+		credentialsPath: config.CredentialsPath,
+		retention:       config.Retention,
+	}
+}
+
+func (c HTTPHookRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+	body := hookRequest{Repository: ref}
+	if c.retention.IsEnabled() {
+		body.Retention = &hookRetention{
+			RetainLatestTags: c.retention.RetainLatestTags,
+			Schedule:         c.retention.GetSchedule(),
+		}
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = setBasicAuth(req, c.credentialsPath); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call repository creation hook for %q: %w", ref, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return nil
+	}
+
+	return unexpectedResponseError(fmt.Sprintf("create repository %q", ref), resp)
+}
@@ -0,0 +1,78 @@
+package registry_test
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTP Hook Repository Creator", func() {
+	var (
+		hook      *fakeHTTPServer
+		config    registry.RepositoryCreatorConfig
+		createErr error
+	)
+
+	BeforeEach(func() {
+		hook = newFakeHTTPServer()
+		DeferCleanup(hook.Close)
+		hook.RespondTo("/create-repo", http.StatusCreated, nil)
+
+		config = registry.RepositoryCreatorConfig{
+			URL:             hook.URL + "/create-repo",
+			CredentialsPath: writeCredentials("hook-user", "hook-password"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		creator := registry.NewHTTPHookRepositoryCreator(hook.Client(), config)
+		createErr = creator.CreateRepository(context.Background(), "my.registry/org/my-app-packages")
+	})
+
+	It("posts the repository to the hook", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(hook.Requests()).To(ConsistOf(recordedRequest{
+			Method:   http.MethodPost,
+			Path:     "/create-repo",
+			Body:     map[string]any{"repository": "my.registry/org/my-app-packages"},
+			Username: "hook-user",
+			Password: "hook-password",
+		}))
+	})
+
+	When("a retention policy is configured", func() {
+		BeforeEach(func() {
+			config.Retention = registry.RetentionPolicy{RetainLatestTags: 3, Schedule: "0 0 * * * *"}
+		})
+
+		It("sends the retention policy", func() {
+			Expect(hook.Requests()[0].Body).To(HaveKeyWithValue("retention", map[string]any{
+				"retainLatestTags": float64(3),
+				"schedule":         "0 0 * * * *",
+			}))
+		})
+	})
+
+	When("the repository already exists", func() {
+		BeforeEach(func() {
+			hook.RespondTo("/create-repo", http.StatusConflict, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the hook fails", func() {
+		BeforeEach(func() {
+			hook.RespondTo("/create-repo", http.StatusInternalServerError, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring(`failed to create repository "my.registry/org/my-app-packages": unexpected status 500: response-body`)))
+		})
+	})
+})
@@ -3,8 +3,13 @@ package registry
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -13,7 +18,50 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-const ECRContainerRegistryType = "ECR"
+const (
+	ECRContainerRegistryType     = "ECR"
+	HarborContainerRegistryType  = "Harbor"
+	GenericContainerRegistryType = "Generic"
+
+	defaultRetentionSchedule = "0 0 0 * * *"
+	httpClientTimeout        = 30 * time.Second
+)
+
+// RepositoryCreatorConfig configures the Harbor and generic repository
+// creators. It is not used for ECR, which is configured via the AWS
+// environment.
+type RepositoryCreatorConfig struct {
+	// URL is the base URL of the Harbor API or the URL of the generic
+	// repository creation hook
+	URL string `yaml:"url"`
+	// CredentialsPath is a directory containing `username` and `password`
+	// files, e.g. a mounted basic-auth secret. Requests are not
+	// authenticated if it is empty.
+	CredentialsPath string          `yaml:"credentialsPath"`
+	Retention       RetentionPolicy `yaml:"retention"`
+}
+
+// RetentionPolicy is applied to repositories (or Harbor projects) when they
+// are created
+type RetentionPolicy struct {
+	// RetainLatestTags is the number of most recently pushed tags to keep per
+	// repository. No policy is applied if it is zero.
+	RetainLatestTags int `yaml:"retainLatestTags"`
+	// Schedule is the cron schedule of the retention runs, defaults to daily
+	Schedule string `yaml:"schedule"`
+}
+
+func (p RetentionPolicy) IsEnabled() bool {
+	return p.RetainLatestTags > 0
+}
+
+func (p RetentionPolicy) GetSchedule() string {
+	if p.Schedule == "" {
+		return defaultRetentionSchedule
+	}
+
+	return p.Schedule
+}
 
 //counterfeiter:generate -o fake -fake-name ECRClient . ECRClient
 
@@ -35,9 +83,14 @@ func createECRClient() *ecr.Client {
 	return ecr.NewFromConfig(awsConfig)
 }
 
-func NewRepositoryCreator(registryType string) RepositoryCreator {
-	if registryType == ECRContainerRegistryType {
+func NewRepositoryCreator(registryType string, config RepositoryCreatorConfig) RepositoryCreator {
+	switch registryType {
+	case ECRContainerRegistryType:
 		return NewECRRepositoryCreator(createECRClient())
+	case HarborContainerRegistryType:
+		return NewHarborRepositoryCreator(&http.Client{Timeout: httpClientTimeout}, config)
+	case GenericContainerRegistryType:
+		return NewHTTPHookRepositoryCreator(&http.Client{Timeout: httpClientTimeout}, config)
 	}
 
 	return NoopRepositoryCreator{}
@@ -74,3 +127,29 @@ type NoopRepositoryCreator struct{}
 func (c NoopRepositoryCreator) CreateRepository(_ context.Context, _ string) error {
 	return nil
 }
+
+func setBasicAuth(req *http.Request, credentialsPath string) error {
+	if credentialsPath == "" {
+		return nil
+	}
+
+	// Credentials are read on every request so that secret rotations are
+	// picked up without a restart
+	username, err := os.ReadFile(filepath.Join(credentialsPath, "username"))
+	if err != nil {
+		return fmt.Errorf("failed to read registry username: %w", err)
+	}
+
+	password, err := os.ReadFile(filepath.Join(credentialsPath, "password"))
+	if err != nil {
+		return fmt.Errorf("failed to read registry password: %w", err)
+	}
+
+	req.SetBasicAuth(strings.TrimSpace(string(username)), strings.TrimSpace(string(password)))
+	return nil
+}
+
+func unexpectedResponseError(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("failed to %s: unexpected status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(body)))
+}
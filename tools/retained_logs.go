@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	RetainedLogsConfigMapKey = "logs"
+
+	// bound the retained logs per source type so that the config map stays
+	// well below the 1MiB object size limit
+	maxRetainedLogEntriesPerSource = 300
+	maxRetainedLogMessageLength    = 1024
+	maxRetainedLogContainers       = 50
+)
+
+type RetainedLogs struct {
+	Entries []RetainedLogEntry `json:"entries"`
+	// Containers are the IDs of the containers that have already been
+	// collected, so that their logs are not retained twice
+	Containers []string `json:"containers"`
+}
+
+type RetainedLogEntry struct {
+	Timestamp  int64  `json:"timestamp"`
+	SourceType string `json:"source_type"`
+	InstanceID string `json:"instance_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+func RetainedLogsConfigMapName(appGUID string) string {
+	return appGUID + "-retained-logs"
+}
+
+func FromRetainedLogsData(data map[string]string) (RetainedLogs, error) {
+	logs := RetainedLogs{}
+
+	value, ok := data[RetainedLogsConfigMapKey]
+	if !ok {
+		return logs, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &logs); err != nil {
+		return RetainedLogs{}, fmt.Errorf("failed to unmarshal retained logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+func (l RetainedLogs) ToData() (map[string]string, error) {
+	value, err := json.Marshal(l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retained logs: %w", err)
+	}
+
+	return map[string]string{
+		RetainedLogsConfigMapKey: string(value),
+	}, nil
+}
+
+func (l RetainedLogs) HasContainer(containerID string) bool {
+	return slices.Contains(l.Containers, containerID)
+}
+
+// Append adds the entries of a container, dropping the oldest entries of
+// the same source type once the buffer for it is full
+func (l *RetainedLogs) Append(containerID string, entries ...RetainedLogEntry) {
+	l.Containers = append(l.Containers, containerID)
+	if len(l.Containers) > maxRetainedLogContainers {
+		l.Containers = l.Containers[len(l.Containers)-maxRetainedLogContainers:]
+	}
+
+	for _, entry := range entries {
+		if len(entry.Message) > maxRetainedLogMessageLength {
+			entry.Message = entry.Message[:maxRetainedLogMessageLength]
+		}
+		l.Entries = append(l.Entries, entry)
+	}
+
+	slices.SortStableFunc(l.Entries, func(a, b RetainedLogEntry) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	perSource := map[string]int{}
+	for _, entry := range l.Entries {
+		perSource[entry.SourceType]++
+	}
+
+	l.Entries = slices.DeleteFunc(l.Entries, func(entry RetainedLogEntry) bool {
+		if perSource[entry.SourceType] > maxRetainedLogEntriesPerSource {
+			perSource[entry.SourceType]--
+			return true
+		}
+		return false
+	})
+}
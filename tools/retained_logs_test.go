@@ -0,0 +1,82 @@
+package tools_test
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("RetainedLogs", func() {
+	var logs tools.RetainedLogs
+
+	BeforeEach(func() {
+		logs = tools.RetainedLogs{}
+	})
+
+	Describe("Append", func() {
+		BeforeEach(func() {
+			logs.Append("container-1",
+				tools.RetainedLogEntry{Timestamp: 3, SourceType: "APP", Message: "three"},
+				tools.RetainedLogEntry{Timestamp: 1, SourceType: "STG", Message: "one"},
+			)
+			logs.Append("container-2",
+				tools.RetainedLogEntry{Timestamp: 2, SourceType: "APP", Message: "two"},
+			)
+		})
+
+		It("keeps the entries ordered by timestamp", func() {
+			Expect(logs.Entries).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{"Message": Equal("one")}),
+				MatchFields(IgnoreExtras, Fields{"Message": Equal("two")}),
+				MatchFields(IgnoreExtras, Fields{"Message": Equal("three")}),
+			))
+		})
+
+		It("records the collected containers", func() {
+			Expect(logs.HasContainer("container-1")).To(BeTrue())
+			Expect(logs.HasContainer("container-2")).To(BeTrue())
+			Expect(logs.HasContainer("container-3")).To(BeFalse())
+		})
+
+		It("truncates long messages", func() {
+			logs.Append("container-3", tools.RetainedLogEntry{Timestamp: 4, SourceType: "APP", Message: strings.Repeat("a", 2000)})
+			Expect(logs.Entries[3].Message).To(HaveLen(1024))
+		})
+
+		It("drops the oldest entries of a source type once its buffer is full", func() {
+			for i := range 300 {
+				logs.Append(fmt.Sprintf("app-container-%d", i), tools.RetainedLogEntry{Timestamp: int64(10 + i), SourceType: "APP", Message: "app"})
+			}
+
+			Expect(logs.Entries).To(HaveLen(301))
+			Expect(logs.Entries[0].Message).To(Equal("one"))
+			Expect(logs.Entries).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Message": Equal("two")})))
+			Expect(logs.Containers).To(HaveLen(50))
+		})
+	})
+
+	Describe("data conversion", func() {
+		It("round trips", func() {
+			logs.Append("container-1", tools.RetainedLogEntry{Timestamp: 1, SourceType: "APP", InstanceID: "0", Message: "hello"})
+
+			data, err := logs.ToData()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(HaveKey(tools.RetainedLogsConfigMapKey))
+
+			Expect(tools.FromRetainedLogsData(data)).To(Equal(logs))
+		})
+
+		It("returns empty logs when there is no data", func() {
+			Expect(tools.FromRetainedLogsData(nil)).To(Equal(tools.RetainedLogs{}))
+		})
+
+		It("fails on invalid data", func() {
+			_, err := tools.FromRetainedLogsData(map[string]string{tools.RetainedLogsConfigMapKey: "{"})
+			Expect(err).To(MatchError(ContainSubstring("failed to unmarshal retained logs")))
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	SSHCodeLabelKey = "korifi.cloudfoundry.org/ssh-code"
+
+	sshCodeUserKey      = "user"
+	sshCodeGroupsKey    = "groups"
+	sshCodeAppGUIDKey   = "app-guid"
+	sshCodeExpiresAtKey = "expires-at"
+)
+
+// SSHCode is a one-time code that the ssh proxy redeems for the identity of
+// the user it was issued to. Codes are stored as secrets in the root
+// namespace, named after the hash of the code so that the secrets do not
+// reveal the codes themselves.
+type SSHCode struct {
+	User   string
+	Groups []string
+	// AppGUID restricts the code to the instances of a single app when set
+	AppGUID   string
+	ExpiresAt time.Time
+}
+
+func NewSSHCode() (string, error) {
+	code := make([]byte, 24)
+	if _, err := rand.Read(code); err != nil {
+		return "", fmt.Errorf("failed to generate ssh code: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(code), nil
+}
+
+func SSHCodeSecretName(code string) string {
+	return fmt.Sprintf("ssh-code-%x", sha256.Sum256([]byte(code)))
+}
+
+func FromSSHCodeData(data map[string][]byte) (SSHCode, error) {
+	user := string(data[sshCodeUserKey])
+	if user == "" {
+		return SSHCode{}, errors.New("ssh code has no user")
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, string(data[sshCodeExpiresAtKey]))
+	if err != nil {
+		return SSHCode{}, fmt.Errorf("ssh code has an invalid expiry: %w", err)
+	}
+
+	var groups []string
+	if groupsData := string(data[sshCodeGroupsKey]); groupsData != "" {
+		groups = strings.Split(groupsData, "\n")
+	}
+
+	return SSHCode{
+		User:      user,
+		Groups:    groups,
+		AppGUID:   string(data[sshCodeAppGUIDKey]),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (c SSHCode) ToData() map[string][]byte {
+	return map[string][]byte{
+		sshCodeUserKey:      []byte(c.User),
+		sshCodeGroupsKey:    []byte(strings.Join(c.Groups, "\n")),
+		sshCodeAppGUIDKey:   []byte(c.AppGUID),
+		sshCodeExpiresAtKey: []byte(c.ExpiresAt.UTC().Format(time.RFC3339)),
+	}
+}
+
+func (c SSHCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
@@ -0,0 +1,53 @@
+package tools_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCode", func() {
+	It("generates unique codes", func() {
+		code1, err := tools.NewSSHCode()
+		Expect(err).NotTo(HaveOccurred())
+		code2, err := tools.NewSSHCode()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(code1).NotTo(BeEmpty())
+		Expect(code1).NotTo(Equal(code2))
+	})
+
+	It("does not reveal the code in the secret name", func() {
+		Expect(tools.SSHCodeSecretName("my-code")).To(HavePrefix("ssh-code-"))
+		Expect(tools.SSHCodeSecretName("my-code")).NotTo(ContainSubstring("my-code"))
+		Expect(tools.SSHCodeSecretName("my-code")).To(Equal(tools.SSHCodeSecretName("my-code")))
+	})
+
+	It("roundtrips through secret data", func() {
+		code := tools.SSHCode{
+			User:      "bob",
+			Groups:    []string{"developers", "admins"},
+			AppGUID:   "my-app",
+			ExpiresAt: time.Now().Add(time.Minute).Truncate(time.Second),
+		}
+
+		decoded, err := tools.FromSSHCodeData(code.ToData())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.User).To(Equal("bob"))
+		Expect(decoded.Groups).To(Equal([]string{"developers", "admins"}))
+		Expect(decoded.AppGUID).To(Equal("my-app"))
+		Expect(decoded.ExpiresAt).To(BeTemporally("==", code.ExpiresAt))
+		Expect(decoded.IsExpired()).To(BeFalse())
+	})
+
+	It("reports expired codes", func() {
+		Expect(tools.SSHCode{ExpiresAt: time.Now().Add(-time.Second)}.IsExpired()).To(BeTrue())
+	})
+
+	It("rejects data without a user", func() {
+		_, err := tools.FromSSHCodeData(map[string][]byte{"expires-at": []byte(time.Now().Format(time.RFC3339))})
+		Expect(err).To(MatchError(ContainSubstring("no user")))
+	})
+})
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	tracerName       = "code.cloudfoundry.org/korifi"
+	annotationPrefix = "korifi.cloudfoundry.org/"
+
+	TraceParentAnnotation = annotationPrefix + "traceparent"
+	TraceStateAnnotation  = annotationPrefix + "tracestate"
+)
+
+// objectPropagator is fixed rather than taken from the global configuration,
+// as the annotations it reads and writes are shared between the API and the
+// controllers
+var objectPropagator = propagation.TraceContext{}
+
+type Config struct {
+	Enabled  bool   `yaml:"enabled"`
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+func (c Config) Validate() error {
+	if c.Enabled && c.Endpoint == "" {
+		return fmt.Errorf("tracing requires an endpoint")
+	}
+
+	return nil
+}
+
+// NewOTLPExporter creates an exporter sending spans to an OTLP collector over
+// gRPC
+func NewOTLPExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// Setup registers a global tracer provider that batches spans to the
+// exporter. The provider should be shut down on exit to flush pending spans.
+func Setup(serviceName string, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider
+}
+
+// Tracer returns the tracer of the global tracer provider. Spans are no-ops
+// unless Setup has been called.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// InjectIntoObject stamps the trace context of ctx onto the object
+// annotations, so that reconcilers can continue the trace
+func InjectIntoObject(ctx context.Context, obj metav1.Object) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	objectPropagator.Inject(ctx, annotationCarrier{obj: obj})
+}
+
+// LinkFromObject returns a link to the span stamped onto the object
+// annotations. The annotations stay on the object until the next stamp, so
+// reconcilers link to the stamped span rather than continuing its trace,
+// which would attribute every later reconcile to the same request. The link
+// is invalid, and ignored when starting a span, if the object is not stamped.
+func LinkFromObject(obj metav1.Object) trace.Link {
+	return trace.Link{SpanContext: SpanContextFromObject(obj)}
+}
+
+// SpanContextFromObject returns the span context stamped onto the object
+// annotations, or an invalid span context if the object is not stamped
+func SpanContextFromObject(obj metav1.Object) trace.SpanContext {
+	return trace.SpanContextFromContext(objectPropagator.Extract(context.Background(), annotationCarrier{obj: obj}))
+}
+
+type annotationCarrier struct {
+	obj metav1.Object
+}
+
+func (c annotationCarrier) Get(key string) string {
+	return c.obj.GetAnnotations()[annotationPrefix+key]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	annotations := c.obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[annotationPrefix+key] = value
+	c.obj.SetAnnotations(annotations)
+}
+
+func (c annotationCarrier) Keys() []string {
+	return objectPropagator.Fields()
+}
@@ -0,0 +1,109 @@
+package tracing_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/tools/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		ctx context.Context
+		obj *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	Describe("InjectIntoObject", func() {
+		var span trace.Span
+
+		BeforeEach(func() {
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracetest.NewSpanRecorder()))
+			ctx, span = provider.Tracer("test").Start(ctx, "test-span")
+		})
+
+		JustBeforeEach(func() {
+			tracing.InjectIntoObject(ctx, obj)
+		})
+
+		It("stamps the trace context onto the object annotations", func() {
+			Expect(obj.Annotations).To(HaveKeyWithValue("foo", "bar"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(tracing.TraceParentAnnotation, ContainSubstring(span.SpanContext().TraceID().String())))
+		})
+
+		It("can be linked from the object", func() {
+			linkedSpanContext := tracing.LinkFromObject(obj).SpanContext
+			Expect(linkedSpanContext.TraceID()).To(Equal(span.SpanContext().TraceID()))
+			Expect(linkedSpanContext.SpanID()).To(Equal(span.SpanContext().SpanID()))
+			Expect(linkedSpanContext.IsRemote()).To(BeTrue())
+		})
+
+		When("the object has no annotations", func() {
+			BeforeEach(func() {
+				obj.Annotations = nil
+			})
+
+			It("stamps the trace context", func() {
+				Expect(obj.Annotations).To(HaveKey(tracing.TraceParentAnnotation))
+			})
+		})
+
+		When("the context carries no span", func() {
+			BeforeEach(func() {
+				ctx = context.Background()
+			})
+
+			It("leaves the object untouched", func() {
+				Expect(obj.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+			})
+		})
+	})
+
+	Describe("LinkFromObject", func() {
+		It("returns an invalid link when the object is not stamped", func() {
+			Expect(tracing.LinkFromObject(obj).SpanContext.IsValid()).To(BeFalse())
+		})
+	})
+
+	Describe("Setup", func() {
+		var exporter *tracetest.InMemoryExporter
+
+		BeforeEach(func() {
+			exporter = tracetest.NewInMemoryExporter()
+		})
+
+		It("exports spans via the exporter", func() {
+			provider := tracing.Setup("test-service", exporter)
+
+			_, span := tracing.Tracer().Start(ctx, "test-span")
+			span.End()
+
+			Expect(provider.ForceFlush(ctx)).To(Succeed())
+			Expect(exporter.GetSpans().Snapshots()).To(ConsistOf(
+				WithTransform(func(s sdktrace.ReadOnlySpan) string { return s.Name() }, Equal("test-span")),
+			))
+		})
+	})
+
+	Describe("Config", func() {
+		It("requires an endpoint when enabled", func() {
+			Expect(tracing.Config{Enabled: true}.Validate()).To(MatchError("tracing requires an endpoint"))
+			Expect(tracing.Config{Enabled: true, Endpoint: "collector:4317"}.Validate()).To(Succeed())
+			Expect(tracing.Config{}.Validate()).To(Succeed())
+		})
+	})
+})